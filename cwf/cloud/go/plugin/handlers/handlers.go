@@ -102,7 +102,7 @@ func getGateway(c echo.Context) error {
 		return nerr
 	}
 
-	magmadModel, nerr := handlers.LoadMagmadGatewayModel(nid, gid)
+	magmadModel, versions, nerr := handlers.LoadMagmadGatewayModel(nid, gid)
 	if nerr != nil {
 		return nerr
 	}
@@ -123,11 +123,13 @@ func getGateway(c echo.Context) error {
 		Status:      magmadModel.Status,
 		Tier:        magmadModel.Tier,
 		Magmad:      magmadModel.Magmad,
+		Labels:      magmadModel.Labels,
 	}
 	if ent.Config != nil {
 		ret.CarrierWifi = ent.Config.(*cwfmodels.GatewayCwfConfigs)
 	}
 
+	obsidian.SetETag(c, append(versions, ent.Version)...)
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -89,6 +89,7 @@ func (m *CwfGateway) FromBackendModels(
 	mdGW := (&models2.MagmadGateway{}).FromBackendModels(magmadGateway, device, status)
 	// TODO: we should change this to a reflection based shallow copy
 	m.ID, m.Name, m.Description, m.Magmad, m.Tier, m.Device, m.Status = mdGW.ID, mdGW.Name, mdGW.Description, mdGW.Magmad, mdGW.Tier, mdGW.Device, mdGW.Status
+	m.Labels = mdGW.Labels
 	if cwfGateway.Config != nil {
 		m.CarrierWifi = cwfGateway.Config.(*GatewayCwfConfigs)
 	}
@@ -97,7 +98,10 @@ func (m *CwfGateway) FromBackendModels(
 }
 
 func (m *MutableCwfGateway) ValidateModel() error {
-	return m.Validate(strfmt.Default)
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	return m.Labels.ValidateModel()
 }
 
 func (m *MutableCwfGateway) GetMagmadGateway() *models2.MagmadGateway {
@@ -105,6 +109,7 @@ func (m *MutableCwfGateway) GetMagmadGateway() *models2.MagmadGateway {
 		Description: m.Description,
 		Device:      m.Device,
 		ID:          m.ID,
+		Labels:      m.Labels,
 		Magmad:      m.Magmad,
 		Name:        m.Name,
 		Tier:        m.Tier,
@@ -119,6 +124,7 @@ func (m *MutableCwfGateway) GetAdditionalWritesOnCreate() []configurator.EntityW
 			Name:        string(m.Name),
 			Description: string(m.Description),
 			Config:      m.CarrierWifi,
+			Labels:      m.Labels,
 		},
 		configurator.EntityUpdateCriteria{
 			Type:              orc8r.MagmadGatewayType,
@@ -143,9 +149,10 @@ func (m *MutableCwfGateway) GetAdditionalWritesOnUpdate(
 	}
 
 	entUpdate := configurator.EntityUpdateCriteria{
-		Type:      cwf.CwfGatewayType,
-		Key:       string(m.ID),
-		NewConfig: m.CarrierWifi,
+		Type:        cwf.CwfGatewayType,
+		Key:         string(m.ID),
+		NewConfig:   m.CarrierWifi,
+		LabelsToSet: m.Labels.ToLabelsToSet(existingEnt.Labels),
 	}
 	if string(m.Name) != existingEnt.Name {
 		entUpdate.NewName = swag.String(string(m.Name))
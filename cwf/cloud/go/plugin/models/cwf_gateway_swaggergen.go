@@ -35,6 +35,9 @@ type CwfGateway struct {
 	// Required: true
 	ID models4.GatewayID `json:"id"`
 
+	// labels
+	Labels models4.Labels `json:"labels,omitempty"`
+
 	// magmad
 	// Required: true
 	Magmad *models5.MagmadGatewayConfigs `json:"magmad"`
@@ -67,6 +67,7 @@ func (m *MutableSymphonyAgent) GetMagmadGateway() *models2.MagmadGateway {
 		Description: m.Description,
 		Device:      m.Device,
 		ID:          models.GatewayID(m.ID),
+		Labels:      m.Labels,
 		Magmad:      m.Magmad,
 		Name:        m.Name,
 		Tier:        m.Tier,
@@ -75,8 +76,9 @@ func (m *MutableSymphonyAgent) GetMagmadGateway() *models2.MagmadGateway {
 
 func (m *MutableSymphonyAgent) GetAdditionalWritesOnCreate() []configurator.EntityWriteOperation {
 	ent := configurator.NetworkEntity{
-		Type: devmand.SymphonyAgentType,
-		Key:  string(m.ID),
+		Type:   devmand.SymphonyAgentType,
+		Key:    string(m.ID),
+		Labels: m.Labels,
 	}
 	for _, managedDevice := range m.ManagedDevices {
 		ent.Associations = append(ent.Associations, storage.TypeAndKey{Type: devmand.SymphonyDeviceType, Key: managedDevice})
@@ -102,7 +104,7 @@ func (m *MutableSymphonyAgent) GetAdditionalWritesOnUpdate(
 	loadedEntities map[storage.TypeAndKey]configurator.NetworkEntity,
 ) ([]configurator.EntityWriteOperation, error) {
 	ret := []configurator.EntityWriteOperation{}
-	_, ok := loadedEntities[storage.TypeAndKey{Type: devmand.SymphonyAgentType, Key: agentID}]
+	existingEnt, ok := loadedEntities[storage.TypeAndKey{Type: devmand.SymphonyAgentType, Key: agentID}]
 	if !ok {
 		return nil, merrors.ErrNotFound
 	}
@@ -110,6 +112,7 @@ func (m *MutableSymphonyAgent) GetAdditionalWritesOnUpdate(
 	update := configurator.EntityUpdateCriteria{
 		Type: devmand.SymphonyAgentType, Key: agentID,
 		AssociationsToSet: []storage.TypeAndKey{},
+		LabelsToSet:       m.Labels.ToLabelsToSet(existingEnt.Labels),
 	}
 	for _, dID := range m.ManagedDevices {
 		update.AssociationsToSet = append(update.AssociationsToSet, storage.TypeAndKey{Type: devmand.SymphonyDeviceType, Key: dID})
@@ -137,6 +140,7 @@ func (m *SymphonyAgent) FromBackendModels(
 ) handlers.GatewayModel {
 	mdGW := (&models2.MagmadGateway{}).FromBackendModels(magmadEnt, device, status)
 	m.ID, m.Name, m.Description, m.Magmad, m.Tier, m.Device, m.Status = mdGW.ID, mdGW.Name, mdGW.Description, mdGW.Magmad, mdGW.Tier, mdGW.Device, mdGW.Status
+	m.Labels = mdGW.Labels
 
 	for _, tk := range agentEnt.Associations {
 		if tk.Type == devmand.SymphonyDeviceType {
@@ -21,7 +21,10 @@ func (m *SymphonyAgent) ValidateModel() error {
 }
 
 func (m *MutableSymphonyAgent) ValidateModel() error {
-	return m.Validate(strfmt.Default)
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	return m.Labels.ValidateModel()
 }
 
 func (m *ManagedDevices) ValidateModel() error {
@@ -15,6 +15,7 @@ import (
 	"regexp"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	cfgObsidian "magma/orc8r/cloud/go/services/config/obsidian"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -74,7 +75,7 @@ func createGatewayConfig(c echo.Context) error {
 
 	// create devmand gateway entity
 	associations := getDeviceTKs(config.ManagedDevices)
-	_, err := configurator.CreateEntity(networkID, configurator.NetworkEntity{
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, configurator.NetworkEntity{
 		Type:         devmand.DevmandGatewayType,
 		Key:          gatewayID,
 		Config:       iConfig,
@@ -84,7 +85,7 @@ func createGatewayConfig(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
-	_, err = configurator.UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{
 		Type:              orc8r.MagmadGatewayType,
 		Key:               gatewayID,
 		AssociationsToSet: []storage.TypeAndKey{{Type: devmand.DevmandGatewayType, Key: gatewayID}},
@@ -120,7 +121,7 @@ func updateGatewayConfig(c echo.Context) error {
 		associationsToDelete = entity.Associations
 	}
 
-	_, err := configurator.UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{
 		Type:                 devmand.DevmandGatewayType,
 		Key:                  gatewayID,
 		NewConfig:            iConfig,
@@ -83,7 +83,7 @@ func getGateway(c echo.Context) error {
 		return nerr
 	}
 
-	magmadModel, nerr := handlers.LoadMagmadGatewayModel(nid, gid)
+	magmadModel, versions, nerr := handlers.LoadMagmadGatewayModel(nid, gid)
 	if nerr != nil {
 		return nerr
 	}
@@ -104,8 +104,10 @@ func getGateway(c echo.Context) error {
 		Status:      magmadModel.Status,
 		Tier:        magmadModel.Tier,
 		Magmad:      magmadModel.Magmad,
+		Labels:      magmadModel.Labels,
 		Federation:  ent.Config.(*fegmodels.GatewayFederationConfigs),
 	}
+	obsidian.SetETag(c, append(versions, ent.Version)...)
 	return c.JSON(http.StatusOK, ret)
 }
 
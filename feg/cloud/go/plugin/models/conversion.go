@@ -154,12 +154,16 @@ func (m *FederationGateway) FromBackendModels(
 	mdGW := (&models2.MagmadGateway{}).FromBackendModels(magmadGateway, device, status)
 	// TODO: we should change this to a reflection based shallow copy
 	m.ID, m.Name, m.Description, m.Magmad, m.Tier, m.Device, m.Status = mdGW.ID, mdGW.Name, mdGW.Description, mdGW.Magmad, mdGW.Tier, mdGW.Device, mdGW.Status
+	m.Labels = mdGW.Labels
 	m.Federation = federationGateway.Config.(*GatewayFederationConfigs)
 	return m
 }
 
 func (m *MutableFederationGateway) ValidateModel() error {
-	return m.Validate(strfmt.Default)
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	return m.Labels.ValidateModel()
 }
 
 func (m *MutableFederationGateway) GetMagmadGateway() *models2.MagmadGateway {
@@ -167,6 +171,7 @@ func (m *MutableFederationGateway) GetMagmadGateway() *models2.MagmadGateway {
 		Description: m.Description,
 		Device:      m.Device,
 		ID:          m.ID,
+		Labels:      m.Labels,
 		Magmad:      m.Magmad,
 		Name:        m.Name,
 		Tier:        m.Tier,
@@ -181,6 +186,7 @@ func (m *MutableFederationGateway) GetAdditionalWritesOnCreate() []configurator.
 			Name:        string(m.Name),
 			Description: string(m.Description),
 			Config:      m.Federation,
+			Labels:      m.Labels,
 		},
 		configurator.EntityUpdateCriteria{
 			Type:              orc8r.MagmadGatewayType,
@@ -205,9 +211,10 @@ func (m *MutableFederationGateway) GetAdditionalWritesOnUpdate(
 	}
 
 	entUpdate := configurator.EntityUpdateCriteria{
-		Type:      feg.FegGatewayType,
-		Key:       string(m.ID),
-		NewConfig: m.Federation,
+		Type:        feg.FegGatewayType,
+		Key:         string(m.ID),
+		NewConfig:   m.Federation,
+		LabelsToSet: m.Labels.ToLabelsToSet(existingEnt.Labels),
 	}
 	if string(m.Name) != existingEnt.Name {
 		entUpdate.NewName = swag.String(string(m.Name))
@@ -35,6 +35,9 @@ type FederationGateway struct {
 	// Required: true
 	ID models3.GatewayID `json:"id"`
 
+	// labels
+	Labels models3.Labels `json:"labels,omitempty"`
+
 	// magmad
 	// Required: true
 	Magmad *models4.MagmadGatewayConfigs `json:"magmad"`
@@ -16,6 +16,7 @@ import (
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/models"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/handlers"
 	orc8rmodels "magma/orc8r/cloud/go/pluginimpl/models"
@@ -158,7 +159,7 @@ func getGateway(c echo.Context) error {
 		return nerr
 	}
 
-	magmadModel, nerr := handlers.LoadMagmadGatewayModel(nid, gid)
+	magmadModel, versions, nerr := handlers.LoadMagmadGatewayModel(nid, gid)
 	if nerr != nil {
 		return nerr
 	}
@@ -179,6 +180,7 @@ func getGateway(c echo.Context) error {
 		Status:      magmadModel.Status,
 		Tier:        magmadModel.Tier,
 		Magmad:      magmadModel.Magmad,
+		Labels:      magmadModel.Labels,
 	}
 	if ent.Config != nil {
 		ret.Cellular = ent.Config.(*ltemodels.GatewayCellularConfigs)
@@ -189,6 +191,7 @@ func getGateway(c echo.Context) error {
 			ret.ConnectedEnodebSerials = append(ret.ConnectedEnodebSerials, tk.Key)
 		}
 	}
+	obsidian.SetETag(c, append(versions, ent.Version)...)
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -246,12 +249,12 @@ func listEnodebs(c echo.Context) error {
 		return nerr
 	}
 
-	ents, err := configurator.LoadAllEntitiesInNetwork(
-		nid, lte.CellularEnodebType,
-		configurator.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsToThis: true},
+	ents, nerr := handlers.LoadEntitiesForList(
+		c, nid, lte.CellularEnodebType,
+		configurator.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsToThis: true, LoadLabels: true},
 	)
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	if nerr != nil {
+		return nerr
 	}
 
 	ret := make(map[string]*ltemodels.Enodeb, len(ents))
@@ -278,12 +281,13 @@ func createEnodeb(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "attached_gateway_id is a read-only property")
 	}
 
-	_, err := configurator.CreateEntity(nid, configurator.NetworkEntity{
+	_, err := access.ConfigWriter(c).CreateEntity(nid, configurator.NetworkEntity{
 		Type:       lte.CellularEnodebType,
 		Key:        payload.Serial,
 		Name:       payload.Name,
 		PhysicalID: payload.Serial,
 		Config:     payload.Config,
+		Labels:     payload.Labels,
 	})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
@@ -300,7 +304,7 @@ func getEnodeb(c echo.Context) error {
 
 	ent, err := configurator.LoadEntity(
 		nid, lte.CellularEnodebType, eid,
-		configurator.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsToThis: true},
+		configurator.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsToThis: true, LoadLabels: true},
 	)
 	switch {
 	case err == merrors.ErrNotFound:
@@ -310,6 +314,7 @@ func getEnodeb(c echo.Context) error {
 	}
 
 	ret := (&ltemodels.Enodeb{}).FromBackendModels(ent)
+	obsidian.SetETag(c, ent.Version)
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -333,9 +338,22 @@ func updateEnodeb(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "serial in body must match serial in path")
 	}
 
-	_, err := configurator.UpdateEntity(nid, payload.ToEntityUpdateCriteria())
+	existingEnt, err := configurator.LoadEntity(nid, lte.CellularEnodebType, eid, configurator.EntityLoadCriteria{LoadLabels: true})
+	switch {
+	case err == merrors.ErrNotFound:
+		return echo.ErrNotFound
+	case err != nil:
+		return obsidian.HttpError(errors.Wrap(err, "failed to load existing enodeb"), http.StatusInternalServerError)
+	}
+
+	update := payload.ToEntityUpdateCriteria(existingEnt)
+	update.ExpectedVersion, nerr = obsidian.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
+	_, err = access.ConfigWriter(c).UpdateEntity(nid, update)
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return handlers.WriteErrorToHttpError(err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -346,9 +364,9 @@ func deleteEnodeb(c echo.Context) error {
 		return nerr
 	}
 
-	err := configurator.DeleteEntity(nid, lte.CellularEnodebType, eid)
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	nerr = handlers.DeleteEntitiesIfMatch(c, nid, storage.TypeAndKey{Type: lte.CellularEnodebType, Key: eid})
+	if nerr != nil {
+		return nerr
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -366,6 +384,7 @@ func getEnodebState(c echo.Context) error {
 	}
 	enodebState := st.ReportedState.(*ltemodels.EnodebState)
 	enodebState.TimeReported = st.TimeMs
+	enodebState.IsStale = st.Stale
 	ent, err := configurator.LoadEntityForPhysicalID(st.ReporterID, configurator.EntityLoadCriteria{})
 	if err == nil {
 		enodebState.ReportingGatewayID = ent.Key
@@ -392,7 +411,7 @@ func deleteConnectedEnodeb(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	_, err := configurator.UpdateEntity(networkID, (&ltemodels.EnodebSerials{}).ToDeleteUpdateCriteria(networkID, gatewayID, enodebSerial))
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, (&ltemodels.EnodebSerials{}).ToDeleteUpdateCriteria(networkID, gatewayID, enodebSerial))
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -410,7 +429,7 @@ func addConnectedEnodeb(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	_, err := configurator.UpdateEntity(networkID, (&ltemodels.EnodebSerials{}).ToCreateUpdateCriteria(networkID, gatewayID, enodebSerial))
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, (&ltemodels.EnodebSerials{}).ToCreateUpdateCriteria(networkID, gatewayID, enodebSerial))
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -423,9 +442,9 @@ func listSubscribers(c echo.Context) error {
 		return nerr
 	}
 
-	ents, err := configurator.LoadAllEntitiesInNetwork(networkID, lte.SubscriberEntityType, configurator.EntityLoadCriteria{LoadConfig: true})
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	ents, nerr := handlers.LoadEntitiesForList(c, networkID, lte.SubscriberEntityType, configurator.EntityLoadCriteria{LoadConfig: true, LoadLabels: true})
+	if nerr != nil {
+		return nerr
 	}
 
 	ret := make(map[string]*ltemodels.Subscriber, len(ents))
@@ -453,10 +472,11 @@ func createSubscriber(c echo.Context) error {
 		return nerr
 	}
 
-	_, err := configurator.CreateEntity(networkID, configurator.NetworkEntity{
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, configurator.NetworkEntity{
 		Type:   lte.SubscriberEntityType,
 		Key:    string(payload.ID),
 		Config: payload.Lte,
+		Labels: payload.Labels,
 	})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
@@ -471,7 +491,7 @@ func getSubscriber(c echo.Context) error {
 		return nerr
 	}
 
-	ent, err := configurator.LoadEntity(networkID, lte.SubscriberEntityType, subscriberID, configurator.EntityLoadCriteria{LoadConfig: true})
+	ent, err := configurator.LoadEntity(networkID, lte.SubscriberEntityType, subscriberID, configurator.EntityLoadCriteria{LoadConfig: true, LoadLabels: true})
 	switch {
 	case err == merrors.ErrNotFound:
 		return echo.ErrNotFound
@@ -480,6 +500,7 @@ func getSubscriber(c echo.Context) error {
 	}
 
 	ret := (&ltemodels.Subscriber{}).FromBackendModels(ent)
+	obsidian.SetETag(c, ent.Version)
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -497,7 +518,7 @@ func updateSubscriber(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	_, err := configurator.LoadEntity(networkID, lte.SubscriberEntityType, subscriberID, configurator.EntityLoadCriteria{})
+	existingEnt, err := configurator.LoadEntity(networkID, lte.SubscriberEntityType, subscriberID, configurator.EntityLoadCriteria{LoadLabels: true})
 	switch {
 	case err == merrors.ErrNotFound:
 		return echo.ErrNotFound
@@ -509,9 +530,19 @@ func updateSubscriber(c echo.Context) error {
 		return nerr
 	}
 
-	err = configurator.CreateOrUpdateEntityConfig(networkID, lte.SubscriberEntityType, subscriberID, payload.Lte)
+	expectedVersion, nerr := obsidian.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+		Type:            lte.SubscriberEntityType,
+		Key:             subscriberID,
+		NewConfig:       payload.Lte,
+		LabelsToSet:     payload.Labels.ToLabelsToSet(existingEnt.Labels),
+		ExpectedVersion: expectedVersion,
+	})
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return handlers.WriteErrorToHttpError(err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -522,9 +553,9 @@ func deleteSubscriber(c echo.Context) error {
 		return nerr
 	}
 
-	err := configurator.DeleteEntity(networkID, lte.SubscriberEntityType, subscriberID)
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	nerr = handlers.DeleteEntitiesIfMatch(c, networkID, storage.TypeAndKey{Type: lte.SubscriberEntityType, Key: subscriberID})
+	if nerr != nil {
+		return nerr
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -557,7 +588,7 @@ func updateSubscriberProfile(c echo.Context) error {
 		return nerr
 	}
 
-	_, err = configurator.UpdateEntity(networkID, configurator.EntityUpdateCriteria{Type: lte.SubscriberEntityType, Key: subscriberID, NewConfig: desiredCfg})
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{Type: lte.SubscriberEntityType, Key: subscriberID, NewConfig: desiredCfg})
 	if err != nil {
 		return obsidian.HttpError(errors.Wrap(err, "failed to update profile"), http.StatusInternalServerError)
 	}
@@ -581,7 +612,7 @@ func makeSubscriberStateHandler(desiredState string) echo.HandlerFunc {
 
 		newConfig := cfg.(*ltemodels.LteSubscription)
 		newConfig.State = desiredState
-		err = configurator.CreateOrUpdateEntityConfig(networkID, lte.SubscriberEntityType, subscriberID, newConfig)
+		err = access.ConfigWriter(c).CreateOrUpdateEntityConfig(networkID, lte.SubscriberEntityType, subscriberID, newConfig)
 		if err != nil {
 			return obsidian.HttpError(err, http.StatusInternalServerError)
 		}
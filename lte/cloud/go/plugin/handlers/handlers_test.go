@@ -1065,16 +1065,23 @@ func TestListAndGetGateways(t *testing.T) {
 	expectedGet.Status.CheckinTime = uint64(time.Unix(1000000, 0).UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond)))
 	expectedGet.Status.CertExpirationTime = time.Unix(1000000, 0).Add(time.Hour * 4).Unix()
 	tc = tests.Test{
-		Method:         "GET",
-		URL:            testURLRoot,
-		Handler:        getGateway,
-		ParamNames:     []string{"network_id", "gateway_id"},
-		ParamValues:    []string{"n1", "g1"},
-		ExpectedStatus: 200,
-		ExpectedResult: expectedGet,
+		Method:          "GET",
+		URL:             testURLRoot,
+		Handler:         getGateway,
+		ParamNames:      []string{"network_id", "gateway_id"},
+		ParamValues:     []string{"n1", "g1"},
+		ExpectedStatus:  200,
+		ExpectedResult:  expectedGet,
+		ExpectedHeaders: map[string]string{"ETag": `"0.0.0"`},
 	}
 	tests.RunUnitTest(t, e, tc)
 
+	// the ETag changes with the cellular gateway as well
+	_, err = configurator.UpdateEntity("n1", configurator.EntityUpdateCriteria{Type: lte.CellularGatewayType, Key: "g1", NewName: swag.String("foo")})
+	assert.NoError(t, err)
+	tc.ExpectedHeaders = map[string]string{"ETag": `"0.0.1"`}
+	tests.RunUnitTest(t, e, tc)
+
 	expectedGet = &models2.LteGateway{
 		ID:   "g2",
 		Name: "barfoo", Description: "bar foo",
@@ -1190,11 +1197,26 @@ func TestUpdateGateway(t *testing.T) {
 		ConnectedEnodebSerials: []string{"enb1", "enb3"},
 	}
 
+	// stale If-Match for the cellular gateway, nothing should be written
 	tc := tests.Test{
 		Method:         "PUT",
 		URL:            testURLRoot,
 		Handler:        updateGateway,
 		Payload:        payload,
+		Headers:        map[string]string{"If-Match": `"0.0.1"`},
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot,
+		Handler:        updateGateway,
+		Payload:        payload,
+		Headers:        map[string]string{"If-Match": `"0.0.0"`},
 		ParamNames:     []string{"network_id", "gateway_id"},
 		ParamValues:    []string{"n1", "g1"},
 		ExpectedStatus: 204,
@@ -1870,13 +1892,14 @@ func TestListAndGetEnodebs(t *testing.T) {
 	tests.RunUnitTest(t, e, tc)
 
 	tc = tests.Test{
-		Method:         "GET",
-		URL:            testURLRoot,
-		Handler:        getEnodeb,
-		ParamNames:     []string{"network_id", "enodeb_serial"},
-		ParamValues:    []string{"n1", "abcdefg"},
-		ExpectedStatus: 200,
-		ExpectedResult: expected["abcdefg"],
+		Method:          "GET",
+		URL:             testURLRoot,
+		Handler:         getEnodeb,
+		ParamNames:      []string{"network_id", "enodeb_serial"},
+		ParamValues:     []string{"n1", "abcdefg"},
+		ExpectedStatus:  200,
+		ExpectedResult:  expected["abcdefg"],
+		ExpectedHeaders: map[string]string{"ETag": `"0"`},
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -2047,7 +2070,9 @@ func TestUpdateEnodeb(t *testing.T) {
 			},
 			Name:   "foobar",
 			Serial: "abcdefg",
+			Labels: map[string]string{"region": "west"},
 		},
+		Headers:        map[string]string{"If-Match": `"0"`},
 		ParamNames:     []string{"network_id", "enodeb_serial"},
 		ParamValues:    []string{"n1", "abcdefg"},
 		ExpectedStatus: 204,
@@ -2073,10 +2098,25 @@ func TestUpdateEnodeb(t *testing.T) {
 			Tac:                    2,
 			TransmitEnabled:        swag.Bool(false),
 		},
+		Labels:  map[string]string{"region": "west"},
 		Version: 1,
 	}
 	assert.Equal(t, expected, actual)
 
+	// stale If-Match
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot,
+		Handler:        updateEnodeb,
+		Payload:        &models2.Enodeb{Config: expected.Config.(*models2.EnodebConfiguration), Name: "stale", Serial: "abcdefg"},
+		Headers:        map[string]string{"If-Match": `"0"`},
+		ParamNames:     []string{"network_id", "enodeb_serial"},
+		ParamValues:    []string{"n1", "abcdefg"},
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
 	tc = tests.Test{
 		Method:  "PUT",
 		URL:     testURLRoot,
@@ -2141,6 +2181,18 @@ func TestDeleteEnodeb(t *testing.T) {
 	assert.NoError(t, err)
 
 	tc := tests.Test{
+		Method:         "DELETE",
+		URL:            testURLRoot,
+		Handler:        deleteEnodeb,
+		Headers:        map[string]string{"If-Match": `"1"`},
+		ParamNames:     []string{"network_id", "enodeb_serial"},
+		ParamValues:    []string{"n1", "abcdefg"},
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
 		Method:         "DELETE",
 		URL:            testURLRoot,
 		Handler:        deleteEnodeb,
@@ -2434,6 +2486,77 @@ func TestListSubscribers(t *testing.T) {
 		}),
 	}
 	tests.RunUnitTest(t, e, tc)
+
+	// Paginated
+	_, firstPageToken, err := configurator.LoadEntitiesPage("n1", lte.SubscriberEntityType, nil, configurator.EntityLoadCriteria{PageSize: 1})
+	assert.NoError(t, err)
+	tc = tests.Test{
+		Method:          "GET",
+		URL:             testURLRoot + "?page_size=1",
+		Handler:         listSubscribers,
+		ParamNames:      []string{"network_id"},
+		ParamValues:     []string{"n1"},
+		ExpectedStatus:  200,
+		ExpectedHeaders: map[string]string{obsidian.NextPageTokenHeader: firstPageToken},
+		ExpectedResult: tests.JSONMarshaler(map[string]*models2.Subscriber{
+			"IMSI0987654321": {
+				ID: "IMSI0987654321",
+				Lte: &models2.LteSubscription{
+					AuthAlgo:   "MILENAGE",
+					AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
+					AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
+					State:      "ACTIVE",
+					SubProfile: "foo",
+				},
+			},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:          "GET",
+		URL:             testURLRoot + "?page_size=2&page_token=" + firstPageToken,
+		Handler:         listSubscribers,
+		ParamNames:      []string{"network_id"},
+		ParamValues:     []string{"n1"},
+		ExpectedStatus:  200,
+		ExpectedHeaders: map[string]string{obsidian.NextPageTokenHeader: ""},
+		ExpectedResult: tests.JSONMarshaler(map[string]*models2.Subscriber{
+			"IMSI1234567890": {
+				ID: "IMSI1234567890",
+				Lte: &models2.LteSubscription{
+					AuthAlgo:   "MILENAGE",
+					AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
+					AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
+					State:      "ACTIVE",
+					SubProfile: "default",
+				},
+			},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "?page_size=1&page_token=garbage",
+		Handler:        listSubscribers,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 400,
+		ExpectedError:  "invalid page token",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            testURLRoot + "?page_size=0",
+		Handler:        listSubscribers,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 400,
+		ExpectedError:  "page_size must be an integer between 1 and 1000",
+	}
+	tests.RunUnitTest(t, e, tc)
 }
 
 func TestGetSubscriber(t *testing.T) {
@@ -2493,6 +2616,7 @@ func TestGetSubscriber(t *testing.T) {
 				SubProfile: "default",
 			},
 		},
+		ExpectedHeaders: map[string]string{"ETag": `"0"`},
 	}
 	tests.RunUnitTest(t, e, tc)
 }
@@ -2572,6 +2696,7 @@ func TestUpdateSubscriber(t *testing.T) {
 			State:      "INACTIVE",
 			SubProfile: "foo",
 		},
+		Labels: map[string]string{"region": "west"},
 	}
 	tc = tests.Test{
 		Method:         "PUT",
@@ -2591,11 +2716,26 @@ func TestUpdateSubscriber(t *testing.T) {
 		Type:      lte.SubscriberEntityType,
 		Key:       "IMSI1234567890",
 		Config:    payload.Lte,
+		Labels:    map[string]string{"region": "west"},
 		GraphID:   "2",
 		Version:   1,
 	}
 	assert.Equal(t, expected, actual)
 
+	// stale If-Match
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot,
+		Handler:        updateSubscriber,
+		Payload:        payload,
+		Headers:        map[string]string{"If-Match": `"0"`},
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n1", "IMSI1234567890"},
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
 	// No profile matching
 	payload.Lte.SubProfile = "bar"
 	tc = tests.Test{
@@ -2643,6 +2783,7 @@ func TestDeleteSubscriber(t *testing.T) {
 		Method:         "DELETE",
 		URL:            testURLRoot,
 		Handler:        deleteSubscriber,
+		Headers:        map[string]string{"If-Match": `"0"`},
 		ParamNames:     []string{"network_id", "subscriber_id"},
 		ParamValues:    []string{"n1", "IMSI1234567890"},
 		ExpectedStatus: 204,
@@ -17,6 +17,7 @@ import (
 	"magma/lte/cloud/go/plugin/models"
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/services/configurator"
 
 	"github.com/labstack/echo"
@@ -68,7 +69,7 @@ func CreateBaseName(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	_, err := configurator.CreateEntity(networkID, bnr.ToEntity())
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, bnr.ToEntity())
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -120,7 +121,7 @@ func UpdateBaseName(c echo.Context) error {
 		return echo.ErrNotFound
 	}
 
-	_, err = configurator.UpdateEntity(networkID, bnr.ToEntityUpdateCriteria())
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, bnr.ToEntityUpdateCriteria())
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -133,7 +134,7 @@ func DeleteBaseName(c echo.Context) error {
 		return nerr
 	}
 
-	err := configurator.DeleteEntity(networkID, lte.BaseNameEntityType, baseName)
+	err := access.ConfigWriter(c).DeleteEntity(networkID, lte.BaseNameEntityType, baseName)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -187,7 +188,7 @@ func CreateRule(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	_, err := configurator.CreateEntity(networkID, rule.ToEntity())
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, rule.ToEntity())
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -242,7 +243,7 @@ func UpdateRule(c echo.Context) error {
 		return echo.ErrNotFound
 	}
 
-	_, err = configurator.UpdateEntity(networkID, rule.ToEntityUpdateCriteria())
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, rule.ToEntityUpdateCriteria())
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -255,7 +256,7 @@ func DeleteRule(c echo.Context) error {
 		return nerr
 	}
 
-	err := configurator.DeleteEntity(networkID, lte.PolicyRuleEntityType, ruleID)
+	err := access.ConfigWriter(c).DeleteEntity(networkID, lte.PolicyRuleEntityType, ruleID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
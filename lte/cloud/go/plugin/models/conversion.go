@@ -168,6 +168,7 @@ func (m *LteGateway) FromBackendModels(
 	mdGW := (&models2.MagmadGateway{}).FromBackendModels(magmadGateway, device, status)
 	// TODO: we should change this to a reflection based shallow copy
 	m.ID, m.Name, m.Description, m.Magmad, m.Tier, m.Device, m.Status = mdGW.ID, mdGW.Name, mdGW.Description, mdGW.Magmad, mdGW.Tier, mdGW.Device, mdGW.Status
+	m.Labels = mdGW.Labels
 
 	if cellularGateway.Config != nil {
 		m.Cellular = cellularGateway.Config.(*GatewayCellularConfigs)
@@ -195,6 +196,9 @@ func (m *MutableLteGateway) ValidateModel() error {
 	if err := m.Device.ValidateModel(); err != nil {
 		res = append(res, err)
 	}
+	if err := m.Labels.ValidateModel(); err != nil {
+		res = append(res, err)
+	}
 
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
@@ -207,6 +211,7 @@ func (m *MutableLteGateway) GetMagmadGateway() *models2.MagmadGateway {
 		Description: m.Description,
 		Device:      m.Device,
 		ID:          m.ID,
+		Labels:      m.Labels,
 		Magmad:      m.Magmad,
 		Name:        m.Name,
 		Tier:        m.Tier,
@@ -220,6 +225,7 @@ func (m *MutableLteGateway) GetAdditionalWritesOnCreate() []configurator.EntityW
 		Name:        string(m.Name),
 		Description: string(m.Description),
 		Config:      m.Cellular,
+		Labels:      m.Labels,
 	}
 	for _, enbSerial := range m.ConnectedEnodebSerials {
 		ent.Associations = append(ent.Associations, storage.TypeAndKey{Type: lte.CellularEnodebType, Key: enbSerial})
@@ -250,9 +256,10 @@ func (m *MutableLteGateway) GetAdditionalWritesOnUpdate(
 	}
 
 	entUpdate := configurator.EntityUpdateCriteria{
-		Type:      lte.CellularGatewayType,
-		Key:       string(m.ID),
-		NewConfig: m.Cellular,
+		Type:        lte.CellularGatewayType,
+		Key:         string(m.ID),
+		NewConfig:   m.Cellular,
+		LabelsToSet: m.Labels.ToLabelsToSet(existingEnt.Labels),
 	}
 	if string(m.Name) != existingEnt.Name {
 		entUpdate.NewName = swag.String(string(m.Name))
@@ -393,6 +400,7 @@ func (m *EnodebSerials) ToCreateUpdateCriteria(networkID, gatewayID, enodebID st
 func (m *Enodeb) FromBackendModels(ent configurator.NetworkEntity) *Enodeb {
 	m.Name = ent.Name
 	m.Serial = ent.Key
+	m.Labels = ent.Labels
 	if ent.Config != nil {
 		m.Config = ent.Config.(*EnodebConfiguration)
 	}
@@ -404,17 +412,19 @@ func (m *Enodeb) FromBackendModels(ent configurator.NetworkEntity) *Enodeb {
 	return m
 }
 
-func (m *Enodeb) ToEntityUpdateCriteria() configurator.EntityUpdateCriteria {
+func (m *Enodeb) ToEntityUpdateCriteria(existingEnt configurator.NetworkEntity) configurator.EntityUpdateCriteria {
 	return configurator.EntityUpdateCriteria{
-		Type:      lte.CellularEnodebType,
-		Key:       m.Serial,
-		NewName:   swag.String(m.Name),
-		NewConfig: m.Config,
+		Type:        lte.CellularEnodebType,
+		Key:         m.Serial,
+		NewName:     swag.String(m.Name),
+		NewConfig:   m.Config,
+		LabelsToSet: m.Labels.ToLabelsToSet(existingEnt.Labels),
 	}
 }
 
 func (m *Subscriber) FromBackendModels(ent configurator.NetworkEntity) *Subscriber {
 	m.ID = SubscriberID(ent.Key)
+	m.Labels = ent.Labels
 	m.Lte = ent.Config.(*LteSubscription)
 	// If no profile in backend, return "default"
 	if m.Lte.SubProfile == "" {
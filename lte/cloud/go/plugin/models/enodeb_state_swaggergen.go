@@ -41,6 +41,9 @@ type EnodebState struct {
 	// Required: true
 	GpsLongitude *string `json:"gps_longitude"`
 
+	// True if the state hasn't been re-reported within the enodeb state TTL
+	IsStale bool `json:"is_stale,omitempty"`
+
 	// mme connected
 	// Required: true
 	MmeConnected *bool `json:"mme_connected"`
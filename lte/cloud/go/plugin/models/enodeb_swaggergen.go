@@ -7,6 +7,7 @@ package models
 
 import (
 	strfmt "github.com/go-openapi/strfmt"
+	models2 "magma/orc8r/cloud/go/models"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
@@ -25,6 +26,9 @@ type Enodeb struct {
 	// Required: true
 	Config *EnodebConfiguration `json:"config"`
 
+	// labels
+	Labels models2.Labels `json:"labels,omitempty"`
+
 	// name
 	// Required: true
 	// Min Length: 1
@@ -39,6 +39,9 @@ type LteGateway struct {
 	// Required: true
 	ID models2.GatewayID `json:"id"`
 
+	// labels
+	Labels models2.Labels `json:"labels,omitempty"`
+
 	// magmad
 	// Required: true
 	Magmad *models3.MagmadGatewayConfigs `json:"magmad"`
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	strfmt "github.com/go-openapi/strfmt"
+	models2 "magma/orc8r/cloud/go/models"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
@@ -29,6 +30,9 @@ type Subscriber struct {
 	// Required: true
 	ID SubscriberID `json:"id"`
 
+	// labels
+	Labels models2.Labels `json:"labels,omitempty"`
+
 	// lte
 	// Required: true
 	Lte *LteSubscription `json:"lte"`
@@ -218,7 +218,10 @@ func (m *EnodebSerials) ValidateModel() error {
 }
 
 func (m *Enodeb) ValidateModel() error {
-	return m.Validate(strfmt.Default)
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	return m.Labels.ValidateModel()
 }
 
 func (m *EnodebConfiguration) ValidateModel() error {
@@ -237,6 +240,9 @@ func (m *Subscriber) ValidateModel() error {
 	if err := m.Lte.ValidateModel(); err != nil {
 		return err
 	}
+	if err := m.Labels.ValidateModel(); err != nil {
+		return err
+	}
 	return nil
 }
 
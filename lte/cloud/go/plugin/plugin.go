@@ -9,6 +9,8 @@ LICENSE file in the root directory of this source tree.
 package plugin
 
 import (
+	"time"
+
 	"magma/lte/cloud/go/lte"
 	"magma/lte/cloud/go/plugin/handlers"
 	lteModels "magma/lte/cloud/go/plugin/models"
@@ -32,6 +34,11 @@ import (
 	"magma/orc8r/cloud/go/services/streamer/providers"
 )
 
+// enodebStateTTL is how long an enodeb's state is considered fresh after it
+// was last reported by default. Stale enodeb states are garbage collected
+// since enodebs are frequently replaced or moved between gateways.
+const enodebStateTTL = time.Hour
+
 // LteOrchestratorPlugin implements OrchestratorPlugin for the LTE module
 type LteOrchestratorPlugin struct{}
 
@@ -49,7 +56,7 @@ func (*LteOrchestratorPlugin) GetServices() []registry.ServiceLocation {
 
 func (*LteOrchestratorPlugin) GetSerdes() []serde.Serde {
 	return []serde.Serde{
-		state.NewStateSerde(lte.EnodebStateType, &lteModels.EnodebState{}),
+		state.NewExpiringStateSerde(lte.EnodebStateType, &lteModels.EnodebState{}, enodebStateTTL),
 		state.NewStateSerde(lte.SubscriberStateType, &models3.SubscriberState{}),
 
 		// Configurator serdes
@@ -16,6 +16,7 @@ import (
 	models2 "magma/lte/cloud/go/plugin/models"
 	"magma/lte/cloud/go/services/cellular/obsidian/models"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	cfgObsidian "magma/orc8r/cloud/go/services/config/obsidian"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -124,7 +125,7 @@ func createGatewayConfig(c echo.Context) error {
 
 	associationsToAdd := getEnodebTKs(config.AttachedEnodebSerials)
 
-	_, err := configurator.CreateEntity(networkID, configurator.NetworkEntity{
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, configurator.NetworkEntity{
 		Type:         lte.CellularGatewayType,
 		Key:          gatewayID,
 		Config:       config,
@@ -134,7 +135,7 @@ func createGatewayConfig(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
-	_, err = configurator.UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{
 		Type:              orc8r.MagmadGatewayType,
 		Key:               gatewayID,
 		AssociationsToSet: []storage.TypeAndKey{{Type: lte.CellularGatewayType, Key: gatewayID}},
@@ -170,7 +171,7 @@ func updateGatewayConfig(c echo.Context) error {
 		associationsToDelete = entity.Associations
 	}
 
-	_, err := configurator.UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{
 		Type:                 lte.CellularGatewayType,
 		Key:                  gatewayID,
 		NewConfig:            config,
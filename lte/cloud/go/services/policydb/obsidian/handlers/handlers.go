@@ -16,6 +16,7 @@ import (
 	"magma/lte/cloud/go/services/policydb/obsidian/models"
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/services/configurator"
 
 	"github.com/go-openapi/strfmt"
@@ -72,7 +73,7 @@ func CreateBaseName(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	_, err := configurator.CreateEntity(networkID, bnr.ToEntity())
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, bnr.ToEntity())
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -120,7 +121,7 @@ func UpdateBaseName(c echo.Context) error {
 	if err := c.Bind(&ruleNames); err != nil {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
-	_, err := configurator.UpdateEntity(
+	_, err := access.ConfigWriter(c).UpdateEntity(
 		networkID,
 		configurator.EntityUpdateCriteria{
 			Type:              lte.BaseNameEntityType,
@@ -144,7 +145,7 @@ func DeleteBaseName(c echo.Context) error {
 		return baseNameHTTPErr()
 	}
 
-	err := configurator.DeleteEntity(networkID, lte.BaseNameEntityType, baseName)
+	err := access.ConfigWriter(c).DeleteEntity(networkID, lte.BaseNameEntityType, baseName)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -192,7 +193,7 @@ func CreateRule(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
-	_, err = configurator.CreateEntity(networkID, ent)
+	_, err = access.ConfigWriter(c).CreateEntity(networkID, ent)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -248,7 +249,7 @@ func UpdateRule(c echo.Context) error {
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
-	err = configurator.CreateOrUpdateEntityConfig(networkID, lte.PolicyRuleEntityType, ruleID, cfg)
+	err = access.ConfigWriter(c).CreateOrUpdateEntityConfig(networkID, lte.PolicyRuleEntityType, ruleID, cfg)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -265,7 +266,7 @@ func DeleteRule(c echo.Context) error {
 		return ruleIDHTTPErr()
 	}
 
-	err := configurator.DeleteEntity(networkID, lte.PolicyRuleEntityType, ruleID)
+	err := access.ConfigWriter(c).DeleteEntity(networkID, lte.PolicyRuleEntityType, ruleID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
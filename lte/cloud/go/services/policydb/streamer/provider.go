@@ -16,15 +16,23 @@ import (
 	lteProtos "magma/lte/cloud/go/protos"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/storage"
 
 	"github.com/go-openapi/swag"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/thoas/go-funk"
 )
 
 const (
 	policyStreamName   = "policydb"
 	baseNameStreamName = "base_names"
+
+	// maxDeltaChanges is the max number of entity changes a delta is
+	// computed from. Gateways which are further behind than this get a full
+	// sync.
+	maxDeltaChanges = 1000
 )
 
 type PoliciesProvider struct{}
@@ -44,6 +52,66 @@ func (provider *PoliciesProvider) GetUpdates(gatewayId string, extraArgs *any.An
 		return nil, err
 	}
 
+	return ruleEntsToUpdates(ruleEnts)
+}
+
+func (provider *PoliciesProvider) GetVersion(gatewayId string, extraArgs *any.Any) (uint64, error) {
+	gwEnt, err := configurator.LoadEntityForPhysicalID(gatewayId, configurator.EntityLoadCriteria{})
+	if err != nil {
+		return 0, err
+	}
+	return configurator.GetLatestEntityChangeSequence(gwEnt.NetworkID)
+}
+
+func (provider *PoliciesProvider) GetUpdatesSince(gatewayId string, fromVersion uint64, extraArgs *any.Any) (*providers.Delta, error) {
+	gwEnt, err := configurator.LoadEntityForPhysicalID(gatewayId, configurator.EntityLoadCriteria{})
+	if err != nil {
+		return nil, err
+	}
+
+	changes, latestVersion, err := configurator.LoadEntityChanges(gwEnt.NetworkID, fromVersion, maxDeltaChanges+1)
+	if err == configurator.ErrCursorExpired {
+		return nil, providers.ErrVersionTooOld
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fromVersion > latestVersion || len(changes) > maxDeltaChanges {
+		return nil, providers.ErrVersionTooOld
+	}
+
+	changedRuleTKs := []storage.TypeAndKey{}
+	for _, change := range changes {
+		tk := storage.TypeAndKey{Type: change.Type, Key: change.Key}
+		if change.Type == lte.PolicyRuleEntityType && !funk.Contains(changedRuleTKs, tk) {
+			changedRuleTKs = append(changedRuleTKs, tk)
+		}
+	}
+
+	ret := &providers.Delta{Version: latestVersion, Updates: []*protos.DataUpdate{}, DeletedKeys: []string{}}
+	if len(changedRuleTKs) == 0 {
+		return ret, nil
+	}
+	ruleEnts, notFound, err := configurator.LoadEntities(
+		gwEnt.NetworkID, nil, nil, nil, changedRuleTKs,
+		configurator.EntityLoadCriteria{LoadConfig: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Updates, err = ruleEntsToUpdates(ruleEnts)
+	if err != nil {
+		return nil, err
+	}
+	for _, tk := range notFound {
+		ret.DeletedKeys = append(ret.DeletedKeys, tk.Key)
+	}
+	sort.Strings(ret.DeletedKeys)
+	return ret, nil
+}
+
+func ruleEntsToUpdates(ruleEnts []configurator.NetworkEntity) ([]*protos.DataUpdate, error) {
 	ruleProtos := make([]*lteProtos.PolicyRule, 0, len(ruleEnts))
 	for _, rule := range ruleEnts {
 		ruleProtos = append(ruleProtos, createRuleProtoFromEnt(rule))
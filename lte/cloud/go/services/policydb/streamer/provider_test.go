@@ -21,6 +21,7 @@ import (
 	orcprotos "magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/configurator"
 	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/streamer/providers"
 	"magma/orc8r/cloud/go/storage"
 
 	"github.com/go-openapi/swag"
@@ -157,3 +158,55 @@ func TestPolicyStreamers(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestPolicyStreamers_Delta(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	_ = plugin.RegisterPluginForTests(t, &plugin2.LteOrchestratorPlugin{})
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: "g1", PhysicalID: "hw1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities("n1", []configurator.NetworkEntity{
+		{Type: lte.PolicyRuleEntityType, Key: "r1", Config: &models.PolicyRuleConfig{MonitoringKey: "foo"}},
+		{Type: lte.PolicyRuleEntityType, Key: "r2", Config: &models.PolicyRuleConfig{MonitoringKey: "bar"}},
+	})
+	assert.NoError(t, err)
+
+	pro := &pdbstreamer.PoliciesProvider{}
+	version, err := pro.GetVersion("hw1", nil)
+	assert.NoError(t, err)
+
+	// Update r1, delete r2, and change an unrelated entity
+	_, err = configurator.UpdateEntity("n1", configurator.EntityUpdateCriteria{
+		Type: lte.PolicyRuleEntityType, Key: "r1",
+		NewConfig: &models.PolicyRuleConfig{MonitoringKey: "baz"},
+	})
+	assert.NoError(t, err)
+	err = configurator.DeleteEntity("n1", lte.PolicyRuleEntityType, "r2")
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: lte.BaseNameEntityType, Key: "b1"})
+	assert.NoError(t, err)
+
+	expectedUpdates := funk.Map(
+		[]*protos.PolicyRule{{Id: "r1", MonitoringKey: "baz", FlowList: []*protos.FlowDescription{}}},
+		func(r *protos.PolicyRule) *orcprotos.DataUpdate {
+			data, err := proto.Marshal(r)
+			assert.NoError(t, err)
+			return &orcprotos.DataUpdate{Key: r.Id, Value: data}
+		},
+	).([]*orcprotos.DataUpdate)
+	actual, err := pro.GetUpdatesSince("hw1", version, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, version+3, actual.Version)
+	assert.Equal(t, expectedUpdates, actual.Updates)
+	assert.Equal(t, []string{"r2"}, actual.DeletedKeys)
+
+	// No rules changed since the latest version
+	actual, err = pro.GetUpdatesSince("hw1", actual.Version, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &providers.Delta{Version: version + 3, Updates: []*orcprotos.DataUpdate{}, DeletedKeys: []string{}}, actual)
+
+	_, err = pro.GetUpdatesSince("hw1", version+100, nil)
+	assert.Equal(t, providers.ErrVersionTooOld, err)
+}
@@ -17,7 +17,10 @@ import (
 	models2 "magma/lte/cloud/go/plugin/models"
 	"magma/lte/cloud/go/services/subscriberdb/obsidian/models"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
+	orc8rhandlers "magma/orc8r/cloud/go/pluginimpl/handlers"
 	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
 
 	"github.com/golang/glog"
 	"github.com/labstack/echo"
@@ -64,7 +67,7 @@ func createSubscriber(c echo.Context) error {
 		subscriberID = string(models2.SubscriberID(sub.ID))
 	}
 
-	_, err := configurator.CreateEntity(networkID, configurator.NetworkEntity{
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, configurator.NetworkEntity{
 		Type:   lte.SubscriberEntityType,
 		Key:    subscriberID,
 		Config: sub.Lte,
@@ -81,9 +84,9 @@ func listSubscribers(c echo.Context) error {
 		return nerr
 	}
 
-	ents, err := configurator.LoadAllEntitiesInNetwork(networkID, lte.SubscriberEntityType, getListSubscribersLoadCriteria(c))
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	ents, nerr := orc8rhandlers.LoadEntitiesForList(c, networkID, lte.SubscriberEntityType, getListSubscribersLoadCriteria(c))
+	if nerr != nil {
+		return nerr
 	}
 
 	// if configs were loaded we'll return those, otherwise just the sids
@@ -121,6 +124,7 @@ func getSubscriber(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 	ret := &models2.Subscriber{ID: models2.SubscriberID(ent.Key), Lte: ent.Config.(*models2.LteSubscription)}
+	obsidian.SetETag(c, ent.Version)
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -144,9 +148,18 @@ func updateSubscriber(c echo.Context) error {
 		subscriberID = string(sub.ID)
 	}
 
-	err := configurator.CreateOrUpdateEntityConfig(networkID, lte.SubscriberEntityType, subscriberID, sub.Lte)
+	expectedVersion, nerr := obsidian.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+		Type:            lte.SubscriberEntityType,
+		Key:             subscriberID,
+		NewConfig:       sub.Lte,
+		ExpectedVersion: expectedVersion,
+	})
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return orc8rhandlers.WriteErrorToHttpError(err)
 	}
 	return c.NoContent(http.StatusOK)
 }
@@ -161,9 +174,9 @@ func deleteSubscriber(c echo.Context) error {
 		return subscriberIdHttpErr()
 	}
 
-	err := configurator.DeleteEntity(networkID, lte.SubscriberEntityType, subscriberID)
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	nerr = orc8rhandlers.DeleteEntitiesIfMatch(c, networkID, storage.TypeAndKey{Type: lte.SubscriberEntityType, Key: subscriberID})
+	if nerr != nil {
+		return nerr
 	}
 	return c.NoContent(http.StatusNoContent)
 }
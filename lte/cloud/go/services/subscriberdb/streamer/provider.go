@@ -16,11 +16,17 @@ import (
 	protos2 "magma/lte/cloud/go/protos"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/storage"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
 )
 
+// maxDeltaChanges is the max number of entity changes a delta is computed
+// from. Gateways which are further behind than this get a full sync.
+const maxDeltaChanges = 1000
+
 type SubscribersProvider struct{}
 
 func (provider *SubscribersProvider) GetStreamName() string {
@@ -38,14 +44,87 @@ func (provider *SubscribersProvider) GetUpdates(gatewayId string, extraArgs *any
 		return nil, err
 	}
 
+	return subscriberEntsToUpdates(ent.NetworkID, subEnts)
+}
+
+func (provider *SubscribersProvider) GetVersion(gatewayId string, extraArgs *any.Any) (uint64, error) {
+	ent, err := configurator.LoadEntityForPhysicalID(gatewayId, configurator.EntityLoadCriteria{})
+	if err != nil {
+		return 0, err
+	}
+	return configurator.GetLatestEntityChangeSequence(ent.NetworkID)
+}
+
+func (provider *SubscribersProvider) GetUpdatesSince(gatewayId string, fromVersion uint64, extraArgs *any.Any) (*providers.Delta, error) {
+	ent, err := configurator.LoadEntityForPhysicalID(gatewayId, configurator.EntityLoadCriteria{})
+	if err != nil {
+		return nil, err
+	}
+
+	changes, latestVersion, err := configurator.LoadEntityChanges(ent.NetworkID, fromVersion, maxDeltaChanges+1)
+	if err == configurator.ErrCursorExpired {
+		return nil, providers.ErrVersionTooOld
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fromVersion > latestVersion || len(changes) > maxDeltaChanges {
+		return nil, providers.ErrVersionTooOld
+	}
+
+	changedSubs := map[string]bool{}
+	for _, change := range changes {
+		switch change.Type {
+		case lte.SubscriberEntityType:
+			changedSubs[change.Key] = true
+		case lte.BaseNameEntityType, lte.PolicyRuleEntityType:
+			// Base name and policy assignments are stored as associations
+			// from those entities, so we can't tell which subscribers a
+			// change to one of them affected
+			return nil, providers.ErrVersionTooOld
+		}
+	}
+
+	ret := &providers.Delta{Version: latestVersion, Updates: []*protos.DataUpdate{}, DeletedKeys: []string{}}
+	if len(changedSubs) == 0 {
+		return ret, nil
+	}
+
+	subTKs := make([]storage.TypeAndKey, 0, len(changedSubs))
+	for key := range changedSubs {
+		subTKs = append(subTKs, storage.TypeAndKey{Type: lte.SubscriberEntityType, Key: key})
+	}
+	subEnts, notFound, err := configurator.LoadEntities(
+		ent.NetworkID, nil, nil, nil, subTKs,
+		configurator.EntityLoadCriteria{LoadConfig: true, LoadAssocsToThis: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Updates, err = subscriberEntsToUpdates(ent.NetworkID, subEnts)
+	if err != nil {
+		return nil, err
+	}
+	for _, tk := range notFound {
+		sid, err := protos2.SidProto(tk.Key)
+		if err != nil {
+			return nil, err
+		}
+		ret.DeletedKeys = append(ret.DeletedKeys, protos2.SidString(sid))
+	}
+	sort.Strings(ret.DeletedKeys)
+	return ret, nil
+}
+
+func subscriberEntsToUpdates(networkID string, subEnts []configurator.NetworkEntity) ([]*protos.DataUpdate, error) {
 	subProtos := make([]*protos2.SubscriberData, 0, len(subEnts))
 	for _, sub := range subEnts {
-		subProto := &protos2.SubscriberData{}
-		subProto, err = subscriberToMconfig(sub)
+		subProto, err := subscriberToMconfig(sub)
 		if err != nil {
 			return nil, err
 		}
-		subProto.NetworkId = &protos.NetworkID{Id: ent.NetworkID}
+		subProto.NetworkId = &protos.NetworkID{Id: networkID}
 		subProtos = append(subProtos, subProto)
 	}
 	return subscribersToUpdates(subProtos)
@@ -21,6 +21,7 @@ import (
 	orcprotos "magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/configurator"
 	cfg_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/streamer/providers"
 	"magma/orc8r/cloud/go/storage"
 
 	"github.com/golang/protobuf/proto"
@@ -114,3 +115,79 @@ func TestSubscriberdbStreamer(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestSubscriberdbStreamer_Delta(t *testing.T) {
+	cfg_test_init.StartTestService(t)
+	_ = plugin.RegisterPluginForTests(t, &plugin2.LteOrchestratorPlugin{})
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: "g1", PhysicalID: "hw1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities("n1", []configurator.NetworkEntity{
+		{Type: lte.SubscriberEntityType, Key: "IMSI12345", Config: &models2.LteSubscription{State: "ACTIVE"}},
+		{Type: lte.SubscriberEntityType, Key: "IMSI67890", Config: &models2.LteSubscription{State: "ACTIVE"}},
+	})
+	assert.NoError(t, err)
+
+	pro := &sdbstreamer.SubscribersProvider{}
+	version, err := pro.GetVersion("hw1", nil)
+	assert.NoError(t, err)
+
+	// Nothing changed
+	actual, err := pro.GetUpdatesSince("hw1", version, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &providers.Delta{Version: version, Updates: []*orcprotos.DataUpdate{}, DeletedKeys: []string{}}, actual)
+
+	// Update 1 sub, delete the other, and create a new one
+	_, err = configurator.UpdateEntity("n1", configurator.EntityUpdateCriteria{
+		Type: lte.SubscriberEntityType, Key: "IMSI12345",
+		NewConfig: &models2.LteSubscription{State: "INACTIVE"},
+	})
+	assert.NoError(t, err)
+	err = configurator.DeleteEntity("n1", lte.SubscriberEntityType, "IMSI67890")
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: lte.SubscriberEntityType, Key: "IMSI11111", Config: &models2.LteSubscription{State: "ACTIVE"}})
+	assert.NoError(t, err)
+
+	expectedProtos := []*protos.SubscriberData{
+		{
+			Sid:        &protos.SubscriberID{Id: "11111", Type: protos.SubscriberID_IMSI},
+			Lte:        &protos.LTESubscription{State: protos.LTESubscription_ACTIVE},
+			NetworkId:  &orcprotos.NetworkID{Id: "n1"},
+			SubProfile: "default",
+		},
+		{
+			Sid:        &protos.SubscriberID{Id: "12345", Type: protos.SubscriberID_IMSI},
+			Lte:        &protos.LTESubscription{State: protos.LTESubscription_INACTIVE},
+			NetworkId:  &orcprotos.NetworkID{Id: "n1"},
+			SubProfile: "default",
+		},
+	}
+	expectedUpdates := funk.Map(
+		expectedProtos,
+		func(sub *protos.SubscriberData) *orcprotos.DataUpdate {
+			data, err := proto.Marshal(sub)
+			assert.NoError(t, err)
+			return &orcprotos.DataUpdate{Key: "IMSI" + sub.Sid.Id, Value: data}
+		},
+	).([]*orcprotos.DataUpdate)
+	actual, err = pro.GetUpdatesSince("hw1", version, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, version+3, actual.Version)
+	assert.Equal(t, expectedUpdates, actual.Updates)
+	assert.Equal(t, []string{"IMSI67890"}, actual.DeletedKeys)
+
+	// Versions from the future can't be diffed against
+	_, err = pro.GetUpdatesSince("hw1", version+100, nil)
+	assert.Equal(t, providers.ErrVersionTooOld, err)
+
+	// Changes to assigned policies require a full sync
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{
+		Type: lte.PolicyRuleEntityType, Key: "r1",
+		Associations: []storage.TypeAndKey{{Type: lte.SubscriberEntityType, Key: "IMSI12345"}},
+	})
+	assert.NoError(t, err)
+	_, err = pro.GetUpdatesSince("hw1", version, nil)
+	assert.Equal(t, providers.ErrVersionTooOld, err)
+}
@@ -28,6 +28,9 @@ class PolicyDBStreamerCallback(StreamerClient.Callback):
     def get_request_args(self, stream_name: str) -> Any:
         return None
 
+    def supports_deltas(self) -> bool:
+        return True
+
     def process_update(self, stream_name, updates, resync):
         logging.info("Processing %d policy updates (resync=%s)",
                      len(updates), resync)
@@ -40,9 +43,19 @@ class PolicyDBStreamerCallback(StreamerClient.Callback):
                 policy_ids.add(policy.id)
             logging.debug("Resync with policies: %s", ','.join(policy_ids))
             self._remove_old_policies(policy_ids)
-            self._policy_dict.send_update_notification()
         else:
-            pass
+            for update in updates:
+                policy = PolicyRule()
+                policy.ParseFromString(update.value)
+                self._store_policy_rule(policy)
+        self._policy_dict.send_update_notification()
+
+    def process_deletes(self, stream_name, deleted_keys):
+        logging.info("Processing %d policy deletes", len(deleted_keys))
+        for rule_id in deleted_keys:
+            if rule_id in self._policy_dict:
+                del self._policy_dict[rule_id]
+        self._policy_dict.send_update_notification()
 
     def _store_policy_rule(self, policy):
         self._policy_dict[policy.id] = policy
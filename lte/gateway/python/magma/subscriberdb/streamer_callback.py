@@ -15,6 +15,8 @@ from lte.protos.s6a_service_pb2_grpc import S6aServiceStub
 from lte.protos.subscriberdb_pb2 import SubscriberData, LTESubscription
 from magma.common.service_registry import ServiceRegistry
 from magma.common.streamer import StreamerClient
+from magma.subscriberdb.sid import SIDUtils
+from magma.subscriberdb.store.base import SubscriberNotFoundError
 
 
 class SubscriberDBStreamerCallback(StreamerClient.Callback):
@@ -29,6 +31,9 @@ class SubscriberDBStreamerCallback(StreamerClient.Callback):
     def get_request_args(self, stream_name: str) -> Any:
         return None
 
+    def supports_deltas(self) -> bool:
+        return True
+
     def process_update(self, stream_name, updates, resync):
         """
         The cloud streams ALL subscribers registered, both active and inactive.
@@ -37,6 +42,9 @@ class SubscriberDBStreamerCallback(StreamerClient.Callback):
         subscribers to keep trying to delete inactive subscribers.
         TODO we can optimize a bit on the MME side to not detach already
         detached subscribers.
+
+        Delta updates only contain the subscribers which changed, those
+        which became inactive are detached.
         """
         logging.info("Processing %d subscriber updates (resync=%s)",
                      len(updates), resync)
@@ -61,8 +69,33 @@ class SubscriberDBStreamerCallback(StreamerClient.Callback):
             logging.debug("Resync with subscribers: %s", ','.join(keys))
             self._store.resync(subscribers)
         else:
-            # TODO: implement updates
-            pass
+            inactive_subscriber_ids = []
+            for update in updates:
+                sub = SubscriberData()
+                sub.ParseFromString(update.value)
+                self._upsert_subscriber(sub)
+                if sub.lte.state != LTESubscription.ACTIVE:
+                    inactive_subscriber_ids.append(update.key)
+            self.detach_subscribers(inactive_subscriber_ids)
+
+    def process_deletes(self, stream_name, deleted_keys):
+        logging.info("Processing %d subscriber deletes", len(deleted_keys))
+        for sub_id in deleted_keys:
+            self._store.delete_subscriber(sub_id)
+        self.detach_subscribers(deleted_keys)
+
+    def _upsert_subscriber(self, sub):
+        """
+        Adds the subscriber or replaces its data, keeping the current state
+        of existing subscribers like resync does.
+        """
+        sid = SIDUtils.to_str(sub.sid)
+        try:
+            with self._store.edit_subscriber(sid) as subscriber_data:
+                sub.state.CopyFrom(subscriber_data.state)
+                subscriber_data.CopyFrom(sub)
+        except SubscriberNotFoundError:
+            self._store.add_subscriber(sub)
 
     def detach_deleted_subscribers(self, old_sub_ids, new_sub_ids):
         """
@@ -90,16 +123,24 @@ class SubscriberDBStreamerCallback(StreamerClient.Callback):
         )
         deleted_sub_ids = [sub_id for sub_id in old_sub_ids
                            if sub_id not in set(new_sub_ids)]
-        if len(deleted_sub_ids) == 0:
+        self.detach_subscribers(deleted_sub_ids)
+
+    def detach_subscribers(self, sub_ids):
+        """
+        Sends a grpc DeleteSubscriber request to mme to detach the
+        subscribers.
+        :param sub_ids: a list of subscriber ids with 'IMSI' prepended
+        :return: n/a
+        """
+        if len(sub_ids) == 0:
             return
-        # send detach request to mme for all deleted subscribers.
         chan = ServiceRegistry.get_rpc_channel('s6a_service',
                                                ServiceRegistry.LOCAL)
         client = S6aServiceStub(chan)
         req = DeleteSubscriberRequest()
 
         # mme expects a list of IMSIs without "IMSI" prefix
-        imsis_to_delete_without_prefix = [sub[4:] for sub in deleted_sub_ids]
+        imsis_to_delete_without_prefix = [sub[4:] for sub in sub_ids]
 
         req.imsi_list.extend(imsis_to_delete_without_prefix)
         future = client.DeleteSubscriber.future(req)
@@ -14,6 +14,10 @@ import unittest
 import unittest.mock
 
 from lte.protos.s6a_service_pb2 import DeleteSubscriberRequest
+from lte.protos.subscriberdb_pb2 import LTESubscription, SubscriberData
+from orc8r.protos.streamer_pb2 import DataUpdate
+from magma.subscriberdb.sid import SIDUtils
+from magma.subscriberdb.store.base import SubscriberNotFoundError
 from magma.subscriberdb.store.sqlite import SqliteStore
 from magma.subscriberdb.streamer_callback import SubscriberDBStreamerCallback
 
@@ -83,6 +87,53 @@ class SubscriberDBStreamerCallbackTests(unittest.TestCase):
         mock.DeleteSubscriber.future.assert_called_once_with(
             DeleteSubscriberRequest(imsi_list=["101", "303"]))
 
+    @unittest.mock.patch('magma.subscriberdb.streamer_callback.S6aServiceStub')
+    def test_process_delta(self, s6a_service_mock_stub):
+        """
+        Test that delta updates upsert subscribers keeping their state, and
+        that deleted subscribers are removed and detached.
+        """
+        mock = unittest.mock.Mock()
+        mock.DeleteSubscriber.future.side_effect = [unittest.mock.Mock(),
+                                                    unittest.mock.Mock()]
+        s6a_service_mock_stub.side_effect = [mock, mock]
+
+        store = self._streamer_callback._store
+        existing = SubscriberData(sid=SIDUtils.to_pb('IMSI101'))
+        existing.state.lte_auth_next_seq = 5
+        store.add_subscriber(existing)
+
+        updates = [
+            DataUpdate(
+                key='IMSI101',
+                value=self._subscriber('IMSI101', LTESubscription.ACTIVE),
+            ),
+            DataUpdate(
+                key='IMSI202',
+                value=self._subscriber('IMSI202', LTESubscription.INACTIVE),
+            ),
+        ]
+        self._streamer_callback.process_update('subscriberdb', updates, False)
+        sub = store.get_subscriber_data('IMSI101')
+        self.assertEqual(LTESubscription.ACTIVE, sub.lte.state)
+        self.assertEqual(5, sub.state.lte_auth_next_seq)
+        self.assertEqual(['IMSI101', 'IMSI202'],
+                         sorted(store.list_subscribers()))
+        mock.DeleteSubscriber.future.assert_called_once_with(
+            DeleteSubscriberRequest(imsi_list=["202"]))
+
+        self._streamer_callback.process_deletes('subscriberdb', ['IMSI101'])
+        with self.assertRaises(SubscriberNotFoundError):
+            store.get_subscriber_data('IMSI101')
+        mock.DeleteSubscriber.future.assert_called_with(
+            DeleteSubscriberRequest(imsi_list=["101"]))
+
+    @staticmethod
+    def _subscriber(sub_id, state):
+        sub = SubscriberData(sid=SIDUtils.to_pb(sub_id))
+        sub.lte.state = state
+        return sub.SerializeToString()
+
 
 if __name__ == "__main__":
     unittest.main()
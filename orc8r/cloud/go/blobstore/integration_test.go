@@ -239,4 +239,91 @@ func integration(t *testing.T, fact blobstore.BlobStorageFactory) {
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, []blobstore.Blob{{Type: "t3", Key: "k3", Value: []byte("v5"), Version: 2}}, getManyActual)
+	assert.NoError(t, store.Commit())
+
+	// Search
+	store, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	err = store.CreateOrUpdate("network3", []blobstore.Blob{
+		{Type: "t1", Key: "foo1", Value: []byte("v1")},
+		{Type: "t1", Key: "foo2", Value: []byte("v2")},
+		{Type: "t1", Key: "FOO3", Value: []byte("v3")},
+		{Type: "t1", Key: "bar", Value: []byte("v4")},
+		{Type: "t2", Key: "foo1", Value: []byte("v5")},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	store, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	searchActual, err := store.Search("network3", blobstore.SearchCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{
+		{Type: "t1", Key: "FOO3", Value: []byte("v3")},
+		{Type: "t1", Key: "bar", Value: []byte("v4")},
+		{Type: "t1", Key: "foo1", Value: []byte("v1")},
+		{Type: "t1", Key: "foo2", Value: []byte("v2")},
+		{Type: "t2", Key: "foo1", Value: []byte("v5")},
+	}, searchActual)
+
+	searchActual, err = store.Search("network3", blobstore.SearchCriteria{Types: []string{"t1"}, KeyPrefix: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{
+		{Type: "t1", Key: "foo1", Value: []byte("v1")},
+		{Type: "t1", Key: "foo2", Value: []byte("v2")},
+	}, searchActual)
+
+	// Paginate
+	searchActual, err = store.Search("network3", blobstore.SearchCriteria{KeyPrefix: "foo", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{
+		{Type: "t1", Key: "foo1", Value: []byte("v1")},
+		{Type: "t1", Key: "foo2", Value: []byte("v2")},
+	}, searchActual)
+	searchActual, err = store.Search("network3", blobstore.SearchCriteria{KeyPrefix: "foo", Limit: 2, After: &storage.TypeAndKey{Type: "t1", Key: "foo2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{{Type: "t2", Key: "foo1", Value: []byte("v5")}}, searchActual)
+
+	// Uncommitted changes are visible within the tx
+	err = store.Delete("network3", []storage.TypeAndKey{{Type: "t1", Key: "foo1"}})
+	assert.NoError(t, err)
+	err = store.CreateOrUpdate("network3", []blobstore.Blob{{Type: "t2", Key: "foo0", Value: []byte("v6")}})
+	assert.NoError(t, err)
+	searchActual, err = store.Search("network3", blobstore.SearchCriteria{KeyPrefix: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{
+		{Type: "t1", Key: "foo2", Value: []byte("v2")},
+		{Type: "t2", Key: "foo0", Value: []byte("v6")},
+		{Type: "t2", Key: "foo1", Value: []byte("v5")},
+	}, searchActual)
+
+	searchActual, err = store.Search("network4", blobstore.SearchCriteria{})
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{}, searchActual)
+	assert.NoError(t, store.Commit())
+
+	// List networks
+	store, err = fact.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	networksActual, err := store.ListNetworkIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"network1", "network2", "network3"}, networksActual)
+	assert.NoError(t, store.Commit())
+
+	// Delete unchanged
+	store, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	err = store.DeleteUnchanged("network3", []blobstore.Blob{
+		{Type: "t1", Key: "foo2", Value: []byte("v2")},
+		{Type: "t2", Key: "foo0", Value: []byte("v6"), Version: 1},
+		{Type: "t2", Key: "foo1", Value: []byte("v0")},
+	})
+	assert.NoError(t, err)
+	searchActual, err = store.Search("network3", blobstore.SearchCriteria{KeyPrefix: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []blobstore.Blob{
+		{Type: "t2", Key: "foo0", Value: []byte("v6")},
+		{Type: "t2", Key: "foo1", Value: []byte("v5")},
+	}, searchActual)
+	assert.NoError(t, store.Commit())
 }
@@ -9,9 +9,11 @@
 package blobstore
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 
 	magmaerrors "magma/orc8r/cloud/go/errors"
@@ -31,6 +33,9 @@ const (
 type change struct {
 	cType changeType
 	blob  Blob
+	// expected is set on deletes which only apply if the stored blob is
+	// still the expected one
+	expected *Blob
 }
 
 type changesByID map[storage.TypeAndKey]change
@@ -118,6 +123,44 @@ func (store *memoryBlobStorage) Rollback() error {
 	return nil
 }
 
+func (store *memoryBlobStorage) ListNetworkIDs() ([]string, error) {
+	store.RLock()
+	defer store.RUnlock()
+
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	networkIDs := map[string]struct{}{}
+	store.shared.RLock()
+	for networkID := range store.shared.table {
+		networkIDs[networkID] = struct{}{}
+	}
+	store.shared.RUnlock()
+	for networkID := range store.changes {
+		networkIDs[networkID] = struct{}{}
+	}
+
+	ret := []string{}
+	for networkID := range networkIDs {
+		store.shared.RLock()
+		blobs := blobsByID{}
+		for id, blob := range store.shared.table[networkID] {
+			blobs[id] = blob
+		}
+		store.shared.RUnlock()
+		blobList, err := store.updateBlobsWithLocalChangesUnsafe(networkID, funk.Keys(store.changes[networkID]).([]storage.TypeAndKey), blobs)
+		if err != nil {
+			return nil, err
+		}
+		if len(blobList) > 0 {
+			ret = append(ret, networkID)
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
 // ListKeys grabs keys from the shared map first, and then updates the keys
 // with changes from the ongoing transaction
 func (store *memoryBlobStorage) ListKeys(networkID string, typeVal string) ([]string, error) {
@@ -214,6 +257,82 @@ func (store *memoryBlobStorage) Delete(networkID string, ids []storage.TypeAndKe
 	return nil
 }
 
+func (store *memoryBlobStorage) DeleteUnchanged(networkID string, blobs []Blob) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+
+	ids := blobsToIDs(blobs)
+	store.shared.RLock()
+	sharedBlobs := store.getManyFromShared(networkID, ids)
+	store.shared.RUnlock()
+	existingBlobs, err := store.updateBlobsWithLocalChangesUnsafe(networkID, ids, sharedBlobs)
+	if err != nil {
+		return err
+	}
+	existingBlobsByID := blobsByID{}
+	for _, blob := range existingBlobs {
+		existingBlobsByID[blob.toID()] = blob
+	}
+
+	store.changes.initializeNetworkTable(networkID)
+	for _, blob := range blobs {
+		if !isUnchanged(existingBlobsByID, blob) {
+			continue
+		}
+		// Blobs written in this transaction are deleted unconditionally,
+		// others only if they're still unchanged on commit
+		id := blob.toID()
+		if _, isLocal := store.changes[networkID][id]; isLocal {
+			store.changes[networkID][id] = change{cType: Delete}
+			continue
+		}
+		expected := blob
+		store.changes[networkID][id] = change{cType: Delete, expected: &expected}
+	}
+	return nil
+}
+
+func (store *memoryBlobStorage) Search(networkID string, criteria SearchCriteria) ([]Blob, error) {
+	store.RLock()
+	defer store.RUnlock()
+
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	store.shared.RLock()
+	blobs := blobsByID{}
+	for id, blob := range store.shared.table[networkID] {
+		blobs[id] = blob
+	}
+	store.shared.RUnlock()
+	blobList, err := store.updateBlobsWithLocalChangesUnsafe(networkID, funk.Keys(store.changes[networkID]).([]storage.TypeAndKey), blobs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(blobList, func(i, j int) bool {
+		if blobList[i].Type != blobList[j].Type {
+			return blobList[i].Type < blobList[j].Type
+		}
+		return blobList[i].Key < blobList[j].Key
+	})
+
+	ret := []Blob{}
+	for _, blob := range blobList {
+		if criteria.Limit > 0 && uint64(len(ret)) >= criteria.Limit {
+			break
+		}
+		if matchesSearchCriteria(blob, criteria) {
+			ret = append(ret, blob)
+		}
+	}
+	return ret, nil
+}
+
 func (store *memoryBlobStorage) GetExistingKeys(keys []string, filter SearchFilter) ([]string, error) {
 	store.Lock()
 	defer store.Unlock()
@@ -352,6 +471,9 @@ func (store *memoryBlobStorage) applyChangesToShared() error {
 		for id, change := range perNetworkChangeMap {
 			switch change.cType {
 			case Delete:
+				if change.expected != nil && !isUnchanged(fact[networkID], *change.expected) {
+					continue
+				}
 				delete(fact[networkID], id)
 			case CreateOrUpdate:
 				fact.initializeNetworkTable(networkID)
@@ -364,6 +486,13 @@ func (store *memoryBlobStorage) applyChangesToShared() error {
 	return nil
 }
 
+// isUnchanged returns true if the blobs contain the expected blob with its
+// expected version and value
+func isUnchanged(blobs blobsByID, expected Blob) bool {
+	blob, exists := blobs[expected.toID()]
+	return exists && blob.Version == expected.Version && bytes.Equal(blob.Value, expected.Value)
+}
+
 // Must be called with write lock on change map.
 func (store *memoryBlobStorage) resetTransaction() {
 	store.transactionExists = false
@@ -474,6 +603,21 @@ func (table transactionTable) initializeNetworkTable(networkID tNetworkID) {
 	}
 }
 
+func matchesSearchCriteria(blob Blob, criteria SearchCriteria) bool {
+	if len(criteria.Types) > 0 && !funk.ContainsString(criteria.Types, blob.Type) {
+		return false
+	}
+	if !strings.HasPrefix(blob.Key, criteria.KeyPrefix) {
+		return false
+	}
+	if criteria.After != nil {
+		if blob.Type < criteria.After.Type || (blob.Type == criteria.After.Type && blob.Key <= criteria.After.Key) {
+			return false
+		}
+	}
+	return true
+}
+
 func (blob *Blob) toID() storage.TypeAndKey {
 	return storage.TypeAndKey{Type: blob.Type, Key: blob.Key}
 }
@@ -61,6 +61,20 @@ func (_m *TransactionalBlobStorage) Delete(networkID string, ids []storage.TypeA
 	return r0
 }
 
+// DeleteUnchanged provides a mock function with given fields: networkID, blobs
+func (_m *TransactionalBlobStorage) DeleteUnchanged(networkID string, blobs []blobstore.Blob) error {
+	ret := _m.Called(networkID, blobs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []blobstore.Blob) error); ok {
+		r0 = rf(networkID, blobs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: networkID, id
 func (_m *TransactionalBlobStorage) Get(networkID string, id storage.TypeAndKey) (blobstore.Blob, error) {
 	ret := _m.Called(networkID, id)
@@ -128,6 +142,29 @@ func (_m *TransactionalBlobStorage) ListKeys(networkID string, typeVal string) (
 	return r0, r1
 }
 
+// ListNetworkIDs provides a mock function with given fields:
+func (_m *TransactionalBlobStorage) ListNetworkIDs() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Rollback provides a mock function with given fields:
 func (_m *TransactionalBlobStorage) Rollback() error {
 	ret := _m.Called()
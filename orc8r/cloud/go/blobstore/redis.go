@@ -0,0 +1,652 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package blobstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	magmaerrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/go-redis/redis"
+	"github.com/thoas/go-funk"
+)
+
+// ErrTransactionConflict is returned by a redis-backed blob storage when a
+// transaction conflicts with a concurrently committed transaction. The
+// transaction has been rolled back and can be retried.
+var ErrTransactionConflict = errors.New("transaction conflicts with a concurrent transaction")
+
+// Redis data model:
+//	<table>:networks		set of network IDs with blobs
+//	<table>:blobs:<network_id>	hash of encoded (type, key) to encoded (version, value)
+//	<table>:rev:<network_id>	counter incremented on every commit to the network
+//
+// Transactions are optimistic. Writes are buffered locally until commit.
+// Reads of specific blobs record the encoded value of each blob read, and
+// reads of whole networks (listings and searches) record the revision of the
+// network. On commit, the keys holding what was read are WATCHed and checked
+// before the writes are applied in a MULTI/EXEC block, so a transaction fails
+// with ErrTransactionConflict if any blob it read, or any network it listed,
+// was modified after the read. Transactions which write different blobs of
+// the same network don't conflict.
+
+// maxCommitAttempts bounds the number of times a commit is retried when a
+// WATCHed key is modified between the checks and the writes of a commit,
+// e.g. by a concurrent write to another blob of the same network.
+const maxCommitAttempts = 5
+
+type redisBlobStoreFactory struct {
+	tableName string
+	client    *redis.Client
+}
+
+type redisBlobStorage struct {
+	tableName string
+	client    *redis.Client
+
+	// transactionExists indicates whether the transaction is still open
+	transactionExists bool
+	// changes stores changes during a transaction
+	changes transactionTable
+	// reads stores the encoded value of each blob read during the
+	// transaction, or nil for blobs which didn't exist
+	reads map[tNetworkID]map[storage.TypeAndKey]interface{}
+	// revisions stores the revision of each network listed during the
+	// transaction
+	revisions map[tNetworkID]int64
+}
+
+// NewRedisBlobStorageFactory returns a BlobStorageFactory implementation
+// which will return storage APIs backed by the given redis client, with all
+// keys namespaced under tableName.
+func NewRedisBlobStorageFactory(tableName string, client *redis.Client) BlobStorageFactory {
+	return &redisBlobStoreFactory{tableName: tableName, client: client}
+}
+
+func (fact *redisBlobStoreFactory) InitializeFactory() error {
+	return fact.client.Ping().Err()
+}
+
+func (fact *redisBlobStoreFactory) StartTransaction(opts *storage.TxOptions) (TransactionalBlobStorage, error) {
+	return &redisBlobStorage{
+		tableName:         fact.tableName,
+		client:            fact.client,
+		transactionExists: true,
+		changes:           transactionTable{},
+		reads:             map[tNetworkID]map[storage.TypeAndKey]interface{}{},
+		revisions:         map[tNetworkID]int64{},
+	}, nil
+}
+
+func (store *redisBlobStorage) Commit() error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+	defer store.resetTransaction()
+	if len(store.changes) == 0 {
+		return nil
+	}
+
+	watchedKeys := make([]string, 0, len(store.reads)+len(store.revisions))
+	for networkID := range store.reads {
+		watchedKeys = append(watchedKeys, store.blobsKey(networkID))
+	}
+	for networkID := range store.revisions {
+		watchedKeys = append(watchedKeys, store.revKey(networkID))
+	}
+	sort.Strings(watchedKeys)
+
+	for attempt := 0; attempt < maxCommitAttempts; attempt++ {
+		err := store.client.Watch(store.commitWatched, watchedKeys...)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return ErrTransactionConflict
+}
+
+// commitWatched checks that nothing the transaction read has changed, then
+// applies the transaction's changes. The keys holding what was read must be
+// WATCHed by tx.
+func (store *redisBlobStorage) commitWatched(tx *redis.Tx) error {
+	for networkID, reads := range store.reads {
+		ids := make([]storage.TypeAndKey, 0, len(reads))
+		fields := make([]string, 0, len(reads))
+		for id := range reads {
+			ids = append(ids, id)
+			fields = append(fields, encodeBlobField(id))
+		}
+		currentValues, err := tx.HMGet(store.blobsKey(networkID), fields...).Result()
+		if err != nil {
+			return err
+		}
+		for i, id := range ids {
+			if currentValues[i] != reads[id] {
+				return ErrTransactionConflict
+			}
+		}
+	}
+	listedNetworkIDs := funk.Keys(store.revisions).([]string)
+	if len(listedNetworkIDs) > 0 {
+		revKeys := make([]string, 0, len(listedNetworkIDs))
+		for _, networkID := range listedNetworkIDs {
+			revKeys = append(revKeys, store.revKey(networkID))
+		}
+		currentRevs, err := tx.MGet(revKeys...).Result()
+		if err != nil {
+			return err
+		}
+		for i, networkID := range listedNetworkIDs {
+			currentRev, err := parseRevision(currentRevs[i])
+			if err != nil {
+				return err
+			}
+			if currentRev != store.revisions[networkID] {
+				return ErrTransactionConflict
+			}
+		}
+	}
+
+	_, err := tx.Pipelined(func(pipe redis.Pipeliner) error {
+		for networkID, changes := range store.changes {
+			for id, change := range changes {
+				switch change.cType {
+				case Delete:
+					pipe.HDel(store.blobsKey(networkID), encodeBlobField(id))
+				case CreateOrUpdate:
+					pipe.HSet(store.blobsKey(networkID), encodeBlobField(id), encodeBlobValue(change.blob))
+				default:
+					return fmt.Errorf("This transaction contains ill-formatted changes.")
+				}
+			}
+			pipe.SAdd(store.networksKey(), networkID)
+			pipe.Incr(store.revKey(networkID))
+		}
+		return nil
+	})
+	return err
+}
+
+func (store *redisBlobStorage) Rollback() error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+	store.resetTransaction()
+	return nil
+}
+
+func (store *redisBlobStorage) ListNetworkIDs() ([]string, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	// The networks set isn't pruned when a network's last blob is deleted
+	candidateIDs, err := store.listNetworkIDs()
+	if err != nil {
+		return nil, err
+	}
+	lenCmds := map[string]*redis.IntCmd{}
+	_, err = store.client.Pipelined(func(pipe redis.Pipeliner) error {
+		for _, networkID := range candidateIDs {
+			lenCmds[networkID] = pipe.HLen(store.blobsKey(networkID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []string{}
+	for _, networkID := range candidateIDs {
+		// Only networks with local changes need their blobs loaded
+		if _, changed := store.changes[networkID]; changed {
+			blobs, err := store.getAll(networkID)
+			if err != nil {
+				return nil, err
+			}
+			if len(blobs) > 0 {
+				ret = append(ret, networkID)
+			}
+		} else if lenCmds[networkID].Val() > 0 {
+			ret = append(ret, networkID)
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+func (store *redisBlobStorage) ListKeys(networkID string, typeVal string) ([]string, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	blobs, err := store.getAll(networkID)
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for id := range blobs {
+		if id.Type == typeVal {
+			keys = append(keys, id.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (store *redisBlobStorage) Get(networkID string, id storage.TypeAndKey) (Blob, error) {
+	multiRet, err := store.GetMany(networkID, []storage.TypeAndKey{id})
+	if err != nil {
+		return Blob{}, err
+	}
+	if len(multiRet) == 0 {
+		return Blob{}, magmaerrors.ErrNotFound
+	}
+	return multiRet[0], nil
+}
+
+func (store *redisBlobStorage) GetMany(networkID string, ids []storage.TypeAndKey) ([]Blob, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	blobs, err := store.getMany(networkID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return blobs.toBlobList(), nil
+}
+
+func (store *redisBlobStorage) CreateOrUpdate(networkID string, blobs []Blob) error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+
+	existingBlobs, err := store.getMany(networkID, blobsToIDs(blobs))
+	if err != nil {
+		return err
+	}
+
+	store.changes.initializeNetworkTable(networkID)
+	for _, blob := range blobs {
+		// increment version if it isn't set in the update
+		existingBlob, exists := existingBlobs[blob.toID()]
+		if exists && blob.Version == 0 {
+			blob.Version = existingBlob.Version + 1
+		}
+		store.changes[networkID][blob.toID()] = change{cType: CreateOrUpdate, blob: blob}
+		existingBlobs[blob.toID()] = blob
+	}
+	return nil
+}
+
+func (store *redisBlobStorage) GetExistingKeys(keys []string, filter SearchFilter) ([]string, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	var networkIDs []string
+	if funk.NotEmpty(filter.NetworkID) {
+		networkIDs = []string{*filter.NetworkID}
+	} else {
+		var err error
+		networkIDs, err = store.listNetworkIDs()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keySet := funk.Map(keys, func(k string) (string, interface{}) { return k, nil }).(map[string]interface{})
+	existingKeys := map[string]struct{}{}
+	for _, networkID := range networkIDs {
+		blobs, err := store.getAll(networkID)
+		if err != nil {
+			return nil, err
+		}
+		for id := range blobs {
+			if _, exists := keySet[id.Key]; exists {
+				existingKeys[id.Key] = struct{}{}
+			}
+		}
+	}
+	return fromKeySet(existingKeys), nil
+}
+
+func (store *redisBlobStorage) Search(networkID string, criteria SearchCriteria) ([]Blob, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	blobs, err := store.getAll(networkID)
+	if err != nil {
+		return nil, err
+	}
+	blobList := blobs.toBlobList()
+	sort.Slice(blobList, func(i, j int) bool {
+		if blobList[i].Type != blobList[j].Type {
+			return blobList[i].Type < blobList[j].Type
+		}
+		return blobList[i].Key < blobList[j].Key
+	})
+
+	ret := []Blob{}
+	for _, blob := range blobList {
+		if criteria.Limit > 0 && uint64(len(ret)) >= criteria.Limit {
+			break
+		}
+		if matchesSearchCriteria(blob, criteria) {
+			ret = append(ret, blob)
+		}
+	}
+	return ret, nil
+}
+
+func (store *redisBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+
+	store.changes.initializeNetworkTable(networkID)
+	for _, id := range ids {
+		store.changes[networkID][id] = change{cType: Delete}
+	}
+	return nil
+}
+
+func (store *redisBlobStorage) DeleteUnchanged(networkID string, blobs []Blob) error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+
+	// Reading the blobs makes the commit fail if they change before it, so
+	// the blobs which are unchanged now can be deleted unconditionally
+	existingBlobs, err := store.getMany(networkID, blobsToIDs(blobs))
+	if err != nil {
+		return err
+	}
+	store.changes.initializeNetworkTable(networkID)
+	for _, blob := range blobs {
+		if isUnchanged(existingBlobs, blob) {
+			store.changes[networkID][blob.toID()] = change{cType: Delete}
+		}
+	}
+	return nil
+}
+
+func (store *redisBlobStorage) IncrementVersion(networkID string, id storage.TypeAndKey) error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+
+	existingBlobs, err := store.getMany(networkID, []storage.TypeAndKey{id})
+	if err != nil {
+		return err
+	}
+	blob := Blob{Type: id.Type, Key: id.Key, Version: 1}
+	if existingBlob, exists := existingBlobs[id]; exists {
+		blob.Version = existingBlob.Version + 1
+		blob.Value = existingBlob.Value
+	}
+
+	store.changes.initializeNetworkTable(networkID)
+	store.changes[networkID][id] = change{cType: CreateOrUpdate, blob: blob}
+	return nil
+}
+
+func (store *redisBlobStorage) validateTx() error {
+	if !store.transactionExists {
+		return errors.New("No transaction is available")
+	}
+	return nil
+}
+
+func (store *redisBlobStorage) resetTransaction() {
+	store.transactionExists = false
+	store.changes = nil
+	store.reads = nil
+	store.revisions = nil
+}
+
+func (store *redisBlobStorage) listNetworkIDs() ([]string, error) {
+	networkIDs, err := store.client.SMembers(store.networksKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+	// Networks which only have uncommitted changes aren't in redis yet
+	for networkID := range store.changes {
+		if !funk.ContainsString(networkIDs, networkID) {
+			networkIDs = append(networkIDs, networkID)
+		}
+	}
+	return networkIDs, nil
+}
+
+// getMany loads the blobs matching the ids from redis, then applies the
+// transaction's local changes on top. The blobs' values are recorded for
+// conflict detection at commit.
+func (store *redisBlobStorage) getMany(networkID string, ids []storage.TypeAndKey) (blobsByID, error) {
+	ret := blobsByID{}
+	if len(ids) == 0 {
+		return ret, nil
+	}
+
+	fields := make([]string, 0, len(ids))
+	for _, id := range ids {
+		fields = append(fields, encodeBlobField(id))
+	}
+	var valuesCmd *redis.SliceCmd
+	readFn := func(pipe redis.Pipeliner) {
+		valuesCmd = pipe.HMGet(store.blobsKey(networkID), fields...)
+	}
+	// Reads from a network which was listed must also see the revision
+	// which was listed
+	var err error
+	if _, listed := store.revisions[networkID]; listed {
+		err = store.readNetwork(networkID, readFn)
+	} else {
+		_, err = store.client.Pipelined(func(pipe redis.Pipeliner) error {
+			readFn(pipe)
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range valuesCmd.Val() {
+		if err := store.recordRead(networkID, ids[i], value); err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		blob, err := decodeBlob(ids[i], value)
+		if err != nil {
+			return nil, err
+		}
+		ret[ids[i]] = blob
+	}
+	return ret, store.applyLocalChanges(networkID, ids, ret)
+}
+
+// getAll loads every blob in a network from redis, then applies the
+// transaction's local changes on top. The network's revision is recorded for
+// conflict detection at commit.
+func (store *redisBlobStorage) getAll(networkID string) (blobsByID, error) {
+	var valuesCmd *redis.StringStringMapCmd
+	err := store.readNetwork(networkID, func(pipe redis.Pipeliner) {
+		valuesCmd = pipe.HGetAll(store.blobsKey(networkID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := valuesCmd.Val()
+	// Blobs read earlier in the transaction must be unchanged
+	for id, readValue := range store.reads[networkID] {
+		value, exists := values[encodeBlobField(id)]
+		if exists != (readValue != nil) || (exists && value != readValue) {
+			return nil, ErrTransactionConflict
+		}
+	}
+
+	ret := blobsByID{}
+	for field, value := range values {
+		id, err := decodeBlobField(field)
+		if err != nil {
+			return nil, err
+		}
+		blob, err := decodeBlob(id, value)
+		if err != nil {
+			return nil, err
+		}
+		ret[id] = blob
+	}
+	return ret, store.applyLocalChanges(networkID, funk.Keys(store.changes[networkID]).([]storage.TypeAndKey), ret)
+}
+
+// recordRead records the encoded value of a blob read from redis, nil if
+// the blob doesn't exist. If the blob was read before with a different
+// value, the transaction can't be serialized and ErrTransactionConflict is
+// returned.
+func (store *redisBlobStorage) recordRead(networkID string, id storage.TypeAndKey, value interface{}) error {
+	networkReads, exists := store.reads[networkID]
+	if !exists {
+		networkReads = map[storage.TypeAndKey]interface{}{}
+		store.reads[networkID] = networkReads
+	}
+	if readValue, exists := networkReads[id]; exists && readValue != value {
+		return ErrTransactionConflict
+	}
+	networkReads[id] = value
+	return nil
+}
+
+// readNetwork reads a network's revision along with the commands queued by
+// readFn in a single MULTI/EXEC block, and records the revision for conflict
+// detection at commit. If the network was modified since the transaction
+// last read its revision, the transaction can't be serialized and
+// ErrTransactionConflict is returned.
+func (store *redisBlobStorage) readNetwork(networkID string, readFn func(pipe redis.Pipeliner)) error {
+	var revCmd *redis.StringCmd
+	_, err := store.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		revCmd = pipe.Get(store.revKey(networkID))
+		readFn(pipe)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	rev := int64(0)
+	if revCmd.Err() != redis.Nil {
+		rev, err = revCmd.Int64()
+		if err != nil {
+			return fmt.Errorf("failed to parse revision of network %s: %s", networkID, err)
+		}
+	}
+	if recordedRev, exists := store.revisions[networkID]; exists && recordedRev != rev {
+		return ErrTransactionConflict
+	}
+	store.revisions[networkID] = rev
+	return nil
+}
+
+// applyLocalChanges applies the transaction's changes to any of the given ids
+// onto blobs.
+// blobs is an output parameter.
+func (store *redisBlobStorage) applyLocalChanges(networkID string, ids []storage.TypeAndKey, blobs blobsByID) error {
+	networkChanges, exists := store.changes[networkID]
+	if !exists {
+		return nil
+	}
+	for _, id := range ids {
+		change, exists := networkChanges[id]
+		if !exists {
+			continue
+		}
+		switch change.cType {
+		case Delete:
+			delete(blobs, id)
+		case CreateOrUpdate:
+			blobs[id] = change.blob
+		default:
+			return fmt.Errorf("This transaction contains ill-formatted changes.")
+		}
+	}
+	return nil
+}
+
+func (store *redisBlobStorage) networksKey() string {
+	return fmt.Sprintf("%s:networks", store.tableName)
+}
+
+func (store *redisBlobStorage) blobsKey(networkID string) string {
+	return fmt.Sprintf("%s:blobs:%s", store.tableName, networkID)
+}
+
+func (store *redisBlobStorage) revKey(networkID string) string {
+	return fmt.Sprintf("%s:rev:%s", store.tableName, networkID)
+}
+
+// Blob hash fields are the length-prefixed type followed by the key, so that
+// neither may contain a reserved separator.
+func encodeBlobField(id storage.TypeAndKey) string {
+	return fmt.Sprintf("%d:%s%s", len(id.Type), id.Type, id.Key)
+}
+
+func decodeBlobField(field string) (storage.TypeAndKey, error) {
+	sep := strings.Index(field, ":")
+	if sep < 0 {
+		return storage.TypeAndKey{}, fmt.Errorf("malformed blob field %q", field)
+	}
+	typeLen, err := strconv.Atoi(field[:sep])
+	if err != nil || sep+1+typeLen > len(field) {
+		return storage.TypeAndKey{}, fmt.Errorf("malformed blob field %q", field)
+	}
+	rest := field[sep+1:]
+	return storage.TypeAndKey{Type: rest[:typeLen], Key: rest[typeLen:]}, nil
+}
+
+// Blob hash values are the big-endian version followed by the value.
+func encodeBlobValue(blob Blob) []byte {
+	ret := make([]byte, 8+len(blob.Value))
+	binary.BigEndian.PutUint64(ret, blob.Version)
+	copy(ret[8:], blob.Value)
+	return ret
+}
+
+func decodeBlob(id storage.TypeAndKey, encoded interface{}) (Blob, error) {
+	encodedStr, ok := encoded.(string)
+	if !ok || len(encodedStr) < 8 {
+		return Blob{}, fmt.Errorf("malformed value for blob (%s, %s)", id.Type, id.Key)
+	}
+	blob := Blob{Type: id.Type, Key: id.Key, Version: binary.BigEndian.Uint64([]byte(encodedStr[:8]))}
+	if len(encodedStr) > 8 {
+		blob.Value = []byte(encodedStr[8:])
+	}
+	return blob, nil
+}
+
+func parseRevision(rev interface{}) (int64, error) {
+	if rev == nil {
+		return 0, nil
+	}
+	revStr, ok := rev.(string)
+	if !ok {
+		return 0, fmt.Errorf("malformed revision %v", rev)
+	}
+	return strconv.ParseInt(revStr, 10, 64)
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package blobstore_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisBlobStorage_Integration(t *testing.T) {
+	fact, server := newTestRedisBlobStorageFactory(t)
+	defer server.Close()
+	integration(t, fact)
+}
+
+func TestRedisBlobStorage_Conflicts(t *testing.T) {
+	fact, server := newTestRedisBlobStorageFactory(t)
+	defer server.Close()
+	assert.NoError(t, fact.InitializeFactory())
+	tk := storage.TypeAndKey{Type: "t1", Key: "k1"}
+
+	store, err := fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store.CreateOrUpdate("network1", []blobstore.Blob{{Type: "t1", Key: "k1", Value: []byte("v1")}}))
+	assert.NoError(t, store.Commit())
+
+	// Two transactions read and increment the same blob, the second to
+	// commit fails
+	store1, err := fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	store2, err := fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store1.IncrementVersion("network1", tk))
+	assert.NoError(t, store2.IncrementVersion("network1", tk))
+	assert.NoError(t, store1.Commit())
+	assert.Equal(t, blobstore.ErrTransactionConflict, store2.Commit())
+
+	// A transaction whose reads are invalidated fails on its next read
+	store1, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	_, err = store1.Get("network1", tk)
+	assert.NoError(t, err)
+	store2, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store2.Delete("network1", []storage.TypeAndKey{tk}))
+	assert.NoError(t, store2.Commit())
+	_, err = store1.ListKeys("network1", "t1")
+	assert.Equal(t, blobstore.ErrTransactionConflict, err)
+	assert.NoError(t, store1.Rollback())
+
+	// Transactions on different networks don't conflict
+	store1, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	store2, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store1.IncrementVersion("network1", tk))
+	assert.NoError(t, store2.IncrementVersion("network2", tk))
+	assert.NoError(t, store2.Commit())
+	assert.NoError(t, store1.Commit())
+
+	store, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	blob, err := store.Get("network1", tk)
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.Blob{Type: "t1", Key: "k1", Version: 1}, blob)
+	blob, err = store.Get("network2", tk)
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.Blob{Type: "t1", Key: "k1", Version: 1}, blob)
+	assert.NoError(t, store.Commit())
+
+	// Transactions writing different blobs of a network don't conflict
+	tk2 := storage.TypeAndKey{Type: "t1", Key: "k2"}
+	store1, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	store2, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store1.IncrementVersion("network1", tk))
+	assert.NoError(t, store2.IncrementVersion("network1", tk2))
+	assert.NoError(t, store1.Commit())
+	assert.NoError(t, store2.Commit())
+
+	// A transaction which listed a network conflicts with any write to it
+	store1, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	keys, err := store1.ListKeys("network1", "t1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"k1", "k2"}, keys)
+	assert.NoError(t, store1.IncrementVersion("network1", tk))
+	store2, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store2.IncrementVersion("network1", tk2))
+	assert.NoError(t, store2.Commit())
+	assert.Equal(t, blobstore.ErrTransactionConflict, store1.Commit())
+
+	store, err = fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	blobs, err := store.GetMany("network1", []storage.TypeAndKey{tk, tk2})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []blobstore.Blob{{Type: "t1", Key: "k1", Version: 2}, {Type: "t1", Key: "k2", Version: 2}}, blobs)
+	assert.NoError(t, store.Commit())
+}
+
+// newTestRedisBlobStorageFactory returns a redis blob storage factory backed
+// by an in-process redis server. Callers should close the server.
+func newTestRedisBlobStorageFactory(t *testing.T) (blobstore.BlobStorageFactory, *miniredis.Miniredis) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Could not start in-process redis: %s", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	return blobstore.NewRedisBlobStorageFactory("network_table", client), server
+}
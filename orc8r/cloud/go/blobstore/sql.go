@@ -13,6 +13,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"unicode/utf8"
 
 	magmaerrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/sqorc"
@@ -118,6 +119,32 @@ func (store *sqlBlobStorage) Rollback() error {
 	return err
 }
 
+func (store *sqlBlobStorage) ListNetworkIDs() ([]string, error) {
+	ret := []string{}
+	if err := store.validateTx(); err != nil {
+		return ret, err
+	}
+
+	rows, err := store.builder.Select(nidCol).Distinct().From(store.tableName).
+		OrderBy(nidCol).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return ret, err
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "ListNetworkIDs")
+
+	for rows.Next() {
+		var networkID string
+		err = rows.Scan(&networkID)
+		if err != nil {
+			return []string{}, err
+		}
+		ret = append(ret, networkID)
+	}
+	return ret, nil
+}
+
 func (store *sqlBlobStorage) ListKeys(networkID string, typeVal string) ([]string, error) {
 	ret := []string{}
 	if err := store.validateTx(); err != nil {
@@ -244,6 +271,52 @@ func (store *sqlBlobStorage) GetExistingKeys(keys []string, filter SearchFilter)
 	return scannedKeys, nil
 }
 
+func (store *sqlBlobStorage) Search(networkID string, criteria SearchCriteria) ([]Blob, error) {
+	if err := store.validateTx(); err != nil {
+		return nil, err
+	}
+
+	whereConditions := sq.And{sq.Eq{nidCol: networkID}}
+	if len(criteria.Types) > 0 {
+		whereConditions = append(whereConditions, sq.Eq{typeCol: criteria.Types})
+	}
+	if criteria.KeyPrefix != "" {
+		// LIKE is case-insensitive in sqlite, so compare substrings instead
+		whereConditions = append(
+			whereConditions,
+			sq.Expr(fmt.Sprintf("SUBSTR(%s, 1, ?) = ?", keyCol), utf8.RuneCountInString(criteria.KeyPrefix), criteria.KeyPrefix),
+		)
+	}
+	if criteria.After != nil {
+		whereConditions = append(whereConditions, sq.Or{
+			sq.Gt{typeCol: criteria.After.Type},
+			sq.And{sq.Eq{typeCol: criteria.After.Type}, sq.Gt{keyCol: criteria.After.Key}},
+		})
+	}
+	selectBuilder := store.builder.Select(typeCol, keyCol, valCol, verCol).From(store.tableName).
+		Where(whereConditions).
+		OrderBy(typeCol, keyCol)
+	if criteria.Limit > 0 {
+		selectBuilder = selectBuilder.Limit(criteria.Limit)
+	}
+	rows, err := selectBuilder.RunWith(store.tx).Query()
+	if err != nil {
+		return nil, err
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "Search")
+
+	ret := []Blob{}
+	for rows.Next() {
+		blob := Blob{}
+		err = rows.Scan(&blob.Type, &blob.Key, &blob.Value, &blob.Version)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, blob)
+	}
+	return ret, nil
+}
+
 func (store *sqlBlobStorage) Delete(networkID string, ids []storage.TypeAndKey) error {
 	if err := store.validateTx(); err != nil {
 		return err
@@ -257,6 +330,40 @@ func (store *sqlBlobStorage) Delete(networkID string, ids []storage.TypeAndKey)
 	return err
 }
 
+func (store *sqlBlobStorage) DeleteUnchanged(networkID string, blobs []Blob) error {
+	if err := store.validateTx(); err != nil {
+		return err
+	}
+
+	// Each blob is deleted by its own statement so that a concurrent write
+	// to one of them only spares that blob
+	sc := sq.NewStmtCache(store.tx)
+	defer sqorc.ClearStatementCacheLogOnError(sc, "DeleteUnchanged")
+	for _, blob := range blobs {
+		// sq.Eq would expand a byte slice into an IN clause
+		var valueCondition sq.Sqlizer = sq.Expr(fmt.Sprintf("%s = ?", valCol), blob.Value)
+		if blob.Value == nil {
+			valueCondition = sq.Eq{valCol: nil}
+		}
+		_, err := store.builder.Delete(store.tableName).
+			Where(
+				sq.And{
+					sq.Eq{nidCol: networkID},
+					sq.Eq{typeCol: blob.Type},
+					sq.Eq{keyCol: blob.Key},
+					sq.Eq{verCol: blob.Version},
+					valueCondition,
+				},
+			).
+			RunWith(sc).
+			Exec()
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete blob (%s, %s, %s)", networkID, blob.Type, blob.Key)
+		}
+	}
+	return nil
+}
+
 func (store *sqlBlobStorage) IncrementVersion(networkID string, id storage.TypeAndKey) error {
 	if err := store.validateTx(); err != nil {
 		return err
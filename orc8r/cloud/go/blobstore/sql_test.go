@@ -381,6 +381,52 @@ func TestSqlBlobStorage_Delete(t *testing.T) {
 	runCase(t, queryError)
 }
 
+func TestSqlBlobStorage_Search(t *testing.T) {
+	happyPath := &testCase{
+		setup: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("SELECT type, \"key\", value, version FROM network_table "+
+				"WHERE \\(network_id = \\$1 AND type IN \\(\\$2,\\$3\\) AND SUBSTR\\(\"key\", 1, \\$4\\) = \\$5 "+
+				"AND \\(type > \\$6 OR \\(type = \\$7 AND \"key\" > \\$8\\)\\)\\) "+
+				"ORDER BY type, \"key\" LIMIT 2").
+				WithArgs("network", "t1", "t2", 3, "foo", "t1", "t1", "foo1").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"type", "key", "value", "version"}).
+						AddRow("t1", "foo2", []byte("v1"), 1).
+						AddRow("t2", "foo1", []byte("v2"), 2),
+				)
+		},
+		run: func(store blobstore.TransactionalBlobStorage) (interface{}, error) {
+			return store.Search("network", blobstore.SearchCriteria{
+				Types:     []string{"t1", "t2"},
+				KeyPrefix: "foo",
+				After:     &storage.TypeAndKey{Type: "t1", Key: "foo1"},
+				Limit:     2,
+			})
+		},
+		expectedError: nil,
+		expectedResult: []blobstore.Blob{
+			{Type: "t1", Key: "foo2", Value: []byte("v1"), Version: 1},
+			{Type: "t2", Key: "foo1", Value: []byte("v2"), Version: 2},
+		},
+	}
+
+	queryError := &testCase{
+		setup: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("SELECT type, \"key\", value, version FROM network_table").
+				WithArgs("network").
+				WillReturnError(errors.New("Mock query error"))
+		},
+		run: func(store blobstore.TransactionalBlobStorage) (interface{}, error) {
+			return store.Search("network", blobstore.SearchCriteria{})
+		},
+		expectedError:  errors.New("Mock query error"),
+		expectedResult: nil,
+	}
+
+	runCase(t, happyPath)
+	runCase(t, queryError)
+}
+
 func TestSqlBlobStorage_IncrementVersion(t *testing.T) {
 	happyPath := &testCase{
 		setup: func(mock sqlmock.Sqlmock) {
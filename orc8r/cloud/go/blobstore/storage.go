@@ -24,6 +24,20 @@ type SearchFilter struct {
 	NetworkID *string
 }
 
+// SearchCriteria specifies which blobs within a network to return from
+// Search. Empty fields match all blobs.
+type SearchCriteria struct {
+	// Types restricts results to blobs of the given types
+	Types []string
+	// KeyPrefix restricts results to blobs whose keys start with the prefix
+	KeyPrefix string
+	// After is an exclusive lower bound on the (type, key) of returned blobs,
+	// used to page through results
+	After *storage.TypeAndKey
+	// Limit is the max number of blobs to return. 0 means no limit.
+	Limit uint64
+}
+
 // BlobStorageFactory is an API to create a storage API bound to a transaction.
 type BlobStorageFactory interface {
 	InitializeFactory() error
@@ -45,6 +59,10 @@ type TransactionalBlobStorage interface {
 	// Rollback rolls back the existing transaction.
 	Rollback() error
 
+	// ListNetworkIDs returns the IDs of all networks which have blobs in
+	// storage.
+	ListNetworkIDs() ([]string, error)
+
 	// ListKeys returns all the blob keys stored for the network and type.
 	ListKeys(networkID string, typeVal string) ([]string, error)
 
@@ -69,9 +87,18 @@ type TransactionalBlobStorage interface {
 	// entire storage or just in a network.
 	GetExistingKeys(keys []string, filter SearchFilter) ([]string, error)
 
+	// Search returns the blobs in the network which match the criteria,
+	// ordered by type then key.
+	Search(networkID string, criteria SearchCriteria) ([]Blob, error)
+
 	// Delete deletes specified blobs from storage.
 	Delete(networkID string, ids []storage.TypeAndKey) error
 
+	// DeleteUnchanged deletes the given blobs from storage unless they have
+	// changed since they were read, i.e. unless the stored version or value
+	// of a blob differs from the given one. Changed blobs are left as is.
+	DeleteUnchanged(networkID string, blobs []Blob) error
+
 	// IncrementVersion is an atomic upsert (INSERT DO ON CONFLICT) that
 	// increments the version column or inserts 1 if it does not exist.
 	IncrementVersion(networkID string, id storage.TypeAndKey) error
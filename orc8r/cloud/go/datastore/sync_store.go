@@ -66,3 +66,9 @@ func (s *SyncStore) DeleteTable(table string) error {
 	defer s.lock.Unlock()
 	return s.store.DeleteTable(table)
 }
+
+func (s *SyncStore) DoesKeyExist(table string, key string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.store.DoesKeyExist(table, key)
+}
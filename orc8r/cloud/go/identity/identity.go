@@ -79,6 +79,9 @@ func IsGateway(id *protos.Identity) bool {
 
 //GetStreamGatewayId returns a valid, non nil Gateway identity based on the
 //stream's metadata CTX or error if no GW Identity can be found/verified
+//
+//Deprecated: streams of orchestrator services are decorated with the caller's
+//identity by the stream middleware, use protos.GetClientGateway(stream.Context())
 func GetStreamGatewayId(stream grpc.ServerStream) (*protos.Identity_Gateway, error) {
 	ctx := stream.Context()
 	if ctx == nil {
@@ -10,6 +10,7 @@ package models
 
 import (
 	"fmt"
+	"reflect"
 
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -189,3 +190,18 @@ func (m *GatewayDescription) FromBackendModels(networkID string, gatewayID strin
 	*m = GatewayDescription(entity.Description)
 	return nil
 }
+
+// ToLabelsToSet returns the labels to set in an entity update to replace the
+// entity's existing labels with these, or nil if the labels are unchanged
+func (m Labels) ToLabelsToSet(existing map[string]string) map[string]string {
+	if len(m) == 0 && len(existing) == 0 {
+		return nil
+	}
+	if reflect.DeepEqual(map[string]string(m), existing) {
+		return nil
+	}
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
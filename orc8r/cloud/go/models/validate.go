@@ -9,6 +9,8 @@
 package models
 
 import (
+	"errors"
+
 	"github.com/go-openapi/strfmt"
 )
 
@@ -31,3 +33,12 @@ func (m *GatewayName) ValidateModel() error {
 func (m *GatewayDescription) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
+
+// ValidateModel checks that all label keys are non-empty, which the swagger
+// spec can't express
+func (m Labels) ValidateModel() error {
+	if _, exists := m[""]; exists {
+		return errors.New("label keys must be non-empty")
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package access
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/accessd"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/labstack/echo"
+)
+
+// isAuditedRequest returns true for requests which have to be recorded in
+// the audit log (all mutating requests)
+func isAuditedRequest(c echo.Context) bool {
+	switch c.Request().Method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// MaxAuditedBodySize is the maximum size of the body of audited requests,
+// larger requests are rejected
+var MaxAuditedBodySize int64 = 8 << 20
+
+// newAuditRecord creates an audit record for the request, it consumes and
+// restores the request body to compute its hash. An error is returned if
+// the body is larger than MaxAuditedBodySize, the record is still returned
+// for the request's rejection to be audited.
+func newAuditRecord(c echo.Context) (*accessprotos.AuditRecord, error) {
+	req := c.Request()
+	route := c.Path()
+	record := &accessprotos.AuditRecord{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		NetworkId:  c.Param("network_id"),
+		EntityType: RouteEntityType(route),
+		EntityKey:  RouteEntityKey(c),
+	}
+	record.Timestamp, _ = ptypes.TimestampProto(clock.Now().UTC())
+	body := []byte{}
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(io.LimitReader(req.Body, MaxAuditedBodySize+1))
+		if err != nil {
+			glog.Error(LogDecorator(c)("Failed to read request body for audit: %s", err))
+		}
+		if int64(len(body)) > MaxAuditedBodySize {
+			return record, fmt.Errorf("body exceeds %d bytes", MaxAuditedBodySize)
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	if len(record.NetworkId) == 0 {
+		record.NetworkId = createdNetworkID(c, body)
+	}
+	bodyHash := sha256.Sum256(body)
+	record.BodySha256 = hex.EncodeToString(bodyHash[:])
+	return record, nil
+}
+
+// createdNetworkID returns the ID of the network the request creates, empty
+// if the request doesn't create a network. Networks are created by POSTs to
+// a collection whose members are addressed by :network_id, with the network
+// ID given by the requested_id query parameter or the "id" field of the body.
+func createdNetworkID(c echo.Context, body []byte) string {
+	if c.Request().Method != http.MethodPost || len(c.Param("network_id")) > 0 {
+		return ""
+	}
+	memberRoute := strings.TrimSuffix(c.Path(), "/") + "/:network_id"
+	isNetworkCollection := false
+	for _, route := range c.Echo().Routes() {
+		if route.Path == memberRoute {
+			isNetworkCollection = true
+			break
+		}
+	}
+	if !isNetworkCollection {
+		return ""
+	}
+	if requestedID := c.QueryParam("requested_id"); len(requestedID) > 0 {
+		return requestedID
+	}
+	network := struct {
+		ID string `json:"id"`
+	}{}
+	if err := json.Unmarshal(body, &network); err != nil {
+		return ""
+	}
+	return network.ID
+}
+
+// auditedNetworkExists returns true if the request isn't network scoped or
+// its network exists. Requests to nonexistent networks are not recorded so
+// arbitrary network IDs can't be used to fill up the audit log. Lookup
+// failures are logged and treated as existing networks to not lose records.
+func auditedNetworkExists(c echo.Context, networkID string) bool {
+	if len(networkID) == 0 {
+		return true
+	}
+	exists, err := configurator.DoesNetworkExist(networkID)
+	if err != nil {
+		glog.Error(LogDecorator(c)("Failed to check audited network %s: %s", networkID, err))
+		return true
+	}
+	return exists
+}
+
+// recordAudit completes the request's audit record with the operator and
+// the request's result and adds it to the audit log.
+// Requests without an identified operator and requests to networks which
+// neither existed before nor after the request are not recorded.
+func recordAudit(c echo.Context, record *accessprotos.AuditRecord, networkExisted bool, oper *protos.Identity, err error) {
+	if oper == nil {
+		return
+	}
+	if !networkExisted && !auditedNetworkExists(c, record.NetworkId) {
+		return
+	}
+	record.Operator = oper
+	record.ResultCode = int32(c.Response().Status)
+	if err != nil {
+		record.ResultCode = http.StatusInternalServerError
+		if httpErr, ok := err.(*echo.HTTPError); ok {
+			record.ResultCode = int32(httpErr.Code)
+		}
+	}
+	if recordErr := accessd.RecordAudit(record); recordErr != nil {
+		glog.Error(LogDecorator(c)("Failed to record audit: %s", recordErr))
+	}
+}
+
+// RouteEntityKey returns the key of the entity addressed by the request: the
+// value of the last route parameter other than network_id, empty if there is
+// none (e.g. POST to a collection)
+func RouteEntityKey(c echo.Context) string {
+	segments := strings.Split(c.Path(), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if strings.HasPrefix(segments[i], ":") && segments[i] != ":network_id" {
+			return c.Param(segments[i][1:])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,12 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package oidc implements validation of OAuth2/OIDC JWT bearer tokens
+// against an OIDC provider's JSON Web Key Set and mapping of the token's
+// claims to REST API operator IDs
+package oidc
@@ -0,0 +1,265 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+
+	"github.com/golang/glog"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	// KeySetRefreshInterval is the max age of loaded keys, older key sets
+	// are reloaded on the next lookup
+	KeySetRefreshInterval = time.Hour
+	// KeySetMinReloadInterval limits how often a lookup of an unknown key ID
+	// can trigger a key set reload
+	KeySetMinReloadInterval = time.Minute
+	// KeySetFetchTimeout is the timeout of key set HTTP(S) requests
+	KeySetFetchTimeout = time.Second * 10
+)
+
+// KeySet is a JSON Web Key Set (RFC 7517) of an OIDC provider's token signing
+// keys. The set is loaded from a local file or an HTTP(S) URL (usually the
+// provider's jwks_uri) and reloaded when it gets stale or when a token is
+// signed by a key which is not in the set (provider's key rotation). Reloads
+// are done without holding the lock, concurrent lookups share a single
+// reload.
+type KeySet struct {
+	sync.Mutex
+	source   string
+	keys     map[string]interface{} // kid -> *rsa.PublicKey | *ecdsa.PublicKey
+	loaded   time.Time
+	lastLoad time.Time
+	loads    singleflight.Group
+}
+
+// NewKeySet returns a KeySet for the given JWKS file path or HTTP(S) URL,
+// keys are loaded on first use
+func NewKeySet(source string) *KeySet {
+	return &KeySet{source: source}
+}
+
+// Key returns the public key with the given key ID, an empty kid matches the
+// set's only key
+func (ks *KeySet) Key(kid string) (interface{}, error) {
+	now := clock.Now()
+	keys, loaded := ks.getKeys()
+	if keys == nil || now.Sub(loaded) > KeySetRefreshInterval {
+		// a failed reload keeps the previously loaded keys
+		ks.reload(now)
+		keys, _ = ks.getKeys()
+	}
+	if keys == nil {
+		return nil, fmt.Errorf("key set from %s is not loaded", ks.source)
+	}
+	key, err := lookup(keys, kid)
+	// the provider may have rotated its keys
+	if err != nil && ks.reload(now) {
+		keys, _ = ks.getKeys()
+		key, err = lookup(keys, kid)
+	}
+	return key, err
+}
+
+func (ks *KeySet) getKeys() (map[string]interface{}, time.Time) {
+	ks.Lock()
+	defer ks.Unlock()
+	return ks.keys, ks.loaded
+}
+
+// reload loads the key set unless the last load attempt is more recent than
+// KeySetMinReloadInterval, returns true if new keys were loaded. Concurrent
+// reloads share a single load.
+func (ks *KeySet) reload(now time.Time) bool {
+	loaded, _, _ := ks.loads.Do(ks.source, func() (interface{}, error) {
+		ks.Lock()
+		canReload := ks.lastLoad.IsZero() || now.Sub(ks.lastLoad) >= KeySetMinReloadInterval
+		if canReload {
+			ks.lastLoad = now
+		}
+		ks.Unlock()
+		if !canReload {
+			return false, nil
+		}
+
+		keys, err := ks.load()
+		if err != nil {
+			return false, nil
+		}
+		ks.Lock()
+		ks.keys, ks.loaded = keys, now
+		ks.Unlock()
+		return true, nil
+	})
+	return loaded.(bool)
+}
+
+func lookup(keys map[string]interface{}, kid string) (interface{}, error) {
+	if len(kid) == 0 {
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("missing key ID, key set has %d keys", len(keys))
+		}
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID '%s'", kid)
+	}
+	return key, nil
+}
+
+func (ks *KeySet) load() (map[string]interface{}, error) {
+	data, err := readKeySetSource(ks.source)
+	if err != nil {
+		glog.Errorf("Failed to load OIDC key set from %s: %s", ks.source, err)
+		return nil, fmt.Errorf("failed to load key set: %s", err)
+	}
+	keys, err := ParseKeySet(data)
+	if err != nil {
+		glog.Errorf("Invalid OIDC key set from %s: %s", ks.source, err)
+		return nil, err
+	}
+	glog.V(1).Infof("Loaded %d OIDC keys from %s", len(keys), ks.source)
+	return keys, nil
+}
+
+func readKeySetSource(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return ioutil.ReadFile(source)
+	}
+	client := &http.Client{Timeout: KeySetFetchTimeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// jsonWebKey is a JWK with the members of RSA & EC public keys
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseKeySet parses a JWKS document and returns its signature verification
+// keys by key ID. Keys of unsupported types & encryption keys are skipped.
+func ParseKeySet(data []byte) (map[string]interface{}, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("invalid key set JSON: %s", err)
+	}
+	keys := map[string]interface{}{}
+	for _, jwk := range jwks.Keys {
+		if len(jwk.Use) > 0 && jwk.Use != "sig" {
+			continue
+		}
+		var (
+			key interface{}
+			err error
+		)
+		switch jwk.Kty {
+		case "RSA":
+			key, err = parseRSAKey(jwk)
+		case "EC":
+			key, err = parseECKey(jwk)
+		default:
+			glog.V(1).Infof("Skipping OIDC key '%s' of unsupported type '%s'", jwk.Kid, jwk.Kty)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key '%s': %s", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("key set has no signature keys")
+	}
+	return keys, nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("modulus: %s", err)
+	}
+	e, err := decodeBigInt(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("exponent: %s", err)
+	}
+	if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+		return nil, fmt.Errorf("exponent is too large")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func parseECKey(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve '%s'", jwk.Crv)
+	}
+	x, err := decodeBigInt(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("x: %s", err)
+	}
+	y, err := decodeBigInt(jwk.Y)
+	if err != nil {
+		return nil, fmt.Errorf("y: %s", err)
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("point is not on curve %s", jwk.Crv)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("missing value")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// DefaultOperatorClaim is the claim mapped to the operator ID if none is
+// configured
+const DefaultOperatorClaim = "sub"
+
+// TokenClockSkew is the allowed clock skew between the token issuer & us
+var TokenClockSkew = time.Minute
+
+// validMethods are the accepted token signing algorithms, symmetric (HS*)
+// and unsigned ('none') tokens are always rejected
+var validMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+}
+
+// OperatorIDSeparator separates the token issuer from the operator claim in
+// operator IDs of token operators
+const OperatorIDSeparator = "|"
+
+// Config of the token Validator
+type Config struct {
+	// Issuer - required 'iss' claim value, must be set
+	Issuer string
+	// Audience - value required in the 'aud' claim, must be set
+	Audience string
+	// OperatorClaim - name of the claim holding the operator ID,
+	// DefaultOperatorClaim if empty
+	OperatorClaim string
+}
+
+// Validator verifies JWT bearer tokens signed by keys of the given KeySet
+type Validator struct {
+	keys   *KeySet
+	config Config
+}
+
+// NewValidator returns a Validator of tokens signed by the keys' owner.
+// Tokens are only accepted from the configured issuer for the configured
+// audience, so both are required.
+func NewValidator(keys *KeySet, config Config) (*Validator, error) {
+	if len(config.Issuer) == 0 {
+		return nil, fmt.Errorf("token issuer must be configured")
+	}
+	if len(config.Audience) == 0 {
+		return nil, fmt.Errorf("token audience must be configured")
+	}
+	if len(config.OperatorClaim) == 0 {
+		config.OperatorClaim = DefaultOperatorClaim
+	}
+	return &Validator{keys: keys, config: config}, nil
+}
+
+// OperatorID verifies the token's signature & claims and returns the
+// operator ID the token was issued to: '<issuer>|<operator claim>', so token
+// operators never collide with certificate operators or other issuers' ones
+func (v *Validator) OperatorID(token string) (string, error) {
+	parser := &jwt.Parser{ValidMethods: validMethods, SkipClaimsValidation: true}
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(token, claims, v.getKey)
+	if err != nil {
+		if verr, ok := err.(*jwt.ValidationError); ok && verr.Inner != nil {
+			err = verr.Inner
+		}
+		return "", fmt.Errorf("invalid token: %s", err)
+	}
+	if err = v.verifyClaims(claims); err != nil {
+		return "", err
+	}
+	oid, _ := claims[v.config.OperatorClaim].(string)
+	oid = strings.TrimSpace(oid)
+	if len(oid) == 0 {
+		return "", fmt.Errorf("token is missing '%s' claim", v.config.OperatorClaim)
+	}
+	return v.config.Issuer + OperatorIDSeparator + oid, nil
+}
+
+// getKey returns the key of the token's kid, the key's type must match the
+// token's signing method
+func (v *Validator) getKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, err := v.keys.Key(kid)
+	if err != nil {
+		return nil, err
+	}
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		if _, ok := key.(*rsa.PublicKey); ok {
+			return key, nil
+		}
+	case *jwt.SigningMethodECDSA:
+		if _, ok := key.(*ecdsa.PublicKey); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("key '%s' does not match signing method %s", kid, token.Method.Alg())
+}
+
+func (v *Validator) verifyClaims(claims jwt.MapClaims) error {
+	now := clock.Now()
+	exp, ok := getTimeClaim(claims, "exp")
+	if !ok {
+		return fmt.Errorf("token is missing 'exp' claim")
+	}
+	if now.After(exp.Add(TokenClockSkew)) {
+		return fmt.Errorf("token expired at %s", exp.UTC().Format(time.RFC3339))
+	}
+	if nbf, ok := getTimeClaim(claims, "nbf"); ok && now.Add(TokenClockSkew).Before(nbf) {
+		return fmt.Errorf("token is not valid before %s", nbf.UTC().Format(time.RFC3339))
+	}
+	if iss, _ := claims["iss"].(string); iss != v.config.Issuer {
+		return fmt.Errorf("unexpected token issuer '%s'", iss)
+	}
+	if !hasAudience(claims, v.config.Audience) {
+		return fmt.Errorf("token is not issued for audience '%s'", v.config.Audience)
+	}
+	return nil
+}
+
+// getTimeClaim returns the value of a NumericDate claim
+func getTimeClaim(claims jwt.MapClaims, name string) (time.Time, bool) {
+	switch val := claims[name].(type) {
+	case float64:
+		return time.Unix(int64(val), 0), true
+	case json.Number:
+		if secs, err := val.Int64(); err == nil {
+			return time.Unix(secs, 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// hasAudience returns true if the 'aud' claim, either a single string or
+// an array of strings, contains the audience
+func hasAudience(claims jwt.MapClaims, audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
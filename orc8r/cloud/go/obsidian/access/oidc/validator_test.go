@@ -0,0 +1,338 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package oidc_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian/access/oidc"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testAudience = "magma"
+)
+
+func TestValidator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	jwksFile := writeKeySetFile(t, map[string]interface{}{"rsa1": &rsaKey.PublicKey, "ec1": &ecKey.PublicKey})
+	defer os.Remove(jwksFile)
+
+	// issuer & audience are required
+	_, err = oidc.NewValidator(oidc.NewKeySet(jwksFile), oidc.Config{Audience: testAudience})
+	assert.Error(t, err)
+	_, err = oidc.NewValidator(oidc.NewKeySet(jwksFile), oidc.Config{Issuer: testIssuer})
+	assert.Error(t, err)
+
+	v, err := oidc.NewValidator(oidc.NewKeySet(jwksFile), oidc.Config{Issuer: testIssuer, Audience: testAudience})
+	assert.NoError(t, err)
+	now := time.Now()
+
+	// RSA & ECDSA signed tokens, operator IDs are namespaced by the issuer
+	oid, err := v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, validClaims("bob", now)))
+	assert.NoError(t, err)
+	assert.Equal(t, testIssuer+"|bob", oid)
+	oid, err = v.OperatorID(signToken(t, jwt.SigningMethodES256, "ec1", ecKey, validClaims("alice", now)))
+	assert.NoError(t, err)
+	assert.Equal(t, testIssuer+"|alice", oid)
+
+	// audience array
+	claims := validClaims("bob", now)
+	claims["aud"] = []string{"other", testAudience}
+	oid, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, testIssuer+"|bob", oid)
+
+	// expired, not yet valid & missing expiration
+	claims = validClaims("bob", now)
+	claims["exp"] = now.Add(-time.Hour).Unix()
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.Error(t, err)
+	claims = validClaims("bob", now)
+	claims["nbf"] = now.Add(time.Hour).Unix()
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.Error(t, err)
+	claims = validClaims("bob", now)
+	delete(claims, "exp")
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.Error(t, err)
+
+	// within allowed clock skew
+	claims = validClaims("bob", now)
+	claims["exp"] = now.Add(-oidc.TokenClockSkew / 2).Unix()
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.NoError(t, err)
+
+	// wrong issuer & audience
+	claims = validClaims("bob", now)
+	claims["iss"] = "https://evil.example.com"
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.Error(t, err)
+	claims = validClaims("bob", now)
+	claims["aud"] = "other"
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.Error(t, err)
+
+	// missing operator claim
+	claims = validClaims("", now)
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", rsaKey, claims))
+	assert.Error(t, err)
+
+	// unknown signer, unknown kid & mismatched key type
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa1", otherKey, validClaims("bob", now)))
+	assert.Error(t, err)
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "rsa2", rsaKey, validClaims("bob", now)))
+	assert.Error(t, err)
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodES256, "rsa1", ecKey, validClaims("bob", now)))
+	assert.Error(t, err)
+
+	// symmetric & unsigned tokens
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodHS256, "rsa1", []byte("secret"), validClaims("bob", now)))
+	assert.Error(t, err)
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodNone, "rsa1", jwt.UnsafeAllowNoneSignatureType, validClaims("bob", now)))
+	assert.Error(t, err)
+
+	// malformed token
+	_, err = v.OperatorID("not.a.token")
+	assert.Error(t, err)
+}
+
+func TestValidatorOperatorClaim(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	jwksFile := writeKeySetFile(t, map[string]interface{}{"": &ecKey.PublicKey})
+	defer os.Remove(jwksFile)
+
+	// operator ID from the email claim
+	v, err := oidc.NewValidator(
+		oidc.NewKeySet(jwksFile),
+		oidc.Config{Issuer: testIssuer, Audience: testAudience, OperatorClaim: "email"})
+	assert.NoError(t, err)
+	claims := validClaims("12345", time.Now())
+	claims["email"] = "bob@example.com"
+	oid, err := v.OperatorID(signToken(t, jwt.SigningMethodES384, "", ecKey, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, testIssuer+"|bob@example.com", oid)
+}
+
+func TestKeySetURLReload(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	var (
+		fetches int32
+		jwks    atomic.Value
+	)
+	jwks.Store(encodeKeySet(t, map[string]interface{}{"k1": &key1.PublicKey}))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jwks.Load().([]byte))
+	}))
+	defer srv.Close()
+
+	clock.SetAndFreezeClock(t, time.Now())
+	defer clock.UnfreezeClock(t)
+
+	v, err := oidc.NewValidator(oidc.NewKeySet(srv.URL), oidc.Config{Issuer: testIssuer, Audience: testAudience})
+	assert.NoError(t, err)
+	claims := validClaims("bob", time.Now())
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "k1", key1, claims))
+	assert.NoError(t, err)
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "k1", key1, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	// provider rotates its keys, unknown kid triggers a reload once the min
+	// reload interval passes
+	jwks.Store(encodeKeySet(t, map[string]interface{}{"k1": &key1.PublicKey, "k2": &key2.PublicKey}))
+	k2Token := signToken(t, jwt.SigningMethodRS256, "k2", key2, claims)
+	_, err = v.OperatorID(k2Token)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	clock.SetAndFreezeClock(t, clock.Now().Add(oidc.KeySetMinReloadInterval))
+	_, err = v.OperatorID(k2Token)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	// stale key set is reloaded, failed reload keeps the loaded keys
+	clock.SetAndFreezeClock(t, clock.Now().Add(oidc.KeySetRefreshInterval+time.Second))
+	jwks.Store([]byte("invalid"))
+	claims = validClaims("bob", clock.Now())
+	_, err = v.OperatorID(signToken(t, jwt.SigningMethodRS256, "k2", key2, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fetches))
+}
+
+func TestKeySetConcurrentReload(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	var fetches int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) > 1 {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(encodeKeySet(t, map[string]interface{}{"k1": &key1.PublicKey}))
+	}))
+	defer srv.Close()
+
+	clock.SetAndFreezeClock(t, time.Now())
+	defer clock.UnfreezeClock(t)
+
+	// concurrent first lookups share a single load
+	keys := oidc.NewKeySet(srv.URL)
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := keys.Key("k1")
+			errs <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, <-errs)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	// lookups of known keys don't wait for a reload triggered by an unknown
+	// key ID
+	clock.SetAndFreezeClock(t, clock.Now().Add(oidc.KeySetMinReloadInterval))
+	go func() {
+		_, err := keys.Key("k2")
+		errs <- err
+	}()
+	for atomic.LoadInt32(&fetches) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	_, err = keys.Key("k1")
+	assert.NoError(t, err)
+	close(release)
+	assert.EqualError(t, <-errs, "unknown key ID 'k2'")
+
+	// unknown key IDs don't reload again before the min reload interval
+	_, err = keys.Key("k2")
+	assert.EqualError(t, err, "unknown key ID 'k2'")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func TestParseKeySet(t *testing.T) {
+	_, err := oidc.ParseKeySet([]byte("{"))
+	assert.Error(t, err)
+	_, err = oidc.ParseKeySet([]byte(`{"keys": []}`))
+	assert.Error(t, err)
+	// encryption & unsupported keys are skipped
+	_, err = oidc.ParseKeySet([]byte(`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`))
+	assert.Error(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	keys, err := oidc.ParseKeySet([]byte(`{"keys": [` +
+		`{"kty": "oct", "kid": "sym", "k": "c2VjcmV0"},` +
+		`{"kty": "EC", "kid": "enc", "use": "enc", "crv": "P-256", "x": "AA", "y": "AA"},` +
+		`{"kty": "EC", "kid": "sig", "use": "sig", "crv": "P-256", ` +
+		`"x": "` + encodeBigInt(ecKey.X) + `", "y": "` + encodeBigInt(ecKey.Y) + `"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(keys))
+	assert.Equal(t, &ecKey.PublicKey, keys["sig"])
+
+	// point not on the curve
+	_, err = oidc.ParseKeySet([]byte(`{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`))
+	assert.Error(t, err)
+	// missing RSA exponent
+	_, err = oidc.ParseKeySet([]byte(`{"keys": [{"kty": "RSA", "n": "AQAB"}]}`))
+	assert.Error(t, err)
+}
+
+func validClaims(operator string, now time.Time) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	if len(operator) > 0 {
+		claims["sub"] = operator
+	}
+	return claims
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if len(kid) > 0 {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func writeKeySetFile(t *testing.T, keys map[string]interface{}) string {
+	f, err := ioutil.TempFile("", "jwks")
+	assert.NoError(t, err)
+	_, err = f.Write(encodeKeySet(t, keys))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	return f.Name()
+}
+
+func encodeKeySet(t *testing.T, keys map[string]interface{}) []byte {
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	for kid, key := range keys {
+		var jwk map[string]string
+		switch k := key.(type) {
+		case *rsa.PublicKey:
+			jwk = map[string]string{
+				"kty": "RSA", "n": encodeBigInt(k.N), "e": encodeBigInt(big.NewInt(int64(k.E))),
+			}
+		case *ecdsa.PublicKey:
+			jwk = map[string]string{
+				"kty": "EC", "crv": k.Curve.Params().Name, "x": encodeBigInt(k.X), "y": encodeBigInt(k.Y),
+			}
+		default:
+			t.Fatalf("unsupported key type %T", key)
+		}
+		jwk["kid"], jwk["use"] = kid, "sig"
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	data, err := json.Marshal(jwks)
+	assert.NoError(t, err)
+	return data
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
@@ -10,25 +10,71 @@ package access
 
 import (
 	"fmt"
+	"strings"
 
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/certifier"
+	"magma/orc8r/cloud/go/services/configurator"
 
 	"github.com/golang/glog"
 	"github.com/labstack/echo"
 )
 
+const (
+	// operatorContextKey is the echo context key of the cached request Operator
+	operatorContextKey = "obsidian_request_operator"
+	// bearerPrefix is the Authorization header prefix of bearer tokens
+	bearerPrefix = "Bearer "
+)
+
+// TokenValidator verifies REST API bearer tokens
+type TokenValidator interface {
+	// OperatorID returns the ID of the operator the valid token was issued to.
+	// The ID should be namespaced by the token issuer, so it can't be used to
+	// impersonate certificate operators or other issuers' operators.
+	OperatorID(token string) (string, error)
+}
+
+// tokenValidator validates bearer tokens of requests without a client
+// certificate, bearer tokens are rejected if it's nil
+var tokenValidator TokenValidator
+
+// SetTokenValidator enables bearer token authentication of operators with
+// tokens verified by the given validator, nil disables it
+func SetTokenValidator(validator TokenValidator) {
+	tokenValidator = validator
+}
+
+// HasOperatorCredentials returns true if the request carries either a client
+// certificate SN header or a bearer token
+func HasOperatorCredentials(c echo.Context) bool {
+	req := c.Request()
+	if req == nil {
+		return false
+	}
+	_, hasToken := getBearerToken(req.Header.Get(echo.HeaderAuthorization))
+	return hasToken || len(req.Header.Get(CLIENT_CERT_SN_KEY)) > 0
+}
+
 // RequestOperator returns Identity of request's Operator (client)
 // If either the request is missing TLS certificate headers or the certificate's
 // SN is not found by Certifier or one of certificate & its identity checks fail
-// - nil will be returned & the corresponding error logged
+// - nil will be returned & the corresponding error logged.
+// Requests without a client certificate may instead authenticate with an
+// 'Authorization: Bearer <token>' header if a TokenValidator is set, the
+// token's operator ID is then subject to the same ACL checks.
+// The found Identity is cached in the request context, so subsequent calls
+// for the same request don't repeat the Certifier lookup
 func RequestOperator(c echo.Context) (*protos.Identity, error) {
 	if c == nil {
 		glog.Error("Nil Echo Context")
 		return nil, fmt.Errorf("Internal Server Error (Context)") // nil CTX, no useful info to log here
 	}
+	if opId, ok := c.Get(operatorContextKey).(*protos.Identity); ok && opId != nil {
+		return opId, nil
+	}
 	req := c.Request()
 	if req == nil {
 		glog.Error("Nil HTTP Request")
@@ -39,6 +85,9 @@ func RequestOperator(c echo.Context) (*protos.Identity, error) {
 	// TBD: to optimize - use map directly
 	csn := req.Header.Get(CLIENT_CERT_SN_KEY)
 	if len(csn) == 0 {
+		if token, ok := getBearerToken(req.Header.Get(echo.HeaderAuthorization)); ok {
+			return tokenOperator(c, token)
+		}
 		glog.Warning(LogDecorator(c)("Missing REST Client Certificate"))
 		return nil, fmt.Errorf("Missing Client Certificate")
 	}
@@ -73,6 +122,48 @@ func RequestOperator(c echo.Context) (*protos.Identity, error) {
 			"Identity (%s) of CSN %s is not Operator", opId.HashString(), csn))
 		return nil, fmt.Errorf("Internal Server Error (Operator)")
 	}
-	// all checks are OK, return it
-	return certInfo.Id, nil
+	// all checks are OK, cache & return it
+	c.Set(operatorContextKey, opId)
+	return opId, nil
+}
+
+// ConfigWriter returns a configurator Writer attributing its writes to the
+// request's operator. Writes of requests without operator credentials or
+// whose operator can't be identified are unattributed.
+func ConfigWriter(c echo.Context) configurator.Writer {
+	if !HasOperatorCredentials(c) {
+		return configurator.Writer{}
+	}
+	operator, err := RequestOperator(c)
+	if err != nil {
+		return configurator.Writer{}
+	}
+	return configurator.AuthoredBy(operator.GetOperator())
+}
+
+// tokenOperator returns Operator Identity of the bearer token
+func tokenOperator(c echo.Context, token string) (*protos.Identity, error) {
+	validator := tokenValidator
+	if validator == nil {
+		glog.Warning(LogDecorator(c)("Bearer Token Authentication is not enabled"))
+		return nil, fmt.Errorf("Bearer Token Authentication is not enabled")
+	}
+	oid, err := validator.OperatorID(token)
+	if err != nil {
+		glog.Error(LogDecorator(c)("Bearer Token Validation Error '%s'", err))
+		return nil, fmt.Errorf("Bearer Token Validation Error: %s", err)
+	}
+	opId := identity.NewOperator(oid)
+	c.Set(operatorContextKey, opId)
+	return opId, nil
+}
+
+// getBearerToken returns the token of a bearer Authorization header value
+func getBearerToken(authorization string) (string, bool) {
+	if len(authorization) <= len(bearerPrefix) ||
+		!strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(authorization[len(bearerPrefix):])
+	return token, len(token) > 0
 }
@@ -15,6 +15,7 @@ import (
 
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/accessd"
 	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
 
@@ -25,10 +26,14 @@ import (
 // Access Middleware:
 // 1) determines request's access type (READ/WRITE)
 // 2) finds Operator & Entities of the request
-// 3) verifies Operator's access permissions for the entities
+// 3) verifies Operator's access permissions for the entities and, if the
+//    Operator's ACL doesn't grant them, the Operator's role permissions for
+//    the request's route & entity type
+// 4) records mutating requests of identified Operators & their results in
+//    the audit log
 
 func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
+	return func(c echo.Context) (err error) {
 		if c == nil || c.Request() == nil {
 			return handleError(c, http.StatusBadRequest, "Invalid Request")
 		}
@@ -36,7 +41,8 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 		perm := requestPermissions(c)
 
 		// Get Request's Operator
-		oper, err := RequestOperator(c)
+		var oper *protos.Identity
+		oper, err = RequestOperator(c)
 		if err != nil {
 			if _, ok := err.(errors.ClientInitError); ok {
 				return handleError(c, http.StatusServiceUnavailable, "Service Unavailable")
@@ -53,6 +59,19 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 				"Missing Client Credentials")
 		}
 
+		// Audit only once the Operator is authenticated, so that anonymous
+		// requests can't make the middleware buffer bodies or look up
+		// networks
+		if isAuditedRequest(c) {
+			record, bodyErr := newAuditRecord(c)
+			// checked before the request runs to audit network deletions
+			networkExisted := auditedNetworkExists(c, record.NetworkId)
+			defer func() { recordAudit(c, record, networkExisted, oper, err) }()
+			if bodyErr != nil {
+				return handleError(c, http.StatusRequestEntityTooLarge, "Invalid Request Body: %s", bodyErr)
+			}
+		}
+
 		// Bypass farther identity Checks for static docs GET having an
 		// operator cert should be enough
 		if urlPath := c.Path(); perm != accessprotos.AccessControl_READ || !(strings.HasPrefix(urlPath, obsidian.StaticURLPrefix)) {
@@ -69,8 +88,13 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 				if _, ok := err.(errors.ClientInitError); ok {
 					return handleError(c, http.StatusServiceUnavailable, "Service Unavailable")
 				}
-				return handleError(
-					c, http.StatusForbidden, "Access Denied (%s)", err)
+				// Check Operator's roles for the requested route
+				roleErr := accessd.CheckRoleAccess(
+					oper, c.Param("network_id"), urlPath, RouteEntityType(urlPath), perm)
+				if roleErr != nil {
+					return handleError(
+						c, http.StatusForbidden, "Access Denied (%s; %s)", err, roleErr)
+				}
 			}
 		}
 		// all good, call next handler
@@ -81,6 +105,38 @@ func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// networksEntityType is the entity type of routes addressing networks
+const networksEntityType = "networks"
+
+// RouteEntityType returns the type of REST resource (entity) addressed by the
+// route: the first static segment following :network_id parameter, or
+// "networks" if there is none, since every route family ending in the
+// network ID addresses networks. Routes without a :network_id parameter
+// address the last static segment of the route. For example:
+//
+//	/magma/v1/lte/:network_id/subscribers/:subscriber_id => subscribers
+//	/magma/v1/networks/:network_id => networks
+//	/magma/v1/lte/:network_id => networks
+//	/magma/v1/channels => channels
+func RouteEntityType(route string) string {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	res := ""
+	for i, segment := range segments {
+		if segment == ":network_id" {
+			for _, next := range segments[i+1:] {
+				if len(next) > 0 && !strings.HasPrefix(next, ":") {
+					return next
+				}
+			}
+			return networksEntityType
+		}
+		if len(segment) > 0 && !strings.HasPrefix(segment, ":") {
+			res = segment
+		}
+	}
+	return res
+}
+
 // Return required request permission (READ, WRITE or READ & WRITE)
 // corresponding to the request method
 func requestPermissions(c echo.Context) accessprotos.AccessControl_Permission {
@@ -9,16 +9,28 @@ LICENSE file in the root directory of this source tree.
 package tests
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
 
+	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/access"
+	"magma/orc8r/cloud/go/services/accessd"
+	"magma/orc8r/cloud/go/services/configurator"
+	configurator_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
 	magmadh "magma/orc8r/cloud/go/services/magmad/obsidian/handlers"
 )
 
+const testSubscriberURL = magmadh.ManageNetwork + "/subscribers/:subscriber_id"
+
+// testLteNetworkURL is a network route outside of the networks route family
+const testLteNetworkURL = obsidian.V1Root + "lte/:network_id"
+
 func TestMiddlewareWithoutCertifier(t *testing.T) {
 	e := startTestMidlewareServer(t)
 
@@ -175,6 +187,197 @@ func TestMiddleware(t *testing.T) {
 	assert.Equal(t, 200, s)
 }
 
+func TestMiddlewareRoles(t *testing.T) {
+	_, superCertSn := MockAccessControl(t)
+	roleCertSn := MockRoleOperator(t, "read-only", "subscriber-manager")
+
+	e := startTestMidlewareServer(t)
+	listener := WaitForTestServer(t, e)
+	if listener == nil {
+		return // WaitForTestServer should have 'logged' error already
+	}
+	urlPrefix := "http://" + listener.Addr().String()
+
+	tests := []struct {
+		method, url string
+		expected    int
+	}{
+		// read-only role in TEST_NETWORK_ID
+		{"GET", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID, 200},
+		{"PUT", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID, 403},
+		{"GET", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID + "/subscribers/IMSI1", 200},
+		{"PUT", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID + "/subscribers/IMSI1", 403},
+		// subscriber-manager role in WRITE_TEST_NETWORK_ID
+		{"GET", magmadh.RegisterNetwork + "/" + WRITE_TEST_NETWORK_ID, 200},
+		{"PUT", magmadh.RegisterNetwork + "/" + WRITE_TEST_NETWORK_ID, 403},
+		{"GET", magmadh.RegisterNetwork + "/" + WRITE_TEST_NETWORK_ID + "/subscribers/IMSI1", 200},
+		{"PUT", magmadh.RegisterNetwork + "/" + WRITE_TEST_NETWORK_ID + "/subscribers/IMSI1", 200},
+		{"GET", obsidian.V1Root + "lte/" + WRITE_TEST_NETWORK_ID, 200},
+		{"PUT", obsidian.V1Root + "lte/" + WRITE_TEST_NETWORK_ID, 403},
+		// roles are not bound outside of the test networks
+		{"GET", magmadh.RegisterNetwork + "/other_network", 403},
+		{"GET", magmadh.RegisterNetwork, 403},
+		{"GET", "/malformed/url", 403},
+	}
+	for _, test := range tests {
+		s, err := SendRequest(test.method, urlPrefix+test.url, roleCertSn)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, s, "%s %s", test.method, test.url)
+	}
+
+	// ACL permissions are not affected by roles
+	s, err := SendRequest("PUT", urlPrefix+magmadh.RegisterNetwork+"/"+WRITE_TEST_NETWORK_ID, superCertSn)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, s)
+}
+
+func TestMiddlewareAudit(t *testing.T) {
+	configurator_test_init.StartTestService(t)
+	operCertSn, superCertSn := MockAccessControl(t)
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: TEST_NETWORK_ID}))
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: WRITE_TEST_NETWORK_ID}))
+
+	e := startTestMidlewareServer(t)
+	listener := WaitForTestServer(t, e)
+	if listener == nil {
+		return // WaitForTestServer should have 'logged' error already
+	}
+	urlPrefix := "http://" + listener.Addr().String()
+	start := time.Now()
+
+	// READ requests are not audited
+	s, err := SendRequest("GET", urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID, operCertSn)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, s)
+	// denied & unauthenticated WRITE requests
+	s, err = SendRequest("PUT", urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID, operCertSn)
+	assert.NoError(t, err)
+	assert.Equal(t, 403, s)
+	s, err = SendRequest("PUT", urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID, "bad cert sn")
+	assert.NoError(t, err)
+	assert.Equal(t, 401, s)
+	// permitted WRITE request
+	s, err = SendRequest("PUT", urlPrefix+magmadh.RegisterNetwork+"/"+WRITE_TEST_NETWORK_ID, operCertSn)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, s)
+	// requests to nonexistent networks are not audited
+	s, err = SendRequest("PUT", urlPrefix+magmadh.RegisterNetwork+"/nonexistent", superCertSn)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, s)
+
+	emptyBodyHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	records, err := accessd.QueryAuditLog(TEST_NETWORK_ID, start, time.Time{}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, TEST_OPERATOR_ID, records[0].Operator.GetOperator())
+		assert.Equal(t, "PUT", records[0].Method)
+		assert.Equal(t, magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID, records[0].Path)
+		assert.Equal(t, "networks", records[0].EntityType)
+		assert.Equal(t, emptyBodyHash, records[0].BodySha256)
+		assert.Equal(t, int32(403), records[0].ResultCode)
+	}
+	records, err = accessd.QueryAuditLog(WRITE_TEST_NETWORK_ID, start, time.Time{}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, TEST_OPERATOR_ID, records[0].Operator.GetOperator())
+		assert.Equal(t, int32(200), records[0].ResultCode)
+	}
+	records, err = accessd.QueryAuditLog("nonexistent", start, time.Time{}, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	// network creations are recorded in the created network's log
+	s, err = SendRequestWithBody("POST", urlPrefix+magmadh.RegisterNetwork, superCertSn, strings.NewReader(`{"id": "created"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 201, s)
+	records, err = accessd.QueryAuditLog("created", start, time.Time{}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "POST", records[0].Method)
+		assert.Equal(t, int32(201), records[0].ResultCode)
+	}
+
+	// audited requests with oversized bodies are rejected
+	maxBodySize := access.MaxAuditedBodySize
+	defer func() { access.MaxAuditedBodySize = maxBodySize }()
+	access.MaxAuditedBodySize = 8
+	s, err = SendRequestWithBody("PUT", urlPrefix+magmadh.RegisterNetwork+"/"+WRITE_TEST_NETWORK_ID, operCertSn, strings.NewReader(`{"name": "too long"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 413, s)
+	records, err = accessd.QueryAuditLog(WRITE_TEST_NETWORK_ID, start, time.Time{}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, int32(413), records[1].ResultCode)
+	}
+}
+
+// testTokenValidator maps valid tokens to operator IDs
+type testTokenValidator map[string]string
+
+func (v testTokenValidator) OperatorID(token string) (string, error) {
+	oid, ok := v[token]
+	if !ok {
+		return "", fmt.Errorf("invalid token")
+	}
+	return oid, nil
+}
+
+func TestMiddlewareBearerToken(t *testing.T) {
+	MockAccessControl(t)
+
+	e := startTestMidlewareServer(t)
+	listener := WaitForTestServer(t, e)
+	if listener == nil {
+		return // WaitForTestServer should have 'logged' error already
+	}
+	urlPrefix := "http://" + listener.Addr().String()
+
+	// bearer tokens are rejected without a validator
+	s, err := SendBearerRequest("GET", urlPrefix+magmadh.RegisterNetwork+"/"+TEST_NETWORK_ID, "bob-token")
+	assert.NoError(t, err)
+	assert.Equal(t, 401, s)
+
+	access.SetTokenValidator(testTokenValidator{
+		"bob-token":   TEST_OPERATOR_ID,
+		"admin-token": TEST_SUPER_OPERATOR_ID,
+		"eve-token":   "eve",
+	})
+	defer access.SetTokenValidator(nil)
+
+	tests := []struct {
+		method, url, token string
+		expected           int
+	}{
+		// token operators get the same ACL checks as certificate operators
+		{"GET", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID, "bob-token", 200},
+		{"PUT", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID, "bob-token", 403},
+		{"PUT", magmadh.RegisterNetwork + "/" + WRITE_TEST_NETWORK_ID, "bob-token", 200},
+		{"GET", magmadh.RegisterNetwork, "bob-token", 403},
+		{"GET", magmadh.RegisterNetwork, "admin-token", 200},
+		{"PUT", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID, "admin-token", 200},
+		// valid token of an operator unknown to accessd
+		{"GET", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID, "eve-token", 403},
+		// invalid token
+		{"GET", magmadh.RegisterNetwork + "/" + TEST_NETWORK_ID, "bad-token", 401},
+	}
+	for _, test := range tests {
+		s, err := SendBearerRequest(test.method, urlPrefix+test.url, test.token)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, s, "%s %s with %s", test.method, test.url, test.token)
+	}
+}
+
+func TestRouteEntityType(t *testing.T) {
+	assert.Equal(t, "subscribers", access.RouteEntityType("/magma/v1/lte/:network_id/subscribers/:subscriber_id"))
+	assert.Equal(t, "gateways", access.RouteEntityType("/magma/v1/networks/:network_id/gateways/:gateway_id/magmad"))
+	assert.Equal(t, "networks", access.RouteEntityType("/magma/v1/networks/:network_id"))
+	assert.Equal(t, "networks", access.RouteEntityType("/magma/v1/lte/:network_id"))
+	assert.Equal(t, "networks", access.RouteEntityType("/magma/v1/feg/:network_id/"))
+	assert.Equal(t, "networks", access.RouteEntityType("/magma/networks"))
+	assert.Equal(t, "channels", access.RouteEntityType("/magma/v1/channels/:channel_id"))
+	assert.Equal(t, "", access.RouteEntityType("/"))
+}
+
 func startTestMidlewareServer(t *testing.T) *echo.Echo {
 	e := echo.New()
 
@@ -185,9 +388,19 @@ func startTestMidlewareServer(t *testing.T) *echo.Echo {
 		return c.String(http.StatusOK, "All good!")
 	})
 
-	// Endpoint requiring Network Wildcard WRITE Access Permissions
+	// Endpoint requiring Network Wildcard WRITE Access Permissions, creates
+	// the network given in the body if any
 	e.POST(magmadh.RegisterNetwork, func(c echo.Context) error {
-		return c.String(http.StatusOK, "")
+		network := struct {
+			ID string `json:"id"`
+		}{}
+		if err := c.Bind(&network); err != nil || len(network.ID) == 0 {
+			return c.String(http.StatusOK, "")
+		}
+		if err := configurator.CreateNetwork(configurator.Network{ID: network.ID}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.String(http.StatusCreated, "")
 	})
 
 	// Endpoint requiring a specific Network READ Entity Access Permissions
@@ -200,6 +413,24 @@ func startTestMidlewareServer(t *testing.T) *echo.Echo {
 		return c.String(http.StatusOK, "")
 	})
 
+	// Endpoints of an LTE network, no identity finder is registered for
+	// them either
+	e.GET(testLteNetworkURL, func(c echo.Context) error {
+		return c.String(http.StatusOK, "All good!")
+	})
+	e.PUT(testLteNetworkURL, func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	})
+
+	// Endpoints of network's subscribers, no identity finder is registered
+	// for them, so they require supervisor permissions or a role
+	e.GET(testSubscriberURL, func(c echo.Context) error {
+		return c.String(http.StatusOK, "All good!")
+	})
+	e.PUT(testSubscriberURL, func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	})
+
 	// Endpoint requiring supervisor permissions
 	e.GET("/malformed/url", func(c echo.Context) error {
 		return c.String(http.StatusOK, "All good!")
@@ -37,6 +37,7 @@ const (
 	TEST_OPERATOR_ID       = "bob"
 	WRITE_TEST_NETWORK_ID  = "N6789"
 	TEST_SUPER_OPERATOR_ID = "admin"
+	TEST_ROLE_OPERATOR_ID  = "carol"
 )
 
 func testGet(t *testing.T, url string) {
@@ -62,13 +63,28 @@ func WaitForTestServer(t *testing.T, e *echo.Echo) net.Listener {
 }
 
 func SendRequest(method, url, certSn string) (int, error) {
-	var body io.Reader = nil
+	return sendRequest(method, url, access.CLIENT_CERT_SN_KEY, certSn, nil)
+}
+
+// SendRequestWithBody sends a request with the given body, authenticated by
+// the client certificate serial number
+func SendRequestWithBody(method, url, certSn string, body io.Reader) (int, error) {
+	return sendRequest(method, url, access.CLIENT_CERT_SN_KEY, certSn, body)
+}
+
+// SendBearerRequest sends a request authenticated by the bearer token
+// instead of a client certificate
+func SendBearerRequest(method, url, token string) (int, error) {
+	return sendRequest(method, url, echo.HeaderAuthorization, "Bearer "+token, nil)
+}
+
+func sendRequest(method, url, authHeader, authValue string, body io.Reader) (int, error) {
 	request, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return 0, err
 	}
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set(access.CLIENT_CERT_SN_KEY, certSn)
+	request.Header.Set(authHeader, authValue)
 
 	var client = &http.Client{}
 
@@ -176,3 +192,22 @@ func MockAccessControl(t *testing.T) (certSn string, superCertSn string) {
 		certSn, superCertSn)
 	return // return (certSn, superCertSn)
 }
+
+// MockRoleOperator creates a certificate for an Operator without ACL and
+// binds readNetRole to it in TEST_NETWORK_ID and writeNetRole in
+// WRITE_TEST_NETWORK_ID
+// Returns the Operator's certificate serial number
+func MockRoleOperator(t *testing.T, readNetRole, writeNetRole string) string {
+	csrMsg, err := certifier_test_utils.CreateCSR(
+		time.Duration(time.Hour*12), TEST_ROLE_OPERATOR_ID, TEST_ROLE_OPERATOR_ID)
+	assert.NoError(t, err)
+	certMsg, err := certifier.SignCSR(csrMsg)
+	assert.NoError(t, err, "Failed to sign CSR")
+	cert, err := x509.ParseCertificates(certMsg.CertDer)
+	assert.NoError(t, err, "Failed to parse cert")
+
+	oper := identity.NewOperator(TEST_ROLE_OPERATOR_ID)
+	assert.NoError(t, accessd.AddRoleBinding(oper, readNetRole, TEST_NETWORK_ID))
+	assert.NoError(t, accessd.AddRoleBinding(oper, writeNetRole, WRITE_TEST_NETWORK_ID))
+	return security_cert.SerialToString(cert[0].SerialNumber)
+}
@@ -31,3 +31,19 @@ var (
 	AllowAnyClientCert bool
 	StaticFolder       string
 )
+
+// REST API rate limits, see RateLimit
+var (
+	OperatorReadRateLimit  RateLimit
+	OperatorWriteRateLimit RateLimit
+	NetworkReadRateLimit   RateLimit
+	NetworkWriteRateLimit  RateLimit
+)
+
+// OIDC bearer token authentication, disabled if OIDCKeySet is empty
+var (
+	OIDCKeySet        string // JWKS file path or HTTP(S) URL
+	OIDCIssuer        string
+	OIDCAudience      string
+	OIDCOperatorClaim string
+)
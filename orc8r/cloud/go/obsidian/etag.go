@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+// ErrPreconditionFailed is returned by writes whose If-Match header doesn't
+// match the current version of the network or entity being written.
+var ErrPreconditionFailed = echo.NewHTTPError(http.StatusPreconditionFailed, fmt.Sprintf("%s does not match the current entity tag", IfMatchHeader))
+
+// SetETag sets the ETag header of a response to the versions of the networks
+// or entities the returned model is built from, in a fixed order.
+func SetETag(c echo.Context, versions ...uint64) {
+	versionStrs := make([]string, 0, len(versions))
+	for _, version := range versions {
+		versionStrs = append(versionStrs, strconv.FormatUint(version, 10))
+	}
+	c.Response().Header().Set(ETagHeader, fmt.Sprintf("%q", strings.Join(versionStrs, ".")))
+}
+
+// GetIfMatchVersion returns the version in the If-Match header of a write
+// request, as set by SetETag for a model built from a single network or
+// entity. A nil version is returned if the header isn't set or is the
+// wildcard "*". Entity tags which weren't issued by SetETag can never match,
+// so they fail the precondition.
+func GetIfMatchVersion(c echo.Context) (*uint64, *echo.HTTPError) {
+	versions, nerr := GetIfMatchVersions(c, 1)
+	if nerr != nil || versions == nil {
+		return nil, nerr
+	}
+	return &versions[0], nil
+}
+
+// GetIfMatchVersions returns the versions in the If-Match header of a write
+// request, as set by SetETag for a model built from numVersions networks or
+// entities. Like GetIfMatchVersion, nil is returned if the header isn't set
+// or is the wildcard "*".
+func GetIfMatchVersions(c echo.Context, numVersions int) ([]uint64, *echo.HTTPError) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(IfMatchHeader))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	unquoted, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s must be a single quoted entity tag", IfMatchHeader))
+	}
+	versionStrs := strings.Split(unquoted, ".")
+	if len(versionStrs) != numVersions {
+		return nil, ErrPreconditionFailed
+	}
+	versions := make([]uint64, 0, numVersions)
+	for _, versionStr := range versionStrs {
+		version, err := strconv.ParseUint(versionStr, 10, 64)
+		if err != nil {
+			return nil, ErrPreconditionFailed
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"magma/orc8r/cloud/go/obsidian"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetETag(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	obsidian.SetETag(e.NewContext(httptest.NewRequest(echo.GET, "/foo", nil), rec), 42)
+	assert.Equal(t, `"42"`, rec.Header().Get(obsidian.ETagHeader))
+
+	rec = httptest.NewRecorder()
+	obsidian.SetETag(e.NewContext(httptest.NewRequest(echo.GET, "/foo", nil), rec), 42, 0, 7)
+	assert.Equal(t, `"42.0.7"`, rec.Header().Get(obsidian.ETagHeader))
+}
+
+func TestGetIfMatchVersion(t *testing.T) {
+	e := echo.New()
+	getVersion := func(ifMatch string) (*uint64, *echo.HTTPError) {
+		req := httptest.NewRequest(echo.PUT, "/foo", nil)
+		if ifMatch != "" {
+			req.Header.Set(obsidian.IfMatchHeader, ifMatch)
+		}
+		return obsidian.GetIfMatchVersion(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	version, nerr := getVersion("")
+	assert.Nil(t, nerr)
+	assert.Nil(t, version)
+
+	version, nerr = getVersion("*")
+	assert.Nil(t, nerr)
+	assert.Nil(t, version)
+
+	version, nerr = getVersion(`"42"`)
+	assert.Nil(t, nerr)
+	assert.Equal(t, uint64(42), *version)
+
+	for _, badIfMatch := range []string{"42", `W/"42"`, `"1", "2"`, "'42'"} {
+		_, nerr = getVersion(badIfMatch)
+		assert.Equal(t, http.StatusBadRequest, nerr.Code)
+		assert.Equal(t, "If-Match must be a single quoted entity tag", nerr.Message)
+	}
+
+	for _, staleIfMatch := range []string{`"abc"`, `"42.1"`} {
+		_, nerr = getVersion(staleIfMatch)
+		assert.Equal(t, http.StatusPreconditionFailed, nerr.Code)
+		assert.Equal(t, "If-Match does not match the current entity tag", nerr.Message)
+	}
+}
+
+func TestGetIfMatchVersions(t *testing.T) {
+	e := echo.New()
+	getVersions := func(ifMatch string, numVersions int) ([]uint64, *echo.HTTPError) {
+		req := httptest.NewRequest(echo.PUT, "/foo", nil)
+		if ifMatch != "" {
+			req.Header.Set(obsidian.IfMatchHeader, ifMatch)
+		}
+		return obsidian.GetIfMatchVersions(e.NewContext(req, httptest.NewRecorder()), numVersions)
+	}
+
+	versions, nerr := getVersions("*", 3)
+	assert.Nil(t, nerr)
+	assert.Nil(t, versions)
+
+	versions, nerr = getVersions(`"42.0.7"`, 3)
+	assert.Nil(t, nerr)
+	assert.Equal(t, []uint64{42, 0, 7}, versions)
+
+	// Entity tags of models built from other entities never match
+	for _, staleIfMatch := range []string{`"42"`, `"42.0"`, `"42.0.7.1"`, `"42..7"`} {
+		_, nerr = getVersions(staleIfMatch, 3)
+		assert.Equal(t, http.StatusPreconditionFailed, nerr.Code)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+const LabelSelectorQueryParam = "label_selector"
+
+// GetLabelSelectorParam parses the label selector query param of a list
+// request. The selector is a comma-separated list of key=value pairs, e.g.
+// "region=west,tier=gold". A nil selector is returned if the param isn't set.
+func GetLabelSelectorParam(c echo.Context) (map[string]string, *echo.HTTPError) {
+	selectorParam := c.QueryParam(LabelSelectorQueryParam)
+	if selectorParam == "" {
+		return nil, nil
+	}
+
+	ret := map[string]string{}
+	for _, requirement := range strings.Split(selectorParam, ",") {
+		kv := strings.SplitN(requirement, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s must be a comma-separated list of key=value pairs", LabelSelectorQueryParam))
+		}
+		if existingValue, exists := ret[kv[0]]; exists && existingValue != kv[1] {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s has conflicting values for label %s", LabelSelectorQueryParam, kv[0]))
+		}
+		ret[kv[0]] = kv[1]
+	}
+	return ret, nil
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"magma/orc8r/cloud/go/obsidian"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLabelSelectorParam(t *testing.T) {
+	e := echo.New()
+	getSelector := func(selector string) (map[string]string, *echo.HTTPError) {
+		query := ""
+		if selector != "" {
+			query = "?label_selector=" + url.QueryEscape(selector)
+		}
+		req := httptest.NewRequest(echo.GET, "/foo"+query, nil)
+		return obsidian.GetLabelSelectorParam(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	selector, nerr := getSelector("")
+	assert.Nil(t, nerr)
+	assert.Nil(t, selector)
+
+	selector, nerr = getSelector("region=west")
+	assert.Nil(t, nerr)
+	assert.Equal(t, map[string]string{"region": "west"}, selector)
+
+	selector, nerr = getSelector("region=west,tier=gold,empty=,region=west")
+	assert.Nil(t, nerr)
+	assert.Equal(t, map[string]string{"region": "west", "tier": "gold", "empty": ""}, selector)
+
+	selector, nerr = getSelector("expr=a=b")
+	assert.Nil(t, nerr)
+	assert.Equal(t, map[string]string{"expr": "a=b"}, selector)
+
+	for _, badSelector := range []string{"region", "=west", "region=west,", "region=west,,tier=gold"} {
+		_, nerr = getSelector(badSelector)
+		assert.Equal(t, http.StatusBadRequest, nerr.Code)
+		assert.Equal(t, "label_selector must be a comma-separated list of key=value pairs", nerr.Message)
+	}
+
+	_, nerr = getSelector("region=west,region=east")
+	assert.Equal(t, http.StatusBadRequest, nerr.Code)
+	assert.Equal(t, "label_selector has conflicting values for label region", nerr.Message)
+}
@@ -14,9 +14,11 @@ import (
 
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access/oidc"
 	"magma/orc8r/cloud/go/obsidian/server"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/services/magmad/jobs"
 )
 
 func main() {
@@ -49,6 +51,36 @@ func main() {
 		"Folder containing the static files served",
 	)
 
+	// Rate limits, <requests per second>[:<burst>], 0 - unlimited
+	flag.Var(
+		&obsidian.OperatorReadRateLimit, "operator_read_rate_limit",
+		"Per operator rate limit of read (GET) requests, <rate>[:<burst>]",
+	)
+	flag.Var(
+		&obsidian.OperatorWriteRateLimit, "operator_write_rate_limit",
+		"Per operator rate limit of write (POST, PUT, DELETE) requests, <rate>[:<burst>]",
+	)
+	flag.Var(
+		&obsidian.NetworkReadRateLimit, "network_read_rate_limit",
+		"Per network rate limit of read (GET) requests, <rate>[:<burst>]",
+	)
+	flag.Var(
+		&obsidian.NetworkWriteRateLimit, "network_write_rate_limit",
+		"Per network rate limit of write (POST, PUT, DELETE) requests, <rate>[:<burst>]",
+	)
+
+	// OIDC bearer token authentication
+	flag.StringVar(
+		&obsidian.OIDCKeySet, "oidc_jwks", "",
+		"OIDC provider's JSON Web Key Set file path or URL, enables bearer token authentication",
+	)
+	flag.StringVar(&obsidian.OIDCIssuer, "oidc_issuer", "", "Required issuer ('iss' claim) of bearer tokens, must be set with oidc_jwks")
+	flag.StringVar(&obsidian.OIDCAudience, "oidc_audience", "", "Required audience ('aud' claim) of bearer tokens, must be set with oidc_jwks")
+	flag.StringVar(
+		&obsidian.OIDCOperatorClaim, "oidc_operator_claim", oidc.DefaultOperatorClaim,
+		"Bearer token claim mapped to the Operator ID",
+	)
+
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, obsidian.ServiceName)
 	if err != nil {
 		log.Fatalf("Error creating service: %s", err)
@@ -61,6 +93,12 @@ func main() {
 		}
 	}
 
+	// Start the batch job manager so that jobs of other obsidian instances
+	// with expired leases are resumed even before any job handler is called
+	if _, err = jobs.DefaultManager(); err != nil {
+		log.Printf("Failed to start batch job manager: %s", err)
+	}
+
 	go srv.Run()
 	server.Start()
 }
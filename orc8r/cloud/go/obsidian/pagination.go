@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+)
+
+const (
+	PageSizeQueryParam  = "page_size"
+	PageTokenQueryParam = "page_token"
+
+	// NextPageTokenHeader is the response header which holds the token for the
+	// next page of a paginated list. It is omitted on the last page.
+	NextPageTokenHeader = "X-Next-Page-Token"
+
+	// MaxPageSize is the largest page size list endpoints will serve
+	MaxPageSize = 1000
+)
+
+// GetPaginationParams returns the page size and page token query params of a
+// list request. A page size of 0 means the request isn't paginated.
+func GetPaginationParams(c echo.Context) (uint32, string, *echo.HTTPError) {
+	pageToken := c.QueryParam(PageTokenQueryParam)
+	pageSizeParam := c.QueryParam(PageSizeQueryParam)
+	if pageSizeParam == "" {
+		if pageToken != "" {
+			return 0, "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s requires %s", PageTokenQueryParam, PageSizeQueryParam))
+		}
+		return 0, "", nil
+	}
+
+	pageSize, err := strconv.ParseUint(pageSizeParam, 10, 32)
+	if err != nil || pageSize == 0 || pageSize > MaxPageSize {
+		return 0, "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s must be an integer between 1 and %d", PageSizeQueryParam, MaxPageSize))
+	}
+	return uint32(pageSize), pageToken, nil
+}
+
+// SetNextPageToken sets the next page token header of a paginated list
+// response. Empty tokens are not set.
+func SetNextPageToken(c echo.Context, nextPageToken string) {
+	if nextPageToken != "" {
+		c.Response().Header().Set(NextPageTokenHeader, nextPageToken)
+	}
+}
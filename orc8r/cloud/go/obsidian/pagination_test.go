@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"magma/orc8r/cloud/go/obsidian"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPaginationParams(t *testing.T) {
+	e := echo.New()
+	getParams := func(query string) (uint32, string, *echo.HTTPError) {
+		req := httptest.NewRequest(echo.GET, "/foo"+query, nil)
+		return obsidian.GetPaginationParams(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	pageSize, pageToken, nerr := getParams("")
+	assert.Nil(t, nerr)
+	assert.Equal(t, uint32(0), pageSize)
+	assert.Equal(t, "", pageToken)
+
+	pageSize, pageToken, nerr = getParams("?page_size=10")
+	assert.Nil(t, nerr)
+	assert.Equal(t, uint32(10), pageSize)
+	assert.Equal(t, "", pageToken)
+
+	pageSize, pageToken, nerr = getParams("?page_size=10&page_token=abc")
+	assert.Nil(t, nerr)
+	assert.Equal(t, uint32(10), pageSize)
+	assert.Equal(t, "abc", pageToken)
+
+	_, _, nerr = getParams("?page_token=abc")
+	assert.Equal(t, http.StatusBadRequest, nerr.Code)
+	assert.Equal(t, "page_token requires page_size", nerr.Message)
+
+	for _, badSize := range []string{"0", "-1", "foo", "1001"} {
+		_, _, nerr = getParams("?page_size=" + badSize)
+		assert.Equal(t, http.StatusBadRequest, nerr.Code)
+		assert.Equal(t, "page_size must be an integer between 1 and 1000", nerr.Message)
+	}
+}
+
+func TestSetNextPageToken(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(echo.GET, "/foo", nil), rec)
+	obsidian.SetNextPageToken(c, "")
+	assert.Empty(t, rec.Header().Get(obsidian.NextPageTokenHeader))
+	obsidian.SetNextPageToken(c, "abc")
+	assert.Equal(t, "abc", rec.Header().Get(obsidian.NextPageTokenHeader))
+}
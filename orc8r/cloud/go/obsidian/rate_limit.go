@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RateLimit is a token bucket configuration: Rate requests per second are
+// allowed on average with bursts of up to Burst requests. Zero Rate disables
+// the limit.
+// RateLimit implements flag.Value, its string form is <rate>[:<burst>]
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled returns true if the limit is configured
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0
+}
+
+func (l *RateLimit) String() string {
+	if l == nil || !l.Enabled() {
+		return "0"
+	}
+	return fmt.Sprintf("%s:%d", strconv.FormatFloat(l.Rate, 'f', -1, 64), l.Burst)
+}
+
+// Set parses <rate>[:<burst>], burst defaults to the rate rounded up
+func (l *RateLimit) Set(value string) error {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid rate limit '%s', expected <rate>[:<burst>]", value)
+	}
+	rate, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || rate < 0 {
+		return fmt.Errorf("invalid rate limit rate '%s'", parts[0])
+	}
+	burst := int(rate)
+	if float64(burst) < rate {
+		burst++
+	}
+	if len(parts) == 2 {
+		burst, err = strconv.Atoi(parts[1])
+		if err != nil || burst < 1 {
+			return fmt.Errorf("invalid rate limit burst '%s'", parts[1])
+		}
+	}
+	l.Rate, l.Burst = rate, burst
+	return nil
+}
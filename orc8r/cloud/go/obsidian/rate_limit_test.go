@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package obsidian_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/obsidian"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitFlag(t *testing.T) {
+	var l obsidian.RateLimit
+	assert.False(t, l.Enabled())
+	assert.Equal(t, "0", l.String())
+
+	assert.NoError(t, l.Set("10:50"))
+	assert.Equal(t, obsidian.RateLimit{Rate: 10, Burst: 50}, l)
+	assert.Equal(t, "10:50", l.String())
+
+	assert.NoError(t, l.Set("2.5"))
+	assert.Equal(t, obsidian.RateLimit{Rate: 2.5, Burst: 3}, l)
+	assert.True(t, l.Enabled())
+
+	assert.NoError(t, l.Set("0"))
+	assert.False(t, l.Enabled())
+
+	assert.Error(t, l.Set("fast"))
+	assert.Error(t, l.Set("-1"))
+	assert.Error(t, l.Set("1:0"))
+	assert.Error(t, l.Set("1:2:3"))
+}
@@ -30,10 +30,17 @@ var (
 		},
 		[]string{"code", "method"},
 	)
+	rateLimitedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limited_requests",
+			Help: "Number of obsidian requests rejected by rate limits",
+		},
+		[]string{"class", "limit"},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(requestCount, respStatuses)
+	prometheus.MustRegister(requestCount, respStatuses, rateLimitedRequests)
 }
 
 // CollectStats is the middleware function
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
+
+	"github.com/golang/glog"
+	"github.com/labstack/echo"
+)
+
+// Request classes & limit types, used as rejection metric labels
+const (
+	readRequests  = "read"
+	writeRequests = "write"
+	operatorLimit = "operator"
+	networkLimit  = "network"
+)
+
+// BucketSweepInterval is the minimal interval between removals of idle
+// (refilled) buckets
+var BucketSweepInterval = time.Minute
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// TokenBucketLimiter keeps a token bucket per key, every allowed request
+// takes a token & buckets are refilled at the configured rate up to the
+// configured burst
+type TokenBucketLimiter struct {
+	limit     obsidian.RateLimit
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	sync.Mutex
+}
+
+func NewTokenBucketLimiter(limit obsidian.RateLimit) *TokenBucketLimiter {
+	return &TokenBucketLimiter{limit: limit, buckets: map[string]*tokenBucket{}}
+}
+
+// Allow takes a token from the key's bucket. If the bucket is empty, Allow
+// returns false and the time after which the next token will be available
+func (l *TokenBucketLimiter) Allow(key string) (bool, time.Duration) {
+	if !l.limit.Enabled() {
+		return true, 0
+	}
+	now := clock.Now()
+	l.Lock()
+	defer l.Unlock()
+
+	l.sweep(now)
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = bucket
+	} else if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens = math.Min(
+			float64(l.limit.Burst), bucket.tokens+elapsed.Seconds()*l.limit.Rate)
+		bucket.last = now
+	}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - bucket.tokens) / l.limit.Rate * float64(time.Second))
+}
+
+// sweep removes buckets which are idle long enough to be full, such buckets
+// are identical to new ones
+func (l *TokenBucketLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < BucketSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	refillTime := time.Duration(float64(l.limit.Burst) / l.limit.Rate * float64(time.Second))
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= refillTime {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimiter limits request rates per request's operator and per request's
+// network separately for read & write requests
+type RateLimiter struct {
+	operatorRead, operatorWrite *TokenBucketLimiter
+	networkRead, networkWrite   *TokenBucketLimiter
+}
+
+func NewRateLimiter(operatorRead, operatorWrite, networkRead, networkWrite obsidian.RateLimit) *RateLimiter {
+	return &RateLimiter{
+		operatorRead:  NewTokenBucketLimiter(operatorRead),
+		operatorWrite: NewTokenBucketLimiter(operatorWrite),
+		networkRead:   NewTokenBucketLimiter(networkRead),
+		networkWrite:  NewTokenBucketLimiter(networkWrite),
+	}
+}
+
+// Enabled returns true if any of the limiter's limits is configured
+func (rl *RateLimiter) Enabled() bool {
+	for _, l := range []*TokenBucketLimiter{rl.operatorRead, rl.operatorWrite, rl.networkRead, rl.networkWrite} {
+		if l.limit.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// OperatorMiddleware rejects requests exceeding their operator's rate limit
+// with 429 (Too Many Requests) & Retry-After header. Requests without an
+// identified operator are limited per client IP, so it can run before access
+// control to shed excess load early.
+func (rl *RateLimiter) OperatorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestClass, limiter := getRequestClass(c), rl.operatorWrite
+		if requestClass == readRequests {
+			limiter = rl.operatorRead
+		}
+		if limiter.limit.Enabled() {
+			if ok, wait := limiter.Allow(requestOperatorKey(c)); !ok {
+				return rejectRequest(c, requestClass, operatorLimit, wait)
+			}
+		}
+		return next(c)
+	}
+}
+
+// NetworkMiddleware rejects requests exceeding their network's rate limit
+// with 429 (Too Many Requests) & Retry-After header. It has to run after
+// access control, so only requests allowed into the network use up its
+// budget.
+func (rl *RateLimiter) NetworkMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestClass, limiter := writeRequests, rl.networkWrite
+		if getRequestClass(c) == readRequests {
+			requestClass, limiter = readRequests, rl.networkRead
+		}
+		if networkID := c.Param("network_id"); len(networkID) > 0 && limiter.limit.Enabled() {
+			if ok, wait := limiter.Allow(networkID); !ok {
+				return rejectRequest(c, requestClass, networkLimit, wait)
+			}
+		}
+		return next(c)
+	}
+}
+
+func getRequestClass(c echo.Context) string {
+	switch c.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return readRequests
+	}
+	return writeRequests
+}
+
+// requestOperatorKey returns the rate limiting key of the request's operator,
+// requests without an identified operator are keyed by their client's IP
+func requestOperatorKey(c echo.Context) string {
+	if access.HasOperatorCredentials(c) {
+		if oper, err := access.RequestOperator(c); err == nil {
+			return oper.HashString()
+		}
+	}
+	return "ip:" + c.RealIP()
+}
+
+func rejectRequest(c echo.Context, class, limit string, wait time.Duration) error {
+	rateLimitedRequests.WithLabelValues(class, limit).Inc()
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	glog.V(2).Infof("Rate limited %s request %s %s by %s limit",
+		class, c.Request().Method, c.Request().URL, limit)
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	return echo.NewHTTPError(
+		http.StatusTooManyRequests, fmt.Sprintf("%s %s rate limit exceeded", limit, class))
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/server"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketLimiter(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	l := server.NewTokenBucketLimiter(obsidian.RateLimit{Rate: 2, Burst: 3})
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("a")
+		assert.True(t, ok)
+	}
+	ok, wait := l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+	// other keys have their own buckets
+	ok, _ = l.Allow("b")
+	assert.True(t, ok)
+
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).Add(500*time.Millisecond))
+	ok, _ = l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+
+	// refill is capped by the burst
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).Add(time.Hour))
+	for i := 0; i < 3; i++ {
+		ok, _ = l.Allow("a")
+		assert.True(t, ok)
+	}
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+
+	// disabled limit allows everything
+	l = server.NewTokenBucketLimiter(obsidian.RateLimit{})
+	for i := 0; i < 100; i++ {
+		ok, _ = l.Allow("a")
+		assert.True(t, ok)
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	rl := server.NewRateLimiter(
+		obsidian.RateLimit{Rate: 1, Burst: 2},
+		obsidian.RateLimit{},
+		obsidian.RateLimit{},
+		obsidian.RateLimit{Rate: 0.5, Burst: 1},
+	)
+	assert.True(t, rl.Enabled())
+	assert.False(t, server.NewRateLimiter(
+		obsidian.RateLimit{}, obsidian.RateLimit{}, obsidian.RateLimit{}, obsidian.RateLimit{}).Enabled())
+
+	e := echo.New()
+	// access control denies requests from 10.0.0.3
+	accessControl := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.RealIP() == "10.0.0.3" {
+				return echo.NewHTTPError(http.StatusForbidden, "Access denied")
+			}
+			return next(c)
+		}
+	}
+	handler := rl.OperatorMiddleware(accessControl(rl.NetworkMiddleware(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})))
+	serve := func(method, networkID, clientIP string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, "/magma/networks/"+networkID, nil)
+		req.Header.Set(echo.HeaderXRealIP, clientIP)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("network_id")
+		c.SetParamValues(networkID)
+		return rec, handler(c)
+	}
+	assertRejected := func(rec *httptest.ResponseRecorder, err error, retryAfter string) {
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusTooManyRequests, err.(*echo.HTTPError).Code)
+		}
+		assert.Equal(t, retryAfter, rec.Header().Get("Retry-After"))
+	}
+
+	// reads are limited per operator (client IP without a certificate)
+	for i := 0; i < 2; i++ {
+		rec, err := serve(http.MethodGet, "net1", "10.0.0.1")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+	rec, err := serve(http.MethodGet, "net1", "10.0.0.1")
+	assertRejected(rec, err, "1")
+	_, err = serve(http.MethodGet, "net1", "10.0.0.2")
+	assert.NoError(t, err)
+
+	// denied requests don't use up the network's budget
+	_, err = serve(http.MethodPut, "net1", "10.0.0.3")
+	assert.Equal(t, http.StatusForbidden, err.(*echo.HTTPError).Code)
+
+	// writes are limited per network only
+	_, err = serve(http.MethodPut, "net1", "10.0.0.1")
+	assert.NoError(t, err)
+	rec, err = serve(http.MethodPut, "net1", "10.0.0.2")
+	assertRejected(rec, err, "2")
+	_, err = serve(http.MethodPut, "net2", "10.0.0.1")
+	assert.NoError(t, err)
+
+	clock.SetAndFreezeClock(t, time.Unix(1000002, 0))
+	_, err = serve(http.MethodPut, "net1", "10.0.0.2")
+	assert.NoError(t, err)
+	_, err = serve(http.MethodGet, "net1", "10.0.0.1")
+	assert.NoError(t, err)
+}
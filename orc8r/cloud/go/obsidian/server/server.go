@@ -18,6 +18,7 @@ import (
 
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/access"
+	"magma/orc8r/cloud/go/obsidian/access/oidc"
 
 	"github.com/labstack/echo"
 	"github.com/labstack/echo/middleware"
@@ -30,6 +31,31 @@ func Start() {
 	// metrics middleware is used before all other middlewares
 	e.Use(CollectStats)
 	e.Use(middleware.Recover())
+	// operator rate limits are checked before access control to shed excess
+	// load early, network rate limits after it so unauthorized requests can't
+	// use up a network's budget
+	rateLimiter := NewRateLimiter(
+		obsidian.OperatorReadRateLimit, obsidian.OperatorWriteRateLimit,
+		obsidian.NetworkReadRateLimit, obsidian.NetworkWriteRateLimit,
+	)
+	if rateLimiter.Enabled() {
+		e.Use(rateLimiter.OperatorMiddleware)
+	}
+	if len(obsidian.OIDCKeySet) > 0 {
+		validator, err := oidc.NewValidator(
+			oidc.NewKeySet(obsidian.OIDCKeySet),
+			oidc.Config{
+				Issuer:        obsidian.OIDCIssuer,
+				Audience:      obsidian.OIDCAudience,
+				OperatorClaim: obsidian.OIDCOperatorClaim,
+			},
+		)
+		if err != nil {
+			log.Fatalf("Invalid OIDC bearer token configuration: %s", err)
+		}
+		log.Printf("Accepting OIDC bearer tokens signed by keys from '%s'", obsidian.OIDCKeySet)
+		access.SetTokenValidator(validator)
+	}
 	// Serve static pages for the API docs
 	e.Static(obsidian.StaticURLPrefix, obsidian.StaticFolder+"/apidocs")
 	e.Static(obsidian.StaticURLPrefix+"/swagger-ui/dist", obsidian.StaticFolder+"/swagger-ui/dist")
@@ -94,9 +120,15 @@ func Start() {
 		if !e.DisableHTTP2 {
 			s.TLSConfig.NextProtos = append(s.TLSConfig.NextProtos, "h2")
 		}
+		if rateLimiter.Enabled() {
+			e.Use(rateLimiter.NetworkMiddleware)
+		}
 		err = e.StartServer(e.TLSServer)
 	} else {
 		e.Use(access.Middleware)
+		if rateLimiter.Enabled() {
+			e.Use(rateLimiter.NetworkMiddleware)
+		}
 		err = e.Start(portStr)
 	}
 	if err != nil {
@@ -28,12 +28,17 @@ type Test struct {
 	URL     string
 	Payload encoding.BinaryMarshaler
 	Handler echo.HandlerFunc
+	// Headers are request headers to set on the test request
+	Headers map[string]string
 
 	ParamNames  []string
 	ParamValues []string
 
 	ExpectedStatus int
 	ExpectedResult encoding.BinaryMarshaler
+	// ExpectedHeaders are response headers which must be set to the given
+	// values. Map a header to an empty string to assert it isn't set.
+	ExpectedHeaders map[string]string
 
 	ExpectedError string
 }
@@ -52,6 +57,9 @@ func RunUnitTest(t *testing.T, e *echo.Echo, test Test) {
 	} else {
 		req = httptest.NewRequest(test.Method, test.URL, bytes.NewReader([]byte{}))
 	}
+	for header, value := range test.Headers {
+		req.Header.Set(header, value)
+	}
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -63,6 +71,9 @@ func RunUnitTest(t *testing.T, e *echo.Echo, test Test) {
 		c.Error(err)
 	}
 	assert.Equal(t, test.ExpectedStatus, rec.Code)
+	for header, expectedValue := range test.ExpectedHeaders {
+		assert.Equal(t, expectedValue, rec.Header().Get(header), "unexpected value for header %s", header)
+	}
 
 	if test.ExpectedError != "" {
 		if httpErr, ok := err.(*echo.HTTPError); ok {
@@ -20,5 +20,6 @@ const (
 	UpgradeTierEntityType           = "upgrade_tier"
 	UpgradeReleaseChannelEntityType = "upgrade_release_channel"
 
-	DnsdNetworkType = "dnsd_network"
+	DnsdNetworkType     = "dnsd_network"
+	MetricsdNetworkType = "metricsd_network"
 )
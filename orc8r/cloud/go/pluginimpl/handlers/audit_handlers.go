@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/accessd"
+
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+const queryParamLimit = "limit"
+
+func listAuditRecords(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	start, err := getTimeQueryParam(c, queryParamStart)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	end, err := getTimeQueryParam(c, queryParamEnd)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	var limit uint64
+	if limitStr := c.QueryParam(queryParamLimit); limitStr != "" {
+		limit, err = strconv.ParseUint(limitStr, 10, 32)
+		if err != nil {
+			return obsidian.HttpError(errors.New("limit must be a non-negative integer"), http.StatusBadRequest)
+		}
+	}
+
+	records, err := accessd.QueryAuditLog(networkID, start, end, uint32(limit))
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	ret := make([]*models.AuditRecord, 0, len(records))
+	for _, record := range records {
+		ret = append(ret, (&models.AuditRecord{}).FromAuditRecordProto(record))
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+// getTimeQueryParam parses an optional RFC3339 time query parameter, a
+// missing parameter results in zero time
+func getTimeQueryParam(c echo.Context, name string) (time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ret, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid %s time", name)
+	}
+	return ret, nil
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/pluginimpl/handlers"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/accessd"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	accessd_test_init "magma/orc8r/cloud/go/services/accessd/test_init"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListAuditRecords(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	accessd_test_init.StartTestService(t)
+
+	e := echo.New()
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	listAudit := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/audit", obsidian.GET).HandlerFunc
+
+	// empty audit log
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/audit_n1/audit",
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"audit_n1"},
+		Handler:        listAudit,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.AuditRecord{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	var expected []*models.AuditRecord
+	for i := 1; i <= 3; i++ {
+		ts := time.Unix(int64(1000000*i), 0).UTC()
+		tsProto, err := ptypes.TimestampProto(ts)
+		assert.NoError(t, err)
+		err = accessd.RecordAudit(&accessprotos.AuditRecord{
+			Operator:   identity.NewOperator("admin"),
+			Method:     "PUT",
+			Path:       "/magma/v1/networks/audit_n1/gateways/gw1",
+			NetworkId:  "audit_n1",
+			EntityType: "gateways",
+			EntityKey:  "gw1",
+			BodySha256: "abcd",
+			ResultCode: 200,
+			Timestamp:  tsProto,
+		})
+		assert.NoError(t, err)
+		dateTime := strfmt.DateTime(ts)
+		expected = append(expected, &models.AuditRecord{
+			Operator:   swag.String("admin"),
+			Method:     swag.String("PUT"),
+			Path:       swag.String("/magma/v1/networks/audit_n1/gateways/gw1"),
+			EntityType: "gateways",
+			EntityKey:  "gw1",
+			BodySha256: "abcd",
+			ResultCode: swag.Int32(200),
+			Timestamp:  &dateTime,
+		})
+	}
+	// other network's records are not listed
+	err := accessd.RecordAudit(&accessprotos.AuditRecord{
+		Operator:  identity.NewOperator("admin"),
+		Method:    "DELETE",
+		Path:      "/magma/v1/networks/audit_n2",
+		NetworkId: "audit_n2",
+	})
+	assert.NoError(t, err)
+
+	tc.ExpectedResult = tests.JSONMarshaler(expected)
+	tests.RunUnitTest(t, e, tc)
+
+	start := time.Unix(2000000, 0).UTC().Format(time.RFC3339)
+	end := time.Unix(3000000, 0).UTC().Format(time.RFC3339)
+	tc.URL = "/magma/v1/networks/audit_n1/audit?start=" + start + "&end=" + end
+	tc.ExpectedResult = tests.JSONMarshaler(expected[1:2])
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/networks/audit_n1/audit?start=" + start
+	tc.ExpectedResult = tests.JSONMarshaler(expected[1:])
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/networks/audit_n1/audit?limit=1"
+	tc.ExpectedResult = tests.JSONMarshaler(expected[2:])
+	tests.RunUnitTest(t, e, tc)
+
+	// invalid filters
+	tc.URL = "/magma/v1/networks/audit_n1/audit?start=yesterday"
+	tc.ExpectedStatus = 400
+	tc.ExpectedResult = nil
+	tc.ExpectedError = "invalid start time: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\""
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/networks/audit_n1/audit?limit=-1"
+	tc.ExpectedError = "limit must be a non-negative integer"
+	tests.RunUnitTest(t, e, tc)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/magmad/jobs"
+
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+// GetBatchJobHandlers returns handlers to submit, inspect & cancel batch jobs
+// of the network, the jobs are run by the given manager
+func GetBatchJobHandlers(manager *jobs.Manager) []obsidian.Handler {
+	return getBatchJobHandlers(func() (*jobs.Manager, error) { return manager, nil })
+}
+
+// getBatchJobHandlers returns the batch job handlers, the jobs are run by the
+// manager returned by getManager. getManager is only called by the handlers,
+// so that the manager isn't created until a job handler is called.
+func getBatchJobHandlers(getManager func() (*jobs.Manager, error)) []obsidian.Handler {
+	return []obsidian.Handler{
+		{Path: ListBatchJobsPath, Methods: obsidian.GET, HandlerFunc: withJobManager(getManager, getListBatchJobsHandler)},
+		{Path: ListBatchJobsPath, Methods: obsidian.POST, HandlerFunc: withJobManager(getManager, getSubmitBatchJobHandler)},
+		{Path: ManageBatchJobPath, Methods: obsidian.GET, HandlerFunc: withJobManager(getManager, getReadBatchJobHandler)},
+		{Path: CancelBatchJobPath, Methods: obsidian.POST, HandlerFunc: withJobManager(getManager, getCancelBatchJobHandler)},
+	}
+}
+
+func withJobManager(
+	getManager func() (*jobs.Manager, error),
+	getHandler func(manager *jobs.Manager) echo.HandlerFunc,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		manager, err := getManager()
+		if err != nil {
+			return getInitErrorHandler(err)(c)
+		}
+		return getHandler(manager)(c)
+	}
+}
+
+func getListBatchJobsHandler(manager *jobs.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, nerr := obsidian.GetNetworkId(c)
+		if nerr != nil {
+			return nerr
+		}
+		batchJobs, err := manager.List(networkID)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		ret := make([]*models.BatchJob, 0, len(batchJobs))
+		for _, job := range batchJobs {
+			ret = append(ret, (&models.BatchJob{}).FromJob(job))
+		}
+		return c.JSON(http.StatusOK, ret)
+	}
+}
+
+func getSubmitBatchJobHandler(manager *jobs.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, nerr := obsidian.GetNetworkId(c)
+		if nerr != nil {
+			return nerr
+		}
+		request := &models.BatchJobRequest{}
+		if err := c.Bind(request); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if err := request.ValidateModel(); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		cmd, err := request.Command.ToJobCommand()
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		var startTime time.Time
+		if request.StartTime != nil {
+			startTime = time.Time(*request.StartTime)
+		}
+
+		job, err := manager.Submit(networkID, cmd, request.Selector.ToJobSelector(), int(request.Concurrency), startTime)
+		if err == jobs.ErrNoGateways {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to submit batch job"), http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusCreated, (&models.BatchJob{}).FromJob(job))
+	}
+}
+
+func getReadBatchJobHandler(manager *jobs.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, jobID, nerr := getNetworkAndBatchJobIDs(c)
+		if nerr != nil {
+			return nerr
+		}
+		job, err := manager.Get(networkID, jobID)
+		if err == jobs.ErrNotFound {
+			return obsidian.HttpError(err, http.StatusNotFound)
+		}
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusOK, (&models.BatchJob{}).FromJob(job))
+	}
+}
+
+func getCancelBatchJobHandler(manager *jobs.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, jobID, nerr := getNetworkAndBatchJobIDs(c)
+		if nerr != nil {
+			return nerr
+		}
+		job, err := manager.Cancel(networkID, jobID)
+		switch {
+		case err == jobs.ErrNotFound:
+			return obsidian.HttpError(err, http.StatusNotFound)
+		case err == jobs.ErrFinished:
+			return obsidian.HttpError(err, http.StatusConflict)
+		case err != nil:
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusOK, (&models.BatchJob{}).FromJob(job))
+	}
+}
+
+func getNetworkAndBatchJobIDs(c echo.Context) (string, string, *echo.HTTPError) {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return "", "", nerr
+	}
+	jobID := c.Param("job_id")
+	if jobID == "" {
+		return "", "", obsidian.HttpError(errors.New("missing job ID"), http.StatusBadRequest)
+	}
+	return networkID, jobID, nil
+}
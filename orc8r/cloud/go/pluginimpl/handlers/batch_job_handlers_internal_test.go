@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"magma/orc8r/cloud/go/services/magmad/jobs"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchJobHandlers_LazyManager(t *testing.T) {
+	calls := 0
+	getManager := func() (*jobs.Manager, error) {
+		calls++
+		return nil, errors.New("database is down")
+	}
+
+	// creating the handlers doesn't create the manager
+	handlers := getBatchJobHandlers(getManager)
+	assert.Equal(t, 0, calls)
+
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/magma/v1/networks/n1/jobs", nil), httptest.NewRecorder())
+	c.SetParamNames("network_id")
+	c.SetParamValues("n1")
+	err := handlers[0].HandlerFunc(c)
+	assert.Equal(t, 1, calls)
+	if assert.IsType(t, &echo.HTTPError{}, err) {
+		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+	}
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/pluginimpl/handlers"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/magmad/jobs"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BatchJobHandlers(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).UTC())
+	defer clock.UnfreezeClock(t)
+
+	release := make(chan struct{})
+	executor := func(networkID, gatewayID string, cmd jobs.Command) (*structpb.Struct, error) {
+		<-release
+		if gatewayID == "gw2" {
+			return nil, errors.New("gateway is offline")
+		}
+		return nil, nil
+	}
+	resolver := func(networkID string, selector jobs.Selector) ([]string, error) {
+		if selector.Tier == "empty" {
+			return nil, nil
+		}
+		return []string{"gw1", "gw2", "gw3"}, nil
+	}
+	manager := jobs.NewManager(blobstore.NewMemoryBlobStorageFactory(), executor, resolver)
+
+	e := echo.New()
+	jobHandlers := handlers.GetBatchJobHandlers(manager)
+	listJobs := tests.GetHandlerByPathAndMethod(t, jobHandlers, "/magma/v1/networks/:network_id/jobs", obsidian.GET).HandlerFunc
+	submitJob := tests.GetHandlerByPathAndMethod(t, jobHandlers, "/magma/v1/networks/:network_id/jobs", obsidian.POST).HandlerFunc
+	getJob := tests.GetHandlerByPathAndMethod(t, jobHandlers, "/magma/v1/networks/:network_id/jobs/:job_id", obsidian.GET).HandlerFunc
+	cancelJob := tests.GetHandlerByPathAndMethod(t, jobHandlers, "/magma/v1/networks/:network_id/jobs/:job_id/cancel", obsidian.POST).HandlerFunc
+
+	// empty case
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/jobs",
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        listJobs,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.BatchJob{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// invalid jobs
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n1/jobs",
+		Payload:        &models.BatchJobRequest{Command: &models.GatewayCommand{Type: swag.String("shutdown")}},
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        submitJob,
+		ExpectedStatus: 400,
+		ExpectedError:  "validation failure list:\nvalidation failure list:\ntype in body should be one of [reboot restart_services generic]",
+	}
+	tests.RunUnitTest(t, e, tc)
+	tc.Payload = &models.BatchJobRequest{Command: &models.GatewayCommand{Type: swag.String("restart_services")}}
+	tc.ExpectedError = "restart_services command requires services"
+	tests.RunUnitTest(t, e, tc)
+	tc.Payload = &models.BatchJobRequest{
+		Command:  &models.GatewayCommand{Type: swag.String("reboot")},
+		Selector: &models.GatewaySelector{Tier: "empty"},
+	}
+	tc.ExpectedError = "no gateways match the selector"
+	tests.RunUnitTest(t, e, tc)
+
+	// submit a job
+	tc = tests.Test{
+		Method: "POST",
+		URL:    "/magma/v1/networks/n1/jobs",
+		Payload: &models.BatchJobRequest{
+			Command:     &models.GatewayCommand{Type: swag.String("restart_services"), Services: []string{"mme"}},
+			Selector:    &models.GatewaySelector{Labels: map[string]string{"region": "west"}},
+			Concurrency: 2,
+		},
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        submitJob,
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+	submitted, err := manager.List("n1")
+	assert.NoError(t, err)
+	if !assert.Len(t, submitted, 1) {
+		return
+	}
+	jobID := submitted[0].ID
+	assert.Equal(t, jobs.Command{Type: jobs.RestartServicesCommand, Services: []string{"mme"}}, submitted[0].Command)
+	assert.Equal(t, map[string]string{"region": "west"}, submitted[0].Selector.Labels)
+	assert.Equal(t, 2, submitted[0].Concurrency)
+
+	// inspect the running job
+	job, err := manager.Get("n1", jobID)
+	assert.NoError(t, err)
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/jobs/" + jobID,
+		ParamNames:     []string{"network_id", "job_id"},
+		ParamValues:    []string{"n1", jobID},
+		Handler:        getJob,
+		ExpectedStatus: 200,
+		ExpectedResult: (&models.BatchJob{}).FromJob(job),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// completed job
+	close(release)
+	for i := 0; i < 100 && !job.IsFinished(); i++ {
+		time.Sleep(time.Millisecond * 10)
+		job, err = manager.Get("n1", jobID)
+		assert.NoError(t, err)
+	}
+	startedAt := clock.Now()
+	expected := &models.BatchJob{
+		ID: swag.String(jobID),
+		Command: &models.GatewayCommand{
+			Type:     swag.String("restart_services"),
+			Services: []string{"mme"},
+		},
+		Selector:    &models.GatewaySelector{Labels: map[string]string{"region": "west"}},
+		Concurrency: swag.Int32(2),
+		StartTime:   toDateTime(startedAt),
+		State:       swag.String("completed"),
+		CreatedAt:   toDateTime(startedAt),
+		FinishedAt:  toDateTime(startedAt),
+		Results: map[string]models.BatchJobGatewayResult{
+			"gw1": {State: swag.String("succeeded"), StartedAt: toDateTime(startedAt), FinishedAt: toDateTime(startedAt)},
+			"gw2": {
+				State:      swag.String("failed"),
+				Error:      "gateway is offline",
+				StartedAt:  toDateTime(startedAt),
+				FinishedAt: toDateTime(startedAt),
+			},
+			"gw3": {State: swag.String("succeeded"), StartedAt: toDateTime(startedAt), FinishedAt: toDateTime(startedAt)},
+		},
+	}
+	tc.ExpectedResult = expected
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/jobs",
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        listJobs,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.BatchJob{expected}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// finished jobs can't be cancelled
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n1/jobs/" + jobID + "/cancel",
+		ParamNames:     []string{"network_id", "job_id"},
+		ParamValues:    []string{"n1", jobID},
+		Handler:        cancelJob,
+		ExpectedStatus: 409,
+		ExpectedError:  "job is already finished",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// cancel a scheduled job
+	startTime := toDateTime(startedAt.Add(time.Hour))
+	tc = tests.Test{
+		Method: "POST",
+		URL:    "/magma/v1/networks/n2/jobs",
+		Payload: &models.BatchJobRequest{
+			Command:   &models.GatewayCommand{Type: swag.String("reboot")},
+			StartTime: startTime,
+		},
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n2"},
+		Handler:        submitJob,
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+	submitted, err = manager.List("n2")
+	assert.NoError(t, err)
+	if !assert.Len(t, submitted, 1) {
+		return
+	}
+	jobID = submitted[0].ID
+	assert.Equal(t, jobs.JobScheduled, submitted[0].State)
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n2/jobs/" + jobID + "/cancel",
+		ParamNames:     []string{"network_id", "job_id"},
+		ParamValues:    []string{"n2", jobID},
+		Handler:        cancelJob,
+		ExpectedStatus: 200,
+	}
+	tests.RunUnitTest(t, e, tc)
+	job, err = manager.Get("n2", jobID)
+	assert.NoError(t, err)
+	for i := 0; i < 100 && !job.IsFinished(); i++ {
+		time.Sleep(time.Millisecond * 10)
+		job, err = manager.Get("n2", jobID)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, jobs.JobCancelled, job.State)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewayCancelled: 3}, job.Counts())
+
+	// unknown job
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/jobs/unknown",
+		ParamNames:     []string{"network_id", "job_id"},
+		ParamValues:    []string{"n1", "unknown"},
+		Handler:        getJob,
+		ExpectedStatus: 404,
+		ExpectedError:  "job not found",
+	}
+	tests.RunUnitTest(t, e, tc)
+}
+
+func toDateTime(t time.Time) *strfmt.DateTime {
+	ret := strfmt.DateTime(t)
+	return &ret
+}
@@ -13,9 +13,13 @@ import (
 	"reflect"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
 
 	"github.com/labstack/echo"
+	"github.com/pkg/errors"
 )
 
 // GetAndValidatePayload can be used by any model that implements ValidateModel
@@ -36,3 +40,87 @@ func GetAndValidatePayload(c echo.Context, model interface{}) (serde.Validatable
 	}
 	return iModel, nil
 }
+
+// LoadEntitiesForList loads the entities of a type in a network for a list
+// endpoint. If the request specifies a label selector, only entities with
+// matching labels are loaded. If the request specifies a page size, only the
+// requested page of entities is loaded and the token for the next page is set
+// on the response.
+func LoadEntitiesForList(c echo.Context, networkID string, entityType string, criteria configurator.EntityLoadCriteria) ([]configurator.NetworkEntity, *echo.HTTPError) {
+	pageSize, pageToken, nerr := obsidian.GetPaginationParams(c)
+	if nerr != nil {
+		return nil, nerr
+	}
+	labelSelector, nerr := obsidian.GetLabelSelectorParam(c)
+	if nerr != nil {
+		return nil, nerr
+	}
+	if pageSize == 0 {
+		ents, err := configurator.LoadAllEntitiesWithLabels(networkID, entityType, labelSelector, criteria)
+		if err != nil {
+			return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		return ents, nil
+	}
+
+	criteria.PageSize, criteria.PageToken = pageSize, pageToken
+	ents, nextPageToken, err := configurator.LoadEntitiesPage(networkID, entityType, labelSelector, criteria)
+	if err == configurator.ErrInvalidPageToken {
+		return nil, obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err != nil {
+		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	obsidian.SetNextPageToken(c, nextPageToken)
+	return ents, nil
+}
+
+// WriteErrorToHttpError converts the error of a configurator write into an
+// HTTP error. Writes rejected because of a stale If-Match header return 412.
+func WriteErrorToHttpError(err error) *echo.HTTPError {
+	if errors.Cause(err) == configurator.ErrVersionMismatch {
+		return obsidian.ErrPreconditionFailed
+	}
+	return obsidian.HttpError(err, http.StatusInternalServerError)
+}
+
+// DeleteEntitiesIfMatch deletes entities in a single transaction. If the
+// request has an If-Match header, the entities are only deleted if the
+// version of the first entity matches. The first entity should be the one
+// whose version is returned as the ETag on reads.
+func DeleteEntitiesIfMatch(c echo.Context, networkID string, ids ...storage.TypeAndKey) *echo.HTTPError {
+	expectedVersion, nerr := obsidian.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	writes := make([]configurator.EntityWriteOperation, 0, len(ids))
+	for i, id := range ids {
+		write := configurator.EntityUpdateCriteria{Type: id.Type, Key: id.Key, DeleteEntity: true}
+		if i == 0 {
+			write.ExpectedVersion = expectedVersion
+		}
+		writes = append(writes, write)
+	}
+	err := access.ConfigWriter(c).WriteEntities(networkID, writes...)
+	if err != nil {
+		return WriteErrorToHttpError(err)
+	}
+	return nil
+}
+
+// DeleteNetworkIfMatch deletes a network. If the request has an If-Match
+// header, the network is only deleted if its version matches.
+func DeleteNetworkIfMatch(c echo.Context, networkID string) *echo.HTTPError {
+	expectedVersion, nerr := obsidian.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
+	err := access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{
+		{ID: networkID, DeleteNetwork: true, ExpectedVersion: expectedVersion},
+	})
+	if err != nil {
+		return WriteErrorToHttpError(err)
+	}
+	return nil
+}
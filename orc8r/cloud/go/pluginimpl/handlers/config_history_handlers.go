@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+func listNetworkConfigHistory(c echo.Context) error {
+	networkID, configType, nerr := getNetworkIDAndConfigType(c)
+	if nerr != nil {
+		return nerr
+	}
+	return listConfigHistory(c, networkID, configurator.NetworkConfigID(configType))
+}
+
+func restoreNetworkConfig(c echo.Context) error {
+	networkID, configType, nerr := getNetworkIDAndConfigType(c)
+	if nerr != nil {
+		return nerr
+	}
+	return restoreConfig(c, networkID, configurator.NetworkConfigID(configType))
+}
+
+func listEntityConfigHistory(c echo.Context) error {
+	networkID, id, nerr := getNetworkIDAndEntityConfigID(c)
+	if nerr != nil {
+		return nerr
+	}
+	return listConfigHistory(c, networkID, id)
+}
+
+func restoreEntityConfig(c echo.Context) error {
+	networkID, id, nerr := getNetworkIDAndEntityConfigID(c)
+	if nerr != nil {
+		return nerr
+	}
+	return restoreConfig(c, networkID, id)
+}
+
+func listConfigHistory(c echo.Context, networkID string, id configurator.ConfigID) error {
+	revisions, err := configurator.ListConfigHistory(networkID, id)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+
+	ret, err := models.ConfigRevisionsFromConfigurator(revisions)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+func restoreConfig(c echo.Context, networkID string, id configurator.ConfigID) error {
+	revision, err := strconv.ParseUint(c.Param("revision"), 10, 64)
+	if err != nil || revision == 0 {
+		return obsidian.HttpError(errors.New("revision must be a positive integer"), http.StatusBadRequest)
+	}
+
+	restored, err := access.ConfigWriter(c).RollbackConfig(networkID, id, revision)
+	if err == merrors.ErrNotFound {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusOK, (&models.ConfigRevision{}).FromConfiguratorConfigRevision(restored))
+}
+
+func getNetworkIDAndConfigType(c echo.Context) (string, string, *echo.HTTPError) {
+	vals, nerr := obsidian.GetParamValues(c, "network_id", "config_type")
+	if nerr != nil {
+		return "", "", nerr
+	}
+	return vals[0], vals[1], nil
+}
+
+func getNetworkIDAndEntityConfigID(c echo.Context) (string, configurator.ConfigID, *echo.HTTPError) {
+	vals, nerr := obsidian.GetParamValues(c, "network_id", "entity_type", "entity_key")
+	if nerr != nil {
+		return "", configurator.ConfigID{}, nerr
+	}
+	return vals[0], configurator.EntityConfigID(vals[1], vals[2]), nil
+}
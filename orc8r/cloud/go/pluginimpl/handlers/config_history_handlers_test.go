@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/pluginimpl/handlers"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/test_init"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NetworkConfigHistoryHandlers(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	test_init.StartTestService(t)
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	e := echo.New()
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	listHistory := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/config_history/:config_type", obsidian.GET).HandlerFunc
+	restore := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/config_history/:config_type/:revision/restore", obsidian.POST).HandlerFunc
+
+	seedNetworks(t)
+	newFeatures := &models.NetworkFeatures{Features: map[string]string{"hello": "world"}}
+	err := configurator.UpdateNetworkConfig("n1", orc8r.NetworkFeaturesConfig, newFeatures)
+	assert.NoError(t, err)
+
+	createdAt := strfmt.DateTime(time.Unix(1000000, 0))
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/config_history/" + orc8r.NetworkFeaturesConfig,
+		ParamNames:     []string{"network_id", "config_type"},
+		ParamValues:    []string{"n1", orc8r.NetworkFeaturesConfig},
+		Handler:        listHistory,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.ConfigRevision{
+			{
+				Revision:  swag.Uint64(2),
+				Config:    newFeatures,
+				Deleted:   swag.Bool(false),
+				Diff:      "--- revision 1\n+++ revision 2\n@@ -1,5 +1,5 @@\n {\n   \"features\": {\n-    \"foo\": \"bar\"\n+    \"hello\": \"world\"\n   }\n }\n",
+				CreatedAt: &createdAt,
+			},
+			{Revision: swag.Uint64(1), Config: models.NewDefaultFeaturesConfig(), Deleted: swag.Bool(false), CreatedAt: &createdAt},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Restore revision 1
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n1/config_history/" + orc8r.NetworkFeaturesConfig + "/1/restore",
+		ParamNames:     []string{"network_id", "config_type", "revision"},
+		ParamValues:    []string{"n1", orc8r.NetworkFeaturesConfig, "1"},
+		Handler:        restore,
+		ExpectedStatus: 200,
+		ExpectedResult: &models.ConfigRevision{Revision: swag.Uint64(3), Config: models.NewDefaultFeaturesConfig(), Deleted: swag.Bool(false), CreatedAt: &createdAt},
+	}
+	tests.RunUnitTest(t, e, tc)
+	actual, err := configurator.LoadNetworkConfig("n1", orc8r.NetworkFeaturesConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewDefaultFeaturesConfig(), actual)
+
+	// Restore a revision which doesn't exist
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n1/config_history/" + orc8r.NetworkFeaturesConfig + "/42/restore",
+		ParamNames:     []string{"network_id", "config_type", "revision"},
+		ParamValues:    []string{"n1", orc8r.NetworkFeaturesConfig, "42"},
+		Handler:        restore,
+		ExpectedStatus: 404,
+		ExpectedError:  "Not Found",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Bad revision
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n1/config_history/" + orc8r.NetworkFeaturesConfig + "/0/restore",
+		ParamNames:     []string{"network_id", "config_type", "revision"},
+		ParamValues:    []string{"n1", orc8r.NetworkFeaturesConfig, "0"},
+		Handler:        restore,
+		ExpectedStatus: 400,
+		ExpectedError:  "revision must be a positive integer",
+	}
+	tests.RunUnitTest(t, e, tc)
+}
+
+func Test_EntityConfigHistoryHandlers(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	test_init.StartTestService(t)
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	e := echo.New()
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	listHistory := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/entity_config_history/:entity_type/:entity_key", obsidian.GET).HandlerFunc
+	restore := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/entity_config_history/:entity_type/:entity_key/:revision/restore", obsidian.POST).HandlerFunc
+
+	seedNetworks(t)
+	tier := &models.Tier{ID: "t1", Name: "tier 1", Version: "1.0.0", Images: models.TierImages{}, Gateways: models.TierGateways{}}
+	_, err := configurator.CreateEntity("n1", tier.ToNetworkEntity())
+	assert.NoError(t, err)
+	err = configurator.DeleteEntityConfig("n1", orc8r.UpgradeTierEntityType, "t1")
+	assert.NoError(t, err)
+
+	createdAt := strfmt.DateTime(time.Unix(1000000, 0))
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/entity_config_history/" + orc8r.UpgradeTierEntityType + "/t1",
+		ParamNames:     []string{"network_id", "entity_type", "entity_key"},
+		ParamValues:    []string{"n1", orc8r.UpgradeTierEntityType, "t1"},
+		Handler:        listHistory,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.ConfigRevision{
+			{
+				Revision:  swag.Uint64(2),
+				Deleted:   swag.Bool(true),
+				Diff:      "--- revision 1\n+++ revision 2\n@@ -1,7 +0,0 @@\n-{\n-  \"gateways\": [],\n-  \"id\": \"t1\",\n-  \"images\": [],\n-  \"name\": \"tier 1\",\n-  \"version\": \"1.0.0\"\n-}\n",
+				CreatedAt: &createdAt,
+			},
+			{Revision: swag.Uint64(1), Config: tier, Deleted: swag.Bool(false), CreatedAt: &createdAt},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n1/entity_config_history/" + orc8r.UpgradeTierEntityType + "/t1/1/restore",
+		ParamNames:     []string{"network_id", "entity_type", "entity_key", "revision"},
+		ParamValues:    []string{"n1", orc8r.UpgradeTierEntityType, "t1", "1"},
+		Handler:        restore,
+		ExpectedStatus: 200,
+		ExpectedResult: &models.ConfigRevision{Revision: swag.Uint64(3), Config: tier, Deleted: swag.Bool(false), CreatedAt: &createdAt},
+	}
+	tests.RunUnitTest(t, e, tc)
+	actual, err := configurator.LoadEntityConfig("n1", orc8r.UpgradeTierEntityType, "t1")
+	assert.NoError(t, err)
+	assert.Equal(t, tier, actual)
+
+	// Label updates are recorded and diffed along with the config
+	_, err = configurator.UpdateEntity("n1", configurator.EntityUpdateCriteria{
+		Type: orc8r.UpgradeTierEntityType, Key: "t1",
+		LabelsToSet: map[string]string{"region": "west"},
+	})
+	assert.NoError(t, err)
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/entity_config_history/" + orc8r.UpgradeTierEntityType + "/t1",
+		ParamNames:     []string{"network_id", "entity_type", "entity_key"},
+		ParamValues:    []string{"n1", orc8r.UpgradeTierEntityType, "t1"},
+		Handler:        listHistory,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.ConfigRevision{
+			{
+				Revision:  swag.Uint64(4),
+				Config:    tier,
+				Labels:    map[string]string{"region": "west"},
+				Deleted:   swag.Bool(false),
+				Diff:      "--- revision 3\n+++ revision 4\n@@ -5,3 +5,4 @@\n   \"name\": \"tier 1\",\n   \"version\": \"1.0.0\"\n }\n+label region=west\n",
+				CreatedAt: &createdAt,
+			},
+			{
+				Revision:  swag.Uint64(3),
+				Config:    tier,
+				Deleted:   swag.Bool(false),
+				Diff:      "--- revision 2\n+++ revision 3\n@@ -0,0 +1,7 @@\n+{\n+  \"gateways\": [],\n+  \"id\": \"t1\",\n+  \"images\": [],\n+  \"name\": \"tier 1\",\n+  \"version\": \"1.0.0\"\n+}\n",
+				CreatedAt: &createdAt,
+			},
+			{
+				Revision:  swag.Uint64(2),
+				Deleted:   swag.Bool(true),
+				Diff:      "--- revision 1\n+++ revision 2\n@@ -1,7 +0,0 @@\n-{\n-  \"gateways\": [],\n-  \"id\": \"t1\",\n-  \"images\": [],\n-  \"name\": \"tier 1\",\n-  \"version\": \"1.0.0\"\n-}\n",
+				CreatedAt: &createdAt,
+			},
+			{Revision: swag.Uint64(1), Config: tier, Deleted: swag.Bool(false), CreatedAt: &createdAt},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Restoring the config of an entity which doesn't exist
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/networks/n1/entity_config_history/" + orc8r.UpgradeTierEntityType + "/t2/1/restore",
+		ParamNames:     []string{"network_id", "entity_type", "entity_key", "revision"},
+		ParamValues:    []string{"n1", orc8r.UpgradeTierEntityType, "t2", "1"},
+		Handler:        restore,
+		ExpectedStatus: 404,
+		ExpectedError:  "Not Found",
+	}
+	tests.RunUnitTest(t, e, tc)
+}
@@ -13,6 +13,7 @@ import (
 
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
 
@@ -111,7 +112,7 @@ func GetPartialUpdateEntityHandler(path string, paramName string, model PartialE
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusBadRequest)
 			}
-			_, err = configurator.UpdateEntities(networkID, updates)
+			_, err = access.ConfigWriter(c).UpdateEntities(networkID, updates)
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusInternalServerError)
 			}
@@ -10,9 +10,11 @@ package handlers
 
 import (
 	"net/http"
+	"reflect"
 
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/serde"
@@ -124,7 +126,7 @@ func GetPartialUpdateGatewayHandler(path string, model PartialGatewayModel) obsi
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusBadRequest)
 			}
-			_, err = configurator.UpdateEntities(networkID, updates)
+			_, err = access.ConfigWriter(c).UpdateEntities(networkID, updates)
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusInternalServerError)
 			}
@@ -203,6 +205,62 @@ func GetUpdateGatewayDeviceHandler(path string) obsidian.Handler {
 	}
 }
 
+// GetRotateGatewayChallengeKeyHandler returns a POST handler to rotate the
+// challenge key of the gateway's device. The posted key becomes the device's
+// key and the replaced key is kept as the device's previous key, which
+// bootstrapper keeps accepting until the gateway bootstraps with the new key.
+// Rotating again before that replaces the new key and keeps the previous one.
+func GetRotateGatewayChallengeKeyHandler(path string) obsidian.Handler {
+	return obsidian.Handler{
+		Path:    path,
+		Methods: obsidian.POST,
+		HandlerFunc: func(c echo.Context) error {
+			networkID, gatewayID, nerr := obsidian.GetNetworkAndGatewayIDs(c)
+			if nerr != nil {
+				return nerr
+			}
+			payload, nerr := GetAndValidatePayload(c, &models.ChallengeKey{})
+			if nerr != nil {
+				return nerr
+			}
+			newKey := payload.(*models.ChallengeKey)
+
+			physicalID, err := configurator.GetPhysicalIDOfEntity(networkID, orc8r.MagmadGatewayType, gatewayID)
+			if err == merrors.ErrNotFound {
+				return obsidian.HttpError(err, http.StatusNotFound)
+			} else if err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			iDevice, err := device.GetDevice(networkID, orc8r.AccessGatewayRecordType, physicalID)
+			if err == merrors.ErrNotFound {
+				return obsidian.HttpError(err, http.StatusNotFound)
+			} else if err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			gwDevice, ok := iDevice.(*models.GatewayDevice)
+			if !ok {
+				return obsidian.HttpError(errors.Errorf("unexpected device type %T", iDevice), http.StatusInternalServerError)
+			}
+			if reflect.DeepEqual(gwDevice.Key, newKey) {
+				return obsidian.HttpError(errors.New("new challenge key is the same as the current key"), http.StatusBadRequest)
+			}
+
+			// while a rotation is pending the gateway may still hold the
+			// previous key, so it's kept until the gateway bootstraps with the
+			// new key
+			if gwDevice.PreviousKey == nil {
+				gwDevice.PreviousKey = gwDevice.Key
+			}
+			gwDevice.Key = newKey
+			err = device.UpdateDevice(networkID, orc8r.AccessGatewayRecordType, physicalID, gwDevice)
+			if err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			return c.NoContent(http.StatusNoContent)
+		},
+	}
+}
+
 func GetListGatewaysHandler(path string, gatewayType string, makeTypedGateways MakeTypedGateways) obsidian.Handler {
 	return obsidian.Handler{
 		Path:    path,
@@ -212,43 +270,41 @@ func GetListGatewaysHandler(path string, gatewayType string, makeTypedGateways M
 			if nerr != nil {
 				return nerr
 			}
-
-			ids, err := configurator.ListEntityKeys(nid, gatewayType)
+			networkExists, err := configurator.DoesNetworkExist(nid)
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusInternalServerError)
 			}
-			// for each ID, we want to load the gateway and the magmad gateway
-			magmadTKs := make([]storage.TypeAndKey, 0, len(ids))
-			for _, id := range ids {
+			if !networkExists {
+				return obsidian.HttpError(merrors.ErrNotFound, http.StatusInternalServerError)
+			}
+
+			gwEnts, nerr := LoadEntitiesForList(c, nid, gatewayType, configurator.FullEntityLoadCriteria())
+			if nerr != nil {
+				return nerr
+			}
+			// for each gateway, we also want to load the magmad gateway
+			magmadTKs := make([]storage.TypeAndKey, 0, len(gwEnts))
+			for _, gw := range gwEnts {
 				magmadTKs = append(
 					magmadTKs,
-					storage.TypeAndKey{Type: orc8r.MagmadGatewayType, Key: id},
-				)
-			}
-			gwTKs := make([]storage.TypeAndKey, 0, len(ids))
-			for _, id := range ids {
-				gwTKs = append(
-					gwTKs,
-					storage.TypeAndKey{Type: gatewayType, Key: id},
+					storage.TypeAndKey{Type: orc8r.MagmadGatewayType, Key: gw.Key},
 				)
 			}
 
-			// we need the two calls because LoadEntities only takes one type filter per call
-			// and we need the type filters in case the TKs are empty
-			magmadGWEnts, _, err := configurator.LoadEntities(nid, swag.String(orc8r.MagmadGatewayType), nil, nil, magmadTKs, configurator.FullEntityLoadCriteria())
-			gwEnts, _, err := configurator.LoadEntities(nid, swag.String(gatewayType), nil, nil, gwTKs, configurator.FullEntityLoadCriteria())
 			ents := configurator.NetworkEntities{}
-			for _, m := range magmadGWEnts {
-				ents = append(ents, m)
-			}
-			for _, g := range gwEnts {
-				ents = append(ents, g)
+			if len(magmadTKs) > 0 {
+				magmadGWEnts, _, err := configurator.LoadEntities(nid, swag.String(orc8r.MagmadGatewayType), nil, nil, magmadTKs, configurator.FullEntityLoadCriteria())
+				if err != nil {
+					return obsidian.HttpError(err, http.StatusInternalServerError)
+				}
+				ents = append(ents, magmadGWEnts...)
 			}
+			ents = append(ents, gwEnts...)
 			entsByTK := ents.ToEntitiesByID()
 
 			// for each magmad gateway, we have to load its corresponding device and
 			// its reported status
-			deviceIDs := make([]string, 0, len(ids))
+			deviceIDs := make([]string, 0, len(gwEnts))
 			for tk, ent := range entsByTK {
 				if tk.Type == orc8r.MagmadGatewayType && ent.PhysicalID != "" {
 					deviceIDs = append(deviceIDs, ent.PhysicalID)
@@ -277,15 +333,20 @@ func GetDeleteGatewayHandler(path string, gatewayType string) obsidian.Handler {
 				return nerr
 			}
 
-			err := configurator.DeleteEntities(
-				nid,
-				[]storage.TypeAndKey{
-					{Type: orc8r.MagmadGatewayType, Key: gid},
-					{Type: gatewayType, Key: gid},
-				},
+			mdGatewayEnt, err := configurator.LoadEntity(
+				nid, orc8r.MagmadGatewayType, gid,
+				configurator.EntityLoadCriteria{LoadAssocsToThis: true},
 			)
-			if err != nil {
-				return obsidian.HttpError(err, http.StatusInternalServerError)
+			switch {
+			case err == merrors.ErrNotFound:
+				return echo.ErrNotFound
+			case err != nil:
+				return obsidian.HttpError(errors.Wrap(err, "failed to load gateway"), http.StatusInternalServerError)
+			}
+
+			nerr = deleteGatewayIfMatch(c, nid, mdGatewayEnt, storage.TypeAndKey{Type: gatewayType, Key: gid})
+			if nerr != nil {
+				return nerr
 			}
 			return c.NoContent(http.StatusNoContent)
 		},
@@ -9,6 +9,11 @@
 package handlers_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"fmt"
 	"testing"
 
@@ -21,8 +26,10 @@ import (
 	"magma/orc8r/cloud/go/services/configurator"
 	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
 	"magma/orc8r/cloud/go/services/configurator/test_utils"
+	"magma/orc8r/cloud/go/services/device"
 	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
@@ -188,6 +195,88 @@ func Test_GetGatewayDeviceHandler(t *testing.T) {
 	tests.RunUnitTest(t, e, tc)
 }
 
+func Test_RotateGatewayChallengeKeyHandler(t *testing.T) {
+	plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	e := echo.New()
+	testURLRoot := "/magma/v1/networks"
+
+	networkID := "test-network"
+	test_utils.RegisterNetwork(t, networkID, "Name")
+
+	rotateURL := fmt.Sprintf("%s/:network_id/gateways/:gateway_id/device/rotate_key", testURLRoot)
+	rotateKey := handlers.GetRotateGatewayChallengeKeyHandler(rotateURL)
+
+	ed25519PubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	marshaledPubKey, err := x509.MarshalPKIXPublicKey(ed25519PubKey)
+	assert.NoError(t, err)
+	ed25519KeyB64 := strfmt.Base64(marshaledPubKey)
+	newKey := &models.ChallengeKey{KeyType: "SOFTWARE_ED25519", Key: &ed25519KeyB64}
+
+	// 404
+	tc := tests.Test{
+		Method:         "POST",
+		URL:            rotateURL,
+		Payload:        newKey,
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{networkID, "test_gateway_1"},
+		Handler:        rotateKey.HandlerFunc,
+		ExpectedStatus: 404,
+		ExpectedError:  "Not found",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	oldKey := &models.ChallengeKey{KeyType: "ECHO"}
+	test_utils.RegisterGateway(t, networkID, "test_gateway_1", &models.GatewayDevice{HardwareID: "test_hardware_id", Key: oldKey})
+
+	// key type doesn't match the key
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	marshaledPubKey, err = x509.MarshalPKIXPublicKey(&p256Key.PublicKey)
+	assert.NoError(t, err)
+	p256KeyB64 := strfmt.Base64(marshaledPubKey)
+	tc.Payload = &models.ChallengeKey{KeyType: "SOFTWARE_ECDSA_SHA384", Key: &p256KeyB64}
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "Key is not an ECDSA P-384 public key"
+	tests.RunUnitTest(t, e, tc)
+
+	// happy path
+	tc.Payload = newKey
+	tc.ExpectedStatus = 204
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+
+	actual, err := device.GetDevice(networkID, orc8r.AccessGatewayRecordType, "test_hardware_id")
+	assert.NoError(t, err)
+	expected := &models.GatewayDevice{HardwareID: "test_hardware_id", Key: newKey, PreviousKey: oldKey}
+	assert.Equal(t, expected, actual)
+
+	// rotating to the current key is rejected
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "new challenge key is the same as the current key"
+	tests.RunUnitTest(t, e, tc)
+
+	// rotating again while a rotation is pending keeps the previous key,
+	// which the gateway still holds
+	ed25519PubKey, _, err = ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	marshaledPubKey, err = x509.MarshalPKIXPublicKey(ed25519PubKey)
+	assert.NoError(t, err)
+	otherKeyB64 := strfmt.Base64(marshaledPubKey)
+	otherKey := &models.ChallengeKey{KeyType: "SOFTWARE_ED25519", Key: &otherKeyB64}
+	tc.Payload = otherKey
+	tc.ExpectedStatus = 204
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+
+	actual, err = device.GetDevice(networkID, orc8r.AccessGatewayRecordType, "test_hardware_id")
+	assert.NoError(t, err)
+	expected = &models.GatewayDevice{HardwareID: "test_hardware_id", Key: otherKey, PreviousKey: oldKey}
+	assert.Equal(t, expected, actual)
+}
+
 type testName struct {
 	Name string
 }
@@ -13,6 +13,7 @@ import (
 
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/serde"
@@ -21,7 +22,6 @@ import (
 	"magma/orc8r/cloud/go/services/state"
 	"magma/orc8r/cloud/go/storage"
 
-	"github.com/go-openapi/swag"
 	"github.com/labstack/echo"
 	"github.com/pkg/errors"
 )
@@ -74,11 +74,11 @@ func ListGatewaysHandler(c echo.Context) error {
 		return nerr
 	}
 
-	ents, _, err := configurator.LoadEntities(nid, swag.String(orc8r.MagmadGatewayType), nil, nil, nil, configurator.FullEntityLoadCriteria())
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	ents, nerr := LoadEntitiesForList(c, nid, orc8r.MagmadGatewayType, configurator.FullEntityLoadCriteria())
+	if nerr != nil {
+		return nerr
 	}
-	entsByTK := ents.ToEntitiesByID()
+	entsByTK := configurator.NetworkEntities(ents).ToEntitiesByID()
 
 	// for each magmad gateway, we have to load its corresponding device and
 	// its reported status
@@ -175,7 +175,7 @@ func CreateMagmadGatewayFromModel(c echo.Context, model MagmadEncompassingGatewa
 		writes = append(writes, encompassingGateway.GetAdditionalWritesOnCreate()...)
 	}
 
-	if err = configurator.WriteEntities(nid, writes...); err != nil {
+	if err = access.ConfigWriter(c).WriteEntities(nid, writes...); err != nil {
 		return obsidian.HttpError(errors.Wrap(err, "failed to create gateway"), http.StatusInternalServerError)
 	}
 	return nil
@@ -186,14 +186,20 @@ func GetGatewayHandler(c echo.Context) error {
 	if nerr != nil {
 		return nerr
 	}
-	ret, nerr := LoadMagmadGatewayModel(nid, gid)
+	ret, versions, nerr := LoadMagmadGatewayModel(nid, gid)
 	if nerr != nil {
 		return nerr
 	}
+	obsidian.SetETag(c, versions...)
 	return c.JSON(http.StatusOK, ret)
 }
 
-func LoadMagmadGatewayModel(networkID string, gatewayID string) (*models.MagmadGateway, *echo.HTTPError) {
+// LoadMagmadGatewayModel loads a magmad gateway along with its device and
+// status. The versions of the magmad gateway entity and of its upgrade tier
+// are also returned, to be set as the ETag of GET responses for the gateway.
+// Gateway models built from additional entities should append the versions
+// of those entities in the order of GetAdditionalEntitiesToLoadOnUpdate.
+func LoadMagmadGatewayModel(networkID string, gatewayID string) (*models.MagmadGateway, []uint64, *echo.HTTPError) {
 	ent, err := configurator.LoadEntity(
 		networkID, orc8r.MagmadGatewayType, gatewayID,
 		configurator.EntityLoadCriteria{
@@ -201,22 +207,27 @@ func LoadMagmadGatewayModel(networkID string, gatewayID string) (*models.MagmadG
 			LoadConfig:         true,
 			LoadAssocsToThis:   true,
 			LoadAssocsFromThis: false,
+			LoadLabels:         true,
 		},
 	)
 	if err == merrors.ErrNotFound {
-		return nil, echo.ErrNotFound
+		return nil, nil, echo.ErrNotFound
 	}
 	if err != nil {
-		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+		return nil, nil, obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
 	dev, err := device.GetDevice(networkID, orc8r.AccessGatewayRecordType, ent.PhysicalID)
 	if err != nil && err != merrors.ErrNotFound {
-		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+		return nil, nil, obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 	status, err := state.GetGatewayStatus(networkID, ent.PhysicalID)
 	if err != nil && err != merrors.ErrNotFound {
-		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+		return nil, nil, obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	tierVersion, err := getTierVersion(networkID, ent)
+	if err != nil {
+		return nil, nil, obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
 	// If the gateway/network is malformed, we could get no corresponding
@@ -225,7 +236,7 @@ func LoadMagmadGatewayModel(networkID string, gatewayID string) (*models.MagmadG
 	if dev != nil {
 		devCasted = dev.(*models.GatewayDevice)
 	}
-	return (&models.MagmadGateway{}).FromBackendModels(ent, devCasted, status), nil
+	return (&models.MagmadGateway{}).FromBackendModels(ent, devCasted, status), []uint64{ent.Version, tierVersion}, nil
 }
 
 func UpdateGatewayHandler(c echo.Context) error {
@@ -250,11 +261,13 @@ func UpdateMagmadGatewayFromModel(c echo.Context, nid string, gid string, model
 
 	entsToLoad := []storage.TypeAndKey{}
 	entsToLoad = append(entsToLoad, mdGateway.GetAdditionalEntitiesToLoadOnUpdate(gid)...)
+	additionalEntIDs := []storage.TypeAndKey{}
 	switch payload.(type) {
 	case *models.MagmadGateway:
 		break
 	default:
-		entsToLoad = append(entsToLoad, encompassingGateway.GetAdditionalEntitiesToLoadOnUpdate(gid)...)
+		additionalEntIDs = encompassingGateway.GetAdditionalEntitiesToLoadOnUpdate(gid)
+		entsToLoad = append(entsToLoad, additionalEntIDs...)
 	}
 
 	loadedEnts, _, err := configurator.LoadEntities(
@@ -271,10 +284,16 @@ func UpdateMagmadGatewayFromModel(c echo.Context, nid string, gid string, model
 	if nerr != nil {
 		return nerr
 	}
+	loadedEntsByID := loadedEnts.ToEntitiesByID()
+	mdGatewayEnt := loadedEntsByID[storage.TypeAndKey{Type: orc8r.MagmadGatewayType, Key: gid}]
+	nerr = setExpectedGatewayVersions(c, nid, mdGatewayEnt, loadedEntsByID, writes, additionalEntIDs...)
+	if nerr != nil {
+		return nerr
+	}
 
-	err = configurator.WriteEntities(nid, writes...)
+	err = access.ConfigWriter(c).WriteEntities(nid, writes...)
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return WriteErrorToHttpError(err)
 	}
 
 	// device info is cheap to update, so just do it all the time if
@@ -318,6 +337,102 @@ func getUpdateWrites(gid string, payload MagmadEncompassingGateway, loadedEnts c
 	return writes, nil
 }
 
+// setExpectedGatewayVersions conditions the writes to a gateway on the
+// versions in the request's If-Match header, which must be the gateway's
+// ETag. The ETag holds the versions of the magmad gateway, its upgrade tier,
+// and any additional entities the gateway model is built from.
+// Writes to those entities are conditioned on their expected versions. The
+// versions of entities which aren't written are compared to their current
+// versions instead.
+func setExpectedGatewayVersions(
+	c echo.Context,
+	networkID string,
+	mdGatewayEnt configurator.NetworkEntity,
+	loadedEntsByID map[storage.TypeAndKey]configurator.NetworkEntity,
+	writes []configurator.EntityWriteOperation,
+	additionalEntIDs ...storage.TypeAndKey,
+) *echo.HTTPError {
+	expectedVersions, nerr := obsidian.GetIfMatchVersions(c, 2+len(additionalEntIDs))
+	if nerr != nil || expectedVersions == nil {
+		return nerr
+	}
+
+	tierID, _ := mdGatewayEnt.GetFirstParentOfType(orc8r.UpgradeTierEntityType)
+	ids := append([]storage.TypeAndKey{mdGatewayEnt.GetTypeAndKey(), tierID}, additionalEntIDs...)
+	for i, id := range ids {
+		if setExpectedVersion(writes, id, expectedVersions[i]) {
+			continue
+		}
+
+		currentVersion := loadedEntsByID[id].Version
+		if id == tierID {
+			tierVersion, err := getTierVersion(networkID, mdGatewayEnt)
+			if err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			currentVersion = tierVersion
+		}
+		if currentVersion != expectedVersions[i] {
+			return obsidian.ErrPreconditionFailed
+		}
+	}
+	return nil
+}
+
+// setExpectedVersion conditions the update of an entity in a list of writes
+// on the entity's current version. It returns false if the entity isn't
+// written.
+func setExpectedVersion(writes []configurator.EntityWriteOperation, id storage.TypeAndKey, expectedVersion uint64) bool {
+	found := false
+	for i, write := range writes {
+		update, ok := write.(configurator.EntityUpdateCriteria)
+		if ok && update.GetTypeAndKey() == id {
+			update.ExpectedVersion = &expectedVersion
+			writes[i] = update
+			found = true
+		}
+	}
+	return found
+}
+
+// getTierVersion returns the version of the upgrade tier of a magmad gateway
+// entity loaded with its parent associations, or 0 if it has no tier.
+func getTierVersion(networkID string, mdGatewayEnt configurator.NetworkEntity) (uint64, error) {
+	tierID, err := mdGatewayEnt.GetFirstParentOfType(orc8r.UpgradeTierEntityType)
+	if err == merrors.ErrNotFound {
+		return 0, nil
+	}
+	tierEnt, err := configurator.LoadEntity(networkID, tierID.Type, tierID.Key, configurator.EntityLoadCriteria{})
+	switch {
+	case err == merrors.ErrNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, errors.Wrap(err, "failed to load upgrade tier")
+	}
+	return tierEnt.Version, nil
+}
+
+// deleteGatewayIfMatch deletes a magmad gateway and any additional entities
+// its gateway model is built from in a single transaction. If the request
+// has an If-Match header, the deletion is conditioned on the gateway's ETag.
+func deleteGatewayIfMatch(c echo.Context, networkID string, mdGatewayEnt configurator.NetworkEntity, additionalEntIDs ...storage.TypeAndKey) *echo.HTTPError {
+	writes := []configurator.EntityWriteOperation{
+		configurator.EntityUpdateCriteria{Type: orc8r.MagmadGatewayType, Key: mdGatewayEnt.Key, DeleteEntity: true},
+	}
+	for _, id := range additionalEntIDs {
+		writes = append(writes, configurator.EntityUpdateCriteria{Type: id.Type, Key: id.Key, DeleteEntity: true})
+	}
+	nerr := setExpectedGatewayVersions(c, networkID, mdGatewayEnt, nil, writes, additionalEntIDs...)
+	if nerr != nil {
+		return nerr
+	}
+	err := access.ConfigWriter(c).WriteEntities(networkID, writes...)
+	if err != nil {
+		return WriteErrorToHttpError(err)
+	}
+	return nil
+}
+
 func DeleteGatewayHandler(c echo.Context) error {
 	nid, gid, nerr := obsidian.GetNetworkAndGatewayIDs(c)
 	if nerr != nil {
@@ -335,9 +450,9 @@ func DeleteGatewayHandler(c echo.Context) error {
 		return obsidian.HttpError(errors.Wrap(err, "failed to load gateway"), http.StatusInternalServerError)
 	}
 
-	err = configurator.DeleteEntity(nid, orc8r.MagmadGatewayType, gid)
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	nerr = deleteGatewayIfMatch(c, nid, existingEnt)
+	if nerr != nil {
+		return nerr
 	}
 
 	if existingEnt.PhysicalID != "" {
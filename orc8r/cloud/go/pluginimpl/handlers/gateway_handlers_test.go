@@ -97,6 +97,45 @@ func TestListGateways(t *testing.T) {
 	}
 	tc.ExpectedResult = tests.JSONMarshaler(expectedResult)
 	tests.RunUnitTest(t, e, tc)
+
+	// paginated
+	_, nextPageToken, err := configurator.LoadEntitiesPage("n1", orc8r.MagmadGatewayType, nil, configurator.EntityLoadCriteria{PageSize: 1})
+	assert.NoError(t, err)
+	tc.URL = testURLRoot + "?page_size=1"
+	tc.ExpectedHeaders = map[string]string{obsidian.NextPageTokenHeader: nextPageToken}
+	tc.ExpectedResult = tests.JSONMarshaler(map[string]models.MagmadGateway{
+		"g1": {ID: "g1", Magmad: &models.MagmadGatewayConfigs{}, Device: gatewayRecord, Status: expectedState},
+	})
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "?page_size=1&page_token=" + nextPageToken
+	tc.ExpectedHeaders = nil
+	tc.ExpectedResult = tests.JSONMarshaler(map[string]models.MagmadGateway{
+		"g2": {ID: "g2", Magmad: &models.MagmadGatewayConfigs{CheckinInterval: 15}},
+	})
+	tests.RunUnitTest(t, e, tc)
+
+	// label selector
+	_, err = configurator.UpdateEntity("n1", configurator.EntityUpdateCriteria{
+		Type:        orc8r.MagmadGatewayType,
+		Key:         "g2",
+		LabelsToSet: map[string]string{"region": "west"},
+	})
+	assert.NoError(t, err)
+	tc.URL = testURLRoot + "?label_selector=region%3Dwest"
+	tc.ExpectedResult = tests.JSONMarshaler(map[string]models.MagmadGateway{
+		"g2": {ID: "g2", Magmad: &models.MagmadGatewayConfigs{CheckinInterval: 15}, Labels: map[string]string{"region": "west"}},
+	})
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "?label_selector=region%3Deast"
+	tc.ExpectedResult = tests.JSONMarshaler(map[string]models.MagmadGateway{})
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = testURLRoot + "?label_selector=region"
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "label_selector must be a comma-separated list of key=value pairs"
+	tests.RunUnitTest(t, e, tc)
 }
 
 func TestCreateGateway(t *testing.T) {
@@ -213,7 +252,8 @@ func TestCreateGateway(t *testing.T) {
 			AutoupgradePollInterval: 300,
 			AutoupgradeEnabled:      swag.Bool(true),
 		},
-		Tier: "t2",
+		Tier:   "t2",
+		Labels: map[string]string{"region": "west"},
 	}
 	tc.Payload = payload
 	tests.RunUnitTest(t, e, tc)
@@ -239,6 +279,7 @@ func TestCreateGateway(t *testing.T) {
 			Config:             payload.Magmad,
 			ParentAssociations: []storage.TypeAndKey{{Type: orc8r.UpgradeTierEntityType, Key: "t2"}},
 			GraphID:            "4",
+			Labels:             map[string]string{"region": "west"},
 		},
 		{
 			NetworkID: "n1", Type: orc8r.UpgradeTierEntityType, Key: "t2",
@@ -307,6 +348,12 @@ func TestCreateGateway(t *testing.T) {
 		ExpectedError:  "device foo-bar-baz-123-42 is already mapped to gateway g1",
 	}
 	tests.RunUnitTest(t, e, tc)
+
+	// empty label key
+	payload.Device.HardwareID = "doesnt-matter"
+	payload.Labels = map[string]string{"": "west"}
+	tc.ExpectedError = "label keys must be non-empty"
+	tests.RunUnitTest(t, e, tc)
 }
 
 func TestGetGateway(t *testing.T) {
@@ -346,6 +393,7 @@ func TestGetGateway(t *testing.T) {
 					CheckinInterval:         15,
 					CheckinTimeout:          5,
 				},
+				Labels: map[string]string{"region": "west"},
 			},
 			{
 				Type: orc8r.UpgradeTierEntityType, Key: "t1",
@@ -389,17 +437,18 @@ func TestGetGateway(t *testing.T) {
 	expected.Status.CertExpirationTime = time.Unix(1000000, 0).Add(time.Hour * 4).Unix()
 
 	tc := tests.Test{
-		Method:         "GET",
-		URL:            testURLRoot + "/g1",
-		Handler:        getGateway,
-		ParamNames:     []string{"network_id", "gateway_id"},
-		ParamValues:    []string{"n1", "g1"},
-		ExpectedStatus: 200,
-		ExpectedResult: expected,
+		Method:          "GET",
+		URL:             testURLRoot + "/g1",
+		Handler:         getGateway,
+		ParamNames:      []string{"network_id", "gateway_id"},
+		ParamValues:     []string{"n1", "g1"},
+		ExpectedStatus:  200,
+		ExpectedResult:  expected,
+		ExpectedHeaders: map[string]string{"ETag": `"0.0"`},
 	}
 	tests.RunUnitTest(t, e, tc)
 
-	// get a gateway without a device or status
+	// get a labeled gateway without a device or status
 	expected = &models.MagmadGateway{
 		ID:   "g2",
 		Name: "barfoo", Description: "bar foo",
@@ -410,6 +459,7 @@ func TestGetGateway(t *testing.T) {
 			CheckinInterval:         15,
 			CheckinTimeout:          5,
 		},
+		Labels: map[string]string{"region": "west"},
 	}
 	tc = tests.Test{
 		Method:         "GET",
@@ -456,6 +506,7 @@ func TestUpdateGateway(t *testing.T) {
 					CheckinInterval:         15,
 					CheckinTimeout:          5,
 				},
+				Labels: map[string]string{"region": "west"},
 			},
 			{
 				Type: orc8r.UpgradeTierEntityType, Key: "t1",
@@ -496,7 +547,8 @@ func TestUpdateGateway(t *testing.T) {
 			FeatureFlags:            map[string]bool{"foo": false},
 			DynamicServices:         []string{"d1", "d2"},
 		},
-		Tier: "t2",
+		Tier:   "t2",
+		Labels: map[string]string{"region": "east"},
 	}
 
 	tc := tests.Test{
@@ -504,12 +556,31 @@ func TestUpdateGateway(t *testing.T) {
 		URL:            testURLRoot + "/g1",
 		Handler:        updateGateway,
 		Payload:        payload,
+		Headers:        map[string]string{"If-Match": `"0.0"`},
 		ParamNames:     []string{"network_id", "gateway_id"},
 		ParamValues:    []string{"n1", "g1"},
 		ExpectedStatus: 204,
 	}
 	tests.RunUnitTest(t, e, tc)
 
+	// stale If-Match, nothing should be written
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot + "/g1",
+		Handler:        updateGateway,
+		Payload:        &models.MagmadGateway{Device: payload.Device, ID: "g1", Name: "stale", Description: "stale", Magmad: payload.Magmad, Tier: "t1"},
+		Headers:        map[string]string{"If-Match": `"0.0"`},
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// the upgrade tier changed since the gateway was read
+	tc.Headers = map[string]string{"If-Match": `"1.0"`}
+	tests.RunUnitTest(t, e, tc)
+
 	// load and validate
 	actualEnts, _, err := configurator.LoadEntities(
 		"n1", nil, nil, nil,
@@ -533,6 +604,7 @@ func TestUpdateGateway(t *testing.T) {
 			ParentAssociations: []storage.TypeAndKey{{Type: orc8r.UpgradeTierEntityType, Key: "t2"}},
 			GraphID:            "6",
 			Version:            1,
+			Labels:             map[string]string{"region": "east"},
 		},
 		{NetworkID: "n1", Type: orc8r.UpgradeTierEntityType, Key: "t1", GraphID: "2", Version: 1},
 		{
@@ -601,6 +673,19 @@ func TestDeleteGateway(t *testing.T) {
 		Method:         "DELETE",
 		URL:            testURLRoot + "/g1",
 		Handler:        deleteGateway,
+		Headers:        map[string]string{"If-Match": `"1.0"`},
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            testURLRoot + "/g1",
+		Handler:        deleteGateway,
+		Headers:        map[string]string{"If-Match": `"0.0"`},
 		ParamNames:     []string{"network_id", "gateway_id"},
 		ParamValues:    []string{"n1", "g1"},
 		ExpectedStatus: 204,
@@ -16,6 +16,7 @@ import (
 	"magma/orc8r/cloud/go/orc8r"
 	models2 "magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/services/magmad/jobs"
 
 	"github.com/labstack/echo"
 	"github.com/olivere/elastic/v7"
@@ -33,16 +34,31 @@ const (
 	ManageNetworkDNSPath               = ManageNetworkPath + obsidian.UrlSep + "dns"
 	ManageNetworkDNSRecordsPath        = ManageNetworkDNSPath + obsidian.UrlSep + "records"
 	ManageNetworkDNSRecordByDomainPath = ManageNetworkDNSRecordsPath + obsidian.UrlSep + ":domain"
-
-	Gateways                     = "gateways"
-	ListGatewaysPath             = ManageNetworkPath + obsidian.UrlSep + Gateways
-	ManageGatewayPath            = ListGatewaysPath + obsidian.UrlSep + ":gateway_id"
-	ManageGatewayNamePath        = ManageGatewayPath + obsidian.UrlSep + "name"
-	ManageGatewayDescriptionPath = ManageGatewayPath + obsidian.UrlSep + "description"
-	ManageGatewayConfigPath      = ManageGatewayPath + obsidian.UrlSep + "magmad"
-	ManageGatewayDevicePath      = ManageGatewayPath + obsidian.UrlSep + "device"
-	ManageGatewayStatePath       = ManageGatewayPath + obsidian.UrlSep + "status"
-	ManageGatewayTierPath        = ManageGatewayPath + obsidian.UrlSep + "tier"
+	ManageNetworkMetricsConfigPath     = ManageNetworkPath + obsidian.UrlSep + "metrics_config"
+
+	NetworkConfigHistoryPath = ManageNetworkPath + obsidian.UrlSep + "config_history" + obsidian.UrlSep + ":config_type"
+	RestoreNetworkConfigPath = NetworkConfigHistoryPath + obsidian.UrlSep + ":revision" + obsidian.UrlSep + "restore"
+	EntityConfigHistoryPath  = ManageNetworkPath + obsidian.UrlSep + "entity_config_history" + obsidian.UrlSep + ":entity_type" + obsidian.UrlSep + ":entity_key"
+	RestoreEntityConfigPath  = EntityConfigHistoryPath + obsidian.UrlSep + ":revision" + obsidian.UrlSep + "restore"
+
+	NetworkAuditPath = ManageNetworkPath + obsidian.UrlSep + "audit"
+
+	BatchJobs          = "jobs"
+	ListBatchJobsPath  = ManageNetworkPath + obsidian.UrlSep + BatchJobs
+	ManageBatchJobPath = ListBatchJobsPath + obsidian.UrlSep + ":job_id"
+	CancelBatchJobPath = ManageBatchJobPath + obsidian.UrlSep + "cancel"
+
+	Gateways                         = "gateways"
+	ListGatewaysPath                 = ManageNetworkPath + obsidian.UrlSep + Gateways
+	ManageGatewayPath                = ListGatewaysPath + obsidian.UrlSep + ":gateway_id"
+	ManageGatewayNamePath            = ManageGatewayPath + obsidian.UrlSep + "name"
+	ManageGatewayDescriptionPath     = ManageGatewayPath + obsidian.UrlSep + "description"
+	ManageGatewayConfigPath          = ManageGatewayPath + obsidian.UrlSep + "magmad"
+	ManageGatewayDevicePath          = ManageGatewayPath + obsidian.UrlSep + "device"
+	ManageGatewayRotateKeyPath       = ManageGatewayDevicePath + obsidian.UrlSep + "rotate_key"
+	ManageGatewayStatePath           = ManageGatewayPath + obsidian.UrlSep + "status"
+	ManageGatewayTierPath            = ManageGatewayPath + obsidian.UrlSep + "tier"
+	ManageGatewayPendingRequestsPath = ManageGatewayPath + obsidian.UrlSep + "pending_requests"
 
 	Channels               = "channels"
 	ListChannelsPath       = obsidian.V1Root + Channels
@@ -75,6 +91,13 @@ func GetObsidianHandlers() []obsidian.Handler {
 		{Path: ManageNetworkDNSRecordByDomainPath, Methods: obsidian.PUT, HandlerFunc: UpdateDNSRecord},
 		{Path: ManageNetworkDNSRecordByDomainPath, Methods: obsidian.DELETE, HandlerFunc: DeleteDNSRecord},
 
+		{Path: NetworkConfigHistoryPath, Methods: obsidian.GET, HandlerFunc: listNetworkConfigHistory},
+		{Path: RestoreNetworkConfigPath, Methods: obsidian.POST, HandlerFunc: restoreNetworkConfig},
+		{Path: EntityConfigHistoryPath, Methods: obsidian.GET, HandlerFunc: listEntityConfigHistory},
+		{Path: RestoreEntityConfigPath, Methods: obsidian.POST, HandlerFunc: restoreEntityConfig},
+
+		{Path: NetworkAuditPath, Methods: obsidian.GET, HandlerFunc: listAuditRecords},
+
 		// Magma V1 Gateways
 		{Path: ListGatewaysPath, Methods: obsidian.GET, HandlerFunc: ListGatewaysHandler},
 		{Path: ListGatewaysPath, Methods: obsidian.POST, HandlerFunc: CreateGatewayHandler},
@@ -82,6 +105,8 @@ func GetObsidianHandlers() []obsidian.Handler {
 		{Path: ManageGatewayPath, Methods: obsidian.PUT, HandlerFunc: UpdateGatewayHandler},
 		{Path: ManageGatewayPath, Methods: obsidian.DELETE, HandlerFunc: DeleteGatewayHandler},
 		{Path: ManageGatewayStatePath, Methods: obsidian.GET, HandlerFunc: GetStateHandler},
+		{Path: ManageGatewayPendingRequestsPath, Methods: obsidian.GET, HandlerFunc: listPendingRequests},
+		{Path: ManageGatewayPendingRequestsPath, Methods: obsidian.DELETE, HandlerFunc: purgePendingRequests},
 
 		// Upgrades
 		{Path: ListChannelsPath, Methods: obsidian.GET, HandlerFunc: listChannelsHandler},
@@ -105,12 +130,16 @@ func GetObsidianHandlers() []obsidian.Handler {
 	ret = append(ret, GetPartialNetworkHandlers(ManageNetworkFeaturesPath, &models2.NetworkFeatures{}, orc8r.NetworkFeaturesConfig)...)
 	ret = append(ret, GetPartialNetworkHandlers(ManageNetworkDNSPath, &models2.NetworkDNSConfig{}, orc8r.DnsdNetworkType)...)
 	ret = append(ret, GetPartialNetworkHandlers(ManageNetworkDNSRecordsPath, new(models2.NetworkDNSRecords), "")...)
+	ret = append(ret, GetPartialNetworkHandlers(ManageNetworkMetricsConfigPath, &models2.NetworkMetricsConfig{}, orc8r.MetricsdNetworkType)...)
 
 	ret = append(ret, GetPartialGatewayHandlers(ManageGatewayNamePath, new(models.GatewayName))...)
 	ret = append(ret, GetPartialGatewayHandlers(ManageGatewayDescriptionPath, new(models.GatewayDescription))...)
 	ret = append(ret, GetPartialGatewayHandlers(ManageGatewayConfigPath, &models2.MagmadGatewayConfigs{})...)
 	ret = append(ret, GetPartialGatewayHandlers(ManageGatewayTierPath, new(models2.TierID))...)
 	ret = append(ret, GetGatewayDeviceHandlers(ManageGatewayDevicePath)...)
+	ret = append(ret, GetRotateGatewayChallengeKeyHandler(ManageGatewayRotateKeyPath))
+
+	ret = append(ret, getBatchJobHandlers(jobs.DefaultManager)...)
 
 	ret = append(ret, GetPartialEntityHandlers(ManageTierNamePath, "tier_id", new(models2.TierName))...)
 	ret = append(ret, GetPartialEntityHandlers(ManageTierVersionPath, "tier_id", new(models2.TierVersion))...)
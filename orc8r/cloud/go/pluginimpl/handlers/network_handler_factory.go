@@ -16,6 +16,7 @@ import (
 
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
 
@@ -141,7 +142,7 @@ func GetPartialUpdateNetworkHandler(path string, model PartialNetworkModel) obsi
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusBadRequest)
 			}
-			err = configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{updateCriteria})
+			err = access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{updateCriteria})
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusInternalServerError)
 			}
@@ -169,7 +170,7 @@ func GetPartialDeleteNetworkHandler(path string, key string) obsidian.Handler {
 				ID:              networkID,
 				ConfigsToDelete: []string{key},
 			}
-			err := configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{update})
+			err := access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{update})
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusInternalServerError)
 			}
@@ -212,7 +213,7 @@ func getCreateTypedNetworkHandler(path string, networkType string, network Netwo
 			if err != nil {
 				return err
 			}
-			err = configurator.CreateNetwork(payload.ToConfiguratorNetwork())
+			err = access.ConfigWriter(c).CreateNetwork(payload.ToConfiguratorNetwork())
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusInternalServerError)
 			}
@@ -244,6 +245,7 @@ func getGetTypedNetworkHandler(path string, networkType string, networkModel Net
 			}
 
 			ret := (networkModel.GetEmptyNetwork()).FromConfiguratorNetwork(network)
+			obsidian.SetETag(c, network.Version)
 			return c.JSON(http.StatusOK, ret)
 		},
 	}
@@ -275,9 +277,14 @@ func getUpdateTypedNetworkHandler(path string, networkType string, networkModel
 				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("network %s is not a <%s> network", nid, networkType))
 			}
 
-			err = configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{payload.ToUpdateCriteria()})
+			update := payload.ToUpdateCriteria()
+			update.ExpectedVersion, nerr = obsidian.GetIfMatchVersion(c)
+			if nerr != nil {
+				return nerr
+			}
+			err = access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{update})
 			if err != nil {
-				return obsidian.HttpError(err, http.StatusInternalServerError)
+				return WriteErrorToHttpError(err)
 			}
 			return c.NoContent(http.StatusNoContent)
 		},
@@ -305,9 +312,8 @@ func getDeleteTypedNetworkHandler(path string, networkType string) obsidian.Hand
 				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("network %s is not a <%s> network", nid, networkType))
 			}
 
-			err = configurator.DeleteNetwork(nid)
-			if err != nil {
-				return obsidian.HttpError(err, http.StatusInternalServerError)
+			if nerr = DeleteNetworkIfMatch(c, nid); nerr != nil {
+				return nerr
 			}
 			return c.NoContent(http.StatusNoContent)
 		},
@@ -14,6 +14,7 @@ import (
 
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -38,7 +39,7 @@ func registerNetwork(c echo.Context) error {
 		return nerr
 	}
 	network := payload.(*models.Network).ToConfiguratorNetwork()
-	createdNetworks, err := configurator.CreateNetworks([]configurator.Network{network})
+	createdNetworks, err := access.ConfigWriter(c).CreateNetworks([]configurator.Network{network})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
@@ -58,6 +59,7 @@ func getNetwork(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 	ret := (&models.Network{}).FromConfiguratorNetwork(network)
+	obsidian.SetETag(c, network.Version)
 	return c.JSON(http.StatusOK, ret)
 }
 
@@ -67,9 +69,13 @@ func updateNetwork(c echo.Context) error {
 		return nerr
 	}
 	update := network.(*models.Network).ToUpdateCriteria()
-	err := configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{update})
+	update.ExpectedVersion, nerr = obsidian.GetIfMatchVersion(c)
+	if nerr != nil {
+		return nerr
+	}
+	err := access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{update})
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return WriteErrorToHttpError(err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -79,9 +85,8 @@ func deleteNetwork(c echo.Context) error {
 	if nerr != nil {
 		return nerr
 	}
-	err := configurator.DeleteNetwork(networkID)
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+	if nerr = DeleteNetworkIfMatch(c, networkID); nerr != nil {
+		return nerr
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -110,7 +115,7 @@ func CreateDNSRecord(c echo.Context) error {
 	}
 
 	dnsConfig.Records = append(dnsConfig.Records, record)
-	nerr = updateDNSConfig(networkID, dnsConfig)
+	nerr = updateDNSConfig(c, networkID, dnsConfig)
 	if nerr != nil {
 		return nerr
 	}
@@ -136,7 +141,7 @@ func UpdateDNSRecord(c echo.Context) error {
 	for i, existingRecord := range dnsConfig.Records {
 		if existingRecord.Domain == domain {
 			dnsConfig.Records[i] = record
-			nerr = updateDNSConfig(networkID, dnsConfig)
+			nerr = updateDNSConfig(c, networkID, dnsConfig)
 			if nerr != nil {
 				return nerr
 			}
@@ -182,7 +187,7 @@ func DeleteDNSRecord(c echo.Context) error {
 			} else {
 				dnsConfig.Records = append(dnsConfig.Records[:i], dnsConfig.Records[i+1:]...)
 			}
-			nerr = updateDNSConfig(networkID, dnsConfig)
+			nerr = updateDNSConfig(c, networkID, dnsConfig)
 			if nerr != nil {
 				return nerr
 			}
@@ -192,8 +197,8 @@ func DeleteDNSRecord(c echo.Context) error {
 	return echo.NewHTTPError(http.StatusNotFound)
 }
 
-func updateDNSConfig(networkID string, dnsConfig *models.NetworkDNSConfig) *echo.HTTPError {
-	err := configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{
+func updateDNSConfig(c echo.Context, networkID string, dnsConfig *models.NetworkDNSConfig) *echo.HTTPError {
+	err := access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{
 		{
 			ID:                   networkID,
 			ConfigsToAddOrUpdate: map[string]interface{}{orc8r.DnsdNetworkType: dnsConfig},
@@ -379,11 +379,25 @@ func Test_DeleteNetworkHandlers(t *testing.T) {
 	}
 	tests.RunUnitTest(t, e, tc)
 
+	// delete with a stale If-Match
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            fmt.Sprintf("%s/%s/", testURLRoot, "n1"),
+		Headers:        map[string]string{"If-Match": `"1"`},
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        deleteNetwork,
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
 	// delete and get
 	tc = tests.Test{
 		Method:         "GET",
 		URL:            fmt.Sprintf("%s/%s/", testURLRoot, "n1"),
 		Payload:        nil,
+		Headers:        map[string]string{"If-Match": `"0"`},
 		ParamNames:     []string{"network_id"},
 		ParamValues:    []string{"n1"},
 		Handler:        deleteNetwork,
@@ -488,14 +502,38 @@ func Test_PutNetworkHandlers(t *testing.T) {
 	tests.RunUnitTest(t, e, tc)
 
 	tc = tests.Test{
-		Method:         "GET",
-		URL:            fmt.Sprintf("%s/%s/", testURLRoot, "n1"),
-		Payload:        nil,
-		ParamNames:     []string{"network_id"},
-		ParamValues:    []string{"n1"},
-		Handler:        getNetworkHandler,
-		ExpectedStatus: 200,
-		ExpectedResult: tests.JSONMarshaler(network1),
+		Method:          "GET",
+		URL:             fmt.Sprintf("%s/%s/", testURLRoot, "n1"),
+		Payload:         nil,
+		ParamNames:      []string{"network_id"},
+		ParamValues:     []string{"n1"},
+		Handler:         getNetworkHandler,
+		ExpectedStatus:  200,
+		ExpectedResult:  tests.JSONMarshaler(network1),
+		ExpectedHeaders: map[string]string{"ETag": `"2"`},
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// stale If-Match
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot,
+		Payload:        tests.JSONMarshaler(network1),
+		Headers:        map[string]string{"If-Match": `"1"`},
+		Handler:        updateNetwork,
+		ExpectedStatus: 412,
+		ExpectedError:  "If-Match does not match the current entity tag",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// current If-Match
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            testURLRoot,
+		Payload:        tests.JSONMarshaler(network1),
+		Headers:        map[string]string{"If-Match": `"2"`},
+		Handler:        updateNetwork,
+		ExpectedStatus: 204,
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -1033,6 +1071,81 @@ func Test_DeleteNetworkDNSHandlers(t *testing.T) {
 
 }
 
+func Test_NetworkMetricsConfigHandlers(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	test_init.StartTestService(t)
+
+	e := echo.New()
+	testURLRoot := "/magma/v1/networks"
+
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	getMetricsConfig := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/metrics_config", obsidian.GET).HandlerFunc
+	updateMetricsConfig := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/metrics_config", obsidian.PUT).HandlerFunc
+	deleteMetricsConfig := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/metrics_config", obsidian.DELETE).HandlerFunc
+
+	seedNetworks(t)
+
+	// invalid rules
+	tc := tests.Test{
+		Method: "PUT",
+		URL:    fmt.Sprintf("%s/%s/metrics_config/", testURLRoot, "n1"),
+		Payload: tests.JSONMarshaler(&models.NetworkMetricsConfig{
+			RelabelRules: []*models.MetricsRelabelRule{{Action: swag.String("rename")}},
+		}),
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        updateMetricsConfig,
+		ExpectedStatus: 400,
+		ExpectedError:  "validation failure list:\nvalidation failure list:\naction in body should be one of [keep drop replace hashmod]",
+	}
+	tests.RunUnitTest(t, e, tc)
+	tc.Payload = tests.JSONMarshaler(&models.NetworkMetricsConfig{
+		RelabelRules: []*models.MetricsRelabelRule{{Action: swag.String("hashmod"), SourceLabel: "imsi", TargetLabel: "shard"}},
+	})
+	tc.ExpectedError = "invalid relabel rule 0: hashmod rules require a positive modulus"
+	tests.RunUnitTest(t, e, tc)
+
+	// happy case
+	metricsConfig := &models.NetworkMetricsConfig{
+		MaxSeries: 1000,
+		RelabelRules: []*models.MetricsRelabelRule{
+			{Action: swag.String("drop"), MetricRegex: "session_.*"},
+			{Action: swag.String("hashmod"), SourceLabel: "imsi", TargetLabel: "shard", Modulus: 16},
+			{Action: swag.String("replace"), TargetLabel: "imsi"},
+		},
+	}
+	tc.Payload = tests.JSONMarshaler(metricsConfig)
+	tc.ExpectedStatus = 204
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+	config, err := configurator.LoadNetworkConfig("n1", orc8r.MetricsdNetworkType)
+	assert.NoError(t, err)
+	assert.Equal(t, metricsConfig, config)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            fmt.Sprintf("%s/%s/metrics_config/", testURLRoot, "n1"),
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        getMetricsConfig,
+		ExpectedStatus: 200,
+		ExpectedResult: metricsConfig,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            fmt.Sprintf("%s/%s/metrics_config/", testURLRoot, "n1"),
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        deleteMetricsConfig,
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	_, err = configurator.LoadNetworkConfig("n1", orc8r.MetricsdNetworkType)
+	assert.EqualError(t, err, "Not found")
+}
+
 func seedNetworks(t *testing.T) {
 	_, err := configurator.CreateNetworks(
 		[]configurator.Network{
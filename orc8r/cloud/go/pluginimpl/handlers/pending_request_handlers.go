@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers
+
+import (
+	"net/http"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/dispatcher"
+
+	"github.com/labstack/echo"
+)
+
+func listPendingRequests(c echo.Context) error {
+	physicalID, nerr := getGatewayPhysicalID(c)
+	if nerr != nil {
+		return nerr
+	}
+	queuedReqs, err := dispatcher.ListQueuedRequests(physicalID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	ret := make([]*models.PendingGatewayRequest, 0, len(queuedReqs))
+	for _, queuedReq := range queuedReqs {
+		ret = append(ret, (&models.PendingGatewayRequest{}).FromQueuedRequestProto(queuedReq))
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+func purgePendingRequests(c echo.Context) error {
+	physicalID, nerr := getGatewayPhysicalID(c)
+	if nerr != nil {
+		return nerr
+	}
+	if err := dispatcher.PurgeQueuedRequests(physicalID); err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getGatewayPhysicalID(c echo.Context) (string, *echo.HTTPError) {
+	networkID, gatewayID, nerr := obsidian.GetNetworkAndGatewayIDs(c)
+	if nerr != nil {
+		return "", nerr
+	}
+	physicalID, err := configurator.GetPhysicalIDOfEntity(networkID, orc8r.MagmadGatewayType, gatewayID)
+	if err != nil {
+		return "", obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	// unknown gateways and gateways without a device have no physical ID
+	if len(physicalID) == 0 {
+		return "", obsidian.HttpError(merrors.ErrNotFound, http.StatusNotFound)
+	}
+	return physicalID, nil
+}
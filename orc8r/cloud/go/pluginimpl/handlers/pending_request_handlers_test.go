@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/pluginimpl/handlers"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/test_init"
+	dispatcherTestInit "magma/orc8r/cloud/go/services/dispatcher/test_init"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingRequestHandlers(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	test_init.StartTestService(t)
+	queue := dispatcherTestInit.StartTestOfflineQueueService(t)
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).UTC())
+	defer clock.UnfreezeClock(t)
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n1",
+		[]configurator.NetworkEntity{
+			{Type: orc8r.MagmadGatewayType, Key: "g1", Config: &models.MagmadGatewayConfigs{}, PhysicalID: "hw1"},
+			{Type: orc8r.MagmadGatewayType, Key: "g2", Config: &models.MagmadGatewayConfigs{}, PhysicalID: "hw2"},
+		},
+	)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	obsidianHandlers := handlers.GetObsidianHandlers()
+	listPending := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/gateways/:gateway_id/pending_requests", obsidian.GET).HandlerFunc
+	purgePending := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, "/magma/v1/networks/:network_id/gateways/:gateway_id/pending_requests", obsidian.DELETE).HandlerFunc
+
+	// empty case
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/gateways/g1/pending_requests",
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		Handler:        listPending,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.PendingGatewayRequest{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// happy path
+	reboot, err := queue.Push(&protos.GatewayRequest{GwId: "hw1", Authority: "magmad", Path: "/magma.orc8r.Magmad/Reboot"}, time.Hour)
+	assert.NoError(t, err)
+	clock.SetAndFreezeClock(t, time.Unix(1000001, 0).UTC())
+	restart, err := queue.Push(&protos.GatewayRequest{GwId: "hw1", Authority: "magmad", Path: "/magma.orc8r.Magmad/RestartServices"}, time.Minute)
+	assert.NoError(t, err)
+	_, err = queue.Push(&protos.GatewayRequest{GwId: "hw2", Authority: "magmad", Path: "/magma.orc8r.Magmad/Reboot"}, time.Hour)
+	assert.NoError(t, err)
+	rebootQueuedAt := strfmt.DateTime(time.Unix(1000000, 0).UTC())
+	rebootExpiresAt := strfmt.DateTime(time.Unix(1000000, 0).Add(time.Hour).UTC())
+	restartQueuedAt := strfmt.DateTime(time.Unix(1000001, 0).UTC())
+	restartExpiresAt := strfmt.DateTime(time.Unix(1000001, 0).Add(time.Minute).UTC())
+	tc.ExpectedResult = tests.JSONMarshaler([]*models.PendingGatewayRequest{
+		{
+			ID:        swag.String(reboot.Id),
+			Service:   swag.String("magmad"),
+			Method:    swag.String("/magma.orc8r.Magmad/Reboot"),
+			QueuedAt:  &rebootQueuedAt,
+			ExpiresAt: &rebootExpiresAt,
+		},
+		{
+			ID:        swag.String(restart.Id),
+			Service:   swag.String("magmad"),
+			Method:    swag.String("/magma.orc8r.Magmad/RestartServices"),
+			QueuedAt:  &restartQueuedAt,
+			ExpiresAt: &restartExpiresAt,
+		},
+	})
+	tests.RunUnitTest(t, e, tc)
+
+	// purge
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            "/magma/v1/networks/n1/gateways/g1/pending_requests",
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g1"},
+		Handler:        purgePending,
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	queuedReqs, err := queue.List("hw1")
+	assert.NoError(t, err)
+	assert.Empty(t, queuedReqs)
+	// other gateways' requests are kept
+	queuedReqs, err = queue.List("hw2")
+	assert.NoError(t, err)
+	assert.Len(t, queuedReqs, 1)
+
+	// unknown gateway
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/networks/n1/gateways/g3/pending_requests",
+		ParamNames:     []string{"network_id", "gateway_id"},
+		ParamValues:    []string{"n1", "g3"},
+		Handler:        listPending,
+		ExpectedStatus: 404,
+		ExpectedError:  "Not found",
+	}
+	tests.RunUnitTest(t, e, tc)
+	tc.Method = "DELETE"
+	tc.Handler = purgePending
+	tests.RunUnitTest(t, e, tc)
+}
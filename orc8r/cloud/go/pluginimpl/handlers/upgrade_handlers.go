@@ -16,6 +16,7 @@ import (
 
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -46,7 +47,7 @@ func createChannelHandler(c echo.Context) error {
 		Name:   string(channel.Name),
 		Config: channel,
 	}
-	_, err := configurator.CreateInternalEntity(entity)
+	_, err := access.ConfigWriter(c).CreateInternalEntity(entity)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -86,7 +87,7 @@ func updateChannelHandler(c echo.Context) error {
 		NewName:   swag.String(string(channel.Name)),
 		NewConfig: channel,
 	}
-	_, err := configurator.UpdateInternalEntity(update)
+	_, err := access.ConfigWriter(c).UpdateInternalEntity(update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -98,7 +99,7 @@ func deleteChannelHandler(c echo.Context) error {
 	if nerr != nil {
 		return nerr
 	}
-	err := configurator.DeleteInternalEntity(orc8r.UpgradeReleaseChannelEntityType, channelID)
+	err := access.ConfigWriter(c).DeleteInternalEntity(orc8r.UpgradeReleaseChannelEntityType, channelID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -129,7 +130,7 @@ func createTierHandler(c echo.Context) error {
 	}
 	tier := payload.(*models.Tier)
 	entity := tier.ToNetworkEntity()
-	_, err := configurator.CreateEntity(networkID, entity)
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, entity)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -151,7 +152,7 @@ func updateTierHandler(c echo.Context) error {
 		return obsidian.HttpError(fmt.Errorf("TierID in URL and payload do not match."), http.StatusBadRequest)
 	}
 	update := tier.ToUpdateCriteria()
-	_, err := configurator.UpdateEntity(networkID, update)
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -182,7 +183,7 @@ func deleteTierHandler(c echo.Context) error {
 	if nerr != nil {
 		return nerr
 	}
-	err := configurator.DeleteEntity(networkID, orc8r.UpgradeTierEntityType, tierID)
+	err := access.ConfigWriter(c).DeleteEntity(networkID, orc8r.UpgradeTierEntityType, tierID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -206,7 +207,7 @@ func createTierImage(c echo.Context) error {
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
-	_, err = configurator.UpdateEntities(networkID, updates)
+	_, err = access.ConfigWriter(c).UpdateEntities(networkID, updates)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -229,7 +230,7 @@ func deleteImage(c echo.Context) error {
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
-	_, err = configurator.UpdateEntity(networkID, update)
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -247,7 +248,7 @@ func createTierGateway(c echo.Context) error {
 	}
 
 	update := (&models.TierGateways{}).ToAddGatewayUpdateCriteria(tierID, gatewayID)
-	_, err := configurator.UpdateEntity(networkID, update)
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -264,7 +265,7 @@ func deleteTierGateway(c echo.Context) error {
 		return nerr
 	}
 	update := (&models.TierGateways{}).ToDeleteGatewayUpdateCriteria(tierID, gatewayID)
-	_, err := configurator.UpdateEntity(networkID, update)
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -25,7 +25,7 @@ type ChallengeKey struct {
 
 	// key type
 	// Required: true
-	// Enum: [ECHO SOFTWARE_ECDSA_SHA256]
+	// Enum: [ECHO SOFTWARE_ECDSA_SHA256 SOFTWARE_ECDSA_SHA384 SOFTWARE_ED25519]
 	KeyType string `json:"key_type"`
 }
 
@@ -62,7 +62,7 @@ var challengeKeyTypeKeyTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["ECHO","SOFTWARE_ECDSA_SHA256"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["ECHO","SOFTWARE_ECDSA_SHA256","SOFTWARE_ECDSA_SHA384","SOFTWARE_ED25519"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -77,6 +77,12 @@ const (
 
 	// ChallengeKeyKeyTypeSOFTWAREECDSASHA256 captures enum value "SOFTWARE_ECDSA_SHA256"
 	ChallengeKeyKeyTypeSOFTWAREECDSASHA256 string = "SOFTWARE_ECDSA_SHA256"
+
+	// ChallengeKeyKeyTypeSOFTWAREECDSASHA384 captures enum value "SOFTWARE_ECDSA_SHA384"
+	ChallengeKeyKeyTypeSOFTWAREECDSASHA384 string = "SOFTWARE_ECDSA_SHA384"
+
+	// ChallengeKeyKeyTypeSOFTWAREED25519 captures enum value "SOFTWARE_ED25519"
+	ChallengeKeyKeyTypeSOFTWAREED25519 string = "SOFTWARE_ED25519"
 )
 
 // prop value enum
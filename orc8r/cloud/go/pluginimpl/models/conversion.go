@@ -9,16 +9,27 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
 	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/models"
 	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/protos"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
 	"magma/orc8r/cloud/go/services/configurator"
+	dispatcherprotos "magma/orc8r/cloud/go/services/dispatcher/protos"
+	"magma/orc8r/cloud/go/services/magmad/jobs"
+	"magma/orc8r/cloud/go/services/metricsd/relabel"
 	"magma/orc8r/cloud/go/storage"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/thoas/go-funk"
 )
 
@@ -78,6 +89,35 @@ func (m *NetworkDNSConfig) ToUpdateCriteria(network configurator.Network) (confi
 	return GetNetworkConfigUpdateCriteria(network.ID, orc8r.DnsdNetworkType, m), nil
 }
 
+func (m *NetworkMetricsConfig) GetFromNetwork(network configurator.Network) interface{} {
+	return GetNetworkConfig(network, orc8r.MetricsdNetworkType)
+}
+
+func (m *NetworkMetricsConfig) ToUpdateCriteria(network configurator.Network) (configurator.NetworkUpdateCriteria, error) {
+	return GetNetworkConfigUpdateCriteria(network.ID, orc8r.MetricsdNetworkType, m), nil
+}
+
+// ToRelabelRules converts the configured relabel rules, they still have to be
+// compiled before being applied
+func (m *NetworkMetricsConfig) ToRelabelRules() []relabel.Rule {
+	ret := make([]relabel.Rule, 0, len(m.RelabelRules))
+	for _, rule := range m.RelabelRules {
+		if rule == nil {
+			continue
+		}
+		ret = append(ret, relabel.Rule{
+			Action:      swag.StringValue(rule.Action),
+			MetricRegex: rule.MetricRegex,
+			SourceLabel: rule.SourceLabel,
+			Regex:       rule.Regex,
+			TargetLabel: rule.TargetLabel,
+			Replacement: rule.Replacement,
+			Modulus:     rule.Modulus,
+		})
+	}
+	return ret
+}
+
 func (m NetworkDNSRecords) GetFromNetwork(network configurator.Network) interface{} {
 	iNetworkDnsConfig := GetNetworkConfig(network, orc8r.DnsdNetworkType)
 	if iNetworkDnsConfig == nil {
@@ -108,6 +148,7 @@ func (m *MagmadGateway) GetAdditionalWritesOnCreate() []configurator.EntityWrite
 			Description: string(m.Description),
 			Config:      m.Magmad,
 			PhysicalID:  m.Device.HardwareID,
+			Labels:      m.Labels,
 		},
 	}
 }
@@ -127,9 +168,10 @@ func (m *MagmadGateway) GetAdditionalWritesOnUpdate(
 	}
 
 	gatewayUpdate := configurator.EntityUpdateCriteria{
-		Type:      orc8r.MagmadGatewayType,
-		Key:       string(m.ID),
-		NewConfig: m.Magmad,
+		Type:        orc8r.MagmadGatewayType,
+		Key:         string(m.ID),
+		NewConfig:   m.Magmad,
+		LabelsToSet: m.Labels.ToLabelsToSet(existingEnt.Labels),
 	}
 	if m.Device.HardwareID != existingEnt.PhysicalID {
 		gatewayUpdate.NewPhysicalID = swag.String(m.Device.HardwareID)
@@ -173,6 +215,7 @@ func (m *MagmadGateway) ToConfiguratorEntities() []configurator.NetworkEntity {
 		Description: string(m.Description),
 		Config:      m.Magmad,
 		PhysicalID:  m.Device.HardwareID,
+		Labels:      m.Labels,
 	}
 	return []configurator.NetworkEntity{gatewayEnt}
 }
@@ -184,6 +227,7 @@ func (m *MagmadGateway) FromBackendModels(ent configurator.NetworkEntity, device
 	if ent.Config != nil {
 		m.Magmad = ent.Config.(*MagmadGatewayConfigs)
 	}
+	m.Labels = ent.Labels
 	m.Device = device
 	m.Status = status
 	tierTK, err := ent.GetFirstParentOfType(orc8r.UpgradeTierEntityType)
@@ -197,9 +241,10 @@ func (m *MagmadGateway) FromBackendModels(ent configurator.NetworkEntity, device
 func (m *MagmadGateway) ToEntityUpdateCriteria(existingEnt configurator.NetworkEntity) []configurator.EntityUpdateCriteria {
 	ret := []configurator.EntityUpdateCriteria{}
 	gatewayUpdate := configurator.EntityUpdateCriteria{
-		Type:      orc8r.MagmadGatewayType,
-		Key:       string(m.ID),
-		NewConfig: m.Magmad,
+		Type:        orc8r.MagmadGatewayType,
+		Key:         string(m.ID),
+		NewConfig:   m.Magmad,
+		LabelsToSet: m.Labels.ToLabelsToSet(existingEnt.Labels),
 	}
 
 	if m.Device.HardwareID != existingEnt.PhysicalID {
@@ -507,3 +552,172 @@ func getGatewayIDs(gatewayTKs []storage.TypeAndKey) []models.GatewayID {
 			return models.GatewayID(tk.Key)
 		}).([]models.GatewayID)
 }
+
+func (m *ConfigRevision) FromConfiguratorConfigRevision(rev configurator.ConfigRevision) *ConfigRevision {
+	createdAt := strfmt.DateTime(rev.CreatedAt)
+	m.Revision = swag.Uint64(rev.Revision)
+	m.Config = rev.Config
+	m.Deleted = swag.Bool(rev.Deleted)
+	m.Labels = rev.Labels
+	m.Author = rev.Author
+	m.CreatedAt = &createdAt
+	return m
+}
+
+// ConfigRevisionsFromConfigurator converts a config's history, newest
+// revision first, and sets each revision's diff against the revision
+// preceding it
+func ConfigRevisionsFromConfigurator(revisions []configurator.ConfigRevision) ([]*ConfigRevision, error) {
+	ret := make([]*ConfigRevision, 0, len(revisions))
+	for i, rev := range revisions {
+		model := (&ConfigRevision{}).FromConfiguratorConfigRevision(rev)
+		if i+1 < len(revisions) {
+			diff, err := diffConfigRevisions(revisions[i+1], rev)
+			if err != nil {
+				return nil, err
+			}
+			model.Diff = diff
+		}
+		ret = append(ret, model)
+	}
+	return ret, nil
+}
+
+// diffConfigRevisions returns the unified diff of the revisions' indented
+// JSON configs followed by their labels, deleted configs have no lines
+func diffConfigRevisions(from, to configurator.ConfigRevision) (string, error) {
+	fromLines, err := configRevisionLines(from)
+	if err != nil {
+		return "", err
+	}
+	toLines, err := configRevisionLines(to)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        fromLines,
+		B:        toLines,
+		FromFile: fmt.Sprintf("revision %d", from.Revision),
+		ToFile:   fmt.Sprintf("revision %d", to.Revision),
+		Context:  3,
+	})
+}
+
+func configRevisionLines(rev configurator.ConfigRevision) ([]string, error) {
+	var lines []string
+	if !rev.Deleted && rev.Config != nil {
+		marshaled, err := json.MarshalIndent(rev.Config, "", "  ")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal config of revision %d", rev.Revision)
+		}
+		lines = difflib.SplitLines(string(marshaled))
+	}
+	labelKeys := make([]string, 0, len(rev.Labels))
+	for k := range rev.Labels {
+		labelKeys = append(labelKeys, k)
+	}
+	sort.Strings(labelKeys)
+	for _, k := range labelKeys {
+		lines = append(lines, fmt.Sprintf("label %s=%s\n", k, rev.Labels[k]))
+	}
+	return lines, nil
+}
+
+func (m *AuditRecord) FromAuditRecordProto(record *accessprotos.AuditRecord) *AuditRecord {
+	timestamp, _ := ptypes.Timestamp(record.Timestamp)
+	dateTime := strfmt.DateTime(timestamp)
+	m.Operator = swag.String(record.Operator.GetOperator())
+	m.Method = swag.String(record.Method)
+	m.Path = swag.String(record.Path)
+	m.EntityType = record.EntityType
+	m.EntityKey = record.EntityKey
+	m.BodySha256 = record.BodySha256
+	m.ResultCode = swag.Int32(record.ResultCode)
+	m.Timestamp = &dateTime
+	return m
+}
+
+func (m *PendingGatewayRequest) FromQueuedRequestProto(queuedReq *dispatcherprotos.QueuedRequest) *PendingGatewayRequest {
+	queuedAt, _ := ptypes.Timestamp(queuedReq.QueuedAt)
+	expiresAt, _ := ptypes.Timestamp(queuedReq.ExpiresAt)
+	queuedAtDateTime, expiresAtDateTime := strfmt.DateTime(queuedAt), strfmt.DateTime(expiresAt)
+	m.ID = swag.String(queuedReq.Id)
+	m.Service = swag.String(queuedReq.Request.GetAuthority())
+	m.Method = swag.String(queuedReq.Request.GetPath())
+	m.QueuedAt = &queuedAtDateTime
+	m.ExpiresAt = &expiresAtDateTime
+	return m
+}
+
+// ToJobCommand converts the command to a batch job command
+func (m *GatewayCommand) ToJobCommand() (jobs.Command, error) {
+	cmd := jobs.Command{Type: jobs.CommandType(swag.StringValue(m.Type)), Services: m.Services}
+	if cmd.Type == jobs.GenericCommand {
+		params, err := models.JSONMapToProtobufStruct(m.Params)
+		if err != nil {
+			return cmd, errors.Wrap(err, "invalid generic command params")
+		}
+		cmd.Generic = &protos.GenericCommandParams{Command: m.GenericCommand, Params: params}
+	}
+	return cmd, cmd.Validate()
+}
+
+func (m *GatewayCommand) FromJobCommand(cmd jobs.Command) *GatewayCommand {
+	m.Type = swag.String(string(cmd.Type))
+	m.Services = cmd.Services
+	if cmd.Generic != nil {
+		m.GenericCommand = cmd.Generic.Command
+		m.Params, _ = models.ProtobufStructToJSONMap(cmd.Generic.Params)
+	}
+	return m
+}
+
+func (m *GatewaySelector) ToJobSelector() jobs.Selector {
+	if m == nil {
+		return jobs.Selector{}
+	}
+	return jobs.Selector{GatewayIDs: m.GatewayIds, Tier: m.Tier, Labels: m.Labels}
+}
+
+func (m *GatewaySelector) FromJobSelector(selector jobs.Selector) *GatewaySelector {
+	m.GatewayIds = selector.GatewayIDs
+	m.Tier = selector.Tier
+	m.Labels = selector.Labels
+	return m
+}
+
+func (m *BatchJob) FromJob(job *jobs.Job) *BatchJob {
+	m.ID = swag.String(job.ID)
+	m.Command = (&GatewayCommand{}).FromJobCommand(job.Command)
+	m.Selector = (&GatewaySelector{}).FromJobSelector(job.Selector)
+	m.Concurrency = swag.Int32(int32(job.Concurrency))
+	m.StartTime = toDateTime(job.StartTime)
+	m.State = swag.String(string(job.State))
+	m.CreatedAt = toDateTime(job.CreatedAt)
+	m.FinishedAt = toDateTime(job.FinishedAt)
+	m.Results = make(map[string]BatchJobGatewayResult, len(job.Results))
+	for gwID, res := range job.Results {
+		m.Results[gwID] = *(&BatchJobGatewayResult{}).FromJobGatewayResult(res)
+	}
+	return m
+}
+
+func (m *BatchJobGatewayResult) FromJobGatewayResult(res *jobs.GatewayResult) *BatchJobGatewayResult {
+	m.State = swag.String(string(res.State))
+	m.Error = res.Error
+	if res.Response != nil {
+		m.Response, _ = models.ProtobufStructToJSONMap(res.Response)
+	}
+	m.StartedAt = toDateTime(res.StartedAt)
+	m.FinishedAt = toDateTime(res.FinishedAt)
+	return m
+}
+
+// toDateTime returns nil for zero time
+func toDateTime(t time.Time) *strfmt.DateTime {
+	if t.IsZero() {
+		return nil
+	}
+	ret := strfmt.DateTime(t)
+	return &ret
+}
@@ -25,6 +25,9 @@ type GatewayDevice struct {
 	// key
 	// Required: true
 	Key *ChallengeKey `json:"key"`
+
+	// Challenge key replaced by the last key rotation. Bootstrapper accepts it until the gateway bootstraps with its current key.
+	PreviousKey *ChallengeKey `json:"previous_key,omitempty"`
 }
 
 // Validate validates this gateway device
@@ -39,6 +42,10 @@ func (m *GatewayDevice) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePreviousKey(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -76,6 +83,24 @@ func (m *GatewayDevice) validateKey(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *GatewayDevice) validatePreviousKey(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PreviousKey) { // not required
+		return nil
+	}
+
+	if m.PreviousKey != nil {
+		if err := m.PreviousKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("previous_key")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *GatewayDevice) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -25,6 +25,9 @@ type GatewayStatus struct {
 	// hardware id
 	HardwareID string `json:"hardware_id,omitempty"`
 
+	// True if the gateway hasn't checked in within the gateway status TTL
+	IsStale bool `json:"is_stale,omitempty"`
+
 	// deprecated
 	KernelVersion string `json:"kernel_version,omitempty"`
 
@@ -30,6 +30,9 @@ type MagmadGateway struct {
 	// Required: true
 	ID models1.GatewayID `json:"id"`
 
+	// labels
+	Labels models1.Labels `json:"labels,omitempty"`
+
 	// magmad
 	// Required: true
 	Magmad *MagmadGatewayConfigs `json:"magmad"`
@@ -9,15 +9,22 @@
 package models
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/x509"
 	"errors"
 	"fmt"
 
+	"magma/orc8r/cloud/go/services/metricsd/relabel"
+
 	"github.com/go-openapi/strfmt"
 )
 
 const echoKeyType = "ECHO"
 const ecdsaKeyType = "SOFTWARE_ECDSA_SHA256"
+const ecdsaP384KeyType = "SOFTWARE_ECDSA_SHA384"
+const ed25519KeyType = "SOFTWARE_ED25519"
 
 func (m *Network) ValidateModel() error {
 	return m.Validate(strfmt.Default)
@@ -31,6 +38,14 @@ func (m *NetworkFeatures) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
 
+func (m *NetworkMetricsConfig) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	_, err := relabel.Compile(m.ToRelabelRules())
+	return err
+}
+
 func (m NetworkDNSRecords) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
@@ -40,13 +55,21 @@ func (m DNSConfigRecord) ValidateModel() error {
 }
 
 func (m *MagmadGateway) ValidateModel() error {
-	return m.Validate(strfmt.Default)
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	return m.Labels.ValidateModel()
 }
 
 func (m *GatewayDevice) ValidateModel() error {
 	if err := m.Key.ValidateModel(); err != nil {
 		return err
 	}
+	if m.PreviousKey != nil {
+		if err := m.PreviousKey.ValidateModel(); err != nil {
+			return fmt.Errorf("Invalid previous key: %s", err)
+		}
+	}
 	return m.Validate(strfmt.Default)
 }
 
@@ -66,6 +89,30 @@ func (m *ChallengeKey) ValidateModel() error {
 			return fmt.Errorf("Failed to parse key: %s", err)
 		}
 		return nil
+	case ecdsaP384KeyType:
+		if m.Key == nil {
+			return fmt.Errorf("No key supplied")
+		}
+		key, err := x509.ParsePKIXPublicKey(*m.Key)
+		if err != nil {
+			return fmt.Errorf("Failed to parse key: %s", err)
+		}
+		if ecKey, ok := key.(*ecdsa.PublicKey); !ok || ecKey.Curve != elliptic.P384() {
+			return fmt.Errorf("Key is not an ECDSA P-384 public key")
+		}
+		return nil
+	case ed25519KeyType:
+		if m.Key == nil {
+			return fmt.Errorf("No key supplied")
+		}
+		key, err := x509.ParsePKIXPublicKey(*m.Key)
+		if err != nil {
+			return fmt.Errorf("Failed to parse key: %s", err)
+		}
+		if _, ok := key.(ed25519.PublicKey); !ok {
+			return fmt.Errorf("Key is not an Ed25519 public key")
+		}
+		return nil
 	default:
 		return fmt.Errorf("Unknown key type %s", m.KeyType)
 	}
@@ -113,3 +160,11 @@ func (m *TierImage) ValidateModel() error {
 func (m *GatewayStatus) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
+
+func (m *BatchJobRequest) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	_, err := m.Command.ToJobCommand()
+	return err
+}
@@ -10,6 +10,7 @@ package pluginimpl
 
 import (
 	"net/http"
+	"time"
 
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/orc8r"
@@ -32,6 +33,7 @@ import (
 	"magma/orc8r/cloud/go/services/metricsd/confignames"
 	"magma/orc8r/cloud/go/services/metricsd/exporters"
 	metricsdh "magma/orc8r/cloud/go/services/metricsd/obsidian/handlers"
+	otlpExp "magma/orc8r/cloud/go/services/metricsd/otlp/exporters"
 	promeExp "magma/orc8r/cloud/go/services/metricsd/prometheus/exporters"
 	"magma/orc8r/cloud/go/services/state"
 	stateh "magma/orc8r/cloud/go/services/state/obsidian/handlers"
@@ -42,6 +44,15 @@ import (
 	"github.com/labstack/echo"
 )
 
+// gatewayStatusTTL is how long a gateway's status is considered fresh after
+// its last checkin by default. It can be overridden in the state service's
+// config. Stale statuses are kept so the gateway's last status stays visible.
+const gatewayStatusTTL = 24 * time.Hour
+
+// gatewayStatusReapTTL is how long after its last checkin a gateway's status
+// is deleted, so statuses of decommissioned gateways don't pile up.
+const gatewayStatusReapTTL = 30 * 24 * time.Hour
+
 // BaseOrchestratorPlugin is the OrchestratorPlugin for the orc8r module
 type BaseOrchestratorPlugin struct{}
 
@@ -60,7 +71,7 @@ func (*BaseOrchestratorPlugin) GetServices() []registry.ServiceLocation {
 func (*BaseOrchestratorPlugin) GetSerdes() []serde.Serde {
 	return []serde.Serde{
 		// State service serdes
-		state.NewStateSerde(orc8r.GatewayStateType, &models.GatewayStatus{}),
+		state.NewStateSerdeWithReapTTL(orc8r.GatewayStateType, &models.GatewayStatus{}, gatewayStatusTTL, gatewayStatusReapTTL),
 		// For checkin_cli.py to test cloud < - > gateway connection
 		state.NewStateSerde(state.StringMapSerdeType, &state.StringToStringMap{}),
 		// For DirectoryD records
@@ -71,6 +82,7 @@ func (*BaseOrchestratorPlugin) GetSerdes() []serde.Serde {
 
 		// Config manager serdes
 		configurator.NewNetworkConfigSerde(orc8r.DnsdNetworkType, &models.NetworkDNSConfig{}),
+		configurator.NewNetworkConfigSerde(orc8r.MetricsdNetworkType, &models.NetworkMetricsConfig{}),
 		configurator.NewNetworkConfigSerde(orc8r.NetworkFeaturesConfig, &models.NetworkFeatures{}),
 
 		configurator.NewNetworkEntityConfigSerde(orc8r.MagmadGatewayType, &models.MagmadGatewayConfigs{}),
@@ -125,6 +137,7 @@ func (*BaseOrchestratorPlugin) GetStreamerProviders() []providers.StreamProvider
 const (
 	ProfileNamePrometheus = "prometheus"
 	ProfileNameExportAll  = "exportall"
+	ProfileNameOTLP       = "otlp"
 )
 
 func getMetricsProfiles(metricsConfig *config.ConfigMap) []metricsd.MetricsProfile {
@@ -149,15 +162,28 @@ func getMetricsProfiles(metricsConfig *config.ConfigMap) []metricsd.MetricsProfi
 		Exporters:  []exporters.Exporter{prometheusCustomPushExporter},
 	}
 
+	profiles := []metricsd.MetricsProfile{prometheusProfile}
+	allExporters := []exporters.Exporter{prometheusCustomPushExporter}
+
+	// OTLP profile - Exports all service metrics to an OpenTelemetry
+	// collector, only available if the collector address is configured
+	otlpAddress, err := metricsConfig.GetStringParam(confignames.OTLPExportAddress)
+	if err == nil && len(otlpAddress) > 0 {
+		otlpExporter := otlpExp.NewOTLPExporter(otlpAddress)
+		profiles = append(profiles, metricsd.MetricsProfile{
+			Name:       ProfileNameOTLP,
+			Collectors: controllerCollectors,
+			Exporters:  []exporters.Exporter{otlpExporter},
+		})
+		allExporters = append(allExporters, otlpExporter)
+	}
+
 	// ExportAllProfile - Exports to all exporters
 	exportAllProfile := metricsd.MetricsProfile{
 		Name:       ProfileNameExportAll,
 		Collectors: controllerCollectors,
-		Exporters:  []exporters.Exporter{prometheusCustomPushExporter},
+		Exporters:  allExporters,
 	}
 
-	return []metricsd.MetricsProfile{
-		prometheusProfile,
-		exportAllProfile,
-	}
+	return append(profiles, exportAllProfile)
 }
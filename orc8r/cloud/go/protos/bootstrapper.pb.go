@@ -30,18 +30,24 @@ const (
 	ChallengeKey_ECHO                  ChallengeKey_KeyType = 0
 	ChallengeKey_SOFTWARE_RSA_SHA256   ChallengeKey_KeyType = 1
 	ChallengeKey_SOFTWARE_ECDSA_SHA256 ChallengeKey_KeyType = 2
+	ChallengeKey_SOFTWARE_ECDSA_SHA384 ChallengeKey_KeyType = 3
+	ChallengeKey_SOFTWARE_ED25519      ChallengeKey_KeyType = 4
 )
 
 var ChallengeKey_KeyType_name = map[int32]string{
 	0: "ECHO",
 	1: "SOFTWARE_RSA_SHA256",
 	2: "SOFTWARE_ECDSA_SHA256",
+	3: "SOFTWARE_ECDSA_SHA384",
+	4: "SOFTWARE_ED25519",
 }
 
 var ChallengeKey_KeyType_value = map[string]int32{
 	"ECHO":                  0,
 	"SOFTWARE_RSA_SHA256":   1,
 	"SOFTWARE_ECDSA_SHA256": 2,
+	"SOFTWARE_ECDSA_SHA384": 3,
+	"SOFTWARE_ED25519":      4,
 }
 
 func (x ChallengeKey_KeyType) String() string {
@@ -157,6 +163,7 @@ type Response struct {
 	//	*Response_EchoResponse
 	//	*Response_RsaResponse
 	//	*Response_EcdsaResponse
+	//	*Response_Ed25519Response
 	Response             isResponse_Response `protobuf_oneof:"response"`
 	Csr                  *CSR                `protobuf:"bytes,6,opt,name=csr,proto3" json:"csr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
@@ -219,12 +226,18 @@ type Response_EcdsaResponse struct {
 	EcdsaResponse *Response_ECDSA `protobuf:"bytes,5,opt,name=ecdsa_response,json=ecdsaResponse,proto3,oneof"`
 }
 
+type Response_Ed25519Response struct {
+	Ed25519Response *Response_Ed25519 `protobuf:"bytes,7,opt,name=ed25519_response,json=ed25519Response,proto3,oneof"`
+}
+
 func (*Response_EchoResponse) isResponse_Response() {}
 
 func (*Response_RsaResponse) isResponse_Response() {}
 
 func (*Response_EcdsaResponse) isResponse_Response() {}
 
+func (*Response_Ed25519Response) isResponse_Response() {}
+
 func (m *Response) GetResponse() isResponse_Response {
 	if m != nil {
 		return m.Response
@@ -253,6 +266,13 @@ func (m *Response) GetEcdsaResponse() *Response_ECDSA {
 	return nil
 }
 
+func (m *Response) GetEd25519Response() *Response_Ed25519 {
+	if x, ok := m.GetResponse().(*Response_Ed25519Response); ok {
+		return x.Ed25519Response
+	}
+	return nil
+}
+
 func (m *Response) GetCsr() *CSR {
 	if m != nil {
 		return m.Csr
@@ -266,6 +286,7 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_EchoResponse)(nil),
 		(*Response_RsaResponse)(nil),
 		(*Response_EcdsaResponse)(nil),
+		(*Response_Ed25519Response)(nil),
 	}
 }
 
@@ -394,6 +415,113 @@ func (m *Response_ECDSA) GetS() []byte {
 	return nil
 }
 
+type Response_Ed25519 struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Response_Ed25519) Reset()         { *m = Response_Ed25519{} }
+func (m *Response_Ed25519) String() string { return proto.CompactTextString(m) }
+func (*Response_Ed25519) ProtoMessage()    {}
+func (*Response_Ed25519) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b592b3c4e9ae6813, []int{2, 3}
+}
+
+func (m *Response_Ed25519) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Response_Ed25519.Unmarshal(m, b)
+}
+func (m *Response_Ed25519) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Response_Ed25519.Marshal(b, m, deterministic)
+}
+func (m *Response_Ed25519) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response_Ed25519.Merge(m, src)
+}
+func (m *Response_Ed25519) XXX_Size() int {
+	return xxx_messageInfo_Response_Ed25519.Size(m)
+}
+func (m *Response_Ed25519) XXX_DiscardUnknown() {
+	xxx_messageInfo_Response_Ed25519.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Response_Ed25519 proto.InternalMessageInfo
+
+func (m *Response_Ed25519) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type RenewRequest struct {
+	// Current, still valid gateway certificate in DER encoding
+	CertDer []byte `protobuf:"bytes,1,opt,name=cert_der,json=certDer,proto3" json:"cert_der,omitempty"`
+	// Challenge returned by GetChallenge
+	Challenge []byte `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Csr       *CSR   `protobuf:"bytes,3,opt,name=csr,proto3" json:"csr,omitempty"`
+	// Signature of the challenge followed by csr.csr_der, made with the key of
+	// the current certificate: PKCS#1 v1.5 SHA-256 for RSA, ASN.1 encoded
+	// SHA-256 for ECDSA and pure Ed25519 certificate keys
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewRequest) Reset()         { *m = RenewRequest{} }
+func (m *RenewRequest) String() string { return proto.CompactTextString(m) }
+func (*RenewRequest) ProtoMessage()    {}
+func (*RenewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b592b3c4e9ae6813, []int{3}
+}
+
+func (m *RenewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewRequest.Unmarshal(m, b)
+}
+func (m *RenewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewRequest.Marshal(b, m, deterministic)
+}
+func (m *RenewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewRequest.Merge(m, src)
+}
+func (m *RenewRequest) XXX_Size() int {
+	return xxx_messageInfo_RenewRequest.Size(m)
+}
+func (m *RenewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewRequest proto.InternalMessageInfo
+
+func (m *RenewRequest) GetCertDer() []byte {
+	if m != nil {
+		return m.CertDer
+	}
+	return nil
+}
+
+func (m *RenewRequest) GetChallenge() []byte {
+	if m != nil {
+		return m.Challenge
+	}
+	return nil
+}
+
+func (m *RenewRequest) GetCsr() *CSR {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
+func (m *RenewRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("magma.orc8r.ChallengeKey_KeyType", ChallengeKey_KeyType_name, ChallengeKey_KeyType_value)
 	proto.RegisterType((*Challenge)(nil), "magma.orc8r.Challenge")
@@ -402,44 +530,53 @@ func init() {
 	proto.RegisterType((*Response_Echo)(nil), "magma.orc8r.Response.Echo")
 	proto.RegisterType((*Response_RSA)(nil), "magma.orc8r.Response.RSA")
 	proto.RegisterType((*Response_ECDSA)(nil), "magma.orc8r.Response.ECDSA")
+	proto.RegisterType((*Response_Ed25519)(nil), "magma.orc8r.Response.Ed25519")
+	proto.RegisterType((*RenewRequest)(nil), "magma.orc8r.RenewRequest")
 }
 
 func init() { proto.RegisterFile("orc8r/protos/bootstrapper.proto", fileDescriptor_b592b3c4e9ae6813) }
 
 var fileDescriptor_b592b3c4e9ae6813 = []byte{
-	// 509 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x5d, 0x6f, 0xda, 0x30,
-	0x14, 0x4d, 0x0a, 0x6d, 0xe9, 0x25, 0xad, 0x90, 0xa7, 0x6e, 0x34, 0x30, 0xad, 0x4b, 0x5f, 0xfa,
-	0x14, 0x34, 0xa6, 0x4d, 0x7b, 0x98, 0xa6, 0x85, 0x8f, 0x42, 0xd5, 0x87, 0x4a, 0x4e, 0xa5, 0x49,
-	0x7b, 0x41, 0xa9, 0xb9, 0x0b, 0x11, 0x14, 0x67, 0xb6, 0x11, 0xca, 0x3f, 0xd9, 0x3f, 0xd8, 0xff,
-	0xd8, 0x2f, 0x9b, 0x62, 0x42, 0x42, 0x24, 0xd6, 0x97, 0x3d, 0xc5, 0xf6, 0x39, 0xf7, 0x9c, 0x9b,
-	0x63, 0x5f, 0x78, 0xc3, 0x05, 0xfb, 0x24, 0x3a, 0xb1, 0xe0, 0x8a, 0xcb, 0xce, 0x23, 0xe7, 0x4a,
-	0x2a, 0x11, 0xc4, 0x31, 0x0a, 0x57, 0x9f, 0x91, 0xfa, 0x53, 0x10, 0x3e, 0x05, 0xae, 0xa6, 0xd9,
-	0xed, 0x12, 0x9b, 0xa1, 0x50, 0xd1, 0x8f, 0x68, 0x4b, 0xb5, 0x5b, 0x25, 0x34, 0x9a, 0xe2, 0x52,
-	0x45, 0x2a, 0xd9, 0x80, 0x4e, 0x08, 0x27, 0xfd, 0x59, 0xb0, 0x58, 0xe0, 0x32, 0x44, 0xf2, 0x19,
-	0x6a, 0x73, 0x4c, 0x26, 0x2a, 0x89, 0xb1, 0x69, 0x5e, 0x9a, 0xd7, 0x67, 0xdd, 0xb7, 0xee, 0x8e,
-	0x8f, 0x9b, 0x33, 0xef, 0x30, 0x71, 0xef, 0x30, 0x79, 0x48, 0x62, 0xa4, 0xc7, 0xf3, 0xcd, 0x82,
-	0xb4, 0xe1, 0x84, 0x6d, 0x09, 0xcd, 0x83, 0x4b, 0xf3, 0xda, 0xa2, 0xc5, 0x81, 0xf3, 0xdb, 0x04,
-	0x6b, 0xb7, 0xfe, 0x3f, 0xcd, 0x1a, 0x50, 0x99, 0x63, 0x92, 0xd9, 0xa4, 0x4b, 0x67, 0x04, 0xc7,
-	0x19, 0x8b, 0xd4, 0xa0, 0x3a, 0xec, 0x8f, 0xef, 0x1b, 0x06, 0x79, 0x05, 0x2f, 0xfc, 0xfb, 0x9b,
-	0x87, 0x6f, 0x1e, 0x1d, 0x4e, 0xa8, 0xef, 0x4d, 0xfc, 0xb1, 0xd7, 0xfd, 0xf0, 0xb1, 0x61, 0x92,
-	0x0b, 0x38, 0xcf, 0x81, 0x61, 0x7f, 0x50, 0x40, 0x07, 0xce, 0x9f, 0x0a, 0xd4, 0x28, 0xca, 0x98,
-	0x2f, 0x25, 0x92, 0x77, 0x70, 0x38, 0x5b, 0x4f, 0xa2, 0xa9, 0x6e, 0xb1, 0xde, 0x6d, 0x97, 0x5a,
-	0xf4, 0x18, 0x43, 0x29, 0x47, 0x81, 0xc2, 0x75, 0x90, 0xdc, 0x0e, 0x68, 0x75, 0xb6, 0xbe, 0x9d,
-	0x3e, 0x9f, 0x03, 0xf1, 0xe0, 0x14, 0xd9, 0x8c, 0x4f, 0x44, 0xe6, 0xd0, 0xac, 0x68, 0x61, 0xbb,
-	0x24, 0xbc, 0xb5, 0x77, 0x87, 0x6c, 0xc6, 0xc7, 0x06, 0xb5, 0xd2, 0x92, 0xbc, 0xa7, 0x2f, 0x60,
-	0x09, 0x19, 0x14, 0x0a, 0x55, 0xad, 0x70, 0xb1, 0x5f, 0x81, 0xfa, 0xde, 0xd8, 0xa0, 0x75, 0x21,
-	0x83, 0xbc, 0x7e, 0x00, 0x67, 0xc8, 0xa6, 0xbb, 0x0a, 0x87, 0x5a, 0xa1, 0xf5, 0x8f, 0x1e, 0xd2,
-	0x78, 0xc6, 0x06, 0x3d, 0xd5, 0x45, 0xb9, 0x8a, 0x03, 0x15, 0x26, 0x45, 0xf3, 0x48, 0x97, 0x36,
-	0xca, 0x57, 0xe7, 0x53, 0x9a, 0x82, 0xb6, 0x03, 0xd5, 0xf4, 0x0f, 0x88, 0x0d, 0xb5, 0xdc, 0xcb,
-	0xd4, 0x89, 0xe4, 0x7b, 0xfb, 0x0a, 0x2a, 0xd4, 0xf7, 0xd2, 0xd4, 0x64, 0x14, 0x2e, 0x03, 0xb5,
-	0x12, 0x5b, 0x4e, 0x71, 0x60, 0x5f, 0xc1, 0xa1, 0x6e, 0x83, 0x58, 0x60, 0x8a, 0x0c, 0x36, 0x45,
-	0xba, 0x93, 0x59, 0xc4, 0xa6, 0xec, 0x41, 0xe1, 0xd2, 0xfd, 0x65, 0x82, 0xd5, 0xdb, 0x19, 0x1b,
-	0x72, 0x03, 0xd6, 0x08, 0x55, 0xf1, 0xd6, 0x9f, 0xbd, 0x49, 0xfb, 0xe5, 0xfe, 0xa7, 0xe8, 0x18,
-	0xe4, 0x2b, 0xd4, 0x29, 0xfe, 0x5c, 0xa1, 0x54, 0x7e, 0x14, 0x2e, 0xc9, 0xf9, 0xde, 0xcc, 0xec,
-	0x66, 0xb9, 0x7e, 0x33, 0x91, 0x2c, 0x50, 0xe8, 0x18, 0xbd, 0xd7, 0xdf, 0x5b, 0x1a, 0xec, 0x6c,
-	0xe6, 0x92, 0x2d, 0xf8, 0x6a, 0xda, 0x09, 0x79, 0x36, 0xa0, 0x8f, 0x47, 0xfa, 0xfb, 0xfe, 0x6f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x97, 0xaa, 0x0d, 0x91, 0x03, 0x04, 0x00, 0x00,
+	// 611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xc1, 0x4e, 0xdb, 0x4c,
+	0x10, 0x8e, 0x89, 0x21, 0x61, 0x62, 0xf8, 0xad, 0xfd, 0x4b, 0x1b, 0x0c, 0xa8, 0xd4, 0x1c, 0xca,
+	0x29, 0x11, 0xa1, 0x54, 0x54, 0xaa, 0xaa, 0x1a, 0x12, 0x08, 0xe5, 0x80, 0xb4, 0x46, 0xaa, 0xd4,
+	0x8b, 0x65, 0xec, 0xa9, 0x63, 0x01, 0xb6, 0xbb, 0xbb, 0x28, 0xf5, 0x23, 0xf4, 0x89, 0xfa, 0x2a,
+	0xbd, 0xf7, 0x45, 0x2a, 0x6f, 0x1c, 0x3b, 0x46, 0x21, 0x3d, 0xf4, 0x64, 0xef, 0x7e, 0xdf, 0x7c,
+	0x33, 0xfb, 0xed, 0xec, 0xc0, 0xcb, 0x98, 0x79, 0xc7, 0xac, 0x9b, 0xb0, 0x58, 0xc4, 0xbc, 0x7b,
+	0x13, 0xc7, 0x82, 0x0b, 0xe6, 0x26, 0x09, 0xb2, 0x8e, 0xdc, 0x23, 0xad, 0x7b, 0x37, 0xb8, 0x77,
+	0x3b, 0x92, 0x66, 0x6c, 0x57, 0xd8, 0x1e, 0x32, 0x11, 0x7e, 0x0d, 0xa7, 0x54, 0x63, 0xab, 0x82,
+	0x86, 0x3e, 0x46, 0x22, 0x14, 0xe9, 0x04, 0x34, 0x03, 0x58, 0x3d, 0x1d, 0xb9, 0x77, 0x77, 0x18,
+	0x05, 0x48, 0xde, 0x43, 0xf3, 0x16, 0x53, 0x47, 0xa4, 0x09, 0xb6, 0x95, 0x5d, 0x65, 0x7f, 0xbd,
+	0xf7, 0xaa, 0x33, 0x93, 0xa7, 0x53, 0x30, 0x2f, 0x31, 0xed, 0x5c, 0x62, 0x7a, 0x9d, 0x26, 0x48,
+	0x1b, 0xb7, 0x93, 0x1f, 0xb2, 0x0d, 0xab, 0xde, 0x94, 0xd0, 0x5e, 0xda, 0x55, 0xf6, 0x35, 0x5a,
+	0x6e, 0x98, 0xbf, 0x14, 0xd0, 0x66, 0xe3, 0xff, 0x31, 0x99, 0x0e, 0xf5, 0x5b, 0x4c, 0xf3, 0x34,
+	0xd9, 0xaf, 0xf9, 0x1d, 0x1a, 0x39, 0x8b, 0x34, 0x41, 0x1d, 0x9c, 0x0e, 0xaf, 0xf4, 0x1a, 0x79,
+	0x01, 0xff, 0xdb, 0x57, 0x67, 0xd7, 0x9f, 0x2d, 0x3a, 0x70, 0xa8, 0x6d, 0x39, 0xf6, 0xd0, 0xea,
+	0x1d, 0xbd, 0xd5, 0x15, 0xb2, 0x09, 0x1b, 0x05, 0x30, 0x38, 0xed, 0x97, 0xd0, 0xd2, 0x7c, 0xe8,
+	0xf0, 0xf8, 0x8d, 0x5e, 0x27, 0xcf, 0x40, 0x2f, 0xa1, 0x7e, 0xef, 0xe8, 0xe8, 0xe0, 0x9d, 0xae,
+	0x9a, 0x3f, 0x55, 0x68, 0x52, 0xe4, 0x49, 0x1c, 0x71, 0x24, 0x07, 0xb0, 0x3c, 0x1a, 0x3b, 0xa1,
+	0x2f, 0xcf, 0xd4, 0xea, 0x6d, 0x57, 0xce, 0x64, 0x79, 0x1e, 0x72, 0x7e, 0xee, 0x0a, 0x1c, 0xbb,
+	0xe9, 0x45, 0x9f, 0xaa, 0xa3, 0xf1, 0x85, 0xbf, 0xd8, 0x38, 0x62, 0xc1, 0x1a, 0x7a, 0xa3, 0xd8,
+	0x61, 0x79, 0x86, 0x76, 0x5d, 0x0a, 0x1b, 0x15, 0xe1, 0x69, 0xfa, 0xce, 0xc0, 0x1b, 0xc5, 0xc3,
+	0x1a, 0xd5, 0xb2, 0x90, 0xa2, 0xa6, 0x0f, 0xa0, 0x31, 0xee, 0x96, 0x0a, 0xaa, 0x54, 0xd8, 0x9c,
+	0xaf, 0x40, 0x6d, 0x6b, 0x58, 0xa3, 0x2d, 0xc6, 0xdd, 0x22, 0xbe, 0x0f, 0xeb, 0xe8, 0xf9, 0xb3,
+	0x0a, 0xcb, 0x52, 0x61, 0xeb, 0x89, 0x1a, 0x32, 0xd3, 0x86, 0x35, 0xba, 0x26, 0x83, 0x0a, 0x95,
+	0x4f, 0xa0, 0xa3, 0x2f, 0x3d, 0x2b, 0x75, 0x1a, 0x52, 0x67, 0xe7, 0x09, 0x9d, 0x09, 0x7b, 0x58,
+	0xa3, 0xff, 0xe5, 0x81, 0x85, 0x96, 0x09, 0x75, 0x8f, 0xb3, 0xf6, 0x8a, 0x0c, 0xd7, 0xab, 0x7d,
+	0x63, 0x53, 0x9a, 0x81, 0x86, 0x09, 0x6a, 0xe6, 0x06, 0x31, 0xa0, 0x59, 0xe4, 0x53, 0xa4, 0xbb,
+	0xc5, 0xda, 0xd8, 0x83, 0x3a, 0xb5, 0xad, 0xec, 0x06, 0x78, 0x18, 0x44, 0xae, 0x78, 0x60, 0x53,
+	0x4e, 0xb9, 0x61, 0xec, 0xc1, 0xb2, 0x3c, 0x12, 0xd1, 0x40, 0x61, 0x39, 0xac, 0xb0, 0x6c, 0xc5,
+	0xf3, 0xeb, 0x52, 0xb8, 0xf1, 0x1a, 0x1a, 0x79, 0xbd, 0x8b, 0xd5, 0x4e, 0xa0, 0x2c, 0xc7, 0xfc,
+	0xa1, 0x80, 0x46, 0x31, 0xc2, 0x31, 0xc5, 0x6f, 0x0f, 0xc8, 0x05, 0xd9, 0x84, 0x66, 0xf6, 0x7c,
+	0x1d, 0x1f, 0xa7, 0x89, 0x1a, 0xd9, 0xba, 0x8f, 0xec, 0x2f, 0x5d, 0x92, 0x1b, 0x52, 0x5f, 0x60,
+	0x48, 0xb5, 0x2e, 0xf5, 0x51, 0x5d, 0xbd, 0xdf, 0x0a, 0x68, 0x27, 0x33, 0x83, 0x86, 0x9c, 0x81,
+	0x76, 0x8e, 0xa2, 0x9c, 0x0e, 0x0b, 0x5b, 0xd9, 0x78, 0x3e, 0xff, 0xf1, 0x9a, 0x35, 0xf2, 0x11,
+	0x5a, 0xf9, 0xf1, 0xec, 0x30, 0x88, 0xc8, 0xc6, 0xdc, 0xcb, 0x36, 0xda, 0xd5, 0xf8, 0xc9, 0x0c,
+	0xf3, 0x5c, 0x91, 0x29, 0x5c, 0x80, 0x2e, 0x5d, 0x9a, 0xd9, 0x25, 0x8f, 0xbb, 0xb7, 0x34, 0x71,
+	0x91, 0xd4, 0xc9, 0xce, 0x97, 0x2d, 0x09, 0x76, 0x27, 0x43, 0xd1, 0xbb, 0x8b, 0x1f, 0xfc, 0x6e,
+	0x10, 0xe7, 0xd3, 0xf1, 0x66, 0x45, 0x7e, 0x0f, 0xff, 0x0c, 0x00, 0xc4, 0x9c, 0x22, 0x6b, 0x80,
+	0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -459,6 +596,11 @@ type BootstrapperClient interface {
 	// send back response and csr for signing
 	// Returns signed certificate.
 	RequestSign(ctx context.Context, in *Response, opts ...grpc.CallOption) (*Certificate, error)
+	// renew the gateway certificate while it is still valid. The gateway
+	// proves possession of its current certificate's key by signing a
+	// challenge, its challenge key is not used.
+	// Returns signed certificate.
+	RenewCertificate(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*Certificate, error)
 }
 
 type bootstrapperClient struct {
@@ -487,6 +629,15 @@ func (c *bootstrapperClient) RequestSign(ctx context.Context, in *Response, opts
 	return out, nil
 }
 
+func (c *bootstrapperClient) RenewCertificate(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*Certificate, error) {
+	out := new(Certificate)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.Bootstrapper/RenewCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BootstrapperServer is the server API for Bootstrapper service.
 type BootstrapperServer interface {
 	// get the challange for gateway specified in hw_id (AccessGatewayID)
@@ -494,6 +645,11 @@ type BootstrapperServer interface {
 	// send back response and csr for signing
 	// Returns signed certificate.
 	RequestSign(context.Context, *Response) (*Certificate, error)
+	// renew the gateway certificate while it is still valid. The gateway
+	// proves possession of its current certificate's key by signing a
+	// challenge, its challenge key is not used.
+	// Returns signed certificate.
+	RenewCertificate(context.Context, *RenewRequest) (*Certificate, error)
 }
 
 // UnimplementedBootstrapperServer can be embedded to have forward compatible implementations.
@@ -506,6 +662,9 @@ func (*UnimplementedBootstrapperServer) GetChallenge(ctx context.Context, req *A
 func (*UnimplementedBootstrapperServer) RequestSign(ctx context.Context, req *Response) (*Certificate, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RequestSign not implemented")
 }
+func (*UnimplementedBootstrapperServer) RenewCertificate(ctx context.Context, req *RenewRequest) (*Certificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewCertificate not implemented")
+}
 
 func RegisterBootstrapperServer(s *grpc.Server, srv BootstrapperServer) {
 	s.RegisterService(&_Bootstrapper_serviceDesc, srv)
@@ -547,6 +706,24 @@ func _Bootstrapper_RequestSign_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bootstrapper_RenewCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BootstrapperServer).RenewCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.Bootstrapper/RenewCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BootstrapperServer).RenewCertificate(ctx, req.(*RenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Bootstrapper_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.Bootstrapper",
 	HandlerType: (*BootstrapperServer)(nil),
@@ -559,6 +736,10 @@ var _Bootstrapper_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RequestSign",
 			Handler:    _Bootstrapper_RequestSign_Handler,
 		},
+		{
+			MethodName: "RenewCertificate",
+			Handler:    _Bootstrapper_RenewCertificate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "orc8r/protos/bootstrapper.proto",
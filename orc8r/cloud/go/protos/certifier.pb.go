@@ -111,13 +111,16 @@ func (m *CSR) GetCertType() CertType {
 }
 
 type Certificate struct {
-	Sn                   *Certificate_SN      `protobuf:"bytes,1,opt,name=sn,proto3" json:"sn,omitempty"`
-	NotBefore            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
-	NotAfter             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
-	CertDer              []byte               `protobuf:"bytes,4,opt,name=cert_der,json=certDer,proto3" json:"cert_der,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Sn        *Certificate_SN      `protobuf:"bytes,1,opt,name=sn,proto3" json:"sn,omitempty"`
+	NotBefore *timestamp.Timestamp `protobuf:"bytes,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	CertDer   []byte               `protobuf:"bytes,4,opt,name=cert_der,json=certDer,proto3" json:"cert_der,omitempty"`
+	// intermediate CA certificates in DER encoding, which chain cert_der
+	// to a root CA, issuer of cert_der first
+	CertChainDer         [][]byte `protobuf:"bytes,5,rep,name=cert_chain_der,json=certChainDer,proto3" json:"cert_chain_der,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Certificate) Reset()         { *m = Certificate{} }
@@ -173,6 +176,13 @@ func (m *Certificate) GetCertDer() []byte {
 	return nil
 }
 
+func (m *Certificate) GetCertChainDer() [][]byte {
+	if m != nil {
+		return m.CertChainDer
+	}
+	return nil
+}
+
 type Certificate_SN struct {
 	Sn                   string   `protobuf:"bytes,1,opt,name=sn,proto3" json:"sn,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -262,30 +272,32 @@ func init() {
 func init() { proto.RegisterFile("orc8r/protos/certifier.proto", fileDescriptor_309897dc79f61bc0) }
 
 var fileDescriptor_309897dc79f61bc0 = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x5d, 0xab, 0xd3, 0x30,
-	0x18, 0xc7, 0x6d, 0x37, 0xd6, 0xf5, 0xe9, 0xe1, 0x70, 0x08, 0x8a, 0x3d, 0xdd, 0x51, 0xcb, 0x40,
-	0x18, 0x0a, 0x29, 0x1c, 0x2f, 0x3c, 0x5e, 0x6e, 0xad, 0x82, 0x20, 0x43, 0xb2, 0xea, 0x85, 0x37,
-	0xa5, 0x6b, 0xd3, 0x12, 0x58, 0x9b, 0x92, 0x66, 0xc2, 0x3e, 0x97, 0x1f, 0xc8, 0xaf, 0x22, 0x49,
-	0x53, 0xd1, 0x0d, 0xce, 0x55, 0x93, 0x3e, 0xbf, 0xf0, 0x7f, 0xe1, 0x81, 0x3b, 0x2e, 0x8a, 0x07,
-	0x11, 0x75, 0x82, 0x4b, 0xde, 0x47, 0x05, 0x15, 0x92, 0x55, 0x8c, 0x0a, 0xac, 0x7f, 0x20, 0xaf,
-	0xc9, 0xeb, 0x26, 0xc7, 0x9a, 0x09, 0x16, 0xff, 0xa1, 0xac, 0xa4, 0xad, 0x64, 0xf2, 0x34, 0x90,
-	0xc1, 0xab, 0x9a, 0xf3, 0xfa, 0x40, 0x87, 0xe9, 0xfe, 0x58, 0x45, 0x92, 0x35, 0xb4, 0x97, 0x79,
-	0xd3, 0x19, 0xe0, 0xe5, 0x39, 0x50, 0x1e, 0x45, 0x2e, 0x19, 0x6f, 0x87, 0xf9, 0xf2, 0x97, 0x05,
-	0x93, 0x78, 0x47, 0xd0, 0x6b, 0xb0, 0x59, 0xe9, 0x5b, 0xa1, 0xb5, 0xf2, 0xee, 0x9f, 0xe1, 0x7f,
-	0xf4, 0xf1, 0x67, 0xa3, 0x48, 0x6c, 0x56, 0xa2, 0x07, 0x80, 0x9f, 0xf9, 0x81, 0x95, 0x99, 0xd2,
-	0xf1, 0x6d, 0x8d, 0xdf, 0xe2, 0x41, 0x03, 0x8f, 0x1a, 0x38, 0x31, 0x1a, 0xc4, 0xd5, 0x70, 0xca,
-	0x1a, 0x8a, 0x9e, 0x83, 0x53, 0xf4, 0x22, 0x2b, 0xa9, 0xf0, 0x27, 0xa1, 0xb5, 0xba, 0x22, 0xb3,
-	0xa2, 0x17, 0x09, 0x15, 0xe8, 0x1e, 0x5c, 0x95, 0x3f, 0x93, 0xa7, 0x8e, 0xfa, 0xd3, 0xd0, 0x5a,
-	0x5d, 0x9f, 0x19, 0x88, 0xa9, 0x90, 0xe9, 0xa9, 0xa3, 0x64, 0x5e, 0x98, 0xd3, 0xf2, 0xb7, 0x05,
-	0x5e, 0x3c, 0x94, 0x56, 0xe4, 0x92, 0xa2, 0xb7, 0x60, 0xf7, 0xad, 0x71, 0xbf, 0xb8, 0x78, 0x6c,
-	0x28, 0xbc, 0xdb, 0x12, 0xbb, 0x6f, 0xd1, 0x07, 0x80, 0x96, 0xcb, 0x6c, 0x4f, 0x2b, 0x2e, 0xc6,
-	0x0c, 0xc1, 0x45, 0x86, 0x74, 0x2c, 0x92, 0xb8, 0x2d, 0x97, 0x1b, 0x0d, 0xa3, 0xf7, 0xa0, 0x2e,
-	0x59, 0x5e, 0x49, 0x13, 0xe3, 0xf1, 0x97, 0xf3, 0x96, 0xcb, 0xb5, 0x62, 0xd1, 0x2d, 0x68, 0xf3,
-	0x3a, 0xfe, 0x54, 0xc7, 0x77, 0xd4, 0x3d, 0xa1, 0x22, 0x78, 0x0a, 0xf6, 0x6e, 0x8b, 0xae, 0xff,
-	0x26, 0x70, 0x95, 0xc9, 0xe5, 0x1d, 0xcc, 0xe2, 0xb5, 0x32, 0x8f, 0x10, 0x4c, 0x15, 0xaa, 0x67,
-	0x57, 0x44, 0x9f, 0xdf, 0x84, 0x30, 0x1f, 0x5b, 0x41, 0x1e, 0x38, 0xc9, 0xc7, 0x4f, 0xeb, 0x6f,
-	0x5f, 0xd2, 0x9b, 0x27, 0xc8, 0x81, 0xc9, 0xf7, 0xaf, 0xdb, 0x1b, 0x6b, 0xf3, 0xe2, 0xc7, 0x42,
-	0xd7, 0x10, 0x0d, 0xdb, 0x53, 0x1c, 0xf8, 0xb1, 0x8c, 0x6a, 0x6e, 0xd6, 0x68, 0x3f, 0xd3, 0xdf,
-	0x77, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xf5, 0xa8, 0xb0, 0xa6, 0x88, 0x02, 0x00, 0x00,
+	// 418 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xdf, 0xab, 0xd3, 0x30,
+	0x14, 0xc7, 0x6d, 0x37, 0xf7, 0xe3, 0x6c, 0x8c, 0x4b, 0x50, 0xdc, 0xdd, 0xae, 0x5a, 0x86, 0xc2,
+	0x50, 0x48, 0xe1, 0xfa, 0xe0, 0xf5, 0x71, 0xb7, 0x53, 0x10, 0x64, 0x48, 0x56, 0x7d, 0xf0, 0xa5,
+	0x64, 0x69, 0x5a, 0x03, 0x6b, 0x52, 0xd2, 0x4c, 0xd8, 0x1f, 0xe0, 0x5f, 0xe4, 0x3f, 0x28, 0x49,
+	0x53, 0xd1, 0x7b, 0xc1, 0xa7, 0x36, 0xe7, 0x7c, 0xc2, 0xf7, 0x7c, 0x0e, 0x81, 0x2b, 0xa5, 0xd9,
+	0x8d, 0x8e, 0x6b, 0xad, 0x8c, 0x6a, 0x62, 0xc6, 0xb5, 0x11, 0x85, 0xe0, 0x1a, 0xbb, 0x02, 0x9a,
+	0x54, 0xb4, 0xac, 0x28, 0x76, 0xcc, 0x62, 0xf9, 0x0f, 0x2a, 0x72, 0x2e, 0x8d, 0x30, 0xe7, 0x96,
+	0x5c, 0x3c, 0x2f, 0x95, 0x2a, 0x8f, 0xbc, 0xed, 0x1e, 0x4e, 0x45, 0x6c, 0x44, 0xc5, 0x1b, 0x43,
+	0xab, 0xda, 0x03, 0xcf, 0xee, 0x02, 0xf9, 0x49, 0x53, 0x23, 0x94, 0x6c, 0xfb, 0xab, 0x5f, 0x01,
+	0xf4, 0x92, 0x3d, 0x41, 0x2f, 0x21, 0x14, 0xf9, 0x3c, 0x88, 0x82, 0xf5, 0xe4, 0xfa, 0x31, 0xfe,
+	0x2b, 0x1f, 0x7f, 0xf4, 0x89, 0x24, 0x14, 0x39, 0xba, 0x01, 0xf8, 0x41, 0x8f, 0x22, 0xcf, 0x6c,
+	0xce, 0x3c, 0x74, 0xf8, 0x25, 0x6e, 0x33, 0x70, 0x97, 0x81, 0xb7, 0x3e, 0x83, 0x8c, 0x1d, 0x9c,
+	0x8a, 0x8a, 0xa3, 0x27, 0x30, 0x64, 0x8d, 0xce, 0x72, 0xae, 0xe7, 0xbd, 0x28, 0x58, 0x4f, 0xc9,
+	0x80, 0x35, 0x7a, 0xcb, 0x35, 0xba, 0x86, 0xb1, 0xf5, 0xcf, 0xcc, 0xb9, 0xe6, 0xf3, 0x7e, 0x14,
+	0xac, 0x67, 0x77, 0x06, 0x48, 0xb8, 0x36, 0xe9, 0xb9, 0xe6, 0x64, 0xc4, 0xfc, 0xdf, 0xea, 0x67,
+	0x08, 0x93, 0xa4, 0x5d, 0x1a, 0xa3, 0x86, 0xa3, 0xd7, 0x10, 0x36, 0xd2, 0x4f, 0xbf, 0xbc, 0x77,
+	0xd9, 0x53, 0x78, 0xbf, 0x23, 0x61, 0x23, 0xd1, 0x3b, 0x00, 0xa9, 0x4c, 0x76, 0xe0, 0x85, 0xd2,
+	0x9d, 0xc3, 0xe2, 0x9e, 0x43, 0xda, 0x2d, 0x92, 0x8c, 0xa5, 0x32, 0xb7, 0x0e, 0x46, 0x6f, 0xc1,
+	0x1e, 0x32, 0x5a, 0x18, 0xaf, 0xf1, 0xff, 0x9b, 0x23, 0xa9, 0xcc, 0xc6, 0xb2, 0xe8, 0x12, 0xdc,
+	0xf0, 0x4e, 0xbf, 0xef, 0xf4, 0x87, 0xf6, 0x6c, 0xfd, 0x5f, 0xc0, 0xcc, 0xb5, 0xd8, 0x77, 0x2a,
+	0xa4, 0x03, 0x1e, 0x46, 0xbd, 0xf5, 0x94, 0x4c, 0x6d, 0x35, 0xb1, 0xc5, 0x2d, 0xd7, 0x8b, 0x47,
+	0x10, 0xee, 0x77, 0x68, 0xf6, 0xc7, 0x73, 0x6c, 0x55, 0x56, 0x57, 0x30, 0x48, 0x36, 0x56, 0x11,
+	0x21, 0xe8, 0x5b, 0xde, 0xf5, 0xa6, 0xc4, 0xfd, 0xbf, 0x8a, 0x60, 0xd4, 0xed, 0x0e, 0x4d, 0x60,
+	0xb8, 0x7d, 0xff, 0x61, 0xf3, 0xe5, 0x53, 0x7a, 0xf1, 0x00, 0x0d, 0xa1, 0xf7, 0xf5, 0xf3, 0xee,
+	0x22, 0xb8, 0x7d, 0xfa, 0x6d, 0xe9, 0x96, 0x15, 0xb7, 0x6f, 0x8c, 0x1d, 0xd5, 0x29, 0x8f, 0x4b,
+	0xe5, 0x1f, 0xdb, 0x61, 0xe0, 0xbe, 0x6f, 0x7e, 0x0f, 0x00, 0xdf, 0x3f, 0x93, 0x2d, 0xae, 0x02,
+	0x00, 0x00,
 }
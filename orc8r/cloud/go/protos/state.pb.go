@@ -119,10 +119,13 @@ func (m *GetStatesRequest) GetIds() []*StateID {
 }
 
 type GetStatesResponse struct {
-	States               []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	States []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	// IDs of the returned states which haven't been re-reported within their
+	// state type's TTL
+	StaleIds             []*StateID `protobuf:"bytes,2,rep,name=staleIds,proto3" json:"staleIds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *GetStatesResponse) Reset()         { *m = GetStatesResponse{} }
@@ -157,6 +160,175 @@ func (m *GetStatesResponse) GetStates() []*State {
 	return nil
 }
 
+func (m *GetStatesResponse) GetStaleIds() []*StateID {
+	if m != nil {
+		return m.StaleIds
+	}
+	return nil
+}
+
+// SearchStatesRequest filters the states of a network. Empty filter fields
+// match all states.
+type SearchStatesRequest struct {
+	NetworkID string   `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Types     []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	// Prefix of the device IDs (keys) of states to return
+	KeyPrefix string `protobuf:"bytes,3,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// Hardware ID of the gateway which reported the states
+	ReporterHardwareID string `protobuf:"bytes,4,opt,name=reporterHardwareID,proto3" json:"reporterHardwareID,omitempty"`
+	// Inclusive bounds on the time at which the states were reported, in
+	// milliseconds since epoch. 0 means unbounded.
+	ReportedAfterMs  uint64 `protobuf:"varint,5,opt,name=reportedAfterMs,proto3" json:"reportedAfterMs,omitempty"`
+	ReportedBeforeMs uint64 `protobuf:"varint,6,opt,name=reportedBeforeMs,proto3" json:"reportedBeforeMs,omitempty"`
+	// Max number of states to return. Defaults to 100 if 0.
+	PageSize uint32 `protobuf:"varint,10,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// Token from the previous page's response to continue searching from
+	PageToken            string   `protobuf:"bytes,11,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchStatesRequest) Reset()         { *m = SearchStatesRequest{} }
+func (m *SearchStatesRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchStatesRequest) ProtoMessage()    {}
+func (*SearchStatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{3}
+}
+
+func (m *SearchStatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchStatesRequest.Unmarshal(m, b)
+}
+func (m *SearchStatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchStatesRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchStatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchStatesRequest.Merge(m, src)
+}
+func (m *SearchStatesRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchStatesRequest.Size(m)
+}
+func (m *SearchStatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchStatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchStatesRequest proto.InternalMessageInfo
+
+func (m *SearchStatesRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *SearchStatesRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *SearchStatesRequest) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return ""
+}
+
+func (m *SearchStatesRequest) GetReporterHardwareID() string {
+	if m != nil {
+		return m.ReporterHardwareID
+	}
+	return ""
+}
+
+func (m *SearchStatesRequest) GetReportedAfterMs() uint64 {
+	if m != nil {
+		return m.ReportedAfterMs
+	}
+	return 0
+}
+
+func (m *SearchStatesRequest) GetReportedBeforeMs() uint64 {
+	if m != nil {
+		return m.ReportedBeforeMs
+	}
+	return 0
+}
+
+func (m *SearchStatesRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchStatesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type SearchStatesResponse struct {
+	States []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	// IDs of the returned states which haven't been re-reported within their
+	// state type's TTL
+	StaleIds []*StateID `protobuf:"bytes,2,rep,name=staleIds,proto3" json:"staleIds,omitempty"`
+	// Token to pass in the next request to load the next page of states.
+	// Empty if there are no more states.
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchStatesResponse) Reset()         { *m = SearchStatesResponse{} }
+func (m *SearchStatesResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchStatesResponse) ProtoMessage()    {}
+func (*SearchStatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_645e93724c8b4dfe, []int{4}
+}
+
+func (m *SearchStatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchStatesResponse.Unmarshal(m, b)
+}
+func (m *SearchStatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchStatesResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchStatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchStatesResponse.Merge(m, src)
+}
+func (m *SearchStatesResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchStatesResponse.Size(m)
+}
+func (m *SearchStatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchStatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchStatesResponse proto.InternalMessageInfo
+
+func (m *SearchStatesResponse) GetStates() []*State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+func (m *SearchStatesResponse) GetStaleIds() []*StateID {
+	if m != nil {
+		return m.StaleIds
+	}
+	return nil
+}
+
+func (m *SearchStatesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type ReportStatesRequest struct {
 	States               []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -168,7 +340,7 @@ func (m *ReportStatesRequest) Reset()         { *m = ReportStatesRequest{} }
 func (m *ReportStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*ReportStatesRequest) ProtoMessage()    {}
 func (*ReportStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_645e93724c8b4dfe, []int{3}
+	return fileDescriptor_645e93724c8b4dfe, []int{5}
 }
 
 func (m *ReportStatesRequest) XXX_Unmarshal(b []byte) error {
@@ -207,7 +379,7 @@ func (m *ReportStatesResponse) Reset()         { *m = ReportStatesResponse{} }
 func (m *ReportStatesResponse) String() string { return proto.CompactTextString(m) }
 func (*ReportStatesResponse) ProtoMessage()    {}
 func (*ReportStatesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_645e93724c8b4dfe, []int{4}
+	return fileDescriptor_645e93724c8b4dfe, []int{6}
 }
 
 func (m *ReportStatesResponse) XXX_Unmarshal(b []byte) error {
@@ -248,7 +420,7 @@ func (m *IDAndError) Reset()         { *m = IDAndError{} }
 func (m *IDAndError) String() string { return proto.CompactTextString(m) }
 func (*IDAndError) ProtoMessage()    {}
 func (*IDAndError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_645e93724c8b4dfe, []int{5}
+	return fileDescriptor_645e93724c8b4dfe, []int{7}
 }
 
 func (m *IDAndError) XXX_Unmarshal(b []byte) error {
@@ -302,7 +474,7 @@ func (m *DeleteStatesRequest) Reset()         { *m = DeleteStatesRequest{} }
 func (m *DeleteStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteStatesRequest) ProtoMessage()    {}
 func (*DeleteStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_645e93724c8b4dfe, []int{6}
+	return fileDescriptor_645e93724c8b4dfe, []int{8}
 }
 
 func (m *DeleteStatesRequest) XXX_Unmarshal(b []byte) error {
@@ -348,7 +520,7 @@ func (m *SyncStatesRequest) Reset()         { *m = SyncStatesRequest{} }
 func (m *SyncStatesRequest) String() string { return proto.CompactTextString(m) }
 func (*SyncStatesRequest) ProtoMessage()    {}
 func (*SyncStatesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_645e93724c8b4dfe, []int{7}
+	return fileDescriptor_645e93724c8b4dfe, []int{9}
 }
 
 func (m *SyncStatesRequest) XXX_Unmarshal(b []byte) error {
@@ -388,7 +560,7 @@ func (m *IDAndVersion) Reset()         { *m = IDAndVersion{} }
 func (m *IDAndVersion) String() string { return proto.CompactTextString(m) }
 func (*IDAndVersion) ProtoMessage()    {}
 func (*IDAndVersion) Descriptor() ([]byte, []int) {
-	return fileDescriptor_645e93724c8b4dfe, []int{8}
+	return fileDescriptor_645e93724c8b4dfe, []int{10}
 }
 
 func (m *IDAndVersion) XXX_Unmarshal(b []byte) error {
@@ -434,7 +606,7 @@ func (m *SyncStatesResponse) Reset()         { *m = SyncStatesResponse{} }
 func (m *SyncStatesResponse) String() string { return proto.CompactTextString(m) }
 func (*SyncStatesResponse) ProtoMessage()    {}
 func (*SyncStatesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_645e93724c8b4dfe, []int{9}
+	return fileDescriptor_645e93724c8b4dfe, []int{11}
 }
 
 func (m *SyncStatesResponse) XXX_Unmarshal(b []byte) error {
@@ -466,6 +638,8 @@ func init() {
 	proto.RegisterType((*StateID)(nil), "magma.orc8r.StateID")
 	proto.RegisterType((*GetStatesRequest)(nil), "magma.orc8r.GetStatesRequest")
 	proto.RegisterType((*GetStatesResponse)(nil), "magma.orc8r.GetStatesResponse")
+	proto.RegisterType((*SearchStatesRequest)(nil), "magma.orc8r.SearchStatesRequest")
+	proto.RegisterType((*SearchStatesResponse)(nil), "magma.orc8r.SearchStatesResponse")
 	proto.RegisterType((*ReportStatesRequest)(nil), "magma.orc8r.ReportStatesRequest")
 	proto.RegisterType((*ReportStatesResponse)(nil), "magma.orc8r.ReportStatesResponse")
 	proto.RegisterType((*IDAndError)(nil), "magma.orc8r.IDAndError")
@@ -478,37 +652,47 @@ func init() {
 func init() { proto.RegisterFile("orc8r/protos/state.proto", fileDescriptor_645e93724c8b4dfe) }
 
 var fileDescriptor_645e93724c8b4dfe = []byte{
-	// 471 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x4f, 0x6f, 0xd3, 0x30,
-	0x14, 0x5f, 0xd3, 0xb1, 0xd1, 0xb7, 0x0a, 0xad, 0x6f, 0x95, 0xc8, 0x02, 0x85, 0x62, 0x21, 0x34,
-	0x71, 0x68, 0x61, 0xbd, 0xc0, 0x09, 0x15, 0x32, 0x50, 0x25, 0x04, 0x92, 0x2b, 0x06, 0x62, 0xa7,
-	0x12, 0x3f, 0x4d, 0x11, 0xab, 0x5d, 0x6c, 0x77, 0xa8, 0x9f, 0x91, 0x2f, 0x85, 0xea, 0x64, 0x6d,
-	0x9c, 0xac, 0x93, 0x76, 0xd8, 0xa9, 0x79, 0xfe, 0xfd, 0xe9, 0xcf, 0xcf, 0xcf, 0x86, 0x50, 0xe9,
-	0xe4, 0x8d, 0xee, 0xcf, 0xb4, 0xb2, 0xca, 0xf4, 0x8d, 0x9d, 0x58, 0xea, 0xb9, 0x02, 0xf7, 0xa6,
-	0x93, 0xf3, 0xe9, 0xa4, 0xe7, 0xf0, 0xe8, 0xd0, 0xa3, 0x25, 0x6a, 0x3a, 0x55, 0x32, 0xe3, 0x45,
-	0x1d, 0xdf, 0x81, 0xf4, 0x65, 0x9a, 0xd0, 0xe0, 0xd5, 0x20, 0x83, 0xd9, 0x5b, 0xd8, 0x1d, 0x2f,
-	0x5d, 0x47, 0x31, 0x22, 0x6c, 0xdb, 0xc5, 0x8c, 0xc2, 0x5a, 0xb7, 0x76, 0xd4, 0xe0, 0xee, 0x1b,
-	0x23, 0xb8, 0x2f, 0x68, 0xa9, 0x18, 0xc5, 0x61, 0xe0, 0xd6, 0x57, 0x35, 0xfb, 0x01, 0xfb, 0x9f,
-	0xc8, 0x3a, 0xb5, 0xe1, 0xf4, 0x67, 0x4e, 0xc6, 0xe2, 0x63, 0x68, 0x48, 0xb2, 0x7f, 0x95, 0xfe,
-	0x3d, 0x8a, 0x73, 0xa3, 0xf5, 0x02, 0xbe, 0x80, 0x7a, 0x2a, 0x4c, 0x18, 0x74, 0xeb, 0x47, 0x7b,
-	0xc7, 0xed, 0x5e, 0x61, 0x07, 0xbd, 0x3c, 0x04, 0x5f, 0x12, 0xd8, 0x3b, 0x68, 0x15, 0x9c, 0xcd,
-	0x4c, 0x49, 0x43, 0xf8, 0x12, 0x76, 0xdc, 0xfe, 0x4d, 0x58, 0x73, 0x7a, 0xac, 0xea, 0x79, 0xce,
-	0x60, 0x43, 0x38, 0xe0, 0x34, 0x53, 0xba, 0x94, 0xee, 0x36, 0x16, 0x67, 0xd0, 0xf6, 0x2d, 0xf2,
-	0x18, 0x1f, 0x60, 0x7f, 0x2e, 0xb5, 0x43, 0x48, 0x8c, 0x8b, 0x6e, 0x0f, 0x3d, 0xb7, 0x51, 0x3c,
-	0x94, 0xe2, 0x44, 0x6b, 0xa5, 0x79, 0x45, 0xc0, 0x38, 0xc0, 0x1a, 0xbf, 0x6d, 0xe3, 0xb1, 0x0d,
-	0xf7, 0x68, 0x29, 0x0c, 0xeb, 0x0e, 0xc8, 0x0a, 0x76, 0x06, 0x07, 0x31, 0x5d, 0x90, 0xa5, 0xbb,
-	0x38, 0x91, 0x8f, 0xd0, 0x1a, 0x2f, 0x64, 0xe2, 0x5b, 0xbf, 0x2e, 0xb5, 0xf3, 0xb0, 0xda, 0x80,
-	0x53, 0xd2, 0x26, 0x55, 0x72, 0xd5, 0xd5, 0x2f, 0xd0, 0x2c, 0xae, 0xe3, 0x73, 0x08, 0x52, 0xe1,
-	0x62, 0x6d, 0xfa, 0xfb, 0x20, 0x15, 0x18, 0xc2, 0xee, 0x65, 0x26, 0x70, 0xbd, 0xd8, 0xe6, 0x57,
-	0x25, 0xfb, 0x0e, 0x58, 0xcc, 0x95, 0x9f, 0xd1, 0x10, 0x1e, 0xcc, 0xa5, 0x59, 0xc8, 0xa4, 0x74,
-	0x42, 0x37, 0x04, 0x2c, 0x09, 0x8e, 0xff, 0x05, 0xd0, 0x74, 0x9f, 0xe3, 0xec, 0xc6, 0xe0, 0x67,
-	0x68, 0xac, 0x66, 0x12, 0x3b, 0x9e, 0x51, 0xf9, 0x16, 0x44, 0x4f, 0x36, 0xc1, 0x59, 0x3e, 0xb6,
-	0x85, 0xdf, 0xa0, 0x59, 0x9c, 0x2e, 0xec, 0x7a, 0x8a, 0x6b, 0x66, 0x37, 0x7a, 0x76, 0x03, 0x63,
-	0x65, 0x7b, 0x02, 0xcd, 0xe2, 0x0c, 0x94, 0x6c, 0xaf, 0x19, 0x8f, 0xa8, 0xe5, 0x31, 0x4e, 0x55,
-	0x2a, 0xd8, 0x16, 0x7e, 0x05, 0x58, 0x77, 0x15, 0xfd, 0xdd, 0x54, 0xc6, 0x20, 0x7a, 0xba, 0x11,
-	0xbf, 0xca, 0xf5, 0xbe, 0xf3, 0xf3, 0x91, 0xe3, 0xf4, 0xb3, 0xc7, 0x28, 0xb9, 0x50, 0x73, 0xd1,
-	0x3f, 0x57, 0xf9, 0xab, 0xf4, 0x6b, 0xc7, 0xfd, 0x0e, 0xfe, 0x07, 0x00, 0x00, 0xff, 0xff, 0x25,
-	0x92, 0x8a, 0xcd, 0xee, 0x04, 0x00, 0x00,
+	// 639 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdf, 0x53, 0xd3, 0x4e,
+	0x10, 0xa7, 0x3f, 0xf8, 0xd1, 0xa5, 0x7c, 0xbf, 0x70, 0x74, 0xc6, 0x10, 0x45, 0xcb, 0x0d, 0xe3,
+	0x74, 0x78, 0x68, 0x11, 0x5e, 0xf4, 0xb1, 0x58, 0xd4, 0xce, 0x88, 0x32, 0x89, 0xa2, 0x23, 0x4f,
+	0x31, 0x59, 0x30, 0x03, 0xcd, 0x95, 0xbb, 0x2b, 0x50, 0xff, 0x14, 0x1f, 0x7d, 0xf1, 0xdf, 0x74,
+	0x72, 0x97, 0x86, 0x5c, 0x02, 0x38, 0x7d, 0xd0, 0xa7, 0xdc, 0xee, 0x7e, 0xf6, 0x73, 0xbb, 0x9f,
+	0xbd, 0xbb, 0x80, 0xc5, 0xb8, 0xff, 0x9c, 0x77, 0x86, 0x9c, 0x49, 0x26, 0x3a, 0x42, 0x7a, 0x12,
+	0xdb, 0xca, 0x20, 0x8b, 0x03, 0xef, 0x74, 0xe0, 0xb5, 0x55, 0xdc, 0x5e, 0x33, 0x60, 0x3e, 0x1b,
+	0x0c, 0x58, 0xa4, 0x71, 0xf6, 0xba, 0xc9, 0x80, 0xfc, 0x32, 0xf4, 0x71, 0x77, 0x7b, 0x57, 0x87,
+	0xe9, 0x0b, 0x98, 0x77, 0x63, 0xd6, 0x7e, 0x8f, 0x10, 0xa8, 0xca, 0xf1, 0x10, 0xad, 0x52, 0xb3,
+	0xd4, 0xaa, 0x39, 0x6a, 0x4d, 0x6c, 0x58, 0x08, 0x30, 0xce, 0xe8, 0xf7, 0xac, 0xb2, 0xf2, 0xa7,
+	0x36, 0xfd, 0x0c, 0xcb, 0xaf, 0x51, 0xaa, 0x6c, 0xe1, 0xe0, 0xc5, 0x08, 0x85, 0x24, 0x8f, 0xa0,
+	0x16, 0xa1, 0xbc, 0x62, 0xfc, 0xac, 0xdf, 0x4b, 0x88, 0x6e, 0x1c, 0xe4, 0x29, 0x54, 0xc2, 0x40,
+	0x58, 0xe5, 0x66, 0xa5, 0xb5, 0xb8, 0xd3, 0x68, 0x67, 0x3a, 0x68, 0x27, 0x45, 0x38, 0x31, 0x80,
+	0x5e, 0xc0, 0x4a, 0x86, 0x59, 0x0c, 0x59, 0x24, 0x90, 0x6c, 0xc1, 0x9c, 0xea, 0x5f, 0x58, 0x25,
+	0x95, 0x4f, 0x8a, 0xf9, 0x4e, 0x82, 0x20, 0xdb, 0xb0, 0x20, 0xa4, 0x77, 0x8e, 0xfd, 0x3f, 0xec,
+	0x96, 0xa2, 0xe8, 0xaf, 0x32, 0xac, 0xba, 0xe8, 0x71, 0xff, 0xdb, 0x34, 0x0d, 0x35, 0x60, 0x36,
+	0x96, 0x49, 0x6f, 0x52, 0x73, 0xb4, 0x11, 0xe7, 0x9c, 0xe1, 0xf8, 0x90, 0xe3, 0x49, 0x78, 0x6d,
+	0x55, 0x74, 0x4e, 0xea, 0x20, 0x6d, 0x20, 0x1c, 0x87, 0x8c, 0x4b, 0xe4, 0x6f, 0x3c, 0x1e, 0x5c,
+	0x79, 0x3c, 0x16, 0xb7, 0xaa, 0x60, 0xb7, 0x44, 0x48, 0x0b, 0xfe, 0x4f, 0xbc, 0x41, 0xf7, 0x44,
+	0x22, 0x3f, 0x10, 0xd6, 0x6c, 0xb3, 0xd4, 0xaa, 0x3a, 0x79, 0x37, 0xd9, 0x82, 0xe5, 0x89, 0x6b,
+	0x0f, 0x4f, 0x18, 0xc7, 0x03, 0x61, 0xcd, 0x29, 0x68, 0xc1, 0x1f, 0x0f, 0x76, 0xe8, 0x9d, 0xa2,
+	0x1b, 0x7e, 0x47, 0x0b, 0x9a, 0xa5, 0xd6, 0x92, 0x93, 0xda, 0x71, 0xfd, 0xf1, 0xfa, 0x03, 0x3b,
+	0xc3, 0xc8, 0x5a, 0xd4, 0xf5, 0xa7, 0x0e, 0xfa, 0xa3, 0x04, 0x0d, 0x53, 0xa9, 0x7f, 0x31, 0x20,
+	0xb2, 0x09, 0x4b, 0x11, 0x5e, 0xcb, 0xc3, 0xb4, 0x30, 0x2d, 0xac, 0xe9, 0xa4, 0x5d, 0x58, 0x75,
+	0x54, 0xab, 0xe6, 0x14, 0xa7, 0x28, 0x8d, 0x1e, 0x43, 0xc3, 0xa4, 0x48, 0xda, 0x7b, 0x09, 0xcb,
+	0xa3, 0x68, 0xa2, 0xa3, 0x9b, 0x65, 0x7b, 0x60, 0xb0, 0xf5, 0x7b, 0xdd, 0x28, 0xd8, 0xe7, 0x9c,
+	0x71, 0xa7, 0x90, 0x40, 0x1d, 0x80, 0x9b, 0xf8, 0xb4, 0x37, 0x2e, 0x3e, 0x6e, 0x18, 0x27, 0x26,
+	0xbd, 0x6b, 0x83, 0x1e, 0xc3, 0x6a, 0x0f, 0xcf, 0x51, 0xe2, 0xdf, 0xb8, 0x8a, 0xaf, 0x60, 0xc5,
+	0x1d, 0x47, 0xbe, 0x49, 0xfd, 0x2c, 0x27, 0xe7, 0x5a, 0x51, 0x80, 0x23, 0xe4, 0x22, 0x64, 0x51,
+	0xaa, 0xea, 0x3b, 0xa8, 0x67, 0xfd, 0x64, 0x13, 0xca, 0x61, 0xa0, 0xca, 0xba, 0x6b, 0xfb, 0x72,
+	0x18, 0x10, 0x0b, 0xe6, 0x2f, 0x75, 0x82, 0xd2, 0xa2, 0xea, 0x4c, 0x4c, 0xfa, 0x09, 0x48, 0xb6,
+	0xae, 0x64, 0x46, 0x5d, 0xf8, 0x6f, 0x14, 0x89, 0x71, 0xe4, 0xe7, 0x26, 0x74, 0x4f, 0x81, 0xb9,
+	0x84, 0x9d, 0x9f, 0x15, 0xa8, 0xab, 0xa5, 0xab, 0x9f, 0x4a, 0xf2, 0x16, 0x6a, 0xe9, 0x63, 0x44,
+	0xd6, 0x0d, 0xa2, 0xfc, 0xf3, 0x67, 0x3f, 0xbe, 0x2b, 0xac, 0xeb, 0xa3, 0x33, 0xe4, 0x23, 0xd4,
+	0xb3, 0x97, 0x87, 0x34, 0xcd, 0xde, 0x8b, 0x2f, 0x90, 0xbd, 0x71, 0x0f, 0x22, 0x4b, 0x9b, 0x3d,
+	0xb4, 0x39, 0xda, 0x5b, 0xae, 0x84, 0xbd, 0x71, 0x0f, 0x22, 0xa5, 0xdd, 0x87, 0x7a, 0xf6, 0x68,
+	0xe5, 0x68, 0x6f, 0x39, 0x75, 0xf6, 0x8a, 0x81, 0x38, 0x62, 0x61, 0x40, 0x67, 0xc8, 0x7b, 0x80,
+	0x9b, 0x61, 0x11, 0x53, 0xa4, 0xc2, 0xe9, 0xb2, 0x9f, 0xdc, 0x19, 0x9f, 0xd4, 0xb5, 0xb7, 0xfe,
+	0xe5, 0xa1, 0xc2, 0x74, 0xf4, 0xcf, 0xcd, 0x3f, 0x67, 0xa3, 0xa0, 0x73, 0xca, 0x92, 0xbf, 0xdc,
+	0xd7, 0x39, 0xf5, 0xdd, 0xfd, 0x3d, 0x00, 0x73, 0x18, 0x7b, 0x94, 0x3e, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -524,6 +708,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type StateServiceClient interface {
 	GetStates(ctx context.Context, in *GetStatesRequest, opts ...grpc.CallOption) (*GetStatesResponse, error)
+	SearchStates(ctx context.Context, in *SearchStatesRequest, opts ...grpc.CallOption) (*SearchStatesResponse, error)
 	ReportStates(ctx context.Context, in *ReportStatesRequest, opts ...grpc.CallOption) (*ReportStatesResponse, error)
 	DeleteStates(ctx context.Context, in *DeleteStatesRequest, opts ...grpc.CallOption) (*Void, error)
 	SyncStates(ctx context.Context, in *SyncStatesRequest, opts ...grpc.CallOption) (*SyncStatesResponse, error)
@@ -546,6 +731,15 @@ func (c *stateServiceClient) GetStates(ctx context.Context, in *GetStatesRequest
 	return out, nil
 }
 
+func (c *stateServiceClient) SearchStates(ctx context.Context, in *SearchStatesRequest, opts ...grpc.CallOption) (*SearchStatesResponse, error) {
+	out := new(SearchStatesResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.StateService/SearchStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *stateServiceClient) ReportStates(ctx context.Context, in *ReportStatesRequest, opts ...grpc.CallOption) (*ReportStatesResponse, error) {
 	out := new(ReportStatesResponse)
 	err := c.cc.Invoke(ctx, "/magma.orc8r.StateService/ReportStates", in, out, opts...)
@@ -576,6 +770,7 @@ func (c *stateServiceClient) SyncStates(ctx context.Context, in *SyncStatesReque
 // StateServiceServer is the server API for StateService service.
 type StateServiceServer interface {
 	GetStates(context.Context, *GetStatesRequest) (*GetStatesResponse, error)
+	SearchStates(context.Context, *SearchStatesRequest) (*SearchStatesResponse, error)
 	ReportStates(context.Context, *ReportStatesRequest) (*ReportStatesResponse, error)
 	DeleteStates(context.Context, *DeleteStatesRequest) (*Void, error)
 	SyncStates(context.Context, *SyncStatesRequest) (*SyncStatesResponse, error)
@@ -588,6 +783,9 @@ type UnimplementedStateServiceServer struct {
 func (*UnimplementedStateServiceServer) GetStates(ctx context.Context, req *GetStatesRequest) (*GetStatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStates not implemented")
 }
+func (*UnimplementedStateServiceServer) SearchStates(ctx context.Context, req *SearchStatesRequest) (*SearchStatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchStates not implemented")
+}
 func (*UnimplementedStateServiceServer) ReportStates(ctx context.Context, req *ReportStatesRequest) (*ReportStatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportStates not implemented")
 }
@@ -620,6 +818,24 @@ func _StateService_GetStates_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StateService_SearchStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServiceServer).SearchStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.StateService/SearchStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServiceServer).SearchStates(ctx, req.(*SearchStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StateService_ReportStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReportStatesRequest)
 	if err := dec(in); err != nil {
@@ -682,6 +898,10 @@ var _StateService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStates",
 			Handler:    _StateService_GetStates_Handler,
 		},
+		{
+			MethodName: "SearchStates",
+			Handler:    _StateService_SearchStates_Handler,
+		},
 		{
 			MethodName: "ReportStates",
 			Handler:    _StateService_ReportStates_Handler,
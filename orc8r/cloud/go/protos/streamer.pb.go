@@ -33,14 +33,18 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // between the cloud and the gateway while abstracting the details of how
 // its implemented in the cloud and what the gateway does with the updates.
 //
-// - The gateways call the GetUpdates() streaming API with a StreamRequest
-//   indicating the stream name and the offset to continue streaming from.
-// - The cloud sends a stream of DataUpdateBatch containing a batch of updates.
-// - If resync is true, then the gateway can cleanup all its data and add
-//   all the keys (the batch is guaranteed to contain only unique keys).
-// - If resync is false, then the gateway can update the keys, or add new
-//   ones if the key is not already present.
-// - Key deletions are not yet supported (#15109350)
+//   - The gateways call the GetUpdates() streaming API with a StreamRequest
+//     indicating the stream name and the offset to continue streaming from.
+//   - The cloud sends a stream of DataUpdateBatch containing a batch of updates.
+//   - If resync is true, then the gateway can cleanup all its data and add
+//     all the keys (the batch is guaranteed to contain only unique keys).
+//   - If resync is false, then the gateway can update the keys, or add new
+//     ones if the key is not already present, and remove the deleted keys.
+//   - Streams which support deltas stamp each batch with a version. The
+//     gateway can send the version of the last batch it applied in its next
+//     request to receive only the keys which changed since then. If the cloud
+//     can't compute a delta from that version, it falls back to a resync.
+//
 // --------------------------------------------------------------------------
 type StreamRequest struct {
 	GatewayId string `protobuf:"bytes,1,opt,name=gatewayId,proto3" json:"gatewayId,omitempty"`
@@ -48,7 +52,10 @@ type StreamRequest struct {
 	StreamName string `protobuf:"bytes,2,opt,name=stream_name,json=streamName,proto3" json:"stream_name,omitempty"`
 	// Any extra data to send up with the stream request. This value will be
 	// different per stream provider.
-	ExtraArgs            *any.Any `protobuf:"bytes,3,opt,name=extra_args,json=extraArgs,proto3" json:"extra_args,omitempty"`
+	ExtraArgs *any.Any `protobuf:"bytes,3,opt,name=extra_args,json=extraArgs,proto3" json:"extra_args,omitempty"`
+	// Version of the last batch the gateway applied for this stream, or 0 if
+	// it has none. Ignored by streams which don't support deltas.
+	LastVersion          uint64   `protobuf:"varint,4,opt,name=last_version,json=lastVersion,proto3" json:"last_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -100,6 +107,13 @@ func (m *StreamRequest) GetExtraArgs() *any.Any {
 	return nil
 }
 
+func (m *StreamRequest) GetLastVersion() uint64 {
+	if m != nil {
+		return m.LastVersion
+	}
+	return 0
+}
+
 type DataUpdate struct {
 	// Unique key for each item
 	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -154,7 +168,12 @@ type DataUpdateBatch struct {
 	Updates []*DataUpdate `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
 	// If resync is true, the updates would be a snapshot of all the
 	// contents in the cloud.
-	Resync               bool     `protobuf:"varint,2,opt,name=resync,proto3" json:"resync,omitempty"`
+	Resync bool `protobuf:"varint,2,opt,name=resync,proto3" json:"resync,omitempty"`
+	// Version of the stream contents after this batch is applied. Only set by
+	// streams which support deltas.
+	Version uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Keys which were deleted. Only set if resync is false.
+	DeletedKeys          []string `protobuf:"bytes,4,rep,name=deleted_keys,json=deletedKeys,proto3" json:"deleted_keys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -199,6 +218,20 @@ func (m *DataUpdateBatch) GetResync() bool {
 	return false
 }
 
+func (m *DataUpdateBatch) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DataUpdateBatch) GetDeletedKeys() []string {
+	if m != nil {
+		return m.DeletedKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*StreamRequest)(nil), "magma.orc8r.StreamRequest")
 	proto.RegisterType((*DataUpdate)(nil), "magma.orc8r.DataUpdate")
@@ -208,27 +241,31 @@ func init() {
 func init() { proto.RegisterFile("orc8r/protos/streamer.proto", fileDescriptor_acdce76608ae0d01) }
 
 var fileDescriptor_acdce76608ae0d01 = []byte{
-	// 312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xc1, 0x4f, 0xf2, 0x40,
-	0x10, 0xc5, 0xbf, 0x7e, 0x44, 0x84, 0xa9, 0x46, 0xb3, 0x21, 0x5a, 0x01, 0x23, 0xe9, 0x89, 0xd3,
-	0x56, 0xc1, 0x83, 0x57, 0x88, 0x89, 0xd1, 0x83, 0x87, 0x25, 0x78, 0x30, 0x26, 0x64, 0x28, 0xe3,
-	0x9a, 0x48, 0xbb, 0xb8, 0xbb, 0x55, 0x7b, 0xf6, 0x1f, 0x37, 0xee, 0x96, 0x20, 0x07, 0x4f, 0xed,
-	0x9b, 0xfd, 0xcd, 0xbc, 0xc9, 0x1b, 0xe8, 0x28, 0x9d, 0x5e, 0xe9, 0x64, 0xa5, 0x95, 0x55, 0x26,
-	0x31, 0x56, 0x13, 0x66, 0xa4, 0xb9, 0xd3, 0x2c, 0xcc, 0x50, 0x66, 0xc8, 0x1d, 0xd2, 0x3e, 0x91,
-	0x4a, 0xc9, 0x25, 0x79, 0x74, 0x5e, 0x3c, 0x27, 0x98, 0x97, 0x9e, 0x8b, 0xbf, 0x02, 0xd8, 0x9f,
-	0xb8, 0x56, 0x41, 0x6f, 0x05, 0x19, 0xcb, 0xba, 0xd0, 0x94, 0x68, 0xe9, 0x03, 0xcb, 0xdb, 0x45,
-	0x14, 0xf4, 0x82, 0x7e, 0x53, 0x6c, 0x0a, 0xec, 0x0c, 0x42, 0xef, 0x34, 0xcb, 0x31, 0xa3, 0xe8,
-	0xbf, 0x7b, 0x07, 0x5f, 0xba, 0xc7, 0x8c, 0xd8, 0x10, 0x80, 0x3e, 0xad, 0xc6, 0x19, 0x6a, 0x69,
-	0xa2, 0x5a, 0x2f, 0xe8, 0x87, 0x83, 0x16, 0xf7, 0x0b, 0xf0, 0xf5, 0x02, 0x7c, 0x94, 0x97, 0xa2,
-	0xe9, 0xb8, 0x91, 0x96, 0x26, 0xbe, 0x04, 0xb8, 0x46, 0x8b, 0xd3, 0xd5, 0x02, 0x2d, 0xb1, 0x43,
-	0xa8, 0xbd, 0x52, 0x59, 0x79, 0xff, 0xfc, 0xb2, 0x16, 0xec, 0xbc, 0xe3, 0xb2, 0xf0, 0x7e, 0x7b,
-	0xc2, 0x8b, 0xf8, 0x09, 0x0e, 0x36, 0x5d, 0x63, 0xb4, 0xe9, 0x0b, 0xbb, 0x80, 0xdd, 0xc2, 0x49,
-	0x13, 0x05, 0xbd, 0x5a, 0x3f, 0x1c, 0x1c, 0xf3, 0x5f, 0x41, 0xf0, 0x0d, 0x2e, 0xd6, 0x1c, 0x3b,
-	0x82, 0xba, 0x26, 0x53, 0xe6, 0xa9, 0x1b, 0xde, 0x10, 0x95, 0x1a, 0x3c, 0x40, 0x63, 0x52, 0x65,
-	0xca, 0xee, 0x00, 0x6e, 0xc8, 0x4e, 0xab, 0x8e, 0xf6, 0xd6, 0xcc, 0xad, 0xf4, 0xda, 0xdd, 0x3f,
-	0xfc, 0xdc, 0x7a, 0xf1, 0xbf, 0xf3, 0x60, 0x7c, 0xfa, 0xd8, 0x71, 0x48, 0xe2, 0xcf, 0x97, 0x2e,
-	0x55, 0xb1, 0x48, 0xa4, 0xaa, 0xee, 0x38, 0xaf, 0xbb, 0xef, 0xf0, 0x3b, 0x00, 0x00, 0xff, 0xff,
-	0x75, 0x62, 0xfb, 0xc6, 0xde, 0x01, 0x00, 0x00,
+	// 369 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xc1, 0x8e, 0xd3, 0x30,
+	0x10, 0x86, 0x31, 0x29, 0xbb, 0x9b, 0xc9, 0x22, 0x90, 0xb5, 0x82, 0xd0, 0x5d, 0x44, 0xc8, 0x29,
+	0xa7, 0x04, 0x5a, 0x0e, 0x5c, 0x5b, 0x21, 0x21, 0x40, 0xe2, 0xe0, 0xaa, 0x3d, 0x70, 0x89, 0xa6,
+	0xc9, 0x10, 0x50, 0x93, 0xb8, 0xd8, 0x4e, 0x21, 0x8f, 0xc2, 0x0b, 0xf0, 0x9c, 0xa8, 0x76, 0xa2,
+	0xd2, 0x03, 0xa7, 0xe4, 0xff, 0xf3, 0x3b, 0xf3, 0x8d, 0x67, 0xe0, 0x56, 0xaa, 0xe2, 0xad, 0xca,
+	0xf6, 0x4a, 0x1a, 0xa9, 0x33, 0x6d, 0x14, 0x61, 0x43, 0x2a, 0xb5, 0x9a, 0x07, 0x0d, 0x56, 0x0d,
+	0xa6, 0x36, 0x32, 0x7d, 0x56, 0x49, 0x59, 0xd5, 0xe4, 0xa2, 0xdb, 0xee, 0x6b, 0x86, 0x6d, 0xef,
+	0x72, 0xf1, 0x1f, 0x06, 0x0f, 0x57, 0xf6, 0xa8, 0xa0, 0x1f, 0x1d, 0x69, 0xc3, 0xef, 0xc0, 0xaf,
+	0xd0, 0xd0, 0x4f, 0xec, 0x3f, 0x94, 0x21, 0x8b, 0x58, 0xe2, 0x8b, 0x93, 0xc1, 0x5f, 0x40, 0xe0,
+	0x2a, 0xe5, 0x2d, 0x36, 0x14, 0xde, 0xb7, 0xdf, 0xc1, 0x59, 0x9f, 0xb1, 0x21, 0x3e, 0x07, 0xa0,
+	0x5f, 0x46, 0x61, 0x8e, 0xaa, 0xd2, 0xa1, 0x17, 0xb1, 0x24, 0x98, 0xdd, 0xa4, 0x0e, 0x20, 0x1d,
+	0x01, 0xd2, 0x45, 0xdb, 0x0b, 0xdf, 0xe6, 0x16, 0xaa, 0xd2, 0xfc, 0x25, 0x5c, 0xd7, 0xa8, 0x4d,
+	0x7e, 0x20, 0xa5, 0xbf, 0xcb, 0x36, 0x9c, 0x44, 0x2c, 0x99, 0x88, 0xe0, 0xe8, 0x6d, 0x9c, 0x15,
+	0xbf, 0x01, 0x78, 0x87, 0x06, 0xd7, 0xfb, 0x12, 0x0d, 0xf1, 0xc7, 0xe0, 0xed, 0xa8, 0x1f, 0xf0,
+	0x8e, 0xaf, 0xfc, 0x06, 0x1e, 0x1c, 0xb0, 0xee, 0x1c, 0xd2, 0xb5, 0x70, 0x22, 0xfe, 0xcd, 0xe0,
+	0xd1, 0xe9, 0xd8, 0x12, 0x4d, 0xf1, 0x8d, 0xbf, 0x86, 0xcb, 0xce, 0x4a, 0x1d, 0xb2, 0xc8, 0x4b,
+	0x82, 0xd9, 0xd3, 0xf4, 0x9f, 0xcb, 0x4a, 0x4f, 0x71, 0x31, 0xe6, 0xf8, 0x13, 0xb8, 0x50, 0xa4,
+	0xfb, 0xb6, 0xb0, 0x7f, 0xbf, 0x12, 0x83, 0xe2, 0x21, 0x5c, 0x8e, 0xc8, 0x9e, 0x45, 0x1e, 0xe5,
+	0xb1, 0xa3, 0x92, 0x6a, 0x32, 0x54, 0xe6, 0x3b, 0xea, 0x75, 0x38, 0x89, 0xbc, 0xc4, 0x17, 0xc1,
+	0xe0, 0x7d, 0xa2, 0x5e, 0xcf, 0x36, 0x70, 0xb5, 0x1a, 0x86, 0xc6, 0x3f, 0x02, 0xbc, 0x27, 0xb3,
+	0x1e, 0xca, 0x4d, 0xcf, 0x80, 0xce, 0xc6, 0x33, 0xbd, 0xfb, 0x0f, 0xac, 0xed, 0x2d, 0xbe, 0xf7,
+	0x8a, 0x2d, 0x9f, 0x7f, 0xb9, 0xb5, 0x91, 0xcc, 0xed, 0x47, 0x51, 0xcb, 0xae, 0xcc, 0x2a, 0x39,
+	0x2c, 0xca, 0xf6, 0xc2, 0x3e, 0xe7, 0x7f, 0x07, 0x00, 0xf1, 0x35, 0xf7, 0x70, 0x3f, 0x02, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -12,7 +12,9 @@ package cert
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"strings"
 )
@@ -36,6 +38,34 @@ func LoadCertAndPrivKey(certFile, keyFile string) (
 	return
 }
 
+// LoadCertChain loads all PEM encoded certificates from chainFile and returns
+// them in DER encoding, in the order they appear in the file
+func LoadCertChain(chainFile string) ([][]byte, error) {
+	chainPEM, err := ioutil.ReadFile(chainFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read certificate chain (%s): %s", chainFile, err)
+	}
+	var chain [][]byte
+	for {
+		var block *pem.Block
+		block, chainPEM = pem.Decode(chainPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, fmt.Errorf("Failed to parse certificate chain (%s): %s", chainFile, err)
+		}
+		chain = append(chain, block.Bytes)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("No certificates found in certificate chain (%s)", chainFile)
+	}
+	return chain, nil
+}
+
 // SerialToString converts big.Int to hexadecimal string with uppercace letters
 // (A,B,C,D,E,F), without base prefix ("0x") and without leading zeros
 func SerialToString(certSerialNumber *big.Int) string {
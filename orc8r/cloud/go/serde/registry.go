@@ -95,6 +95,39 @@ func Deserialize(domain string, typeVal string, data []byte) (interface{}, error
 	return subregistry.deserialize(typeVal, data)
 }
 
+// GetSerde returns the Serde registered for the given domain and type. This
+// function is thread-safe.
+func GetSerde(domain string, typeVal string) (Serde, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	subregistry, ok := registry.serdeRegistriesByDomain[domain]
+	if !ok {
+		return nil, fmt.Errorf("No serdes registered for domain %s", domain)
+	}
+	subregistry.RLock()
+	defer subregistry.RUnlock()
+	return subregistry.getSerdeUnsafe(typeVal)
+}
+
+// GetSerdesForDomain returns all Serdes registered for the given domain,
+// sorted by type. This function is thread-safe.
+func GetSerdesForDomain(domain string) []Serde {
+	registry.RLock()
+	defer registry.RUnlock()
+	subregistry, ok := registry.serdeRegistriesByDomain[domain]
+	if !ok {
+		return []Serde{}
+	}
+	subregistry.RLock()
+	defer subregistry.RUnlock()
+	ret := make([]Serde, 0, len(subregistry.serdesByKey))
+	for _, s := range subregistry.serdesByKey {
+		ret = append(ret, s)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].GetType() < ret[j].GetType() })
+	return ret
+}
+
 func getSerdesByDomain(serdesToGroup []Serde) map[string][]Serde {
 	ret := map[string][]Serde{}
 	for _, s := range serdesToGroup {
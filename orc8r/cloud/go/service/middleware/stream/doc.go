@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package stream provides some default streaming RPC interceptors and a
+// wrapper around GRPC's stream interceptors called Interceptor. This package
+// maintains a registry of interceptors to run on streaming RPC requests, it
+// mirrors the unary package's registry.
+package stream
@@ -0,0 +1,152 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package stream
+
+import (
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	uncaughtCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_stream_handler_panic",
+			Help: "There was a panic in a streaming RPC handler",
+		},
+		[]string{"fullMethod"},
+	)
+	streamCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_stream_count",
+			Help: "Number of started streaming RPCs",
+		},
+		[]string{"fullMethod"},
+	)
+	streamErrorCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_stream_errors",
+			Help: "Number of streaming RPCs completed with an error, by GRPC code",
+		},
+		[]string{"fullMethod", "code"},
+	)
+	activeStreamsGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "grpc_active_streams",
+			Help: "Number of currently active streaming RPCs",
+		},
+		[]string{"fullMethod"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(uncaughtCounterVec, streamCounterVec, streamErrorCounterVec, activeStreamsGaugeVec)
+}
+
+// registry is a list of all stream interceptors, they are called in the
+// registration order
+var registry = []Interceptor{
+	{
+		Handler:     SetIdentityFromContext,
+		Name:        "Stream Identity Decorator",
+		Description: "Identity Decorator injects protos.Identity instance into stream context",
+	},
+	{
+		Handler:     BlockUnregisteredGateways,
+		Name:        "BlockUnregisteredGateways",
+		Description: "interceptor which blocks unregistered gateways from establishing streams",
+	},
+}
+
+// InterceptorHandler is a function type to intercept the execution of a
+// streaming RPC call. It is called before the stream's handler with the
+// stream's context.
+//
+// If newCtx is not nil, it replaces the stream's context for the subsequent
+// interceptors & the handler.
+// If err is not nil, the stream is terminated with the error and
+// neither subsequent interceptors nor the handler are called.
+type InterceptorHandler func(ctx context.Context, info *grpc.StreamServerInfo) (newCtx context.Context, err error)
+
+// Interceptor wraps an InterceptorHandler with a name and description
+type Interceptor struct {
+	Handler InterceptorHandler
+
+	// Name of the interceptor
+	Name string
+
+	// Description of the interceptor's functionality
+	Description string
+}
+
+// stream.MiddlewareHandler iterates through and calls all registered stream
+// interceptors and then the stream's handler with the stream's context as
+// decorated by the interceptors.
+// MiddlewareHandler implements grpc.StreamServerInterceptor
+func MiddlewareHandler(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	streamCounterVec.WithLabelValues(info.FullMethod).Inc()
+	defer func() {
+		if err != nil {
+			streamErrorCounterVec.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		}
+	}()
+
+	ctx := ss.Context()
+	for _, streamInterceptor := range registry {
+		newCtx, err := streamInterceptor.Handler(ctx, info)
+		if err != nil {
+			glog.Errorf("Error %s from stream interceptor %s ", err, streamInterceptor.Name)
+			return err
+		}
+		if newCtx != nil {
+			ctx = newCtx
+		}
+	}
+	if ctx != ss.Context() {
+		ss = &wrappedServerStream{ServerStream: ss, ctx: ctx}
+	}
+	return callHandler(srv, ss, info, handler)
+}
+
+func callHandler(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	activeStreams := activeStreamsGaugeVec.WithLabelValues(info.FullMethod)
+	activeStreams.Inc()
+	defer func() {
+		activeStreams.Dec()
+		if r := recover(); r != nil {
+			err = status.Errorf(codes.Unknown, "Handler Panic: %s", r)
+			uncaughtCounterVec.WithLabelValues(info.FullMethod).Inc()
+		}
+	}()
+
+	err = handler(srv, ss)
+	if err != nil {
+		glog.Errorf("[ERROR %s]: %s", info.FullMethod, err)
+	}
+	return
+}
+
+// wrappedServerStream is a grpc.ServerStream with the context replaced by
+// the interceptors' decorated one
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedServerStream) Context() context.Context {
+	return s.ctx
+}
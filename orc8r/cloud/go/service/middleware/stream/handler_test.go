@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testCtxKey struct{}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func createFakeServerInfo(method string) *grpc.StreamServerInfo {
+	return &grpc.StreamServerInfo{FullMethod: method}
+}
+
+// setTestRegistry replaces the interceptor registry & returns a function
+// restoring the original one
+func setTestRegistry(interceptors ...Interceptor) func() {
+	oldRegistry := registry
+	registry = interceptors
+	return func() { registry = oldRegistry }
+}
+
+func TestMiddlewareHandlerDecoratesContext(t *testing.T) {
+	defer setTestRegistry(
+		Interceptor{
+			Name: "decorator",
+			Handler: func(ctx context.Context, info *grpc.StreamServerInfo) (context.Context, error) {
+				return context.WithValue(ctx, testCtxKey{}, info.FullMethod), nil
+			},
+		},
+		Interceptor{
+			Name: "noop",
+			Handler: func(ctx context.Context, info *grpc.StreamServerInfo) (context.Context, error) {
+				return nil, nil
+			},
+		},
+	)()
+	var handlerCtxValue interface{}
+	err := MiddlewareHandler(
+		nil,
+		&mockServerStream{ctx: context.Background()},
+		createFakeServerInfo("decorated method"),
+		func(srv interface{}, ss grpc.ServerStream) error {
+			handlerCtxValue = ss.Context().Value(testCtxKey{})
+			return nil
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "decorated method", handlerCtxValue)
+	assert.EqualValues(t, 1, testutil.ToFloat64(streamCounterVec.WithLabelValues("decorated method")))
+	assert.EqualValues(t, 0, testutil.ToFloat64(activeStreamsGaugeVec.WithLabelValues("decorated method")))
+}
+
+func TestMiddlewareHandlerInterceptorError(t *testing.T) {
+	defer setTestRegistry(
+		Interceptor{
+			Name: "blocker",
+			Handler: func(ctx context.Context, info *grpc.StreamServerInfo) (context.Context, error) {
+				return nil, status.Error(codes.PermissionDenied, "blocked")
+			},
+		},
+	)()
+	handlerCalled := false
+	err := MiddlewareHandler(
+		nil,
+		&mockServerStream{ctx: context.Background()},
+		createFakeServerInfo("blocked method"),
+		func(srv interface{}, ss grpc.ServerStream) error {
+			handlerCalled = true
+			return nil
+		},
+	)
+	assert.EqualError(t, err, "rpc error: code = PermissionDenied desc = blocked")
+	assert.False(t, handlerCalled)
+	assert.EqualValues(t, 1, testutil.ToFloat64(
+		streamErrorCounterVec.WithLabelValues("blocked method", codes.PermissionDenied.String())))
+}
+
+func TestCallHandlerSimpleError(t *testing.T) {
+	err := callHandler(
+		nil,
+		&mockServerStream{ctx: context.Background()},
+		createFakeServerInfo("error method"),
+		func(srv interface{}, ss grpc.ServerStream) error {
+			return errors.New("some error")
+		},
+	)
+	assert.EqualError(t, err, "some error")
+}
+
+func TestCallHandlerPanics(t *testing.T) {
+	err := callHandler(
+		nil,
+		&mockServerStream{ctx: context.Background()},
+		createFakeServerInfo("panic method"),
+		func(srv interface{}, ss grpc.ServerStream) error {
+			panic("failed")
+		},
+	)
+	assert.EqualError(t, err, "rpc error: code = Unknown desc = Handler Panic: failed")
+	assert.EqualValues(t, 1, testutil.ToFloat64(uncaughtCounterVec))
+	assert.EqualValues(t, 0, testutil.ToFloat64(activeStreamsGaugeVec.WithLabelValues("panic method")))
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package stream
+
+import (
+	"magma/orc8r/cloud/go/service/middleware/unary"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// SetIdentityFromContext is the stream counterpart of
+// unary.SetIdentityFromContext: it finds the caller's Identity by the client
+// certificate serial number from the stream's metadata & injects the
+// 'decorated' Identity into the stream's context.
+// Streams without client certificate metadata are only allowed from local
+// clients, the unary identity decorator bypass list applies to streams as well
+func SetIdentityFromContext(ctx context.Context, info *grpc.StreamServerInfo) (newCtx context.Context, err error) {
+	newCtx, _, _, err = unary.SetIdentityFromContext(ctx, nil, toUnaryServerInfo(info))
+	return
+}
+
+// toUnaryServerInfo returns unary server info of the stream's RPC method, so
+// unary interceptors can be reused for streams
+func toUnaryServerInfo(info *grpc.StreamServerInfo) *grpc.UnaryServerInfo {
+	if info == nil {
+		return nil
+	}
+	return &grpc.UnaryServerInfo{FullMethod: info.FullMethod}
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package stream_test
+
+import (
+	"net"
+	"testing"
+
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/service/middleware/stream"
+	"magma/orc8r/cloud/go/service/middleware/unary"
+	"magma/orc8r/cloud/go/service/middleware/unary/test_utils"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	configuratorTestUtils "magma/orc8r/cloud/go/services/configurator/test_utils"
+	"magma/orc8r/cloud/go/services/device"
+	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const testAgHwID = "Test-AGW-Hw-Id"
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamIdentityDecorator(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	_ = serde.RegisterSerdes(
+		serde.NewBinarySerde(device.SerdeDomain, orc8r.AccessGatewayRecordType, &models.GatewayDevice{}),
+	)
+	networkID := "stream_identity_decorator_test_network"
+	configuratorTestUtils.RegisterNetwork(t, networkID, "Stream Identity Decorator Test")
+	configuratorTestUtils.RegisterGateway(t, networkID, testAgHwID, &models.GatewayDevice{HardwareID: testAgHwID})
+	csn := test_utils.StartMockGwAccessControl(t, []string{testAgHwID})
+
+	info := &grpc.StreamServerInfo{FullMethod: "/magma.orc8r.Streamer/GetUpdates", IsServerStream: true}
+	var lastClientIdentity *protos.Identity
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		lastClientIdentity = protos.GetClientIdentity(ss.Context())
+		return nil
+	}
+	serve := func(ctx context.Context) error {
+		lastClientIdentity = nil
+		return stream.MiddlewareHandler(nil, &mockServerStream{ctx: ctx}, info, handler)
+	}
+
+	// Gateway stream with a valid certificate
+	gwCtx := metadata.NewIncomingContext(
+		context.Background(), metadata.Pairs(unary.CLIENT_CERT_SN_KEY, csn[0]))
+	assert.NoError(t, serve(gwCtx))
+	gwid := lastClientIdentity.GetGateway()
+	if assert.NotNil(t, gwid) {
+		assert.Equal(t, testAgHwID, gwid.HardwareId)
+		assert.Equal(t, networkID, gwid.NetworkId)
+		assert.Equal(t, testAgHwID, gwid.LogicalId)
+	}
+
+	// Local client without certificate headers
+	localCtx := peer.NewContext(
+		metadata.NewIncomingContext(context.Background(), metadata.MD{}),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1234}})
+	assert.NoError(t, serve(localCtx))
+	assert.Nil(t, lastClientIdentity)
+
+	// Remote client without certificate headers
+	remoteCtx := peer.NewContext(
+		metadata.NewIncomingContext(context.Background(), metadata.MD{}),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	err := serve(remoteCtx)
+	assert.EqualError(t, err, "rpc error: code = PermissionDenied desc = Missing Client Certificate from Client 10.0.0.1")
+	assert.Nil(t, lastClientIdentity)
+
+	// Certificate CN without SN
+	err = serve(metadata.NewIncomingContext(
+		context.Background(), metadata.Pairs("x-magma-client-cert-cn", "bla bla bla")))
+	assert.Error(t, err)
+	assert.Nil(t, lastClientIdentity)
+
+	// Unregistered Gateway is blocked
+	assert.NoError(t, configurator.DeleteEntity(networkID, orc8r.MagmadGatewayType, testAgHwID))
+	err = serve(gwCtx)
+	assert.EqualError(t, err, "rpc error: code = PermissionDenied desc = Unregistered Gateway Test-AGW-Hw-Id")
+	assert.Nil(t, lastClientIdentity)
+}
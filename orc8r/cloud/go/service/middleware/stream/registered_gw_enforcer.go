@@ -0,0 +1,25 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package stream
+
+import (
+	"magma/orc8r/cloud/go/service/middleware/unary"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// BlockUnregisteredGateways is an Interceptor blocking streams from Gateways
+// which were not registered on the cloud.
+// BlockUnregisteredGateways must be invoked after Identity Decorator since
+// it relies on the Identity Decorator's results
+func BlockUnregisteredGateways(ctx context.Context, info *grpc.StreamServerInfo) (newCtx context.Context, err error) {
+	newCtx, _, _, err = unary.BlockUnregisteredGateways(ctx, nil, toUnaryServerInfo(info))
+	return
+}
@@ -68,6 +68,11 @@ func (srv *testStateServer) SyncStates(ctx context.Context, req *protos.SyncStat
 	return &protos.SyncStatesResponse{UnsyncedStates: []*protos.IDAndVersion{}}, nil
 }
 
+func (srv *testStateServer) SearchStates(ctx context.Context, req *protos.SearchStatesRequest) (*protos.SearchStatesResponse, error) {
+	srv.lastClientIdentity = proto.Clone(protos.GetClientIdentity(ctx)).(*protos.Identity)
+	return &protos.SearchStatesResponse{}, nil
+}
+
 func TestIdentityInjector(t *testing.T) {
 	configuratorTestInit.StartTestService(t)
 	deviceTestInit.StartTestService(t)
@@ -21,6 +21,7 @@ import (
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/registry"
 	"magma/orc8r/cloud/go/service/config"
+	"magma/orc8r/cloud/go/service/middleware/stream"
 	"magma/orc8r/cloud/go/service/middleware/unary"
 	"magma/orc8r/cloud/go/util"
 
@@ -80,20 +81,22 @@ type Service struct {
 }
 
 // NewOrchestratorService returns a new GRPC orchestrator service
-// implementing service303. This service will implement a middleware
-// interceptor to perform identity check. If your service does not or can not
-// perform identity checks, (e.g. federation), use NewServiceWithOptions.
+// implementing service303. This service will implement unary & stream
+// middleware interceptors to perform identity check. If your service does not
+// or can not perform identity checks, (e.g. federation), use
+// NewServiceWithOptions.
 func NewOrchestratorService(moduleName string, serviceName string) (*Service, error) {
 	plugin.LoadAllPluginsFatalOnError(&plugin.DefaultOrchestratorPluginLoader{})
-	return NewServiceWithOptions(moduleName, serviceName, grpc.UnaryInterceptor(unary.MiddlewareHandler))
+	return NewServiceWithOptions(moduleName, serviceName, grpc.UnaryInterceptor(unary.MiddlewareHandler), grpc.StreamInterceptor(stream.MiddlewareHandler))
 }
 
 // NewOrchestratorServiceWithOptions returns a new GRPC orchestrator service
 // implementing service303 with the specified grpc server options. This service
-// will implement a middleware interceptor to perform identity check.
+// will implement unary & stream middleware interceptors to perform identity
+// check, so serverOptions must not set their own interceptors.
 func NewOrchestratorServiceWithOptions(moduleName string, serviceName string, serverOptions ...grpc.ServerOption) (*Service, error) {
 	plugin.LoadAllPluginsFatalOnError(&plugin.DefaultOrchestratorPluginLoader{})
-	serverOptions = append(serverOptions, grpc.UnaryInterceptor(unary.MiddlewareHandler))
+	serverOptions = append(serverOptions, grpc.UnaryInterceptor(unary.MiddlewareHandler), grpc.StreamInterceptor(stream.MiddlewareHandler))
 	return NewServiceWithOptions(moduleName, serviceName, serverOptions...)
 }
 
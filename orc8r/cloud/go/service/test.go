@@ -11,6 +11,7 @@ package service
 import (
 	"testing"
 
+	"magma/orc8r/cloud/go/service/middleware/stream"
 	"magma/orc8r/cloud/go/service/middleware/unary"
 
 	"google.golang.org/grpc"
@@ -23,5 +24,5 @@ func NewTestOrchestratorService(t *testing.T, moduleName string, serviceType str
 	if t == nil {
 		panic("Nice try, but *testing.T must be non-nil. NewTestOrchestratorService can only be used in a test context.")
 	}
-	return NewServiceWithOptions(moduleName, serviceType, grpc.UnaryInterceptor(unary.MiddlewareHandler))
+	return NewServiceWithOptions(moduleName, serviceType, grpc.UnaryInterceptor(unary.MiddlewareHandler), grpc.StreamInterceptor(stream.MiddlewareHandler))
 }
@@ -11,7 +11,9 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"flag"
 	"log"
+	"time"
 
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
@@ -20,6 +22,13 @@ import (
 	"magma/orc8r/cloud/go/services/accessd/protos"
 	"magma/orc8r/cloud/go/services/accessd/servicers"
 	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/golang/glog"
+)
+
+var (
+	auditRetentionDays = flag.Int64("audit-retention-days", 90, "Audit log records retention time (in days)")
+	auditGCHours       = flag.Int64("audit-gc-hours", 1, "Audit log garbage collection time interval (in hours)")
 )
 
 func main() {
@@ -39,6 +48,26 @@ func main() {
 	// Add servicers to the service
 	accessdServer := servicers.NewAccessdServer(ds)
 	protos.RegisterAccessControlManagerServer(srv.GrpcServer, accessdServer)
+	db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %s", err)
+	}
+	auditServer := servicers.NewAuditLogServer(db, sqorc.GetSqlBuilder())
+	if err = auditServer.Initialize(); err != nil {
+		log.Fatalf("Error initializing audit log database: %s", err)
+	}
+	protos.RegisterAuditLogServer(srv.GrpcServer, auditServer)
+
+	// Start Audit Log Garbage Collector Ticker
+	servicers.AuditRetention = time.Hour * 24 * time.Duration(*auditRetentionDays)
+	gc := time.Tick(time.Hour * time.Duration(*auditGCHours))
+	go func() {
+		for range gc {
+			if err := auditServer.CollectGarbage(); err != nil {
+				glog.Errorf("error collecting garbage for audit log: %s", err)
+			}
+		}
+	}()
 
 	// Run the service
 	err = srv.Run()
@@ -10,14 +10,21 @@ package accessd_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
 
+	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/accessd"
 	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	"magma/orc8r/cloud/go/services/accessd/servicers"
 	accessd_test_service "magma/orc8r/cloud/go/services/accessd/test_init"
+	"magma/orc8r/cloud/go/sqorc"
 )
 
 func TestAccessManager(t *testing.T) {
@@ -173,3 +180,165 @@ func TestAccessManager(t *testing.T) {
 		assert.Equal(t, "Id_Operator_operator2", opers[0].HashString())
 	}
 }
+
+func TestRoles(t *testing.T) {
+	accessd_test_service.StartTestService(t)
+
+	roles, err := accessd.ListRoles()
+	assert.NoError(t, err)
+	assert.Len(t, roles, 3)
+	assert.Equal(t, "network-admin", roles[0].Name)
+	assert.Equal(t, "read-only", roles[1].Name)
+	assert.Equal(t, "subscriber-manager", roles[2].Name)
+
+	_, err = accessd.GetRole("gateway-manager")
+	assert.Error(t, err)
+	assert.Error(t, accessd.SetRole(&accessprotos.Role{}))
+	gwManager := &accessprotos.Role{
+		Name: "gateway-manager",
+		Rules: []*accessprotos.Role_Rule{
+			{
+				PathPatterns: []string{"/magma/v1/networks/:network_id/gateways*"},
+				Permissions:  accessprotos.AccessControl_READ | accessprotos.AccessControl_WRITE,
+			},
+		},
+	}
+	assert.NoError(t, accessd.SetRole(gwManager))
+	role, err := accessd.GetRole("gateway-manager")
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(gwManager, role))
+	roles, err = accessd.ListRoles()
+	assert.NoError(t, err)
+	assert.Len(t, roles, 4)
+
+	op := identity.NewOperator("role_operator")
+	assert.Error(t, accessd.AddRoleBinding(op, "no-such-role", ""))
+	assert.NoError(t, accessd.AddRoleBinding(op, "gateway-manager", "network1"))
+	assert.NoError(t, accessd.AddRoleBinding(op, "gateway-manager", "network1"))
+	assert.NoError(t, accessd.AddRoleBinding(op, "read-only", ""))
+	bindings, err := accessd.GetRoleBindings(op)
+	assert.NoError(t, err)
+	assert.Len(t, bindings, 2)
+
+	gwPath := "/magma/v1/networks/:network_id/gateways/:gateway_id"
+	rw := accessprotos.AccessControl_READ | accessprotos.AccessControl_WRITE
+	assert.NoError(t, accessd.CheckRoleAccess(op, "network1", gwPath, "gateways", rw))
+	assert.Error(t, accessd.CheckRoleAccess(op, "network2", gwPath, "gateways", accessprotos.AccessControl_WRITE))
+	assert.NoError(t, accessd.CheckRoleAccess(op, "network2", gwPath, "gateways", accessprotos.AccessControl_READ))
+	assert.NoError(t, accessd.CheckRoleAccess(op, "", "/magma/v1/networks", "networks", accessprotos.AccessControl_READ))
+	assert.Error(t, accessd.CheckRoleAccess(op, "", "/magma/v1/networks", "networks", accessprotos.AccessControl_WRITE))
+
+	// bound role cannot be deleted
+	assert.Error(t, accessd.DeleteRole("gateway-manager"))
+	assert.NoError(t, accessd.RemoveRoleBinding(op, "gateway-manager", "network1"))
+	assert.Error(t, accessd.RemoveRoleBinding(op, "gateway-manager", "network1"))
+	assert.Error(t, accessd.CheckRoleAccess(op, "network1", gwPath, "gateways", accessprotos.AccessControl_WRITE))
+	assert.NoError(t, accessd.DeleteRole("gateway-manager"))
+	_, err = accessd.GetRole("gateway-manager")
+	assert.Error(t, err)
+
+	// overwritten built-in role is restored on delete
+	assert.Error(t, accessd.DeleteRole("read-only"))
+	assert.NoError(t, accessd.SetRole(&accessprotos.Role{
+		Name:  "read-only",
+		Rules: []*accessprotos.Role_Rule{{EntityTypes: []string{"gateways"}, Permissions: accessprotos.AccessControl_READ}},
+	}))
+	role, err = accessd.GetRole("read-only")
+	assert.NoError(t, err)
+	assert.True(t, role.Builtin)
+	assert.Error(t, accessd.CheckRoleAccess(op, "", "/magma/v1/networks", "networks", accessprotos.AccessControl_READ))
+	assert.NoError(t, accessd.DeleteRole("read-only"))
+	assert.NoError(t, accessd.CheckRoleAccess(op, "", "/magma/v1/networks", "networks", accessprotos.AccessControl_READ))
+
+	assert.NoError(t, accessd.DeleteOperator(op))
+	bindings, err = accessd.GetRoleBindings(op)
+	assert.NoError(t, err)
+	assert.Empty(t, bindings)
+	assert.Error(t, accessd.CheckRoleAccess(op, "", "/magma/v1/networks", "networks", accessprotos.AccessControl_READ))
+}
+
+func TestAuditLog(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1500000000, 0))
+	defer clock.UnfreezeClock(t)
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	srv := servicers.NewAuditLogServer(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, srv.Initialize())
+	// initialization is idempotent
+	assert.NoError(t, srv.Initialize())
+	ctx := context.Background()
+
+	op := identity.NewOperator("operator1")
+	record := func(networkID string, ts time.Time) {
+		tsProto, err := ptypes.TimestampProto(ts)
+		assert.NoError(t, err)
+		_, err = srv.Record(ctx, &accessprotos.AuditRecord{Operator: op, Method: "POST", NetworkId: networkID, Timestamp: tsProto})
+		assert.NoError(t, err)
+	}
+	now := clock.Now()
+	old := now.Add(-servicers.AuditRetention - time.Hour)
+	record("network1", old)
+	record("network1", now)
+	record("network2", old)
+	record("", old)
+	// records without timestamp are stamped with the current time
+	_, err = srv.Record(ctx, &accessprotos.AuditRecord{Operator: op, Method: "PUT"})
+	assert.NoError(t, err)
+
+	// network IDs are query parameters, not table names
+	record("n1; DROP TABLE access_audit_log", now)
+
+	res, err := srv.Query(ctx, &accessprotos.AuditQuery{NetworkId: "network1"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Records, 2)
+	// limited queries return the most recent records, oldest first
+	record("network1", now.Add(time.Second))
+	res, err = srv.Query(ctx, &accessprotos.AuditQuery{NetworkId: "network1", Limit: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Records, 2) {
+		ts, _ := ptypes.Timestamp(res.Records[0].Timestamp)
+		assert.Equal(t, now.Unix(), ts.Unix())
+		ts, _ = ptypes.Timestamp(res.Records[1].Timestamp)
+		assert.Equal(t, now.Add(time.Second).Unix(), ts.Unix())
+	}
+	res, err = srv.Query(ctx, &accessprotos.AuditQuery{NetworkId: "n1; DROP TABLE access_audit_log"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Records, 1)
+	res, err = srv.Query(ctx, &accessprotos.AuditQuery{})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Records, 2) {
+		assert.Equal(t, "PUT", res.Records[1].Method)
+		ts, _ := ptypes.Timestamp(res.Records[1].Timestamp)
+		assert.Equal(t, now.Unix(), ts.Unix())
+	}
+
+	assert.NoError(t, srv.CollectGarbage())
+	for _, networkID := range []string{"network1", "network2", ""} {
+		res, err = srv.Query(ctx, &accessprotos.AuditQuery{NetworkId: networkID})
+		assert.NoError(t, err)
+		for _, r := range res.Records {
+			ts, _ := ptypes.Timestamp(r.Timestamp)
+			assert.True(t, ts.Unix() >= now.Unix())
+		}
+	}
+	res, err = srv.Query(ctx, &accessprotos.AuditQuery{NetworkId: "network2"})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Records)
+
+	// queries are limited by default and limits are capped
+	defaultLimit, maxLimit := servicers.DefaultAuditQueryLimit, servicers.MaxAuditQueryLimit
+	defer func() { servicers.DefaultAuditQueryLimit, servicers.MaxAuditQueryLimit = defaultLimit, maxLimit }()
+	servicers.DefaultAuditQueryLimit, servicers.MaxAuditQueryLimit = 2, 3
+	for i := 0; i < 4; i++ {
+		record("network3", now.Add(time.Duration(i)*time.Second))
+	}
+	res, err = srv.Query(ctx, &accessprotos.AuditQuery{NetworkId: "network3"})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Records, 2) {
+		ts, _ := ptypes.Timestamp(res.Records[1].Timestamp)
+		assert.Equal(t, now.Add(3*time.Second).Unix(), ts.Unix())
+	}
+	res, err = srv.Query(ctx, &accessprotos.AuditQuery{NetworkId: "network3", Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, res.Records, 3)
+}
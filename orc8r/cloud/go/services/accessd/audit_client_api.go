@@ -0,0 +1,83 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package accessd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/registry"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/net/context"
+)
+
+// getAuditLogClient is a utility function to get a RPC connection to the
+// accessd service's audit log
+func getAuditLogClient() (accessprotos.AuditLogClient, error) {
+	conn, err := registry.GetConnection(ServiceName)
+	if err != nil {
+		initErr := merrors.NewInitError(err, ServiceName)
+		glog.Error(initErr)
+		return nil, initErr
+	}
+	return accessprotos.NewAuditLogClient(conn), err
+}
+
+// RecordAudit adds the record to the audit log of its network
+func RecordAudit(record *accessprotos.AuditRecord) error {
+	client, err := getAuditLogClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.Record(context.Background(), record)
+	if err != nil {
+		errMsg := fmt.Sprintf("Record Audit for %s %s error: %s", record.GetMethod(), record.GetPath(), err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// QueryAuditLog returns the network's audit records logged within
+// [start, end), oldest first. Zero start or end leaves the range open on
+// that side. Only the limit most recent records are returned, the service
+// applies its default limit if limit is 0 and caps larger limits.
+func QueryAuditLog(networkID string, start, end time.Time, limit uint32) ([]*accessprotos.AuditRecord, error) {
+	client, err := getAuditLogClient()
+	if err != nil {
+		return nil, err
+	}
+	query := &accessprotos.AuditQuery{NetworkId: networkID, Limit: limit}
+	if query.Start, err = toTimestampProto(start); err != nil {
+		return nil, err
+	}
+	if query.End, err = toTimestampProto(end); err != nil {
+		return nil, err
+	}
+	resp, err := client.Query(context.Background(), query)
+	if err != nil {
+		errMsg := fmt.Sprintf("Query Audit Log for network %s error: %s", networkID, err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return resp.Records, nil
+}
+
+func toTimestampProto(t time.Time) (*timestamp.Timestamp, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return ptypes.TimestampProto(t)
+}
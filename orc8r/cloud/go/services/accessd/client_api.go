@@ -184,3 +184,136 @@ func ListOperators() ([]*protos.Identity, error) {
 	}
 	return opslist.List, nil
 }
+
+// SetRole creates or overwrites a Role
+func SetRole(role *accessprotos.Role) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.SetRole(context.Background(), role)
+	if err != nil {
+		errMsg := fmt.Sprintf("Set Role %s error: %s", role.GetName(), err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// GetRole returns the Role with the given name
+func GetRole(name string) (*accessprotos.Role, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	role, err := client.GetRole(context.Background(), &accessprotos.RoleName{Name: name})
+	if err != nil {
+		errMsg := fmt.Sprintf("Get Role %s error: %s", name, err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return role, nil
+}
+
+// ListRoles returns all Roles, including built-in roles
+func ListRoles() ([]*accessprotos.Role, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	roles, err := client.ListRoles(context.Background(), &protos.Void{})
+	if err != nil || roles == nil {
+		return nil, err
+	}
+	return roles.Roles, nil
+}
+
+// DeleteRole deletes the Role with the given name
+func DeleteRole(name string) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteRole(context.Background(), &accessprotos.RoleName{Name: name})
+	if err != nil {
+		errMsg := fmt.Sprintf("Delete Role %s error: %s", name, err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// AddRoleBinding binds the Role to operator in the given network, an empty
+// networkID binds the role in all networks
+func AddRoleBinding(operator *protos.Identity, role, networkID string) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.AddRoleBinding(
+		context.Background(),
+		&accessprotos.RoleBinding{Operator: operator, Role: role, NetworkId: networkID})
+	if err != nil {
+		errMsg := fmt.Sprintf("Bind Role %s to Operator %s error: %s", role, operator.HashString(), err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// RemoveRoleBinding removes the operator's binding of the Role in the given
+// network
+func RemoveRoleBinding(operator *protos.Identity, role, networkID string) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.RemoveRoleBinding(
+		context.Background(),
+		&accessprotos.RoleBinding{Operator: operator, Role: role, NetworkId: networkID})
+	if err != nil {
+		errMsg := fmt.Sprintf("Unbind Role %s from Operator %s error: %s", role, operator.HashString(), err)
+		glog.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// GetRoleBindings returns all Role bindings of the operator
+func GetRoleBindings(operator *protos.Identity) ([]*accessprotos.RoleBinding, error) {
+	client, err := getAccessdClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetRoleBindings(context.Background(), operator)
+	if err != nil {
+		errMsg := fmt.Sprintf("Get Role Bindings for Operator %s error: %s", operator.HashString(), err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return resp.Bindings, nil
+}
+
+// CheckRoleAccess verifies that the operator's roles grant perm for the REST
+// route & entity type in the given network and returns error if either
+// request fails or the permissions are not granted
+func CheckRoleAccess(
+	operator *protos.Identity,
+	networkID, path, entityType string,
+	perm accessprotos.AccessControl_Permission,
+) error {
+	client, err := getAccessdClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.CheckRoleAccess(
+		context.Background(),
+		&accessprotos.RoleAccessRequest{
+			Operator:    operator,
+			NetworkId:   networkID,
+			Path:        path,
+			EntityType:  entityType,
+			Permissions: perm,
+		})
+	return err
+}
@@ -321,6 +321,387 @@ func (m *AccessControl_Lists) GetAcls() []*AccessControl_List {
 	return nil
 }
 
+// Role Based Access Control Definitions:
+//
+//	A Role is a named set of rules, each rule grants permissions on REST
+//	requests matching its path patterns AND entity types. Empty path patterns
+//	or entity types match any request.
+//
+//	Path patterns are matched against the REST route of a request
+//	(e.g. /magma/v1/lte/:network_id/subscribers/:subscriber_id), '*' matches
+//	any sequence of characters. Entity type of a request is the REST resource
+//	it addresses: the first static route segment following :network_id or,
+//	if there is none, the last static segment of the route
+//	(e.g. subscribers, gateways, networks).
+//
+//	Operators are granted roles via Role Bindings, a binding is scoped to a
+//	single network or, if network_id is empty, to all networks.
+//	Role permissions are evaluated in addition to the operator's ACL.
+type Role struct {
+	Name  string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rules []*Role_Rule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	// built-in roles are always provided by accessd, deleting an overwritten
+	// built-in role restores its default rules
+	Builtin              bool     `protobuf:"varint,3,opt,name=builtin,proto3" json:"builtin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Role) Reset()         { *m = Role{} }
+func (m *Role) String() string { return proto.CompactTextString(m) }
+func (*Role) ProtoMessage()    {}
+func (*Role) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{1}
+}
+
+func (m *Role) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Role.Unmarshal(m, b)
+}
+func (m *Role) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Role.Marshal(b, m, deterministic)
+}
+func (m *Role) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Role.Merge(m, src)
+}
+func (m *Role) XXX_Size() int {
+	return xxx_messageInfo_Role.Size(m)
+}
+func (m *Role) XXX_DiscardUnknown() {
+	xxx_messageInfo_Role.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Role proto.InternalMessageInfo
+
+func (m *Role) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Role) GetRules() []*Role_Rule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *Role) GetBuiltin() bool {
+	if m != nil {
+		return m.Builtin
+	}
+	return false
+}
+
+type Role_Rule struct {
+	PathPatterns         []string                 `protobuf:"bytes,1,rep,name=path_patterns,json=pathPatterns,proto3" json:"path_patterns,omitempty"`
+	EntityTypes          []string                 `protobuf:"bytes,2,rep,name=entity_types,json=entityTypes,proto3" json:"entity_types,omitempty"`
+	Permissions          AccessControl_Permission `protobuf:"varint,3,opt,name=permissions,proto3,enum=magma.orc8r.accessd.AccessControl_Permission" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *Role_Rule) Reset()         { *m = Role_Rule{} }
+func (m *Role_Rule) String() string { return proto.CompactTextString(m) }
+func (*Role_Rule) ProtoMessage()    {}
+func (*Role_Rule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{1, 0}
+}
+
+func (m *Role_Rule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Role_Rule.Unmarshal(m, b)
+}
+func (m *Role_Rule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Role_Rule.Marshal(b, m, deterministic)
+}
+func (m *Role_Rule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Role_Rule.Merge(m, src)
+}
+func (m *Role_Rule) XXX_Size() int {
+	return xxx_messageInfo_Role_Rule.Size(m)
+}
+func (m *Role_Rule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Role_Rule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Role_Rule proto.InternalMessageInfo
+
+func (m *Role_Rule) GetPathPatterns() []string {
+	if m != nil {
+		return m.PathPatterns
+	}
+	return nil
+}
+
+func (m *Role_Rule) GetEntityTypes() []string {
+	if m != nil {
+		return m.EntityTypes
+	}
+	return nil
+}
+
+func (m *Role_Rule) GetPermissions() AccessControl_Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return AccessControl_NONE
+}
+
+type Roles struct {
+	Roles                []*Role  `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Roles) Reset()         { *m = Roles{} }
+func (m *Roles) String() string { return proto.CompactTextString(m) }
+func (*Roles) ProtoMessage()    {}
+func (*Roles) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{2}
+}
+
+func (m *Roles) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Roles.Unmarshal(m, b)
+}
+func (m *Roles) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Roles.Marshal(b, m, deterministic)
+}
+func (m *Roles) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Roles.Merge(m, src)
+}
+func (m *Roles) XXX_Size() int {
+	return xxx_messageInfo_Roles.Size(m)
+}
+func (m *Roles) XXX_DiscardUnknown() {
+	xxx_messageInfo_Roles.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Roles proto.InternalMessageInfo
+
+func (m *Roles) GetRoles() []*Role {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type RoleName struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleName) Reset()         { *m = RoleName{} }
+func (m *RoleName) String() string { return proto.CompactTextString(m) }
+func (*RoleName) ProtoMessage()    {}
+func (*RoleName) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{3}
+}
+
+func (m *RoleName) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleName.Unmarshal(m, b)
+}
+func (m *RoleName) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleName.Marshal(b, m, deterministic)
+}
+func (m *RoleName) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleName.Merge(m, src)
+}
+func (m *RoleName) XXX_Size() int {
+	return xxx_messageInfo_RoleName.Size(m)
+}
+func (m *RoleName) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleName.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleName proto.InternalMessageInfo
+
+func (m *RoleName) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RoleBinding struct {
+	Operator             *protos.Identity `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	Role                 string           `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	NetworkId            string           `protobuf:"bytes,3,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RoleBinding) Reset()         { *m = RoleBinding{} }
+func (m *RoleBinding) String() string { return proto.CompactTextString(m) }
+func (*RoleBinding) ProtoMessage()    {}
+func (*RoleBinding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{4}
+}
+
+func (m *RoleBinding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleBinding.Unmarshal(m, b)
+}
+func (m *RoleBinding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleBinding.Marshal(b, m, deterministic)
+}
+func (m *RoleBinding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleBinding.Merge(m, src)
+}
+func (m *RoleBinding) XXX_Size() int {
+	return xxx_messageInfo_RoleBinding.Size(m)
+}
+func (m *RoleBinding) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleBinding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleBinding proto.InternalMessageInfo
+
+func (m *RoleBinding) GetOperator() *protos.Identity {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *RoleBinding) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *RoleBinding) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+// Role bindings of a single operator
+type RoleBindings struct {
+	Operator             *protos.Identity `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	Bindings             []*RoleBinding   `protobuf:"bytes,2,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RoleBindings) Reset()         { *m = RoleBindings{} }
+func (m *RoleBindings) String() string { return proto.CompactTextString(m) }
+func (*RoleBindings) ProtoMessage()    {}
+func (*RoleBindings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{5}
+}
+
+func (m *RoleBindings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleBindings.Unmarshal(m, b)
+}
+func (m *RoleBindings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleBindings.Marshal(b, m, deterministic)
+}
+func (m *RoleBindings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleBindings.Merge(m, src)
+}
+func (m *RoleBindings) XXX_Size() int {
+	return xxx_messageInfo_RoleBindings.Size(m)
+}
+func (m *RoleBindings) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleBindings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleBindings proto.InternalMessageInfo
+
+func (m *RoleBindings) GetOperator() *protos.Identity {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *RoleBindings) GetBindings() []*RoleBinding {
+	if m != nil {
+		return m.Bindings
+	}
+	return nil
+}
+
+// RPC Request used to verify Operator's role permissions for a REST request
+type RoleAccessRequest struct {
+	Operator             *protos.Identity         `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	NetworkId            string                   `protobuf:"bytes,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Path                 string                   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	EntityType           string                   `protobuf:"bytes,4,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Permissions          AccessControl_Permission `protobuf:"varint,5,opt,name=permissions,proto3,enum=magma.orc8r.accessd.AccessControl_Permission" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *RoleAccessRequest) Reset()         { *m = RoleAccessRequest{} }
+func (m *RoleAccessRequest) String() string { return proto.CompactTextString(m) }
+func (*RoleAccessRequest) ProtoMessage()    {}
+func (*RoleAccessRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a098e900d2c3a6f2, []int{6}
+}
+
+func (m *RoleAccessRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleAccessRequest.Unmarshal(m, b)
+}
+func (m *RoleAccessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleAccessRequest.Marshal(b, m, deterministic)
+}
+func (m *RoleAccessRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleAccessRequest.Merge(m, src)
+}
+func (m *RoleAccessRequest) XXX_Size() int {
+	return xxx_messageInfo_RoleAccessRequest.Size(m)
+}
+func (m *RoleAccessRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleAccessRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleAccessRequest proto.InternalMessageInfo
+
+func (m *RoleAccessRequest) GetOperator() *protos.Identity {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *RoleAccessRequest) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *RoleAccessRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *RoleAccessRequest) GetEntityType() string {
+	if m != nil {
+		return m.EntityType
+	}
+	return ""
+}
+
+func (m *RoleAccessRequest) GetPermissions() AccessControl_Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return AccessControl_NONE
+}
+
 func init() {
 	proto.RegisterEnum("magma.orc8r.accessd.AccessControl_Permission", AccessControl_Permission_name, AccessControl_Permission_value)
 	proto.RegisterType((*AccessControl)(nil), "magma.orc8r.accessd.AccessControl")
@@ -330,46 +711,75 @@ func init() {
 	proto.RegisterType((*AccessControl_ListRequest)(nil), "magma.orc8r.accessd.AccessControl.ListRequest")
 	proto.RegisterType((*AccessControl_PermissionsRequest)(nil), "magma.orc8r.accessd.AccessControl.PermissionsRequest")
 	proto.RegisterType((*AccessControl_Lists)(nil), "magma.orc8r.accessd.AccessControl.Lists")
+	proto.RegisterType((*Role)(nil), "magma.orc8r.accessd.Role")
+	proto.RegisterType((*Role_Rule)(nil), "magma.orc8r.accessd.Role.Rule")
+	proto.RegisterType((*Roles)(nil), "magma.orc8r.accessd.Roles")
+	proto.RegisterType((*RoleName)(nil), "magma.orc8r.accessd.RoleName")
+	proto.RegisterType((*RoleBinding)(nil), "magma.orc8r.accessd.RoleBinding")
+	proto.RegisterType((*RoleBindings)(nil), "magma.orc8r.accessd.RoleBindings")
+	proto.RegisterType((*RoleAccessRequest)(nil), "magma.orc8r.accessd.RoleAccessRequest")
 }
 
 func init() { proto.RegisterFile("access.proto", fileDescriptor_a098e900d2c3a6f2) }
 
 var fileDescriptor_a098e900d2c3a6f2 = []byte{
-	// 531 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xe1, 0x6b, 0xd3, 0x40,
-	0x14, 0x4f, 0xba, 0xb5, 0x74, 0x2f, 0x6b, 0x89, 0x4f, 0x85, 0x7a, 0x7e, 0x19, 0x01, 0xb1, 0x22,
-	0xcb, 0xb0, 0x32, 0x18, 0x53, 0xd0, 0xda, 0x06, 0x19, 0xd4, 0x55, 0x4f, 0xe7, 0x60, 0xdf, 0x62,
-	0x72, 0xce, 0xb0, 0x26, 0x57, 0x73, 0xb7, 0x41, 0xbf, 0xf9, 0xcd, 0x7f, 0xd8, 0x8f, 0x22, 0x92,
-	0xbb, 0xac, 0x4d, 0x58, 0x37, 0x52, 0xdd, 0xa7, 0x5c, 0xee, 0xfd, 0x7e, 0xbf, 0xf7, 0x7b, 0xef,
-	0x5d, 0x2e, 0xb0, 0xe9, 0x07, 0x01, 0x13, 0xc2, 0x9d, 0xa6, 0x5c, 0x72, 0xbc, 0x1b, 0xfb, 0xa7,
-	0xb1, 0xef, 0xf2, 0x34, 0xd8, 0x4b, 0x5d, 0x1d, 0x09, 0xc9, 0x03, 0xf5, 0xba, 0xa3, 0x10, 0x62,
-	0x27, 0xe0, 0x71, 0xcc, 0x13, 0x8d, 0x27, 0x0f, 0x4b, 0xa1, 0x28, 0x64, 0x89, 0x8c, 0xe4, 0x4c,
-	0x07, 0x9d, 0x3f, 0x75, 0x68, 0xf5, 0x95, 0xc6, 0x80, 0x27, 0x32, 0xe5, 0x13, 0xf2, 0xc3, 0x84,
-	0x86, 0xa7, 0x20, 0xf8, 0x08, 0x6a, 0x51, 0xd8, 0x31, 0xb7, 0xcc, 0xae, 0xd5, 0xbb, 0xef, 0x16,
-	0xd3, 0x1e, 0xe4, 0x2a, 0xb4, 0x16, 0x85, 0x38, 0x06, 0x6b, 0xca, 0xd2, 0x38, 0x12, 0x22, 0xe2,
-	0x89, 0xe8, 0xd4, 0xb6, 0xcc, 0x6e, 0xbb, 0xb7, 0xed, 0x2e, 0xb1, 0xe9, 0x96, 0x52, 0xb9, 0xef,
-	0xe7, 0x2c, 0x5a, 0x54, 0x20, 0xbf, 0x4c, 0x58, 0x1f, 0x45, 0x42, 0xe2, 0x33, 0x68, 0xf2, 0x29,
-	0x4b, 0x7d, 0xc9, 0xd3, 0x9b, 0x6d, 0xcc, 0x61, 0xf8, 0x01, 0x9a, 0x6a, 0x2f, 0x62, 0x99, 0x93,
-	0xb5, 0xae, 0xd5, 0xdb, 0xad, 0xe0, 0x24, 0xcb, 0xe6, 0x7a, 0x39, 0xcf, 0x4b, 0x64, 0x3a, 0xa3,
-	0x73, 0x19, 0xf2, 0x15, 0x5a, 0xa5, 0x10, 0xda, 0xb0, 0x76, 0xc6, 0x66, 0xca, 0xd1, 0x06, 0xcd,
-	0x96, 0xf8, 0x0a, 0xea, 0x17, 0xfe, 0xe4, 0x9c, 0xa9, 0xe2, 0xad, 0xde, 0x93, 0x0a, 0x29, 0x75,
-	0x8f, 0xa9, 0xe6, 0xed, 0xd7, 0xf6, 0x4c, 0xf2, 0xd3, 0x04, 0x2b, 0x33, 0x42, 0xd9, 0xf7, 0x73,
-	0xf6, 0x6f, 0xd5, 0x7b, 0x57, 0xaa, 0x5f, 0xc1, 0xca, 0xa2, 0xe2, 0x0b, 0xc0, 0xc5, 0x6c, 0xc4,
-	0x7f, 0xf8, 0xd9, 0x86, 0x86, 0xde, 0xcb, 0x1b, 0x73, 0x0d, 0x21, 0x07, 0x91, 0x21, 0xd4, 0xb3,
-	0x06, 0x08, 0x7c, 0x01, 0xeb, 0x7e, 0x30, 0x11, 0x1d, 0x53, 0xd5, 0xf0, 0xb8, 0xe2, 0x04, 0xa9,
-	0x22, 0x39, 0x4f, 0x01, 0x16, 0xee, 0xb1, 0x09, 0xeb, 0x87, 0xe3, 0x43, 0xcf, 0x36, 0xb2, 0x15,
-	0xf5, 0xfa, 0x43, 0xdb, 0xc4, 0x0d, 0xa8, 0x1f, 0xd3, 0x83, 0x4f, 0x9e, 0x5d, 0xeb, 0xfd, 0xae,
-	0xc3, 0xbd, 0x92, 0xd2, 0x3b, 0x3f, 0xf1, 0x4f, 0x59, 0x8a, 0x14, 0xac, 0x8f, 0x4c, 0x8e, 0x2f,
-	0x2b, 0x71, 0xab, 0x7a, 0xd0, 0xcd, 0x22, 0x77, 0x4a, 0xf8, 0xcf, 0x3c, 0x0a, 0x1d, 0x03, 0x8f,
-	0xa0, 0x7d, 0x34, 0x0d, 0x7d, 0xc9, 0x6e, 0x57, 0xf6, 0x25, 0xb4, 0x87, 0x6c, 0xc2, 0x0a, 0xb2,
-	0xcb, 0xfb, 0xbc, 0x9c, 0x4d, 0xa1, 0xfd, 0x76, 0x51, 0x68, 0x7f, 0x30, 0xba, 0x8e, 0x5d, 0x75,
-	0x0c, 0x8e, 0x81, 0x27, 0x60, 0x17, 0x34, 0x45, 0x7f, 0x30, 0x12, 0x48, 0x96, 0xaa, 0x2a, 0x06,
-	0xe9, 0x56, 0x94, 0x16, 0x8e, 0x81, 0x52, 0xf9, 0x2d, 0x9c, 0x4f, 0xdc, 0x5d, 0xe9, 0xae, 0xb9,
-	0x3c, 0xcf, 0xa4, 0xfa, 0xa7, 0xe1, 0x18, 0x78, 0x0c, 0xf6, 0xe0, 0x1b, 0x0b, 0xce, 0x8a, 0x79,
-	0x6f, 0x65, 0x78, 0xaf, 0xa1, 0x95, 0x61, 0xe6, 0xbd, 0xc2, 0xab, 0x28, 0x72, 0x43, 0xeb, 0x1c,
-	0x03, 0xf7, 0x61, 0x53, 0x8f, 0x3f, 0xbf, 0xb6, 0x57, 0x18, 0xfe, 0x9b, 0xe6, 0x49, 0x43, 0xff,
-	0x18, 0xbe, 0xe8, 0xe7, 0xf3, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x04, 0xce, 0x33, 0x6d,
-	0x06, 0x00, 0x00,
+	// 881 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5f, 0x6f, 0xdb, 0x54,
+	0x14, 0x8f, 0x1d, 0xbb, 0x8d, 0x8f, 0x93, 0x2c, 0xbd, 0x80, 0x94, 0x19, 0x6d, 0x64, 0x46, 0x40,
+	0x10, 0x9a, 0x2b, 0x02, 0x93, 0xca, 0x98, 0x04, 0x59, 0x6a, 0x55, 0x45, 0x5d, 0x3a, 0xee, 0x36,
+	0x26, 0xed, 0xa5, 0x72, 0xe3, 0x4b, 0x67, 0xd5, 0x7f, 0x82, 0xef, 0x4d, 0x51, 0x9e, 0xe0, 0x8d,
+	0x17, 0xbe, 0x03, 0xdf, 0x81, 0xaf, 0xc5, 0x2b, 0xcf, 0x08, 0xdd, 0x7b, 0xdd, 0xc4, 0xe9, 0xec,
+	0xd6, 0x6d, 0xfa, 0x94, 0xeb, 0x7b, 0xce, 0xef, 0x9c, 0xf3, 0xfb, 0x9d, 0xe3, 0xe3, 0x40, 0xd3,
+	0x9b, 0x4c, 0x08, 0xa5, 0xce, 0x34, 0x4d, 0x58, 0x82, 0xde, 0x8b, 0xbc, 0x93, 0xc8, 0x73, 0x92,
+	0x74, 0xb2, 0x93, 0x3a, 0xd2, 0xe2, 0x5b, 0x77, 0xc5, 0xe3, 0xb6, 0xf0, 0xa0, 0xdb, 0x93, 0x24,
+	0x8a, 0x92, 0x58, 0xfa, 0x5b, 0x1f, 0xae, 0x98, 0x02, 0x9f, 0xc4, 0x2c, 0x60, 0x73, 0x69, 0xb4,
+	0xff, 0xd3, 0xa1, 0x35, 0x14, 0x31, 0x46, 0x49, 0xcc, 0xd2, 0x24, 0xb4, 0x7e, 0x57, 0x60, 0xc3,
+	0x15, 0x2e, 0xe8, 0x13, 0x50, 0x03, 0xbf, 0xab, 0xf4, 0x94, 0xbe, 0x39, 0xf8, 0xc0, 0xc9, 0xa7,
+	0xdd, 0xcf, 0xa2, 0x60, 0x35, 0xf0, 0xd1, 0x21, 0x98, 0x53, 0x92, 0x46, 0x01, 0xa5, 0x41, 0x12,
+	0xd3, 0xae, 0xda, 0x53, 0xfa, 0xed, 0xc1, 0x43, 0xa7, 0xa0, 0x4c, 0x67, 0x25, 0x95, 0xf3, 0x7c,
+	0x81, 0xc2, 0xf9, 0x08, 0xd6, 0xbf, 0x0a, 0x68, 0x07, 0x01, 0x65, 0xe8, 0x4b, 0x68, 0x24, 0x53,
+	0x92, 0x7a, 0x2c, 0x49, 0x2f, 0x2f, 0x63, 0xe1, 0x86, 0x7e, 0x84, 0x86, 0xb8, 0x0b, 0x08, 0xaf,
+	0xa4, 0xde, 0x37, 0x07, 0x8f, 0x2a, 0x54, 0xc2, 0xb3, 0x39, 0x6e, 0x86, 0x73, 0x63, 0x96, 0xce,
+	0xf1, 0x22, 0x8c, 0xf5, 0x33, 0xb4, 0x56, 0x4c, 0xa8, 0x03, 0xf5, 0x53, 0x32, 0x17, 0x15, 0x19,
+	0x98, 0x1f, 0xd1, 0x77, 0xa0, 0x9f, 0x79, 0xe1, 0x8c, 0x08, 0xf2, 0xe6, 0xe0, 0xf3, 0x0a, 0x29,
+	0xa5, 0xc6, 0x58, 0xe2, 0x1e, 0xab, 0x3b, 0x8a, 0xf5, 0x87, 0x02, 0x26, 0x2f, 0x04, 0x93, 0x5f,
+	0x66, 0xe4, 0x66, 0xec, 0xdd, 0x77, 0xd8, 0x5f, 0xa3, 0x94, 0x25, 0xe3, 0x33, 0x40, 0xcb, 0xde,
+	0xd0, 0x35, 0xea, 0x79, 0x08, 0x1b, 0xf2, 0xae, 0xab, 0x5e, 0x06, 0xc8, 0x9c, 0xac, 0x5d, 0xd0,
+	0xb9, 0x00, 0x14, 0x7d, 0x0b, 0x9a, 0x37, 0x09, 0x69, 0x57, 0x11, 0x1c, 0x3e, 0xab, 0xd8, 0x41,
+	0x2c, 0x40, 0xf6, 0x17, 0x00, 0xcb, 0xea, 0x51, 0x03, 0xb4, 0xf1, 0xe1, 0xd8, 0xed, 0xd4, 0xf8,
+	0x09, 0xbb, 0xc3, 0xdd, 0x8e, 0x82, 0x0c, 0xd0, 0x5f, 0xe3, 0xfd, 0x97, 0x6e, 0x47, 0xb5, 0xff,
+	0x54, 0x41, 0xc3, 0x49, 0x48, 0x10, 0x02, 0x2d, 0xf6, 0x22, 0x92, 0x75, 0x55, 0x9c, 0xd1, 0xd7,
+	0xa0, 0xa7, 0xb3, 0x70, 0xa1, 0xe5, 0xfd, 0xc2, 0x3a, 0x38, 0xda, 0xc1, 0xb3, 0x90, 0x60, 0xe9,
+	0x8c, 0xba, 0xb0, 0x79, 0x3c, 0x0b, 0x42, 0x16, 0xc4, 0xdd, 0x7a, 0x4f, 0xe9, 0x37, 0xf0, 0xf9,
+	0xa3, 0xf5, 0x97, 0x02, 0x1a, 0xf7, 0x44, 0x1f, 0x43, 0x6b, 0xea, 0xb1, 0xb7, 0x47, 0x53, 0x8f,
+	0x31, 0x92, 0xc6, 0x92, 0xa8, 0x81, 0x9b, 0xfc, 0xf2, 0x79, 0x76, 0x87, 0x1e, 0x40, 0x53, 0xea,
+	0x72, 0xc4, 0xe6, 0xd3, 0xac, 0x08, 0x03, 0x9b, 0xf2, 0xee, 0x25, 0xbf, 0xba, 0xf8, 0xea, 0xd5,
+	0xd7, 0x7d, 0xf5, 0xec, 0x1d, 0xd0, 0x39, 0x1f, 0x8a, 0xb6, 0x41, 0x4f, 0xf9, 0x21, 0x6b, 0xc1,
+	0xdd, 0x52, 0xea, 0x58, 0xfa, 0xd9, 0xf7, 0xa1, 0xc1, 0x1f, 0xc7, 0x5e, 0x54, 0xa8, 0xa5, 0x4d,
+	0xc1, 0xe4, 0xf6, 0xa7, 0x41, 0xec, 0x07, 0xf1, 0xc9, 0x4d, 0x86, 0x09, 0x81, 0xc6, 0x53, 0x89,
+	0x51, 0x32, 0xb0, 0x38, 0xa3, 0x7b, 0x00, 0x31, 0x61, 0xbf, 0x26, 0xe9, 0xe9, 0x51, 0xe0, 0x0b,
+	0xfe, 0x06, 0x36, 0xb2, 0x9b, 0x7d, 0xdf, 0xfe, 0x0d, 0x9a, 0xb9, 0xa4, 0xf4, 0x26, 0x59, 0x9f,
+	0x40, 0xe3, 0x38, 0x83, 0x67, 0x63, 0xd0, 0x2b, 0xd5, 0x22, 0xcb, 0x83, 0x17, 0x08, 0xfb, 0x1f,
+	0x05, 0xb6, 0xb8, 0x45, 0xaa, 0xbf, 0xc6, 0x9b, 0xb4, 0x4a, 0x54, 0xbd, 0x40, 0x94, 0x6b, 0xc3,
+	0x67, 0x27, 0x53, 0x40, 0x9c, 0xd1, 0x47, 0x60, 0xe6, 0xe6, 0xa7, 0xab, 0x09, 0x13, 0x2c, 0xc7,
+	0xe7, 0xe2, 0xf4, 0xe8, 0xeb, 0x4e, 0xcf, 0xe0, 0x6f, 0x80, 0xf7, 0x57, 0x3c, 0x9f, 0x79, 0xb1,
+	0x77, 0x42, 0x52, 0x84, 0xc1, 0x7c, 0x41, 0xd8, 0xe1, 0x39, 0x19, 0xa7, 0xea, 0x0b, 0x2d, 0xf5,
+	0xb2, 0xb6, 0x56, 0xfc, 0x7f, 0x4a, 0x02, 0xdf, 0xae, 0xa1, 0x57, 0xd0, 0x7e, 0x35, 0xf5, 0x3d,
+	0x46, 0x6e, 0x37, 0xec, 0x13, 0x68, 0xef, 0x92, 0x90, 0xe4, 0xc2, 0x16, 0xf7, 0xa6, 0x18, 0x8d,
+	0xa1, 0xbd, 0xb7, 0x24, 0x3a, 0x1c, 0x1d, 0x94, 0xa1, 0xab, 0xee, 0x34, 0xbb, 0x86, 0xde, 0x40,
+	0x27, 0x17, 0x93, 0x0e, 0x47, 0x07, 0x14, 0x59, 0x85, 0x51, 0x05, 0xc2, 0xea, 0x57, 0x0c, 0x4d,
+	0xed, 0x1a, 0x62, 0xa2, 0xde, 0xdc, 0xb2, 0x47, 0x8f, 0xae, 0xd5, 0xff, 0xf3, 0x91, 0xb6, 0xaa,
+	0x7f, 0x67, 0xec, 0x1a, 0x7a, 0x0d, 0x9d, 0xd1, 0x5b, 0x32, 0x39, 0xcd, 0xe7, 0xbd, 0x95, 0xe6,
+	0x7d, 0x0f, 0x2d, 0xee, 0xb3, 0xd0, 0x0a, 0xbd, 0xeb, 0x65, 0x5d, 0x22, 0x9d, 0x5d, 0x43, 0x8f,
+	0xa1, 0x29, 0xdb, 0x9f, 0xfd, 0x07, 0xba, 0x4e, 0xf3, 0xbf, 0x81, 0xcd, 0x17, 0x84, 0x89, 0xaf,
+	0x49, 0xf9, 0xbe, 0x2c, 0x86, 0xba, 0xb0, 0xb9, 0x97, 0x41, 0xef, 0x95, 0x42, 0xf9, 0x6e, 0xb5,
+	0xca, 0x23, 0x8b, 0xe1, 0x35, 0x84, 0x46, 0x62, 0x85, 0x5f, 0xc9, 0x3d, 0x0f, 0xa6, 0x42, 0x3d,
+	0x90, 0xdc, 0xab, 0xd4, 0x51, 0x48, 0x63, 0x0f, 0xda, 0x43, 0xdf, 0xcf, 0xef, 0xf9, 0x2b, 0x97,
+	0x65, 0x71, 0xa0, 0x1f, 0x60, 0x0b, 0x93, 0x28, 0x39, 0x23, 0xb7, 0x10, 0xeb, 0x19, 0xdc, 0xc9,
+	0xb4, 0x5d, 0x7c, 0x07, 0x4a, 0xba, 0xfa, 0xe0, 0xaa, 0x04, 0x5c, 0xa5, 0x31, 0xdc, 0x11, 0xc3,
+	0xbb, 0x5c, 0xeb, 0xe8, 0xd3, 0x52, 0xdc, 0xca, 0xde, 0x2f, 0x2c, 0xef, 0x69, 0xe3, 0xcd, 0x86,
+	0xfc, 0x6f, 0x7e, 0x2c, 0x7f, 0xbf, 0xfa, 0x7f, 0x00, 0x0c, 0x0b, 0xa9, 0x15, 0xf0, 0x0b, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -412,6 +822,25 @@ type AccessControlManagerClient interface {
 	ListOperators(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*protos.Identity_List, error)
 	// Cleanup a given entity from all Operators' ACLs
 	DeleteEntity(ctx context.Context, in *protos.Identity, opts ...grpc.CallOption) (*protos.Void, error)
+	// Creates or overwrites a Role, built-in roles may be overwritten
+	SetRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns the Role with the given name
+	GetRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*Role, error)
+	// Lists all Roles, including built-in roles
+	ListRoles(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*Roles, error)
+	// Deletes a Role, fails if the role is bound to any operator or is a
+	// built-in role which has not been overwritten
+	DeleteRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*protos.Void, error)
+	// Binds a Role to an Operator in a network (or all networks)
+	AddRoleBinding(ctx context.Context, in *RoleBinding, opts ...grpc.CallOption) (*protos.Void, error)
+	// Removes an Operator's Role binding
+	RemoveRoleBinding(ctx context.Context, in *RoleBinding, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns all Role bindings of the Operator
+	GetRoleBindings(ctx context.Context, in *protos.Identity, opts ...grpc.CallOption) (*RoleBindings, error)
+	// CheckRoleAccess verifies that Roles bound to the Operator grant the
+	// requested permissions for the REST request described by
+	// RoleAccessRequest
+	CheckRoleAccess(ctx context.Context, in *RoleAccessRequest, opts ...grpc.CallOption) (*protos.Void, error)
 }
 
 type accessControlManagerClient struct {
@@ -503,6 +932,78 @@ func (c *accessControlManagerClient) DeleteEntity(ctx context.Context, in *proto
 	return out, nil
 }
 
+func (c *accessControlManagerClient) SetRole(ctx context.Context, in *Role, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/SetRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) GetRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*Role, error) {
+	out := new(Role)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/GetRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) ListRoles(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*Roles, error) {
+	out := new(Roles)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/ListRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) DeleteRole(ctx context.Context, in *RoleName, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/DeleteRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) AddRoleBinding(ctx context.Context, in *RoleBinding, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/AddRoleBinding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) RemoveRoleBinding(ctx context.Context, in *RoleBinding, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/RemoveRoleBinding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) GetRoleBindings(ctx context.Context, in *protos.Identity, opts ...grpc.CallOption) (*RoleBindings, error) {
+	out := new(RoleBindings)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/GetRoleBindings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlManagerClient) CheckRoleAccess(ctx context.Context, in *RoleAccessRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AccessControlManager/CheckRoleAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccessControlManagerServer is the server API for AccessControlManager service.
 type AccessControlManagerServer interface {
 	// Overwrites Permissions for operator Identity to manage others
@@ -533,6 +1034,25 @@ type AccessControlManagerServer interface {
 	ListOperators(context.Context, *protos.Void) (*protos.Identity_List, error)
 	// Cleanup a given entity from all Operators' ACLs
 	DeleteEntity(context.Context, *protos.Identity) (*protos.Void, error)
+	// Creates or overwrites a Role, built-in roles may be overwritten
+	SetRole(context.Context, *Role) (*protos.Void, error)
+	// Returns the Role with the given name
+	GetRole(context.Context, *RoleName) (*Role, error)
+	// Lists all Roles, including built-in roles
+	ListRoles(context.Context, *protos.Void) (*Roles, error)
+	// Deletes a Role, fails if the role is bound to any operator or is a
+	// built-in role which has not been overwritten
+	DeleteRole(context.Context, *RoleName) (*protos.Void, error)
+	// Binds a Role to an Operator in a network (or all networks)
+	AddRoleBinding(context.Context, *RoleBinding) (*protos.Void, error)
+	// Removes an Operator's Role binding
+	RemoveRoleBinding(context.Context, *RoleBinding) (*protos.Void, error)
+	// Returns all Role bindings of the Operator
+	GetRoleBindings(context.Context, *protos.Identity) (*RoleBindings, error)
+	// CheckRoleAccess verifies that Roles bound to the Operator grant the
+	// requested permissions for the REST request described by
+	// RoleAccessRequest
+	CheckRoleAccess(context.Context, *RoleAccessRequest) (*protos.Void, error)
 }
 
 // UnimplementedAccessControlManagerServer can be embedded to have forward compatible implementations.
@@ -566,6 +1086,30 @@ func (*UnimplementedAccessControlManagerServer) ListOperators(ctx context.Contex
 func (*UnimplementedAccessControlManagerServer) DeleteEntity(ctx context.Context, req *protos.Identity) (*protos.Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntity not implemented")
 }
+func (*UnimplementedAccessControlManagerServer) SetRole(ctx context.Context, req *Role) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) GetRole(ctx context.Context, req *RoleName) (*Role, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) ListRoles(ctx context.Context, req *protos.Void) (*Roles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoles not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) DeleteRole(ctx context.Context, req *RoleName) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRole not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) AddRoleBinding(ctx context.Context, req *RoleBinding) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleBinding not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) RemoveRoleBinding(ctx context.Context, req *RoleBinding) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleBinding not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) GetRoleBindings(ctx context.Context, req *protos.Identity) (*RoleBindings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoleBindings not implemented")
+}
+func (*UnimplementedAccessControlManagerServer) CheckRoleAccess(ctx context.Context, req *RoleAccessRequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckRoleAccess not implemented")
+}
 
 func RegisterAccessControlManagerServer(s *grpc.Server, srv AccessControlManagerServer) {
 	s.RegisterService(&_AccessControlManager_serviceDesc, srv)
@@ -733,6 +1277,150 @@ func _AccessControlManager_DeleteEntity_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccessControlManager_SetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Role)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).SetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/SetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).SetRole(ctx, req.(*Role))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_GetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).GetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/GetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).GetRole(ctx, req.(*RoleName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_ListRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).ListRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/ListRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).ListRoles(ctx, req.(*protos.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_DeleteRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).DeleteRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/DeleteRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).DeleteRole(ctx, req.(*RoleName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_AddRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleBinding)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).AddRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/AddRoleBinding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).AddRoleBinding(ctx, req.(*RoleBinding))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_RemoveRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleBinding)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).RemoveRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/RemoveRoleBinding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).RemoveRoleBinding(ctx, req.(*RoleBinding))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_GetRoleBindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Identity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).GetRoleBindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/GetRoleBindings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).GetRoleBindings(ctx, req.(*protos.Identity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControlManager_CheckRoleAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlManagerServer).CheckRoleAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AccessControlManager/CheckRoleAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlManagerServer).CheckRoleAccess(ctx, req.(*RoleAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AccessControlManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.accessd.AccessControlManager",
 	HandlerType: (*AccessControlManagerServer)(nil),
@@ -773,6 +1461,38 @@ var _AccessControlManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEntity",
 			Handler:    _AccessControlManager_DeleteEntity_Handler,
 		},
+		{
+			MethodName: "SetRole",
+			Handler:    _AccessControlManager_SetRole_Handler,
+		},
+		{
+			MethodName: "GetRole",
+			Handler:    _AccessControlManager_GetRole_Handler,
+		},
+		{
+			MethodName: "ListRoles",
+			Handler:    _AccessControlManager_ListRoles_Handler,
+		},
+		{
+			MethodName: "DeleteRole",
+			Handler:    _AccessControlManager_DeleteRole_Handler,
+		},
+		{
+			MethodName: "AddRoleBinding",
+			Handler:    _AccessControlManager_AddRoleBinding_Handler,
+		},
+		{
+			MethodName: "RemoveRoleBinding",
+			Handler:    _AccessControlManager_RemoveRoleBinding_Handler,
+		},
+		{
+			MethodName: "GetRoleBindings",
+			Handler:    _AccessControlManager_GetRoleBindings_Handler,
+		},
+		{
+			MethodName: "CheckRoleAccess",
+			Handler:    _AccessControlManager_CheckRoleAccess_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "access.proto",
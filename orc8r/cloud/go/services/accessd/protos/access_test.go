@@ -34,3 +34,27 @@ func TestAccessControlDefinitions(t *testing.T) {
 
 	assert.Equal(t, "NONE", protos.AccessControl_Permission(16).ToString())
 }
+
+func TestRoleMatching(t *testing.T) {
+	assert.True(t, protos.MatchPathPattern("/magma/v1/networks", "/magma/v1/networks"))
+	assert.False(t, protos.MatchPathPattern("/magma/v1/networks", "/magma/v1/networks/:network_id"))
+	assert.True(t, protos.MatchPathPattern("/magma/v1/networks*", "/magma/v1/networks/:network_id"))
+	assert.True(t, protos.MatchPathPattern("*", "/anything"))
+	assert.True(t, protos.MatchPathPattern("/magma/*/subscribers*", "/magma/v1/lte/:network_id/subscribers"))
+	assert.False(t, protos.MatchPathPattern("/magma/*/subscribers*", "/magma/v1/lte/:network_id/enodebs"))
+
+	role := &protos.Role{
+		Name: "test",
+		Rules: []*protos.Role_Rule{
+			{EntityTypes: []string{"subscribers"}, Permissions: protos.AccessControl_READ | protos.AccessControl_WRITE},
+			{PathPatterns: []string{"/magma/v1/lte*"}, Permissions: protos.AccessControl_READ},
+		},
+	}
+	assert.Equal(
+		t,
+		protos.AccessControl_READ|protos.AccessControl_WRITE,
+		role.Permissions("/magma/v1/lte/:network_id/subscribers", "subscribers"))
+	assert.Equal(t, protos.AccessControl_READ, role.Permissions("/magma/v1/lte/:network_id/enodebs", "enodebs"))
+	assert.Equal(t, protos.AccessControl_NONE, role.Permissions("/magma/v1/networks", "networks"))
+	assert.Equal(t, protos.AccessControl_NONE, (&protos.Role{}).Permissions("/magma/v1/networks", "networks"))
+}
@@ -0,0 +1,394 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: audit.proto
+
+package protos
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protos "magma/orc8r/cloud/go/protos"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type AuditRecord struct {
+	Operator             *protos.Identity     `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	Method               string               `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Path                 string               `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	NetworkId            string               `protobuf:"bytes,4,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	EntityType           string               `protobuf:"bytes,5,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityKey            string               `protobuf:"bytes,6,opt,name=entity_key,json=entityKey,proto3" json:"entity_key,omitempty"`
+	BodySha256           string               `protobuf:"bytes,7,opt,name=body_sha256,json=bodySha256,proto3" json:"body_sha256,omitempty"`
+	ResultCode           int32                `protobuf:"varint,8,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AuditRecord) Reset()         { *m = AuditRecord{} }
+func (m *AuditRecord) String() string { return proto.CompactTextString(m) }
+func (*AuditRecord) ProtoMessage()    {}
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{0}
+}
+
+func (m *AuditRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditRecord.Unmarshal(m, b)
+}
+func (m *AuditRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditRecord.Marshal(b, m, deterministic)
+}
+func (m *AuditRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditRecord.Merge(m, src)
+}
+func (m *AuditRecord) XXX_Size() int {
+	return xxx_messageInfo_AuditRecord.Size(m)
+}
+func (m *AuditRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditRecord proto.InternalMessageInfo
+
+func (m *AuditRecord) GetOperator() *protos.Identity {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *AuditRecord) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetEntityType() string {
+	if m != nil {
+		return m.EntityType
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetEntityKey() string {
+	if m != nil {
+		return m.EntityKey
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetBodySha256() string {
+	if m != nil {
+		return m.BodySha256
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetResultCode() int32 {
+	if m != nil {
+		return m.ResultCode
+	}
+	return 0
+}
+
+func (m *AuditRecord) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type AuditRecords struct {
+	Records              []*AuditRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AuditRecords) Reset()         { *m = AuditRecords{} }
+func (m *AuditRecords) String() string { return proto.CompactTextString(m) }
+func (*AuditRecords) ProtoMessage()    {}
+func (*AuditRecords) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{1}
+}
+
+func (m *AuditRecords) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditRecords.Unmarshal(m, b)
+}
+func (m *AuditRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditRecords.Marshal(b, m, deterministic)
+}
+func (m *AuditRecords) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditRecords.Merge(m, src)
+}
+func (m *AuditRecords) XXX_Size() int {
+	return xxx_messageInfo_AuditRecords.Size(m)
+}
+func (m *AuditRecords) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditRecords.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditRecords proto.InternalMessageInfo
+
+func (m *AuditRecords) GetRecords() []*AuditRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// AuditQuery selects records of the network logged within [start, end),
+// unset start or end leaves the range open on that side
+type AuditQuery struct {
+	NetworkId            string               `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Start                *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Limit                uint32               `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AuditQuery) Reset()         { *m = AuditQuery{} }
+func (m *AuditQuery) String() string { return proto.CompactTextString(m) }
+func (*AuditQuery) ProtoMessage()    {}
+func (*AuditQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5594839dd8e38a1b, []int{2}
+}
+
+func (m *AuditQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditQuery.Unmarshal(m, b)
+}
+func (m *AuditQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditQuery.Marshal(b, m, deterministic)
+}
+func (m *AuditQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditQuery.Merge(m, src)
+}
+func (m *AuditQuery) XXX_Size() int {
+	return xxx_messageInfo_AuditQuery.Size(m)
+}
+func (m *AuditQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditQuery proto.InternalMessageInfo
+
+func (m *AuditQuery) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *AuditQuery) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *AuditQuery) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *AuditQuery) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*AuditRecord)(nil), "magma.orc8r.accessd.AuditRecord")
+	proto.RegisterType((*AuditRecords)(nil), "magma.orc8r.accessd.AuditRecords")
+	proto.RegisterType((*AuditQuery)(nil), "magma.orc8r.accessd.AuditQuery")
+}
+
+func init() { proto.RegisterFile("audit.proto", fileDescriptor_5594839dd8e38a1b) }
+
+var fileDescriptor_5594839dd8e38a1b = []byte{
+	// 437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcb, 0x8e, 0xd3, 0x30,
+	0x18, 0x85, 0x27, 0xed, 0xa4, 0xd3, 0xfe, 0x81, 0x05, 0xe6, 0x22, 0x13, 0x84, 0x1a, 0xb2, 0xea,
+	0x02, 0xb9, 0x50, 0x04, 0x1a, 0xb1, 0x41, 0xc0, 0x6a, 0xb8, 0x2c, 0x08, 0x23, 0x16, 0x6c, 0x2a,
+	0x37, 0xfe, 0x69, 0xa3, 0xa9, 0xfb, 0x47, 0xb6, 0x2b, 0x94, 0x57, 0x61, 0xc7, 0xd3, 0xf0, 0x5a,
+	0x28, 0x76, 0x3a, 0x74, 0x46, 0x8c, 0xba, 0xaa, 0x7d, 0xce, 0x77, 0x7c, 0x39, 0x6e, 0x20, 0x91,
+	0x5b, 0x55, 0x39, 0x51, 0x1b, 0x72, 0xc4, 0xee, 0x6a, 0xb9, 0xd4, 0x52, 0x90, 0x29, 0x4f, 0x8d,
+	0x90, 0x65, 0x89, 0xd6, 0xaa, 0xf4, 0xa1, 0x9f, 0x4e, 0x3d, 0x61, 0xa7, 0x25, 0x69, 0x4d, 0x9b,
+	0xc0, 0xa7, 0x8f, 0xae, 0x58, 0x95, 0xc2, 0x8d, 0xab, 0x5c, 0xd3, 0x99, 0xe3, 0x25, 0xd1, 0x72,
+	0x8d, 0xc1, 0x5d, 0x6c, 0x7f, 0x4c, 0x5d, 0xa5, 0xd1, 0x3a, 0xa9, 0xeb, 0x00, 0xe4, 0x7f, 0x7a,
+	0x90, 0xbc, 0x6d, 0x77, 0x2f, 0xb0, 0x24, 0xa3, 0xd8, 0x73, 0x18, 0x52, 0x8d, 0x46, 0x3a, 0x32,
+	0x3c, 0xca, 0xa2, 0x49, 0x32, 0xbb, 0x2f, 0xf6, 0x0f, 0x74, 0xd6, 0xad, 0x5f, 0x5c, 0x62, 0xec,
+	0x01, 0x0c, 0x34, 0xba, 0x15, 0x29, 0xde, 0xcb, 0xa2, 0xc9, 0xa8, 0xe8, 0x66, 0x8c, 0xc1, 0x71,
+	0x2d, 0xdd, 0x8a, 0xf7, 0xbd, 0xea, 0xc7, 0xec, 0x31, 0xc0, 0x06, 0xdd, 0x4f, 0x32, 0x17, 0xf3,
+	0x4a, 0xf1, 0x63, 0xef, 0x8c, 0x3a, 0xe5, 0x4c, 0xb1, 0x31, 0x24, 0x61, 0xf9, 0xb9, 0x6b, 0x6a,
+	0xe4, 0xb1, 0xf7, 0x21, 0x48, 0xe7, 0x4d, 0x8d, 0x6d, 0xbe, 0x03, 0x2e, 0xb0, 0xe1, 0x83, 0x90,
+	0x0f, 0xca, 0x47, 0x6c, 0xda, 0xfc, 0x82, 0x54, 0x33, 0xb7, 0x2b, 0x39, 0x7b, 0xf9, 0x8a, 0x9f,
+	0x84, 0x7c, 0x2b, 0x7d, 0xf5, 0x4a, 0x0b, 0x18, 0xb4, 0xdb, 0xb5, 0x9b, 0x97, 0xa4, 0x90, 0x0f,
+	0xb3, 0x68, 0x12, 0x17, 0x10, 0xa4, 0xf7, 0xa4, 0x90, 0x9d, 0xc2, 0xe8, 0xb2, 0x22, 0x3e, 0xf2,
+	0x05, 0xa4, 0x22, 0x94, 0x28, 0x76, 0x25, 0x8a, 0xf3, 0x1d, 0x51, 0xfc, 0x83, 0xf3, 0x0f, 0x70,
+	0x6b, 0xaf, 0x48, 0xcb, 0x5e, 0xc3, 0x89, 0x09, 0x43, 0x1e, 0x65, 0xfd, 0x49, 0x32, 0xcb, 0xc4,
+	0x7f, 0x5e, 0x56, 0xec, 0x65, 0x8a, 0x5d, 0x20, 0xff, 0x1d, 0x01, 0x78, 0xe3, 0xcb, 0x16, 0x4d,
+	0x73, 0xad, 0xb5, 0xe8, 0x7a, 0x6b, 0xcf, 0x20, 0xb6, 0x4e, 0x1a, 0xc7, 0x7b, 0x07, 0xcf, 0x1b,
+	0x40, 0xf6, 0x14, 0xfa, 0xb8, 0x51, 0xbc, 0x7f, 0x90, 0x6f, 0x31, 0x76, 0x0f, 0xe2, 0x75, 0xa5,
+	0x2b, 0xe7, 0xdf, 0xeb, 0x76, 0x11, 0x26, 0xb3, 0x5f, 0x11, 0x0c, 0xfd, 0x19, 0x3f, 0xd1, 0x92,
+	0xbd, 0x81, 0x41, 0xf7, 0x07, 0x3a, 0x78, 0xcb, 0xf4, 0xce, 0x15, 0xe2, 0x1b, 0x55, 0x2a, 0x3f,
+	0x62, 0x9f, 0x21, 0x0e, 0x77, 0x1d, 0xdf, 0x9c, 0xf7, 0x40, 0xfa, 0xe4, 0xd0, 0x06, 0x36, 0x3f,
+	0x7a, 0x37, 0xfc, 0x3e, 0x08, 0x1f, 0xc4, 0x22, 0xfc, 0xbe, 0xf8, 0x3b, 0x00, 0x1c, 0xfb, 0x42,
+	0xa3, 0x64, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AuditLogClient is the client API for AuditLog service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AuditLogClient interface {
+	// Adds a record to the audit log
+	Record(ctx context.Context, in *AuditRecord, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns records matching the query, oldest first
+	Query(ctx context.Context, in *AuditQuery, opts ...grpc.CallOption) (*AuditRecords, error)
+}
+
+type auditLogClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuditLogClient(cc *grpc.ClientConn) AuditLogClient {
+	return &auditLogClient{cc}
+}
+
+func (c *auditLogClient) Record(ctx context.Context, in *AuditRecord, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AuditLog/Record", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditLogClient) Query(ctx context.Context, in *AuditQuery, opts ...grpc.CallOption) (*AuditRecords, error) {
+	out := new(AuditRecords)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.accessd.AuditLog/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditLogServer is the server API for AuditLog service.
+type AuditLogServer interface {
+	// Adds a record to the audit log
+	Record(context.Context, *AuditRecord) (*protos.Void, error)
+	// Returns records matching the query, oldest first
+	Query(context.Context, *AuditQuery) (*AuditRecords, error)
+}
+
+// UnimplementedAuditLogServer can be embedded to have forward compatible implementations.
+type UnimplementedAuditLogServer struct {
+}
+
+func (*UnimplementedAuditLogServer) Record(ctx context.Context, req *AuditRecord) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Record not implemented")
+}
+func (*UnimplementedAuditLogServer) Query(ctx context.Context, req *AuditQuery) (*AuditRecords, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+
+func RegisterAuditLogServer(s *grpc.Server, srv AuditLogServer) {
+	s.RegisterService(&_AuditLog_serviceDesc, srv)
+}
+
+func _AuditLog_Record_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditRecord)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditLogServer).Record(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AuditLog/Record",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditLogServer).Record(ctx, req.(*AuditRecord))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditLog_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditLogServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.accessd.AuditLog/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditLogServer).Query(ctx, req.(*AuditQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AuditLog_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "magma.orc8r.accessd.AuditLog",
+	HandlerType: (*AuditLogServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Record",
+			Handler:    _AuditLog_Record_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _AuditLog_Query_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "audit.proto",
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// role_helper provides Role rule matching
+package protos
+
+import "strings"
+
+// Permissions returns the aggregated (ORed) permissions of all the role's
+// rules matching the given REST route and entity type
+func (r *Role) Permissions(path, entityType string) AccessControl_Permission {
+	res := AccessControl_NONE
+	for _, rule := range r.GetRules() {
+		if rule.Matches(path, entityType) {
+			res |= rule.Permissions
+		}
+	}
+	return res
+}
+
+// Matches returns true if the rule applies to the given REST route and
+// entity type. Empty path patterns or entity types match everything.
+func (r *Role_Rule) Matches(path, entityType string) bool {
+	if r == nil {
+		return false
+	}
+	if len(r.EntityTypes) > 0 {
+		found := false
+		for _, t := range r.EntityTypes {
+			if t == "*" || t == entityType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.PathPatterns) == 0 {
+		return true
+	}
+	for _, pattern := range r.PathPatterns {
+		if MatchPathPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPathPattern reports whether path matches the pattern, where '*'
+// matches any (including empty) sequence of characters and all other
+// characters match themselves
+func MatchPathPattern(pattern, path string) bool {
+	star := strings.IndexByte(pattern, '*')
+	if star < 0 {
+		return pattern == path
+	}
+	if !strings.HasPrefix(path, pattern[:star]) {
+		return false
+	}
+	rest := pattern[star+1:]
+	for i := star; i <= len(path); i++ {
+		if MatchPathPattern(rest, path[i:]) {
+			return true
+		}
+	}
+	return false
+}
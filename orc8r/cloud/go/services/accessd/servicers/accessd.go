@@ -23,7 +23,9 @@ import (
 )
 
 const (
-	ACCESS_TABLE = "access_control"
+	ACCESS_TABLE       = "access_control"
+	ROLE_TABLE         = "access_roles"
+	ROLE_BINDING_TABLE = "access_role_bindings"
 )
 
 type AccessControlServer struct {
@@ -80,14 +82,19 @@ func (srv *AccessControlServer) UpdateOperator(ctx context.Context, req *accessp
 	return voidVar, nil
 }
 
-// DeleteOperator Removes all operator's permissions (the entire operator's ACL)
+// DeleteOperator Removes all operator's permissions (the entire operator's ACL
+// and role bindings)
 func (srv *AccessControlServer) DeleteOperator(ctx context.Context, oper *protos.Identity) (*protos.Void, error) {
 
 	if oper == nil {
 		return &protos.Void{}, status.Errorf(codes.InvalidArgument, "Nil Operator")
 	}
 	opkey, table := getKeyTablePair(oper)
-	err := srv.store.Delete(table, opkey)
+	err := srv.store.Delete(ROLE_BINDING_TABLE, opkey)
+	if err != nil {
+		return &protos.Void{}, status.Errorf(codes.Unknown, "Operator %s Delete from table %s error: %s", opkey, ROLE_BINDING_TABLE, err)
+	}
+	err = srv.store.Delete(table, opkey)
 	if err != nil {
 		return &protos.Void{}, status.Errorf(codes.NotFound, "Operator %s Delete from table %s error: %s", opkey, table, err)
 	}
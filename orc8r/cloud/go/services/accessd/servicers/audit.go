@@ -0,0 +1,226 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// AUDIT_TABLE is the audit log table of all networks. Records of calls
+	// which are not network scoped have an empty network ID.
+	AUDIT_TABLE = "access_audit_log"
+
+	auditIDCol      = "id"
+	auditNetworkCol = "network_id"
+	auditTimeCol    = "ts"
+	auditRecordCol  = "record"
+)
+
+// AuditRetention is the time after which audit records are removed
+var AuditRetention = time.Hour * 24 * 90
+
+// DefaultAuditQueryLimit is the number of records returned by queries which
+// don't set a limit, MaxAuditQueryLimit caps the limit of all queries
+var (
+	DefaultAuditQueryLimit uint32 = 100
+	MaxAuditQueryLimit     uint32 = 1000
+)
+
+type AuditLogServer struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+func NewAuditLogServer(db *sql.DB, builder sqorc.StatementBuilder) *AuditLogServer {
+	return &AuditLogServer{db: db, builder: builder}
+}
+
+// Initialize creates the audit log table and its indexes if they don't
+// exist yet
+func (srv *AuditLogServer) Initialize() error {
+	_, err := sqorc.ExecInTx(srv.db, func(*sql.Tx) error { return nil }, func(tx *sql.Tx) (interface{}, error) {
+		_, err := srv.builder.CreateTable(AUDIT_TABLE).
+			IfNotExists().
+			Column(auditIDCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+			Column(auditNetworkCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(auditTimeCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(auditRecordCol).Type(sqorc.ColumnTypeBytes).NotNull().EndColumn().
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit log table: %s", err)
+		}
+		// Queries are always network scoped, garbage collection is not
+		_, err = srv.builder.CreateIndex("audit_nid_ts_idx").
+			IfNotExists().
+			On(AUDIT_TABLE).
+			Columns(auditNetworkCol, auditTimeCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit log network index: %s", err)
+		}
+		_, err = srv.builder.CreateIndex("audit_ts_idx").
+			IfNotExists().
+			On(AUDIT_TABLE).
+			Columns(auditTimeCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit log time index: %s", err)
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// Record adds a record to the network's audit log. Records without a
+// timestamp are stamped with the current time.
+func (srv *AuditLogServer) Record(ctx context.Context, record *accessprotos.AuditRecord) (*protos.Void, error) {
+	voidRes := &protos.Void{}
+	if record == nil {
+		return voidRes, protos.Errorf(codes.InvalidArgument, "Nil AuditRecord")
+	}
+	ts := clock.Now().UTC()
+	if record.Timestamp != nil {
+		var err error
+		ts, err = ptypes.Timestamp(record.Timestamp)
+		if err != nil {
+			return voidRes, protos.Errorf(codes.InvalidArgument, "Invalid AuditRecord timestamp: %s", err)
+		}
+	} else {
+		record = proto.Clone(record).(*accessprotos.AuditRecord)
+		record.Timestamp, _ = ptypes.TimestampProto(ts)
+	}
+	marshaledRecord, err := proto.Marshal(record)
+	if err != nil {
+		return voidRes, protos.Errorf(codes.Unknown, "AuditRecord Marshal error: %s", err)
+	}
+	_, err = srv.builder.Insert(AUDIT_TABLE).
+		Columns(auditIDCol, auditNetworkCol, auditTimeCol, auditRecordCol).
+		Values(newAuditKey(ts), record.NetworkId, ts.UnixNano(), marshaledRecord).
+		RunWith(srv.db).
+		Exec()
+	if err != nil {
+		return voidRes, protos.Errorf(codes.Unknown, "AuditRecord insert error: %s", err)
+	}
+	return voidRes, nil
+}
+
+// Query returns the network's most recent audit records logged within the
+// query's time range, oldest first. At most the query's limit of records is
+// returned, DefaultAuditQueryLimit if the query has no limit, and never more
+// than MaxAuditQueryLimit.
+func (srv *AuditLogServer) Query(ctx context.Context, query *accessprotos.AuditQuery) (*accessprotos.AuditRecords, error) {
+	res := &accessprotos.AuditRecords{}
+	if query == nil {
+		return res, protos.Errorf(codes.InvalidArgument, "Nil AuditQuery")
+	}
+	start, end := int64(0), int64(-1)
+	if query.Start != nil {
+		startTime, err := ptypes.Timestamp(query.Start)
+		if err != nil {
+			return res, protos.Errorf(codes.InvalidArgument, "Invalid AuditQuery start: %s", err)
+		}
+		start = startTime.UnixNano()
+	}
+	if query.End != nil {
+		endTime, err := ptypes.Timestamp(query.End)
+		if err != nil {
+			return res, protos.Errorf(codes.InvalidArgument, "Invalid AuditQuery end: %s", err)
+		}
+		end = endTime.UnixNano()
+	}
+
+	builder := srv.builder.Select(auditRecordCol).
+		From(AUDIT_TABLE).
+		Where(sq.And{
+			sq.Eq{auditNetworkCol: query.NetworkId},
+			sq.GtOrEq{auditTimeCol: start},
+		})
+	if end >= 0 {
+		builder = builder.Where(sq.Lt{auditTimeCol: end})
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = DefaultAuditQueryLimit
+	}
+	if limit > MaxAuditQueryLimit {
+		limit = MaxAuditQueryLimit
+	}
+	// select the most recent records and reverse them below
+	builder = builder.
+		OrderBy(fmt.Sprintf("%s DESC", auditTimeCol), fmt.Sprintf("%s DESC", auditIDCol)).
+		Limit(uint64(limit))
+	rows, err := builder.RunWith(srv.db).Query()
+	if err != nil {
+		return res, protos.Errorf(codes.Unknown, "AuditRecords query error: %s", err)
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "Query")
+
+	for rows.Next() {
+		var marshaledRecord []byte
+		if err = rows.Scan(&marshaledRecord); err != nil {
+			return res, protos.Errorf(codes.Unknown, "AuditRecord scan error: %s", err)
+		}
+		record := &accessprotos.AuditRecord{}
+		if err = proto.Unmarshal(marshaledRecord, record); err != nil {
+			return res, protos.Errorf(codes.Unknown, "AuditRecord Unmarshal error: %s", err)
+		}
+		res.Records = append(res.Records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return res, protos.Errorf(codes.Unknown, "AuditRecords query error: %s", err)
+	}
+	for i, j := 0, len(res.Records)-1; i < j; i, j = i+1, j-1 {
+		res.Records[i], res.Records[j] = res.Records[j], res.Records[i]
+	}
+	return res, nil
+}
+
+// CollectGarbage removes audit records older than AuditRetention
+func (srv *AuditLogServer) CollectGarbage() error {
+	threshold := clock.Now().Add(-AuditRetention).UnixNano()
+	res, err := srv.builder.Delete(AUDIT_TABLE).
+		Where(sq.Lt{auditTimeCol: threshold}).
+		RunWith(srv.db).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("failed to delete audit records: %s", err)
+	}
+	if removed, err := res.RowsAffected(); err == nil {
+		glog.V(2).Infof("Removed %d stale audit records", removed)
+	}
+	return nil
+}
+
+// newAuditKey returns a unique record key starting with the zero padded
+// record timestamp
+func newAuditKey(ts time.Time) string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return fmt.Sprintf("%020d-%s", ts.UnixNano(), hex.EncodeToString(suffix))
+}
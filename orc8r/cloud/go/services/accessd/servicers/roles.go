@@ -0,0 +1,386 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+// Role based access control: roles, operators' role bindings & their checks
+import (
+	"sort"
+	"strings"
+
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+// Built-in role names
+const (
+	ROLE_NETWORK_ADMIN      = "network-admin"
+	ROLE_READ_ONLY          = "read-only"
+	ROLE_SUBSCRIBER_MANAGER = "subscriber-manager"
+)
+
+// BuiltinRoles returns newly created default definitions of all built-in
+// roles:
+//
+//	network-admin: full access to everything in the bound network(s)
+//	read-only: read access to everything in the bound network(s)
+//	subscriber-manager: full access to subscribers and read access to
+//	  networks of the bound network(s)
+func BuiltinRoles() []*accessprotos.Role {
+	rw := accessprotos.AccessControl_READ | accessprotos.AccessControl_WRITE
+	return []*accessprotos.Role{
+		{
+			Name:    ROLE_NETWORK_ADMIN,
+			Rules:   []*accessprotos.Role_Rule{{Permissions: rw}},
+			Builtin: true,
+		},
+		{
+			Name:    ROLE_READ_ONLY,
+			Rules:   []*accessprotos.Role_Rule{{Permissions: accessprotos.AccessControl_READ}},
+			Builtin: true,
+		},
+		{
+			Name: ROLE_SUBSCRIBER_MANAGER,
+			Rules: []*accessprotos.Role_Rule{
+				{EntityTypes: []string{"subscribers"}, Permissions: rw},
+				{EntityTypes: []string{"networks"}, Permissions: accessprotos.AccessControl_READ},
+			},
+			Builtin: true,
+		},
+	}
+}
+
+func getBuiltinRole(name string) *accessprotos.Role {
+	for _, role := range BuiltinRoles() {
+		if role.Name == name {
+			return role
+		}
+	}
+	return nil
+}
+
+// SetRole creates or overwrites a Role
+func (srv *AccessControlServer) SetRole(ctx context.Context, role *accessprotos.Role) (*protos.Void, error) {
+	voidRes := &protos.Void{}
+	if role == nil || len(strings.TrimSpace(role.Name)) == 0 {
+		return voidRes, protos.Errorf(codes.InvalidArgument, "Invalid Role: missing name")
+	}
+	for i, rule := range role.Rules {
+		if rule == nil || rule.Permissions == accessprotos.AccessControl_NONE {
+			return voidRes, protos.Errorf(
+				codes.InvalidArgument, "Invalid Role %s rule @ index: %d", role.Name, i)
+		}
+	}
+	stored := proto.Clone(role).(*accessprotos.Role)
+	stored.Builtin = getBuiltinRole(role.Name) != nil
+	marshaledRole, err := proto.Marshal(stored)
+	if err != nil {
+		return voidRes, protos.Errorf(codes.Unknown, "Role %s Marshal error: %s", role.Name, err)
+	}
+	err = srv.store.Put(ROLE_TABLE, role.Name, marshaledRole)
+	if err != nil {
+		return voidRes, protos.Errorf(
+			codes.Unknown, "Role PUT error '%s' for %s, table %s", err, role.Name, ROLE_TABLE)
+	}
+	return voidRes, nil
+}
+
+// GetRole returns the Role with the given name
+func (srv *AccessControlServer) GetRole(ctx context.Context, req *accessprotos.RoleName) (*accessprotos.Role, error) {
+	if req == nil {
+		return &accessprotos.Role{}, protos.Errorf(codes.InvalidArgument, "Nil Role Name")
+	}
+	return srv.getRole(req.Name)
+}
+
+// ListRoles lists all stored & built-in Roles sorted by name
+func (srv *AccessControlServer) ListRoles(ctx context.Context, _ *protos.Void) (*accessprotos.Roles, error) {
+	res := &accessprotos.Roles{}
+	keys, err := srv.store.ListKeys(ROLE_TABLE)
+	if err != nil {
+		return res, protos.Errorf(codes.Unknown, "Error %s listing table %s keys", err, ROLE_TABLE)
+	}
+	roles := map[string]*accessprotos.Role{}
+	for _, role := range BuiltinRoles() {
+		roles[role.Name] = role
+	}
+	for _, key := range keys {
+		role, err := srv.getStoredRole(key)
+		if err != nil {
+			return res, err
+		}
+		roles[key] = role
+	}
+	for _, role := range roles {
+		res.Roles = append(res.Roles, role)
+	}
+	sort.Slice(res.Roles, func(i, j int) bool { return res.Roles[i].Name < res.Roles[j].Name })
+	return res, nil
+}
+
+// DeleteRole deletes a Role. Deleting an overwritten built-in role restores
+// its default definition.
+func (srv *AccessControlServer) DeleteRole(ctx context.Context, req *accessprotos.RoleName) (*protos.Void, error) {
+	voidRes := &protos.Void{}
+	if req == nil {
+		return voidRes, protos.Errorf(codes.InvalidArgument, "Nil Role Name")
+	}
+	_, err := srv.getStoredRole(req.Name)
+	if err != nil {
+		if getBuiltinRole(req.Name) != nil {
+			return voidRes, protos.Errorf(
+				codes.FailedPrecondition, "Built-in Role %s cannot be deleted", req.Name)
+		}
+		return voidRes, err
+	}
+	if getBuiltinRole(req.Name) == nil {
+		bound, err := srv.getRoleOperators(req.Name)
+		if err != nil {
+			return voidRes, err
+		}
+		if len(bound) > 0 {
+			return voidRes, protos.Errorf(
+				codes.FailedPrecondition, "Role %s is bound to Operators: %s",
+				req.Name, strings.Join(bound, ", "))
+		}
+	}
+	err = srv.store.Delete(ROLE_TABLE, req.Name)
+	if err != nil {
+		return voidRes, protos.Errorf(
+			codes.Unknown, "Role %s Delete from table %s error: %s", req.Name, ROLE_TABLE, err)
+	}
+	return voidRes, nil
+}
+
+// AddRoleBinding binds an existing Role to the Operator
+func (srv *AccessControlServer) AddRoleBinding(
+	ctx context.Context,
+	binding *accessprotos.RoleBinding,
+) (*protos.Void, error) {
+	voidRes := &protos.Void{}
+	err := verifyRoleBinding(binding)
+	if err != nil {
+		return voidRes, err
+	}
+	if _, err = srv.getRole(binding.Role); err != nil {
+		return voidRes, err
+	}
+	bindings, err := srv.getRoleBindings(binding.Operator)
+	if err != nil {
+		return voidRes, err
+	}
+	for _, b := range bindings.Bindings {
+		if b.Role == binding.Role && b.NetworkId == binding.NetworkId {
+			return voidRes, nil
+		}
+	}
+	bindings.Bindings = append(bindings.Bindings, binding)
+	return voidRes, srv.putRoleBindings(bindings)
+}
+
+// RemoveRoleBinding removes the Operator's Role binding
+func (srv *AccessControlServer) RemoveRoleBinding(
+	ctx context.Context,
+	binding *accessprotos.RoleBinding,
+) (*protos.Void, error) {
+	voidRes := &protos.Void{}
+	err := verifyRoleBinding(binding)
+	if err != nil {
+		return voidRes, err
+	}
+	bindings, err := srv.getRoleBindings(binding.Operator)
+	if err != nil {
+		return voidRes, err
+	}
+	remaining := make([]*accessprotos.RoleBinding, 0, len(bindings.Bindings))
+	for _, b := range bindings.Bindings {
+		if b.Role != binding.Role || b.NetworkId != binding.NetworkId {
+			remaining = append(remaining, b)
+		}
+	}
+	if len(remaining) == len(bindings.Bindings) {
+		return voidRes, protos.Errorf(
+			codes.NotFound, "Role %s is not bound to Operator %s in network '%s'",
+			binding.Role, binding.Operator.HashString(), binding.NetworkId)
+	}
+	bindings.Bindings = remaining
+	return voidRes, srv.putRoleBindings(bindings)
+}
+
+// GetRoleBindings returns all Role bindings of the Operator
+func (srv *AccessControlServer) GetRoleBindings(
+	ctx context.Context,
+	oper *protos.Identity,
+) (*accessprotos.RoleBindings, error) {
+	if oper == nil {
+		return &accessprotos.RoleBindings{}, protos.Errorf(codes.InvalidArgument, "Nil Operator")
+	}
+	return srv.getRoleBindings(oper)
+}
+
+// CheckRoleAccess verifies that Roles bound to the Operator in the request's
+// network (or in all networks) grant all requested permissions for the
+// request's REST route & entity type
+func (srv *AccessControlServer) CheckRoleAccess(
+	ctx context.Context,
+	req *accessprotos.RoleAccessRequest,
+) (*protos.Void, error) {
+	voidRes := &protos.Void{}
+	if req == nil || req.Operator == nil {
+		return voidRes, protos.Errorf(codes.InvalidArgument, "Nil RoleAccessRequest Operator")
+	}
+	bindings, err := srv.getRoleBindings(req.Operator)
+	if err != nil {
+		return voidRes, err
+	}
+	perm := accessprotos.AccessControl_NONE
+	for _, b := range bindings.Bindings {
+		if len(b.NetworkId) > 0 && b.NetworkId != req.NetworkId {
+			continue
+		}
+		role, err := srv.getRole(b.Role)
+		if err != nil {
+			glog.Errorf("Role %s bound to %s error: %s", b.Role, req.Operator.HashString(), err)
+			continue
+		}
+		perm |= role.Permissions(req.Path, req.EntityType)
+	}
+	if req.Permissions&perm != req.Permissions {
+		return voidRes, protos.Errorf(
+			codes.PermissionDenied,
+			"Unsatisfied role permissions, need: b%08b, got: b%08b for %s %s",
+			req.Permissions, perm, req.EntityType, req.Path)
+	}
+	return voidRes, nil
+}
+
+// getRole returns the stored Role with the given name or, if there is none,
+// the built-in Role of the name
+func (srv *AccessControlServer) getRole(name string) (*accessprotos.Role, error) {
+	role, err := srv.getStoredRole(name)
+	if err == nil {
+		return role, nil
+	}
+	if builtin := getBuiltinRole(name); builtin != nil {
+		return builtin, nil
+	}
+	return role, err
+}
+
+func (srv *AccessControlServer) getStoredRole(name string) (*accessprotos.Role, error) {
+	role := &accessprotos.Role{}
+	marshaledRole, _, err := srv.store.Get(ROLE_TABLE, name)
+	if err != nil {
+		if datastore.IsErrNotFound(err) {
+			return role, protos.Errorf(codes.NotFound, "Role %s not found", name)
+		}
+		return role, protos.Errorf(
+			codes.Unknown, "Get Role error '%s' for %s, table %s", err, name, ROLE_TABLE)
+	}
+	err = proto.Unmarshal(marshaledRole, role)
+	if err != nil {
+		return role, protos.Errorf(
+			codes.Unknown, "Role Unmarshal error '%s' for %s from table %s", err, name, ROLE_TABLE)
+	}
+	return role, nil
+}
+
+// getRoleBindings returns the Operator's Role bindings, an Operator without
+// bindings has an empty list
+func (srv *AccessControlServer) getRoleBindings(oper *protos.Identity) (*accessprotos.RoleBindings, error) {
+	opkey := oper.HashString()
+	bindings := &accessprotos.RoleBindings{Operator: oper}
+	marshaledBindings, _, err := srv.store.Get(ROLE_BINDING_TABLE, opkey)
+	if err != nil {
+		if datastore.IsErrNotFound(err) {
+			return bindings, nil
+		}
+		return bindings, protos.Errorf(
+			codes.Unknown, "Get Role Bindings error '%s' for Operator %s, table %s",
+			err, opkey, ROLE_BINDING_TABLE)
+	}
+	err = proto.Unmarshal(marshaledBindings, bindings)
+	if err != nil {
+		return bindings, protos.Errorf(
+			codes.Unknown, "Role Bindings Unmarshal error '%s' for Operator %s from table %s",
+			err, opkey, ROLE_BINDING_TABLE)
+	}
+	return bindings, nil
+}
+
+func (srv *AccessControlServer) putRoleBindings(bindings *accessprotos.RoleBindings) error {
+	opkey := bindings.Operator.HashString()
+	if len(bindings.Bindings) == 0 {
+		err := srv.store.Delete(ROLE_BINDING_TABLE, opkey)
+		if err != nil {
+			return protos.Errorf(
+				codes.Unknown, "Role Bindings Delete error '%s' for Operator %s, table %s",
+				err, opkey, ROLE_BINDING_TABLE)
+		}
+		return nil
+	}
+	marshaledBindings, err := proto.Marshal(bindings)
+	if err != nil {
+		return protos.Errorf(codes.Unknown, "Role Bindings Marshal error '%s' for Operator %s", err, opkey)
+	}
+	err = srv.store.Put(ROLE_BINDING_TABLE, opkey, marshaledBindings)
+	if err != nil {
+		return protos.Errorf(
+			codes.Unknown, "Role Bindings PUT error '%s' for Operator %s, table %s",
+			err, opkey, ROLE_BINDING_TABLE)
+	}
+	return nil
+}
+
+// getRoleOperators returns hash strings of all Operators the Role is bound to
+func (srv *AccessControlServer) getRoleOperators(name string) ([]string, error) {
+	keys, err := srv.store.ListKeys(ROLE_BINDING_TABLE)
+	if err != nil {
+		return nil, protos.Errorf(codes.Unknown, "Error %s listing table %s keys", err, ROLE_BINDING_TABLE)
+	}
+	marshaledValues, err := srv.store.GetMany(ROLE_BINDING_TABLE, keys)
+	if err != nil {
+		return nil, protos.Errorf(codes.Unknown, "Get Role Bindings error '%s', table %s", err, ROLE_BINDING_TABLE)
+	}
+	var res []string
+	for opkey, val := range marshaledValues {
+		bindings := &accessprotos.RoleBindings{}
+		if err = proto.Unmarshal(val.Value, bindings); err != nil {
+			return nil, protos.Errorf(
+				codes.Unknown, "Role Bindings Unmarshal error '%s' for Operator %s from table %s",
+				err, opkey, ROLE_BINDING_TABLE)
+		}
+		for _, b := range bindings.Bindings {
+			if b.Role == name {
+				res = append(res, opkey)
+				break
+			}
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func verifyRoleBinding(binding *accessprotos.RoleBinding) error {
+	if binding == nil {
+		return protos.Errorf(codes.InvalidArgument, "Nil RoleBinding")
+	}
+	if binding.Operator == nil {
+		return protos.Errorf(codes.InvalidArgument, "Nil RoleBinding Operator")
+	}
+	if len(binding.Role) == 0 {
+		return protos.Errorf(codes.InvalidArgument, "Missing RoleBinding Role")
+	}
+	return nil
+}
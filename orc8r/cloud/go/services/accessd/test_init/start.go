@@ -15,7 +15,10 @@ import (
 	"magma/orc8r/cloud/go/services/accessd"
 	"magma/orc8r/cloud/go/services/accessd/protos"
 	"magma/orc8r/cloud/go/services/accessd/servicers"
+	"magma/orc8r/cloud/go/sqorc"
 	"magma/orc8r/cloud/go/test_utils"
+
+	_ "github.com/mattn/go-sqlite3"
 )
 
 func StartTestService(t *testing.T) {
@@ -23,5 +26,14 @@ func StartTestService(t *testing.T) {
 	protos.RegisterAccessControlManagerServer(
 		srv.GrpcServer,
 		servicers.NewAccessdServer(test_utils.GetMockDatastoreInstance()))
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	auditServer := servicers.NewAuditLogServer(db, sqorc.GetSqlBuilder())
+	if err = auditServer.Initialize(); err != nil {
+		t.Fatalf("Could not initialize audit log: %s", err)
+	}
+	protos.RegisterAuditLogServer(srv.GrpcServer, auditServer)
 	go srv.GrpcServer.Serve(lis)
 }
@@ -8,4 +8,10 @@ LICENSE file in the root directory of this source tree.
 
 package bootstrapper
 
-const ServiceName = "BOOTSTRAPPER"
+const (
+	ServiceName = "BOOTSTRAPPER"
+
+	// DBTableName is the name of the sql table which keeps the failed
+	// challenge counts shared by all bootstrapper instances
+	DBTableName = "bootstrapper_challenge_failures"
+)
@@ -13,12 +13,15 @@ import (
 	"flag"
 	"log"
 
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/security/key"
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/services/bootstrapper"
 	"magma/orc8r/cloud/go/services/bootstrapper/servicers"
+	"magma/orc8r/cloud/go/sqorc"
 )
 
 var (
@@ -37,7 +40,15 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to read private key: %s", err)
 	}
-	servicer, err := servicers.NewBootstrapperServer(privKey.(*rsa.PrivateKey))
+	db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %s", err)
+	}
+	store := blobstore.NewSQLBlobStorageFactory(bootstrapper.DBTableName, db, sqorc.GetSqlBuilder())
+	if err = store.InitializeFactory(); err != nil {
+		log.Fatalf("Error initializing bootstrapper database: %s", err)
+	}
+	servicer, err := servicers.NewBootstrapperServer(privKey.(*rsa.PrivateKey), store)
 	if err != nil {
 		log.Fatalf("Failed to create bootstrapper servicer: %s", err)
 	}
@@ -12,24 +12,35 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
 	"encoding/binary"
 	"fmt"
 	"log"
 	"math/big"
+	"strings"
 	"time"
 
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/orc8r"
 	models2 "magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/protos"
+	security_cert "magma/orc8r/cloud/go/security/cert"
 	"magma/orc8r/cloud/go/services/certifier"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/cloud/go/services/device"
+	"magma/orc8r/cloud/go/storage"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -41,12 +52,80 @@ const TimeLength = 8 // length of time encoded in byte array from int64
 const MinKeyLength = 1024
 const GatewayCertificateDuration = time.Hour * 97 // 4 days, lifetime of GW Certificate
 
+// MaxFailedChallenges is the number of consecutive failed challenges within
+// ChallengeFailureWindow after which a hardware ID is locked out of
+// bootstrapping for ChallengeLockout
+var MaxFailedChallenges = 5
+
+// ChallengeFailureWindow is the duration over which failed challenges are
+// counted, failures older than the window are forgotten
+var ChallengeFailureWindow = time.Hour
+
+// ChallengeLockout is the duration of a hardware ID's bootstrap lockout
+var ChallengeLockout = time.Minute * 15
+
+// Bootstrap attempt results, used as the bootstrap_attempts metric label
+const (
+	attemptSuccess         = "success"
+	attemptFailedChallenge = "failed_challenge"
+	attemptLockedOut       = "locked_out"
+	attemptError           = "error"
+)
+
+var (
+	bootstrapAttempts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bootstrap_attempts",
+			Help: "Number of gateway bootstrap (sign request) attempts by result",
+		},
+		[]string{"result"},
+	)
+	bootstrapLockouts = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "bootstrap_lockouts",
+			Help: "Number of hardware ID lockouts due to repeated failed challenges",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(bootstrapAttempts, bootstrapLockouts)
+}
+
+// Failed challenges are tracked in blobs of challengeFailuresType keyed by
+// hardware ID. A blob's value holds the number of consecutive failed
+// challenges, the start of the window they were counted in and the end of
+// the lockout, if any.
+const (
+	challengeFailuresNetworkID = "bootstrapper"
+	challengeFailuresType      = "challenge_failures"
+)
+
 type BootstrapperServer struct {
 	privKey *rsa.PrivateKey
+
+	// store tracks consecutive failed challenges & lockouts by hardware ID,
+	// it's shared by all bootstrapper instances
+	store blobstore.BlobStorageFactory
+}
+
+// challengeKey is one of the gateway's active challenge keys
+type challengeKey struct {
+	keyType protos.ChallengeKey_KeyType
+	key     []byte
+}
+
+// gatewayChallengeKeys is the gateway's device record & its active keys
+type gatewayChallengeKeys struct {
+	networkID string
+	record    *models2.GatewayDevice
+	// keys starts with the current key, followed by the previous key while
+	// the key rotation is in progress
+	keys []challengeKey
 }
 
-func NewBootstrapperServer(privKey *rsa.PrivateKey) (*BootstrapperServer, error) {
-	srv := new(BootstrapperServer)
+func NewBootstrapperServer(privKey *rsa.PrivateKey, store blobstore.BlobStorageFactory) (*BootstrapperServer, error) {
+	srv := &BootstrapperServer{store: store}
 	if privKey.N.BitLen() < MinKeyLength {
 		return nil, errorLogger(fmt.Errorf("Private key is too short"))
 	}
@@ -56,19 +135,17 @@ func NewBootstrapperServer(privKey *rsa.PrivateKey) (*BootstrapperServer, error)
 
 // generate challenge in the format of [randomText : timestamp : signature]
 // the format is designed mainly for demo/interface design, subjects to change in the future
+// The challenge's key type is the one of the gateway's current key.
 func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.AccessGatewayID) (*protos.Challenge, error) {
-	var keyType protos.ChallengeKey_KeyType
-
-	// case based on the env variable whether to use magmad or configurator
-	var err error
-	keyType, _, err = getChallengeKey(hwId.Id)
+	if err := srv.checkLockout(hwId.GetId()); err != nil {
+		return nil, errorLogger(err)
+	}
+	gwKeys, err := getChallengeKeys(hwId.GetId())
 	if err != nil {
 		return nil, err
 	}
-
-	if keyType != protos.ChallengeKey_ECHO &&
-		keyType != protos.ChallengeKey_SOFTWARE_RSA_SHA256 &&
-		keyType != protos.ChallengeKey_SOFTWARE_ECDSA_SHA256 {
+	keyType := gwKeys.keys[0].keyType
+	if !isSupportedKeyType(keyType) {
 		return nil, errorLogger(status.Errorf(codes.Aborted, "Unsupported key type: %s", keyType))
 	}
 
@@ -98,49 +175,309 @@ func (srv *BootstrapperServer) GetChallenge(ctx context.Context, hwId *protos.Ac
 func (srv *BootstrapperServer) RequestSign(
 	ctx context.Context, resp *protos.Response) (*protos.Certificate, error) {
 
-	hwId := resp.HwId.Id
-	keyType, key, err := getChallengeKey(hwId)
+	hwId := resp.GetHwId().GetId()
+	if err := srv.checkLockout(hwId); err != nil {
+		bootstrapAttempts.WithLabelValues(attemptLockedOut).Inc()
+		return nil, errorLogger(err)
+	}
+	gwKeys, err := getChallengeKeys(hwId)
 	if err != nil {
+		bootstrapAttempts.WithLabelValues(attemptError).Inc()
 		return nil, err
 	}
 
 	err = srv.verifyChallenge(resp.Challenge)
 	if err != nil {
+		srv.recordChallengeFailure(hwId)
 		return nil, errorLogger(status.Errorf(
 			codes.Aborted, "Failed to verify challenge: %s", err))
 	}
 
-	// verify authentication / real response
-	switch keyType {
-	case protos.ChallengeKey_ECHO:
-		err = verifyEcho(resp)
-	case protos.ChallengeKey_SOFTWARE_RSA_SHA256:
-		err = verifySoftwareRSASHA256(resp, key)
-	case protos.ChallengeKey_SOFTWARE_ECDSA_SHA256:
-		err = verifySoftwareECDSASHA256(resp, key)
-	default:
-		err = fmt.Errorf("Unsupported key type: %s", keyType)
-	}
+	// verify authentication / real response with any of the active keys
+	keyIdx, err := verifyResponse(resp, gwKeys.keys)
 	if err != nil {
+		srv.recordChallengeFailure(hwId)
 		return nil, errorLogger(status.Errorf(
 			codes.Aborted, "Failed to verify response: %s", err))
 	}
-
-	// Ignore requested cert duration & overwrite it with our own if it's
-	// longer than our default duration (allow shorter-lived certs)
-	if resp.Csr != nil {
-		reqValidDuration, err := ptypes.Duration(resp.Csr.ValidTime)
-		if err != nil || reqValidDuration.Nanoseconds() > GatewayCertificateDuration.Nanoseconds() {
-			resp.Csr.ValidTime = ptypes.DurationProto(GatewayCertificateDuration)
-		}
+	srv.recordChallengeSuccess(hwId)
+	if keyIdx == 0 && gwKeys.record.PreviousKey != nil {
+		completeKeyRotation(hwId, gwKeys)
 	}
+
+	limitCertificateDuration(resp.Csr)
 	cert, err := certifier.SignCSR(resp.Csr)
 	if err != nil {
+		bootstrapAttempts.WithLabelValues(attemptError).Inc()
 		return nil, errorLogger(status.Errorf(codes.Aborted, "Failed to sign csr: %s", err))
 	}
+	bootstrapAttempts.WithLabelValues(attemptSuccess).Inc()
+	return cert, nil
+}
+
+// checkLockout returns an error if the hardware ID is locked out due to
+// repeated failed challenges
+func (srv *BootstrapperServer) checkLockout(hwId string) error {
+	store, err := srv.store.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to check lockout of hwid %s: %s", hwId, err)
+	}
+	blob, err := store.Get(challengeFailuresNetworkID, storage.TypeAndKey{Type: challengeFailuresType, Key: hwId})
+	store.Rollback()
+	if err == merrors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to check lockout of hwid %s: %s", hwId, err)
+	}
+	lockedUntil := decodeChallengeFailures(blob.Value).lockedUntil
+	if lockedUntil.IsZero() {
+		return nil
+	}
+	now := clock.Now()
+	if now.Before(lockedUntil) {
+		return status.Errorf(
+			codes.ResourceExhausted, "Too many failed challenges for hwid %s, retry in %s",
+			hwId, lockedUntil.Sub(now).Round(time.Second))
+	}
+	// lockout expired, start over
+	srv.resetChallengeFailures(hwId)
+	return nil
+}
+
+// recordChallengeFailure counts the hardware ID's failed challenge & locks
+// it out once MaxFailedChallenges consecutive failures are reached within
+// ChallengeFailureWindow
+func (srv *BootstrapperServer) recordChallengeFailure(hwId string) {
+	bootstrapAttempts.WithLabelValues(attemptFailedChallenge).Inc()
+	tk := storage.TypeAndKey{Type: challengeFailuresType, Key: hwId}
+	store, err := srv.store.StartTransaction(nil)
+	if err != nil {
+		errorLogger(fmt.Errorf("Failed to record failed challenge of hwid %s: %s", hwId, err))
+		return
+	}
+	// the increment locks the hardware ID's row until the transaction ends
+	err = store.IncrementVersion(challengeFailuresNetworkID, tk)
+	if err != nil {
+		store.Rollback()
+		errorLogger(fmt.Errorf("Failed to record failed challenge of hwid %s: %s", hwId, err))
+		return
+	}
+	blob, err := store.Get(challengeFailuresNetworkID, tk)
+	if err != nil {
+		store.Rollback()
+		errorLogger(fmt.Errorf("Failed to record failed challenge of hwid %s: %s", hwId, err))
+		return
+	}
+	now := clock.Now()
+	failures := decodeChallengeFailures(blob.Value)
+	if now.Sub(failures.windowStart) >= ChallengeFailureWindow {
+		failures = challengeFailures{windowStart: now}
+	}
+	failures.count++
+	lockout := failures.count >= uint64(MaxFailedChallenges)
+	if lockout {
+		failures.lockedUntil = now.Add(ChallengeLockout)
+	}
+	blob.Value = encodeChallengeFailures(failures)
+	if err = store.CreateOrUpdate(challengeFailuresNetworkID, []blobstore.Blob{blob}); err != nil {
+		store.Rollback()
+		errorLogger(fmt.Errorf("Failed to record failed challenge of hwid %s: %s", hwId, err))
+		return
+	}
+	if err = store.Commit(); err != nil {
+		errorLogger(fmt.Errorf("Failed to record failed challenge of hwid %s: %s", hwId, err))
+		return
+	}
+	if lockout {
+		bootstrapLockouts.Inc()
+		log.Printf("Locking out hwid %s for %s after %d failed challenges", hwId, ChallengeLockout, failures.count)
+	}
+}
+
+func (srv *BootstrapperServer) recordChallengeSuccess(hwId string) {
+	srv.resetChallengeFailures(hwId)
+}
+
+func (srv *BootstrapperServer) resetChallengeFailures(hwId string) {
+	store, err := srv.store.StartTransaction(nil)
+	if err != nil {
+		errorLogger(fmt.Errorf("Failed to reset failed challenges of hwid %s: %s", hwId, err))
+		return
+	}
+	err = store.Delete(challengeFailuresNetworkID, []storage.TypeAndKey{{Type: challengeFailuresType, Key: hwId}})
+	if err != nil {
+		store.Rollback()
+		errorLogger(fmt.Errorf("Failed to reset failed challenges of hwid %s: %s", hwId, err))
+		return
+	}
+	if err = store.Commit(); err != nil {
+		errorLogger(fmt.Errorf("Failed to reset failed challenges of hwid %s: %s", hwId, err))
+	}
+}
+
+// challengeFailures are the failed challenges of a hardware ID. lockedUntil
+// is zero if the hardware ID isn't locked out.
+type challengeFailures struct {
+	count       uint64
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+func encodeChallengeFailures(failures challengeFailures) []byte {
+	ret := make([]byte, 3*TimeLength)
+	binary.BigEndian.PutUint64(ret, failures.count)
+	binary.BigEndian.PutUint64(ret[TimeLength:], encodeTime(failures.windowStart))
+	binary.BigEndian.PutUint64(ret[2*TimeLength:], encodeTime(failures.lockedUntil))
+	return ret
+}
+
+// decodeChallengeFailures decodes the failures, values which can't be
+// decoded count as no failures. Lockouts recorded before failures were
+// windowed only hold the end of the lockout.
+func decodeChallengeFailures(value []byte) challengeFailures {
+	switch len(value) {
+	case TimeLength:
+		return challengeFailures{lockedUntil: decodeTime(binary.BigEndian.Uint64(value))}
+	case 3 * TimeLength:
+		return challengeFailures{
+			count:       binary.BigEndian.Uint64(value),
+			windowStart: decodeTime(binary.BigEndian.Uint64(value[TimeLength:])),
+			lockedUntil: decodeTime(binary.BigEndian.Uint64(value[2*TimeLength:])),
+		}
+	default:
+		return challengeFailures{}
+	}
+}
+
+func encodeTime(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+func decodeTime(nanos uint64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nanos))
+}
+
+// completeKeyRotation removes the gateway's previous challenge key once the
+// gateway has proven possession of its current key. The record is only
+// updated if it wasn't modified since the keys were loaded, so that a
+// rotation started in the meantime isn't overwritten.
+func completeKeyRotation(hwId string, gwKeys *gatewayChallengeKeys) {
+	record := *gwKeys.record
+	record.PreviousKey = nil
+	err := device.CompareAndSwapDevice(gwKeys.networkID, orc8r.AccessGatewayRecordType, hwId, gwKeys.record, &record)
+	if err == device.ErrModified {
+		log.Printf("Not completing challenge key rotation for hwid %s, its record was modified concurrently", hwId)
+		return
+	}
+	if err != nil {
+		errorLogger(fmt.Errorf("Failed to remove previous challenge key of hwid %s: %s", hwId, err))
+		return
+	}
+	log.Printf("Completed challenge key rotation for hwid %s", hwId)
+}
+
+// RenewCertificate signs a new certificate for a gateway which still holds
+// a valid certificate, without using its challenge key. The gateway proves
+// possession of its current certificate's key by signing a challenge
+// returned by GetChallenge together with the CSR. The certificate must
+// belong to a gateway that is still registered.
+func (srv *BootstrapperServer) RenewCertificate(
+	ctx context.Context, req *protos.RenewRequest) (*protos.Certificate, error) {
+
+	if req == nil || req.Csr == nil || len(req.CertDer) == 0 || len(req.Signature) == 0 {
+		return nil, errorLogger(status.Errorf(codes.InvalidArgument, "Invalid certificate renewal request"))
+	}
+	err := srv.verifyChallenge(req.Challenge)
+	if err != nil {
+		return nil, errorLogger(status.Errorf(
+			codes.Aborted, "Failed to verify challenge: %s", err))
+	}
+	sn, err := verifyRenewedCertificate(req)
+	if err != nil {
+		return nil, errorLogger(status.Errorf(
+			codes.PermissionDenied, "Failed to verify client certificate: %s", err))
+	}
+	certInfo, err := certifier.GetIdentity(&protos.Certificate_SN{Sn: sn})
+	if err != nil {
+		return nil, errorLogger(status.Errorf(
+			codes.PermissionDenied, "Failed to verify client certificate: %s", err))
+	}
+	gw := certInfo.Id.GetGateway()
+	if gw == nil {
+		return nil, errorLogger(status.Errorf(
+			codes.PermissionDenied, "Client certificate does not belong to a gateway"))
+	}
+	_, err = configurator.LoadEntityForPhysicalID(gw.HardwareId, configurator.EntityLoadCriteria{})
+	if err != nil {
+		return nil, errorLogger(status.Errorf(
+			codes.NotFound, "Gateway with hwid %s is not registered: %s", gw.HardwareId, err))
+	}
+
+	limitCertificateDuration(req.Csr)
+	cert, err := certifier.RenewCertificate(&protos.Certificate_SN{Sn: sn}, req.Csr)
+	if err != nil {
+		return nil, errorLogger(status.Errorf(codes.Aborted, "Failed to renew certificate: %s", err))
+	}
 	return cert, nil
 }
 
+// Ignore requested cert duration & overwrite it with our own if it's
+// longer than our default duration (allow shorter-lived certs)
+func limitCertificateDuration(csr *protos.CSR) {
+	if csr == nil {
+		return
+	}
+	reqValidDuration, err := ptypes.Duration(csr.ValidTime)
+	if err != nil || reqValidDuration.Nanoseconds() > GatewayCertificateDuration.Nanoseconds() {
+		csr.ValidTime = ptypes.DurationProto(GatewayCertificateDuration)
+	}
+}
+
+// verifyRenewedCertificate verifies that the renewal request's certificate
+// was issued by the certifier's CA & that the request is signed with the
+// certificate's key. Returns the certificate's serial number.
+func verifyRenewedCertificate(req *protos.RenewRequest) (string, error) {
+	cert, err := x509.ParseCertificate(req.CertDer)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate: %s", err)
+	}
+	caMsg, err := certifier.GetCACert(&certprotos.GetCARequest{CertType: req.Csr.CertType})
+	if err != nil {
+		return "", fmt.Errorf("failed to get CA certificate: %s", err)
+	}
+	ca, err := x509.ParseCertificate(caMsg.Cert)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse CA certificate: %s", err)
+	}
+	if err = cert.CheckSignatureFrom(ca); err != nil {
+		return "", fmt.Errorf("certificate is not signed by the CA: %s", err)
+	}
+
+	var algo x509.SignatureAlgorithm
+	switch cert.PublicKeyAlgorithm {
+	case x509.RSA:
+		algo = x509.SHA256WithRSA
+	case x509.ECDSA:
+		algo = x509.ECDSAWithSHA256
+	case x509.Ed25519:
+		algo = x509.PureEd25519
+	default:
+		return "", fmt.Errorf("unsupported certificate key algorithm %s", cert.PublicKeyAlgorithm)
+	}
+	signed := append(append([]byte{}, req.Challenge...), req.Csr.CsrDer...)
+	if err = cert.CheckSignature(algo, signed, req.Signature); err != nil {
+		return "", fmt.Errorf("invalid signature: %s", err)
+	}
+	return security_cert.SerialToString(cert.SerialNumber), nil
+}
+
 // return the length of signature (number of bytes)
 func (srv *BootstrapperServer) signatureLength() int {
 	keyLength := srv.privKey.N.BitLen()
@@ -202,6 +539,46 @@ func generateRandomText(length int) ([]byte, error) {
 	return randText, nil
 }
 
+func isSupportedKeyType(keyType protos.ChallengeKey_KeyType) bool {
+	switch keyType {
+	case protos.ChallengeKey_ECHO,
+		protos.ChallengeKey_SOFTWARE_RSA_SHA256,
+		protos.ChallengeKey_SOFTWARE_ECDSA_SHA256,
+		protos.ChallengeKey_SOFTWARE_ECDSA_SHA384,
+		protos.ChallengeKey_SOFTWARE_ED25519:
+		return true
+	}
+	return false
+}
+
+// verifyResponse verifies the response with each of the keys and returns the
+// index of the first key the response is verified with
+func verifyResponse(resp *protos.Response, keys []challengeKey) (int, error) {
+	var errs []string
+	for idx, key := range keys {
+		var err error
+		switch key.keyType {
+		case protos.ChallengeKey_ECHO:
+			err = verifyEcho(resp)
+		case protos.ChallengeKey_SOFTWARE_RSA_SHA256:
+			err = verifySoftwareRSASHA256(resp, key.key)
+		case protos.ChallengeKey_SOFTWARE_ECDSA_SHA256:
+			err = verifySoftwareECDSASHA256(resp, key.key)
+		case protos.ChallengeKey_SOFTWARE_ECDSA_SHA384:
+			err = verifySoftwareECDSASHA384(resp, key.key)
+		case protos.ChallengeKey_SOFTWARE_ED25519:
+			err = verifySoftwareEd25519(resp, key.key)
+		default:
+			err = fmt.Errorf("Unsupported key type: %s", key.keyType)
+		}
+		if err == nil {
+			return idx, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return -1, fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
 // verify response with echo "encryption" method
 func verifyEcho(resp *protos.Response) error {
 	response := resp.GetEchoResponse() //.Response
@@ -254,30 +631,95 @@ func verifySoftwareECDSASHA256(resp *protos.Response, key []byte) error {
 	return nil
 }
 
-func getChallengeKey(hwID string) (protos.ChallengeKey_KeyType, []byte, error) {
-	var empty protos.ChallengeKey_KeyType
+// verify response with ecdsa P-384 signature and sha384 hash
+func verifySoftwareECDSASHA384(resp *protos.Response, key []byte) error {
+	publicKey, err := x509.ParsePKIXPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("Failed to parse ECDSA public key %s", err)
+	}
+	ecdsaKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok || ecdsaKey.Curve != elliptic.P384() {
+		return fmt.Errorf("Key is not an ECDSA P-384 public key")
+	}
+
+	response := resp.GetEcdsaResponse()
+	if response == nil {
+		return fmt.Errorf("Wrong type of response, expected ECDSA")
+	}
+
+	var r, s big.Int
+	r.SetBytes(response.R)
+	s.SetBytes(response.S)
+	hashed := sha512.Sum384(resp.Challenge)
+	if !ecdsa.Verify(ecdsaKey, hashed[:], &r, &s) {
+		return fmt.Errorf("Wrong response")
+	}
+	return nil
+}
+
+// verify response with ed25519 signature of the challenge
+func verifySoftwareEd25519(resp *protos.Response, key []byte) error {
+	publicKey, err := x509.ParsePKIXPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Ed25519 public key %s", err)
+	}
+	ed25519Key, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("Key is not an Ed25519 public key")
+	}
+
+	response := resp.GetEd25519Response()
+	if response == nil {
+		return fmt.Errorf("Wrong type of response, expected Ed25519")
+	}
+	if !ed25519.Verify(ed25519Key, resp.Challenge, response.Signature) {
+		return fmt.Errorf("Wrong response")
+	}
+	return nil
+}
+
+// getChallengeKeys returns the gateway's device record & its active
+// challenge keys
+func getChallengeKeys(hwID string) (*gatewayChallengeKeys, error) {
 	entity, err := configurator.LoadEntityForPhysicalID(hwID, configurator.EntityLoadCriteria{})
 	if err != nil {
-		return empty, nil, errorLogger(status.Errorf(codes.NotFound, "Gateway with hwid %s is not registered: %s", hwID, err))
+		return nil, errorLogger(status.Errorf(codes.NotFound, "Gateway with hwid %s is not registered: %s", hwID, err))
 	}
 	iRecord, err := device.GetDevice(entity.NetworkID, orc8r.AccessGatewayRecordType, hwID)
 	if err != nil {
-		return empty, nil, errorLogger(status.Errorf(codes.NotFound, "Failed to find gateway record: %s", err))
+		return nil, errorLogger(status.Errorf(codes.NotFound, "Failed to find gateway record: %s", err))
 	}
 	record, ok := iRecord.(*models2.GatewayDevice)
-	if !ok {
-		return empty, nil, errorLogger(status.Errorf(codes.NotFound, "Failed to find gateway record"))
+	if !ok || record.Key == nil {
+		return nil, errorLogger(status.Errorf(codes.NotFound, "Failed to find gateway record"))
+	}
+
+	currentKey, err := toChallengeKey(record.Key)
+	if err != nil {
+		return nil, errorLogger(status.Error(codes.Aborted, err.Error()))
 	}
+	ret := &gatewayChallengeKeys{networkID: entity.NetworkID, record: record, keys: []challengeKey{currentKey}}
+	if record.PreviousKey != nil {
+		previousKey, err := toChallengeKey(record.PreviousKey)
+		if err != nil {
+			errorLogger(fmt.Errorf("Ignoring previous challenge key of hwid %s: %s", hwID, err))
+		} else {
+			ret.keys = append(ret.keys, previousKey)
+		}
+	}
+	return ret, nil
+}
 
-	var key []byte
-	keyType, ok := protos.ChallengeKey_KeyType_value[record.Key.KeyType]
+func toChallengeKey(model *models2.ChallengeKey) (challengeKey, error) {
+	keyType, ok := protos.ChallengeKey_KeyType_value[model.KeyType]
 	if !ok {
-		return empty, nil, errorLogger(status.Errorf(codes.Aborted, "Unsupported key type: %v", keyType))
+		return challengeKey{}, fmt.Errorf("Unsupported key type: %v", model.KeyType)
 	}
-	if record.Key.Key != nil {
-		key = *record.Key.Key
+	ret := challengeKey{keyType: protos.ChallengeKey_KeyType(keyType)}
+	if model.Key != nil {
+		ret.key = *model.Key
 	}
-	return protos.ChallengeKey_KeyType(keyType), key, nil
+	return ret, nil
 }
 
 func errorLogger(err error) error {
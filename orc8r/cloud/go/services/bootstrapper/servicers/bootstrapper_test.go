@@ -11,19 +11,27 @@ package servicers_test
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"testing"
 	"time"
 
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/security/key"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/bootstrapper/servicers"
+	"magma/orc8r/cloud/go/services/certifier"
 	certifierTestInit "magma/orc8r/cloud/go/services/certifier/test_init"
 	certifierTestUtils "magma/orc8r/cloud/go/services/certifier/test_utils"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -33,15 +41,20 @@ import (
 	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	echoType  = "ECHO"
-	rsaType   = "SOFTWARE_RSA_SHA256"
-	ecdsaType = "SOFTWARE_ECDSA_SHA256"
+	echoType      = "ECHO"
+	rsaType       = "SOFTWARE_RSA_SHA256"
+	ecdsaType     = "SOFTWARE_ECDSA_SHA256"
+	ecdsaP384Type = "SOFTWARE_ECDSA_SHA384"
+	ed25519Type   = "SOFTWARE_ED25519"
 )
 
 func testWithECHO(
@@ -178,6 +191,239 @@ func testWithECDSA(
 	assert.NotNil(t, cert)
 }
 
+func testWithECDSAP384(
+	t *testing.T, networkId string, srv *servicers.BootstrapperServer, ctx context.Context) {
+
+	testAgHwId := "test_ag_ecdsa_p384"
+	privateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	registerGatewayWithKey(t, networkId, testAgHwId, ecdsaP384Type, &privateKey.PublicKey)
+
+	challenge, err := srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: testAgHwId})
+	assert.NoError(t, err)
+	assert.Equal(t, protos.ChallengeKey_SOFTWARE_ECDSA_SHA384, challenge.KeyType)
+
+	cert, err := srv.RequestSign(ctx, createECDSAP384Response(t, testAgHwId, challenge, privateKey))
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+
+	// P-384 key type requires SHA-384 hash
+	hashed := sha256.Sum256(challenge.Challenge)
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hashed[:])
+	assert.NoError(t, err)
+	resp := createResponse(t, testAgHwId, challenge)
+	resp.Response = &protos.Response_EcdsaResponse{
+		EcdsaResponse: &protos.Response_ECDSA{R: r.Bytes(), S: s.Bytes()},
+	}
+	_, err = srv.RequestSign(ctx, resp)
+	assert.Error(t, err)
+}
+
+func testWithEd25519(
+	t *testing.T, networkId string, srv *servicers.BootstrapperServer, ctx context.Context) {
+
+	testAgHwId := "test_ag_ed25519"
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	registerGatewayWithKey(t, networkId, testAgHwId, ed25519Type, publicKey)
+
+	challenge, err := srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: testAgHwId})
+	assert.NoError(t, err)
+	assert.Equal(t, protos.ChallengeKey_SOFTWARE_ED25519, challenge.KeyType)
+
+	cert, err := srv.RequestSign(ctx, createEd25519Response(t, testAgHwId, challenge, privateKey))
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+
+	// signature by another key
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	_, err = srv.RequestSign(ctx, createEd25519Response(t, testAgHwId, challenge, otherKey))
+	assert.Error(t, err)
+}
+
+func testKeyRotation(
+	t *testing.T, networkId string, srv *servicers.BootstrapperServer, ctx context.Context) {
+
+	testAgHwId := "test_ag_key_rotation"
+	oldKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	newPublicKey, newKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	oldRecord := registerGatewayWithKey(t, networkId, testAgHwId, ecdsaP384Type, &oldKey.PublicKey)
+
+	// rotate to the new key, keeping the old one as the previous key
+	rotatedRecord := &models.GatewayDevice{
+		HardwareID:  testAgHwId,
+		Key:         newChallengeKey(t, ed25519Type, newPublicKey),
+		PreviousKey: oldRecord.Key,
+	}
+	assert.NoError(t, device.UpdateDevice(networkId, orc8r.AccessGatewayRecordType, testAgHwId, rotatedRecord))
+
+	// challenge is issued for the new key, but both keys are accepted
+	challenge, err := srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: testAgHwId})
+	assert.NoError(t, err)
+	assert.Equal(t, protos.ChallengeKey_SOFTWARE_ED25519, challenge.KeyType)
+	_, err = srv.RequestSign(ctx, createECDSAP384Response(t, testAgHwId, challenge, oldKey))
+	assert.NoError(t, err)
+	record, err := device.GetDevice(networkId, orc8r.AccessGatewayRecordType, testAgHwId)
+	assert.NoError(t, err)
+	assert.Equal(t, rotatedRecord, record)
+
+	// bootstrapping with the new key completes the rotation
+	_, err = srv.RequestSign(ctx, createEd25519Response(t, testAgHwId, challenge, newKey))
+	assert.NoError(t, err)
+	record, err = device.GetDevice(networkId, orc8r.AccessGatewayRecordType, testAgHwId)
+	assert.NoError(t, err)
+	assert.Nil(t, record.(*models.GatewayDevice).PreviousKey)
+	assert.Equal(t, rotatedRecord.Key, record.(*models.GatewayDevice).Key)
+
+	// the old key is not accepted anymore
+	_, err = srv.RequestSign(ctx, createECDSAP384Response(t, testAgHwId, challenge, oldKey))
+	assert.Error(t, err)
+}
+
+func testLockout(
+	t *testing.T, networkId string, srv *servicers.BootstrapperServer, ctx context.Context) {
+
+	testAgHwId := "test_ag_lockout"
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	registerGatewayWithKey(t, networkId, testAgHwId, ed25519Type, publicKey)
+
+	now := time.Now()
+	clock.SetAndFreezeClock(t, now)
+	defer clock.UnfreezeClock(t)
+
+	challenge, err := srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: testAgHwId})
+	assert.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	badResp := createEd25519Response(t, testAgHwId, challenge, otherKey)
+	for i := 0; i < servicers.MaxFailedChallenges; i++ {
+		_, err = srv.RequestSign(ctx, badResp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	}
+
+	// locked out, even with a valid response
+	goodResp := createEd25519Response(t, testAgHwId, challenge, privateKey)
+	_, err = srv.RequestSign(ctx, goodResp)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: testAgHwId})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// lockout expires
+	clock.SetAndFreezeClock(t, now.Add(servicers.ChallengeLockout))
+	_, err = srv.RequestSign(ctx, goodResp)
+	assert.NoError(t, err)
+
+	// a success resets the failure count
+	for i := 0; i < servicers.MaxFailedChallenges-1; i++ {
+		_, err = srv.RequestSign(ctx, badResp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	}
+	_, err = srv.RequestSign(ctx, goodResp)
+	assert.NoError(t, err)
+	_, err = srv.RequestSign(ctx, badResp)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	// failures older than the window are forgotten
+	_, err = srv.RequestSign(ctx, goodResp)
+	assert.NoError(t, err)
+	for i := 0; i < servicers.MaxFailedChallenges-1; i++ {
+		_, err = srv.RequestSign(ctx, badResp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	}
+	clock.SetAndFreezeClock(t, now.Add(servicers.ChallengeLockout+servicers.ChallengeFailureWindow))
+	for i := 0; i < servicers.MaxFailedChallenges-1; i++ {
+		_, err = srv.RequestSign(ctx, badResp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	}
+	_, err = srv.RequestSign(ctx, goodResp)
+	assert.NoError(t, err)
+}
+
+func testSharedLockout(
+	t *testing.T, networkId string, srv, otherSrv *servicers.BootstrapperServer, ctx context.Context) {
+
+	testAgHwId := "test_ag_shared_lockout"
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	registerGatewayWithKey(t, networkId, testAgHwId, ed25519Type, publicKey)
+
+	challenge, err := srv.GetChallenge(ctx, &protos.AccessGatewayID{Id: testAgHwId})
+	assert.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	badResp := createEd25519Response(t, testAgHwId, challenge, otherKey)
+
+	// failures are counted across instances
+	for i := 0; i < servicers.MaxFailedChallenges; i++ {
+		server := srv
+		if i%2 == 1 {
+			server = otherSrv
+		}
+		_, err = server.RequestSign(ctx, badResp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	}
+	goodResp := createEd25519Response(t, testAgHwId, challenge, privateKey)
+	for _, server := range []*servicers.BootstrapperServer{srv, otherSrv} {
+		_, err = server.RequestSign(ctx, goodResp)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	}
+}
+
+func newChallengeKey(t *testing.T, keyType string, publicKey interface{}) *models.ChallengeKey {
+	marshaledPubKey, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+	pubKey := strfmt.Base64(marshaledPubKey)
+	return &models.ChallengeKey{KeyType: keyType, Key: &pubKey}
+}
+
+func registerGatewayWithKey(
+	t *testing.T, networkId string, hwId string, keyType string, publicKey interface{}) *models.GatewayDevice {
+
+	record := &models.GatewayDevice{HardwareID: hwId, Key: newChallengeKey(t, keyType, publicKey)}
+	assert.NoError(t, record.ValidateModel())
+	configuratorTestUtils.RegisterGateway(t, networkId, hwId, record)
+	return record
+}
+
+// createResponse returns a response to the challenge with a CSR, but
+// without the challenge response itself
+func createResponse(t *testing.T, hwId string, challenge *protos.Challenge) *protos.Response {
+	csr, err := certifierTestUtils.CreateCSR(time.Duration(time.Hour*24*10), "cn", "cn")
+	assert.NoError(t, err)
+	return &protos.Response{
+		HwId:      &protos.AccessGatewayID{Id: hwId},
+		Challenge: challenge.Challenge,
+		Csr:       csr,
+	}
+}
+
+func createECDSAP384Response(
+	t *testing.T, hwId string, challenge *protos.Challenge, privateKey *ecdsa.PrivateKey) *protos.Response {
+
+	hashed := sha512.Sum384(challenge.Challenge)
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hashed[:])
+	assert.NoError(t, err)
+	resp := createResponse(t, hwId, challenge)
+	resp.Response = &protos.Response_EcdsaResponse{
+		EcdsaResponse: &protos.Response_ECDSA{R: r.Bytes(), S: s.Bytes()},
+	}
+	return resp
+}
+
+func createEd25519Response(
+	t *testing.T, hwId string, challenge *protos.Challenge, privateKey ed25519.PrivateKey) *protos.Response {
+
+	resp := createResponse(t, hwId, challenge)
+	resp.Response = &protos.Response_Ed25519Response{
+		Ed25519Response: &protos.Response_Ed25519{Signature: ed25519.Sign(privateKey, challenge.Challenge)},
+	}
+	return resp
+}
+
 func testNegative(
 	t *testing.T, networkId string, srv *servicers.BootstrapperServer, ctx context.Context) {
 
@@ -280,6 +526,106 @@ func testNegative(
 	assert.Error(t, err)
 }
 
+// createGatewayCSR returns a CSR for the gateway & its private key
+func createGatewayCSR(t *testing.T, gwId *protos.Identity, validTime time.Duration) (*rsa.PrivateKey, *protos.CSR) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	template := x509.CertificateRequest{Subject: pkix.Name{CommonName: *gwId.ToCommonName()}}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &template, privKey)
+	assert.NoError(t, err)
+	return privKey, &protos.CSR{Id: gwId, ValidTime: ptypes.DurationProto(validTime), CsrDer: csrDER}
+}
+
+// newRenewRequest returns a renewal request for the certificate signed
+// with signingKey
+func newRenewRequest(
+	t *testing.T, srv *servicers.BootstrapperServer, hwId string, certDer []byte, signingKey *rsa.PrivateKey, csr *protos.CSR,
+) *protos.RenewRequest {
+	challenge, err := srv.GetChallenge(context.Background(), &protos.AccessGatewayID{Id: hwId})
+	assert.NoError(t, err)
+	hashed := sha256.Sum256(append(append([]byte{}, challenge.Challenge...), csr.CsrDer...))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, signingKey, crypto.SHA256, hashed[:])
+	assert.NoError(t, err)
+	return &protos.RenewRequest{CertDer: certDer, Challenge: challenge.Challenge, Csr: csr, Signature: signature}
+}
+
+func testRenewCertificate(t *testing.T, networkId string, srv *servicers.BootstrapperServer) {
+	testAgHwId := "test_ag_renew"
+
+	configuratorTestUtils.RegisterGateway(
+		t,
+		networkId,
+		testAgHwId,
+		&models.GatewayDevice{
+			HardwareID: testAgHwId,
+			Key:        &models.ChallengeKey{KeyType: echoType},
+		},
+	)
+	gwId := identity.NewGateway(testAgHwId, "", "")
+	oldKey, csr := createGatewayCSR(t, gwId, time.Hour)
+	oldCert, err := certifier.SignCSR(csr)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// renew, requested duration is capped to the gateway certificate duration
+	_, csr = createGatewayCSR(t, gwId, time.Hour*24*10)
+	newCert, err := srv.RenewCertificate(ctx, newRenewRequest(t, srv, testAgHwId, oldCert.CertDer, oldKey, csr))
+	assert.NoError(t, err)
+	assert.NotEqual(t, oldCert.Sn.Sn, newCert.Sn.Sn)
+	certInfo, err := certifier.GetIdentity(newCert.Sn)
+	assert.NoError(t, err)
+	assert.Equal(t, gwId.HashString(), certInfo.Id.HashString())
+	notBefore, _ := ptypes.Timestamp(newCert.NotBefore)
+	notAfter, _ := ptypes.Timestamp(newCert.NotAfter)
+	assert.True(t, notAfter.Sub(notBefore) <= servicers.GatewayCertificateDuration+time.Hour)
+
+	// missing certificate or signature
+	_, err = srv.RenewCertificate(ctx, &protos.RenewRequest{Csr: csr})
+	assert.Error(t, err)
+	req := newRenewRequest(t, srv, testAgHwId, oldCert.CertDer, oldKey, csr)
+	req.Signature = nil
+	_, err = srv.RenewCertificate(ctx, req)
+	assert.Error(t, err)
+
+	// a client certificate SN header is not trusted, the request has to be
+	// signed with the certificate's key
+	forgedCtx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs(identity.CLIENT_CERT_SN_KEY, oldCert.Sn.Sn))
+	attackerKey, _ := createGatewayCSR(t, gwId, time.Hour)
+	_, err = srv.RenewCertificate(forgedCtx, newRenewRequest(t, srv, testAgHwId, oldCert.CertDer, attackerKey, csr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// self signed certificate with the serial number of a valid certificate
+	parsedOldCert, err := x509.ParseCertificate(oldCert.CertDer)
+	assert.NoError(t, err)
+	forgedParent := *parsedOldCert
+	forgedParent.PublicKey = &attackerKey.PublicKey
+	forgedCertDer, err := x509.CreateCertificate(rand.Reader, parsedOldCert, &forgedParent, &attackerKey.PublicKey, attackerKey)
+	assert.NoError(t, err)
+	_, err = srv.RenewCertificate(forgedCtx, newRenewRequest(t, srv, testAgHwId, forgedCertDer, attackerKey, csr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the signature covers the CSR
+	req = newRenewRequest(t, srv, testAgHwId, oldCert.CertDer, oldKey, csr)
+	_, otherCSR := createGatewayCSR(t, gwId, time.Hour)
+	req.Csr = otherCSR
+	_, err = srv.RenewCertificate(ctx, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// challenges must be issued by the bootstrapper
+	req = newRenewRequest(t, srv, testAgHwId, oldCert.CertDer, oldKey, csr)
+	req.Challenge[0]++
+	_, err = srv.RenewCertificate(ctx, req)
+	assert.Error(t, err)
+
+	// unregistered gateways cannot renew
+	req = newRenewRequest(t, srv, testAgHwId, oldCert.CertDer, oldKey, csr)
+	configuratorTestUtils.RemoveGateway(t, networkId, testAgHwId)
+	_, err = srv.RenewCertificate(ctx, req)
+	assert.Error(t, err)
+}
+
 func TestBootstrapperServer(t *testing.T) {
 	configuratorTestInit.StartTestService(t)
 	deviceTestInit.StartTestService(t)
@@ -299,13 +645,14 @@ func TestBootstrapperServer(t *testing.T) {
 	// create bootstrapper with short key
 	privateKey, err := key.GenerateKey("", 512)
 	assert.NoError(t, err)
-	_, err = servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey))
+	_, err = servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey), blobstore.NewMemoryBlobStorageFactory())
 	assert.Error(t, err)
 
 	// create bootstrapper server
 	privateKey, err = key.GenerateKey("", 2048)
 	assert.NoError(t, err)
-	srv, err := servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey))
+	store := blobstore.NewMemoryBlobStorageFactory()
+	srv, err := servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey), store)
 
 	// for signing csr
 	certifierTestInit.StartTestService(t)
@@ -323,4 +670,14 @@ func TestBootstrapperServer(t *testing.T) {
 		context.Background(),
 		metadata.Pairs("x-magma-client-cert-cn", "bla"))
 	testNegative(t, testNetworkID, srv, ctx)
+	testRenewCertificate(t, testNetworkID, srv)
+	testWithECDSAP384(t, testNetworkID, srv, ctx)
+	testWithEd25519(t, testNetworkID, srv, ctx)
+	testKeyRotation(t, testNetworkID, srv, ctx)
+	testLockout(t, testNetworkID, srv, ctx)
+
+	// bootstrapper instances sharing the store share lockouts
+	otherSrv, err := servicers.NewBootstrapperServer(privateKey.(*rsa.PrivateKey), store)
+	assert.NoError(t, err)
+	testSharedLockout(t, testNetworkID, srv, otherSrv, ctx)
 }
@@ -10,7 +10,9 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	"magma/orc8r/cloud/go/datastore"
@@ -19,8 +21,10 @@ import (
 	"magma/orc8r/cloud/go/security/cert"
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/services/certifier"
+	"magma/orc8r/cloud/go/services/certifier/crl"
 	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/certifier/servicers"
+	"magma/orc8r/cloud/go/services/certifier/signer"
 	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/glog"
@@ -30,11 +34,22 @@ import (
 var (
 	bootstrapCACertFile = flag.String("cac", "server_cert.pem", "Signer CA's Certificate file")
 	bootstrapCAKeyFile  = flag.String("cak", "server_cert.key.pem", "Signer CA's Private Key file")
+	bootstrapCAChain    = flag.String("cac-chain", "", "Signer CA's intermediate certificate chain file (optional)")
 
 	vpnCertFile = flag.String("vpnc", "vpn_ca.crt", "VPN CA's Certificate file")
 	vpnKeyFile  = flag.String("vpnk", "vpn_ca.key", "VPN CA's Private Key file")
+	vpnChain    = flag.String("vpnc-chain", "", "VPN CA's intermediate certificate chain file (optional)")
 
 	gcHours = flag.Int64("gc-hours", 12, "Garbage Collection time interval (in hours)")
+
+	expiryWindowDays = flag.Int64("expiry-window-days", 1, "Report certificates expiring within this many days")
+	expiryReportMins = flag.Int64("expiry-report-mins", 10, "Expiring certificates report interval (in minutes)")
+
+	crlDir           = flag.String("crl-dir", "", "Directory to publish CRL files into, empty disables CRL files")
+	crlHttpAddr      = flag.String("crl-http-addr", "", "Address to serve CRLs over HTTP on, empty disables CRL HTTP server")
+	crlHttpPath      = flag.String("crl-http-path", "/crl/", "HTTP path prefix CRLs are served under")
+	crlPublishMins   = flag.Int64("crl-publish-mins", 10, "CRL publish interval (in minutes)")
+	crlValidityHours = flag.Int64("crl-validity-hours", 24, "Time until the next CRL update advertised in CRLs (in hours)")
 )
 
 func main() {
@@ -69,7 +84,11 @@ func main() {
 	} else {
 		caMap[protos.CertType_VPN] = &servicers.CAInfo{Cert: vpnCert, PrivKey: vpnPrivKey}
 	}
-	servicer, err := servicers.NewCertifierServer(store, caMap)
+	signers, err := newSigners(caMap)
+	if err != nil {
+		log.Fatalf("Failed to create CA signers: %s", err)
+	}
+	servicer, err := servicers.NewCertifierServerWithSigners(store, signers)
 	if err != nil {
 		log.Fatalf("Failed to create certifier server: %s", err)
 	}
@@ -87,9 +106,74 @@ func main() {
 		}
 	}()
 
+	// Start Expiring Certificates Reporter Ticker
+	expiryWindow := time.Hour * 24 * time.Duration(*expiryWindowDays)
+	expiryReport := time.Tick(time.Minute * time.Duration(*expiryReportMins))
+	go func() {
+		for range expiryReport {
+			err := servicer.ReportExpiringCertificates(expiryWindow)
+			if err != nil {
+				glog.Errorf("error reporting expiring certificates: %s", err)
+			}
+		}
+	}()
+
+	// Start CRL Publisher Ticker
+	if len(*crlDir) > 0 || len(*crlHttpAddr) > 0 {
+		servicers.CRLValidity = time.Hour * time.Duration(*crlValidityHours)
+		certTypes := make([]protos.CertType, 0, len(caMap))
+		for certType := range caMap {
+			certTypes = append(certTypes, certType)
+		}
+		publisher := crl.NewPublisher(servicer, certTypes, *crlDir)
+		if err := publisher.Publish(); err != nil {
+			glog.Errorf("error publishing CRLs: %s", err)
+		}
+		publish := time.Tick(time.Minute * time.Duration(*crlPublishMins))
+		go func() {
+			for range publish {
+				if err := publisher.Publish(); err != nil {
+					glog.Errorf("error publishing CRLs: %s", err)
+				}
+			}
+		}()
+		if len(*crlHttpAddr) > 0 {
+			mux := http.NewServeMux()
+			mux.Handle(*crlHttpPath, publisher)
+			go func() {
+				log.Fatalf("CRL HTTP server failed: %s", http.ListenAndServe(*crlHttpAddr, mux))
+			}()
+		}
+	}
+
 	// Run the service
 	err = srv.Run()
 	if err != nil {
 		log.Fatalf("Error running service: %s", err)
 	}
 }
+
+// newSigners creates a local CA signer for every loaded CA, with the CA's
+// intermediate chain if one is configured
+func newSigners(caMap map[protos.CertType]*servicers.CAInfo) (map[protos.CertType]signer.Signer, error) {
+	chainFiles := map[protos.CertType]string{
+		protos.CertType_DEFAULT: *bootstrapCAChain,
+		protos.CertType_VPN:     *vpnChain,
+	}
+	signers := map[protos.CertType]signer.Signer{}
+	for certType, ca := range caMap {
+		var chain [][]byte
+		var err error
+		if chainFile := chainFiles[certType]; len(chainFile) > 0 {
+			chain, err = cert.LoadCertChain(chainFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		signers[certType], err = signer.NewLocalSigner(ca.Cert, ca.PrivKey, chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s CA signer: %s", certType.String(), err)
+		}
+	}
+	return signers, nil
+}
@@ -11,6 +11,7 @@ package certifier
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"magma/orc8r/cloud/go/clock"
 	merrors "magma/orc8r/cloud/go/errors"
@@ -203,6 +204,68 @@ func CollectGarbage() error {
 	return nil
 }
 
+// ListExpiringCertificates returns records of all valid certificates which
+// expire within the given duration, keyed by serial number
+func ListExpiringCertificates(within time.Duration) (map[string]*certifierprotos.CertificateInfo, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+	certMap, err := client.ListExpiringCertificates(
+		context.Background(),
+		&certifierprotos.ListExpiringCertificatesRequest{Within: ptypes.DurationProto(within)})
+	if err != nil || certMap == nil {
+		return nil, err
+	}
+	return certMap.GetCertificates(), err
+}
+
+// RenewCertificate returns a new certificate signed for the Identity of the
+// still valid certificate with the given SN
+func RenewCertificate(sn *protos.Certificate_SN, csr *protos.CSR) (*protos.Certificate, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := client.RenewCertificate(
+		context.Background(), &certifierprotos.RenewCertificateRequest{Sn: sn, Csr: csr})
+	if err != nil {
+		glog.Errorf("Failed to renew certificate with SN: %s, %s", sn.Sn, err)
+		return nil, err
+	}
+	return cert, nil
+}
+
+// GetCertificateStatus returns the revocation status of the certificate with
+// the given serial number
+func GetCertificateStatus(sn *protos.Certificate_SN) (*certifierprotos.CertificateStatus, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+	certStatus, err := client.GetCertificateStatus(context.Background(), sn)
+	if err != nil {
+		glog.Errorf("Failed to get status of certificate with SN: %s, %s", sn.Sn, err)
+		return nil, err
+	}
+	return certStatus, nil
+}
+
+// GetCRL returns the DER encoded CRL of the CA of the given type
+func GetCRL(certType protos.CertType) ([]byte, error) {
+	client, err := getCertifierClient()
+	if err != nil {
+		return nil, err
+	}
+	crl, err := client.GetCRL(context.Background(), &certifierprotos.GetCRLRequest{CertType: certType})
+	if err != nil {
+		glog.Errorf("Failed to get CRL for cert type: %s, %s", certType, err)
+		return nil, err
+	}
+	return crl.CrlDer, nil
+}
+
 type CertDateRange interface {
 	GetNotBefore() *timestamp.Timestamp
 	GetNotAfter() *timestamp.Timestamp
@@ -22,6 +22,7 @@ import (
 	"magma/orc8r/cloud/go/protos"
 	security_cert "magma/orc8r/cloud/go/security/cert"
 	"magma/orc8r/cloud/go/services/certifier"
+	certifierprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/certifier/servicers"
 	"magma/orc8r/cloud/go/services/certifier/test_init"
 	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
@@ -57,6 +58,14 @@ func TestCertifier(t *testing.T) {
 	assert.NoError(t, err, "Failed to revoke cert")
 	_, err = certifier.GetIdentity(snMsg)
 	assert.Error(t, err, "Error: no error getting revoked identity")
+	certStatus, err := certifier.GetCertificateStatus(snMsg)
+	assert.NoError(t, err, "Error getting revoked certificate status")
+	assert.Equal(t, certifierprotos.CertificateStatus_REVOKED, certStatus.Status)
+	crlDER, err := certifier.GetCRL(protos.CertType_DEFAULT)
+	assert.NoError(t, err, "Error getting CRL")
+	crl, err := x509.ParseCRL(crlDER)
+	assert.NoError(t, err, "Failed to parse CRL")
+	assert.Equal(t, 1, len(crl.TBSCertList.RevokedCertificates))
 
 	// test collect garbage
 	servicers.CollectGarbageAfter = time.Duration(0)
@@ -100,6 +109,23 @@ func TestCertifier(t *testing.T) {
 	assert.NoError(t, err, "Error Listing Certificates")
 	assert.Equal(t, 2, len(sns))
 
+	expiring, err := certifier.ListExpiringCertificates(time.Hour * 3)
+	assert.NoError(t, err, "Error Listing Expiring Certificates")
+	assert.Equal(t, 1, len(expiring))
+	assert.Contains(t, expiring, certMsg.Sn.Sn)
+
+	renewCSR, err := certifier_test_utils.CreateCSR(time.Duration(time.Hour*2), "cn1", "cn1")
+	assert.NoError(t, err)
+	renewedMsg, err := certifier.RenewCertificate(certMsg.Sn, renewCSR)
+	assert.NoError(t, err, "Failed to renew certificate")
+	certInfoMsg, err = certifier.GetIdentity(renewedMsg.Sn)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(certInfoMsg.Id, csrMsg.Id))
+
+	sns, err = certifier.ListCertificates()
+	assert.NoError(t, err, "Error Listing Certificates")
+	assert.Equal(t, 3, len(sns))
+
 	operSNs, err := certifier.FindCertificates(oper)
 	assert.NoError(t, err, "Error Finding Operator Certificates")
 	assert.Equal(t, 1, len(operSNs))
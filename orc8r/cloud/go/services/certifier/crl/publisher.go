@@ -0,0 +1,128 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package crl publishes certifier's certificate revocation lists to
+// TLS terminating proxies, which cannot query certifier directly.
+package crl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"magma/orc8r/cloud/go/protos"
+)
+
+// Generator creates a DER encoded CRL for CA of the given certificate type
+type Generator interface {
+	GenerateCRL(certType protos.CertType) ([]byte, error)
+}
+
+// Publisher periodically regenerates CRLs, writes them into a directory and
+// serves the latest CRLs over HTTP.
+// Each CRL is published as <lowercase cert type>.crl, e.g. default.crl
+type Publisher struct {
+	generator Generator
+	certTypes []protos.CertType
+	// dir is the directory the CRL files are written into, empty dir
+	// disables writing files
+	dir string
+
+	sync.RWMutex
+	crls map[string][]byte
+}
+
+// NewPublisher returns a Publisher of CRLs for the given cert types
+func NewPublisher(generator Generator, certTypes []protos.CertType, dir string) *Publisher {
+	return &Publisher{
+		generator: generator,
+		certTypes: certTypes,
+		dir:       dir,
+		crls:      map[string][]byte{},
+	}
+}
+
+// FileName returns the name under which the CRL of the given cert type is
+// published
+func FileName(certType protos.CertType) string {
+	return strings.ToLower(certType.String()) + ".crl"
+}
+
+// Publish regenerates the CRLs of all cert types and writes them to the
+// publisher's directory. A failure for one cert type does not prevent the
+// others from being published.
+func (p *Publisher) Publish() error {
+	var errs []string
+	for _, certType := range p.certTypes {
+		crlDER, err := p.generator.GenerateCRL(certType)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", certType, err))
+			continue
+		}
+		name := FileName(certType)
+		p.Lock()
+		p.crls[name] = crlDER
+		p.Unlock()
+
+		if len(p.dir) == 0 {
+			continue
+		}
+		if err = writeFileAtomic(filepath.Join(p.dir, name), crlDER); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", certType, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to publish CRLs: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ServeHTTP serves the last published CRL for the file name at the end of
+// the request path
+func (p *Publisher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	p.RLock()
+	crlDER, ok := p.crls[path.Base(r.URL.Path)]
+	p.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	w.Write(crlDER)
+}
+
+// writeFileAtomic writes into a temporary file and renames it, so readers
+// never see a partially written CRL
+func writeFileAtomic(filename string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package crl_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/certifier/crl"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGenerator struct {
+	crls map[protos.CertType][]byte
+}
+
+func (m *mockGenerator) GenerateCRL(certType protos.CertType) ([]byte, error) {
+	crlDER, ok := m.crls[certType]
+	if !ok {
+		return nil, errors.New("no CA")
+	}
+	return crlDER, nil
+}
+
+func TestPublisher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crl_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	generator := &mockGenerator{crls: map[protos.CertType][]byte{
+		protos.CertType_DEFAULT: []byte("default crl"),
+	}}
+	publisher := crl.NewPublisher(
+		generator, []protos.CertType{protos.CertType_DEFAULT, protos.CertType_VPN}, dir)
+
+	// VPN CRL fails, default CRL is still published
+	err = publisher.Publish()
+	assert.Error(t, err)
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "default.crl"))
+	assert.NoError(t, err)
+	assert.Equal(t, "default crl", string(contents))
+	_, err = os.Stat(filepath.Join(dir, "vpn.crl"))
+	assert.True(t, os.IsNotExist(err))
+
+	generator.crls[protos.CertType_DEFAULT] = []byte("new default crl")
+	generator.crls[protos.CertType_VPN] = []byte("vpn crl")
+	assert.NoError(t, publisher.Publish())
+	contents, err = ioutil.ReadFile(filepath.Join(dir, "default.crl"))
+	assert.NoError(t, err)
+	assert.Equal(t, "new default crl", string(contents))
+	contents, err = ioutil.ReadFile(filepath.Join(dir, "vpn.crl"))
+	assert.NoError(t, err)
+	assert.Equal(t, "vpn crl", string(contents))
+
+	// no temporary files are left behind
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	rec := httptest.NewRecorder()
+	publisher.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/crl/vpn.crl", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/pkix-crl", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "vpn crl", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	publisher.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/crl/other.crl", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	publisher.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/crl/vpn.crl", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestPublisherWithoutDir(t *testing.T) {
+	generator := &mockGenerator{crls: map[protos.CertType][]byte{
+		protos.CertType_DEFAULT: []byte("default crl"),
+	}}
+	publisher := crl.NewPublisher(generator, []protos.CertType{protos.CertType_DEFAULT}, "")
+	assert.NoError(t, publisher.Publish())
+
+	rec := httptest.NewRecorder()
+	publisher.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/crl/default.crl", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "default crl", rec.Body.String())
+}
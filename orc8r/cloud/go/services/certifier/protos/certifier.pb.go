@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -26,6 +27,37 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type CertificateStatus_Status int32
+
+const (
+	CertificateStatus_UNKNOWN CertificateStatus_Status = 0
+	CertificateStatus_GOOD    CertificateStatus_Status = 1
+	CertificateStatus_REVOKED CertificateStatus_Status = 2
+	CertificateStatus_EXPIRED CertificateStatus_Status = 3
+)
+
+var CertificateStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "GOOD",
+	2: "REVOKED",
+	3: "EXPIRED",
+}
+
+var CertificateStatus_Status_value = map[string]int32{
+	"UNKNOWN": 0,
+	"GOOD":    1,
+	"REVOKED": 2,
+	"EXPIRED": 3,
+}
+
+func (x CertificateStatus_Status) String() string {
+	return proto.EnumName(CertificateStatus_Status_name, int32(x))
+}
+
+func (CertificateStatus_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{6, 0}
+}
+
 type CertificateInfo struct {
 	Id                   *protos.Identity     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	NotBefore            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
@@ -261,56 +293,352 @@ func (m *GetCARequest) GetCertType() protos.CertType {
 	return protos.CertType_DEFAULT
 }
 
+type RevokedCertificateInfo struct {
+	Info                 *CertificateInfo     `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	RevocationTime       *timestamp.Timestamp `protobuf:"bytes,2,opt,name=revocation_time,json=revocationTime,proto3" json:"revocation_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RevokedCertificateInfo) Reset()         { *m = RevokedCertificateInfo{} }
+func (m *RevokedCertificateInfo) String() string { return proto.CompactTextString(m) }
+func (*RevokedCertificateInfo) ProtoMessage()    {}
+func (*RevokedCertificateInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{5}
+}
+
+func (m *RevokedCertificateInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokedCertificateInfo.Unmarshal(m, b)
+}
+func (m *RevokedCertificateInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokedCertificateInfo.Marshal(b, m, deterministic)
+}
+func (m *RevokedCertificateInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokedCertificateInfo.Merge(m, src)
+}
+func (m *RevokedCertificateInfo) XXX_Size() int {
+	return xxx_messageInfo_RevokedCertificateInfo.Size(m)
+}
+func (m *RevokedCertificateInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokedCertificateInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokedCertificateInfo proto.InternalMessageInfo
+
+func (m *RevokedCertificateInfo) GetInfo() *CertificateInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *RevokedCertificateInfo) GetRevocationTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.RevocationTime
+	}
+	return nil
+}
+
+type CertificateStatus struct {
+	Status CertificateStatus_Status `protobuf:"varint,1,opt,name=status,proto3,enum=magma.orc8r.certifier.CertificateStatus_Status" json:"status,omitempty"`
+	// Set only for REVOKED certificates
+	RevocationTime       *timestamp.Timestamp `protobuf:"bytes,2,opt,name=revocation_time,json=revocationTime,proto3" json:"revocation_time,omitempty"`
+	NotAfter             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CertificateStatus) Reset()         { *m = CertificateStatus{} }
+func (m *CertificateStatus) String() string { return proto.CompactTextString(m) }
+func (*CertificateStatus) ProtoMessage()    {}
+func (*CertificateStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{6}
+}
+
+func (m *CertificateStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateStatus.Unmarshal(m, b)
+}
+func (m *CertificateStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateStatus.Marshal(b, m, deterministic)
+}
+func (m *CertificateStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateStatus.Merge(m, src)
+}
+func (m *CertificateStatus) XXX_Size() int {
+	return xxx_messageInfo_CertificateStatus.Size(m)
+}
+func (m *CertificateStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateStatus proto.InternalMessageInfo
+
+func (m *CertificateStatus) GetStatus() CertificateStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return CertificateStatus_UNKNOWN
+}
+
+func (m *CertificateStatus) GetRevocationTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.RevocationTime
+	}
+	return nil
+}
+
+func (m *CertificateStatus) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+type GetCRLRequest struct {
+	CertType             protos.CertType `protobuf:"varint,1,opt,name=cert_type,json=certType,proto3,enum=magma.orc8r.CertType" json:"cert_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetCRLRequest) Reset()         { *m = GetCRLRequest{} }
+func (m *GetCRLRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCRLRequest) ProtoMessage()    {}
+func (*GetCRLRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{7}
+}
+
+func (m *GetCRLRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCRLRequest.Unmarshal(m, b)
+}
+func (m *GetCRLRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCRLRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCRLRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCRLRequest.Merge(m, src)
+}
+func (m *GetCRLRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCRLRequest.Size(m)
+}
+func (m *GetCRLRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCRLRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCRLRequest proto.InternalMessageInfo
+
+func (m *GetCRLRequest) GetCertType() protos.CertType {
+	if m != nil {
+		return m.CertType
+	}
+	return protos.CertType_DEFAULT
+}
+
+type CRL struct {
+	// CRL signed by the CA of the requested type, in DER encoding
+	CrlDer               []byte   `protobuf:"bytes,1,opt,name=crl_der,json=crlDer,proto3" json:"crl_der,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CRL) Reset()         { *m = CRL{} }
+func (m *CRL) String() string { return proto.CompactTextString(m) }
+func (*CRL) ProtoMessage()    {}
+func (*CRL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{8}
+}
+
+func (m *CRL) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CRL.Unmarshal(m, b)
+}
+func (m *CRL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CRL.Marshal(b, m, deterministic)
+}
+func (m *CRL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CRL.Merge(m, src)
+}
+func (m *CRL) XXX_Size() int {
+	return xxx_messageInfo_CRL.Size(m)
+}
+func (m *CRL) XXX_DiscardUnknown() {
+	xxx_messageInfo_CRL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CRL proto.InternalMessageInfo
+
+func (m *CRL) GetCrlDer() []byte {
+	if m != nil {
+		return m.CrlDer
+	}
+	return nil
+}
+
+type ListExpiringCertificatesRequest struct {
+	// Only certificates which expire within this duration from now are returned
+	Within               *duration.Duration `protobuf:"bytes,1,opt,name=within,proto3" json:"within,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListExpiringCertificatesRequest) Reset()         { *m = ListExpiringCertificatesRequest{} }
+func (m *ListExpiringCertificatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListExpiringCertificatesRequest) ProtoMessage()    {}
+func (*ListExpiringCertificatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{9}
+}
+
+func (m *ListExpiringCertificatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExpiringCertificatesRequest.Unmarshal(m, b)
+}
+func (m *ListExpiringCertificatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExpiringCertificatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListExpiringCertificatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExpiringCertificatesRequest.Merge(m, src)
+}
+func (m *ListExpiringCertificatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListExpiringCertificatesRequest.Size(m)
+}
+func (m *ListExpiringCertificatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExpiringCertificatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExpiringCertificatesRequest proto.InternalMessageInfo
+
+func (m *ListExpiringCertificatesRequest) GetWithin() *duration.Duration {
+	if m != nil {
+		return m.Within
+	}
+	return nil
+}
+
+type RenewCertificateRequest struct {
+	// Serial number of the existing, still valid certificate
+	Sn                   *protos.Certificate_SN `protobuf:"bytes,1,opt,name=sn,proto3" json:"sn,omitempty"`
+	Csr                  *protos.CSR            `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *RenewCertificateRequest) Reset()         { *m = RenewCertificateRequest{} }
+func (m *RenewCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*RenewCertificateRequest) ProtoMessage()    {}
+func (*RenewCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_515f9a7ba5ef1ab9, []int{10}
+}
+
+func (m *RenewCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewCertificateRequest.Unmarshal(m, b)
+}
+func (m *RenewCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *RenewCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewCertificateRequest.Merge(m, src)
+}
+func (m *RenewCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_RenewCertificateRequest.Size(m)
+}
+func (m *RenewCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewCertificateRequest proto.InternalMessageInfo
+
+func (m *RenewCertificateRequest) GetSn() *protos.Certificate_SN {
+	if m != nil {
+		return m.Sn
+	}
+	return nil
+}
+
+func (m *RenewCertificateRequest) GetCsr() *protos.CSR {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("magma.orc8r.certifier.CertificateStatus_Status", CertificateStatus_Status_name, CertificateStatus_Status_value)
 	proto.RegisterType((*CertificateInfo)(nil), "magma.orc8r.certifier.CertificateInfo")
 	proto.RegisterType((*CertificateInfoMap)(nil), "magma.orc8r.certifier.CertificateInfoMap")
 	proto.RegisterMapType((map[string]*CertificateInfo)(nil), "magma.orc8r.certifier.CertificateInfoMap.CertificatesEntry")
 	proto.RegisterType((*AddCertRequest)(nil), "magma.orc8r.certifier.AddCertRequest")
 	proto.RegisterType((*SerialNumbers)(nil), "magma.orc8r.certifier.SerialNumbers")
 	proto.RegisterType((*GetCARequest)(nil), "magma.orc8r.certifier.GetCARequest")
+	proto.RegisterType((*RevokedCertificateInfo)(nil), "magma.orc8r.certifier.RevokedCertificateInfo")
+	proto.RegisterType((*CertificateStatus)(nil), "magma.orc8r.certifier.CertificateStatus")
+	proto.RegisterType((*GetCRLRequest)(nil), "magma.orc8r.certifier.GetCRLRequest")
+	proto.RegisterType((*CRL)(nil), "magma.orc8r.certifier.CRL")
+	proto.RegisterType((*ListExpiringCertificatesRequest)(nil), "magma.orc8r.certifier.ListExpiringCertificatesRequest")
+	proto.RegisterType((*RenewCertificateRequest)(nil), "magma.orc8r.certifier.RenewCertificateRequest")
 }
 
 func init() { proto.RegisterFile("certifier.proto", fileDescriptor_515f9a7ba5ef1ab9) }
 
 var fileDescriptor_515f9a7ba5ef1ab9 = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdf, 0x8e, 0xd2, 0x40,
-	0x14, 0xc6, 0x5b, 0x90, 0x15, 0x0e, 0xc8, 0xc2, 0x98, 0x4d, 0xba, 0x5d, 0x13, 0xb1, 0xba, 0x06,
-	0x6f, 0x4a, 0x82, 0x17, 0xe2, 0x9f, 0x1b, 0xc0, 0x15, 0x37, 0xd9, 0xdd, 0xc4, 0x42, 0xbc, 0xf0,
-	0x86, 0x94, 0xf6, 0xd0, 0x4c, 0xb6, 0xed, 0xe0, 0x74, 0xd8, 0x84, 0x17, 0xf0, 0x4d, 0x7c, 0x2d,
-	0xe3, 0xa3, 0x98, 0xfe, 0x5b, 0x29, 0x14, 0xed, 0x15, 0x33, 0x73, 0xbe, 0xf9, 0x7d, 0x67, 0xbe,
-	0x19, 0x0a, 0xc7, 0x16, 0x72, 0x41, 0x97, 0x14, 0xb9, 0xbe, 0xe2, 0x4c, 0x30, 0x72, 0xe2, 0x99,
-	0x8e, 0x67, 0xea, 0x8c, 0x5b, 0x03, 0xae, 0xdf, 0x17, 0xd5, 0x27, 0xd1, 0x42, 0x2f, 0xd2, 0x04,
-	0xbd, 0x9d, 0x4d, 0xea, 0x69, 0xb6, 0xca, 0x3c, 0x8f, 0xf9, 0x49, 0xe9, 0x2c, 0x53, 0xa2, 0x36,
-	0xfa, 0x82, 0x8a, 0x4d, 0x52, 0x7c, 0xea, 0x30, 0xe6, 0xb8, 0x18, 0x57, 0x17, 0xeb, 0x65, 0x4f,
-	0x50, 0x0f, 0x03, 0x61, 0x7a, 0xab, 0x58, 0xa0, 0xfd, 0x96, 0xe1, 0x78, 0x1c, 0x9b, 0x59, 0xa6,
-	0xc0, 0x4b, 0x7f, 0xc9, 0xc8, 0x39, 0x94, 0xa8, 0xad, 0xc8, 0x1d, 0xb9, 0x5b, 0xef, 0x9f, 0xe8,
-	0xdb, 0xed, 0x5e, 0x26, 0x74, 0xa3, 0x44, 0x6d, 0xf2, 0x16, 0xc0, 0x67, 0x62, 0xbe, 0xc0, 0x25,
-	0xe3, 0xa8, 0x94, 0x22, 0xb9, 0xaa, 0xc7, 0x86, 0x7a, 0x6a, 0xa8, 0xcf, 0x52, 0x43, 0xa3, 0xe6,
-	0x33, 0x31, 0x8a, 0xc4, 0xe4, 0x0d, 0x84, 0x93, 0xb9, 0xb9, 0x14, 0xc8, 0x95, 0xf2, 0x7f, 0x77,
-	0x56, 0x7d, 0x26, 0x86, 0xa1, 0x96, 0xf4, 0xa1, 0x16, 0x46, 0x33, 0x17, 0x9b, 0x15, 0x2a, 0x0f,
-	0x3a, 0x72, 0xb7, 0xb9, 0xd3, 0x61, 0x78, 0x96, 0xd9, 0x66, 0x85, 0x46, 0xd5, 0x4a, 0x46, 0xda,
-	0x2f, 0x19, 0xc8, 0xce, 0x11, 0xaf, 0xcd, 0x15, 0x99, 0x43, 0xc3, 0xfa, 0xbb, 0x1a, 0x28, 0x72,
-	0xa7, 0xdc, 0xad, 0xf7, 0xdf, 0xeb, 0xb9, 0xd7, 0xa3, 0xef, 0x03, 0xb6, 0x97, 0x82, 0x0b, 0x5f,
-	0xf0, 0x8d, 0x91, 0x01, 0xaa, 0x0e, 0xb4, 0xf7, 0x24, 0xa4, 0x05, 0xe5, 0x5b, 0xdc, 0x44, 0xe1,
-	0xd6, 0x8c, 0x70, 0x48, 0x3e, 0x40, 0xe5, 0xce, 0x74, 0xd7, 0x69, 0x82, 0x2f, 0x8b, 0x35, 0x60,
-	0xc4, 0x9b, 0xde, 0x95, 0x06, 0xb2, 0xf6, 0x43, 0x86, 0xe6, 0xd0, 0xb6, 0x43, 0x85, 0x81, 0xdf,
-	0xd7, 0x18, 0x88, 0xa2, 0x57, 0x78, 0x0a, 0x51, 0x4c, 0x73, 0x1b, 0x79, 0x64, 0xdf, 0x30, 0x1e,
-	0x86, 0xf3, 0x8f, 0xbb, 0x49, 0x97, 0x8b, 0x25, 0xfd, 0x0c, 0x1e, 0x4d, 0x91, 0x53, 0xd3, 0xbd,
-	0x59, 0x7b, 0x0b, 0xe4, 0x41, 0x78, 0xda, 0xc0, 0x8f, 0xa3, 0xad, 0x19, 0xe1, 0x50, 0x1b, 0x41,
-	0x63, 0x82, 0x62, 0x3c, 0x4c, 0x1b, 0xcd, 0xd8, 0xc8, 0x85, 0x6c, 0xfa, 0x3f, 0x2b, 0x50, 0x1b,
-	0xa7, 0xc1, 0x90, 0x31, 0x54, 0x22, 0x22, 0x79, 0x7e, 0x20, 0xb9, 0x6d, 0x3f, 0xf5, 0x71, 0x16,
-	0x3e, 0x0c, 0x39, 0x9a, 0x44, 0x46, 0x40, 0xa6, 0xd4, 0xf1, 0x93, 0x14, 0x93, 0x9c, 0x49, 0x2b,
-	0x2b, 0x9e, 0x1a, 0xaa, 0xb2, 0xd7, 0x5b, 0xa2, 0xd5, 0x24, 0x32, 0x83, 0xfa, 0x04, 0x45, 0x9a,
-	0x2f, 0x39, 0x3b, 0x24, 0xd5, 0xa7, 0x37, 0x6a, 0xc1, 0x5b, 0xd6, 0x24, 0x72, 0x01, 0x6d, 0x03,
-	0xef, 0xd8, 0x2d, 0x6e, 0x37, 0xf6, 0x4f, 0x76, 0x3b, 0x53, 0xfc, 0xca, 0xa8, 0xad, 0x49, 0xe4,
-	0xea, 0xfe, 0x89, 0xa4, 0x8c, 0xf3, 0x03, 0x2d, 0x64, 0x5f, 0x52, 0x3e, 0xed, 0x0b, 0xb4, 0x3e,
-	0x51, 0x7f, 0x1b, 0x17, 0x90, 0xfc, 0x67, 0xa6, 0xbe, 0x38, 0x60, 0x93, 0x79, 0x28, 0x9a, 0x44,
-	0xae, 0xa1, 0x75, 0x45, 0x03, 0x91, 0x41, 0xee, 0x7b, 0x17, 0xc6, 0x7d, 0x86, 0xa3, 0x09, 0x8a,
-	0xa1, 0xeb, 0xe6, 0x41, 0x5e, 0x15, 0xfe, 0x93, 0x6b, 0x12, 0x19, 0x40, 0x73, 0xcc, 0x5c, 0x17,
-	0x2d, 0x31, 0x31, 0xf9, 0xc2, 0x74, 0x30, 0x8f, 0x98, 0x97, 0xd2, 0xa8, 0xfa, 0xed, 0x28, 0xfe,
-	0x2a, 0x2f, 0xe2, 0xdf, 0xd7, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xfb, 0xa9, 0xfc, 0xb5, 0x0d,
-	0x06, 0x00, 0x00,
+	// 883 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5f, 0x73, 0xdb, 0x44,
+	0x10, 0xb7, 0xec, 0xd4, 0xb1, 0x37, 0x69, 0xa2, 0x1c, 0x94, 0x2a, 0x2a, 0xd3, 0x86, 0xa3, 0x65,
+	0xc2, 0x30, 0xa3, 0x0c, 0x61, 0x06, 0xd2, 0xc2, 0x8b, 0x23, 0x1b, 0x93, 0xa9, 0xeb, 0xc0, 0x39,
+	0x14, 0x86, 0x07, 0x3c, 0xb2, 0x74, 0x36, 0x47, 0x65, 0x9d, 0x39, 0x9d, 0x53, 0xfc, 0xc4, 0x1b,
+	0xdf, 0x80, 0x07, 0x3e, 0x08, 0x5f, 0x87, 0xe1, 0xa3, 0x30, 0xa7, 0x3f, 0xae, 0x65, 0x5b, 0x8e,
+	0x0a, 0x7d, 0xf2, 0xe9, 0x76, 0xf7, 0xb7, 0xbf, 0xdd, 0xdb, 0xdf, 0x26, 0xb0, 0xef, 0x52, 0x21,
+	0xd9, 0x90, 0x51, 0x61, 0x4d, 0x04, 0x97, 0x1c, 0xdd, 0x19, 0x3b, 0xa3, 0xb1, 0x63, 0x71, 0xe1,
+	0x9e, 0x09, 0x6b, 0x6e, 0x34, 0xdf, 0x8d, 0x2e, 0x4e, 0x22, 0x9f, 0xf0, 0x64, 0x29, 0xc8, 0x3c,
+	0xcc, 0x5a, 0xf9, 0x78, 0xcc, 0x83, 0xc4, 0x74, 0x2f, 0x63, 0x62, 0x1e, 0x0d, 0x24, 0x93, 0xb3,
+	0xc4, 0x78, 0x7f, 0xc4, 0xf9, 0xc8, 0xa7, 0xb1, 0x75, 0x30, 0x1d, 0x9e, 0x78, 0x53, 0xe1, 0x48,
+	0x36, 0x0f, 0x7e, 0xb0, 0x6c, 0x97, 0x6c, 0x4c, 0x43, 0xe9, 0x8c, 0x27, 0xb1, 0x03, 0xfe, 0x47,
+	0x83, 0x7d, 0x3b, 0x26, 0xe3, 0x3a, 0x92, 0x5e, 0x04, 0x43, 0x8e, 0x1e, 0x41, 0x99, 0x79, 0x86,
+	0x76, 0xa4, 0x1d, 0xef, 0x9c, 0xde, 0xb1, 0x16, 0xcb, 0xb9, 0x48, 0xb2, 0x93, 0x32, 0xf3, 0xd0,
+	0x63, 0x80, 0x80, 0xcb, 0xfe, 0x80, 0x0e, 0xb9, 0xa0, 0x46, 0x39, 0x72, 0x37, 0xad, 0x38, 0xa1,
+	0x95, 0x26, 0xb4, 0xae, 0xd2, 0x84, 0xa4, 0x1e, 0x70, 0x79, 0x1e, 0x39, 0xa3, 0xcf, 0x40, 0x7d,
+	0xf4, 0x9d, 0xa1, 0xa4, 0xc2, 0xa8, 0xdc, 0x18, 0x59, 0x0b, 0xb8, 0x6c, 0x28, 0x5f, 0x74, 0x0a,
+	0x75, 0xd5, 0xba, 0xbe, 0x9c, 0x4d, 0xa8, 0xb1, 0x75, 0xa4, 0x1d, 0xef, 0x2d, 0x31, 0x54, 0xb5,
+	0x5c, 0xcd, 0x26, 0x94, 0xd4, 0xdc, 0xe4, 0x84, 0xff, 0xd6, 0x00, 0x2d, 0x95, 0xf8, 0xcc, 0x99,
+	0xa0, 0x3e, 0xec, 0xba, 0xaf, 0x6e, 0x43, 0x43, 0x3b, 0xaa, 0x1c, 0xef, 0x9c, 0x7e, 0x6e, 0xad,
+	0x7d, 0x3e, 0x6b, 0x15, 0x60, 0xf1, 0x2a, 0x6c, 0x05, 0x52, 0xcc, 0x48, 0x06, 0xd0, 0x1c, 0xc1,
+	0xc1, 0x8a, 0x0b, 0xd2, 0xa1, 0xf2, 0x82, 0xce, 0xa2, 0xe6, 0xd6, 0x89, 0x3a, 0xa2, 0x2f, 0xe0,
+	0xd6, 0xb5, 0xe3, 0x4f, 0xd3, 0x0e, 0x7e, 0x50, 0x8c, 0x00, 0x89, 0x83, 0x9e, 0x94, 0xcf, 0x34,
+	0xfc, 0xbb, 0x06, 0x7b, 0x0d, 0xcf, 0x53, 0x1e, 0x84, 0xfe, 0x32, 0xa5, 0xa1, 0x2c, 0xfa, 0x84,
+	0x87, 0x10, 0xb5, 0xa9, 0xef, 0x51, 0x11, 0xa5, 0xdf, 0x25, 0xdb, 0xea, 0xbb, 0xb9, 0xdc, 0xe9,
+	0x4a, 0xb1, 0x4e, 0xbf, 0x07, 0xb7, 0x7b, 0x54, 0x30, 0xc7, 0xef, 0x4e, 0xc7, 0x03, 0x2a, 0x42,
+	0x55, 0x6d, 0x18, 0xc4, 0xad, 0xad, 0x13, 0x75, 0xc4, 0xe7, 0xb0, 0xdb, 0xa6, 0xd2, 0x6e, 0xa4,
+	0x44, 0x33, 0x69, 0xb4, 0x62, 0x69, 0xfe, 0xd4, 0xe0, 0x1d, 0x42, 0xaf, 0xf9, 0x0b, 0xea, 0x2d,
+	0x8f, 0xee, 0x13, 0xd8, 0x62, 0xc1, 0x90, 0x1b, 0xda, 0x6b, 0xf5, 0x32, 0x8a, 0x41, 0x36, 0xec,
+	0x0b, 0x7a, 0xcd, 0xdd, 0x48, 0x3f, 0x7d, 0x25, 0x94, 0x02, 0x43, 0xbd, 0xf7, 0x2a, 0x44, 0x5d,
+	0xe2, 0x3f, 0xca, 0x99, 0x57, 0xef, 0x49, 0x47, 0x4e, 0x43, 0xd4, 0x86, 0x6a, 0x18, 0x9d, 0x92,
+	0x12, 0x4f, 0x6e, 0x26, 0x16, 0x47, 0x5a, 0xf1, 0x0f, 0x49, 0xc2, 0xdf, 0x08, 0xc7, 0xff, 0xac,
+	0x3e, 0xfc, 0x18, 0xaa, 0x49, 0x41, 0x3b, 0xb0, 0xfd, 0x6d, 0xf7, 0x69, 0xf7, 0xf2, 0xbb, 0xae,
+	0x5e, 0x42, 0x35, 0xd8, 0x6a, 0x5f, 0x5e, 0x36, 0x75, 0x4d, 0x5d, 0x93, 0xd6, 0xf3, 0xcb, 0xa7,
+	0xad, 0xa6, 0x5e, 0x56, 0x1f, 0xad, 0xef, 0xbf, 0xbe, 0x20, 0xad, 0xa6, 0x5e, 0xc1, 0x36, 0xdc,
+	0x56, 0xef, 0x4e, 0x3a, 0xff, 0xe7, 0xe1, 0xef, 0x43, 0xc5, 0x26, 0x1d, 0x74, 0x17, 0xb6, 0x5d,
+	0xe1, 0x47, 0x43, 0xab, 0x45, 0x43, 0x5b, 0x75, 0x85, 0xdf, 0xa4, 0x02, 0x5f, 0xc1, 0x83, 0x0e,
+	0x0b, 0x65, 0xeb, 0xd7, 0x09, 0x13, 0x2c, 0x18, 0x2d, 0xaa, 0x2f, 0x4d, 0xfb, 0x31, 0x54, 0x5f,
+	0x32, 0xf9, 0x13, 0x0b, 0x92, 0x11, 0x39, 0x5c, 0x29, 0xbc, 0x99, 0x6c, 0x50, 0x92, 0x38, 0xe2,
+	0x9f, 0xe1, 0x2e, 0xa1, 0x01, 0x7d, 0xb9, 0x00, 0x97, 0xa2, 0x7d, 0x04, 0xe5, 0x30, 0x45, 0xba,
+	0xb7, 0xc2, 0x3e, 0x71, 0xb6, 0x7a, 0x5d, 0x52, 0x0e, 0x03, 0x84, 0xa1, 0xe2, 0x86, 0x22, 0x79,
+	0x2f, 0x3d, 0xeb, 0xdd, 0x23, 0x44, 0x19, 0x4f, 0xff, 0xaa, 0x41, 0xdd, 0x4e, 0xc7, 0x01, 0xd9,
+	0x70, 0x2b, 0x12, 0x0b, 0x7a, 0x3f, 0x67, 0x5e, 0x16, 0xa5, 0x64, 0xbe, 0x95, 0x85, 0x6c, 0x28,
+	0x1c, 0x5c, 0x42, 0xe7, 0x80, 0x7a, 0x6c, 0x14, 0x24, 0x0b, 0x22, 0xe1, 0x84, 0x56, 0xf2, 0x9b,
+	0x46, 0x1e, 0x7f, 0x5c, 0x42, 0x57, 0xb0, 0xd3, 0xa6, 0x32, 0x5d, 0x1d, 0x68, 0x53, 0xa9, 0x66,
+	0x41, 0xd1, 0xe1, 0x12, 0x6a, 0xc1, 0x41, 0x2c, 0xe3, 0x45, 0x62, 0x1b, 0xb1, 0x0f, 0x32, 0xc6,
+	0xe7, 0x9c, 0x79, 0xb8, 0x84, 0x3a, 0xf3, 0xed, 0x97, 0x62, 0x3c, 0xca, 0xa1, 0x90, 0x5d, 0x92,
+	0xeb, 0xd1, 0xbe, 0x01, 0xfd, 0x4b, 0x16, 0x2c, 0xc2, 0x85, 0x68, 0xfd, 0x06, 0x35, 0x1f, 0xe6,
+	0xa4, 0xc9, 0xec, 0x40, 0x5c, 0x42, 0xcf, 0x40, 0x57, 0x63, 0x99, 0x81, 0x5c, 0xcd, 0x5d, 0x18,
+	0xee, 0x2b, 0xa8, 0xb6, 0xa9, 0x6c, 0xf8, 0xfe, 0x3a, 0x90, 0x0f, 0x0b, 0xff, 0xfd, 0xc2, 0x25,
+	0x74, 0x06, 0x7b, 0x36, 0xf7, 0x7d, 0xea, 0xca, 0xb6, 0x23, 0x06, 0xce, 0x88, 0xae, 0x43, 0x5c,
+	0xdb, 0xa5, 0xdf, 0xc0, 0xc8, 0x53, 0x1a, 0xfa, 0x34, 0x87, 0xc2, 0x0d, 0xd2, 0x7c, 0x3d, 0xea,
+	0x3f, 0x82, 0xbe, 0x2c, 0x4a, 0x64, 0xe5, 0x00, 0xe4, 0xa8, 0x77, 0xe3, 0xc4, 0xf7, 0xe1, 0x6d,
+	0x25, 0xae, 0x95, 0x4d, 0xbe, 0x71, 0x3c, 0x8f, 0x8b, 0xae, 0xf5, 0x68, 0x6a, 0xab, 0xf1, 0x42,
+	0x44, 0x0f, 0x37, 0x88, 0x7b, 0xbe, 0x2f, 0x4d, 0x33, 0x0f, 0x9b, 0x74, 0x70, 0xe9, 0xbc, 0xf6,
+	0x43, 0x35, 0xfe, 0x07, 0x71, 0x10, 0xff, 0x7e, 0xf2, 0xef, 0x00, 0x80, 0xb3, 0xf9, 0xed, 0x98,
+	0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -337,6 +665,7 @@ type CertifierClient interface {
 	GetIdentity(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*CertificateInfo, error)
 	// Revoke an existing certificate.
 	// If the certificate does not exist or is expired, this request is ignored.
+	// Revoked certificates are listed in the CRL until they expire.
 	//
 	RevokeCertificate(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*protos.Void, error)
 	// Add provided Certificate (AddCertRequest.cert_der) into Certifier table and
@@ -352,6 +681,21 @@ type CertifierClient interface {
 	// cleanup expired certificates
 	//
 	CollectGarbage(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns all certificates which are still valid but expire within the
+	// requested duration
+	ListExpiringCertificates(ctx context.Context, in *ListExpiringCertificatesRequest, opts ...grpc.CallOption) (*CertificateInfoMap, error)
+	// Signs a new certificate for the Identity of an existing, still valid
+	// certificate. The existing certificate is left in place until it expires.
+	// Returns signed certificate.
+	//
+	RenewCertificate(ctx context.Context, in *RenewCertificateRequest, opts ...grpc.CallOption) (*protos.Certificate, error)
+	// Returns the revocation status of a certificate.
+	// Unknown serial numbers are reported with UNKNOWN status.
+	//
+	GetCertificateStatus(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*CertificateStatus, error)
+	// Returns a CRL of all revoked, not yet expired certificates of the
+	// requested type, signed by the CA of that type
+	GetCRL(ctx context.Context, in *GetCRLRequest, opts ...grpc.CallOption) (*CRL, error)
 }
 
 type certifierClient struct {
@@ -443,6 +787,42 @@ func (c *certifierClient) CollectGarbage(ctx context.Context, in *protos.Void, o
 	return out, nil
 }
 
+func (c *certifierClient) ListExpiringCertificates(ctx context.Context, in *ListExpiringCertificatesRequest, opts ...grpc.CallOption) (*CertificateInfoMap, error) {
+	out := new(CertificateInfoMap)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/ListExpiringCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) RenewCertificate(ctx context.Context, in *RenewCertificateRequest, opts ...grpc.CallOption) (*protos.Certificate, error) {
+	out := new(protos.Certificate)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/RenewCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) GetCertificateStatus(ctx context.Context, in *protos.Certificate_SN, opts ...grpc.CallOption) (*CertificateStatus, error) {
+	out := new(CertificateStatus)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/GetCertificateStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certifierClient) GetCRL(ctx context.Context, in *GetCRLRequest, opts ...grpc.CallOption) (*CRL, error) {
+	out := new(CRL)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.certifier.Certifier/GetCRL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CertifierServer is the server API for Certifier service.
 type CertifierServer interface {
 	// Returns the cert of the requested CA
@@ -457,6 +837,7 @@ type CertifierServer interface {
 	GetIdentity(context.Context, *protos.Certificate_SN) (*CertificateInfo, error)
 	// Revoke an existing certificate.
 	// If the certificate does not exist or is expired, this request is ignored.
+	// Revoked certificates are listed in the CRL until they expire.
 	//
 	RevokeCertificate(context.Context, *protos.Certificate_SN) (*protos.Void, error)
 	// Add provided Certificate (AddCertRequest.cert_der) into Certifier table and
@@ -472,6 +853,21 @@ type CertifierServer interface {
 	// cleanup expired certificates
 	//
 	CollectGarbage(context.Context, *protos.Void) (*protos.Void, error)
+	// Returns all certificates which are still valid but expire within the
+	// requested duration
+	ListExpiringCertificates(context.Context, *ListExpiringCertificatesRequest) (*CertificateInfoMap, error)
+	// Signs a new certificate for the Identity of an existing, still valid
+	// certificate. The existing certificate is left in place until it expires.
+	// Returns signed certificate.
+	//
+	RenewCertificate(context.Context, *RenewCertificateRequest) (*protos.Certificate, error)
+	// Returns the revocation status of a certificate.
+	// Unknown serial numbers are reported with UNKNOWN status.
+	//
+	GetCertificateStatus(context.Context, *protos.Certificate_SN) (*CertificateStatus, error)
+	// Returns a CRL of all revoked, not yet expired certificates of the
+	// requested type, signed by the CA of that type
+	GetCRL(context.Context, *GetCRLRequest) (*CRL, error)
 }
 
 // UnimplementedCertifierServer can be embedded to have forward compatible implementations.
@@ -505,6 +901,18 @@ func (*UnimplementedCertifierServer) GetAll(ctx context.Context, req *protos.Voi
 func (*UnimplementedCertifierServer) CollectGarbage(ctx context.Context, req *protos.Void) (*protos.Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CollectGarbage not implemented")
 }
+func (*UnimplementedCertifierServer) ListExpiringCertificates(ctx context.Context, req *ListExpiringCertificatesRequest) (*CertificateInfoMap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringCertificates not implemented")
+}
+func (*UnimplementedCertifierServer) RenewCertificate(ctx context.Context, req *RenewCertificateRequest) (*protos.Certificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewCertificate not implemented")
+}
+func (*UnimplementedCertifierServer) GetCertificateStatus(ctx context.Context, req *protos.Certificate_SN) (*CertificateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCertificateStatus not implemented")
+}
+func (*UnimplementedCertifierServer) GetCRL(ctx context.Context, req *GetCRLRequest) (*CRL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCRL not implemented")
+}
 
 func RegisterCertifierServer(s *grpc.Server, srv CertifierServer) {
 	s.RegisterService(&_Certifier_serviceDesc, srv)
@@ -672,6 +1080,78 @@ func _Certifier_CollectGarbage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Certifier_ListExpiringCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).ListExpiringCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/ListExpiringCertificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).ListExpiringCertificates(ctx, req.(*ListExpiringCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_RenewCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).RenewCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/RenewCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).RenewCertificate(ctx, req.(*RenewCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_GetCertificateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Certificate_SN)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).GetCertificateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/GetCertificateStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).GetCertificateStatus(ctx, req.(*protos.Certificate_SN))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Certifier_GetCRL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCRLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertifierServer).GetCRL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.certifier.Certifier/GetCRL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertifierServer).GetCRL(ctx, req.(*GetCRLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Certifier_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.certifier.Certifier",
 	HandlerType: (*CertifierServer)(nil),
@@ -712,6 +1192,22 @@ var _Certifier_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CollectGarbage",
 			Handler:    _Certifier_CollectGarbage_Handler,
 		},
+		{
+			MethodName: "ListExpiringCertificates",
+			Handler:    _Certifier_ListExpiringCertificates_Handler,
+		},
+		{
+			MethodName: "RenewCertificate",
+			Handler:    _Certifier_RenewCertificate_Handler,
+		},
+		{
+			MethodName: "GetCertificateStatus",
+			Handler:    _Certifier_GetCertificateStatus_Handler,
+		},
+		{
+			MethodName: "GetCRL",
+			Handler:    _Certifier_GetCRL_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "certifier.proto",
@@ -22,10 +22,12 @@ import (
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/security/cert"
 	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+	"magma/orc8r/cloud/go/services/certifier/signer"
 
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -36,9 +38,18 @@ var (
 	CollectGarbageAfter time.Duration // remove cert if expired for certain amount of time
 )
 
+var expiringCertCount = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "certifier_expiring_certificates",
+		Help: "Number of valid certificates which expire within the reporting window",
+	},
+	[]string{"cert_type"},
+)
+
 func init() {
 	NumTrialsForSn = 1
 	CollectGarbageAfter = time.Duration(time.Hour * 24)
+	prometheus.MustRegister(expiringCertCount)
 }
 
 type CAInfo struct {
@@ -47,23 +58,78 @@ type CAInfo struct {
 }
 
 type CertifierServer struct {
-	store datastore.Api
-	CAs   map[protos.CertType]*CAInfo
+	store   datastore.Api
+	signers map[protos.CertType]signer.Signer
+
+	// CAs are the in-memory CAs of certifiers created with
+	// NewCertifierServer, CAs of cert types without a signer sign with their
+	// in-memory key.
+	//
+	// Deprecated: create the certifier with NewCertifierServerWithSigners
+	// instead.
+	CAs map[protos.CertType]*CAInfo
 }
 
+// NewCertifierServer returns a certifier which signs with the in-memory CA
+// keys provided in CAs
 func NewCertifierServer(store datastore.Api, CAs map[protos.CertType]*CAInfo) (srv *CertifierServer, err error) {
-	srv = new(CertifierServer)
-	srv.store = store
 	if CAs == nil {
 		return nil, fmt.Errorf("CA info not provided to certifier")
 	}
 	if len(CAs) == 0 {
 		return nil, fmt.Errorf("No Certificates are provided to certifier")
 	}
-	srv.CAs = CAs
+	for certType, ca := range CAs {
+		if _, err = newLocalSigner(ca); err != nil {
+			return nil, fmt.Errorf("Invalid %s CA: %s", certType.String(), err)
+		}
+	}
+	return &CertifierServer{store: store, signers: map[protos.CertType]signer.Signer{}, CAs: CAs}, nil
+}
+
+// NewCertifierServerWithSigners returns a certifier which issues
+// certificates of each type with the given CA signer
+func NewCertifierServerWithSigners(
+	store datastore.Api,
+	signers map[protos.CertType]signer.Signer,
+) (srv *CertifierServer, err error) {
+	srv = new(CertifierServer)
+	srv.store = store
+	if signers == nil {
+		return nil, fmt.Errorf("CA info not provided to certifier")
+	}
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("No Certificates are provided to certifier")
+	}
+	srv.signers = signers
 	return srv, nil
 }
 
+// getSigner returns the signer of the cert type's CA, false if there is no
+// CA for the cert type
+func (srv *CertifierServer) getSigner(certType protos.CertType) (signer.Signer, bool) {
+	if caSigner, ok := srv.signers[certType]; ok {
+		return caSigner, true
+	}
+	ca, ok := srv.CAs[certType]
+	if !ok {
+		return nil, false
+	}
+	caSigner, err := newLocalSigner(ca)
+	if err != nil {
+		glog.Errorf("Invalid %s CA: %s", certType.String(), err)
+		return nil, false
+	}
+	return caSigner, true
+}
+
+func newLocalSigner(ca *CAInfo) (signer.Signer, error) {
+	if ca == nil {
+		return nil, fmt.Errorf("CA info is nil")
+	}
+	return signer.NewLocalSigner(ca.Cert, ca.PrivKey, nil)
+}
+
 func generateSerialNumber(store datastore.Api, tableName string) (sn *big.Int, err error) {
 	limit := new(big.Int).Lsh(big.NewInt(1), 128)
 
@@ -101,15 +167,11 @@ func (srv *CertifierServer) signCSR(
 	validTime time.Duration,
 ) ([]byte, time.Time, time.Time, error) {
 
-	if srv.CAs == nil {
-		return nil, time.Time{}, time.Time{}, fmt.Errorf("CAInfo not found")
-	}
-	ca, ok := srv.CAs[certType]
+	caSigner, ok := srv.getSigner(certType)
 	if !ok {
 		return nil, time.Time{}, time.Time{}, fmt.Errorf("No CA found for given cert type: %s", certType.String())
 	}
-	signingCert := ca.Cert
-	signingKey := ca.PrivKey
+	signingCert := caSigner.Certificate()
 
 	now := clock.Now().UTC()
 	// Provide a cert from an hour ago to account for clock skews
@@ -129,8 +191,7 @@ func (srv *CertifierServer) signCSR(
 		BasicConstraintsValid: true,
 	}
 
-	clientCertDER, err := x509.CreateCertificate(
-		rand.Reader, &template, signingCert, csr.PublicKey, signingKey)
+	clientCertDER, err := caSigner.CreateCertificate(&template, csr.PublicKey)
 	if err != nil {
 		return nil, time.Time{}, time.Time{}, fmt.Errorf("Failed to sign csr: %s", err)
 	}
@@ -182,16 +243,13 @@ func (srv *CertifierServer) getCertInfo(sn string) (*certprotos.CertificateInfo,
 // Verify that the certificate is signed by our CA
 func (srv *CertifierServer) verifyCert(clientCert *x509.Certificate, certType protos.CertType) error {
 	// Check if CAInfo / cert exists for requested cert type
-	if srv.CAs == nil {
-		return fmt.Errorf("CAInfo not found")
-	}
-	ca, ok := srv.CAs[certType]
+	caSigner, ok := srv.getSigner(certType)
 	if !ok {
 		return fmt.Errorf("No CA found for given cert type: %s", certType.String())
 	}
 
 	caPool := x509.NewCertPool()
-	caPool.AddCert(ca.Cert) // Use appropriate cert to check against
+	caPool.AddCert(caSigner.Certificate()) // Use appropriate cert to check against
 	opts := x509.VerifyOptions{
 		Roots:         caPool,
 		Intermediates: x509.NewCertPool(),
@@ -209,12 +267,12 @@ func (srv *CertifierServer) GetCA(ctx context.Context, getCAReqMsg *certprotos.G
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid CA request")
 	}
 
-	ca, ok := srv.CAs[getCAReqMsg.CertType]
+	caSigner, ok := srv.getSigner(getCAReqMsg.CertType)
 	if !ok {
 		return nil, fmt.Errorf("no CA found for given CA type: %s", getCAReqMsg.CertType.String())
 	}
 
-	caCertMsg := &protos.CACert{Cert: ca.Cert.Raw}
+	caCertMsg := &protos.CACert{Cert: caSigner.Certificate().Raw}
 
 	return caCertMsg, nil
 }
@@ -276,6 +334,9 @@ func (srv *CertifierServer) SignAddCertificate(ctx context.Context, csrMsg *prot
 		NotAfter:  notAfterProto,
 		CertDer:   certDER,
 	}
+	if caSigner, ok := srv.getSigner(csrMsg.CertType); ok {
+		certMsg.CertChainDer = caSigner.Chain()
+	}
 	return &certMsg, nil
 }
 
@@ -316,10 +377,16 @@ func (srv *CertifierServer) RevokeCertificate(
 	if snMsg != nil {
 		certSN = strings.TrimLeft(snMsg.Sn, "0")
 	}
-	_, _, err := srv.store.Get(CERTIFICATE_INFO_TABLE, certSN)
+	certInfo, err := srv.getCertInfo(certSN)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Cannot find certificate with SN: %s", certSN)
 	}
+	// Record the revocation before removing the certificate, so a failed
+	// delete never leaves a revoked certificate out of the CRL
+	err = srv.addRevokedCertInfo(certSN, certInfo)
+	if err != nil {
+		return nil, status.Errorf(codes.Aborted, "Failed to record certificate revocation: %s", err)
+	}
 	err = srv.store.Delete(CERTIFICATE_INFO_TABLE, certSN)
 	if err != nil {
 		return nil, status.Errorf(codes.Aborted, "Failed to delete certificate: %s", err)
@@ -468,6 +535,12 @@ func (srv *CertifierServer) CollectGarbage(ctx context.Context, void *protos.Voi
 	if count > 0 {
 		glog.V(2).Infof("Removed %d stale certificates", count)
 	}
+	if err = srv.collectRevokedGarbage(); err != nil {
+		errorList = append(errorList, struct {
+			sn  string
+			err error
+		}{"revoked", err})
+	}
 	if len(errorList) > 0 {
 		msg := "Failed to delete certificate[s]:"
 		for _, e := range errorList {
@@ -478,3 +551,95 @@ func (srv *CertifierServer) CollectGarbage(ctx context.Context, void *protos.Voi
 	}
 	return res, nil
 }
+
+// ListExpiringCertificates returns all certificates which are still valid
+// but expire within the requested duration
+func (srv *CertifierServer) ListExpiringCertificates(
+	ctx context.Context, req *certprotos.ListExpiringCertificatesRequest) (*certprotos.CertificateInfoMap, error) {
+
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid expiring certificates request")
+	}
+	within, err := ptypes.Duration(req.Within)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid expiration window: %s", err)
+	}
+	all, err := srv.GetAll(ctx, &protos.Void{})
+	if err != nil {
+		return nil, err
+	}
+	now := clock.Now().UTC()
+	deadline := now.Add(within)
+	res := &certprotos.CertificateInfoMap{Certificates: map[string]*certprotos.CertificateInfo{}}
+	for sn, certInfo := range all.Certificates {
+		notAfter, err := ptypes.Timestamp(certInfo.NotAfter)
+		if err != nil {
+			glog.Errorf("Invalid expiration time of certificate with serial number %s: %s", sn, err)
+			continue
+		}
+		if notAfter.After(now) && !notAfter.After(deadline) {
+			res.Certificates[sn] = certInfo
+		}
+	}
+	return res, nil
+}
+
+// ReportExpiringCertificates updates the expiring certificates metric with
+// the number of valid certificates of each type which expire within the
+// given duration
+func (srv *CertifierServer) ReportExpiringCertificates(within time.Duration) error {
+	expiring, err := srv.ListExpiringCertificates(
+		context.Background(),
+		&certprotos.ListExpiringCertificatesRequest{Within: ptypes.DurationProto(within)})
+	if err != nil {
+		return err
+	}
+	counts := map[protos.CertType]int{}
+	for certType := range srv.signers {
+		counts[certType] = 0
+	}
+	for certType := range srv.CAs {
+		counts[certType] = 0
+	}
+	for _, certInfo := range expiring.Certificates {
+		counts[certInfo.CertType]++
+	}
+	for certType, count := range counts {
+		expiringCertCount.WithLabelValues(certType.String()).Set(float64(count))
+	}
+	return nil
+}
+
+// RenewCertificate signs a new certificate for the Identity associated with
+// an existing, still valid certificate. The existing certificate is not
+// revoked, it remains usable until it expires.
+func (srv *CertifierServer) RenewCertificate(
+	ctx context.Context, req *certprotos.RenewCertificateRequest) (*protos.Certificate, error) {
+
+	if req == nil || req.Sn == nil || req.Csr == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid certificate renewal request")
+	}
+	certSN := strings.TrimLeft(req.Sn.Sn, "0")
+	certInfo, err := srv.getCertInfo(certSN)
+	if err != nil {
+		return nil, err
+	}
+	notBefore, _ := ptypes.Timestamp(certInfo.NotBefore)
+	notAfter, _ := ptypes.Timestamp(certInfo.NotAfter)
+	now := clock.Now().UTC()
+	if now.After(notAfter) || now.Before(notBefore) {
+		return nil, status.Errorf(codes.OutOfRange,
+			"Certificate with serial number '%s' is not valid, it cannot be renewed", certSN)
+	}
+	if req.Csr.Id != nil && req.Csr.Id.HashString() != certInfo.Id.HashString() {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"CSR Identity does not match Identity of certificate with serial number '%s'", certSN)
+	}
+	if req.Csr.CertType != certInfo.CertType {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"CSR certificate type does not match type of certificate with serial number '%s'", certSN)
+	}
+	csrMsg := proto.Clone(req.Csr).(*protos.CSR)
+	csrMsg.Id = certInfo.Id
+	return srv.SignAddCertificate(ctx, csrMsg)
+}
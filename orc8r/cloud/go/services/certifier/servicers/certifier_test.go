@@ -10,11 +10,14 @@ package servicers_test
 
 import (
 	"crypto/x509"
+	"math/big"
 	"testing"
 	"time"
 
 	"magma/orc8r/cloud/go/protos"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
 	"magma/orc8r/cloud/go/services/certifier/servicers"
+	"magma/orc8r/cloud/go/services/certifier/signer"
 	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
 	"magma/orc8r/cloud/go/test_utils"
 
@@ -22,6 +25,8 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestCertifier(t *testing.T) {
@@ -116,3 +121,206 @@ func TestCertifier(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, cert.Subject.CommonName, *csrMsg.Id.ToCommonName())
 }
+
+func TestListExpiringCertificates(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {caCert, caKey},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	sign := func(validTime time.Duration) string {
+		csrMsg, err := certifier_test_utils.CreateCSR(validTime, "cn", "cn")
+		assert.NoError(t, err)
+		certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+		assert.NoError(t, err)
+		return certMsg.Sn.Sn
+	}
+	expired := sign(0)
+	soon := sign(time.Hour)
+	later := sign(time.Hour * 24 * 3)
+
+	res, err := srv.ListExpiringCertificates(
+		ctx, &certprotos.ListExpiringCertificatesRequest{Within: ptypes.DurationProto(time.Hour * 2)})
+	assert.NoError(t, err)
+	assert.Len(t, res.Certificates, 1)
+	assert.Contains(t, res.Certificates, soon)
+	assert.NotContains(t, res.Certificates, expired)
+
+	res, err = srv.ListExpiringCertificates(
+		ctx, &certprotos.ListExpiringCertificatesRequest{Within: ptypes.DurationProto(time.Hour * 24 * 4)})
+	assert.NoError(t, err)
+	assert.Len(t, res.Certificates, 2)
+	assert.Contains(t, res.Certificates, soon)
+	assert.Contains(t, res.Certificates, later)
+
+	_, err = srv.ListExpiringCertificates(ctx, nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, srv.ReportExpiringCertificates(time.Hour*2))
+}
+
+func TestRenewCertificate(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {caCert, caKey},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	gwID := protos.NewGatewayIdentity("hw1", "", "")
+	csrMsg, err := certifier_test_utils.CreateCSRForId(time.Hour, gwID)
+	assert.NoError(t, err)
+	oldCert, err := srv.SignAddCertificate(ctx, csrMsg)
+	assert.NoError(t, err)
+
+	// renew with a new CSR for the same identity
+	csrMsg, err = certifier_test_utils.CreateCSRForId(time.Hour*4, gwID)
+	assert.NoError(t, err)
+	newCert, err := srv.RenewCertificate(
+		ctx, &certprotos.RenewCertificateRequest{Sn: oldCert.Sn, Csr: csrMsg})
+	assert.NoError(t, err)
+	assert.NotEqual(t, oldCert.Sn.Sn, newCert.Sn.Sn)
+	certInfo, err := srv.GetIdentity(ctx, newCert.Sn)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(gwID, certInfo.Id))
+
+	// the old certificate remains valid until it expires
+	_, err = srv.GetIdentity(ctx, oldCert.Sn)
+	assert.NoError(t, err)
+
+	// renewal cannot change the identity
+	csrMsg, err = certifier_test_utils.CreateCSRForId(time.Hour, protos.NewGatewayIdentity("hw2", "", ""))
+	assert.NoError(t, err)
+	_, err = srv.RenewCertificate(
+		ctx, &certprotos.RenewCertificateRequest{Sn: oldCert.Sn, Csr: csrMsg})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// unknown certificate
+	csrMsg, err = certifier_test_utils.CreateCSRForId(time.Hour, gwID)
+	assert.NoError(t, err)
+	_, err = srv.RenewCertificate(
+		ctx, &certprotos.RenewCertificateRequest{Sn: &protos.Certificate_SN{Sn: "1234"}, Csr: csrMsg})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// expired certificate
+	csrMsg, err = certifier_test_utils.CreateCSRForId(0, gwID)
+	assert.NoError(t, err)
+	expiredCert, err := srv.SignAddCertificate(ctx, csrMsg)
+	assert.NoError(t, err)
+	csrMsg, err = certifier_test_utils.CreateCSRForId(time.Hour, gwID)
+	assert.NoError(t, err)
+	_, err = srv.RenewCertificate(
+		ctx, &certprotos.RenewCertificateRequest{Sn: expiredCert.Sn, Csr: csrMsg})
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestCertifierWithSigners(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	rootCert, rootKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caCert, caKey, err := certifier_test_utils.CreateIntermediateCertAndPrivKey(
+		time.Duration(time.Hour*24*10), rootCert, rootKey)
+	assert.NoError(t, err)
+
+	token := signer.NewSoftToken()
+	assert.NoError(t, token.ImportKey("default", caKey))
+	caSigner, err := signer.NewTokenSigner(caCert, token, "default", [][]byte{rootCert.Raw})
+	assert.NoError(t, err)
+	srv, err := servicers.NewCertifierServerWithSigners(
+		ds, map[protos.CertType]signer.Signer{protos.CertType_DEFAULT: caSigner})
+	assert.NoError(t, err)
+
+	_, err = servicers.NewCertifierServerWithSigners(ds, map[protos.CertType]signer.Signer{})
+	assert.Error(t, err)
+
+	// GetCA returns the issuing CA
+	caMsg, err := srv.GetCA(ctx, &certprotos.GetCARequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	assert.Equal(t, caCert.Raw, caMsg.Cert)
+
+	// issued certificates carry the chain to the root
+	csrMsg, err := certifier_test_utils.CreateCSR(time.Hour, "cn", "cn")
+	assert.NoError(t, err)
+	certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{rootCert.Raw}, certMsg.CertChainDer)
+
+	cert, err := x509.ParseCertificate(certMsg.CertDer)
+	assert.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(caCert)
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	assert.NoError(t, err)
+
+	// certificates issued by the intermediate outside of certifier can be added
+	template := *cert
+	template.SerialNumber = big.NewInt(42)
+	opCertDER, err := caSigner.CreateCertificate(&template, cert.PublicKey)
+	assert.NoError(t, err)
+	_, err = srv.AddCertificate(ctx, &certprotos.AddCertRequest{
+		Id:       protos.NewOperatorIdentity("op"),
+		CertDer:  opCertDER,
+		CertType: protos.CertType_DEFAULT,
+	})
+	assert.NoError(t, err)
+}
+
+func TestCertifierDeprecatedCAs(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+	assert.Equal(t, caMap, srv.CAs)
+
+	// CAs added to the deprecated field are used for signing
+	vpnCert, vpnKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	srv.CAs[protos.CertType_VPN] = &servicers.CAInfo{Cert: vpnCert, PrivKey: vpnKey}
+	caMsg, err := srv.GetCA(ctx, &certprotos.GetCARequest{CertType: protos.CertType_VPN})
+	assert.NoError(t, err)
+	assert.Equal(t, vpnCert.Raw, caMsg.Cert)
+	csrMsg, err := certifier_test_utils.CreateCSR(time.Hour, "cn", "cn")
+	assert.NoError(t, err)
+	csrMsg.CertType = protos.CertType_VPN
+	certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+	assert.NoError(t, err)
+	assert.Empty(t, certMsg.CertChainDer)
+	cert, err := x509.ParseCertificate(certMsg.CertDer)
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(vpnCert))
+
+	// invalid CAs are rejected
+	_, err = servicers.NewCertifierServer(ds, map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, PrivKey: vpnKey},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/protos"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CRLValidity is the time after which consumers of a generated CRL should
+// fetch a new one (CRL nextUpdate)
+var CRLValidity = time.Hour * 24
+
+// GetCertificateStatus returns the revocation status of the certificate
+// with the given serial number
+func (srv *CertifierServer) GetCertificateStatus(
+	ctx context.Context, snMsg *protos.Certificate_SN) (*certprotos.CertificateStatus, error) {
+
+	if snMsg == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing certificate serial number")
+	}
+	certSN := strings.TrimLeft(snMsg.Sn, "0")
+
+	// Revoked certificates are recorded before they are removed from the
+	// certificate table, so the revocation record takes precedence
+	revoked, err := srv.getRevokedCertInfo(certSN)
+	if err == nil {
+		return &certprotos.CertificateStatus{
+			Status:         certprotos.CertificateStatus_REVOKED,
+			RevocationTime: revoked.RevocationTime,
+			NotAfter:       revoked.GetInfo().GetNotAfter(),
+		}, nil
+	}
+	if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	marshalledCertInfo, _, err := srv.store.Get(CERTIFICATE_INFO_TABLE, certSN)
+	if err != nil {
+		if datastore.IsErrNotFound(err) {
+			return &certprotos.CertificateStatus{Status: certprotos.CertificateStatus_UNKNOWN}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to load certificate record: %s", err)
+	}
+	certInfo := &certprotos.CertificateInfo{}
+	if err = proto.Unmarshal(marshalledCertInfo, certInfo); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to unmarshal certificate record: %s", err)
+	}
+	res := &certprotos.CertificateStatus{
+		Status:   certprotos.CertificateStatus_GOOD,
+		NotAfter: certInfo.NotAfter,
+	}
+	notAfter, _ := ptypes.Timestamp(certInfo.NotAfter)
+	if clock.Now().UTC().After(notAfter) {
+		res.Status = certprotos.CertificateStatus_EXPIRED
+	}
+	return res, nil
+}
+
+// GetCRL returns a CRL of all revoked, unexpired certificates of the
+// requested type
+func (srv *CertifierServer) GetCRL(ctx context.Context, req *certprotos.GetCRLRequest) (*certprotos.CRL, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid CRL request")
+	}
+	crlDER, err := srv.GenerateCRL(req.CertType)
+	if err != nil {
+		return nil, err
+	}
+	return &certprotos.CRL{CrlDer: crlDER}, nil
+}
+
+// GenerateCRL creates a DER encoded CRL of all revoked, unexpired
+// certificates of the given type, signed by the CA of that type
+func (srv *CertifierServer) GenerateCRL(certType protos.CertType) ([]byte, error) {
+	caSigner, ok := srv.getSigner(certType)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "No CA found for given cert type: %s", certType.String())
+	}
+	revokedSNs, err := srv.store.ListKeys(REVOKED_CERTIFICATE_INFO_TABLE)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list revoked certificates: %s", err)
+	}
+	now := clock.Now().UTC()
+	revokedCerts := []pkix.RevokedCertificate{}
+	for _, sn := range revokedSNs {
+		// A single bad record mustn't prevent publishing the other revocations
+		revoked, err := srv.getRevokedCertInfo(sn)
+		if err != nil {
+			glog.Errorf("Skipping revoked certificate %s in CRL: %s", sn, err)
+			continue
+		}
+		info := revoked.GetInfo()
+		if info.GetCertType() != certType {
+			continue
+		}
+		notAfter, _ := ptypes.Timestamp(info.GetNotAfter())
+		if now.After(notAfter) {
+			continue
+		}
+		serialNumber, ok := new(big.Int).SetString(sn, 16)
+		if !ok {
+			glog.Errorf("Invalid serial number of revoked certificate: %s", sn)
+			continue
+		}
+		revocationTime, _ := ptypes.Timestamp(revoked.RevocationTime)
+		revokedCerts = append(revokedCerts, pkix.RevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: revocationTime,
+		})
+	}
+
+	crlDER, err := caSigner.CreateCRL(revokedCerts, now, now.Add(CRLValidity))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create CRL: %s", err)
+	}
+	return crlDER, nil
+}
+
+func (srv *CertifierServer) addRevokedCertInfo(sn string, certInfo *certprotos.CertificateInfo) error {
+	revocationTime, err := ptypes.TimestampProto(clock.Now().UTC())
+	if err != nil {
+		return err
+	}
+	marshaled, err := proto.Marshal(&certprotos.RevokedCertificateInfo{
+		Info:           certInfo,
+		RevocationTime: revocationTime,
+	})
+	if err != nil {
+		return err
+	}
+	return srv.store.Put(REVOKED_CERTIFICATE_INFO_TABLE, sn, marshaled)
+}
+
+func (srv *CertifierServer) getRevokedCertInfo(sn string) (*certprotos.RevokedCertificateInfo, error) {
+	marshaled, _, err := srv.store.Get(REVOKED_CERTIFICATE_INFO_TABLE, sn)
+	if err != nil {
+		if datastore.IsErrNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "Failed to load revoked certificate: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to load revoked certificate: %s", err)
+	}
+	revoked := &certprotos.RevokedCertificateInfo{}
+	err = proto.Unmarshal(marshaled, revoked)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to unmarshal revoked certificate record: %s", err)
+	}
+	return revoked, nil
+}
+
+// collectRevokedGarbage removes revocation records of certificates which
+// expired more than CollectGarbageAfter ago, they no longer need to be
+// listed in the CRL
+func (srv *CertifierServer) collectRevokedGarbage() error {
+	revokedSNs, err := srv.store.ListKeys(REVOKED_CERTIFICATE_INFO_TABLE)
+	if err != nil {
+		return err
+	}
+	var staleSNs []string
+	for _, sn := range revokedSNs {
+		revoked, err := srv.getRevokedCertInfo(sn)
+		if err != nil {
+			return err
+		}
+		notAfter, _ := ptypes.Timestamp(revoked.GetInfo().GetNotAfter())
+		if clock.Now().UTC().After(notAfter.Add(CollectGarbageAfter)) {
+			staleSNs = append(staleSNs, sn)
+		}
+	}
+	if len(staleSNs) == 0 {
+		return nil
+	}
+	failed, err := srv.store.DeleteMany(REVOKED_CERTIFICATE_INFO_TABLE, staleSNs)
+	if err != nil {
+		return fmt.Errorf("failed to delete revoked certificate records %v: %s", failed, err)
+	}
+	glog.V(2).Infof("Removed %d stale revoked certificate records", len(staleSNs))
+	return nil
+}
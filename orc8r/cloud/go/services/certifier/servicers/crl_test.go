@@ -0,0 +1,173 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	certprotos "magma/orc8r/cloud/go/services/certifier/protos"
+	"magma/orc8r/cloud/go/services/certifier/servicers"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+	"magma/orc8r/cloud/go/test_utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCertificateRevocation(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	vpnCert, vpnKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(
+		time.Duration(time.Hour * 24 * 10))
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{
+		protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey},
+		protos.CertType_VPN:     {Cert: vpnCert, PrivKey: vpnKey},
+	}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	sign := func(validTime time.Duration) *protos.Certificate {
+		csrMsg, err := certifier_test_utils.CreateCSR(validTime, "cn", "cn")
+		assert.NoError(t, err)
+		certMsg, err := srv.SignAddCertificate(ctx, csrMsg)
+		assert.NoError(t, err)
+		return certMsg
+	}
+	good := sign(time.Hour)
+	revoked := sign(time.Hour)
+	expired := sign(0)
+
+	_, err = srv.RevokeCertificate(ctx, revoked.Sn)
+	assert.NoError(t, err)
+
+	certStatus, err := srv.GetCertificateStatus(ctx, good.Sn)
+	assert.NoError(t, err)
+	assert.Equal(t, certprotos.CertificateStatus_GOOD, certStatus.Status)
+	assert.True(t, proto.Equal(good.NotAfter, certStatus.NotAfter))
+
+	certStatus, err = srv.GetCertificateStatus(ctx, revoked.Sn)
+	assert.NoError(t, err)
+	assert.Equal(t, certprotos.CertificateStatus_REVOKED, certStatus.Status)
+	assert.NotNil(t, certStatus.RevocationTime)
+
+	certStatus, err = srv.GetCertificateStatus(ctx, expired.Sn)
+	assert.NoError(t, err)
+	assert.Equal(t, certprotos.CertificateStatus_EXPIRED, certStatus.Status)
+
+	certStatus, err = srv.GetCertificateStatus(ctx, &protos.Certificate_SN{Sn: "1234"})
+	assert.NoError(t, err)
+	assert.Equal(t, certprotos.CertificateStatus_UNKNOWN, certStatus.Status)
+
+	// CRL is signed by the CA and lists only the revoked certificate
+	crl, err := srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	crlList, err := x509.ParseCRL(crl.CrlDer)
+	assert.NoError(t, err)
+	assert.NoError(t, caCert.CheckCRLSignature(crlList))
+	revokedCerts := crlList.TBSCertList.RevokedCertificates
+	assert.Len(t, revokedCerts, 1)
+	revokedX509, err := x509.ParseCertificate(revoked.CertDer)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, revokedX509.SerialNumber.Cmp(revokedCerts[0].SerialNumber))
+
+	// VPN CRL doesn't list certificates of other types
+	crl, err = srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_VPN})
+	assert.NoError(t, err)
+	crlList, err = x509.ParseCRL(crl.CrlDer)
+	assert.NoError(t, err)
+	assert.NoError(t, vpnCert.CheckCRLSignature(crlList))
+	assert.Empty(t, crlList.TBSCertList.RevokedCertificates)
+
+	// expired revoked certificates are dropped from the CRL and collected
+	_, err = srv.RevokeCertificate(ctx, expired.Sn)
+	assert.NoError(t, err)
+	crl, err = srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	crlList, err = x509.ParseCRL(crl.CrlDer)
+	assert.NoError(t, err)
+	assert.Len(t, crlList.TBSCertList.RevokedCertificates, 1)
+
+	servicers.CollectGarbageAfter = time.Duration(0)
+	_, err = srv.CollectGarbage(ctx, &protos.Void{})
+	assert.NoError(t, err)
+	revokedSNs, err := ds.ListKeys(servicers.REVOKED_CERTIFICATE_INFO_TABLE)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{revoked.Sn.Sn}, revokedSNs)
+}
+
+func TestCertificateRevocation_PartialRecords(t *testing.T) {
+	ds := test_utils.NewMockDatastore()
+	ctx := context.Background()
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24 * 10)
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey}}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	csrMsg, err := certifier_test_utils.CreateCSR(time.Hour, "cn", "cn")
+	assert.NoError(t, err)
+	cert, err := srv.SignAddCertificate(ctx, csrMsg)
+	assert.NoError(t, err)
+
+	// a revocation whose certificate delete failed is still revoked
+	certInfo, _, err := ds.Get(servicers.CERTIFICATE_INFO_TABLE, cert.Sn.Sn)
+	assert.NoError(t, err)
+	_, err = srv.RevokeCertificate(ctx, cert.Sn)
+	assert.NoError(t, err)
+	assert.NoError(t, ds.Put(servicers.CERTIFICATE_INFO_TABLE, cert.Sn.Sn, certInfo))
+	certStatus, err := srv.GetCertificateStatus(ctx, cert.Sn)
+	assert.NoError(t, err)
+	assert.Equal(t, certprotos.CertificateStatus_REVOKED, certStatus.Status)
+
+	// bad revocation records are left out of the CRL
+	assert.NoError(t, ds.Put(servicers.REVOKED_CERTIFICATE_INFO_TABLE, "abcd", []byte("not a proto")))
+	crl, err := srv.GetCRL(ctx, &certprotos.GetCRLRequest{CertType: protos.CertType_DEFAULT})
+	assert.NoError(t, err)
+	crlList, err := x509.ParseCRL(crl.CrlDer)
+	assert.NoError(t, err)
+	assert.Len(t, crlList.TBSCertList.RevokedCertificates, 1)
+}
+
+func TestGetCertificateStatus_StoreError(t *testing.T) {
+	ds := &failingGetDatastore{MockDatastore: test_utils.NewMockDatastore()}
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24 * 10)
+	assert.NoError(t, err)
+	caMap := map[protos.CertType]*servicers.CAInfo{protos.CertType_DEFAULT: {Cert: caCert, PrivKey: caKey}}
+	srv, err := servicers.NewCertifierServer(ds, caMap)
+	assert.NoError(t, err)
+
+	// store errors aren't reported as unknown certificates
+	ds.failGets = true
+	_, err = srv.GetCertificateStatus(context.Background(), &protos.Certificate_SN{Sn: "1234"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+type failingGetDatastore struct {
+	*test_utils.MockDatastore
+	failGets bool
+}
+
+func (d *failingGetDatastore) Get(table string, key string) ([]byte, uint64, error) {
+	if d.failGets {
+		return nil, 0, errors.New("database is unavailable")
+	}
+	return d.MockDatastore.Get(table, key)
+}
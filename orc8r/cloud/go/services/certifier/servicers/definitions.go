@@ -9,5 +9,6 @@ LICENSE file in the root directory of this source tree.
 package servicers
 
 const (
-	CERTIFICATE_INFO_TABLE = "certificate_info_db"
+	CERTIFICATE_INFO_TABLE         = "certificate_info_db"
+	REVOKED_CERTIFICATE_INFO_TABLE = "revoked_certificate_info_db"
 )
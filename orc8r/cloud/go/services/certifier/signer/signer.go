@@ -0,0 +1,119 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package signer provides the CA backends certifier issues certificates and
+// CRLs with. The CA private key is either held in process (local signer) or
+// by a PKCS#11-style token which only exposes signing operations.
+package signer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"time"
+)
+
+// Signer issues certificates and CRLs on behalf of a CA
+type Signer interface {
+	// Certificate returns the certificate of the CA
+	Certificate() *x509.Certificate
+
+	// Chain returns DER encoded intermediate CA certificates which chain the
+	// CA certificate to a root CA, issuer of the CA certificate first.
+	// Chain is empty if the CA is a root CA.
+	Chain() [][]byte
+
+	// CreateCertificate returns a DER encoded certificate based on the
+	// template for the given public key, signed by the CA
+	CreateCertificate(template *x509.Certificate, pub interface{}) ([]byte, error)
+
+	// CreateCRL returns a DER encoded CRL of the given revoked certificates,
+	// signed by the CA
+	CreateCRL(revoked []pkix.RevokedCertificate, now, nextUpdate time.Time) ([]byte, error)
+}
+
+// caSigner implements Signer for any crypto.Signer holding the CA key
+type caSigner struct {
+	cert  *x509.Certificate
+	key   crypto.Signer
+	chain [][]byte
+}
+
+// NewLocalSigner returns a Signer which signs with the CA private key held
+// in memory
+func NewLocalSigner(cert *x509.Certificate, privKey interface{}, chain [][]byte) (Signer, error) {
+	key, ok := privKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA private key type %T", privKey)
+	}
+	return newCASigner(cert, key, chain)
+}
+
+func newCASigner(cert *x509.Certificate, key crypto.Signer, chain [][]byte) (*caSigner, error) {
+	if cert == nil {
+		return nil, fmt.Errorf("CA certificate must be provided")
+	}
+	if err := checkKeyMatchesCert(cert, key.Public()); err != nil {
+		return nil, err
+	}
+	if err := checkChain(cert, chain); err != nil {
+		return nil, err
+	}
+	return &caSigner{cert: cert, key: key, chain: chain}, nil
+}
+
+func (s *caSigner) Certificate() *x509.Certificate {
+	return s.cert
+}
+
+func (s *caSigner) Chain() [][]byte {
+	return s.chain
+}
+
+func (s *caSigner) CreateCertificate(template *x509.Certificate, pub interface{}) ([]byte, error) {
+	return x509.CreateCertificate(rand.Reader, template, s.cert, pub, s.key)
+}
+
+func (s *caSigner) CreateCRL(revoked []pkix.RevokedCertificate, now, nextUpdate time.Time) ([]byte, error) {
+	return s.cert.CreateCRL(rand.Reader, s.key, revoked, now, nextUpdate)
+}
+
+func checkKeyMatchesCert(cert *x509.Certificate, pub crypto.PublicKey) error {
+	keyDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return fmt.Errorf("failed to marshal CA public key: %s", err)
+	}
+	certKeyDER, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal CA certificate public key: %s", err)
+	}
+	if !bytes.Equal(keyDER, certKeyDER) {
+		return fmt.Errorf("CA private key does not match CA certificate")
+	}
+	return nil
+}
+
+// checkChain verifies that every certificate of the chain is signed by the
+// one following it, starting with the CA certificate
+func checkChain(cert *x509.Certificate, chain [][]byte) error {
+	child := cert
+	for i, der := range chain {
+		parent, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("failed to parse chain certificate %d: %s", i, err)
+		}
+		if err = child.CheckSignatureFrom(parent); err != nil {
+			return fmt.Errorf("chain certificate %d is not the issuer of the preceding certificate: %s", i, err)
+		}
+		child = parent
+	}
+	return nil
+}
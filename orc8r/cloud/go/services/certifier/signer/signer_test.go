@@ -0,0 +1,118 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package signer_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/security/key"
+	"magma/orc8r/cloud/go/services/certifier/signer"
+	certifier_test_utils "magma/orc8r/cloud/go/services/certifier/test_utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalSigner(t *testing.T) {
+	rootCert, rootKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24)
+	assert.NoError(t, err)
+	caCert, caKey, err := certifier_test_utils.CreateIntermediateCertAndPrivKey(time.Hour*24, rootCert, rootKey)
+	assert.NoError(t, err)
+
+	s, err := signer.NewLocalSigner(caCert, caKey, [][]byte{rootCert.Raw})
+	assert.NoError(t, err)
+	assert.Equal(t, caCert, s.Certificate())
+	assert.Equal(t, [][]byte{rootCert.Raw}, s.Chain())
+	testSigner(t, s, rootCert)
+
+	// key doesn't match the certificate
+	_, err = signer.NewLocalSigner(caCert, rootKey, nil)
+	assert.EqualError(t, err, "CA private key does not match CA certificate")
+
+	// chain doesn't link the certificate
+	otherCert, _, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24)
+	assert.NoError(t, err)
+	_, err = signer.NewLocalSigner(caCert, caKey, [][]byte{otherCert.Raw})
+	assert.Error(t, err)
+	_, err = signer.NewLocalSigner(caCert, caKey, [][]byte{[]byte("garbage")})
+	assert.Error(t, err)
+
+	_, err = signer.NewLocalSigner(caCert, "not a key", nil)
+	assert.Error(t, err)
+}
+
+func TestTokenSigner(t *testing.T) {
+	caCert, caKey, err := certifier_test_utils.CreateSignedCertAndPrivKey(time.Hour * 24)
+	assert.NoError(t, err)
+
+	token := signer.NewSoftToken()
+	assert.NoError(t, token.ImportKey("default", caKey))
+	assert.Error(t, token.ImportKey("default", caKey))
+
+	s, err := signer.NewTokenSigner(caCert, token, "default", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, s.Chain())
+	testSigner(t, s, caCert)
+
+	_, err = signer.NewTokenSigner(caCert, token, "vpn", nil)
+	assert.EqualError(t, err, "failed to get public key vpn from token: key vpn not found")
+
+	otherKey, err := key.GenerateKey("", 2048)
+	assert.NoError(t, err)
+	assert.NoError(t, token.ImportKey("other", otherKey))
+	_, err = signer.NewTokenSigner(caCert, token, "other", nil)
+	assert.EqualError(t, err, "CA private key does not match CA certificate")
+}
+
+// testSigner checks that certificates issued by the signer verify against
+// root with the signer's chain, and that its CRLs are signed by the CA
+func testSigner(t *testing.T, s signer.Signer, root *x509.Certificate) {
+	priv, err := key.GenerateKey("", 2048)
+	assert.NoError(t, err)
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "gw"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := s.CreateCertificate(template, key.PublicKey(priv))
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	assert.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(s.Certificate())
+	for _, der := range s.Chain() {
+		chainCert, err := x509.ParseCertificate(der)
+		assert.NoError(t, err)
+		intermediates.AddCert(chainCert)
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	assert.NoError(t, err)
+
+	revoked := []pkix.RevokedCertificate{{SerialNumber: big.NewInt(42), RevocationTime: now}}
+	crlDER, err := s.CreateCRL(revoked, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	crl, err := x509.ParseCRL(crlDER)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Certificate().CheckCRLSignature(crl))
+	assert.Len(t, crl.TBSCertList.RevokedCertificates, 1)
+}
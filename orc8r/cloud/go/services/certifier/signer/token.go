@@ -0,0 +1,108 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package signer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Token is a PKCS#11-style key store: keys are referenced by label and only
+// public keys and signatures ever leave the token. Implementations may wrap
+// an HSM or a remote signing service.
+type Token interface {
+	// PublicKey returns the public key of the key pair with the given label
+	PublicKey(label string) (crypto.PublicKey, error)
+
+	// Sign signs the digest with the private key with the given label, see
+	// crypto.Signer
+	Sign(label string, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+}
+
+// NewTokenSigner returns a Signer which delegates all signing operations to
+// the key with the given label on the token
+func NewTokenSigner(cert *x509.Certificate, token Token, label string, chain [][]byte) (Signer, error) {
+	pub, err := token.PublicKey(label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key %s from token: %s", label, err)
+	}
+	return newCASigner(cert, &tokenKey{token: token, label: label, pub: pub}, chain)
+}
+
+// tokenKey is a crypto.Signer backed by a token key
+type tokenKey struct {
+	token Token
+	label string
+	pub   crypto.PublicKey
+}
+
+func (k *tokenKey) Public() crypto.PublicKey {
+	return k.pub
+}
+
+func (k *tokenKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return k.token.Sign(k.label, digest, opts)
+}
+
+// SoftToken is an in-memory Token. It stands in for an HSM or a remote
+// signing service where none is available, e.g. in development and tests.
+type SoftToken struct {
+	sync.RWMutex
+	keys map[string]crypto.Signer
+}
+
+// NewSoftToken returns an empty SoftToken
+func NewSoftToken() *SoftToken {
+	return &SoftToken{keys: map[string]crypto.Signer{}}
+}
+
+// ImportKey stores the private key on the token under the given label
+func (t *SoftToken) ImportKey(label string, privKey interface{}) error {
+	key, ok := privKey.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("unsupported private key type %T", privKey)
+	}
+	t.Lock()
+	defer t.Unlock()
+	if _, exists := t.keys[label]; exists {
+		return fmt.Errorf("key %s already exists", label)
+	}
+	t.keys[label] = key
+	return nil
+}
+
+func (t *SoftToken) PublicKey(label string) (crypto.PublicKey, error) {
+	key, err := t.getKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return key.Public(), nil
+}
+
+func (t *SoftToken) Sign(label string, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	key, err := t.getKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return key.Sign(rand.Reader, digest, opts)
+}
+
+func (t *SoftToken) getKey(label string) (crypto.Signer, error) {
+	t.RLock()
+	defer t.RUnlock()
+	key, ok := t.keys[label]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", label)
+	}
+	return key, nil
+}
@@ -105,3 +105,47 @@ func CreateSignedCertAndPrivKey(validTime time.Duration) (*x509.Certificate, int
 	}
 	return cert, priv, nil
 }
+
+// CreateIntermediateCertAndPrivKey returns a CA certificate & its private
+// key, signed by the given parent CA
+func CreateIntermediateCertAndPrivKey(
+	validTime time.Duration,
+	parent *x509.Certificate,
+	parentKey interface{},
+) (*x509.Certificate, interface{}, error) {
+	priv, err := key.GenerateKey("", 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to create key: %s", err)
+	}
+	notBefore := clock.Now().UTC()
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to create serial number: %s", err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(validTime),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		Subject: pkix.Name{
+			Country:            []string{"US"},
+			Organization:       []string{"FB TEST INTERMEDIATE CA"},
+			OrganizationalUnit: []string{"FB TEST INTERMEDIATE CA"},
+		},
+		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(
+		rand.Reader, &template, parent, key.PublicKey(priv), parentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to create certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to parse certificate: %s", err)
+	}
+	return cert, priv, nil
+}
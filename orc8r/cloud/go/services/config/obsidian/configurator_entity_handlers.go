@@ -14,6 +14,7 @@ import (
 
 	magma_errors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/services/configurator"
 
 	"github.com/labstack/echo"
@@ -32,7 +33,7 @@ func configuratorCreateEntityConfig(c echo.Context, networkID string, entityType
 		return obsidian.HttpError(errors.Wrap(err, fmt.Sprintf("Entity %s,%s does not exist in %s", entityType, entityKey, networkID)), http.StatusInternalServerError)
 	}
 	if !entityExists {
-		_, err = configurator.CreateEntity(networkID, configurator.NetworkEntity{
+		_, err = access.ConfigWriter(c).CreateEntity(networkID, configurator.NetworkEntity{
 			Key:    entityKey,
 			Type:   entityType,
 			Config: config,
@@ -41,7 +42,7 @@ func configuratorCreateEntityConfig(c echo.Context, networkID string, entityType
 			return obsidian.HttpError(errors.Wrap(err, "Failed to create entity"), http.StatusInternalServerError)
 		}
 	} else {
-		err := configurator.CreateOrUpdateEntityConfig(networkID, entityType, entityKey, config)
+		err := access.ConfigWriter(c).CreateOrUpdateEntityConfig(networkID, entityType, entityKey, config)
 		if err != nil {
 			return obsidian.HttpError(errors.Wrap(err, "Failed to create entity config"), http.StatusInternalServerError)
 		}
@@ -68,7 +69,7 @@ func configuratorUpdateEntityConfig(c echo.Context, networkID string, entityType
 	if nerr != nil {
 		return nerr
 	}
-	err := configurator.CreateOrUpdateEntityConfig(networkID, entityType, entityKey, config)
+	err := access.ConfigWriter(c).CreateOrUpdateEntityConfig(networkID, entityType, entityKey, config)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -76,7 +77,7 @@ func configuratorUpdateEntityConfig(c echo.Context, networkID string, entityType
 }
 
 func configuratorDeleteEntityConfig(c echo.Context, networkID string, entityType string, entityKey string) error {
-	err := configurator.DeleteEntityConfig(networkID, entityType, entityKey)
+	err := access.ConfigWriter(c).DeleteEntityConfig(networkID, entityType, entityKey)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	models2 "magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -40,7 +41,7 @@ func configuratorCreateGatewayConfig(c echo.Context, networkID string, configTyp
 	// access gateway to it
 	// note that this operation is not atomic, so there is a very slim but
 	// nonzero chance that the entity is created without the proper assoc
-	_, err := configurator.CreateEntity(networkID, configurator.NetworkEntity{
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, configurator.NetworkEntity{
 		Type:   configType,
 		Key:    configKey,
 		Config: config,
@@ -49,7 +50,7 @@ func configuratorCreateGatewayConfig(c echo.Context, networkID string, configTyp
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
-	_, err = configurator.UpdateEntity(networkID, configurator.EntityUpdateCriteria{
+	_, err = access.ConfigWriter(c).UpdateEntity(networkID, configurator.EntityUpdateCriteria{
 		Type:              orc8r.MagmadGatewayType,
 		Key:               configKey,
 		AssociationsToAdd: []storage.TypeAndKey{{Type: configType, Key: configKey}},
@@ -69,7 +70,7 @@ func configuratorDeleteGatewayConfig(c echo.Context, networkID string, configTyp
 		return configuratorDeleteMagmadGatewayConfig(c, networkID, configKey)
 	}
 
-	err := configurator.DeleteEntity(networkID, configType, configKey)
+	err := access.ConfigWriter(c).DeleteEntity(networkID, configType, configKey)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -88,7 +89,7 @@ func configuratorCreateMagmadGatewayConfig(c echo.Context, networkID string, gat
 		Key:       gatewayID,
 		NewConfig: requestedConfig,
 	}
-	_, err := configurator.UpdateEntities(networkID, []configurator.EntityUpdateCriteria{gwUpdate})
+	_, err := access.ConfigWriter(c).UpdateEntities(networkID, []configurator.EntityUpdateCriteria{gwUpdate})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -101,7 +102,7 @@ func configuratorDeleteMagmadGatewayConfig(c echo.Context, networkID, gatewayID
 		Key:          gatewayID,
 		DeleteConfig: true,
 	}
-	_, err := configurator.UpdateEntity(networkID, update)
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/services/configurator"
 
 	"github.com/labstack/echo"
@@ -43,7 +44,7 @@ func configuratorCreateNetworkConfig(c echo.Context, networkID string, configTyp
 	if nerr != nil {
 		return nerr
 	}
-	err := configurator.UpdateNetworkConfig(networkID, configType, config)
+	err := access.ConfigWriter(c).UpdateNetworkConfig(networkID, configType, config)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -66,7 +67,7 @@ func configuratorUpdateNetworkConfig(c echo.Context, networkID string, configTyp
 	if nerr != nil {
 		return nerr
 	}
-	err := configurator.UpdateNetworkConfig(networkID, configType, config)
+	err := access.ConfigWriter(c).UpdateNetworkConfig(networkID, configType, config)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -74,7 +75,7 @@ func configuratorUpdateNetworkConfig(c echo.Context, networkID string, configTyp
 }
 
 func configuratorDeleteNetworkConfig(c echo.Context, networkID string, configType string) error {
-	err := configurator.DeleteNetworkConfig(networkID, configType)
+	err := access.ConfigWriter(c).DeleteNetworkConfig(networkID, configType)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
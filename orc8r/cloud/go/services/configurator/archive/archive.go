@@ -0,0 +1,293 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package archive exports a network's configuration to a portable archive
+// and imports it into another orc8r deployment.
+package archive
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/device"
+
+	"github.com/pkg/errors"
+)
+
+// Version is the version of the archive format written by Export. Archives
+// of any other version are rejected when read.
+const Version = 1
+
+// Archive is a portable snapshot of a network: its metadata and configs, all
+// of its entities and their associations, and the device records of entities
+// with physical IDs. Configs and device records are kept in their serialized
+// form, keyed by serde type, so an archive can be imported into any orc8r
+// which has the same serdes registered.
+type Archive struct {
+	Version int `json:"version"`
+	// ExportedAt is the unix time in seconds at which the archive was created
+	ExportedAt int64 `json:"exported_at"`
+
+	Network  Network  `json:"network"`
+	Entities []Entity `json:"entities"`
+	Devices  []Device `json:"devices"`
+}
+
+type Network struct {
+	ID          string `json:"id"`
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Configs maps network config type to serialized config
+	Configs map[string][]byte `json:"configs,omitempty"`
+}
+
+type Entity struct {
+	Type        string `json:"type"`
+	Key         string `json:"key"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	PhysicalID  string `json:"physical_id,omitempty"`
+
+	// Config is the serialized entity config, keyed by the entity's type
+	Config []byte            `json:"config,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+
+	Associations []EntityID `json:"associations,omitempty"`
+}
+
+type EntityID struct {
+	Type string `json:"type"`
+	Key  string `json:"key"`
+}
+
+type Device struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	// Info is the serialized device record, keyed by the device's type
+	Info []byte `json:"info"`
+}
+
+// DefaultPageSize is the number of entities and device records which are
+// loaded at a time during exports.
+const DefaultPageSize = 500
+
+var exportEntityLoadCriteria = configurator.EntityLoadCriteria{
+	LoadMetadata:       true,
+	LoadConfig:         true,
+	LoadAssocsFromThis: true,
+	LoadLabels:         true,
+}
+
+// Export creates an archive of a network. The whole archive is held in
+// memory, use WriteExport to export large networks.
+func Export(networkID string) (*Archive, error) {
+	builder := &archiveBuilder{}
+	err := export(networkID, DefaultPageSize, builder)
+	if err != nil {
+		return nil, err
+	}
+	return builder.archive, nil
+}
+
+// WriteExport exports a network to w in the format written by Write.
+// Entities and device records are loaded pageSize at a time and written out
+// as they are loaded, so only the IDs of the network's devices are held in
+// memory for the whole export.
+// Each page is loaded in its own read transaction, so the archive of a
+// network which is written to during the export isn't a snapshot. See
+// configurator.LoadAllEntitiesInNetwork for what the result contains.
+func WriteExport(w io.Writer, networkID string, pageSize int) error {
+	if pageSize <= 0 {
+		return errors.Errorf("invalid page size %d", pageSize)
+	}
+	return export(networkID, pageSize, &archiveWriter{w: w})
+}
+
+// Write writes an archive to w as indented JSON.
+func Write(w io.Writer, archive *Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// Read reads an archive written by Write.
+func Read(r io.Reader) (*Archive, error) {
+	ret := &Archive{}
+	err := json.NewDecoder(r).Decode(ret)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode archive")
+	}
+	if ret.Version != Version {
+		return nil, errors.Errorf("unsupported archive version %d, expected %d", ret.Version, Version)
+	}
+	if ret.Network.ID == "" {
+		return nil, errors.New("archive is missing network ID")
+	}
+	return ret, nil
+}
+
+func exportNetwork(network configurator.Network) (Network, error) {
+	ret := Network{
+		ID:          network.ID,
+		Type:        network.Type,
+		Name:        network.Name,
+		Description: network.Description,
+		Configs:     make(map[string][]byte, len(network.Configs)),
+	}
+	for configType, config := range network.Configs {
+		bConfig, err := serde.Serialize(configurator.NetworkConfigSerdeDomain, configType, config)
+		if err != nil {
+			return Network{}, errors.Wrapf(err, "failed to serialize network config %s", configType)
+		}
+		ret.Configs[configType] = bConfig
+	}
+	return ret, nil
+}
+
+// export loads a network a page at a time and passes it to sink. Entities
+// are loaded in order of their IDs, device records in order of their types
+// and IDs.
+func export(networkID string, pageSize int, sink exportSink) error {
+	network, err := configurator.LoadNetwork(networkID, true, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to load network")
+	}
+	exportedNetwork, err := exportNetwork(network)
+	if err != nil {
+		return err
+	}
+	err = sink.writeHeader(clock.Now().Unix(), exportedNetwork)
+	if err != nil {
+		return err
+	}
+
+	physicalIDs := []string{}
+	criteria := exportEntityLoadCriteria
+	criteria.PageSize = uint32(pageSize)
+	for {
+		page, nextPageToken, err := configurator.LoadEntitiesPage(networkID, "", nil, criteria)
+		if err != nil {
+			return errors.Wrap(err, "failed to load entities")
+		}
+		exported, err := exportEntities(page)
+		if err != nil {
+			return err
+		}
+		err = sink.writeEntities(exported)
+		if err != nil {
+			return err
+		}
+		for _, ent := range page {
+			if ent.PhysicalID != "" {
+				physicalIDs = append(physicalIDs, ent.PhysicalID)
+			}
+		}
+		if nextPageToken == "" {
+			break
+		}
+		criteria.PageToken = nextPageToken
+	}
+
+	err = exportDevices(networkID, physicalIDs, pageSize, sink)
+	if err != nil {
+		return err
+	}
+	return sink.close()
+}
+
+func exportEntities(ents []configurator.NetworkEntity) ([]Entity, error) {
+	ret := make([]Entity, 0, len(ents))
+	for _, ent := range ents {
+		exported := Entity{
+			Type:        ent.Type,
+			Key:         ent.Key,
+			Name:        ent.Name,
+			Description: ent.Description,
+			PhysicalID:  ent.PhysicalID,
+			Labels:      ent.Labels,
+		}
+		if ent.Config != nil {
+			bConfig, err := serde.Serialize(configurator.NetworkEntitySerdeDomain, ent.Type, ent.Config)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to serialize config of entity %s", ent.GetTypeAndKey())
+			}
+			exported.Config = bConfig
+		}
+		for _, assoc := range ent.Associations {
+			exported.Associations = append(exported.Associations, EntityID{Type: assoc.Type, Key: assoc.Key})
+		}
+		sortEntityIDs(exported.Associations)
+		ret = append(ret, exported)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return entityIDLess(ret[i].id(), ret[j].id())
+	})
+	return ret, nil
+}
+
+// exportDevices loads the device records of the given physical IDs,
+// pageSize records at a time. Entities don't record the type of their
+// device, so every registered device type is queried.
+func exportDevices(networkID string, physicalIDs []string, pageSize int, sink exportSink) error {
+	sort.Strings(physicalIDs)
+	deviceSerdes := serde.GetSerdesForDomain(device.SerdeDomain)
+	sort.Slice(deviceSerdes, func(i, j int) bool { return deviceSerdes[i].GetType() < deviceSerdes[j].GetType() })
+
+	for _, deviceSerde := range deviceSerdes {
+		deviceType := deviceSerde.GetType()
+		for start := 0; start < len(physicalIDs); start += pageSize {
+			end := start + pageSize
+			if end > len(physicalIDs) {
+				end = len(physicalIDs)
+			}
+			devices, err := device.GetDevices(networkID, deviceType, physicalIDs[start:end])
+			if err != nil {
+				return errors.Wrapf(err, "failed to load devices of type %s", deviceType)
+			}
+			page := make([]Device, 0, len(devices))
+			for deviceID, info := range devices {
+				bInfo, err := deviceSerde.Serialize(info)
+				if err != nil {
+					return errors.Wrapf(err, "failed to serialize device %s", deviceID)
+				}
+				page = append(page, Device{Type: deviceType, ID: deviceID, Info: bInfo})
+			}
+			sort.Slice(page, func(i, j int) bool { return page[i].ID < page[j].ID })
+			err = sink.writeDevices(page)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ent Entity) id() EntityID {
+	return EntityID{Type: ent.Type, Key: ent.Key}
+}
+
+func (id EntityID) String() string {
+	return id.Type + "/" + id.Key
+}
+
+func entityIDLess(a, b EntityID) bool {
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	return a.Key < b.Key
+}
+
+func sortEntityIDs(ids []EntityID) {
+	sort.Slice(ids, func(i, j int) bool { return entityIDLess(ids[i], ids[j]) })
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package archive_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/archive"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/device"
+	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportImport(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.GetUnfreezeClockDeferFunc(t)()
+
+	err := configurator.CreateNetwork(configurator.Network{
+		ID:          "n1",
+		Name:        "network 1",
+		Description: "first network",
+		Configs: map[string]interface{}{
+			orc8r.NetworkFeaturesConfig: &models.NetworkFeatures{Features: map[string]string{"foo": "bar"}},
+		},
+	})
+	assert.NoError(t, err)
+	gatewayRecord := &models.GatewayDevice{HardwareID: "hw1", Key: &models.ChallengeKey{KeyType: "ECHO"}}
+	err = device.RegisterDevice("n1", orc8r.AccessGatewayRecordType, "hw1", gatewayRecord)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities("n1", []configurator.NetworkEntity{
+		{
+			Type: orc8r.MagmadGatewayType, Key: "g1",
+			Name: "gateway 1", PhysicalID: "hw1",
+			Config: &models.MagmadGatewayConfigs{CheckinInterval: 15},
+			Labels: map[string]string{"region": "west"},
+		},
+		{
+			Type: orc8r.UpgradeTierEntityType, Key: "t1",
+			Config:       &models.Tier{ID: "t1", Version: "1.0.0"},
+			Associations: []storage.TypeAndKey{{Type: orc8r.MagmadGatewayType, Key: "g1"}},
+		},
+	})
+	assert.NoError(t, err)
+
+	exported, err := archive.Export("n1")
+	assert.NoError(t, err)
+	assert.Equal(t, archive.Version, exported.Version)
+	assert.Equal(t, int64(1000000), exported.ExportedAt)
+	assert.Equal(t, "n1", exported.Network.ID)
+	assert.Equal(t, "network 1", exported.Network.Name)
+	assert.Len(t, exported.Network.Configs, 1)
+	assert.Len(t, exported.Entities, 2)
+	assert.Equal(t, orc8r.MagmadGatewayType, exported.Entities[0].Type)
+	assert.Equal(t, map[string]string{"region": "west"}, exported.Entities[0].Labels)
+	assert.Equal(t, []archive.EntityID{{Type: orc8r.MagmadGatewayType, Key: "g1"}}, exported.Entities[1].Associations)
+	assert.Equal(t, 1, len(exported.Devices))
+	assert.Equal(t, "hw1", exported.Devices[0].ID)
+
+	// Round trip through the archive encoding
+	buf := &bytes.Buffer{}
+	err = archive.Write(buf, exported)
+	assert.NoError(t, err)
+	read, err := archive.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, exported, read)
+
+	// Streamed exports are written a page at a time in the same format
+	streamed := &bytes.Buffer{}
+	err = archive.WriteExport(streamed, "n1", 1)
+	assert.NoError(t, err)
+	written := &bytes.Buffer{}
+	err = archive.Write(written, exported)
+	assert.NoError(t, err)
+	assert.Equal(t, written.String(), streamed.String())
+	err = archive.WriteExport(&bytes.Buffer{}, "n1", 0)
+	assert.EqualError(t, err, "invalid page size 0")
+
+	err = configurator.CreateNetwork(configurator.Network{ID: "empty"})
+	assert.NoError(t, err)
+	emptyExport, err := archive.Export("empty")
+	assert.NoError(t, err)
+	streamed.Reset()
+	written.Reset()
+	assert.NoError(t, archive.WriteExport(streamed, "empty", 1))
+	assert.NoError(t, archive.Write(written, emptyExport))
+	assert.Equal(t, written.String(), streamed.String())
+
+	_, err = archive.Read(bytes.NewBufferString(`{"version": 42, "network": {"id": "n1"}}`))
+	assert.EqualError(t, err, "unsupported archive version 42, expected 1")
+
+	// Import into an empty orc8r
+	err = configurator.DeleteNetwork("n1")
+	assert.NoError(t, err)
+	err = device.DeleteDevice("n1", orc8r.AccessGatewayRecordType, "hw1")
+	assert.NoError(t, err)
+
+	expectedChanges := []archive.Change{
+		{Kind: archive.CreateChange, Object: "network n1"},
+		{Kind: archive.CreateChange, Object: "network config " + orc8r.NetworkFeaturesConfig},
+		{Kind: archive.CreateChange, Object: "entity magmad_gateway/g1"},
+		{Kind: archive.CreateChange, Object: "entity upgrade_tier/t1"},
+		{Kind: archive.CreateChange, Object: "device access_gateway_record/hw1"},
+	}
+	changes, err := archive.Import("n1", read, true)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChanges, changes)
+	exists, err := configurator.DoesNetworkExist("n1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	changes, err = archive.Import("n1", read, false)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChanges, changes)
+	reexported, err := archive.Export("n1")
+	assert.NoError(t, err)
+	assert.Equal(t, exported, reexported)
+
+	// Importing again is a no-op
+	changes, err = archive.Import("n1", read, false)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Changes in the archive are applied as updates
+	read.Network.Description = "updated"
+	read.Entities[0].Labels = nil
+	read.Entities[1].Associations = nil
+	expectedChanges = []archive.Change{
+		{Kind: archive.UpdateChange, Object: "network n1", Fields: []string{"description"}},
+		{Kind: archive.UpdateChange, Object: "entity magmad_gateway/g1", Fields: []string{"labels"}},
+		{Kind: archive.UpdateChange, Object: "entity upgrade_tier/t1", Fields: []string{"associations"}},
+	}
+	changes, err = archive.Import("n1", read, true)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChanges, changes)
+	changes, err = archive.Import("n1", read, false)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedChanges, changes)
+
+	network, err := configurator.LoadNetwork("n1", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", network.Description)
+	tier, err := configurator.LoadEntity("n1", orc8r.UpgradeTierEntityType, "t1", configurator.EntityLoadCriteria{LoadAssocsFromThis: true})
+	assert.NoError(t, err)
+	assert.Empty(t, tier.Associations)
+
+	// Entities which aren't in the archive are left alone
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: "g2", Name: "gateway 2"})
+	assert.NoError(t, err)
+	changes, err = archive.Import("n1", read, false)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+	exists, err = configurator.DoesEntityExist("n1", orc8r.MagmadGatewayType, "g2")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
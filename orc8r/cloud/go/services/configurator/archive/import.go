@@ -0,0 +1,342 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/device"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+)
+
+type ChangeKind string
+
+const (
+	CreateChange ChangeKind = "create"
+	UpdateChange ChangeKind = "update"
+)
+
+// Change describes a single write which importing an archive makes to the
+// target network.
+type Change struct {
+	Kind ChangeKind
+	// Object describes the changed object, e.g. "entity magmad_gateway/gw1"
+	Object string
+	// Fields lists the fields of the object which differ, for updates
+	Fields []string
+}
+
+func (c Change) String() string {
+	if len(c.Fields) == 0 {
+		return fmt.Sprintf("%s %s", c.Kind, c.Object)
+	}
+	return fmt.Sprintf("%s %s (%s)", c.Kind, c.Object, strings.Join(c.Fields, ", "))
+}
+
+// Import re-creates the contents of an archive in the network networkID,
+// creating the network if it doesn't exist. Import is idempotent: anything
+// which already matches the archive is left untouched, and anything in the
+// target network which isn't in the archive is never deleted.
+// The changes which the import makes are returned. If dryRun is true, the
+// changes are computed but not applied.
+func Import(networkID string, archive *Archive, dryRun bool) ([]Change, error) {
+	current, err := Export(networkID)
+	if errors.Cause(err) == merrors.ErrNotFound {
+		current = nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to load target network")
+	}
+
+	plan := &importPlan{networkID: networkID}
+	err = plan.planNetwork(current, archive)
+	if err != nil {
+		return nil, err
+	}
+	err = plan.planEntities(current, archive)
+	if err != nil {
+		return nil, err
+	}
+	plan.planDevices(current, archive)
+	if dryRun {
+		return plan.changes, nil
+	}
+	return plan.changes, plan.apply()
+}
+
+type importPlan struct {
+	networkID string
+	changes   []Change
+
+	networkToCreate *configurator.Network
+	networkUpdate   *configurator.NetworkUpdateCriteria
+
+	entityWrites []configurator.EntityWriteOperation
+
+	devicesToRegister []Device
+	devicesToUpdate   []Device
+}
+
+func (p *importPlan) planNetwork(current *Archive, archive *Archive) error {
+	desired := archive.Network
+	configs := map[string]interface{}{}
+	var configChanges []Change
+	for configType, bConfig := range desired.Configs {
+		var currentConfig []byte
+		if current != nil {
+			currentConfig = current.Network.Configs[configType]
+		}
+		if currentConfig != nil && bytes.Equal(currentConfig, bConfig) {
+			continue
+		}
+
+		config, err := serde.Deserialize(configurator.NetworkConfigSerdeDomain, configType, bConfig)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize network config %s", configType)
+		}
+		configs[configType] = config
+		kind := UpdateChange
+		if currentConfig == nil {
+			kind = CreateChange
+		}
+		configChanges = append(configChanges, Change{Kind: kind, Object: fmt.Sprintf("network config %s", configType)})
+	}
+	sortChanges(configChanges)
+
+	if current == nil {
+		p.networkToCreate = &configurator.Network{
+			ID:          p.networkID,
+			Type:        desired.Type,
+			Name:        desired.Name,
+			Description: desired.Description,
+			Configs:     configs,
+		}
+		p.changes = append(p.changes, Change{Kind: CreateChange, Object: fmt.Sprintf("network %s", p.networkID)})
+		p.changes = append(p.changes, configChanges...)
+		return nil
+	}
+
+	update := configurator.NetworkUpdateCriteria{ID: p.networkID, ConfigsToAddOrUpdate: configs}
+	var fields []string
+	if current.Network.Type != desired.Type {
+		update.NewType = swag.String(desired.Type)
+		fields = append(fields, "type")
+	}
+	if current.Network.Name != desired.Name {
+		update.NewName = swag.String(desired.Name)
+		fields = append(fields, "name")
+	}
+	if current.Network.Description != desired.Description {
+		update.NewDescription = swag.String(desired.Description)
+		fields = append(fields, "description")
+	}
+	if len(fields) > 0 {
+		p.changes = append(p.changes, Change{Kind: UpdateChange, Object: fmt.Sprintf("network %s", p.networkID), Fields: fields})
+	}
+	p.changes = append(p.changes, configChanges...)
+	if len(fields) > 0 || len(configs) > 0 {
+		p.networkUpdate = &update
+	}
+	return nil
+}
+
+// planEntities creates missing entities first, then updates entities in a
+// second pass so that associations can refer to entities created by the
+// import regardless of the order in which they appear in the archive.
+func (p *importPlan) planEntities(current *Archive, archive *Archive) error {
+	currentEnts := map[EntityID]Entity{}
+	if current != nil {
+		for _, ent := range current.Entities {
+			currentEnts[ent.id()] = ent
+		}
+	}
+
+	var creates, updates []configurator.EntityWriteOperation
+	for _, desired := range archive.Entities {
+		var config interface{}
+		if desired.Config != nil {
+			var err error
+			config, err = serde.Deserialize(configurator.NetworkEntitySerdeDomain, desired.Type, desired.Config)
+			if err != nil {
+				return errors.Wrapf(err, "failed to deserialize config of entity %s", desired.id())
+			}
+		}
+
+		existing, exists := currentEnts[desired.id()]
+		if !exists {
+			creates = append(creates, configurator.NetworkEntity{
+				Type:        desired.Type,
+				Key:         desired.Key,
+				Name:        desired.Name,
+				Description: desired.Description,
+				PhysicalID:  desired.PhysicalID,
+				Config:      config,
+				Labels:      desired.Labels,
+			})
+			if len(desired.Associations) > 0 {
+				updates = append(updates, configurator.EntityUpdateCriteria{
+					Type:              desired.Type,
+					Key:               desired.Key,
+					AssociationsToSet: entityIDsToTKs(desired.Associations),
+				})
+			}
+			p.changes = append(p.changes, Change{Kind: CreateChange, Object: fmt.Sprintf("entity %s", desired.id())})
+			continue
+		}
+
+		update := configurator.EntityUpdateCriteria{Type: desired.Type, Key: desired.Key}
+		var fields []string
+		if existing.Name != desired.Name {
+			update.NewName = swag.String(desired.Name)
+			fields = append(fields, "name")
+		}
+		if existing.Description != desired.Description {
+			update.NewDescription = swag.String(desired.Description)
+			fields = append(fields, "description")
+		}
+		if existing.PhysicalID != desired.PhysicalID {
+			update.NewPhysicalID = swag.String(desired.PhysicalID)
+			fields = append(fields, "physical_id")
+		}
+		if !bytes.Equal(existing.Config, desired.Config) {
+			if config == nil {
+				update.DeleteConfig = true
+			} else {
+				update.NewConfig = config
+			}
+			fields = append(fields, "config")
+		}
+		if !stringMapsEqual(existing.Labels, desired.Labels) {
+			update.LabelsToSet = desired.Labels
+			if update.LabelsToSet == nil {
+				update.LabelsToSet = map[string]string{}
+			}
+			fields = append(fields, "labels")
+		}
+		if !entityIDsEqual(existing.Associations, desired.Associations) {
+			update.AssociationsToSet = entityIDsToTKs(desired.Associations)
+			fields = append(fields, "associations")
+		}
+		if len(fields) > 0 {
+			updates = append(updates, update)
+			p.changes = append(p.changes, Change{Kind: UpdateChange, Object: fmt.Sprintf("entity %s", desired.id()), Fields: fields})
+		}
+	}
+	p.entityWrites = append(creates, updates...)
+	return nil
+}
+
+func (p *importPlan) planDevices(current *Archive, archive *Archive) {
+	currentDevices := map[EntityID]Device{}
+	if current != nil {
+		for _, d := range current.Devices {
+			currentDevices[EntityID{Type: d.Type, Key: d.ID}] = d
+		}
+	}
+
+	for _, desired := range archive.Devices {
+		existing, exists := currentDevices[EntityID{Type: desired.Type, Key: desired.ID}]
+		if !exists {
+			p.devicesToRegister = append(p.devicesToRegister, desired)
+			p.changes = append(p.changes, Change{Kind: CreateChange, Object: fmt.Sprintf("device %s/%s", desired.Type, desired.ID)})
+			continue
+		}
+		if !bytes.Equal(existing.Info, desired.Info) {
+			p.devicesToUpdate = append(p.devicesToUpdate, desired)
+			p.changes = append(p.changes, Change{Kind: UpdateChange, Object: fmt.Sprintf("device %s/%s", desired.Type, desired.ID), Fields: []string{"info"}})
+		}
+	}
+}
+
+func (p *importPlan) apply() error {
+	if p.networkToCreate != nil {
+		err := configurator.CreateNetwork(*p.networkToCreate)
+		if err != nil {
+			return errors.Wrap(err, "failed to create network")
+		}
+	}
+	if p.networkUpdate != nil {
+		err := configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{*p.networkUpdate})
+		if err != nil {
+			return errors.Wrap(err, "failed to update network")
+		}
+	}
+
+	// Register devices before writing entities, as the gateway handlers do
+	for _, d := range p.devicesToRegister {
+		info, err := serde.Deserialize(device.SerdeDomain, d.Type, d.Info)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize device %s", d.ID)
+		}
+		err = device.RegisterDevice(p.networkID, d.Type, d.ID, info)
+		if err != nil {
+			return errors.Wrapf(err, "failed to register device %s", d.ID)
+		}
+	}
+	for _, d := range p.devicesToUpdate {
+		info, err := serde.Deserialize(device.SerdeDomain, d.Type, d.Info)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize device %s", d.ID)
+		}
+		err = device.UpdateDevice(p.networkID, d.Type, d.ID, info)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update device %s", d.ID)
+		}
+	}
+
+	if len(p.entityWrites) > 0 {
+		err := configurator.WriteEntities(p.networkID, p.entityWrites...)
+		if err != nil {
+			return errors.Wrap(err, "failed to write entities")
+		}
+	}
+	return nil
+}
+
+func entityIDsToTKs(ids []EntityID) []storage.TypeAndKey {
+	ret := make([]storage.TypeAndKey, 0, len(ids))
+	for _, id := range ids {
+		ret = append(ret, storage.TypeAndKey{Type: id.Type, Key: id.Key})
+	}
+	return ret
+}
+
+// entityIDsEqual compares lists of entity IDs regardless of order. Nil and
+// empty lists are considered equal.
+func entityIDsEqual(a, b []EntityID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]EntityID{}, a...)
+	sortedB := append([]EntityID{}, b...)
+	sortEntityIDs(sortedA)
+	sortEntityIDs(sortedB)
+	return reflect.DeepEqual(sortedA, sortedB)
+}
+
+// stringMapsEqual considers nil and empty maps to be equal.
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Object < changes[j].Object })
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// exportSink receives an export as it is loaded. The header is written
+// first, then all pages of entities, then all pages of device records.
+type exportSink interface {
+	writeHeader(exportedAt int64, network Network) error
+	writeEntities(ents []Entity) error
+	writeDevices(devices []Device) error
+	close() error
+}
+
+// archiveBuilder collects an export into an Archive
+type archiveBuilder struct {
+	archive *Archive
+}
+
+func (b *archiveBuilder) writeHeader(exportedAt int64, network Network) error {
+	b.archive = &Archive{
+		Version:    Version,
+		ExportedAt: exportedAt,
+		Network:    network,
+		Entities:   []Entity{},
+		Devices:    []Device{},
+	}
+	return nil
+}
+
+func (b *archiveBuilder) writeEntities(ents []Entity) error {
+	b.archive.Entities = append(b.archive.Entities, ents...)
+	return nil
+}
+
+func (b *archiveBuilder) writeDevices(devices []Device) error {
+	b.archive.Devices = append(b.archive.Devices, devices...)
+	return nil
+}
+
+func (b *archiveBuilder) close() error {
+	return nil
+}
+
+// archiveWriter streams an export to a writer. The output is the same as
+// that of Write for the equivalent Archive.
+type archiveWriter struct {
+	w io.Writer
+	// array is the JSON field of the array being written, if any
+	array string
+	// elements is the number of elements written to the array so far
+	elements int
+	// opened holds the array fields which have been written so far
+	opened map[string]bool
+}
+
+const (
+	entitiesField = "entities"
+	devicesField  = "devices"
+
+	fieldIndent   = "  "
+	elementIndent = "    "
+)
+
+func (aw *archiveWriter) writeHeader(exportedAt int64, network Network) error {
+	if _, err := io.WriteString(aw.w, "{"); err != nil {
+		return err
+	}
+	if err := aw.writeField("version", Version, true); err != nil {
+		return err
+	}
+	if err := aw.writeField("exported_at", exportedAt, false); err != nil {
+		return err
+	}
+	return aw.writeField("network", network, false)
+}
+
+func (aw *archiveWriter) writeEntities(ents []Entity) error {
+	if err := aw.openArray(entitiesField); err != nil {
+		return err
+	}
+	for _, ent := range ents {
+		if err := aw.writeElement(ent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (aw *archiveWriter) writeDevices(devices []Device) error {
+	// Archives list entities before devices even if there are none
+	if err := aw.openArray(entitiesField); err != nil {
+		return err
+	}
+	if err := aw.openArray(devicesField); err != nil {
+		return err
+	}
+	for _, device := range devices {
+		if err := aw.writeElement(device); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (aw *archiveWriter) close() error {
+	if err := aw.writeDevices(nil); err != nil {
+		return err
+	}
+	if err := aw.closeArray(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(aw.w, "\n}\n")
+	return err
+}
+
+func (aw *archiveWriter) writeField(name string, value interface{}, first bool) error {
+	marshaled, err := json.MarshalIndent(value, fieldIndent, fieldIndent)
+	if err != nil {
+		return err
+	}
+	separator := ","
+	if first {
+		separator = ""
+	}
+	_, err = fmt.Fprintf(aw.w, "%s\n%s%q: %s", separator, fieldIndent, name, marshaled)
+	return err
+}
+
+// openArray starts the array field, closing the previous array. Opening an
+// array which has already been opened is a no-op.
+func (aw *archiveWriter) openArray(name string) error {
+	if aw.opened[name] {
+		return nil
+	}
+	if aw.opened == nil {
+		aw.opened = map[string]bool{}
+	}
+	aw.opened[name] = true
+	if err := aw.closeArray(); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(aw.w, ",\n%s%q: [", fieldIndent, name); err != nil {
+		return err
+	}
+	aw.array, aw.elements = name, 0
+	return nil
+}
+
+func (aw *archiveWriter) closeArray() error {
+	if aw.array == "" {
+		return nil
+	}
+	closing := "]"
+	if aw.elements > 0 {
+		closing = "\n" + fieldIndent + "]"
+	}
+	if _, err := io.WriteString(aw.w, closing); err != nil {
+		return err
+	}
+	aw.array = ""
+	return nil
+}
+
+func (aw *archiveWriter) writeElement(value interface{}) error {
+	marshaled, err := json.MarshalIndent(value, elementIndent, fieldIndent)
+	if err != nil {
+		return err
+	}
+	separator := ","
+	if aw.elements == 0 {
+		separator = ""
+	}
+	if _, err = fmt.Fprintf(aw.w, "%s\n%s%s", separator, elementIndent, marshaled); err != nil {
+		return err
+	}
+	aw.elements++
+	return nil
+}
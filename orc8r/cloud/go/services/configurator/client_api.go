@@ -11,6 +11,7 @@ package configurator
 import (
 	"context"
 	"fmt"
+	"io"
 
 	merrors "magma/orc8r/cloud/go/errors"
 	commonProtos "magma/orc8r/cloud/go/protos"
@@ -23,8 +24,33 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// ErrInvalidPageToken is returned by paginated loads when the page token is
+// malformed.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrCursorExpired is returned by change log loads and watches when changes
+// after the cursor have been pruned from the change log. Callers have to
+// resync from a full load.
+var ErrCursorExpired = errors.New("cursor expired")
+
+// ErrVersionMismatch is returned by writes when an update's expected version
+// doesn't match the current version of the network or entity being updated.
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// loadAllEntitiesPageSize is the page size used when loading every entity of
+// a type in a network.
+const loadAllEntitiesPageSize = 1000
+
+// AuthorMetadataKey is the gRPC metadata key under which callers can
+// identify who is making a write. The author is recorded in config history.
+// Configurator doesn't authenticate the author, so it is advisory only: any
+// cloud service can claim any author.
+const AuthorMetadataKey = "config-author"
+
 func getNBConfiguratorClient() (protos.NorthboundConfiguratorClient, error) {
 	conn, err := registry.GetConnection(ServiceName)
 	if err != nil {
@@ -71,81 +97,27 @@ func ListNetworksOfType(networkType string) ([]string, error) {
 }
 
 func CreateNetwork(network Network) error {
-	_, err := CreateNetworks([]Network{network})
-	return err
+	return Writer{}.CreateNetwork(network)
 }
 
 // CreateNetworks registers the given list of Networks and returns the created networks
 func CreateNetworks(networks []Network) ([]Network, error) {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return nil, err
-	}
-
-	req := &protos.CreateNetworksRequest{Networks: make([]*storage.Network, 0, len(networks))}
-	for _, n := range networks {
-		pNet, err := n.toStorageProto()
-		if err != nil {
-			return nil, err
-		}
-		req.Networks = append(req.Networks, pNet)
-	}
-	result, err := client.CreateNetworks(context.Background(), req)
-	if err != nil {
-		return nil, err
-	}
-
-	ret := make([]Network, len(result.CreatedNetworks))
-	for i, protoNet := range result.CreatedNetworks {
-		ent, err := ret[i].fromStorageProto(protoNet)
-		if err != nil {
-			return nil, err
-		}
-		ret[i] = ent
-	}
-	return ret, nil
+	return Writer{}.CreateNetworks(networks)
 }
 
 // UpdateNetworks updates the specified networks and returns the updated networks
 func UpdateNetworks(updates []NetworkUpdateCriteria) error {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return err
-	}
-
-	request := &protos.UpdateNetworksRequest{Updates: make([]*storage.NetworkUpdateCriteria, 0, len(updates))}
-	for _, update := range updates {
-		protoUpdate, err := update.toStorageProto()
-		if err != nil {
-			return err
-		}
-		request.Updates = append(request.Updates, protoUpdate)
-	}
-	_, err = client.UpdateNetworks(context.Background(), request)
-	return err
+	return Writer{}.UpdateNetworks(updates)
 }
 
 // DeleteNetworks deletes the network specified by networkID
 func DeleteNetworks(networkIDs []string) error {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return err
-	}
-	_, err = client.DeleteNetworks(context.Background(), &protos.DeleteNetworksRequest{NetworkIDs: networkIDs})
-	return err
+	return Writer{}.DeleteNetworks(networkIDs)
 }
 
 // DeleteNetwork deletes a network.
 func DeleteNetwork(networkID string) error {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return err
-	}
-	_, err = client.DeleteNetworks(
-		context.Background(),
-		&protos.DeleteNetworksRequest{NetworkIDs: []string{networkID}},
-	)
-	return err
+	return Writer{}.DeleteNetwork(networkID)
 }
 
 // DoesNetworkExist returns a boolean that indicates whether the networkID
@@ -249,19 +221,11 @@ func LoadNetworkConfig(networkID, configType string) (interface{}, error) {
 }
 
 func UpdateNetworkConfig(networkID, configType string, config interface{}) error {
-	updateCriteria := NetworkUpdateCriteria{
-		ID:                   networkID,
-		ConfigsToAddOrUpdate: map[string]interface{}{configType: config},
-	}
-	return UpdateNetworks([]NetworkUpdateCriteria{updateCriteria})
+	return Writer{}.UpdateNetworkConfig(networkID, configType, config)
 }
 
 func DeleteNetworkConfig(networkID, configType string) error {
-	updateCriteria := NetworkUpdateCriteria{
-		ID:              networkID,
-		ConfigsToDelete: []string{configType},
-	}
-	return UpdateNetworks([]NetworkUpdateCriteria{updateCriteria})
+	return Writer{}.DeleteNetworkConfig(networkID, configType)
 }
 
 func GetNetworkConfigsByType(networkID string, configType string) (interface{}, error) {
@@ -280,176 +244,61 @@ func GetNetworkConfigsByType(networkID string, configType string) (interface{},
 // This function is all-or-nothing - any failure or error encountered during
 // any operation will rollback the entire batch.
 func WriteEntities(networkID string, writes ...EntityWriteOperation) error {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return err
-	}
-
-	req := &protos.WriteEntitiesRequest{NetworkID: networkID}
-	for _, write := range writes {
-		switch op := write.(type) {
-		case NetworkEntity:
-			protoEnt, err := op.toStorageProto()
-			if err != nil {
-				return err
-			}
-			req.Writes = append(req.Writes, &protos.WriteEntityRequest{Request: &protos.WriteEntityRequest_Create{Create: protoEnt}})
-		case EntityUpdateCriteria:
-			protoEuc, err := op.toStorageProto()
-			if err != nil {
-				return err
-			}
-			req.Writes = append(req.Writes, &protos.WriteEntityRequest{Request: &protos.WriteEntityRequest_Update{Update: protoEuc}})
-		default:
-			return errors.Errorf("unrecognized entity write operation %T", op)
-		}
-	}
-
-	_, err = client.WriteEntities(context.Background(), req)
-	if err != nil {
-		return err
-	}
-	return nil
+	return Writer{}.WriteEntities(networkID, writes...)
 }
 
 func CreateEntity(networkID string, entity NetworkEntity) (NetworkEntity, error) {
-	ret, err := CreateEntities(networkID, []NetworkEntity{entity})
-	if err != nil {
-		return NetworkEntity{}, err
-	}
-	return ret[0], nil
+	return Writer{}.CreateEntity(networkID, entity)
 }
 
 // CreateEntities registers the given entities and returns the created network entities
 func CreateEntities(networkID string, entities []NetworkEntity) ([]NetworkEntity, error) {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return nil, err
-	}
-
-	request := &protos.CreateEntitiesRequest{NetworkID: networkID, Entities: make([]*storage.NetworkEntity, 0, len(entities))}
-	for _, ent := range entities {
-		protoEnt, err := ent.toStorageProto()
-		if err != nil {
-			return nil, err
-		}
-		request.Entities = append(request.Entities, protoEnt)
-	}
-	response, err := client.CreateEntities(context.Background(), request)
-	if err != nil {
-		return nil, err
-	}
-
-	ret := make([]NetworkEntity, len(response.CreatedEntities))
-	for i, protoEnt := range response.CreatedEntities {
-		ent, err := ret[i].fromStorageProto(protoEnt)
-		if err != nil {
-			return nil, errors.Wrap(err, "request succeeded but deserialization failed")
-		}
-		ret[i] = ent
-	}
-	return ret, err
+	return Writer{}.CreateEntities(networkID, entities)
 }
 
 // CreateInternalEntity is a loose wrapper around CreateEntity to create an
 // entity in the internal network structure
 func CreateInternalEntity(entity NetworkEntity) (NetworkEntity, error) {
-	return CreateEntity(storage.InternalNetworkID, entity)
+	return Writer{}.CreateInternalEntity(entity)
 }
 
 func UpdateEntity(networkID string, update EntityUpdateCriteria) (NetworkEntity, error) {
-	retMap, err := UpdateEntities(networkID, []EntityUpdateCriteria{update})
-	if err != nil {
-		return NetworkEntity{}, err
-	}
-	for _, v := range retMap {
-		return v, nil
-	}
-	return NetworkEntity{}, merrors.ErrNotFound
+	return Writer{}.UpdateEntity(networkID, update)
 }
 
 // UpdateEntities updates the registered entities and returns the updated entities
 func UpdateEntities(networkID string, updates []EntityUpdateCriteria) (map[string]NetworkEntity, error) {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return nil, err
-	}
-
-	request := &protos.UpdateEntitiesRequest{NetworkID: networkID, Updates: make([]*storage.EntityUpdateCriteria, 0, len(updates))}
-	for _, update := range updates {
-		upProto, err := update.toStorageProto()
-		if err != nil {
-			return nil, err
-		}
-		request.Updates = append(request.Updates, upProto)
-	}
-	response, err := client.UpdateEntities(context.Background(), request)
-	if err != nil {
-		return nil, err
-	}
-
-	ret := map[string]NetworkEntity{}
-	for id, protoEnt := range response.UpdatedEntities {
-		ent, err := (NetworkEntity{}).fromStorageProto(protoEnt)
-		if err != nil {
-			return nil, errors.Wrap(err, "request succeeded but response deserialization failed")
-		}
-		ret[id] = ent
-	}
-	return ret, err
+	return Writer{}.UpdateEntities(networkID, updates)
 }
 
 // UpdateInternalEntity is a loose wrapper around UpdateEntity to update an
 // entity in the internal network structure
 func UpdateInternalEntity(update EntityUpdateCriteria) (NetworkEntity, error) {
-	return UpdateEntity(storage.InternalNetworkID, update)
+	return Writer{}.UpdateInternalEntity(update)
 }
 
 func CreateOrUpdateEntityConfig(networkID string, entityType string, entityKey string, config interface{}) error {
-	updateCriteria := EntityUpdateCriteria{
-		Key:       entityKey,
-		Type:      entityType,
-		NewConfig: config,
-	}
-	_, err := UpdateEntities(networkID, []EntityUpdateCriteria{updateCriteria})
-	return err
+	return Writer{}.CreateOrUpdateEntityConfig(networkID, entityType, entityKey, config)
 }
 
 func DeleteEntityConfig(networkID, entityType, entityKey string) error {
-	updateCriteria := EntityUpdateCriteria{
-		Key:          entityKey,
-		Type:         entityType,
-		DeleteConfig: true,
-	}
-	_, err := UpdateEntities(networkID, []EntityUpdateCriteria{updateCriteria})
-	return err
+	return Writer{}.DeleteEntityConfig(networkID, entityType, entityKey)
 }
 
 func DeleteEntity(networkID string, entityType string, entityKey string) error {
-	return DeleteEntities(networkID, []storage2.TypeAndKey{{Type: entityType, Key: entityKey}})
+	return Writer{}.DeleteEntity(networkID, entityType, entityKey)
 }
 
 // DeleteEntity deletes the entity specified by networkID, type, key
 // We also have cascading deletes to delete foreign keys for assocs
 func DeleteEntities(networkID string, ids []storage2.TypeAndKey) error {
-	client, err := getNBConfiguratorClient()
-	if err != nil {
-		return err
-	}
-	_, err = client.DeleteEntities(
-		context.Background(),
-		&protos.DeleteEntitiesRequest{
-			NetworkID: networkID,
-			ID:        tksToEntIDs(ids),
-		},
-	)
-	return err
+	return Writer{}.DeleteEntities(networkID, ids)
 }
 
 // DeleteInternalEntity is a loose wrapper around DeleteEntities to delete an
 // entity in the internal network structure
 func DeleteInternalEntity(entityType, entityKey string) error {
-	return DeleteEntity(storage.InternalNetworkID, entityType, entityKey)
+	return Writer{}.DeleteInternalEntity(entityType, entityKey)
 }
 
 // GetPhysicalIDOfEntity gets the physicalID associated with the entity
@@ -632,38 +481,247 @@ func DoesInternalEntityExist(entityType, entityKey string) (bool, error) {
 	return DoesEntityExist(storage.InternalNetworkID, entityType, entityKey)
 }
 
-// LoadAllEntitiesInNetwork fetches all entities of specified type in a network
+// LoadAllEntitiesInNetwork fetches all entities of specified type in a network.
+// See LoadAllEntitiesWithLabels for the consistency of the result.
 func LoadAllEntitiesInNetwork(networkID string, entityType string, criteria EntityLoadCriteria) ([]NetworkEntity, error) {
+	return LoadAllEntitiesWithLabels(networkID, entityType, nil, criteria)
+}
+
+// LoadAllEntitiesWithLabels fetches all entities of specified type in a
+// network which have every label in labelSelector. A nil or empty selector
+// matches all entities.
+// The entities are loaded a page at a time, each page in its own read
+// transaction, so the result isn't a snapshot of the network. Pages are keyed
+// on the last loaded entity ID, so entities which exist for the whole load
+// are returned exactly once, but entities created or deleted concurrently may
+// or may not be included, and entities updated concurrently may reflect
+// writes committed after earlier pages were loaded.
+func LoadAllEntitiesWithLabels(networkID string, entityType string, labelSelector map[string]string, criteria EntityLoadCriteria) ([]NetworkEntity, error) {
+	// Load the entities a page at a time so large networks don't exceed the
+	// gRPC message size limit
+	criteria.PageSize = loadAllEntitiesPageSize
+	criteria.PageToken = ""
+
+	ret := []NetworkEntity{}
+	for {
+		page, nextPageToken, err := LoadEntitiesPage(networkID, entityType, labelSelector, criteria)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, page...)
+		if nextPageToken == "" {
+			return ret, nil
+		}
+		criteria.PageToken = nextPageToken
+	}
+}
+
+// LoadEntitiesPage loads a single page of entities of a given type in a
+// network, as specified by the criteria's PageSize and PageToken. Pass an
+// empty entityType to load entities of all types. Only entities which have
+// every label in labelSelector are loaded. The token for the next page is
+// returned, or an empty string if this is the last page.
+// If the page token is malformed, ErrInvalidPageToken is returned.
+func LoadEntitiesPage(networkID string, entityType string, labelSelector map[string]string, criteria EntityLoadCriteria) ([]NetworkEntity, string, error) {
 	client, err := getNBConfiguratorClient()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
+	filter := &storage.EntityLoadFilter{LabelSelector: labelSelector}
+	if entityType != "" {
+		filter.TypeFilter = &wrappers.StringValue{Value: entityType}
+	}
 	resp, err := client.LoadEntities(
 		context.Background(),
 		&protos.LoadEntitiesRequest{
 			NetworkID: networkID,
-			Filter: &storage.EntityLoadFilter{
-				TypeFilter: &wrappers.StringValue{Value: entityType},
-			},
-			Criteria: criteria.toStorageProto(),
+			Filter:    filter,
+			Criteria:  criteria.toStorageProto(),
 		},
 	)
+	if isInvalidPageTokenError(err) {
+		return nil, "", ErrInvalidPageToken
+	}
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	ret := make([]NetworkEntity, len(resp.Entities))
 	for i, protoEnt := range resp.Entities {
 		ent, err := ret[i].fromStorageProto(protoEnt)
 		if err != nil {
-			return nil, errors.Wrapf(err, "request succeeded but deserialization failed")
+			return nil, "", errors.Wrapf(err, "request succeeded but deserialization failed")
 		}
 		ret[i] = ent
 	}
+	return ret, resp.NextPageToken, nil
+}
+
+// isCursorExpiredError returns true if a change log load failed because
+// changes after the cursor have been pruned
+func isCursorExpiredError(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.OutOfRange && st.Message() == storage.ErrCursorExpired.Error()
+}
+
+// isInvalidPageTokenError returns true if the load failed because of a
+// malformed page token, rather than another invalid argument
+func isInvalidPageTokenError(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.InvalidArgument && st.Message() == storage.ErrInvalidPageToken.Error()
+}
+
+// WatchNetworks streams every network change committed after the
+// afterSequence cursor to the provided callback, in order. The call blocks
+// until ctx is cancelled or the callback returns an error. Callers which
+// need to resume after a restart should persist the sequence of the last
+// change they processed and pass it back in as the cursor. If changes after
+// the cursor have been pruned from the change log, ErrCursorExpired is
+// returned.
+func WatchNetworks(ctx context.Context, afterSequence uint64, callback func(*storage.Change) error) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+	stream, err := client.WatchNetworks(ctx, &protos.WatchNetworksRequest{AfterSequence: afterSequence})
+	if err != nil {
+		return err
+	}
+	return receiveChanges(ctx, stream.Recv, callback)
+}
+
+// WatchEntities streams every entity change within a network committed after
+// the afterSequence cursor to the provided callback, in order. Pass an empty
+// entityType to watch entities of all types. Deleting a network streams a
+// delete for each of its entities. The call blocks until ctx is cancelled or
+// the callback returns an error. Sequences of entity changes are only
+// comparable within a network. If changes after the cursor have been pruned
+// from the change log, ErrCursorExpired is returned.
+func WatchEntities(ctx context.Context, networkID string, entityType string, afterSequence uint64, callback func(*storage.Change) error) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+	stream, err := client.WatchEntities(
+		ctx,
+		&protos.WatchEntitiesRequest{NetworkID: networkID, Type: entityType, AfterSequence: afterSequence},
+	)
+	if err != nil {
+		return err
+	}
+	return receiveChanges(ctx, stream.Recv, callback)
+}
+
+// LoadEntityChanges returns up to limit entity changes within a network
+// committed after the afterSequence cursor, in order, along with the latest
+// sequence in the change log. Changes after the returned latest sequence are
+// never included, so the latest sequence can be used as the cursor for the
+// next call once all changes up to it have been processed. A limit of 0
+// means no limit. If changes after the cursor have been pruned from the
+// change log, ErrCursorExpired is returned.
+func LoadEntityChanges(networkID string, afterSequence uint64, limit uint32) ([]*storage.Change, uint64, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, 0, err
+	}
+	res, err := client.LoadChanges(
+		context.Background(),
+		&protos.LoadChangesRequest{
+			Filter: &storage.ChangeLoadFilter{
+				AfterSequence: afterSequence,
+				NetworkID:     &wrappers.StringValue{Value: networkID},
+				Kinds:         []storage.Change_Kind{storage.Change_ENTITY},
+				Limit:         limit,
+			},
+		},
+	)
+	if isCursorExpiredError(err) {
+		return nil, 0, ErrCursorExpired
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return res.Changes, res.LatestSequence, nil
+}
+
+// GetLatestEntityChangeSequence returns the sequence of the latest entity
+// change within a network, or 0 if there are no changes.
+func GetLatestEntityChangeSequence(networkID string) (uint64, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return 0, err
+	}
+	res, err := client.LoadChanges(
+		context.Background(),
+		&protos.LoadChangesRequest{
+			Filter: &storage.ChangeLoadFilter{
+				NetworkID: &wrappers.StringValue{Value: networkID},
+				Kinds:     []storage.Change_Kind{storage.Change_ENTITY},
+			},
+			LatestSequenceOnly: true,
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LatestSequence, nil
+}
+
+func receiveChanges(ctx context.Context, recv func() (*storage.Change, error), callback func(*storage.Change) error) error {
+	for {
+		change, err := recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if isCursorExpiredError(err) {
+			return ErrCursorExpired
+		}
+		if err != nil {
+			return err
+		}
+		if err := callback(change); err != nil {
+			return err
+		}
+	}
+}
+
+// ListConfigHistory returns the revisions of a network config or an entity's
+// config, newest first. Only the latest storage.MaxConfigRevisions revisions
+// of each config are kept.
+func ListConfigHistory(networkID string, id ConfigID) ([]ConfigRevision, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.ListConfigHistory(
+		context.Background(),
+		&protos.ListConfigHistoryRequest{
+			Filter: &storage.ConfigHistoryFilter{NetworkID: networkID, Kind: id.Kind, Type: id.Type, Key: id.Key},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]ConfigRevision, 0, len(res.Revisions))
+	for _, protoRev := range res.Revisions {
+		rev, err := (ConfigRevision{}).fromStorageProto(id, protoRev)
+		if err != nil {
+			return nil, errors.Wrapf(err, "request succeeded but deserialization failed")
+		}
+		ret = append(ret, rev)
+	}
 	return ret, nil
 }
 
+// RollbackConfig atomically restores a network config or an entity's config
+// to its value as of the given revision, entities' labels are restored as
+// well. The restore is recorded as a new revision. If the revision doesn't exist, merrors.ErrNotFound is returned.
+func RollbackConfig(networkID string, id ConfigID, revision uint64) (ConfigRevision, error) {
+	return Writer{}.RollbackConfig(networkID, id, revision)
+}
+
 func getSBConfiguratorClient() (protos.SouthboundConfiguratorClient, error) {
 	conn, err := registry.GetConnection(ServiceName)
 	if err != nil {
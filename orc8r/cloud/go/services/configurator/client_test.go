@@ -8,11 +8,15 @@ LICENSE file in the root directory of this source tree.
 package configurator_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
+	cfgStorage "magma/orc8r/cloud/go/services/configurator/storage"
 	"magma/orc8r/cloud/go/services/configurator/test_init"
 	"magma/orc8r/cloud/go/storage"
 
@@ -78,6 +82,16 @@ func TestConfiguratorService(t *testing.T) {
 	assert.False(t, fooPresent)
 	assert.Equal(t, "hello", networks[0].Configs["bar"])
 
+	// Updates with a stale expected version are rejected
+	err = configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{
+		{ID: networkID1, NewName: swag.String("stale"), ExpectedVersion: swag.Uint64(networks[0].Version - 1)},
+	})
+	assert.Equal(t, configurator.ErrVersionMismatch, err)
+	err = configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{
+		{ID: networkID1, NewName: swag.String("test_network"), ExpectedVersion: swag.Uint64(networks[0].Version)},
+	})
+	assert.NoError(t, err)
+
 	// Create, Load
 	network2 := configurator.Network{
 		ID:          networkID2,
@@ -172,6 +186,57 @@ func TestConfiguratorService(t *testing.T) {
 	assert.Equal(t, "foobar", entities[0].Name)
 	assert.Equal(t, "fooboo", entities[1].Name)
 
+	// LoadEntitiesPage
+	pagedCriteria := fullEntityLoad
+	pagedCriteria.PageSize = 1
+	entities, nextPageToken, err := configurator.LoadEntitiesPage(networkID1, "foo", nil, pagedCriteria)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entities))
+	assert.Equal(t, "foobar", entities[0].Name)
+	assert.NotEmpty(t, nextPageToken)
+	pagedCriteria.PageToken = nextPageToken
+	entities, nextPageToken, err = configurator.LoadEntitiesPage(networkID1, "foo", nil, pagedCriteria)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entities))
+	assert.Equal(t, "fooboo", entities[0].Name)
+	assert.NotEmpty(t, nextPageToken)
+	pagedCriteria.PageToken = nextPageToken
+	entities, nextPageToken, err = configurator.LoadEntitiesPage(networkID1, "foo", nil, pagedCriteria)
+	assert.NoError(t, err)
+	assert.Empty(t, entities)
+	assert.Empty(t, nextPageToken)
+	pagedCriteria.PageToken = "garbage"
+	_, _, err = configurator.LoadEntitiesPage(networkID1, "foo", nil, pagedCriteria)
+	assert.Equal(t, configurator.ErrInvalidPageToken, err)
+
+	// Labels
+	_, err = configurator.UpdateEntities(networkID1, []configurator.EntityUpdateCriteria{
+		{Type: entityID2.Type, Key: entityID2.Key, LabelsToSet: map[string]string{"env": "prod"}},
+	})
+	assert.NoError(t, err)
+	entities, err = configurator.LoadAllEntitiesWithLabels(networkID1, "foo", map[string]string{"env": "prod"}, configurator.EntityLoadCriteria{LoadLabels: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entities))
+	assert.Equal(t, entityID2.Key, entities[0].Key)
+	assert.Equal(t, map[string]string{"env": "prod"}, entities[0].Labels)
+	entities, _, err = configurator.LoadEntitiesPage(networkID1, "foo", map[string]string{"env": "dev"}, configurator.EntityLoadCriteria{PageSize: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, entities)
+	_, err = configurator.UpdateEntities(networkID1, []configurator.EntityUpdateCriteria{
+		{Type: entityID2.Type, Key: entityID2.Key, LabelsToSet: map[string]string{}},
+	})
+	assert.NoError(t, err)
+
+	// Updates with a stale expected version are rejected
+	_, err = configurator.UpdateEntity(networkID1, configurator.EntityUpdateCriteria{
+		Type: entityID2.Type, Key: entityID2.Key, NewDescription: swag.String("stale"), ExpectedVersion: swag.Uint64(1),
+	})
+	assert.Equal(t, configurator.ErrVersionMismatch, err)
+	err = configurator.WriteEntities(networkID1, configurator.EntityUpdateCriteria{
+		Type: entityID2.Type, Key: entityID2.Key, DeleteEntity: true, ExpectedVersion: swag.Uint64(1),
+	})
+	assert.Equal(t, configurator.ErrVersionMismatch, err)
+
 	// Update, Load add an association from foobar to fooboo
 	newPhysID := "4321"
 	entityUpdateCriteria := configurator.EntityUpdateCriteria{
@@ -243,7 +308,8 @@ func TestConfiguratorService(t *testing.T) {
 			GraphID:            "2",
 			Associations:       []storage.TypeAndKey{{Type: "foo", Key: "baz"}},
 			ParentAssociations: []storage.TypeAndKey{entityID1},
-			Version:            1,
+			// fooboo's labels were set and cleared above
+			Version: 3,
 		},
 	}
 	assert.Equal(t, expected, entities)
@@ -261,6 +327,102 @@ func TestConfiguratorService(t *testing.T) {
 	assert.Equal(t, 2, len(entities))
 	assert.Equal(t, 0, len(entitiesNotFound))
 	assert.Equal(t, "foobar", entities[0].Name)
+
+	// Watch entity changes from the start of the change log, then make sure
+	// new writes are streamed to the watcher in order
+	changes := make(chan *cfgStorage.Change, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- configurator.WatchEntities(ctx, networkID1, "foo", 0, func(change *cfgStorage.Change) error {
+			changes <- change
+			return nil
+		})
+	}()
+
+	_, err = configurator.CreateEntity(networkID1, configurator.NetworkEntity{Type: "foo", Key: "watched"})
+	assert.NoError(t, err)
+	err = configurator.DeleteEntity(networkID1, "foo", "watched")
+	assert.NoError(t, err)
+
+	var received []*cfgStorage.Change
+	for len(received) < 2 {
+		select {
+		case change := <-changes:
+			assert.Equal(t, networkID1, change.NetworkID)
+			assert.Equal(t, "foo", change.Type)
+			if len(received) > 0 || change.Key == "watched" {
+				received = append(received, change)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for entity changes")
+		}
+	}
+	assert.Equal(t, cfgStorage.Change_CREATE, received[0].Operation)
+	assert.Equal(t, cfgStorage.Change_DELETE, received[1].Operation)
+	assert.Equal(t, "watched", received[1].Key)
+	assert.True(t, received[1].Sequence > received[0].Sequence)
+	latestSeq, err := configurator.GetLatestEntityChangeSequence(networkID1)
+	assert.NoError(t, err)
+	assert.Equal(t, received[1].Sequence, latestSeq)
+
+	cancel()
+	assert.NoError(t, <-watchErr)
+
+	// Config history and rollback
+	historyNetworkID := "history_network"
+	err = configurator.CreateNetwork(configurator.Network{ID: historyNetworkID, Configs: map[string]interface{}{"foo": "v1"}})
+	assert.NoError(t, err)
+	err = configurator.AuthoredBy("bob").UpdateNetworkConfig(historyNetworkID, "foo", "v2")
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity(historyNetworkID, configurator.NetworkEntity{Type: "foo", Key: "ent", Config: "e1", Labels: map[string]string{"region": "west"}})
+	assert.NoError(t, err)
+	err = configurator.DeleteEntityConfig(historyNetworkID, "foo", "ent")
+	assert.NoError(t, err)
+	_, err = configurator.UpdateEntity(historyNetworkID, configurator.EntityUpdateCriteria{Type: "foo", Key: "ent", LabelsToSet: map[string]string{"region": "east"}})
+	assert.NoError(t, err)
+
+	netHistory, err := configurator.ListConfigHistory(historyNetworkID, configurator.NetworkConfigID("foo"))
+	assert.NoError(t, err)
+	assert.Len(t, netHistory, 2)
+	assert.Equal(t, uint64(2), netHistory[0].Revision)
+	assert.Equal(t, "v2", netHistory[0].Config)
+	assert.Equal(t, "bob", netHistory[0].Author)
+	assert.Equal(t, uint64(1), netHistory[1].Revision)
+	assert.Equal(t, "v1", netHistory[1].Config)
+	assert.Empty(t, netHistory[1].Author)
+
+	restored, err := configurator.AuthoredBy("alice").RollbackConfig(historyNetworkID, configurator.NetworkConfigID("foo"), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), restored.Revision)
+	assert.Equal(t, "v1", restored.Config)
+	assert.Equal(t, "alice", restored.Author)
+	actualConfig, err := configurator.LoadNetworkConfig(historyNetworkID, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", actualConfig)
+
+	entHistory, err := configurator.ListConfigHistory(historyNetworkID, configurator.EntityConfigID("foo", "ent"))
+	assert.NoError(t, err)
+	assert.Len(t, entHistory, 3)
+	assert.True(t, entHistory[0].Deleted)
+	assert.Nil(t, entHistory[0].Config)
+	assert.Equal(t, map[string]string{"region": "east"}, entHistory[0].Labels)
+	assert.True(t, entHistory[1].Deleted)
+	assert.Equal(t, map[string]string{"region": "west"}, entHistory[1].Labels)
+
+	// Rolling back an entity's config restores its labels as well
+	restored, err = configurator.RollbackConfig(historyNetworkID, configurator.EntityConfigID("foo", "ent"), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), restored.Revision)
+	assert.Equal(t, "e1", restored.Config)
+	assert.Equal(t, map[string]string{"region": "west"}, restored.Labels)
+	actualEnt, err := configurator.LoadEntity(historyNetworkID, "foo", "ent", configurator.EntityLoadCriteria{LoadConfig: true, LoadLabels: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "e1", actualEnt.Config)
+	assert.Equal(t, map[string]string{"region": "west"}, actualEnt.Labels)
+
+	_, err = configurator.RollbackConfig(historyNetworkID, configurator.EntityConfigID("foo", "ent"), 42)
+	assert.Equal(t, merrors.ErrNotFound, err)
 }
 
 func strPointer(str string) *string {
@@ -14,6 +14,8 @@ and meta data for the network and network entity structures.
 package main
 
 import (
+	"time"
+
 	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/service"
@@ -26,6 +28,10 @@ import (
 	"github.com/golang/glog"
 )
 
+// changeLogPruneInterval is how often changes past their retention period are
+// deleted from the change log
+const changeLogPruneInterval = time.Hour
+
 func main() {
 	// Create the service
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, configurator.ServiceName)
@@ -55,6 +61,8 @@ func main() {
 	}
 	protos.RegisterSouthboundConfiguratorServer(srv.GrpcServer, sbServicer)
 
+	go servicers.PruneChangeLog(factory, changeLogPruneInterval)
+
 	err = srv.Run()
 	if err != nil {
 		glog.Fatalf("Failed to start configurator service: %v", err)
@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator"
 
@@ -78,7 +79,7 @@ func GetDeleteNetworkConfigHandler(path string, configType string) obsidian.Hand
 			if nerr != nil {
 				return nerr
 			}
-			err := configurator.DeleteNetworkConfig(networkID, configType)
+			err := access.ConfigWriter(c).DeleteNetworkConfig(networkID, configType)
 			if err != nil {
 				return obsidian.HttpError(err, http.StatusBadRequest)
 			}
@@ -101,7 +102,7 @@ func createOrUpdateNetworkConfig(c echo.Context, configType string, modelPtr ser
 		ID:                   networkID,
 		ConfigsToAddOrUpdate: map[string]interface{}{configType: config},
 	}
-	err = configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{updateCriteria})
+	err = access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{updateCriteria})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
@@ -716,6 +716,352 @@ func (m *DeleteEntitiesRequest) GetID() []*storage.EntityID {
 	return nil
 }
 
+type WatchNetworksRequest struct {
+	// Only changes after this cursor will be streamed. Pass 0 to stream the
+	// full change log.
+	AfterSequence        uint64   `protobuf:"varint,1,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchNetworksRequest) Reset()         { *m = WatchNetworksRequest{} }
+func (m *WatchNetworksRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchNetworksRequest) ProtoMessage()    {}
+func (*WatchNetworksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{15}
+}
+
+func (m *WatchNetworksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchNetworksRequest.Unmarshal(m, b)
+}
+func (m *WatchNetworksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchNetworksRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchNetworksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchNetworksRequest.Merge(m, src)
+}
+func (m *WatchNetworksRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchNetworksRequest.Size(m)
+}
+func (m *WatchNetworksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchNetworksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchNetworksRequest proto.InternalMessageInfo
+
+func (m *WatchNetworksRequest) GetAfterSequence() uint64 {
+	if m != nil {
+		return m.AfterSequence
+	}
+	return 0
+}
+
+type WatchEntitiesRequest struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// If non-empty, only changes to entities of this type will be streamed.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Only changes after this cursor will be streamed. Pass 0 to stream the
+	// full change log.
+	AfterSequence        uint64   `protobuf:"varint,3,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEntitiesRequest) Reset()         { *m = WatchEntitiesRequest{} }
+func (m *WatchEntitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEntitiesRequest) ProtoMessage()    {}
+func (*WatchEntitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{16}
+}
+
+func (m *WatchEntitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEntitiesRequest.Unmarshal(m, b)
+}
+func (m *WatchEntitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEntitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchEntitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEntitiesRequest.Merge(m, src)
+}
+func (m *WatchEntitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchEntitiesRequest.Size(m)
+}
+func (m *WatchEntitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEntitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEntitiesRequest proto.InternalMessageInfo
+
+func (m *WatchEntitiesRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *WatchEntitiesRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *WatchEntitiesRequest) GetAfterSequence() uint64 {
+	if m != nil {
+		return m.AfterSequence
+	}
+	return 0
+}
+
+type LoadChangesRequest struct {
+	// The filter selects the change stream to load from, see
+	// storage.ChangeLoadFilter
+	Filter *storage.ChangeLoadFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// If set, only the latest sequence of the stream is returned
+	LatestSequenceOnly   bool     `protobuf:"varint,2,opt,name=latest_sequence_only,json=latestSequenceOnly,proto3" json:"latest_sequence_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoadChangesRequest) Reset()         { *m = LoadChangesRequest{} }
+func (m *LoadChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadChangesRequest) ProtoMessage()    {}
+func (*LoadChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{17}
+}
+
+func (m *LoadChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadChangesRequest.Unmarshal(m, b)
+}
+func (m *LoadChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadChangesRequest.Marshal(b, m, deterministic)
+}
+func (m *LoadChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadChangesRequest.Merge(m, src)
+}
+func (m *LoadChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_LoadChangesRequest.Size(m)
+}
+func (m *LoadChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadChangesRequest proto.InternalMessageInfo
+
+func (m *LoadChangesRequest) GetFilter() *storage.ChangeLoadFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *LoadChangesRequest) GetLatestSequenceOnly() bool {
+	if m != nil {
+		return m.LatestSequenceOnly
+	}
+	return false
+}
+
+type LoadChangesResponse struct {
+	Changes              []*storage.Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	LatestSequence       uint64            `protobuf:"varint,2,opt,name=latest_sequence,json=latestSequence,proto3" json:"latest_sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LoadChangesResponse) Reset()         { *m = LoadChangesResponse{} }
+func (m *LoadChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*LoadChangesResponse) ProtoMessage()    {}
+func (*LoadChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{18}
+}
+
+func (m *LoadChangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadChangesResponse.Unmarshal(m, b)
+}
+func (m *LoadChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadChangesResponse.Marshal(b, m, deterministic)
+}
+func (m *LoadChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadChangesResponse.Merge(m, src)
+}
+func (m *LoadChangesResponse) XXX_Size() int {
+	return xxx_messageInfo_LoadChangesResponse.Size(m)
+}
+func (m *LoadChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadChangesResponse proto.InternalMessageInfo
+
+func (m *LoadChangesResponse) GetChanges() []*storage.Change {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *LoadChangesResponse) GetLatestSequence() uint64 {
+	if m != nil {
+		return m.LatestSequence
+	}
+	return 0
+}
+
+type ListConfigHistoryRequest struct {
+	Filter               *storage.ConfigHistoryFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ListConfigHistoryRequest) Reset()         { *m = ListConfigHistoryRequest{} }
+func (m *ListConfigHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListConfigHistoryRequest) ProtoMessage()    {}
+func (*ListConfigHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{19}
+}
+
+func (m *ListConfigHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListConfigHistoryRequest.Unmarshal(m, b)
+}
+func (m *ListConfigHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListConfigHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *ListConfigHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConfigHistoryRequest.Merge(m, src)
+}
+func (m *ListConfigHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ListConfigHistoryRequest.Size(m)
+}
+func (m *ListConfigHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConfigHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConfigHistoryRequest proto.InternalMessageInfo
+
+func (m *ListConfigHistoryRequest) GetFilter() *storage.ConfigHistoryFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type ListConfigHistoryResponse struct {
+	Revisions            []*storage.ConfigRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ListConfigHistoryResponse) Reset()         { *m = ListConfigHistoryResponse{} }
+func (m *ListConfigHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ListConfigHistoryResponse) ProtoMessage()    {}
+func (*ListConfigHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{20}
+}
+
+func (m *ListConfigHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListConfigHistoryResponse.Unmarshal(m, b)
+}
+func (m *ListConfigHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListConfigHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *ListConfigHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConfigHistoryResponse.Merge(m, src)
+}
+func (m *ListConfigHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_ListConfigHistoryResponse.Size(m)
+}
+func (m *ListConfigHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConfigHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConfigHistoryResponse proto.InternalMessageInfo
+
+func (m *ListConfigHistoryResponse) GetRevisions() []*storage.ConfigRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type RollbackConfigRequest struct {
+	NetworkID string                      `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Kind      storage.ConfigRevision_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=magma.orc8r.configurator.storage.ConfigRevision_Kind" json:"kind,omitempty"`
+	Type      string                      `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Key       string                      `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	// Revision to restore
+	Revision             uint64   `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackConfigRequest) Reset()         { *m = RollbackConfigRequest{} }
+func (m *RollbackConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackConfigRequest) ProtoMessage()    {}
+func (*RollbackConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90b042c70967f647, []int{21}
+}
+
+func (m *RollbackConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackConfigRequest.Unmarshal(m, b)
+}
+func (m *RollbackConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *RollbackConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackConfigRequest.Merge(m, src)
+}
+func (m *RollbackConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_RollbackConfigRequest.Size(m)
+}
+func (m *RollbackConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackConfigRequest proto.InternalMessageInfo
+
+func (m *RollbackConfigRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *RollbackConfigRequest) GetKind() storage.ConfigRevision_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return storage.ConfigRevision_NETWORK_CONFIG
+}
+
+func (m *RollbackConfigRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *RollbackConfigRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *RollbackConfigRequest) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ListNetworkIDsResponse)(nil), "magma.orc8r.configurator.ListNetworkIDsResponse")
 	proto.RegisterType((*LoadNetworksRequest)(nil), "magma.orc8r.configurator.LoadNetworksRequest")
@@ -734,63 +1080,90 @@ func init() {
 	proto.RegisterType((*UpdateEntitiesResponse)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse")
 	proto.RegisterMapType((map[string]*storage.NetworkEntity)(nil), "magma.orc8r.configurator.UpdateEntitiesResponse.UpdatedEntitiesEntry")
 	proto.RegisterType((*DeleteEntitiesRequest)(nil), "magma.orc8r.configurator.DeleteEntitiesRequest")
+	proto.RegisterType((*WatchNetworksRequest)(nil), "magma.orc8r.configurator.WatchNetworksRequest")
+	proto.RegisterType((*WatchEntitiesRequest)(nil), "magma.orc8r.configurator.WatchEntitiesRequest")
+	proto.RegisterType((*LoadChangesRequest)(nil), "magma.orc8r.configurator.LoadChangesRequest")
+	proto.RegisterType((*LoadChangesResponse)(nil), "magma.orc8r.configurator.LoadChangesResponse")
+	proto.RegisterType((*ListConfigHistoryRequest)(nil), "magma.orc8r.configurator.ListConfigHistoryRequest")
+	proto.RegisterType((*ListConfigHistoryResponse)(nil), "magma.orc8r.configurator.ListConfigHistoryResponse")
+	proto.RegisterType((*RollbackConfigRequest)(nil), "magma.orc8r.configurator.RollbackConfigRequest")
 }
 
 func init() { proto.RegisterFile("northbound.proto", fileDescriptor_90b042c70967f647) }
 
 var fileDescriptor_90b042c70967f647 = []byte{
-	// 809 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcd, 0x6e, 0xd3, 0x4c,
-	0x14, 0x8d, 0x53, 0x7d, 0x69, 0x72, 0xfb, 0xd1, 0x86, 0xa1, 0x89, 0xa2, 0x08, 0x41, 0x35, 0xab,
-	0x82, 0x20, 0xae, 0x52, 0xa0, 0x55, 0x57, 0xa8, 0x75, 0x10, 0xa1, 0x55, 0xd5, 0x5a, 0xd0, 0x4a,
-	0x5d, 0x80, 0x5c, 0x67, 0x1a, 0x4c, 0x13, 0x4f, 0x3a, 0x1e, 0xb7, 0x0a, 0x4b, 0x36, 0x3c, 0x11,
-	0x3b, 0x78, 0x08, 0x96, 0x3c, 0x0d, 0x28, 0x9e, 0xb1, 0x63, 0x3b, 0x4e, 0x62, 0x77, 0x81, 0x58,
-	0xc5, 0x9a, 0x99, 0x7b, 0xce, 0xfd, 0x39, 0x73, 0xe7, 0x06, 0xca, 0x36, 0x65, 0xfc, 0xe3, 0x39,
-	0x75, 0xed, 0x4e, 0x63, 0xc0, 0x28, 0xa7, 0xa8, 0xd6, 0x37, 0xba, 0x7d, 0xa3, 0x41, 0x99, 0xb9,
-	0xcd, 0x1a, 0x26, 0xb5, 0x2f, 0xac, 0xae, 0xcb, 0x0c, 0x4e, 0x59, 0xfd, 0xa1, 0xb7, 0xa3, 0x7a,
-	0x3b, 0xaa, 0x77, 0xd8, 0x51, 0x4d, 0xda, 0xef, 0x53, 0x5b, 0x98, 0xd6, 0x5f, 0x86, 0x0f, 0x98,
-	0x3d, 0xea, 0x76, 0xd4, 0x2e, 0x55, 0x1d, 0xc2, 0xae, 0x2d, 0x93, 0x8c, 0xce, 0x8e, 0xc1, 0x54,
-	0x87, 0x53, 0x66, 0x74, 0x89, 0xff, 0x2b, 0x10, 0xf0, 0x36, 0x54, 0x0f, 0x2c, 0x87, 0x1f, 0x12,
-	0x7e, 0x43, 0xd9, 0x65, 0x5b, 0x73, 0x74, 0xe2, 0x0c, 0xa8, 0xed, 0x10, 0xf4, 0x00, 0xc0, 0x0e,
-	0x56, 0x6b, 0xca, 0xda, 0xc2, 0x7a, 0x49, 0x0f, 0xad, 0xe0, 0x6f, 0x0a, 0xdc, 0x3b, 0xa0, 0x46,
-	0x47, 0x9a, 0x3a, 0x3a, 0xb9, 0x72, 0x89, 0xc3, 0xd1, 0x31, 0x14, 0x4d, 0x66, 0x71, 0xc2, 0x2c,
-	0xa3, 0x96, 0x5f, 0x53, 0xd6, 0x97, 0x9a, 0xcf, 0x1b, 0xd3, 0x22, 0x6c, 0xf8, 0xce, 0x48, 0x90,
-	0x11, 0xde, 0x9e, 0x34, 0xd6, 0x03, 0x18, 0xb4, 0x0f, 0x85, 0x0b, 0xab, 0xc7, 0x09, 0xab, 0x2d,
-	0x78, 0x80, 0x9b, 0x99, 0x00, 0x5f, 0x79, 0xa6, 0xba, 0x84, 0xc0, 0xef, 0xa1, 0xb2, 0xc7, 0x88,
-	0xc1, 0x49, 0xdc, 0xf1, 0x16, 0x14, 0x65, 0x78, 0x22, 0xdc, 0xa5, 0xe6, 0xa3, 0xd4, 0x3c, 0x7a,
-	0x60, 0x8a, 0x6d, 0xa8, 0xc6, 0xf1, 0x65, 0x46, 0xdf, 0x42, 0xd9, 0xf4, 0x76, 0x3a, 0x1f, 0x6e,
-	0x4f, 0xb4, 0x22, 0x21, 0x7c, 0x74, 0xfc, 0x09, 0x2a, 0xef, 0x06, 0x9d, 0x84, 0x78, 0x8e, 0x61,
-	0xd1, 0xf5, 0x36, 0x7c, 0x96, 0xad, 0xd4, 0x2c, 0x02, 0x30, 0xa8, 0x84, 0x8f, 0x83, 0xb7, 0xa0,
-	0xa2, 0x91, 0x1e, 0x99, 0xe4, 0x9a, 0x27, 0x96, 0x9f, 0x52, 0x2c, 0x2d, 0x9b, 0x5b, 0xdc, 0x22,
-	0x81, 0xdd, 0x7d, 0x28, 0x05, 0xa7, 0x6a, 0xca, 0x9a, 0xb2, 0x5e, 0xd2, 0xc7, 0x0b, 0xe8, 0x4d,
-	0x50, 0x77, 0x21, 0xa4, 0xe6, 0xfc, 0x00, 0x3c, 0x82, 0xe1, 0x64, 0xd9, 0xd1, 0x51, 0x48, 0x96,
-	0x42, 0x45, 0xcf, 0xb2, 0xa0, 0x4d, 0xaa, 0x12, 0x7f, 0x86, 0xd5, 0xd3, 0xd1, 0x77, 0xb6, 0x98,
-	0x34, 0x28, 0xdc, 0x8c, 0xac, 0x9c, 0x5a, 0xde, 0x2b, 0xca, 0x93, 0xe9, 0x5e, 0x8c, 0xd1, 0x87,
-	0x12, 0x5b, 0x97, 0xb6, 0xf8, 0xbb, 0x02, 0x68, 0x72, 0x1b, 0xb5, 0xa1, 0x20, 0xe4, 0xe1, 0xf1,
-	0x2e, 0x35, 0xd5, 0xd4, 0x15, 0x17, 0x38, 0xaf, 0x73, 0xba, 0x04, 0x40, 0x47, 0x50, 0x10, 0x55,
-	0x97, 0xb9, 0x7f, 0x91, 0x36, 0x5b, 0x51, 0xed, 0x8c, 0x10, 0x05, 0xce, 0x6e, 0x09, 0x16, 0x99,
-	0xf0, 0x13, 0xff, 0xca, 0x43, 0x25, 0x96, 0x3b, 0x79, 0x47, 0xce, 0xc6, 0x77, 0x84, 0xc8, 0x3d,
-	0xa9, 0xde, 0xac, 0xb1, 0x04, 0x37, 0xc5, 0xe7, 0x40, 0x14, 0xca, 0xc2, 0x95, 0x10, 0xb6, 0x28,
-	0x82, 0x96, 0xa6, 0x08, 0x21, 0x37, 0x1b, 0x22, 0xc8, 0x00, 0xba, 0x65, 0x73, 0x36, 0xd4, 0x57,
-	0xdc, 0xe8, 0x6a, 0xdd, 0x81, 0xd5, 0xa4, 0x83, 0xa8, 0x0c, 0x0b, 0x97, 0x64, 0x28, 0xb5, 0x31,
-	0xfa, 0x44, 0x2d, 0xf8, 0xef, 0xda, 0xe8, 0xb9, 0x7e, 0xb2, 0x33, 0xc7, 0x2a, 0xac, 0x77, 0xf2,
-	0xdb, 0x0a, 0xfe, 0xa2, 0xf8, 0x0d, 0x2e, 0x9b, 0x30, 0xf7, 0xa1, 0x18, 0xcb, 0x4a, 0x66, 0x2f,
-	0x02, 0x00, 0xcc, 0xfd, 0x26, 0xf8, 0x37, 0x0b, 0x8c, 0xbf, 0x2a, 0x7e, 0x2f, 0xcc, 0x16, 0xfa,
-	0xd1, 0xb8, 0x53, 0x8a, 0xc8, 0x6f, 0x29, 0xf6, 0x71, 0xa3, 0xfc, 0xad, 0x40, 0x35, 0xee, 0x89,
-	0x4c, 0xc0, 0x20, 0x41, 0x85, 0x22, 0x01, 0xad, 0xe9, 0xac, 0xc9, 0x58, 0xff, 0xb2, 0x0c, 0xaf,
-	0xfc, 0xa7, 0x22, 0x5b, 0x29, 0x76, 0x20, 0xdf, 0xd6, 0x64, 0x15, 0x1e, 0xa7, 0xad, 0x42, 0x5b,
-	0xd3, 0xf3, 0x6d, 0xad, 0xf9, 0xa3, 0x08, 0xd5, 0xc3, 0x60, 0xba, 0xda, 0x0b, 0x9d, 0x47, 0xa7,
-	0xb0, 0x1c, 0x1d, 0x73, 0xd0, 0xdd, 0x08, 0xf8, 0x09, 0xb5, 0x3a, 0xf5, 0x8d, 0xe9, 0x7c, 0xc9,
-	0x33, 0x12, 0xce, 0x21, 0x17, 0x96, 0xa3, 0xaf, 0x3d, 0x9a, 0x91, 0xb4, 0xc4, 0xb9, 0x63, 0x16,
-	0x6d, 0xf2, 0x20, 0x81, 0x73, 0xe8, 0x04, 0x96, 0xa3, 0x8f, 0xfe, 0x2c, 0xda, 0xc4, 0xf1, 0xa0,
-	0x3e, 0x99, 0x00, 0x81, 0x1b, 0x7d, 0xe0, 0x67, 0xe1, 0x26, 0x8e, 0x02, 0xc9, 0xb8, 0x1c, 0xfe,
-	0x0f, 0xcf, 0x8a, 0xe8, 0xe9, 0x8c, 0x54, 0x4f, 0xce, 0x94, 0xf5, 0x6c, 0x03, 0x9f, 0x4e, 0x1c,
-	0xb7, 0xc7, 0x71, 0x0e, 0x31, 0xb8, 0x13, 0x69, 0xdf, 0xa8, 0x91, 0xba, 0xcf, 0x0b, 0x5e, 0x35,
-	0xe3, 0xbb, 0x10, 0x16, 0x44, 0x40, 0x3a, 0x57, 0x10, 0x71, 0xd6, 0x8d, 0xf4, 0x06, 0x61, 0xda,
-	0x68, 0x8f, 0x98, 0x2f, 0x88, 0x0c, 0xb4, 0xc9, 0xed, 0x27, 0xac, 0x97, 0x34, 0xb4, 0x89, 0xfd,
-	0x20, 0x59, 0x2f, 0x8e, 0xd0, 0x4b, 0x80, 0x3a, 0x47, 0x2f, 0x71, 0xcc, 0x4c, 0x83, 0xa2, 0x2f,
-	0x97, 0xdd, 0xe2, 0x59, 0x41, 0xfc, 0xc9, 0x3a, 0x17, 0xbf, 0x9b, 0x7f, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0xda, 0x6f, 0xc2, 0x9d, 0xad, 0x0d, 0x00, 0x00,
+	// 1128 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4f, 0x6f, 0xe3, 0xc4,
+	0x1b, 0xae, 0xd3, 0x6c, 0x9a, 0xbc, 0xfd, 0x35, 0xed, 0xce, 0xaf, 0xa9, 0x82, 0x85, 0xa0, 0x1a,
+	0x09, 0x51, 0xd0, 0x6e, 0x12, 0xa5, 0x2c, 0x5b, 0xad, 0x84, 0x84, 0xda, 0x04, 0x6d, 0xe8, 0x52,
+	0xba, 0x03, 0x6c, 0xa5, 0x3d, 0x50, 0xb9, 0xce, 0x34, 0x35, 0x71, 0x3c, 0x59, 0x7b, 0xd2, 0x55,
+	0x38, 0x20, 0xb4, 0x17, 0xee, 0x7c, 0x17, 0x6e, 0x9c, 0xf8, 0x06, 0x1c, 0xf9, 0x34, 0xa0, 0x78,
+	0xc6, 0x13, 0xdb, 0x99, 0x26, 0xf6, 0x1e, 0x10, 0xa7, 0xb8, 0x33, 0x7e, 0x9f, 0xe7, 0xfd, 0xf3,
+	0x78, 0xde, 0x77, 0x0a, 0x3b, 0x1e, 0xf3, 0xf9, 0xcd, 0x15, 0x9b, 0x78, 0xfd, 0xc6, 0xd8, 0x67,
+	0x9c, 0xa1, 0xfa, 0xc8, 0x1a, 0x8c, 0xac, 0x06, 0xf3, 0xed, 0x23, 0xbf, 0x61, 0x33, 0xef, 0xda,
+	0x19, 0x4c, 0x7c, 0x8b, 0x33, 0xdf, 0x7c, 0x3f, 0xdc, 0x69, 0x86, 0x3b, 0xcd, 0xf0, 0xe5, 0xa0,
+	0x69, 0xb3, 0xd1, 0x88, 0x79, 0xc2, 0xd4, 0xfc, 0x3c, 0xfe, 0x82, 0xed, 0xb2, 0x49, 0xbf, 0x39,
+	0x60, 0xcd, 0x80, 0xfa, 0xb7, 0x8e, 0x4d, 0x83, 0x66, 0x1c, 0xac, 0x19, 0x70, 0xe6, 0x5b, 0x03,
+	0x1a, 0xfd, 0x0a, 0x04, 0x7c, 0x04, 0x7b, 0xcf, 0x9c, 0x80, 0x9f, 0x51, 0xfe, 0x9a, 0xf9, 0xc3,
+	0x5e, 0x27, 0x20, 0x34, 0x18, 0x33, 0x2f, 0xa0, 0xe8, 0x3d, 0x00, 0x4f, 0xad, 0xd6, 0x8d, 0xfd,
+	0xf5, 0x83, 0x0a, 0x89, 0xad, 0xe0, 0xdf, 0x0c, 0xf8, 0xff, 0x33, 0x66, 0xf5, 0xa5, 0x69, 0x40,
+	0xe8, 0xab, 0x09, 0x0d, 0x38, 0x7a, 0x0e, 0x65, 0xdb, 0x77, 0x38, 0xf5, 0x1d, 0xab, 0x5e, 0xd8,
+	0x37, 0x0e, 0x36, 0xdb, 0x8f, 0x1a, 0x77, 0x45, 0xd8, 0x88, 0x9c, 0x91, 0x20, 0x33, 0xbc, 0x13,
+	0x69, 0x4c, 0x14, 0x0c, 0x3a, 0x85, 0xd2, 0xb5, 0xe3, 0x72, 0xea, 0xd7, 0xd7, 0x43, 0xc0, 0xc3,
+	0x5c, 0x80, 0x5f, 0x84, 0xa6, 0x44, 0x42, 0xe0, 0xef, 0xa1, 0x76, 0xe2, 0x53, 0x8b, 0xd3, 0xb4,
+	0xe3, 0x5d, 0x28, 0xcb, 0xf0, 0x44, 0xb8, 0x9b, 0xed, 0x8f, 0x32, 0xf3, 0x10, 0x65, 0x8a, 0x3d,
+	0xd8, 0x4b, 0xe3, 0xcb, 0x8c, 0x7e, 0x0b, 0x3b, 0x76, 0xb8, 0xd3, 0xbf, 0x7c, 0x7b, 0xa2, 0x6d,
+	0x09, 0x11, 0xa1, 0xe3, 0x1f, 0xa0, 0xf6, 0xdd, 0xb8, 0xaf, 0x89, 0xe7, 0x39, 0x6c, 0x4c, 0xc2,
+	0x8d, 0x88, 0xe5, 0x71, 0x66, 0x16, 0x01, 0xa8, 0x2a, 0x11, 0xe1, 0xe0, 0xc7, 0x50, 0xeb, 0x50,
+	0x97, 0x2e, 0x72, 0xad, 0x12, 0xcb, 0x9f, 0x52, 0x2c, 0x5d, 0x8f, 0x3b, 0xdc, 0xa1, 0xca, 0xee,
+	0x5d, 0xa8, 0xa8, 0xb7, 0xea, 0xc6, 0xbe, 0x71, 0x50, 0x21, 0xf3, 0x05, 0xf4, 0xa5, 0xaa, 0xbb,
+	0x10, 0x52, 0x7b, 0x75, 0x00, 0x21, 0xc1, 0x74, 0xb1, 0xec, 0xe8, 0x3c, 0x26, 0x4b, 0xa1, 0xa2,
+	0x4f, 0xf2, 0xa0, 0x2d, 0xaa, 0x12, 0xff, 0x08, 0xbb, 0x17, 0xb3, 0xe7, 0x7c, 0x31, 0x75, 0xa0,
+	0xf4, 0x7a, 0x66, 0x15, 0xd4, 0x0b, 0x61, 0x51, 0x1e, 0xdc, 0xed, 0xc5, 0x1c, 0x7d, 0x2a, 0xb1,
+	0x89, 0xb4, 0xc5, 0xbf, 0x1b, 0x80, 0x16, 0xb7, 0x51, 0x0f, 0x4a, 0x42, 0x1e, 0x21, 0xef, 0x66,
+	0xbb, 0x99, 0xb9, 0xe2, 0x02, 0xe7, 0xe9, 0x1a, 0x91, 0x00, 0xe8, 0x1c, 0x4a, 0xa2, 0xea, 0x32,
+	0xf7, 0x9f, 0x66, 0xcd, 0x56, 0x52, 0x3b, 0x33, 0x44, 0x81, 0x73, 0x5c, 0x81, 0x0d, 0x5f, 0xf8,
+	0x89, 0xff, 0x2a, 0x40, 0x2d, 0x95, 0x3b, 0xf9, 0x8d, 0xbc, 0x9c, 0x7f, 0x23, 0x54, 0xee, 0x49,
+	0xf5, 0xe6, 0x8d, 0x45, 0x7d, 0x29, 0x11, 0x07, 0x62, 0xb0, 0x23, 0x5c, 0x89, 0x61, 0x8b, 0x22,
+	0x74, 0xb2, 0x14, 0x21, 0xe6, 0x66, 0x43, 0x04, 0xa9, 0xa0, 0xbb, 0x1e, 0xf7, 0xa7, 0x64, 0x7b,
+	0x92, 0x5c, 0x35, 0x03, 0xd8, 0xd5, 0xbd, 0x88, 0x76, 0x60, 0x7d, 0x48, 0xa7, 0x52, 0x1b, 0xb3,
+	0x47, 0xd4, 0x85, 0x7b, 0xb7, 0x96, 0x3b, 0x89, 0x92, 0x9d, 0x3b, 0x56, 0x61, 0xfd, 0xa4, 0x70,
+	0x64, 0xe0, 0x37, 0x46, 0x74, 0xc0, 0xe5, 0x13, 0xe6, 0x29, 0x94, 0x53, 0x59, 0xc9, 0xed, 0x85,
+	0x02, 0xc0, 0x3c, 0x3a, 0x04, 0xff, 0xcd, 0x02, 0xe3, 0x5f, 0x8c, 0xe8, 0x2c, 0xcc, 0x17, 0xfa,
+	0xf9, 0xfc, 0xa4, 0x14, 0x91, 0xbf, 0xa5, 0xd8, 0xe7, 0x07, 0xe5, 0xdf, 0x06, 0xec, 0xa5, 0x3d,
+	0x91, 0x09, 0x18, 0x6b, 0x54, 0x28, 0x12, 0xd0, 0xbd, 0x9b, 0x55, 0x8f, 0xf5, 0x5f, 0x96, 0xe1,
+	0xab, 0xa8, 0x55, 0xe4, 0x2b, 0xc5, 0x13, 0x28, 0xf4, 0x3a, 0xb2, 0x0a, 0x1f, 0x67, 0xad, 0x42,
+	0xaf, 0x43, 0x0a, 0xbd, 0x0e, 0xfe, 0x0c, 0x76, 0x2f, 0x2c, 0x6e, 0xdf, 0xa4, 0x9b, 0xd3, 0x07,
+	0x50, 0xb5, 0xae, 0x39, 0xf5, 0x2f, 0x83, 0xd9, 0x82, 0x67, 0x8b, 0xd3, 0xb1, 0x48, 0xb6, 0xc2,
+	0xd5, 0x6f, 0xe4, 0x22, 0x66, 0xd2, 0x3c, 0x9f, 0xc3, 0x08, 0x8a, 0x7c, 0x3a, 0x16, 0x19, 0xab,
+	0x90, 0xf0, 0x59, 0x43, 0xb8, 0xae, 0x23, 0xfc, 0xd5, 0x00, 0x14, 0xf6, 0x96, 0x1b, 0xcb, 0x1b,
+	0xcc, 0xf9, 0xe6, 0x5d, 0xcf, 0xc8, 0xda, 0xf5, 0x04, 0x82, 0xa6, 0xeb, 0xb5, 0x60, 0xd7, 0x9d,
+	0x09, 0x92, 0x2b, 0x57, 0x2e, 0x99, 0xe7, 0x4e, 0x43, 0x6f, 0xcb, 0x04, 0x89, 0xbd, 0xc8, 0xa1,
+	0xaf, 0x3d, 0x77, 0x8a, 0xdf, 0xc8, 0x4e, 0xad, 0x9c, 0x92, 0xb2, 0x3d, 0x86, 0x0d, 0x5b, 0x2c,
+	0x49, 0xb5, 0x1e, 0x64, 0x75, 0x8b, 0x44, 0x86, 0xe8, 0x43, 0xd8, 0x4e, 0x79, 0x13, 0x3a, 0x52,
+	0x24, 0xd5, 0xa4, 0x23, 0xd8, 0x81, 0xfa, 0x6c, 0x2a, 0x3d, 0x09, 0x41, 0x9f, 0x3a, 0x33, 0x3c,
+	0xd5, 0xe3, 0xbe, 0x4a, 0xa5, 0x27, 0xc3, 0x74, 0x99, 0xc0, 0x49, 0x8d, 0x83, 0x43, 0x78, 0x47,
+	0x43, 0x25, 0x83, 0x3e, 0x83, 0x8a, 0x4f, 0x6f, 0x9d, 0xc0, 0x61, 0x5e, 0x14, 0x76, 0x2b, 0x2b,
+	0x1d, 0x91, 0x86, 0x64, 0x0e, 0x81, 0xff, 0x30, 0xa0, 0x46, 0x98, 0xeb, 0x5e, 0x59, 0xf6, 0x30,
+	0x7a, 0x2b, 0x8b, 0xc8, 0x7a, 0x50, 0x1c, 0x3a, 0x5e, 0x3f, 0xcc, 0x56, 0xb5, 0xfd, 0x28, 0xaf,
+	0x0b, 0x8d, 0x53, 0xc7, 0xeb, 0x93, 0x10, 0x42, 0xe9, 0x75, 0x3d, 0xa6, 0x57, 0x79, 0x10, 0x14,
+	0xe7, 0x07, 0x81, 0x09, 0xe5, 0xc8, 0xeb, 0xfa, 0xbd, 0xb0, 0x44, 0xea, 0xef, 0xf6, 0xcf, 0x5b,
+	0xb0, 0x77, 0xa6, 0x2e, 0x31, 0x27, 0x31, 0x7a, 0x74, 0x01, 0xd5, 0xe4, 0x6d, 0x02, 0xdd, 0x4f,
+	0xf8, 0xfa, 0x82, 0x39, 0x7d, 0x73, 0x49, 0x06, 0xf5, 0x57, 0x11, 0xbc, 0x86, 0x26, 0x50, 0x4d,
+	0x0e, 0xd5, 0x68, 0xc9, 0xd9, 0xa4, 0x1d, 0xef, 0xcd, 0x56, 0x76, 0x03, 0x45, 0xfb, 0x02, 0xaa,
+	0xc9, 0xd9, 0x7a, 0x19, 0xad, 0x76, 0x0a, 0x37, 0x17, 0x13, 0x20, 0x70, 0x93, 0x73, 0xf4, 0x32,
+	0x5c, 0xed, 0xc4, 0xad, 0xc7, 0xe5, 0xf0, 0xbf, 0xf8, 0x95, 0x0c, 0x3d, 0x5c, 0x92, 0xea, 0xc5,
+	0xab, 0x9b, 0x99, 0xef, 0x5e, 0x45, 0x68, 0x30, 0x71, 0x39, 0x5e, 0x43, 0x3e, 0x6c, 0x25, 0xa6,
+	0x24, 0xd4, 0xc8, 0x3c, 0x4e, 0x09, 0xde, 0x66, 0xce, 0xf1, 0x2b, 0x2e, 0x08, 0x45, 0xba, 0x52,
+	0x10, 0x69, 0xd6, 0x56, 0x76, 0x83, 0x38, 0x6d, 0xb2, 0x15, 0xaf, 0x16, 0x44, 0x0e, 0x5a, 0x7d,
+	0x97, 0x8f, 0xeb, 0x25, 0x0b, 0xad, 0xb6, 0xed, 0xea, 0xf5, 0x12, 0x08, 0xbd, 0x28, 0xd4, 0x15,
+	0x7a, 0x49, 0x63, 0xe6, 0xba, 0x8f, 0x29, 0xb9, 0x8c, 0x60, 0x2b, 0xd1, 0xa6, 0x97, 0xca, 0x45,
+	0xd3, 0xcf, 0xcd, 0xcc, 0x9d, 0x07, 0xaf, 0xb5, 0x0c, 0x45, 0x97, 0x49, 0x9d, 0x9a, 0xfe, 0x9f,
+	0x93, 0xce, 0x85, 0xcd, 0x58, 0xfb, 0x44, 0x0f, 0x96, 0x67, 0x34, 0xd9, 0xfa, 0xcd, 0x87, 0x19,
+	0xdf, 0x56, 0xc2, 0xf8, 0x09, 0xee, 0x2f, 0x74, 0x2f, 0xd4, 0x5e, 0x7e, 0xc0, 0xea, 0xba, 0xaa,
+	0x79, 0x98, 0xcb, 0x26, 0xfe, 0x3d, 0x24, 0xfb, 0xd9, 0x32, 0x61, 0x6a, 0x3b, 0x9f, 0x99, 0xbb,
+	0xa1, 0xe2, 0xb5, 0xe3, 0xf2, 0xcb, 0x92, 0xf8, 0x77, 0xd8, 0x95, 0xf8, 0x3d, 0xfc, 0x67, 0x00,
+	0x4f, 0xf7, 0xb8, 0xe6, 0x57, 0x13, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -826,6 +1199,26 @@ type NorthboundConfiguratorClient interface {
 	DeleteEntities(ctx context.Context, in *DeleteEntitiesRequest, opts ...grpc.CallOption) (*protos.Void, error)
 	// LoadEntities fetches the set of Entities specified by the request
 	LoadEntities(ctx context.Context, in *LoadEntitiesRequest, opts ...grpc.CallOption) (*storage.EntityLoadResult, error)
+	// WatchNetworks streams every network change committed after the
+	// requested cursor. The stream stays open until the client cancels it,
+	// or fails with OUT_OF_RANGE once changes after the cursor are pruned.
+	WatchNetworks(ctx context.Context, in *WatchNetworksRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchNetworksClient, error)
+	// WatchEntities streams every entity change within a network committed
+	// after the requested cursor. The stream stays open until the client
+	// cancels it, or fails with OUT_OF_RANGE once changes after the cursor
+	// are pruned.
+	WatchEntities(ctx context.Context, in *WatchEntitiesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchEntitiesClient, error)
+	// LoadChanges returns the changes matching the filter which were
+	// committed up to the latest sequence of the filter's change stream at
+	// the time of the request, along with that latest sequence.
+	LoadChanges(ctx context.Context, in *LoadChangesRequest, opts ...grpc.CallOption) (*LoadChangesResponse, error)
+	// ListConfigHistory returns the revisions of a network config or entity
+	// config, newest first.
+	ListConfigHistory(ctx context.Context, in *ListConfigHistoryRequest, opts ...grpc.CallOption) (*ListConfigHistoryResponse, error)
+	// RollbackConfig atomically restores a network config or entity config
+	// to the value it had at a previous revision. The restore is itself
+	// recorded as a new revision, which is returned.
+	RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*storage.ConfigRevision, error)
 }
 
 type northboundConfiguratorClient struct {
@@ -926,6 +1319,97 @@ func (c *northboundConfiguratorClient) LoadEntities(ctx context.Context, in *Loa
 	return out, nil
 }
 
+func (c *northboundConfiguratorClient) WatchNetworks(ctx context.Context, in *WatchNetworksRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchNetworksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NorthboundConfigurator_serviceDesc.Streams[0], "/magma.orc8r.configurator.NorthboundConfigurator/WatchNetworks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &northboundConfiguratorWatchNetworksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NorthboundConfigurator_WatchNetworksClient interface {
+	Recv() (*storage.Change, error)
+	grpc.ClientStream
+}
+
+type northboundConfiguratorWatchNetworksClient struct {
+	grpc.ClientStream
+}
+
+func (x *northboundConfiguratorWatchNetworksClient) Recv() (*storage.Change, error) {
+	m := new(storage.Change)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *northboundConfiguratorClient) WatchEntities(ctx context.Context, in *WatchEntitiesRequest, opts ...grpc.CallOption) (NorthboundConfigurator_WatchEntitiesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NorthboundConfigurator_serviceDesc.Streams[1], "/magma.orc8r.configurator.NorthboundConfigurator/WatchEntities", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &northboundConfiguratorWatchEntitiesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NorthboundConfigurator_WatchEntitiesClient interface {
+	Recv() (*storage.Change, error)
+	grpc.ClientStream
+}
+
+type northboundConfiguratorWatchEntitiesClient struct {
+	grpc.ClientStream
+}
+
+func (x *northboundConfiguratorWatchEntitiesClient) Recv() (*storage.Change, error) {
+	m := new(storage.Change)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *northboundConfiguratorClient) LoadChanges(ctx context.Context, in *LoadChangesRequest, opts ...grpc.CallOption) (*LoadChangesResponse, error) {
+	out := new(LoadChangesResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/LoadChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) ListConfigHistory(ctx context.Context, in *ListConfigHistoryRequest, opts ...grpc.CallOption) (*ListConfigHistoryResponse, error) {
+	out := new(ListConfigHistoryResponse)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/ListConfigHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *northboundConfiguratorClient) RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*storage.ConfigRevision, error) {
+	out := new(storage.ConfigRevision)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.configurator.NorthboundConfigurator/RollbackConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NorthboundConfiguratorServer is the server API for NorthboundConfigurator service.
 type NorthboundConfiguratorServer interface {
 	// ListNetworkIDs fetches the list of networkIDs registered
@@ -949,6 +1433,26 @@ type NorthboundConfiguratorServer interface {
 	DeleteEntities(context.Context, *DeleteEntitiesRequest) (*protos.Void, error)
 	// LoadEntities fetches the set of Entities specified by the request
 	LoadEntities(context.Context, *LoadEntitiesRequest) (*storage.EntityLoadResult, error)
+	// WatchNetworks streams every network change committed after the
+	// requested cursor. The stream stays open until the client cancels it,
+	// or fails with OUT_OF_RANGE once changes after the cursor are pruned.
+	WatchNetworks(*WatchNetworksRequest, NorthboundConfigurator_WatchNetworksServer) error
+	// WatchEntities streams every entity change within a network committed
+	// after the requested cursor. The stream stays open until the client
+	// cancels it, or fails with OUT_OF_RANGE once changes after the cursor
+	// are pruned.
+	WatchEntities(*WatchEntitiesRequest, NorthboundConfigurator_WatchEntitiesServer) error
+	// LoadChanges returns the changes matching the filter which were
+	// committed up to the latest sequence of the filter's change stream at
+	// the time of the request, along with that latest sequence.
+	LoadChanges(context.Context, *LoadChangesRequest) (*LoadChangesResponse, error)
+	// ListConfigHistory returns the revisions of a network config or entity
+	// config, newest first.
+	ListConfigHistory(context.Context, *ListConfigHistoryRequest) (*ListConfigHistoryResponse, error)
+	// RollbackConfig atomically restores a network config or entity config
+	// to the value it had at a previous revision. The restore is itself
+	// recorded as a new revision, which is returned.
+	RollbackConfig(context.Context, *RollbackConfigRequest) (*storage.ConfigRevision, error)
 }
 
 // UnimplementedNorthboundConfiguratorServer can be embedded to have forward compatible implementations.
@@ -985,6 +1489,21 @@ func (*UnimplementedNorthboundConfiguratorServer) DeleteEntities(ctx context.Con
 func (*UnimplementedNorthboundConfiguratorServer) LoadEntities(ctx context.Context, req *LoadEntitiesRequest) (*storage.EntityLoadResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoadEntities not implemented")
 }
+func (*UnimplementedNorthboundConfiguratorServer) WatchNetworks(req *WatchNetworksRequest, srv NorthboundConfigurator_WatchNetworksServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNetworks not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) WatchEntities(req *WatchEntitiesRequest, srv NorthboundConfigurator_WatchEntitiesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEntities not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) LoadChanges(ctx context.Context, req *LoadChangesRequest) (*LoadChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadChanges not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) ListConfigHistory(ctx context.Context, req *ListConfigHistoryRequest) (*ListConfigHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfigHistory not implemented")
+}
+func (*UnimplementedNorthboundConfiguratorServer) RollbackConfig(ctx context.Context, req *RollbackConfigRequest) (*storage.ConfigRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackConfig not implemented")
+}
 
 func RegisterNorthboundConfiguratorServer(s *grpc.Server, srv NorthboundConfiguratorServer) {
 	s.RegisterService(&_NorthboundConfigurator_serviceDesc, srv)
@@ -1170,6 +1689,102 @@ func _NorthboundConfigurator_LoadEntities_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NorthboundConfigurator_WatchNetworks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNetworksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NorthboundConfiguratorServer).WatchNetworks(m, &northboundConfiguratorWatchNetworksServer{stream})
+}
+
+type NorthboundConfigurator_WatchNetworksServer interface {
+	Send(*storage.Change) error
+	grpc.ServerStream
+}
+
+type northboundConfiguratorWatchNetworksServer struct {
+	grpc.ServerStream
+}
+
+func (x *northboundConfiguratorWatchNetworksServer) Send(m *storage.Change) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NorthboundConfigurator_WatchEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEntitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NorthboundConfiguratorServer).WatchEntities(m, &northboundConfiguratorWatchEntitiesServer{stream})
+}
+
+type NorthboundConfigurator_WatchEntitiesServer interface {
+	Send(*storage.Change) error
+	grpc.ServerStream
+}
+
+type northboundConfiguratorWatchEntitiesServer struct {
+	grpc.ServerStream
+}
+
+func (x *northboundConfiguratorWatchEntitiesServer) Send(m *storage.Change) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NorthboundConfigurator_LoadChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).LoadChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/LoadChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).LoadChanges(ctx, req.(*LoadChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_ListConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).ListConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/ListConfigHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).ListConfigHistory(ctx, req.(*ListConfigHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NorthboundConfigurator_RollbackConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NorthboundConfiguratorServer).RollbackConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.configurator.NorthboundConfigurator/RollbackConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NorthboundConfiguratorServer).RollbackConfig(ctx, req.(*RollbackConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NorthboundConfigurator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.configurator.NorthboundConfigurator",
 	HandlerType: (*NorthboundConfiguratorServer)(nil),
@@ -1214,7 +1829,30 @@ var _NorthboundConfigurator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LoadEntities",
 			Handler:    _NorthboundConfigurator_LoadEntities_Handler,
 		},
+		{
+			MethodName: "LoadChanges",
+			Handler:    _NorthboundConfigurator_LoadChanges_Handler,
+		},
+		{
+			MethodName: "ListConfigHistory",
+			Handler:    _NorthboundConfigurator_ListConfigHistory_Handler,
+		},
+		{
+			MethodName: "RollbackConfig",
+			Handler:    _NorthboundConfigurator_RollbackConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNetworks",
+			Handler:       _NorthboundConfigurator_WatchNetworks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchEntities",
+			Handler:       _NorthboundConfigurator_WatchEntities_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "northbound.proto",
 }
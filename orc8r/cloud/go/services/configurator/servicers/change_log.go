@@ -0,0 +1,46 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"context"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+
+	"github.com/golang/glog"
+)
+
+// ChangeRetention is how long changes are kept in the change log. Watchers
+// whose cursor falls further behind have to resync from a full load.
+var ChangeRetention = time.Hour * 24 * 7
+
+// PruneChangeLog deletes changes older than ChangeRetention from the change
+// log every interval. This function blocks.
+func PruneChangeLog(factory storage.ConfiguratorStorageFactory, interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := pruneChangeLog(factory); err != nil {
+			glog.Errorf("Error pruning configurator change log: %s", err)
+		}
+	}
+}
+
+func pruneChangeLog(factory storage.ConfiguratorStorageFactory) error {
+	store, err := factory.StartTransaction(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	err = store.PruneChanges(clock.Now().Add(-ChangeRetention))
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return err
+	}
+	return store.Commit()
+}
@@ -11,6 +11,7 @@ package servicers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	commonProtos "magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/serde"
@@ -19,10 +20,20 @@ import (
 	"magma/orc8r/cloud/go/services/configurator/storage"
 	orc8rStorage "magma/orc8r/cloud/go/storage"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// changePollInterval is how often change-feed streams poll the change log
+	// once they have caught up.
+	changePollInterval = time.Second
+	// changePageSize is the max number of changes loaded from storage at once
+	changePageSize = 500
+)
+
 type nbConfiguratorServicer struct {
 	factory storage.ConfiguratorStorageFactory
 }
@@ -71,7 +82,7 @@ func (srv *nbConfiguratorServicer) ListNetworkIDs(context context.Context, void
 
 func (srv *nbConfiguratorServicer) CreateNetworks(context context.Context, req *protos.CreateNetworksRequest) (*protos.CreateNetworksResponse, error) {
 	emptyRes := &protos.CreateNetworksResponse{}
-	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
 	if err != nil {
 		return emptyRes, err
 	}
@@ -94,7 +105,7 @@ func (srv *nbConfiguratorServicer) CreateNetworks(context context.Context, req *
 
 func (srv *nbConfiguratorServicer) UpdateNetworks(context context.Context, req *protos.UpdateNetworksRequest) (*commonProtos.Void, error) {
 	void := &commonProtos.Void{}
-	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
 	if err != nil {
 		return void, err
 	}
@@ -110,6 +121,9 @@ func (srv *nbConfiguratorServicer) UpdateNetworks(context context.Context, req *
 	err = store.UpdateNetworks(updates)
 	if err != nil {
 		storage.RollbackLogOnError(store)
+		if err == storage.ErrVersionMismatch {
+			return void, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return void, err
 	}
 	return void, store.Commit()
@@ -117,7 +131,7 @@ func (srv *nbConfiguratorServicer) UpdateNetworks(context context.Context, req *
 
 func (srv *nbConfiguratorServicer) DeleteNetworks(context context.Context, req *protos.DeleteNetworksRequest) (*commonProtos.Void, error) {
 	void := &commonProtos.Void{}
-	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
 	if err != nil {
 		return void, err
 	}
@@ -144,6 +158,9 @@ func (srv *nbConfiguratorServicer) LoadEntities(context context.Context, req *pr
 	loadResult, err := store.LoadEntities(req.NetworkID, *req.Filter, *req.Criteria)
 	if err != nil {
 		storage.RollbackLogOnError(store)
+		if err == storage.ErrInvalidPageToken || err == storage.ErrPaginationNotSupported {
+			return emptyRes, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return emptyRes, err
 	}
 	return &loadResult, store.Commit()
@@ -151,7 +168,7 @@ func (srv *nbConfiguratorServicer) LoadEntities(context context.Context, req *pr
 
 func (srv *nbConfiguratorServicer) WriteEntities(context context.Context, req *protos.WriteEntitiesRequest) (*protos.WriteEntitiesResponse, error) {
 	emptyRes := &protos.WriteEntitiesResponse{}
-	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
 	if err != nil {
 		return emptyRes, err
 	}
@@ -172,6 +189,9 @@ func (srv *nbConfiguratorServicer) WriteEntities(context context.Context, req *p
 			updatedEnt, err := updateEntity(store, req.NetworkID, op.Update)
 			if err != nil {
 				storage.RollbackLogOnError(store)
+				if err == storage.ErrVersionMismatch {
+					return emptyRes, status.Error(codes.FailedPrecondition, err.Error())
+				}
 				return emptyRes, status.Error(codes.Internal, err.Error())
 			}
 			ret.UpdatedEntities[updatedEnt.Key] = updatedEnt
@@ -185,7 +205,7 @@ func (srv *nbConfiguratorServicer) WriteEntities(context context.Context, req *p
 
 func (srv *nbConfiguratorServicer) CreateEntities(context context.Context, req *protos.CreateEntitiesRequest) (*protos.CreateEntitiesResponse, error) {
 	emptyRes := &protos.CreateEntitiesResponse{}
-	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
 	if err != nil {
 		return emptyRes, err
 	}
@@ -204,7 +224,7 @@ func (srv *nbConfiguratorServicer) CreateEntities(context context.Context, req *
 
 func (srv *nbConfiguratorServicer) UpdateEntities(context context.Context, req *protos.UpdateEntitiesRequest) (*protos.UpdateEntitiesResponse, error) {
 	emptyRes := &protos.UpdateEntitiesResponse{}
-	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
 	if err != nil {
 		return emptyRes, err
 	}
@@ -214,6 +234,9 @@ func (srv *nbConfiguratorServicer) UpdateEntities(context context.Context, req *
 		updatedEntity, err := updateEntity(store, req.NetworkID, update)
 		if err != nil {
 			storage.RollbackLogOnError(store)
+			if err == storage.ErrVersionMismatch {
+				return emptyRes, status.Error(codes.FailedPrecondition, err.Error())
+			}
 			return emptyRes, err
 		}
 		updatedEntities[update.Key] = updatedEntity
@@ -223,7 +246,7 @@ func (srv *nbConfiguratorServicer) UpdateEntities(context context.Context, req *
 
 func (srv *nbConfiguratorServicer) DeleteEntities(context context.Context, req *protos.DeleteEntitiesRequest) (*commonProtos.Void, error) {
 	void := &commonProtos.Void{}
-	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: false})
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
 	if err != nil {
 		return void, err
 	}
@@ -243,6 +266,244 @@ func (srv *nbConfiguratorServicer) DeleteEntities(context context.Context, req *
 	return void, store.Commit()
 }
 
+func (srv *nbConfiguratorServicer) WatchNetworks(req *protos.WatchNetworksRequest, stream protos.NorthboundConfigurator_WatchNetworksServer) error {
+	filter := storage.ChangeLoadFilter{
+		AfterSequence: req.AfterSequence,
+		Kinds:         []storage.Change_Kind{storage.Change_NETWORK},
+	}
+	return srv.streamChanges(stream.Context(), filter, stream.Send)
+}
+
+func (srv *nbConfiguratorServicer) WatchEntities(req *protos.WatchEntitiesRequest, stream protos.NorthboundConfigurator_WatchEntitiesServer) error {
+	if req.NetworkID == "" {
+		return status.Error(codes.InvalidArgument, "network ID must be provided")
+	}
+	filter := storage.ChangeLoadFilter{
+		AfterSequence: req.AfterSequence,
+		NetworkID:     &wrappers.StringValue{Value: req.NetworkID},
+		Kinds:         []storage.Change_Kind{storage.Change_ENTITY},
+	}
+	if req.Type != "" {
+		filter.TypeFilter = &wrappers.StringValue{Value: req.Type}
+	}
+	return srv.streamChanges(stream.Context(), filter, stream.Send)
+}
+
+// streamChanges tails the change log from the filter's cursor, sending each
+// matching change in order until the stream's context is done.
+func (srv *nbConfiguratorServicer) streamChanges(ctx context.Context, filter storage.ChangeLoadFilter, send func(*storage.Change) error) error {
+	filter.Limit = changePageSize
+	ticker := time.NewTicker(changePollInterval)
+	defer ticker.Stop()
+
+	for {
+		changes, err := srv.loadChanges(ctx, filter)
+		if err == storage.ErrCursorExpired {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		for _, change := range changes {
+			if err := send(change); err != nil {
+				return err
+			}
+			filter.AfterSequence = change.Sequence
+		}
+		// Keep draining without waiting if there may be more changes
+		if len(changes) == changePageSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *nbConfiguratorServicer) loadChanges(ctx context.Context, filter storage.ChangeLoadFilter) ([]*storage.Change, error) {
+	store, err := srv.factory.StartTransaction(ctx, &orc8rStorage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := store.LoadChanges(filter)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return nil, err
+	}
+	return changes, store.Commit()
+}
+
+func (srv *nbConfiguratorServicer) LoadChanges(context context.Context, req *protos.LoadChangesRequest) (*protos.LoadChangesResponse, error) {
+	res := &protos.LoadChangesResponse{}
+	if req.Filter == nil {
+		return res, status.Error(codes.InvalidArgument, "change filter must be provided")
+	}
+	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return res, err
+	}
+
+	res.LatestSequence, err = store.GetLatestChangeSequence(*req.Filter)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return res, changeLoadError(err)
+	}
+	if req.LatestSequenceOnly {
+		return res, store.Commit()
+	}
+
+	changes, err := store.LoadChanges(*req.Filter)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return res, changeLoadError(err)
+	}
+	// Changes committed after we read the latest sequence are left for the
+	// next request so callers can use the latest sequence as a cursor
+	for _, change := range changes {
+		if change.Sequence <= res.LatestSequence {
+			res.Changes = append(res.Changes, change)
+		}
+	}
+	return res, store.Commit()
+}
+
+// changeLoadError returns the status error of a failed change log load
+func changeLoadError(err error) error {
+	switch err {
+	case storage.ErrInvalidChangeFilter:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case storage.ErrCursorExpired:
+		return status.Error(codes.OutOfRange, err.Error())
+	default:
+		return err
+	}
+}
+
+func (srv *nbConfiguratorServicer) ListConfigHistory(context context.Context, req *protos.ListConfigHistoryRequest) (*protos.ListConfigHistoryResponse, error) {
+	res := &protos.ListConfigHistoryResponse{}
+	if req.Filter == nil {
+		return res, status.Error(codes.InvalidArgument, "filter must be provided")
+	}
+	store, err := srv.factory.StartTransaction(context, &orc8rStorage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return res, err
+	}
+
+	res.Revisions, err = store.LoadConfigHistory(*req.Filter)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return res, err
+	}
+	return res, store.Commit()
+}
+
+func (srv *nbConfiguratorServicer) RollbackConfig(context context.Context, req *protos.RollbackConfigRequest) (*storage.ConfigRevision, error) {
+	store, err := srv.factory.StartTransaction(withAuthor(context), &orc8rStorage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := rollbackConfig(store, req)
+	if err != nil {
+		storage.RollbackLogOnError(store)
+		return nil, err
+	}
+	return ret, store.Commit()
+}
+
+// rollbackConfig restores the requested revision of a config by writing its
+// value, and the labels of entities, as the latest revision. The new latest
+// revision is returned.
+func rollbackConfig(store storage.ConfiguratorStorage, req *protos.RollbackConfigRequest) (*storage.ConfigRevision, error) {
+	filter := storage.ConfigHistoryFilter{NetworkID: req.NetworkID, Kind: req.Kind, Type: req.Type, Key: req.Key}
+	revisionFilter := filter
+	revisionFilter.Revision = &wrappers.UInt64Value{Value: req.Revision}
+	revisions, err := store.LoadConfigHistory(revisionFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "revision %d of config (%s, %s) not found", req.Revision, req.Type, req.Key)
+	}
+	target := revisions[0]
+
+	switch req.Kind {
+	case storage.ConfigRevision_NETWORK_CONFIG:
+		loaded, err := store.LoadNetworks(storage.NetworkLoadFilter{Ids: []string{req.NetworkID}}, storage.NetworkLoadCriteria{})
+		if err != nil {
+			return nil, err
+		}
+		if len(loaded.Networks) == 0 {
+			return nil, status.Errorf(codes.NotFound, "network %s not found", req.NetworkID)
+		}
+
+		update := storage.NetworkUpdateCriteria{ID: req.NetworkID}
+		if target.Deleted {
+			update.ConfigsToDelete = []string{req.Type}
+		} else {
+			update.ConfigsToAddOrUpdate = map[string][]byte{req.Type: target.Value}
+			if err := networkConfigsAreValid(update.ConfigsToAddOrUpdate); err != nil {
+				return nil, err
+			}
+		}
+		err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{update})
+		if err != nil {
+			return nil, err
+		}
+	case storage.ConfigRevision_ENTITY_CONFIG:
+		entID := &storage.EntityID{Type: req.Type, Key: req.Key}
+		loaded, err := store.LoadEntities(req.NetworkID, storage.EntityLoadFilter{IDs: []*storage.EntityID{entID}}, storage.EntityLoadCriteria{})
+		if err != nil {
+			return nil, err
+		}
+		if len(loaded.Entities) == 0 {
+			return nil, status.Errorf(codes.NotFound, "entity (%s, %s) not found", req.Type, req.Key)
+		}
+
+		update := &storage.EntityUpdateCriteria{
+			Type:        req.Type,
+			Key:         req.Key,
+			NewConfig:   &wrappers.BytesValue{Value: target.Value},
+			LabelsToSet: &storage.EntityLabelsToSet{Labels: target.Labels},
+		}
+		_, err = updateEntity(store, req.NetworkID, update)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unrecognized config kind %s", req.Kind)
+	}
+
+	revisions, err = store.LoadConfigHistory(filter)
+	if err != nil {
+		return nil, err
+	}
+	return revisions[0], nil
+}
+
+// withAuthor attributes config writes made in transactions started from the
+// returned context to the author in the incoming request metadata, if any.
+// The author isn't authenticated, so it is recorded as advisory only.
+func withAuthor(ctx context.Context) context.Context {
+	// Gateways can't author config writes
+	if commonProtos.GetClientIdentity(ctx) != nil {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	authors := md.Get(configurator.AuthorMetadataKey)
+	if len(authors) != 1 {
+		return ctx
+	}
+	return storage.WithAuthor(ctx, authors[0])
+}
+
 func networkConfigsAreValid(configs map[string][]byte) error {
 	for typeVal, config := range configs {
 		_, err := serde.Deserialize(configurator.NetworkConfigSerdeDomain, typeVal, config)
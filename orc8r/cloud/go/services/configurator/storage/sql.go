@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
 	"magma/orc8r/cloud/go/sqorc"
 	"magma/orc8r/cloud/go/storage"
@@ -31,6 +32,11 @@ const (
 	entityTable      = "cfg_entities"
 	entityAssocTable = "cfg_assocs"
 	entityAclTable   = "cfg_acls"
+	entityLabelTable = "cfg_entity_labels"
+
+	changeTable        = "cfg_changes"
+	changeSeqTable     = "cfg_change_seq"
+	configHistoryTable = "cfg_config_history"
 )
 
 const (
@@ -65,6 +71,34 @@ const (
 	aclTypeCol     = "type"
 	aclIdFilterCol = "id_filter"
 	aclVerCol      = "version"
+
+	labelEntCol = "entity_pk"
+	labelKeyCol = "\"key\""
+	labelValCol = "value"
+
+	chStreamCol  = "stream"
+	chSeqCol     = "seq"
+	chNidCol     = "network_id"
+	chKindCol    = "kind"
+	chOpCol      = "operation"
+	chTypeCol    = "type"
+	chKeyCol     = "\"key\""
+	chCreatedCol = "created_at"
+
+	chSeqStreamCol = "stream"
+	chSeqValCol    = "seq"
+	chSeqPrunedCol = "pruned"
+
+	histNidCol     = "network_id"
+	histKindCol    = "kind"
+	histTypeCol    = "type"
+	histKeyCol     = "\"key\""
+	histRevCol     = "revision"
+	histValCol     = "value"
+	histDelCol     = "deleted"
+	histLabelsCol  = "labels"
+	histAuthorCol  = "author"
+	histCreatedCol = "created_at"
 )
 
 type IDGenerator interface {
@@ -215,6 +249,20 @@ func (fact *sqlConfiguratorStorageFactory) InitializeServiceStorage() (err error
 		return
 	}
 
+	_, err = fact.builder.CreateTable(entityLabelTable).
+		IfNotExists().
+		Column(labelEntCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(labelKeyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(labelValCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		PrimaryKey(labelEntCol, labelKeyCol).
+		ForeignKey(entityTable, map[string]string{labelEntCol: entPkCol}, sqorc.ColumnOnDeleteCascade).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create entity label table")
+		return
+	}
+
 	// Create indexes (index is not implicitly created on a referencing FK)
 	_, err = fact.builder.CreateIndex("graph_id_idx").
 		IfNotExists().
@@ -238,6 +286,87 @@ func (fact *sqlConfiguratorStorageFactory) InitializeServiceStorage() (err error
 		return
 	}
 
+	// Label selectors look up entities by (key, value)
+	_, err = fact.builder.CreateIndex("label_kv_idx").
+		IfNotExists().
+		On(entityLabelTable).
+		Columns(labelKeyCol, labelValCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create label key-value index")
+		return
+	}
+
+	// The change log intentionally has no foreign keys so that deletions of
+	// networks and entities remain observable. Changes are only deleted once
+	// they're older than the retention period, see PruneChanges.
+	_, err = fact.builder.CreateTable(changeTable).
+		IfNotExists().
+		Column(chStreamCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(chSeqCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(chNidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(chKindCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(chOpCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(chTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(chKeyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(chCreatedCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		PrimaryKey(chStreamCol, chSeqCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create change log table")
+		return
+	}
+
+	_, err = fact.builder.CreateIndex("change_created_idx").
+		IfNotExists().
+		On(changeTable).
+		Columns(chCreatedCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create change log creation time index")
+		return
+	}
+
+	// Change log sequence numbers are claimed from a counter row per change
+	// stream, see recordChanges. Each row also records up to which sequence
+	// number the stream's changes have been pruned.
+	_, err = fact.builder.CreateTable(changeSeqTable).
+		IfNotExists().
+		Column(chSeqStreamCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(chSeqValCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(chSeqPrunedCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create change log sequence table")
+		return
+	}
+
+	// Like the change log, config history has no foreign keys so revisions
+	// outlive the networks and entities they belong to.
+	_, err = fact.builder.CreateTable(configHistoryTable).
+		IfNotExists().
+		Column(histNidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(histKindCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(histTypeCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(histKeyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(histRevCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(histValCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+		Column(histDelCol).Type(sqorc.ColumnTypeBool).NotNull().EndColumn().
+		Column(histLabelsCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+		Column(histAuthorCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(histCreatedCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		PrimaryKey(histNidCol, histKindCol, histTypeCol, histKeyCol, histRevCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create config history table")
+		return
+	}
+
 	// Create internal network(s)
 	_, err = fact.builder.Insert(networksTable).
 		Columns(nwIDCol, nwTypeCol, nwNameCol, nwDescCol).
@@ -258,7 +387,7 @@ func (fact *sqlConfiguratorStorageFactory) StartTransaction(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	return &sqlConfiguratorStorage{tx: tx, idGenerator: fact.idGenerator, builder: fact.builder}, nil
+	return &sqlConfiguratorStorage{tx: tx, idGenerator: fact.idGenerator, builder: fact.builder, author: getAuthor(ctx)}, nil
 }
 
 func getSqlOpts(opts *storage.TxOptions) *sql.TxOptions {
@@ -275,6 +404,7 @@ type sqlConfiguratorStorage struct {
 	tx          *sql.Tx
 	idGenerator IDGenerator
 	builder     sqorc.StatementBuilder
+	author      string
 }
 
 func (store *sqlConfiguratorStorage) Commit() error {
@@ -374,38 +504,44 @@ func (store *sqlConfiguratorStorage) CreateNetwork(network Network) (Network, er
 		return network, fmt.Errorf("error inserting network: %s", err)
 	}
 
-	if funk.IsEmpty(network.Configs) {
-		return network, nil
-	}
-
-	// Sort config keys for deterministic behavior
-	configKeys := funk.Keys(network.Configs).([]string)
-	sort.Strings(configKeys)
-	insertBuilder := store.builder.Insert(networkConfigTable).
-		Columns(nwcIDCol, nwcTypeCol, nwcValCol)
-	for _, configKey := range configKeys {
-		insertBuilder = insertBuilder.Values(network.ID, configKey, network.Configs[configKey])
-	}
-	_, err = insertBuilder.RunWith(store.tx).Exec()
-	if err != nil {
-		return network, errors.Wrap(err, "error inserting network configs")
+	if funk.NotEmpty(network.Configs) {
+		// Sort config keys for deterministic behavior
+		configKeys := funk.Keys(network.Configs).([]string)
+		sort.Strings(configKeys)
+		insertBuilder := store.builder.Insert(networkConfigTable).
+			Columns(nwcIDCol, nwcTypeCol, nwcValCol)
+		revisions := make([]*ConfigRevision, 0, len(configKeys))
+		for _, configKey := range configKeys {
+			insertBuilder = insertBuilder.Values(network.ID, configKey, network.Configs[configKey])
+			revisions = append(revisions, newNetworkConfigRevision(network.ID, configKey, network.Configs[configKey]))
+		}
+		_, err = insertBuilder.RunWith(store.tx).Exec()
+		if err != nil {
+			return network, errors.Wrap(err, "error inserting network configs")
+		}
+		err = store.recordConfigRevisions(revisions...)
+		if err != nil {
+			return network, err
+		}
 	}
 
-	return network, nil
+	return network, store.recordChanges(newNetworkChange(network.ID, Change_CREATE))
 }
 
 func (store *sqlConfiguratorStorage) UpdateNetworks(updates []NetworkUpdateCriteria) error {
 	if err := validateNetworkUpdates(updates); err != nil {
 		return err
 	}
-
 	networksToDelete := []string{}
 	networksToUpdate := []NetworkUpdateCriteria{}
+	changes := make([]*Change, 0, len(updates))
 	for _, update := range updates {
 		if update.DeleteNetwork {
 			networksToDelete = append(networksToDelete, update.ID)
+			changes = append(changes, newNetworkChange(update.ID, Change_DELETE))
 		} else {
 			networksToUpdate = append(networksToUpdate, update)
+			changes = append(changes, newNetworkChange(update.ID, Change_UPDATE))
 		}
 	}
 
@@ -415,12 +551,33 @@ func (store *sqlConfiguratorStorage) UpdateNetworks(updates []NetworkUpdateCrite
 	// Update networks first
 	for _, update := range networksToUpdate {
 		err := store.updateNetwork(update, stmtCache)
+		if err == ErrVersionMismatch {
+			return err
+		}
 		if err != nil {
 			return errors.WithStack(err)
 		}
+		err = store.recordConfigRevisions(getNetworkConfigRevisions(update)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Deleting a network cascades to its entities, which are logged as
+	// deleted before the network itself
+	if len(networksToDelete) > 0 {
+		entityChanges, err := store.loadEntityDeleteChanges(networksToDelete)
+		if err != nil {
+			return err
+		}
+		changes = append(entityChanges, changes...)
 	}
 
-	_, err := store.builder.Delete(networkConfigTable).Where(sq.Eq{nwcIDCol: networksToDelete}).
+	err := store.deleteVersionedNetworks(updates)
+	if err != nil {
+		return err
+	}
+	_, err = store.builder.Delete(networkConfigTable).Where(sq.Eq{nwcIDCol: networksToDelete}).
 		RunWith(store.tx).
 		Exec()
 	if err != nil {
@@ -432,7 +589,7 @@ func (store *sqlConfiguratorStorage) UpdateNetworks(updates []NetworkUpdateCrite
 	if err != nil {
 		return errors.Wrap(err, "failed to delete networks")
 	}
-	return nil
+	return store.recordChanges(changes...)
 }
 
 func (store *sqlConfiguratorStorage) LoadEntities(networkID string, filter EntityLoadFilter, loadCriteria EntityLoadCriteria) (EntityLoadResult, error) {
@@ -449,10 +606,11 @@ func (store *sqlConfiguratorStorage) LoadEntities(networkID string, filter Entit
 	// be smart here and only load (type, key) for PKs which we don't know.
 	// Finally, we will update the entity objects to return with their edges.
 
-	entsByPk, err := store.loadFromEntitiesTable(networkID, filter, loadCriteria)
+	entsByPk, nextPageToken, err := store.loadPageFromEntitiesTable(networkID, filter, loadCriteria)
 	if err != nil {
 		return ret, err
 	}
+	ret.NextPageToken = nextPageToken
 	assocs, allAssocPks, err := store.loadFromAssocsTable(filter, loadCriteria, entsByPk)
 	if err != nil {
 		return ret, err
@@ -482,6 +640,10 @@ func (store *sqlConfiguratorStorage) LoadEntities(networkID string, filter Entit
 }
 
 func (store *sqlConfiguratorStorage) CreateEntity(networkID string, entity NetworkEntity) (NetworkEntity, error) {
+	err := validateLabels(entity.Labels)
+	if err != nil {
+		return NetworkEntity{}, err
+	}
 	exists, err := store.doesEntExist(networkID, entity.GetTypeAndKey())
 	if err != nil {
 		return NetworkEntity{}, err
@@ -511,6 +673,11 @@ func (store *sqlConfiguratorStorage) CreateEntity(networkID string, entity Netwo
 		return NetworkEntity{}, err
 	}
 
+	err = store.createLabels(createdEntWithPk.pk, createdEntWithPk.Labels)
+	if err != nil {
+		return NetworkEntity{}, err
+	}
+
 	allAssociatedEntsByTk, err := store.createEdges(networkID, createdEntWithPk)
 	if err != nil {
 		return NetworkEntity{}, err
@@ -522,6 +689,17 @@ func (store *sqlConfiguratorStorage) CreateEntity(networkID string, entity Netwo
 	}
 	createdEntWithPk.GraphID = newGraphID
 
+	if entity.Config != nil || len(entity.Labels) > 0 {
+		err = store.recordConfigRevisions(newEntityConfigRevision(networkID, entity.Type, entity.Key, entity.Config, entity.Labels))
+		if err != nil {
+			return NetworkEntity{}, err
+		}
+	}
+	err = store.recordChanges(newEntityChange(networkID, entity.Type, entity.Key, Change_CREATE))
+	if err != nil {
+		return NetworkEntity{}, err
+	}
+
 	// If we were given duplicate edges, get rid of those
 	if !funk.IsEmpty(createdEntWithPk.Associations) {
 		createdEntWithPk.Associations = funk.Chain(createdEntWithPk.Associations).
@@ -537,6 +715,12 @@ func (store *sqlConfiguratorStorage) CreateEntity(networkID string, entity Netwo
 
 func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update EntityUpdateCriteria) (NetworkEntity, error) {
 	emptyRet := NetworkEntity{Type: update.Type, Key: update.Key}
+	if update.LabelsToSet != nil {
+		err := validateLabels(update.LabelsToSet.Labels)
+		if err != nil {
+			return emptyRet, err
+		}
+	}
 	entToUpdate, err := store.loadEntToUpdate(networkID, update)
 	if err != nil && !update.DeleteEntity {
 		return emptyRet, errors.Wrap(err, "failed to load entity being updated")
@@ -544,20 +728,34 @@ func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update Entit
 	if entToUpdate == nil {
 		return emptyRet, nil
 	}
+	// The writes below only apply to the expected version as well, since the
+	// entity could have been updated concurrently after it was loaded
+	if update.ExpectedVersion != nil && update.ExpectedVersion.Value != entToUpdate.Version {
+		return emptyRet, ErrVersionMismatch
+	}
 
 	if update.DeleteEntity {
 		// Cascading FK relations in the schema will handle the other tables
-		_, err := store.builder.Delete(entityTable).
-			Where(sq.And{
-				sq.Eq{entNidCol: networkID},
-				sq.Eq{entTypeCol: update.Type},
-				sq.Eq{entKeyCol: update.Key},
-			}).
+		where := sq.And{
+			sq.Eq{entNidCol: networkID},
+			sq.Eq{entTypeCol: update.Type},
+			sq.Eq{entKeyCol: update.Key},
+		}
+		if update.ExpectedVersion != nil {
+			where = append(where, sq.Eq{entVerCol: update.ExpectedVersion.Value})
+		}
+		res, err := store.builder.Delete(entityTable).
+			Where(where).
 			RunWith(store.tx).
 			Exec()
 		if err != nil {
 			return emptyRet, errors.Wrapf(err, "failed to delete entity (%s, %s)", update.Type, update.Key)
 		}
+		if update.ExpectedVersion != nil {
+			if err := checkVersionedWrite(res); err != nil {
+				return emptyRet, err
+			}
+		}
 
 		// Deleting a node could partition its graph
 		err = store.fixGraph(networkID, entToUpdate.GraphID, entToUpdate)
@@ -565,12 +763,15 @@ func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update Entit
 			return emptyRet, errors.Wrap(err, "failed to fix entity graph after deletion")
 		}
 
-		return emptyRet, nil
+		return emptyRet, store.recordChanges(newEntityChange(networkID, update.Type, update.Key, Change_DELETE))
 	}
 
 	// Then, update the fields on the entity table
 	entToUpdate.NetworkID = networkID
 	err = store.processEntityFieldsUpdate(entToUpdate.pk, update, &entToUpdate.NetworkEntity)
+	if err == ErrVersionMismatch {
+		return entToUpdate.NetworkEntity, err
+	}
 	if err != nil {
 		return entToUpdate.NetworkEntity, errors.WithStack(err)
 	}
@@ -581,15 +782,100 @@ func (store *sqlConfiguratorStorage) UpdateEntity(networkID string, update Entit
 		return entToUpdate.NetworkEntity, errors.WithStack(err)
 	}
 
+	// Next, replace labels
+	err = store.processLabelUpdates(entToUpdate.pk, update, &entToUpdate.NetworkEntity)
+	if err != nil {
+		return entToUpdate.NetworkEntity, errors.WithStack(err)
+	}
+
 	// Finally, process edge updates for the graph
 	err = store.processEdgeUpdates(networkID, update, entToUpdate)
 	if err != nil {
 		return entToUpdate.NetworkEntity, errors.WithStack(err)
 	}
 
+	// Entity config revisions also track the entity's labels
+	if update.NewConfig != nil || update.LabelsToSet != nil {
+		err = store.recordConfigRevisions(newEntityConfigRevision(networkID, update.Type, update.Key, entToUpdate.Config, entToUpdate.Labels))
+		if err != nil {
+			return entToUpdate.NetworkEntity, err
+		}
+	}
+	err = store.recordChanges(newEntityChange(networkID, update.Type, update.Key, Change_UPDATE))
+	if err != nil {
+		return entToUpdate.NetworkEntity, err
+	}
 	return entToUpdate.NetworkEntity, nil
 }
 
+func (store *sqlConfiguratorStorage) LoadChanges(filter ChangeLoadFilter) ([]*Change, error) {
+	stream, err := getChangeFilterStream(filter)
+	if err != nil {
+		return nil, err
+	}
+	_, prunedSeq, err := store.getChangeStreamSequences(stream)
+	if err != nil {
+		return nil, err
+	}
+	if filter.AfterSequence < prunedSeq {
+		return nil, ErrCursorExpired
+	}
+
+	rows, err := store.getLoadChangesSelectBuilder(stream, filter).RunWith(store.tx).Query()
+	if err != nil {
+		return nil, fmt.Errorf("error querying for changes: %s", err)
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadChanges")
+
+	return scanChangeRows(rows)
+}
+
+func (store *sqlConfiguratorStorage) GetLatestChangeSequence(filter ChangeLoadFilter) (uint64, error) {
+	stream, err := getChangeFilterStream(filter)
+	if err != nil {
+		return 0, err
+	}
+	latestSeq, _, err := store.getChangeStreamSequences(stream)
+	return latestSeq, err
+}
+
+func (store *sqlConfiguratorStorage) PruneChanges(before time.Time) error {
+	rows, err := store.builder.Select(chStreamCol, fmt.Sprintf("MAX(%s)", chSeqCol)).
+		From(changeTable).
+		Where(sq.Lt{chCreatedCol: before.Unix()}).
+		GroupBy(chStreamCol).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return fmt.Errorf("error querying for changes to prune: %s", err)
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "PruneChanges")
+
+	prunedSeqsByStream := map[string]uint64{}
+	for rows.Next() {
+		var stream string
+		var prunedSeq uint64
+		if err = rows.Scan(&stream, &prunedSeq); err != nil {
+			return fmt.Errorf("error while scanning changes to prune: %s", err)
+		}
+		prunedSeqsByStream[stream] = prunedSeq
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error while scanning changes to prune: %s", err)
+	}
+	return store.pruneChangeStreams(prunedSeqsByStream)
+}
+
+func (store *sqlConfiguratorStorage) LoadConfigHistory(filter ConfigHistoryFilter) ([]*ConfigRevision, error) {
+	rows, err := store.getLoadConfigHistorySelectBuilder(filter).RunWith(store.tx).Query()
+	if err != nil {
+		return nil, fmt.Errorf("error querying for config history: %s", err)
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadConfigHistory")
+
+	return scanConfigRevisionRows(rows)
+}
+
 func (store *sqlConfiguratorStorage) LoadGraphForEntity(networkID string, entityID EntityID, loadCriteria EntityLoadCriteria) (EntityGraph, error) {
 	// Technically you could do this in one DB query with a subquery in the
 	// WHERE when selecting from the entity table.
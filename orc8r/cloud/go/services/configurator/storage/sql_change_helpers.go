@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+func newNetworkChange(networkID string, op Change_Operation) *Change {
+	return &Change{NetworkID: networkID, Kind: Change_NETWORK, Operation: op}
+}
+
+func newEntityChange(networkID string, entType string, entKey string, op Change_Operation) *Change {
+	return &Change{NetworkID: networkID, Kind: Change_ENTITY, Operation: op, Type: entType, Key: entKey}
+}
+
+// networkChangeStream is the change stream of network changes. Entity
+// changes are streamed per network, under the network's ID, which is never
+// empty.
+const networkChangeStream = ""
+
+// getChangeStream returns the stream a change is sequenced in
+func getChangeStream(change *Change) string {
+	if change.Kind == Change_NETWORK {
+		return networkChangeStream
+	}
+	return change.NetworkID
+}
+
+// getChangeFilterStream returns the stream a change filter loads from. A
+// filter has to select either network changes or the entity changes of a
+// single network.
+func getChangeFilterStream(filter ChangeLoadFilter) (string, error) {
+	if len(filter.Kinds) != 1 {
+		return "", ErrInvalidChangeFilter
+	}
+	switch filter.Kinds[0] {
+	case Change_NETWORK:
+		return networkChangeStream, nil
+	case Change_ENTITY:
+		if filter.NetworkID == nil || filter.NetworkID.Value == "" {
+			return "", ErrInvalidChangeFilter
+		}
+		return filter.NetworkID.Value, nil
+	default:
+		return "", ErrInvalidChangeFilter
+	}
+}
+
+// recordChanges appends the given changes to the change log. Each change is
+// assigned the next sequence number of its stream, claimed in stream order.
+// changes is an output parameter - entries will be updated in-place with
+// their assigned sequence numbers.
+func (store *sqlConfiguratorStorage) recordChanges(changes ...*Change) error {
+	if funk.IsEmpty(changes) {
+		return nil
+	}
+
+	changesByStream := map[string][]*Change{}
+	for _, change := range changes {
+		stream := getChangeStream(change)
+		changesByStream[stream] = append(changesByStream[stream], change)
+	}
+	streams := funk.Keys(changesByStream).([]string)
+	sort.Strings(streams)
+	for _, stream := range streams {
+		streamChanges := changesByStream[stream]
+		lastSeq, err := store.claimChangeSequences(stream, len(streamChanges))
+		if err != nil {
+			return err
+		}
+		nextSeq := lastSeq - uint64(len(streamChanges)) + 1
+		for _, change := range streamChanges {
+			change.Sequence = nextSeq
+			nextSeq++
+		}
+	}
+
+	now := clock.Now().Unix()
+	insertBuilder := store.builder.Insert(changeTable).
+		Columns(chStreamCol, chSeqCol, chNidCol, chKindCol, chOpCol, chTypeCol, chKeyCol, chCreatedCol)
+	for _, change := range changes {
+		insertBuilder = insertBuilder.Values(getChangeStream(change), change.Sequence, change.NetworkID, change.Kind, change.Operation, change.Type, change.Key, now)
+	}
+	_, err := insertBuilder.RunWith(store.tx).Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to record changes")
+	}
+	return nil
+}
+
+// claimChangeSequences claims the next n sequence numbers of a change stream
+// from the stream's counter row and returns the last of them. Claiming locks
+// the counter row until the transaction ends, so concurrent writers to the
+// same stream wait for each other rather than fail, while writers to other
+// streams aren't blocked. This also means that a stream's changes become
+// visible in sequence order: a reader which has seen a sequence number will
+// never see a smaller one of the same stream appear later.
+func (store *sqlConfiguratorStorage) claimChangeSequences(stream string, n int) (uint64, error) {
+	_, err := store.builder.Insert(changeSeqTable).
+		Columns(chSeqStreamCol, chSeqValCol, chSeqPrunedCol).
+		Values(stream, n, 0).
+		OnConflict(
+			[]sqorc.UpsertValue{{Column: chSeqValCol, Value: sq.Expr(fmt.Sprintf("%s.%s+%d", changeSeqTable, chSeqValCol, n))}},
+			chSeqStreamCol,
+		).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to claim change sequence numbers")
+	}
+	var lastSeq uint64
+	err = store.builder.Select(chSeqValCol).
+		From(changeSeqTable).
+		Where(sq.Eq{chSeqStreamCol: stream}).
+		RunWith(store.tx).
+		QueryRow().Scan(&lastSeq)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to load claimed change sequence numbers")
+	}
+	return lastSeq, nil
+}
+
+// getChangeStreamSequences returns the latest sequence number of a change
+// stream, and the sequence number up to which its changes have been pruned.
+// Both are 0 for streams without changes.
+func (store *sqlConfiguratorStorage) getChangeStreamSequences(stream string) (uint64, uint64, error) {
+	var latestSeq, prunedSeq uint64
+	err := store.builder.Select(chSeqValCol, chSeqPrunedCol).
+		From(changeSeqTable).
+		Where(sq.Eq{chSeqStreamCol: stream}).
+		RunWith(store.tx).
+		QueryRow().Scan(&latestSeq, &prunedSeq)
+	if err == sql.ErrNoRows {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to load change sequence numbers")
+	}
+	return latestSeq, prunedSeq, nil
+}
+
+func (store *sqlConfiguratorStorage) getLoadChangesSelectBuilder(stream string, filter ChangeLoadFilter) sq.SelectBuilder {
+	selectBuilder := store.builder.Select(chSeqCol, chNidCol, chKindCol, chOpCol, chTypeCol, chKeyCol).
+		From(changeTable).
+		Where(sq.Eq{chStreamCol: stream}).
+		Where(sq.Gt{chSeqCol: filter.AfterSequence}).
+		OrderBy(chSeqCol)
+	if filter.NetworkID != nil {
+		selectBuilder = selectBuilder.Where(sq.Eq{chNidCol: filter.NetworkID.Value})
+	}
+	if filter.TypeFilter != nil {
+		selectBuilder = selectBuilder.Where(sq.Eq{chTypeCol: filter.TypeFilter.Value})
+	}
+	if filter.Limit > 0 {
+		selectBuilder = selectBuilder.Limit(uint64(filter.Limit))
+	}
+	return selectBuilder
+}
+
+// pruneChangeStreams deletes the changes of each given stream up to the
+// given sequence number, and records that they have been pruned.
+func (store *sqlConfiguratorStorage) pruneChangeStreams(prunedSeqsByStream map[string]uint64) error {
+	streams := funk.Keys(prunedSeqsByStream).([]string)
+	sort.Strings(streams)
+	for _, stream := range streams {
+		prunedSeq := prunedSeqsByStream[stream]
+		_, err := store.builder.Update(changeSeqTable).
+			Set(chSeqPrunedCol, prunedSeq).
+			Where(sq.And{sq.Eq{chSeqStreamCol: stream}, sq.Lt{chSeqPrunedCol: prunedSeq}}).
+			RunWith(store.tx).
+			Exec()
+		if err != nil {
+			return errors.Wrap(err, "failed to update pruned change sequence")
+		}
+		_, err = store.builder.Delete(changeTable).
+			Where(sq.And{sq.Eq{chStreamCol: stream}, sq.LtOrEq{chSeqCol: prunedSeq}}).
+			RunWith(store.tx).
+			Exec()
+		if err != nil {
+			return errors.Wrap(err, "failed to delete pruned changes")
+		}
+	}
+	return nil
+}
+
+func scanChangeRows(rows *sql.Rows) ([]*Change, error) {
+	ret := []*Change{}
+	for rows.Next() {
+		var kind, op int32
+		change := &Change{}
+		err := rows.Scan(&change.Sequence, &change.NetworkID, &kind, &op, &change.Type, &change.Key)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning change row: %s", err)
+		}
+		change.Kind, change.Operation = Change_Kind(kind), Change_Operation(op)
+		ret = append(ret, change)
+	}
+	return ret, nil
+}
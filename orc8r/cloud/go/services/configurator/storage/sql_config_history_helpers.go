@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"magma/orc8r/cloud/go/clock"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+func newNetworkConfigRevision(networkID string, configType string, value []byte) *ConfigRevision {
+	return &ConfigRevision{
+		NetworkID: networkID,
+		Kind:      ConfigRevision_NETWORK_CONFIG,
+		Type:      configType,
+		Value:     value,
+		Deleted:   value == nil,
+	}
+}
+
+func newEntityConfigRevision(networkID string, entType string, entKey string, value []byte, labels map[string]string) *ConfigRevision {
+	// Entity configs are cleared by setting them to an empty value
+	if len(value) == 0 {
+		value = nil
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+	return &ConfigRevision{
+		NetworkID: networkID,
+		Kind:      ConfigRevision_ENTITY_CONFIG,
+		Type:      entType,
+		Key:       entKey,
+		Value:     value,
+		Deleted:   value == nil,
+		Labels:    labels,
+	}
+}
+
+// getNetworkConfigRevisions returns the config revisions produced by a
+// network update, with upserts in sorted order followed by deletions.
+func getNetworkConfigRevisions(update NetworkUpdateCriteria) []*ConfigRevision {
+	configUpdateTypes := funk.Keys(update.ConfigsToAddOrUpdate).([]string)
+	sort.Strings(configUpdateTypes)
+
+	ret := make([]*ConfigRevision, 0, len(configUpdateTypes)+len(update.ConfigsToDelete))
+	for _, configType := range configUpdateTypes {
+		ret = append(ret, newNetworkConfigRevision(update.ID, configType, update.ConfigsToAddOrUpdate[configType]))
+	}
+	for _, configType := range update.ConfigsToDelete {
+		ret = append(ret, newNetworkConfigRevision(update.ID, configType, nil))
+	}
+	return ret
+}
+
+// MaxConfigRevisions is the number of revisions kept in the config history of
+// each config. Recording a revision deletes the config's revisions which fall
+// out of this window.
+var MaxConfigRevisions uint64 = 100
+
+// recordConfigRevisions appends the given revisions to the config history.
+// Each revision is assigned the next revision number for its config. As with
+// the change log, (network, kind, type, key, revision) is the primary key so
+// concurrent writes to the same config will conflict.
+// revisions is an output parameter - entries will be updated in-place with
+// their assigned revision numbers, authors, and timestamps.
+func (store *sqlConfiguratorStorage) recordConfigRevisions(revisions ...*ConfigRevision) error {
+	now := clock.Now().Unix()
+	for _, rev := range revisions {
+		var maxRev sql.NullInt64
+		err := store.builder.Select(fmt.Sprintf("MAX(%s)", histRevCol)).
+			From(configHistoryTable).
+			Where(sq.Eq{histNidCol: rev.NetworkID, histKindCol: rev.Kind, histTypeCol: rev.Type, histKeyCol: rev.Key}).
+			RunWith(store.tx).
+			QueryRow().Scan(&maxRev)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load latest revision of config (%s, %s)", rev.Type, rev.Key)
+		}
+
+		labels, err := marshalRevisionLabels(rev.Labels)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal labels of config (%s, %s)", rev.Type, rev.Key)
+		}
+		rev.Revision = uint64(maxRev.Int64) + 1
+		rev.Author = store.author
+		rev.CreatedAt = now
+		_, err = store.builder.Insert(configHistoryTable).
+			Columns(histNidCol, histKindCol, histTypeCol, histKeyCol, histRevCol, histValCol, histDelCol, histLabelsCol, histAuthorCol, histCreatedCol).
+			Values(rev.NetworkID, rev.Kind, rev.Type, rev.Key, rev.Revision, rev.Value, rev.Deleted, labels, rev.Author, rev.CreatedAt).
+			RunWith(store.tx).
+			Exec()
+		if err != nil {
+			return errors.Wrapf(err, "failed to record revision of config (%s, %s)", rev.Type, rev.Key)
+		}
+		if rev.Revision <= MaxConfigRevisions {
+			continue
+		}
+		_, err = store.builder.Delete(configHistoryTable).
+			Where(sq.And{
+				sq.Eq{histNidCol: rev.NetworkID, histKindCol: rev.Kind, histTypeCol: rev.Type, histKeyCol: rev.Key},
+				sq.LtOrEq{histRevCol: rev.Revision - MaxConfigRevisions},
+			}).
+			RunWith(store.tx).
+			Exec()
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete old revisions of config (%s, %s)", rev.Type, rev.Key)
+		}
+	}
+	return nil
+}
+
+// marshalRevisionLabels returns the JSON encoding of a revision's labels, or
+// nil if the revision has no labels
+func marshalRevisionLabels(labels map[string]string) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(labels)
+}
+
+func (store *sqlConfiguratorStorage) getLoadConfigHistorySelectBuilder(filter ConfigHistoryFilter) sq.SelectBuilder {
+	selectBuilder := store.builder.Select(histNidCol, histKindCol, histTypeCol, histKeyCol, histRevCol, histValCol, histDelCol, histLabelsCol, histAuthorCol, histCreatedCol).
+		From(configHistoryTable).
+		Where(sq.Eq{histNidCol: filter.NetworkID, histKindCol: filter.Kind, histTypeCol: filter.Type, histKeyCol: filter.Key}).
+		OrderBy(fmt.Sprintf("%s DESC", histRevCol))
+	if filter.Revision != nil {
+		selectBuilder = selectBuilder.Where(sq.Eq{histRevCol: filter.Revision.Value})
+	}
+	return selectBuilder
+}
+
+func scanConfigRevisionRows(rows *sql.Rows) ([]*ConfigRevision, error) {
+	ret := []*ConfigRevision{}
+	for rows.Next() {
+		var kind int32
+		var labels []byte
+		rev := &ConfigRevision{}
+		err := rows.Scan(&rev.NetworkID, &kind, &rev.Type, &rev.Key, &rev.Revision, &rev.Value, &rev.Deleted, &labels, &rev.Author, &rev.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning config revision row: %s", err)
+		}
+		rev.Kind = ConfigRevision_Kind(kind)
+		if len(labels) > 0 {
+			if err = json.Unmarshal(labels, &rev.Labels); err != nil {
+				return nil, fmt.Errorf("error while unmarshaling labels of config revision: %s", err)
+			}
+		}
+		ret = append(ret, rev)
+	}
+	return ret, nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+// ErrEmptyLabelKey is returned when creating or updating an entity with a
+// label which has an empty key.
+var ErrEmptyLabelKey = errors.New("label keys must be non-empty")
+
+func validateLabels(labels map[string]string) error {
+	if _, exists := labels[""]; exists {
+		return ErrEmptyLabelKey
+	}
+	return nil
+}
+
+// getLabelSelectorClause returns a WHERE clause matching entities which have
+// every label in the selector. Each label is matched with a subquery so the
+// clause can be combined with the rest of the entity load query.
+func getLabelSelectorClause(labelSelector map[string]string) sq.And {
+	// ent.pk IN (SELECT entity_pk FROM cfg_entity_labels WHERE "key" = $1 AND value = $2) AND ...
+	keys := funk.Keys(labelSelector).([]string)
+	sort.Strings(keys)
+
+	ret := make(sq.And, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, sq.Expr(
+			fmt.Sprintf(
+				"ent.%s IN (SELECT %s FROM %s WHERE %s = ? AND %s = ?)",
+				entPkCol, labelEntCol, entityLabelTable, labelKeyCol, labelValCol,
+			),
+			k, labelSelector[k],
+		))
+	}
+	return ret
+}
+
+// entsByPkOut is an output parameter - loaded labels will be set on the
+// entities in-place.
+func (store *sqlConfiguratorStorage) loadLabels(entsByPkOut map[string]*NetworkEntity) error {
+	if len(entsByPkOut) == 0 {
+		return nil
+	}
+	entPks := funk.Keys(entsByPkOut).([]string)
+	sort.Strings(entPks)
+
+	// SELECT entity_pk, "key", value FROM cfg_entity_labels WHERE entity_pk IN ($1, $2, ...)
+	rows, err := store.builder.Select(labelEntCol, labelKeyCol, labelValCol).
+		From(entityLabelTable).
+		Where(sq.Eq{labelEntCol: entPks}).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return errors.Wrap(err, "error querying for entity labels")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "LoadEntities")
+
+	for rows.Next() {
+		var pk, key, value string
+		err = rows.Scan(&pk, &key, &value)
+		if err != nil {
+			return errors.Wrap(err, "error scanning entity label row")
+		}
+		ent, exists := entsByPkOut[pk]
+		if !exists {
+			continue
+		}
+		if ent.Labels == nil {
+			ent.Labels = map[string]string{}
+		}
+		ent.Labels[key] = value
+	}
+	return nil
+}
+
+func (store *sqlConfiguratorStorage) createLabels(pk string, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	keys := funk.Keys(labels).([]string)
+	sort.Strings(keys)
+	insertBuilder := store.builder.Insert(entityLabelTable).
+		Columns(labelEntCol, labelKeyCol, labelValCol)
+	for _, k := range keys {
+		insertBuilder = insertBuilder.Values(pk, k, labels[k])
+	}
+	_, err := insertBuilder.RunWith(store.tx).Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to create labels")
+	}
+	return nil
+}
+
+// entOut is an output parameter
+func (store *sqlConfiguratorStorage) processLabelUpdates(entPk string, update EntityUpdateCriteria, entOut *NetworkEntity) error {
+	if update.LabelsToSet == nil {
+		return nil
+	}
+
+	_, err := store.builder.Delete(entityLabelTable).
+		Where(sq.Eq{labelEntCol: entPk}).
+		RunWith(store.tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete existing labels")
+	}
+	err = store.createLabels(entPk, update.LabelsToSet.Labels)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	entOut.Labels = update.LabelsToSet.Labels
+	return nil
+}
@@ -10,6 +10,7 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"sort"
 	"strings"
@@ -19,18 +20,30 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
 )
 
 func (store *sqlConfiguratorStorage) loadFromEntitiesTable(networkID string, filter EntityLoadFilter, criteria EntityLoadCriteria) (map[string]*NetworkEntity, error) {
+	entsByPk, _, err := store.loadPageFromEntitiesTable(networkID, filter, criteria)
+	return entsByPk, err
+}
+
+// loadPageFromEntitiesTable loads entities like loadFromEntitiesTable, and
+// additionally returns the token for the next page if the load criteria
+// specify a page size and the page is full.
+func (store *sqlConfiguratorStorage) loadPageFromEntitiesTable(networkID string, filter EntityLoadFilter, criteria EntityLoadCriteria) (map[string]*NetworkEntity, string, error) {
 	// Pointer values because we're modifying entities in-place with ACLs (LEFT JOIN)
 	entsByPk := map[string]*NetworkEntity{}
 
-	selectBuilder := store.getLoadEntitiesSelectBuilder(networkID, filter, criteria)
+	selectBuilder, err := store.getLoadEntitiesSelectBuilder(networkID, filter, criteria)
+	if err != nil {
+		return entsByPk, "", err
+	}
 	rows, err := selectBuilder.RunWith(store.tx).Query()
 	if err != nil {
-		return entsByPk, errors.Wrap(err, "error querying for entities")
+		return entsByPk, "", errors.Wrap(err, "error querying for entities")
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
@@ -38,16 +51,34 @@ func (store *sqlConfiguratorStorage) loadFromEntitiesTable(networkID string, fil
 		}
 	}()
 
+	// Rows are ordered by (type, key) when paginating, so the last row
+	// scanned is the last entity of the page
+	var lastPk string
 	for rows.Next() {
-		err = scanNextEntityRow(rows, criteria, entsByPk)
+		lastPk, err = scanNextEntityRow(rows, criteria, entsByPk)
+		if err != nil {
+			return entsByPk, "", err
+		}
+	}
+
+	if criteria.LoadLabels {
+		err = store.loadLabels(entsByPk)
 		if err != nil {
-			return entsByPk, err
+			return entsByPk, "", err
 		}
 	}
-	return entsByPk, nil
+
+	nextPageToken := ""
+	if criteria.PageSize > 0 && uint32(len(entsByPk)) == criteria.PageSize {
+		nextPageToken, err = encodeEntityPageToken(entsByPk[lastPk].GetTypeAndKey())
+		if err != nil {
+			return entsByPk, "", err
+		}
+	}
+	return entsByPk, nextPageToken, nil
 }
 
-func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID string, filter EntityLoadFilter, criteria EntityLoadCriteria) sq.SelectBuilder {
+func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID string, filter EntityLoadFilter, criteria EntityLoadCriteria) (sq.SelectBuilder, error) {
 	// SELECT ent.pk, ent.key, ent.type, ent.physical_id, ent.version, graph.graph_id, ent.name, ent.description, ent.config,
 	// [[ acl.id, acl.scope, acl.permission, acl.type, acl.id_filter, acl.version ]]
 	// FROM cfg_entities AS ent
@@ -87,7 +118,58 @@ func (store *sqlConfiguratorStorage) getLoadEntitiesSelectBuilder(networkID stri
 		}
 	}
 
-	return selectBuilder
+	// ... [[ AND ent.pk IN (SELECT entity_pk FROM cfg_entity_labels WHERE ...) ]]
+	if len(filter.LabelSelector) > 0 {
+		selectBuilder = selectBuilder.Where(getLabelSelectorClause(filter.LabelSelector))
+	}
+
+	if criteria.PageSize > 0 {
+		if !funk.IsEmpty(filter.IDs) || filter.PhysicalID != nil || criteria.LoadPermissions {
+			return selectBuilder, ErrPaginationNotSupported
+		}
+		// ... [[ AND (ent.type > $4 OR (ent.type = $5 AND ent.key > $6)) ]]
+		// ORDER BY ent.type, ent.key LIMIT $7
+		if criteria.PageToken != "" {
+			after, err := decodeEntityPageToken(criteria.PageToken)
+			if err != nil {
+				return selectBuilder, err
+			}
+			selectBuilder = selectBuilder.Where(sq.Or{
+				sq.Gt{fmt.Sprintf("ent.%s", entTypeCol): after.Type},
+				sq.And{
+					sq.Eq{fmt.Sprintf("ent.%s", entTypeCol): after.Type},
+					sq.Gt{fmt.Sprintf("ent.%s", entKeyCol): after.Key},
+				},
+			})
+		}
+		selectBuilder = selectBuilder.
+			OrderBy(fmt.Sprintf("ent.%s", entTypeCol), fmt.Sprintf("ent.%s", entKeyCol)).
+			Limit(uint64(criteria.PageSize))
+	}
+	return selectBuilder, nil
+}
+
+// Page tokens are the base64-encoded ID of the last entity on the page
+
+func encodeEntityPageToken(lastEntity storage.TypeAndKey) (string, error) {
+	marshaled, err := proto.Marshal(&EntityID{Type: lastEntity.Type, Key: lastEntity.Key})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode page token")
+	}
+	return base64.RawURLEncoding.EncodeToString(marshaled), nil
+}
+
+func decodeEntityPageToken(token string) (storage.TypeAndKey, error) {
+	marshaled, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return storage.TypeAndKey{}, ErrInvalidPageToken
+	}
+	id := &EntityID{}
+	err = proto.Unmarshal(marshaled, id)
+	if err != nil {
+		return storage.TypeAndKey{}, ErrInvalidPageToken
+	}
+	return id.ToTypeAndKey(), nil
 }
 
 func getLoadEntitiesColumns(criteria EntityLoadCriteria) []string {
@@ -120,8 +202,9 @@ func getLoadEntitiesColumns(criteria EntityLoadCriteria) []string {
 	return fields
 }
 
-// existingEntsByPkOut is an output parameter
-func scanNextEntityRow(rows *sql.Rows, criteria EntityLoadCriteria, existingEntsByPkOut map[string]*NetworkEntity) error {
+// existingEntsByPkOut is an output parameter. Returns the PK of the scanned
+// entity.
+func scanNextEntityRow(rows *sql.Rows, criteria EntityLoadCriteria, existingEntsByPkOut map[string]*NetworkEntity) (string, error) {
 	var nid, pk, key, entType, graphID string
 	var physicalID sql.NullString
 	var name, description sql.NullString
@@ -148,7 +231,7 @@ func scanNextEntityRow(rows *sql.Rows, criteria EntityLoadCriteria, existingEnts
 
 	err := rows.Scan(scanArgs...)
 	if err != nil {
-		return fmt.Errorf("error while scanning entity row: %s", err)
+		return "", fmt.Errorf("error while scanning entity row: %s", err)
 	}
 
 	ent := NetworkEntity{
@@ -189,7 +272,7 @@ func scanNextEntityRow(rows *sql.Rows, criteria EntityLoadCriteria, existingEnts
 	} else {
 		existingEntsByPkOut[pk] = &ent
 	}
-	return nil
+	return pk, nil
 }
 
 func deserializeACLScope(aclScope string) isACL_Scope {
@@ -242,7 +325,7 @@ func (store *sqlConfiguratorStorage) loadFromAssocsTable(filter EntityLoadFilter
 	}
 	// if we loaded all entities, save some network traffic and just load the
 	// entire assocs table
-	if filter.IsLoadAllEntities() {
+	if filter.IsLoadAllEntities() && criteria.PageSize == 0 {
 		orClause = sq.Or{sq.Eq{"1": 1}}
 	}
 
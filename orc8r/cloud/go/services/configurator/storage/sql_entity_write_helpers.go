@@ -218,10 +218,17 @@ func (store *sqlConfiguratorStorage) mergeGraphs(createdEntity entWithPk, allAss
 }
 
 func (store *sqlConfiguratorStorage) loadEntToUpdate(networkID string, update EntityUpdateCriteria) (*entWithPk, error) {
+	// Config revisions record both the config and labels of the entity, so
+	// load whichever of the two the update doesn't replace
+	criteria := EntityLoadCriteria{}
+	if !update.DeleteEntity {
+		criteria.LoadConfig = update.NewConfig == nil && update.LabelsToSet != nil
+		criteria.LoadLabels = update.NewConfig != nil && update.LabelsToSet == nil
+	}
 	loadedEntByPk, err := store.loadFromEntitiesTable(
 		networkID,
 		EntityLoadFilter{IDs: []*EntityID{update.GetID()}},
-		EntityLoadCriteria{},
+		criteria,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load entity to update")
@@ -241,12 +248,17 @@ func (store *sqlConfiguratorStorage) loadEntToUpdate(networkID string, update En
 
 // entOut is an output parameter
 func (store *sqlConfiguratorStorage) processEntityFieldsUpdate(pk string, update EntityUpdateCriteria, entOut *NetworkEntity) error {
-	_, err := store.getEntityUpdateQueryBuilder(pk, update).
+	res, err := store.getEntityUpdateQueryBuilder(pk, update).
 		RunWith(store.tx).
 		Exec()
 	if err != nil {
 		return errors.Wrap(err, "failed to update entity fields")
 	}
+	if update.ExpectedVersion != nil {
+		if err := checkVersionedWrite(res); err != nil {
+			return err
+		}
+	}
 
 	if update.NewName != nil {
 		entOut.Name = (*update.NewName).Value
@@ -355,8 +367,11 @@ func (store *sqlConfiguratorStorage) processEdgeUpdates(networkID string, update
 
 func (store *sqlConfiguratorStorage) getEntityUpdateQueryBuilder(pk string, update EntityUpdateCriteria) sq.UpdateBuilder {
 	// UPDATE cfg_entities SET (name, description, physical_id, config, version) = ($1, $2, $3, $4, cfg_entities.version + 1)
-	// WHERE pk = $5
+	// WHERE pk = $5 [AND version = $6]
 	updateBuilder := store.builder.Update(entityTable).Where(sq.Eq{entPkCol: pk})
+	if update.ExpectedVersion != nil {
+		updateBuilder = updateBuilder.Where(sq.Eq{entVerCol: update.ExpectedVersion.Value})
+	}
 	if update.NewName != nil {
 		updateBuilder = updateBuilder.Set(entNameCol, update.NewName.Value)
 	}
@@ -554,3 +569,16 @@ func toNullable(field interface{}) interface{} {
 		return field
 	}
 }
+
+// checkVersionedWrite returns ErrVersionMismatch if a write which was
+// conditioned on the expected version of its row didn't affect any rows.
+func checkVersionedWrite(res sql.Result) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get number of affected rows")
+	}
+	if rowsAffected == 0 {
+		return ErrVersionMismatch
+	}
+	return nil
+}
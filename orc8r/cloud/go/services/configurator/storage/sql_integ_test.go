@@ -11,8 +11,11 @@ package storage_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/services/configurator/storage"
 	"magma/orc8r/cloud/go/sqorc"
 	orc8rStorage "magma/orc8r/cloud/go/storage"
@@ -897,3 +900,757 @@ func TestSqlConfiguratorStorage_Integration(t *testing.T) {
 		allEnts,
 	)
 }
+
+func TestSqlConfiguratorStorage_ChangeLogIntegration(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n2"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "baz", Key: "quz"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n2", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	// Writes in a rolled back transaction should not show up in the log
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "rolledback"})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Rollback())
+
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewName: &wrappers.StringValue{Value: "foobar"}})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "baz", Key: "quz", DeleteEntity: true})
+	assert.NoError(t, err)
+	// Deleting an entity which doesn't exist is a no-op
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "baz", Key: "dne", DeleteEntity: true})
+	assert.NoError(t, err)
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{
+		{ID: "n1", NewName: &wrappers.StringValue{Value: "network 1"}},
+		{ID: "n2", DeleteNetwork: true},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+
+	// Network changes are sequenced in one stream, and each network's entity
+	// changes in another
+	networkChanges, err := store.LoadChanges(storage.ChangeLoadFilter{Kinds: []storage.Change_Kind{storage.Change_NETWORK}})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*storage.Change{
+			{Sequence: 1, NetworkID: "n1", Kind: storage.Change_NETWORK, Operation: storage.Change_CREATE},
+			{Sequence: 2, NetworkID: "n2", Kind: storage.Change_NETWORK, Operation: storage.Change_CREATE},
+			{Sequence: 3, NetworkID: "n1", Kind: storage.Change_NETWORK, Operation: storage.Change_UPDATE},
+			{Sequence: 4, NetworkID: "n2", Kind: storage.Change_NETWORK, Operation: storage.Change_DELETE},
+		},
+		networkChanges,
+	)
+	n1Changes, err := store.LoadChanges(storage.ChangeLoadFilter{
+		NetworkID: &wrappers.StringValue{Value: "n1"},
+		Kinds:     []storage.Change_Kind{storage.Change_ENTITY},
+	})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*storage.Change{
+			{Sequence: 1, NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_CREATE, Type: "foo", Key: "bar"},
+			{Sequence: 2, NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_CREATE, Type: "baz", Key: "quz"},
+			{Sequence: 3, NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_UPDATE, Type: "foo", Key: "bar"},
+			{Sequence: 4, NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_DELETE, Type: "baz", Key: "quz"},
+		},
+		n1Changes,
+	)
+	n2Changes, err := store.LoadChanges(storage.ChangeLoadFilter{
+		NetworkID: &wrappers.StringValue{Value: "n2"},
+		Kinds:     []storage.Change_Kind{storage.Change_ENTITY},
+	})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*storage.Change{
+			{Sequence: 1, NetworkID: "n2", Kind: storage.Change_ENTITY, Operation: storage.Change_CREATE, Type: "foo", Key: "bar"},
+			// entities of deleted networks are deleted along with them
+			{Sequence: 2, NetworkID: "n2", Kind: storage.Change_ENTITY, Operation: storage.Change_DELETE, Type: "foo", Key: "bar"},
+		},
+		n2Changes,
+	)
+
+	// Resume from a cursor with filters
+	actual, err := store.LoadChanges(storage.ChangeLoadFilter{
+		AfterSequence: 1,
+		NetworkID:     &wrappers.StringValue{Value: "n1"},
+		Kinds:         []storage.Change_Kind{storage.Change_ENTITY},
+		TypeFilter:    &wrappers.StringValue{Value: "foo"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Change{n1Changes[2]}, actual)
+
+	actual, err = store.LoadChanges(storage.ChangeLoadFilter{
+		Kinds: []storage.Change_Kind{storage.Change_NETWORK},
+		Limit: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Change{networkChanges[0], networkChanges[1]}, actual)
+
+	actual, err = store.LoadChanges(storage.ChangeLoadFilter{
+		NetworkID: &wrappers.StringValue{Value: "n2"},
+		Kinds:     []storage.Change_Kind{storage.Change_NETWORK},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Change{networkChanges[1], networkChanges[3]}, actual)
+
+	actual, err = store.LoadChanges(storage.ChangeLoadFilter{AfterSequence: 4, Kinds: []storage.Change_Kind{storage.Change_NETWORK}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Change{}, actual)
+
+	// Filters have to select a single stream
+	_, err = store.LoadChanges(storage.ChangeLoadFilter{})
+	assert.Equal(t, storage.ErrInvalidChangeFilter, err)
+	_, err = store.LoadChanges(storage.ChangeLoadFilter{Kinds: []storage.Change_Kind{storage.Change_ENTITY}})
+	assert.Equal(t, storage.ErrInvalidChangeFilter, err)
+	_, err = store.GetLatestChangeSequence(storage.ChangeLoadFilter{
+		NetworkID: &wrappers.StringValue{Value: "n1"},
+		Kinds:     []storage.Change_Kind{storage.Change_NETWORK, storage.Change_ENTITY},
+	})
+	assert.Equal(t, storage.ErrInvalidChangeFilter, err)
+
+	latestSeq, err := store.GetLatestChangeSequence(storage.ChangeLoadFilter{Kinds: []storage.Change_Kind{storage.Change_NETWORK}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), latestSeq)
+	latestSeq, err = store.GetLatestChangeSequence(storage.ChangeLoadFilter{
+		NetworkID: &wrappers.StringValue{Value: "n2"},
+		Kinds:     []storage.Change_Kind{storage.Change_ENTITY},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), latestSeq)
+	latestSeq, err = store.GetLatestChangeSequence(storage.ChangeLoadFilter{
+		NetworkID: &wrappers.StringValue{Value: "n3"},
+		Kinds:     []storage.Change_Kind{storage.Change_ENTITY},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), latestSeq)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_PruneChanges(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	networkFilter := storage.ChangeLoadFilter{Kinds: []storage.Change_Kind{storage.Change_NETWORK}}
+	entityFilter := storage.ChangeLoadFilter{NetworkID: &wrappers.StringValue{Value: "n1"}, Kinds: []storage.Change_Kind{storage.Change_ENTITY}}
+	loadChanges := func(filter storage.ChangeLoadFilter, afterSequence uint64) ([]*storage.Change, error) {
+		store, err := factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+		assert.NoError(t, err)
+		defer store.Commit()
+		filter.AfterSequence = afterSequence
+		return store.LoadChanges(filter)
+	}
+	write := func(f func(store storage.ConfiguratorStorage) error) {
+		store, err := factory.StartTransaction(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.NoError(t, f(store))
+		assert.NoError(t, store.Commit())
+	}
+	prune := func(before time.Time) {
+		write(func(store storage.ConfiguratorStorage) error { return store.PruneChanges(before) })
+	}
+
+	write(func(store storage.ConfiguratorStorage) error {
+		if _, err := store.CreateNetwork(storage.Network{ID: "n1"}); err != nil {
+			return err
+		}
+		_, err := store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "bar"})
+		return err
+	})
+	clock.SetAndFreezeClock(t, time.Unix(2000, 0))
+	write(func(store storage.ConfiguratorStorage) error {
+		if err := store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n1", NewName: &wrappers.StringValue{Value: "network 1"}}}); err != nil {
+			return err
+		}
+		_, err := store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "baz"})
+		return err
+	})
+
+	// Nothing is older than the retention period yet
+	prune(time.Unix(1000, 0))
+	actual, err := loadChanges(networkFilter, 0)
+	assert.NoError(t, err)
+	assert.Len(t, actual, 2)
+
+	// Cursors before pruned changes expire, later cursors keep working
+	prune(time.Unix(1500, 0))
+	_, err = loadChanges(networkFilter, 0)
+	assert.Equal(t, storage.ErrCursorExpired, err)
+	_, err = loadChanges(entityFilter, 0)
+	assert.Equal(t, storage.ErrCursorExpired, err)
+	actual, err = loadChanges(networkFilter, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Change{{Sequence: 2, NetworkID: "n1", Kind: storage.Change_NETWORK, Operation: storage.Change_UPDATE}}, actual)
+	actual, err = loadChanges(entityFilter, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Change{{Sequence: 2, NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_CREATE, Type: "foo", Key: "baz"}}, actual)
+
+	// Pruning every change keeps the latest sequences, and cursors at them
+	// stay valid
+	prune(time.Unix(3000, 0))
+	_, err = loadChanges(entityFilter, 1)
+	assert.Equal(t, storage.ErrCursorExpired, err)
+	actual, err = loadChanges(entityFilter, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	write(func(store storage.ConfiguratorStorage) error {
+		latestSeq, err := store.GetLatestChangeSequence(entityFilter)
+		assert.Equal(t, uint64(2), latestSeq)
+		if err != nil {
+			return err
+		}
+		_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "quz"})
+		return err
+	})
+	actual, err = loadChanges(entityFilter, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Change{{Sequence: 3, NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_CREATE, Type: "foo", Key: "quz"}}, actual)
+}
+
+func TestSqlConfiguratorStorage_ConfigHistoryIntegration(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1", Configs: map[string][]byte{"foo": []byte("v1")}})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "bar", Config: []byte("e1")})
+	assert.NoError(t, err)
+	// Entities created without configs or labels have no history
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "noconfig"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "labeled", Labels: map[string]string{"region": "west"}})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	clock.SetAndFreezeClock(t, time.Unix(2000, 0))
+	store, err = factory.StartTransaction(storage.WithAuthor(context.Background(), "alice"), nil)
+	assert.NoError(t, err)
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{
+		{ID: "n1", ConfigsToAddOrUpdate: map[string][]byte{"foo": []byte("v2")}},
+	})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewConfig: &wrappers.BytesValue{Value: []byte("e2")}})
+	assert.NoError(t, err)
+	// Updates which don't touch the config or labels have no history
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewName: &wrappers.StringValue{Value: "foobar"}})
+	assert.NoError(t, err)
+	// Revisions track both the config and the labels of entities
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "labeled", NewConfig: &wrappers.BytesValue{Value: []byte("l1")}})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+		Type:        "foo",
+		Key:         "labeled",
+		LabelsToSet: &storage.EntityLabelsToSet{Labels: map[string]string{"region": "east"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(storage.WithAuthor(context.Background(), "bob"), nil)
+	assert.NoError(t, err)
+	err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{{ID: "n1", ConfigsToDelete: []string{"foo"}}})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewConfig: &wrappers.BytesValue{}})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+
+	actual, err := store.LoadConfigHistory(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*storage.ConfigRevision{
+			{NetworkID: "n1", Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: "foo", Revision: 3, Deleted: true, Author: "bob", CreatedAt: 2000},
+			{NetworkID: "n1", Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: "foo", Revision: 2, Value: []byte("v2"), Author: "alice", CreatedAt: 2000},
+			{NetworkID: "n1", Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: "foo", Revision: 1, Value: []byte("v1"), CreatedAt: 1000},
+		},
+		actual,
+	)
+
+	actual, err = store.LoadConfigHistory(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*storage.ConfigRevision{
+			{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "bar", Revision: 3, Deleted: true, Author: "bob", CreatedAt: 2000},
+			{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "bar", Revision: 2, Value: []byte("e2"), Author: "alice", CreatedAt: 2000},
+			{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "bar", Revision: 1, Value: []byte("e1"), CreatedAt: 1000},
+		},
+		actual,
+	)
+
+	actual, err = store.LoadConfigHistory(storage.ConfigHistoryFilter{
+		NetworkID: "n1",
+		Kind:      storage.ConfigRevision_ENTITY_CONFIG,
+		Type:      "foo",
+		Key:       "bar",
+		Revision:  &wrappers.UInt64Value{Value: 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*storage.ConfigRevision{
+			{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "bar", Revision: 2, Value: []byte("e2"), Author: "alice", CreatedAt: 2000},
+		},
+		actual,
+	)
+
+	actual, err = store.LoadConfigHistory(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "noconfig"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.ConfigRevision{}, actual)
+
+	actual, err = store.LoadConfigHistory(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "labeled"})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*storage.ConfigRevision{
+			{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "labeled", Revision: 3, Value: []byte("l1"), Labels: map[string]string{"region": "east"}, Author: "alice", CreatedAt: 2000},
+			{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "labeled", Revision: 2, Value: []byte("l1"), Labels: map[string]string{"region": "west"}, Author: "alice", CreatedAt: 2000},
+			{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "labeled", Revision: 1, Deleted: true, Labels: map[string]string{"region": "west"}, CreatedAt: 1000},
+		},
+		actual,
+	)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_ConfigHistoryRetention(t *testing.T) {
+	oldMaxRevisions := storage.MaxConfigRevisions
+	storage.MaxConfigRevisions = 3
+	defer func() { storage.MaxConfigRevisions = oldMaxRevisions }()
+
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1", Configs: map[string][]byte{"foo": []byte("v1"), "bar": []byte("v1")}})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "ent", Config: []byte("e1")})
+	assert.NoError(t, err)
+	for i := 2; i <= 5; i++ {
+		err = store.UpdateNetworks([]storage.NetworkUpdateCriteria{
+			{ID: "n1", ConfigsToAddOrUpdate: map[string][]byte{"foo": []byte(fmt.Sprintf("v%d", i))}},
+		})
+		assert.NoError(t, err)
+		_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "ent", NewConfig: &wrappers.BytesValue{Value: []byte(fmt.Sprintf("e%d", i))}})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	getRevisions := func(filter storage.ConfigHistoryFilter) []uint64 {
+		history, err := store.LoadConfigHistory(filter)
+		assert.NoError(t, err)
+		ret := []uint64{}
+		for _, rev := range history {
+			ret = append(ret, rev.Revision)
+		}
+		return ret
+	}
+
+	// Only the latest revisions of each config are kept
+	assert.Equal(t, []uint64{5, 4, 3}, getRevisions(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: "foo"}))
+	assert.Equal(t, []uint64{5, 4, 3}, getRevisions(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: "foo", Key: "ent"}))
+	// Other configs aren't affected
+	assert.Equal(t, []uint64{1}, getRevisions(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: "bar"}))
+	// Deleted revisions can't be loaded
+	assert.Empty(t, getRevisions(storage.ConfigHistoryFilter{NetworkID: "n1", Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: "foo", Revision: &wrappers.UInt64Value{Value: 2}}))
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_PaginationIntegration(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "bar", Key: "x"})
+	assert.NoError(t, err)
+	for _, key := range []string{"e", "c", "a", "d", "b"} {
+		_, err = store.CreateEntity("n1", storage.NetworkEntity{
+			Type:         "foo",
+			Key:          key,
+			Associations: []*storage.EntityID{{Type: "bar", Key: "x"}},
+		})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+
+	// Page through all foo entities, loading their assocs
+	var pages [][]string
+	pageToken := ""
+	for {
+		res, err := store.LoadEntities(
+			"n1",
+			storage.EntityLoadFilter{TypeFilter: stringPointer("foo")},
+			storage.EntityLoadCriteria{LoadAssocsFromThis: true, PageSize: 2, PageToken: pageToken},
+		)
+		assert.NoError(t, err)
+		var page []string
+		for _, ent := range res.Entities {
+			assert.Equal(t, []*storage.EntityID{{Type: "bar", Key: "x"}}, ent.Associations)
+			page = append(page, ent.Key)
+		}
+		pages = append(pages, page)
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, pages)
+
+	// Pages across types are ordered by type, then key
+	res, err := store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.EntityLoadCriteria{PageSize: 3})
+	assert.NoError(t, err)
+	assert.Len(t, res.Entities, 3)
+	assert.Equal(t, "x", res.Entities[0].Key)
+	assert.NotEmpty(t, res.NextPageToken)
+	res, err = store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.EntityLoadCriteria{PageSize: 3, PageToken: res.NextPageToken})
+	assert.NoError(t, err)
+	assert.Len(t, res.Entities, 3)
+	assert.Equal(t, "e", res.Entities[2].Key)
+	assert.NotEmpty(t, res.NextPageToken)
+	res, err = store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.EntityLoadCriteria{PageSize: 3, PageToken: res.NextPageToken})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Entities)
+	assert.Empty(t, res.NextPageToken)
+
+	_, err = store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.EntityLoadCriteria{PageSize: 3, PageToken: "garbage"})
+	assert.EqualError(t, err, "invalid page token")
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_LabelsIntegration(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	created, err := store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "a", Labels: map[string]string{"env": "prod", "region": "west"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "west"}, created.Labels)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "b", Labels: map[string]string{"env": "prod", "region": "east"}})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "c"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "bar", Key: "d", Labels: map[string]string{"env": "prod"}})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "e", Labels: map[string]string{"": "nope"}})
+	assert.Equal(t, storage.ErrEmptyLabelKey, err)
+	assert.NoError(t, store.Commit())
+
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+
+	loadKeys := func(filter storage.EntityLoadFilter, criteria storage.EntityLoadCriteria) []string {
+		res, err := store.LoadEntities("n1", filter, criteria)
+		assert.NoError(t, err)
+		keys := []string{}
+		for _, ent := range res.Entities {
+			keys = append(keys, ent.Key)
+		}
+		return keys
+	}
+	assert.Equal(t, []string{"d", "a", "b"}, loadKeys(storage.EntityLoadFilter{LabelSelector: map[string]string{"env": "prod"}}, storage.EntityLoadCriteria{}))
+	assert.Equal(t, []string{"a", "b"}, loadKeys(storage.EntityLoadFilter{TypeFilter: stringPointer("foo"), LabelSelector: map[string]string{"env": "prod"}}, storage.EntityLoadCriteria{}))
+	assert.Equal(t, []string{"a"}, loadKeys(storage.EntityLoadFilter{LabelSelector: map[string]string{"env": "prod", "region": "west"}}, storage.EntityLoadCriteria{}))
+	assert.Equal(t, []string{}, loadKeys(storage.EntityLoadFilter{LabelSelector: map[string]string{"env": "dev"}}, storage.EntityLoadCriteria{}))
+	assert.Equal(t, []string{"a"}, loadKeys(storage.EntityLoadFilter{LabelSelector: map[string]string{"env": "prod"}}, storage.EntityLoadCriteria{PageSize: 1, PageToken: entityPageToken(t, "bar", "d")}))
+
+	// Labels are only loaded when requested
+	res, err := store.LoadEntities("n1", storage.EntityLoadFilter{TypeFilter: stringPointer("foo")}, storage.EntityLoadCriteria{LoadLabels: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "west"}, res.Entities[0].Labels)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "east"}, res.Entities[1].Labels)
+	assert.Nil(t, res.Entities[2].Labels)
+	res, err = store.LoadEntities("n1", storage.EntityLoadFilter{TypeFilter: stringPointer("foo")}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Nil(t, res.Entities[0].Labels)
+
+	// Setting labels replaces all of them, an empty set clears them, and a
+	// nil set leaves them alone
+	updated, err := store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "a", LabelsToSet: &storage.EntityLabelsToSet{Labels: map[string]string{"env": "dev"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "dev"}, updated.Labels)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "b", LabelsToSet: &storage.EntityLabelsToSet{}})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "bar", Key: "d", NewName: stringPointer("d")})
+	assert.NoError(t, err)
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "foo", Key: "c", LabelsToSet: &storage.EntityLabelsToSet{Labels: map[string]string{"": "nope"}}})
+	assert.Equal(t, storage.ErrEmptyLabelKey, err)
+	assert.Equal(t, []string{"d"}, loadKeys(storage.EntityLoadFilter{LabelSelector: map[string]string{"env": "prod"}}, storage.EntityLoadCriteria{}))
+	assert.Equal(t, []string{"a"}, loadKeys(storage.EntityLoadFilter{LabelSelector: map[string]string{"env": "dev"}}, storage.EntityLoadCriteria{}))
+
+	// Deleting an entity deletes its labels
+	_, err = store.UpdateEntity("n1", storage.EntityUpdateCriteria{Type: "bar", Key: "d", DeleteEntity: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, loadKeys(storage.EntityLoadFilter{LabelSelector: map[string]string{"env": "prod"}}, storage.EntityLoadCriteria{}))
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_ConcurrentVersionedWrites(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	store, err := factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n1"})
+	assert.NoError(t, err)
+	_, err = store.CreateEntity("n1", storage.NetworkEntity{Type: "foo", Key: "bar"})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+
+	// Concurrent writers which all expect the same version: exactly one of
+	// them wins and the others see a version mismatch
+	const numWriters = 8
+	runConcurrently := func(write func(store storage.ConfiguratorStorage, i int) error) (int, int) {
+		errs := make(chan error, numWriters)
+		wg := sync.WaitGroup{}
+		for i := 0; i < numWriters; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				store, err := factory.StartTransaction(context.Background(), nil)
+				if err != nil {
+					errs <- err
+					return
+				}
+				err = write(store, i)
+				if err != nil {
+					storage.RollbackLogOnError(store)
+					errs <- err
+					return
+				}
+				errs <- store.Commit()
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		numSucceeded, numMismatched := 0, 0
+		for err := range errs {
+			switch err {
+			case nil:
+				numSucceeded++
+			case storage.ErrVersionMismatch:
+				numMismatched++
+			default:
+				assert.NoError(t, err)
+			}
+		}
+		return numSucceeded, numMismatched
+	}
+
+	numSucceeded, numMismatched := runConcurrently(func(store storage.ConfiguratorStorage, i int) error {
+		_, err := store.UpdateEntity("n1", storage.EntityUpdateCriteria{
+			Type:            "foo",
+			Key:             "bar",
+			NewName:         &wrappers.StringValue{Value: fmt.Sprintf("writer%d", i)},
+			ExpectedVersion: &wrappers.UInt64Value{Value: 0},
+		})
+		return err
+	})
+	assert.Equal(t, 1, numSucceeded)
+	assert.Equal(t, numWriters-1, numMismatched)
+
+	numSucceeded, numMismatched = runConcurrently(func(store storage.ConfiguratorStorage, i int) error {
+		return store.UpdateNetworks([]storage.NetworkUpdateCriteria{{
+			ID:              "n1",
+			NewName:         &wrappers.StringValue{Value: fmt.Sprintf("writer%d", i)},
+			ExpectedVersion: &wrappers.UInt64Value{Value: 0},
+		}})
+	})
+	assert.Equal(t, 1, numSucceeded)
+	assert.Equal(t, numWriters-1, numMismatched)
+
+	store, err = factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	loadedEnts, err := store.LoadEntities("n1", storage.EntityLoadFilter{}, storage.EntityLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Len(t, loadedEnts.Entities, 1)
+	assert.Equal(t, uint64(1), loadedEnts.Entities[0].Version)
+	loadedNetworks, err := store.LoadNetworks(storage.NetworkLoadFilter{Ids: []string{"n1"}}, storage.NetworkLoadCriteria{})
+	assert.NoError(t, err)
+	assert.Len(t, loadedNetworks.Networks, 1)
+	assert.Equal(t, uint64(1), loadedNetworks.Networks[0].Version)
+	assert.NoError(t, store.Commit())
+}
+
+func TestSqlConfiguratorStorage_ConcurrentChanges(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	factory := storage.NewSQLConfiguratorStorageFactory(db, &mockIDGenerator{}, sqorc.GetSqlBuilder())
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+
+	const numWriters = 8
+	runConcurrently := func(write func(store storage.ConfiguratorStorage, i int) error) {
+		errs := make(chan error, numWriters)
+		wg := sync.WaitGroup{}
+		for i := 0; i < numWriters; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				store, err := factory.StartTransaction(context.Background(), nil)
+				if err != nil {
+					errs <- err
+					return
+				}
+				err = write(store, i)
+				if err != nil {
+					storage.RollbackLogOnError(store)
+					errs <- err
+					return
+				}
+				errs <- store.Commit()
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+	}
+	networkFilter := storage.ChangeLoadFilter{Kinds: []storage.Change_Kind{storage.Change_NETWORK}}
+
+	// Concurrent network creations all succeed and claim distinct, contiguous
+	// sequence numbers of the network change stream
+	runConcurrently(func(store storage.ConfiguratorStorage, i int) error {
+		_, err := store.CreateNetwork(storage.Network{ID: fmt.Sprintf("n%d", i)})
+		return err
+	})
+	store, err := factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	changes, err := store.LoadChanges(networkFilter)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+	assert.Len(t, changes, numWriters)
+	for i, change := range changes {
+		assert.Equal(t, uint64(i+1), change.Sequence)
+	}
+
+	// Concurrent entity writers to different networks claim sequence numbers
+	// from their own network's counter, so they don't lock the same row and
+	// don't wait for each other on databases with row-level locking. SQLite
+	// serializes all writes regardless, so this checks that the writers
+	// don't share a counter.
+	runConcurrently(func(store storage.ConfiguratorStorage, i int) error {
+		networkID := fmt.Sprintf("n%d", i)
+		for j := 0; j < 2; j++ {
+			_, err := store.CreateEntity(networkID, storage.NetworkEntity{Type: "foo", Key: fmt.Sprintf("%s_%d", networkID, j)})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	store, err = factory.StartTransaction(context.Background(), &orc8rStorage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	for i := 0; i < numWriters; i++ {
+		networkID := fmt.Sprintf("n%d", i)
+		changes, err = store.LoadChanges(storage.ChangeLoadFilter{
+			NetworkID: &wrappers.StringValue{Value: networkID},
+			Kinds:     []storage.Change_Kind{storage.Change_ENTITY},
+		})
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			[]*storage.Change{
+				{Sequence: 1, NetworkID: networkID, Kind: storage.Change_ENTITY, Operation: storage.Change_CREATE, Type: "foo", Key: networkID + "_0"},
+				{Sequence: 2, NetworkID: networkID, Kind: storage.Change_ENTITY, Operation: storage.Change_CREATE, Type: "foo", Key: networkID + "_1"},
+			},
+			changes,
+		)
+	}
+	// Entity writes don't touch the network change stream
+	latestSeq, err := store.GetLatestChangeSequence(networkFilter)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(numWriters), latestSeq)
+	assert.NoError(t, store.Commit())
+
+	// Re-initializing the storage doesn't reset the sequence
+	err = factory.InitializeServiceStorage()
+	assert.NoError(t, err)
+	store, err = factory.StartTransaction(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = store.CreateNetwork(storage.Network{ID: "n_last"})
+	assert.NoError(t, err)
+	latestSeq, err = store.GetLatestChangeSequence(networkFilter)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(numWriters+1), latestSeq)
+	assert.NoError(t, store.Commit())
+}
@@ -146,6 +146,72 @@ func validateNetworkUpdates(updates []NetworkUpdateCriteria) error {
 	return nil
 }
 
+// deleteVersionedNetworks deletes the networks of all deletions which specify
+// an expected version, if the network is still at that version. It returns
+// ErrVersionMismatch otherwise. Networks which don't exist are ignored.
+func (store *sqlConfiguratorStorage) deleteVersionedNetworks(updates []NetworkUpdateCriteria) error {
+	for _, update := range updates {
+		if !update.DeleteNetwork || update.ExpectedVersion == nil {
+			continue
+		}
+		// Cascading FK relations in the schema will handle network configs
+		res, err := store.builder.Delete(networksTable).
+			Where(sq.Eq{nwIDCol: update.ID, nwVerCol: update.ExpectedVersion.Value}).
+			RunWith(store.tx).
+			Exec()
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete network %s", update.ID)
+		}
+		err = store.checkVersionedNetworkWrite(update.ID, res)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadEntityDeleteChanges returns a delete change for each entity of the
+// given networks.
+func (store *sqlConfiguratorStorage) loadEntityDeleteChanges(networkIDs []string) ([]*Change, error) {
+	rows, err := store.builder.Select(entNidCol, entTypeCol, entKeyCol).
+		From(entityTable).
+		Where(sq.Eq{entNidCol: networkIDs}).
+		OrderBy(entNidCol, entTypeCol, entKeyCol).
+		RunWith(store.tx).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load entities of deleted networks")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "UpdateNetworks")
+
+	ret := []*Change{}
+	for rows.Next() {
+		var networkID, entType, entKey string
+		if err := rows.Scan(&networkID, &entType, &entKey); err != nil {
+			return nil, errors.Wrap(err, "failed to scan entity of deleted network")
+		}
+		ret = append(ret, newEntityChange(networkID, entType, entKey, Change_DELETE))
+	}
+	return ret, nil
+}
+
+// checkVersionedNetworkWrite returns ErrVersionMismatch if a write to an
+// existing network conditioned on its version didn't affect the network.
+func (store *sqlConfiguratorStorage) checkVersionedNetworkWrite(networkID string, res sql.Result) error {
+	err := checkVersionedWrite(res)
+	if err != ErrVersionMismatch {
+		return err
+	}
+	exists, err := store.doesNetworkExist(networkID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
 func (store *sqlConfiguratorStorage) updateNetwork(update NetworkUpdateCriteria, stmtCache *sq.StmtCache) error {
 	// Update the network table first
 	updateBuilder := store.builder.Update(networksTable).Where(sq.Eq{nwIDCol: update.ID})
@@ -159,10 +225,19 @@ func (store *sqlConfiguratorStorage) updateNetwork(update NetworkUpdateCriteria,
 		updateBuilder = updateBuilder.Set(nwTypeCol, stringPtrToVal(update.NewType))
 	}
 	updateBuilder = updateBuilder.Set(nwVerCol, sq.Expr(fmt.Sprintf("%s.%s+1", networksTable, nwVerCol)))
-	_, err := updateBuilder.RunWith(stmtCache).Exec()
+	if update.ExpectedVersion != nil {
+		updateBuilder = updateBuilder.Where(sq.Eq{nwVerCol: update.ExpectedVersion.Value})
+	}
+	res, err := updateBuilder.RunWith(stmtCache).Exec()
 	if err != nil {
 		return errors.Wrapf(err, "error updating network %s", update.ID)
 	}
+	if update.ExpectedVersion != nil {
+		err = store.checkVersionedNetworkWrite(update.ID, res)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Sort config keys for deterministic behavior on upserts
 	configUpdateTypes := funk.Keys(update.ConfigsToAddOrUpdate).([]string)
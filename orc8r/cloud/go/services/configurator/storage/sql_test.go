@@ -11,9 +11,12 @@ package storage_test
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"testing"
 
@@ -21,6 +24,7 @@ import (
 	"magma/orc8r/cloud/go/sqorc"
 	storage2 "magma/orc8r/cloud/go/storage"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
 	"github.com/thoas/go-funk"
@@ -205,6 +209,8 @@ func TestSqlConfiguratorStorage_CreateNetwork(t *testing.T) {
 			m.ExpectExec("INSERT INTO cfg_networks").
 				WithArgs("n1", "", "", "").
 				WillReturnResult(mockResult)
+
+			expectChangesRecorded(m, 0, networkChange("n1", storage.Change_CREATE))
 		},
 		run: runFactory(storage.Network{ID: "n1"}),
 
@@ -220,6 +226,8 @@ func TestSqlConfiguratorStorage_CreateNetwork(t *testing.T) {
 			m.ExpectExec("INSERT INTO cfg_networks").
 				WithArgs("n2", "", "hello", "world").
 				WillReturnResult(mockResult)
+
+			expectChangesRecorded(m, 0, networkChange("n2", storage.Change_CREATE))
 		},
 		run: runFactory(storage.Network{ID: "n2", Name: "hello", Description: "world"}),
 
@@ -252,6 +260,10 @@ func TestSqlConfiguratorStorage_CreateNetwork(t *testing.T) {
 					"n3", "foo", []byte("bar"),
 				).
 				WillReturnResult(mockResult)
+
+			expectConfigRevisionRecorded(m, 0, networkConfigRevision("n3", "baz", []byte("quz")))
+			expectConfigRevisionRecorded(m, 0, networkConfigRevision("n3", "foo", []byte("bar")))
+			expectChangesRecorded(m, 41, networkChange("n3", storage.Change_CREATE))
 		},
 		run: runFactory(everythingNw),
 
@@ -350,14 +362,39 @@ func TestSqlConfiguratorStorage_UpdateNetworks(t *testing.T) {
 			upsertStmt.ExpectExec().WithArgs("n3", "baz", []byte("quz"), []byte("quz")).WillReturnResult(mockResult)
 			upsertStmt.ExpectExec().WithArgs("n3", "foo", []byte("bar"), []byte("bar")).WillReturnResult(mockResult)
 			m.ExpectExec("DELETE FROM cfg_network_configs").WithArgs("n3", "hello", "n3", "world").WillReturnResult(mockResult)
+			expectConfigRevisionRecorded(m, 0, networkConfigRevision("n3", "baz", []byte("quz")))
+			expectConfigRevisionRecorded(m, 2, networkConfigRevision("n3", "foo", []byte("bar")))
+			expectConfigRevisionRecorded(m, 1, networkConfigRevision("n3", "hello", nil))
+			expectConfigRevisionRecorded(m, 1, networkConfigRevision("n3", "world", nil))
 
 			prepWithNameAndDesc.ExpectExec().WithArgs(names[2], "", "n4").WillReturnResult(mockResult)
 			upsertStmt.ExpectExec().WithArgs("n4", "baz", []byte("quz"), []byte("quz")).WillReturnResult(mockResult)
 			upsertStmt.ExpectExec().WithArgs("n4", "foo", []byte("bar"), []byte("bar")).WillReturnResult(mockResult)
 			m.ExpectExec("DELETE FROM cfg_network_configs").WithArgs("n4", "hello", "n4", "world").WillReturnResult(mockResult)
+			expectConfigRevisionRecorded(m, 0, networkConfigRevision("n4", "baz", []byte("quz")))
+			expectConfigRevisionRecorded(m, 0, networkConfigRevision("n4", "foo", []byte("bar")))
+			expectConfigRevisionRecorded(m, 3, networkConfigRevision("n4", "hello", nil))
+			expectConfigRevisionRecorded(m, 3, networkConfigRevision("n4", "world", nil))
 
+			// entities of deleted networks are logged as deleted
+			m.ExpectQuery("SELECT network_id, type, \"key\" FROM cfg_entities").WithArgs("n1").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"network_id", "type", "key"}).
+						AddRow("n1", "foo", "bar").
+						AddRow("n1", "foo", "baz"),
+				)
 			m.ExpectExec("DELETE FROM cfg_network_configs").WithArgs("n1").WillReturnResult(mockResult)
 			m.ExpectExec("DELETE FROM cfg_networks").WithArgs("n1").WillReturnResult(mockResult)
+
+			expectChangesRecorded(
+				m, 0,
+				&storage.Change{NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_DELETE, Type: "foo", Key: "bar"},
+				&storage.Change{NetworkID: "n1", Kind: storage.Change_ENTITY, Operation: storage.Change_DELETE, Type: "foo", Key: "baz"},
+				networkChange("n1", storage.Change_DELETE),
+				networkChange("n2", storage.Change_UPDATE),
+				networkChange("n3", storage.Change_UPDATE),
+				networkChange("n4", storage.Change_UPDATE),
+			)
 		},
 		run: runFactory(
 			[]storage.NetworkUpdateCriteria{
@@ -413,9 +450,51 @@ func TestSqlConfiguratorStorage_UpdateNetworks(t *testing.T) {
 		expectedError: errors.New("multiple updates for a single network are not allowed"),
 	}
 
+	// Versioned writes which don't affect an existing network fail
+	versionMismatch := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			updateStmt := m.ExpectPrepare("UPDATE cfg_networks").WillBeClosed()
+			updateStmt.ExpectExec().WithArgs("name2", "n2", 4).WillReturnResult(sqlmock.NewResult(1, 0))
+			m.ExpectQuery("SELECT COUNT\\(1\\) FROM cfg_networks").WithArgs("n2").
+				WillReturnRows(sqlmock.NewRows([]string{"COUNT(1)"}).AddRow(1))
+		},
+		run: runFactory(
+			[]storage.NetworkUpdateCriteria{
+				{ID: "n1", DeleteNetwork: true, ExpectedVersion: &wrappers.UInt64Value{Value: 3}},
+				{ID: "n2", NewName: &wrappers.StringValue{Value: names[1]}, ExpectedVersion: &wrappers.UInt64Value{Value: 4}},
+			},
+		),
+
+		expectedError:      storage.ErrVersionMismatch,
+		matchErrorInstance: true,
+	}
+
 	runCase(t, happyPath)
 	runCase(t, errorCase)
 	runCase(t, validationFailure)
+	runCase(t, versionMismatch)
+
+	// Versioned deletes of networks which don't exist are ignored
+	versionedDelete := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT network_id, type, \"key\" FROM cfg_entities").WithArgs("n1", "n2").
+				WillReturnRows(sqlmock.NewRows([]string{"network_id", "type", "key"}))
+			m.ExpectExec("DELETE FROM cfg_networks").WithArgs("n1", 3).WillReturnResult(sqlmock.NewResult(1, 1))
+			m.ExpectExec("DELETE FROM cfg_networks").WithArgs("n2", 5).WillReturnResult(sqlmock.NewResult(1, 0))
+			m.ExpectQuery("SELECT COUNT\\(1\\) FROM cfg_networks").WithArgs("n2").
+				WillReturnRows(sqlmock.NewRows([]string{"COUNT(1)"}).AddRow(0))
+			m.ExpectExec("DELETE FROM cfg_network_configs").WithArgs("n1", "n2").WillReturnResult(mockResult)
+			m.ExpectExec("DELETE FROM cfg_networks").WithArgs("n1", "n2").WillReturnResult(mockResult)
+			expectChangesRecorded(m, 0, networkChange("n1", storage.Change_DELETE), networkChange("n2", storage.Change_DELETE))
+		},
+		run: runFactory(
+			[]storage.NetworkUpdateCriteria{
+				{ID: "n1", DeleteNetwork: true, ExpectedVersion: &wrappers.UInt64Value{Value: 3}},
+				{ID: "n2", DeleteNetwork: true, ExpectedVersion: &wrappers.UInt64Value{Value: 5}},
+			},
+		),
+	}
+	runCase(t, versionedDelete)
 }
 
 func TestSqlConfiguratorStorage_LoadEntities(t *testing.T) {
@@ -664,6 +743,13 @@ func TestSqlConfiguratorStorage_LoadEntities(t *testing.T) {
 						AddRow("network", "foobar", "bar", "foo", nil, 1, "42", "foobar", "foobar ent", []byte("foobar"), "foobar_acl_2", "n4", storage.ACL_READ, "baz", nil, 2).
 						AddRow("network", "foobaz", "baz", "foo", nil, 2, "42", "foobaz", "foobaz ent", []byte("foobaz"), "foobaz_acl_1", "WILDCARD_ALL", storage.ACL_WRITE, "WILDCARD_ALL", nil, 3),
 				)
+			m.ExpectQuery("SELECT entity_pk, \"key\", value FROM cfg_entity_labels").
+				WithArgs("foobar", "foobaz").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"entity_pk", "key", "value"}).
+						AddRow("foobar", "env", "prod").
+						AddRow("foobar", "region", "west"),
+				)
 
 			expectAssocQuery(
 				m,
@@ -699,6 +785,7 @@ func TestSqlConfiguratorStorage_LoadEntities(t *testing.T) {
 					Name:        "foobar",
 					Description: "foobar ent",
 					Config:      []byte("foobar"),
+					Labels:      map[string]string{"env": "prod", "region": "west"},
 					Permissions: []*storage.ACL{
 						{
 							ID:         "foobar_acl_1",
@@ -802,6 +889,124 @@ func TestSqlConfiguratorStorage_LoadEntities(t *testing.T) {
 		},
 	}
 
+	// Paginated load with type filter
+	paginated := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT ent.network_id, ent.pk, ent.\"key\", ent.type, ent.physical_id, ent.version, ent.graph_id FROM cfg_entities AS ent "+
+				"WHERE \\(ent.network_id = \\$1 AND ent.type = \\$2\\) AND \\(ent.type > \\$3 OR \\(ent.type = \\$4 AND ent.\"key\" > \\$5\\)\\) "+
+				"ORDER BY ent.type, ent.\"key\" LIMIT 2").
+				WithArgs("network", "foo", "foo", "foo", "a").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"network_id", "pk", "key", "type", "physical_id", "version", "graph_id"}).
+						AddRow("network", "abc", "b", "foo", nil, 2, "42").
+						AddRow("network", "def", "c", "foo", nil, 1, "42"),
+				)
+		},
+		run: runFactory(
+			"network",
+			storage.EntityLoadFilter{TypeFilter: stringPointer("foo")},
+			storage.EntityLoadCriteria{PageSize: 2, PageToken: entityPageToken(t, "foo", "a")},
+		),
+
+		expectedResult: storage.EntityLoadResult{
+			Entities: []*storage.NetworkEntity{
+				{NetworkID: "network", Type: "foo", Key: "b", GraphID: "42", Version: 2},
+				{NetworkID: "network", Type: "foo", Key: "c", GraphID: "42", Version: 1},
+			},
+			EntitiesNotFound: []*storage.EntityID{},
+			NextPageToken:    entityPageToken(t, "foo", "c"),
+		},
+	}
+
+	// Last page isn't full
+	lastPage := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT ent.network_id, ent.pk, ent.\"key\", ent.type, ent.physical_id, ent.version, ent.graph_id FROM cfg_entities AS ent "+
+				"WHERE \\(ent.network_id = \\$1 AND ent.type = \\$2\\) AND \\(ent.type > \\$3 OR \\(ent.type = \\$4 AND ent.\"key\" > \\$5\\)\\) "+
+				"ORDER BY ent.type, ent.\"key\" LIMIT 2").
+				WithArgs("network", "foo", "foo", "foo", "c").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"network_id", "pk", "key", "type", "physical_id", "version", "graph_id"}).
+						AddRow("network", "ghi", "d", "foo", nil, 1, "42"),
+				)
+		},
+		run: runFactory(
+			"network",
+			storage.EntityLoadFilter{TypeFilter: stringPointer("foo")},
+			storage.EntityLoadCriteria{PageSize: 2, PageToken: entityPageToken(t, "foo", "c")},
+		),
+
+		expectedResult: storage.EntityLoadResult{
+			Entities: []*storage.NetworkEntity{
+				{NetworkID: "network", Type: "foo", Key: "d", GraphID: "42", Version: 1},
+			},
+			EntitiesNotFound: []*storage.EntityID{},
+		},
+	}
+
+	// Pagination isn't supported when loading by IDs
+	paginatedIDs := &testCase{
+		setup: func(m sqlmock.Sqlmock) {},
+		run: runFactory(
+			"network",
+			storage.EntityLoadFilter{IDs: []*storage.EntityID{{Type: "foo", Key: "bar"}}},
+			storage.EntityLoadCriteria{PageSize: 2},
+		),
+		expectedError: errors.New("pagination is not supported when loading specific IDs, physical IDs, or permissions"),
+	}
+
+	// Malformed page token
+	badToken := &testCase{
+		setup: func(m sqlmock.Sqlmock) {},
+		run: runFactory(
+			"network",
+			storage.EntityLoadFilter{TypeFilter: stringPointer("foo")},
+			storage.EntityLoadCriteria{PageSize: 2, PageToken: "???"},
+		),
+		expectedError: errors.New("invalid page token"),
+	}
+
+	// Label selector with label load
+	labelSelector := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			m.ExpectQuery("SELECT ent.network_id, ent.pk, ent.\"key\", ent.type, ent.physical_id, ent.version, ent.graph_id FROM cfg_entities AS ent "+
+				"WHERE \\(ent.network_id = \\$1 AND ent.type = \\$2\\) AND "+
+				"\\(ent.pk IN \\(SELECT entity_pk FROM cfg_entity_labels WHERE \"key\" = \\$3 AND value = \\$4\\) AND "+
+				"ent.pk IN \\(SELECT entity_pk FROM cfg_entity_labels WHERE \"key\" = \\$5 AND value = \\$6\\)\\)").
+				WithArgs("network", "foo", "env", "prod", "region", "west").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"network_id", "pk", "key", "type", "physical_id", "version", "graph_id"}).
+						AddRow("network", "abc", "bar", "foo", nil, 1, "42"),
+				)
+			m.ExpectQuery("SELECT entity_pk, \"key\", value FROM cfg_entity_labels WHERE entity_pk IN \\(\\$1\\)").
+				WithArgs("abc").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"entity_pk", "key", "value"}).
+						AddRow("abc", "env", "prod").
+						AddRow("abc", "region", "west").
+						AddRow("abc", "tier", "gold"),
+				)
+		},
+		run: runFactory(
+			"network",
+			storage.EntityLoadFilter{
+				TypeFilter:    stringPointer("foo"),
+				LabelSelector: map[string]string{"region": "west", "env": "prod"},
+			},
+			storage.EntityLoadCriteria{LoadLabels: true},
+		),
+
+		expectedResult: storage.EntityLoadResult{
+			Entities: []*storage.NetworkEntity{
+				{
+					NetworkID: "network", Type: "foo", Key: "bar", GraphID: "42", Version: 1,
+					Labels: map[string]string{"env": "prod", "region": "west", "tier": "gold"},
+				},
+			},
+			EntitiesNotFound: []*storage.EntityID{},
+		},
+	}
+
 	runCase(t, basicOnly)
 	runCase(t, loadEverything)
 	runCase(t, assocsTo)
@@ -809,6 +1014,17 @@ func TestSqlConfiguratorStorage_LoadEntities(t *testing.T) {
 	runCase(t, fullLoadTypeFilter)
 	runCase(t, typeAndKeyFilters)
 	runCase(t, physicalID)
+	runCase(t, paginated)
+	runCase(t, lastPage)
+	runCase(t, paginatedIDs)
+	runCase(t, badToken)
+	runCase(t, labelSelector)
+}
+
+func entityPageToken(t *testing.T, entType string, key string) string {
+	marshaled, err := proto.Marshal(&storage.EntityID{Type: entType, Key: key})
+	assert.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(marshaled)
 }
 
 func TestSqlConfiguratorStorage_CreateEntity(t *testing.T) {
@@ -828,6 +1044,8 @@ func TestSqlConfiguratorStorage_CreateEntity(t *testing.T) {
 			m.ExpectExec("INSERT INTO cfg_entities").
 				WithArgs("1", "network", "foo", "bar", "2", "foobar", "foobar ent", nil, nil).
 				WillReturnResult(mockResult)
+
+			expectChangesRecorded(m, 0, entityChange("foo", "bar", storage.Change_CREATE))
 		},
 		run: runFactory(
 			"network",
@@ -876,6 +1094,7 @@ func TestSqlConfiguratorStorage_CreateEntity(t *testing.T) {
 				WillReturnResult(mockResult)
 
 			expectPermissionCreation(m, "1", 3, perms...)
+			expectChangesRecorded(m, 0, entityChange("foo", "bar", storage.Change_CREATE))
 		},
 		run: runFactory(
 			"network",
@@ -932,6 +1151,7 @@ func TestSqlConfiguratorStorage_CreateEntity(t *testing.T) {
 			expectEdgeQueries(m, assocs, edgesByTk)
 			expectEdgeInsertions(m, assocsToEdges("1", assocs, edgesByTk))
 			expectMergeGraphs(m, [][2]string{{"2", "1"}, {"3", "1"}})
+			expectChangesRecorded(m, 0, entityChange("foo", "bar", storage.Change_CREATE))
 		},
 		run: runFactory(
 			"network",
@@ -1005,6 +1225,7 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 			expectBasicEntityQueries(m, expectedFooBarQuery)
 			m.ExpectExec("DELETE FROM cfg_entities").WithArgs("network", "foo", "bar").WillReturnResult(mockResult)
 			expectBulkEntityQuery(m, []driver.Value{"g1"})
+			expectChangesRecorded(m, 0, entityChange("foo", "bar", storage.Change_DELETE))
 		},
 		run: runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", DeleteEntity: true}),
 
@@ -1037,12 +1258,52 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 			)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("1", "barfoo").WillReturnResult(mockResult)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("2", "bazbar").WillReturnResult(mockResult)
+			expectChangesRecorded(m, 0, entityChange("foo", "bar", storage.Change_DELETE))
 		},
 		run:            runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", DeleteEntity: true}),
 		expectedResult: storage.NetworkEntity{Type: "foo", Key: "bar"},
 	}
 	runCase(t, deleteWithPartition)
 
+	// Expected version doesn't match
+	versionMismatchCase := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			expectBasicEntityQueries(m, expectedEntQueryResult{"foo", "bar", "1", "", "g1", 2})
+		},
+		run: runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", DeleteEntity: true, ExpectedVersion: &wrappers.UInt64Value{Value: 1}}),
+
+		expectedError:      storage.ErrVersionMismatch,
+		matchErrorInstance: true,
+	}
+	runCase(t, versionMismatchCase)
+
+	// Expected version matches
+	versionMatchCase := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			expectBasicEntityQueries(m, expectedEntQueryResult{"foo", "bar", "1", "", "g1", 2})
+			m.ExpectExec("DELETE FROM cfg_entities").WithArgs("network", "foo", "bar", 2).WillReturnResult(mockResult)
+			expectBulkEntityQuery(m, []driver.Value{"g1"})
+			expectChangesRecorded(m, 0, entityChange("foo", "bar", storage.Change_DELETE))
+		},
+		run: runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", DeleteEntity: true, ExpectedVersion: &wrappers.UInt64Value{Value: 2}}),
+
+		expectedResult: storage.NetworkEntity{Type: "foo", Key: "bar"},
+	}
+	runCase(t, versionMatchCase)
+
+	// Entity was updated concurrently after it was loaded
+	concurrentUpdateCase := &testCase{
+		setup: func(m sqlmock.Sqlmock) {
+			expectBasicEntityQueries(m, expectedEntQueryResult{"foo", "bar", "1", "", "g1", 2})
+			m.ExpectExec("UPDATE cfg_entities").WithArgs("foobar", "1", 2).WillReturnResult(sqlmock.NewResult(1, 0))
+		},
+		run: runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", NewName: stringPointer("foobar"), ExpectedVersion: &wrappers.UInt64Value{Value: 2}}),
+
+		expectedError:      storage.ErrVersionMismatch,
+		matchErrorInstance: true,
+	}
+	runCase(t, concurrentUpdateCase)
+
 	// Test some permutations of updating basic fields
 	runCase(
 		t,
@@ -1309,6 +1570,7 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 			)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("1", "quzbaz").WillReturnResult(mockResult)
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("2", "barfoo", "bazbar").WillReturnResult(mockResult)
+			expectChangesRecorded(m, 0, entityChange("baz", "quz", storage.Change_UPDATE))
 		},
 		run:            runFactory("network", storage.EntityUpdateCriteria{Type: "baz", Key: "quz", AssociationsToDelete: []*storage.EntityID{{Type: "quz", Key: "baz"}, {Type: "baz", Key: "bar"}}}),
 		expectedResult: storage.NetworkEntity{NetworkID: "network", Type: "baz", Key: "quz", GraphID: "g1", Version: 1},
@@ -1333,6 +1595,7 @@ func TestSqlConfiguratorStorage_UpdateEntity(t *testing.T) {
 
 			// Graph partition update
 			m.ExpectExec("UPDATE cfg_entities").WithArgs("1", "barbaz").WillReturnResult(mockResult)
+			expectChangesRecorded(m, 0, entityChange("foo", "bar", storage.Change_UPDATE))
 		},
 		run:            runFactory("network", storage.EntityUpdateCriteria{Type: "foo", Key: "bar", AssociationsToSet: &storage.EntityAssociationsToSet{}}),
 		expectedResult: storage.NetworkEntity{NetworkID: "network", Type: "foo", Key: "bar", GraphID: "g1", Version: 1},
@@ -1646,6 +1909,10 @@ func getTestCaseForEntityUpdate(
 		setup: func(m sqlmock.Sqlmock) {
 			// Basic fields
 			expectBasicEntityQueries(m, entToUpdate)
+			if update.NewConfig != nil && update.LabelsToSet == nil {
+				m.ExpectQuery("SELECT .* FROM cfg_entity_labels").WithArgs(entToUpdate.pk).
+					WillReturnRows(sqlmock.NewRows([]string{"entity_pk", "key", "value"}))
+			}
 			updateWithArgs := []driver.Value{}
 			if update.NewName != nil {
 				updateWithArgs = append(updateWithArgs, update.NewName.Value)
@@ -1709,6 +1976,11 @@ func getTestCaseForEntityUpdate(
 				expectBulkEntityQuery(m, []driver.Value{entToUpdate.graphID}, entToUpdate)
 				expectAssocQuery(m, []driver.Value{entToUpdate.pk, entToUpdate.pk})
 			}
+
+			if update.NewConfig != nil {
+				expectConfigRevisionRecorded(m, 0, entityConfigRevision(entToUpdate.entType, entToUpdate.key, update.NewConfig.Value))
+			}
+			expectChangesRecorded(m, 0, entityChange(entToUpdate.entType, entToUpdate.key, storage.Change_UPDATE))
 		},
 		run: func(store storage.ConfiguratorStorage) (interface{}, error) {
 			return store.UpdateEntity("network", update)
@@ -1820,6 +2092,81 @@ type expectedACLInsert struct {
 	id, entPk, scope, perm, aclType, filter driver.Value
 }
 
+// expectChangesRecorded expects changes to be recorded as the changes
+// following maxSeq in each of their streams.
+func expectChangesRecorded(m sqlmock.Sqlmock, maxSeq int64, changes ...*storage.Change) {
+	streams := []string{}
+	numChangesByStream := map[string]int64{}
+	for _, change := range changes {
+		stream := getChangeStream(change)
+		if _, exists := numChangesByStream[stream]; !exists {
+			streams = append(streams, stream)
+		}
+		numChangesByStream[stream]++
+	}
+	sort.Strings(streams)
+	for _, stream := range streams {
+		m.ExpectExec("INSERT INTO cfg_change_seq").WithArgs(stream, numChangesByStream[stream], 0).WillReturnResult(mockResult)
+		m.ExpectQuery("SELECT seq FROM cfg_change_seq").WithArgs(stream).
+			WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(maxSeq + numChangesByStream[stream]))
+	}
+
+	args := make([]driver.Value, 0, len(changes)*8)
+	seqsByStream := map[string]int64{}
+	for _, change := range changes {
+		stream := getChangeStream(change)
+		seqsByStream[stream]++
+		args = append(args, stream, maxSeq+seqsByStream[stream], change.NetworkID, int64(change.Kind), int64(change.Operation), change.Type, change.Key, sqlmock.AnyArg())
+	}
+	m.ExpectExec("INSERT INTO cfg_changes").WithArgs(args...).WillReturnResult(mockResult)
+}
+
+// getChangeStream returns the change stream a change is sequenced in
+func getChangeStream(change *storage.Change) string {
+	if change.Kind == storage.Change_NETWORK {
+		return ""
+	}
+	return change.NetworkID
+}
+
+func networkChange(networkID string, op storage.Change_Operation) *storage.Change {
+	return &storage.Change{NetworkID: networkID, Kind: storage.Change_NETWORK, Operation: op}
+}
+
+func entityChange(entType string, entKey string, op storage.Change_Operation) *storage.Change {
+	return &storage.Change{NetworkID: "network", Kind: storage.Change_ENTITY, Operation: op, Type: entType, Key: entKey}
+}
+
+// expectConfigRevisionRecorded expects rev to be recorded as the revision
+// following maxRev.
+func expectConfigRevisionRecorded(m sqlmock.Sqlmock, maxRev int64, rev *storage.ConfigRevision) {
+	m.ExpectQuery(`SELECT MAX\(revision\) FROM cfg_config_history`).
+		WithArgs(rev.Key, int64(rev.Kind), rev.NetworkID, rev.Type).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(maxRev))
+	m.ExpectExec("INSERT INTO cfg_config_history").
+		WithArgs(rev.NetworkID, int64(rev.Kind), rev.Type, rev.Key, maxRev+1, rev.Value, rev.Deleted, marshalLabels(rev.Labels), "", sqlmock.AnyArg()).
+		WillReturnResult(mockResult)
+}
+
+func marshalLabels(labels map[string]string) []byte {
+	if len(labels) == 0 {
+		return nil
+	}
+	marshaled, _ := json.Marshal(labels)
+	return marshaled
+}
+
+func networkConfigRevision(networkID string, configType string, value []byte) *storage.ConfigRevision {
+	return &storage.ConfigRevision{NetworkID: networkID, Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: configType, Value: value, Deleted: value == nil}
+}
+
+func entityConfigRevision(entType string, entKey string, value []byte) *storage.ConfigRevision {
+	if len(value) == 0 {
+		value = nil
+	}
+	return &storage.ConfigRevision{NetworkID: "network", Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: entType, Key: entKey, Value: value, Deleted: value == nil}
+}
+
 func getExpectedACLInsert(entPk string, idOverride *int, perm *storage.ACL) expectedACLInsert {
 	var scope, typeVal, filter driver.Value
 
@@ -11,7 +11,9 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"magma/orc8r/cloud/go/storage"
 
@@ -87,6 +89,55 @@ type ConfiguratorStorage interface {
 	// entity. The load criteria fields on associations are ignored, and the
 	// returned entities will always have both association fields filled out.
 	LoadGraphForEntity(networkID string, entityID EntityID, loadCriteria EntityLoadCriteria) (EntityGraph, error)
+
+	// =======================================================================
+	// Change Log Operations
+	// =======================================================================
+
+	// LoadChanges returns the changes in the change log matching the provided
+	// filter, ordered by ascending sequence number. Every network and entity
+	// write records a change within the same transaction.
+	// Network changes are sequenced in one stream, and the entity changes of
+	// each network in another, so the filter has to select one of these
+	// streams. ErrInvalidChangeFilter is returned otherwise.
+	// If changes after the filter's cursor have been pruned, ErrCursorExpired
+	// is returned.
+	LoadChanges(filter ChangeLoadFilter) ([]*Change, error)
+
+	// GetLatestChangeSequence returns the sequence number of the latest
+	// change in the stream selected by the filter, or 0 if the stream is
+	// empty. The filter's cursor and other conditions are ignored.
+	GetLatestChangeSequence(filter ChangeLoadFilter) (uint64, error)
+
+	// PruneChanges deletes the changes recorded before the given time from
+	// the change log. Loading changes from a cursor before a pruned change
+	// fails with ErrCursorExpired.
+	PruneChanges(before time.Time) error
+
+	// =======================================================================
+	// Config History Operations
+	// =======================================================================
+
+	// LoadConfigHistory returns the revisions of a network or entity config
+	// matching the provided filter, newest first. Every write to a network
+	// config or entity config records a revision within the same
+	// transaction. Only the latest MaxConfigRevisions revisions of each
+	// config are kept.
+	LoadConfigHistory(filter ConfigHistoryFilter) ([]*ConfigRevision, error)
+}
+
+// WithAuthor returns a copy of ctx which attributes config writes made in
+// transactions started from it to the given author. The author is recorded
+// in config history.
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorContextKey{}, author)
+}
+
+type authorContextKey struct{}
+
+func getAuthor(ctx context.Context) string {
+	author, _ := ctx.Value(authorContextKey{}).(string)
+	return author
 }
 
 // RollbackLogOnError calls Rollback on the provided ConfiguratorStorage and
@@ -153,9 +204,31 @@ func (m NetworkEntity) GetGraphEdges() []*GraphEdge {
 // IsLoadAllEntities return true if the EntityLoadFilter is specifying to load
 // all entities in a network, false if there are any filter conditions.
 func (m *EntityLoadFilter) IsLoadAllEntities() bool {
-	return m.TypeFilter == nil && m.KeyFilter == nil && m.GraphID == nil && funk.IsEmpty(m.IDs)
+	return m.TypeFilter == nil && m.KeyFilter == nil && m.GraphID == nil && funk.IsEmpty(m.IDs) && len(m.LabelSelector) == 0
 }
 
+// ErrInvalidPageToken is returned by LoadEntities when the load criteria's
+// page token is malformed.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrPaginationNotSupported is returned by LoadEntities when a paginated load
+// requests specific IDs, a physical ID, or permissions.
+var ErrPaginationNotSupported = errors.New("pagination is not supported when loading specific IDs, physical IDs, or permissions")
+
+// ErrInvalidChangeFilter is returned by LoadChanges and
+// GetLatestChangeSequence when the filter doesn't select either network
+// changes or the entity changes of a single network.
+var ErrInvalidChangeFilter = errors.New("change filter must select either network changes or the entity changes of one network")
+
+// ErrCursorExpired is returned by LoadChanges when changes after the filter's
+// cursor have been pruned from the change log.
+var ErrCursorExpired = errors.New("cursor is older than the oldest retained change")
+
+// ErrVersionMismatch is returned by UpdateNetworks and UpdateEntity when an
+// update's expected version doesn't match the current version of the network
+// or entity being updated.
+var ErrVersionMismatch = errors.New("version mismatch")
+
 // FullEntityLoadCriteria is an EntityLoadCriteria which loads everything
 var FullEntityLoadCriteria = EntityLoadCriteria{
 	LoadMetadata:       true,
@@ -163,6 +236,7 @@ var FullEntityLoadCriteria = EntityLoadCriteria{
 	LoadAssocsToThis:   true,
 	LoadAssocsFromThis: true,
 	LoadPermissions:    true,
+	LoadLabels:         true,
 }
 
 func (m *EntityUpdateCriteria) GetID() *EntityID {
@@ -78,6 +78,84 @@ func (ACL_Wildcard) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{7, 1}
 }
 
+type Change_Kind int32
+
+const (
+	Change_NETWORK Change_Kind = 0
+	Change_ENTITY  Change_Kind = 1
+)
+
+var Change_Kind_name = map[int32]string{
+	0: "NETWORK",
+	1: "ENTITY",
+}
+
+var Change_Kind_value = map[string]int32{
+	"NETWORK": 0,
+	"ENTITY":  1,
+}
+
+func (x Change_Kind) String() string {
+	return proto.EnumName(Change_Kind_name, int32(x))
+}
+
+func (Change_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{16, 0}
+}
+
+type Change_Operation int32
+
+const (
+	Change_CREATE Change_Operation = 0
+	Change_UPDATE Change_Operation = 1
+	Change_DELETE Change_Operation = 2
+)
+
+var Change_Operation_name = map[int32]string{
+	0: "CREATE",
+	1: "UPDATE",
+	2: "DELETE",
+}
+
+var Change_Operation_value = map[string]int32{
+	"CREATE": 0,
+	"UPDATE": 1,
+	"DELETE": 2,
+}
+
+func (x Change_Operation) String() string {
+	return proto.EnumName(Change_Operation_name, int32(x))
+}
+
+func (Change_Operation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{16, 1}
+}
+
+type ConfigRevision_Kind int32
+
+const (
+	ConfigRevision_NETWORK_CONFIG ConfigRevision_Kind = 0
+	ConfigRevision_ENTITY_CONFIG  ConfigRevision_Kind = 1
+)
+
+var ConfigRevision_Kind_name = map[int32]string{
+	0: "NETWORK_CONFIG",
+	1: "ENTITY_CONFIG",
+}
+
+var ConfigRevision_Kind_value = map[string]int32{
+	"NETWORK_CONFIG": 0,
+	"ENTITY_CONFIG":  1,
+}
+
+func (x ConfigRevision_Kind) String() string {
+	return proto.EnumName(ConfigRevision_Kind_name, int32(x))
+}
+
+func (ConfigRevision_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{18, 0}
+}
+
 // A network represents a tenant. Networks can be configured in a hierarchical
 // manner - network-level configurations are assumed to apply across multiple
 // entities within the network.
@@ -317,6 +395,9 @@ type NetworkUpdateCriteria struct {
 	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	// Set DeleteNetwork to true to delete the network
 	DeleteNetwork bool `protobuf:"varint,10,opt,name=delete_network,json=deleteNetwork,proto3" json:"delete_network,omitempty"`
+	// If set, the update (or deletion) is only applied if the network's
+	// current version matches. ErrVersionMismatch is returned otherwise.
+	ExpectedVersion *wrappers.UInt64Value `protobuf:"bytes,11,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	// Set NewName, NewDescription, or NewType to nil to indicate that no update is
 	// desired. To clear the value of name or description, set these fields to
 	// a wrapper to an empty string.
@@ -371,6 +452,13 @@ func (m *NetworkUpdateCriteria) GetDeleteNetwork() bool {
 	return false
 }
 
+func (m *NetworkUpdateCriteria) GetExpectedVersion() *wrappers.UInt64Value {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
 func (m *NetworkUpdateCriteria) GetNewName() *wrappers.StringValue {
 	if m != nil {
 		return m.NewName
@@ -480,11 +568,14 @@ type NetworkEntity struct {
 	// creation.
 	ParentAssociations []*EntityID `protobuf:"bytes,51,rep,name=parent_associations,json=parentAssociations,proto3" json:"parent_associations,omitempty"`
 	// Permissions defines the access control for this entity.
-	Permissions          []*ACL   `protobuf:"bytes,60,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	Version              uint64   `protobuf:"varint,70,opt,name=version,proto3" json:"version,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Permissions []*ACL `protobuf:"bytes,60,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Version     uint64 `protobuf:"varint,70,opt,name=version,proto3" json:"version,omitempty"`
+	// Labels are arbitrary key-value pairs attached to the entity which can
+	// be used to select entities when loading.
+	Labels               map[string]string `protobuf:"bytes,80,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *NetworkEntity) Reset()         { *m = NetworkEntity{} }
@@ -596,6 +687,13 @@ func (m *NetworkEntity) GetVersion() uint64 {
 	return 0
 }
 
+func (m *NetworkEntity) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 // ACL (Access Control List) defines a specific permission for an entity on
 // access to other entities.
 type ACL struct {
@@ -816,10 +914,13 @@ type EntityLoadFilter struct {
 	GraphID *wrappers.StringValue `protobuf:"bytes,4,opt,name=graphID,proto3" json:"graphID,omitempty"`
 	// If PhysicalID is provided, the query will return all entities matching
 	// the provided ID. All other fields are ignored if this is set.
-	PhysicalID           *wrappers.StringValue `protobuf:"bytes,5,opt,name=physicalID,proto3" json:"physicalID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	PhysicalID *wrappers.StringValue `protobuf:"bytes,5,opt,name=physicalID,proto3" json:"physicalID,omitempty"`
+	// If LabelSelector is provided, the query will only return entities
+	// which have all of the given labels with matching values.
+	LabelSelector        map[string]string `protobuf:"bytes,6,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *EntityLoadFilter) Reset()         { *m = EntityLoadFilter{} }
@@ -882,14 +983,29 @@ func (m *EntityLoadFilter) GetPhysicalID() *wrappers.StringValue {
 	return nil
 }
 
+func (m *EntityLoadFilter) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
 // EntityLoadCriteria specifies how much of an entity to load
 type EntityLoadCriteria struct {
 	// Set LoadMetadata to true to load the metadata fields (name, description)
-	LoadMetadata         bool     `protobuf:"varint,1,opt,name=load_metadata,json=loadMetadata,proto3" json:"load_metadata,omitempty"`
-	LoadConfig           bool     `protobuf:"varint,2,opt,name=load_config,json=loadConfig,proto3" json:"load_config,omitempty"`
-	LoadAssocsToThis     bool     `protobuf:"varint,3,opt,name=load_assocs_to_this,json=loadAssocsToThis,proto3" json:"load_assocs_to_this,omitempty"`
-	LoadAssocsFromThis   bool     `protobuf:"varint,4,opt,name=load_assocs_from_this,json=loadAssocsFromThis,proto3" json:"load_assocs_from_this,omitempty"`
-	LoadPermissions      bool     `protobuf:"varint,5,opt,name=load_permissions,json=loadPermissions,proto3" json:"load_permissions,omitempty"`
+	LoadMetadata       bool `protobuf:"varint,1,opt,name=load_metadata,json=loadMetadata,proto3" json:"load_metadata,omitempty"`
+	LoadConfig         bool `protobuf:"varint,2,opt,name=load_config,json=loadConfig,proto3" json:"load_config,omitempty"`
+	LoadAssocsToThis   bool `protobuf:"varint,3,opt,name=load_assocs_to_this,json=loadAssocsToThis,proto3" json:"load_assocs_to_this,omitempty"`
+	LoadAssocsFromThis bool `protobuf:"varint,4,opt,name=load_assocs_from_this,json=loadAssocsFromThis,proto3" json:"load_assocs_from_this,omitempty"`
+	LoadPermissions    bool `protobuf:"varint,5,opt,name=load_permissions,json=loadPermissions,proto3" json:"load_permissions,omitempty"`
+	LoadLabels         bool `protobuf:"varint,6,opt,name=load_labels,json=loadLabels,proto3" json:"load_labels,omitempty"`
+	// Set page_size to a nonzero value to load at most that many entities,
+	// ordered by (type, key). Pagination is not supported when loading
+	// specific IDs, physical IDs, or permissions.
+	PageSize uint32 `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page's result. Leave
+	// empty to load the first page.
+	PageToken            string   `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -955,12 +1071,36 @@ func (m *EntityLoadCriteria) GetLoadPermissions() bool {
 	return false
 }
 
+func (m *EntityLoadCriteria) GetLoadLabels() bool {
+	if m != nil {
+		return m.LoadLabels
+	}
+	return false
+}
+
+func (m *EntityLoadCriteria) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *EntityLoadCriteria) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type EntityLoadResult struct {
-	Entities             []*NetworkEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
-	EntitiesNotFound     []*EntityID      `protobuf:"bytes,2,rep,name=entities_not_found,json=entitiesNotFound,proto3" json:"entities_not_found,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Entities         []*NetworkEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	EntitiesNotFound []*EntityID      `protobuf:"bytes,2,rep,name=entities_not_found,json=entitiesNotFound,proto3" json:"entities_not_found,omitempty"`
+	// Token to load the next page of entities with. Empty if the load wasn't
+	// paginated or if there are no more entities to load.
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EntityLoadResult) Reset()         { *m = EntityLoadResult{} }
@@ -1002,16 +1142,26 @@ func (m *EntityLoadResult) GetEntitiesNotFound() []*EntityID {
 	return nil
 }
 
+func (m *EntityLoadResult) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 // EntityUpdateCriteria specifies a patch operation on a network entity.
 type EntityUpdateCriteria struct {
 	// (Type, Key) of the entity to update
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Key  string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	// Set DeleteEntity to true to mark the entity for deletion
-	DeleteEntity   bool                  `protobuf:"varint,10,opt,name=delete_entity,json=deleteEntity,proto3" json:"delete_entity,omitempty"`
-	NewName        *wrappers.StringValue `protobuf:"bytes,20,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
-	NewDescription *wrappers.StringValue `protobuf:"bytes,21,opt,name=new_description,json=newDescription,proto3" json:"new_description,omitempty"`
-	NewPhysicalID  *wrappers.StringValue `protobuf:"bytes,22,opt,name=new_physicalID,json=newPhysicalID,proto3" json:"new_physicalID,omitempty"`
+	DeleteEntity bool `protobuf:"varint,10,opt,name=delete_entity,json=deleteEntity,proto3" json:"delete_entity,omitempty"`
+	// If set, the update (or deletion) is only applied if the entity's
+	// current version matches. ErrVersionMismatch is returned otherwise.
+	ExpectedVersion *wrappers.UInt64Value `protobuf:"bytes,11,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	NewName         *wrappers.StringValue `protobuf:"bytes,20,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	NewDescription  *wrappers.StringValue `protobuf:"bytes,21,opt,name=new_description,json=newDescription,proto3" json:"new_description,omitempty"`
+	NewPhysicalID   *wrappers.StringValue `protobuf:"bytes,22,opt,name=new_physicalID,json=newPhysicalID,proto3" json:"new_physicalID,omitempty"`
 	// A nil value here indicates no update.
 	NewConfig *wrappers.BytesValue `protobuf:"bytes,23,opt,name=new_config,json=newConfig,proto3" json:"new_config,omitempty"`
 	// Wrap the repeated field in a message because a nil struct and a struct
@@ -1020,12 +1170,16 @@ type EntityUpdateCriteria struct {
 	AssociationsToAdd    []*EntityID              `protobuf:"bytes,31,rep,name=associations_to_add,json=associationsToAdd,proto3" json:"associations_to_add,omitempty"`
 	AssociationsToDelete []*EntityID              `protobuf:"bytes,32,rep,name=associations_to_delete,json=associationsToDelete,proto3" json:"associations_to_delete,omitempty"`
 	// New ACLs to add. ACL IDs are ignored and generated by the system.
-	PermissionsToCreate  []*ACL   `protobuf:"bytes,40,rep,name=permissions_to_create,json=permissionsToCreate,proto3" json:"permissions_to_create,omitempty"`
-	PermissionsToUpdate  []*ACL   `protobuf:"bytes,41,rep,name=permissions_to_update,json=permissionsToUpdate,proto3" json:"permissions_to_update,omitempty"`
-	PermissionsToDelete  []string `protobuf:"bytes,42,rep,name=permissions_to_delete,json=permissionsToDelete,proto3" json:"permissions_to_delete,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PermissionsToCreate []*ACL   `protobuf:"bytes,40,rep,name=permissions_to_create,json=permissionsToCreate,proto3" json:"permissions_to_create,omitempty"`
+	PermissionsToUpdate []*ACL   `protobuf:"bytes,41,rep,name=permissions_to_update,json=permissionsToUpdate,proto3" json:"permissions_to_update,omitempty"`
+	PermissionsToDelete []string `protobuf:"bytes,42,rep,name=permissions_to_delete,json=permissionsToDelete,proto3" json:"permissions_to_delete,omitempty"`
+	// Wrap the labels in a message because a nil struct and a struct with an
+	// empty labels map mean different things. A non-nil value replaces all
+	// existing labels on the entity.
+	LabelsToSet          *EntityLabelsToSet `protobuf:"bytes,50,opt,name=labels_to_set,json=labelsToSet,proto3" json:"labels_to_set,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *EntityUpdateCriteria) Reset()         { *m = EntityUpdateCriteria{} }
@@ -1074,6 +1228,13 @@ func (m *EntityUpdateCriteria) GetDeleteEntity() bool {
 	return false
 }
 
+func (m *EntityUpdateCriteria) GetExpectedVersion() *wrappers.UInt64Value {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
 func (m *EntityUpdateCriteria) GetNewName() *wrappers.StringValue {
 	if m != nil {
 		return m.NewName
@@ -1144,6 +1305,13 @@ func (m *EntityUpdateCriteria) GetPermissionsToDelete() []string {
 	return nil
 }
 
+func (m *EntityUpdateCriteria) GetLabelsToSet() *EntityLabelsToSet {
+	if m != nil {
+		return m.LabelsToSet
+	}
+	return nil
+}
+
 type EntityAssociationsToSet struct {
 	AssociationsToSet    []*EntityID `protobuf:"bytes,1,rep,name=associations_to_set,json=associationsToSet,proto3" json:"associations_to_set,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -1183,6 +1351,45 @@ func (m *EntityAssociationsToSet) GetAssociationsToSet() []*EntityID {
 	return nil
 }
 
+type EntityLabelsToSet struct {
+	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *EntityLabelsToSet) Reset()         { *m = EntityLabelsToSet{} }
+func (m *EntityLabelsToSet) String() string { return proto.CompactTextString(m) }
+func (*EntityLabelsToSet) ProtoMessage()    {}
+func (*EntityLabelsToSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{13}
+}
+
+func (m *EntityLabelsToSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntityLabelsToSet.Unmarshal(m, b)
+}
+func (m *EntityLabelsToSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntityLabelsToSet.Marshal(b, m, deterministic)
+}
+func (m *EntityLabelsToSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntityLabelsToSet.Merge(m, src)
+}
+func (m *EntityLabelsToSet) XXX_Size() int {
+	return xxx_messageInfo_EntityLabelsToSet.Size(m)
+}
+func (m *EntityLabelsToSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntityLabelsToSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntityLabelsToSet proto.InternalMessageInfo
+
+func (m *EntityLabelsToSet) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 // EntityGraph represents a DAG of associated network entities.
 type EntityGraph struct {
 	// All nodes in the graph
@@ -1200,7 +1407,7 @@ func (m *EntityGraph) Reset()         { *m = EntityGraph{} }
 func (m *EntityGraph) String() string { return proto.CompactTextString(m) }
 func (*EntityGraph) ProtoMessage()    {}
 func (*EntityGraph) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{13}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{14}
 }
 
 func (m *EntityGraph) XXX_Unmarshal(b []byte) error {
@@ -1255,7 +1462,7 @@ func (m *GraphEdge) Reset()         { *m = GraphEdge{} }
 func (m *GraphEdge) String() string { return proto.CompactTextString(m) }
 func (*GraphEdge) ProtoMessage()    {}
 func (*GraphEdge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{14}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{15}
 }
 
 func (m *GraphEdge) XXX_Unmarshal(b []byte) error {
@@ -1290,9 +1497,378 @@ func (m *GraphEdge) GetFrom() *EntityID {
 	return nil
 }
 
+// Change is a single record in the configurator change log. A change is
+// written in the same transaction as the network or entity write it
+// describes, so tailing the log by sequence number observes every committed
+// write in order.
+type Change struct {
+	// Sequence is a system-generated, monotonically increasing cursor into
+	// the change log. Network changes are sequenced in one stream, and the
+	// entity changes of each network in another, so sequences are only
+	// comparable within a stream.
+	Sequence  uint64           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	NetworkID string           `protobuf:"bytes,2,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Kind      Change_Kind      `protobuf:"varint,3,opt,name=kind,proto3,enum=magma.orc8r.configurator.storage.Change_Kind" json:"kind,omitempty"`
+	Operation Change_Operation `protobuf:"varint,4,opt,name=operation,proto3,enum=magma.orc8r.configurator.storage.Change_Operation" json:"operation,omitempty"`
+	// (Type, Key) of the changed entity. These are empty for network changes.
+	Type                 string   `protobuf:"bytes,10,opt,name=type,proto3" json:"type,omitempty"`
+	Key                  string   `protobuf:"bytes,11,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Change) Reset()         { *m = Change{} }
+func (m *Change) String() string { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()    {}
+func (*Change) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{16}
+}
+
+func (m *Change) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Change.Unmarshal(m, b)
+}
+func (m *Change) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Change.Marshal(b, m, deterministic)
+}
+func (m *Change) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Change.Merge(m, src)
+}
+func (m *Change) XXX_Size() int {
+	return xxx_messageInfo_Change.Size(m)
+}
+func (m *Change) XXX_DiscardUnknown() {
+	xxx_messageInfo_Change.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Change proto.InternalMessageInfo
+
+func (m *Change) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Change) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *Change) GetKind() Change_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return Change_NETWORK
+}
+
+func (m *Change) GetOperation() Change_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return Change_CREATE
+}
+
+func (m *Change) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Change) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+// ChangeLoadFilter specifies which changes to load from the change log
+type ChangeLoadFilter struct {
+	// Only changes with a sequence strictly greater than AfterSequence will be
+	// loaded.
+	AfterSequence uint64 `protobuf:"varint,1,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	// If NetworkID is provided, the query will only return changes within
+	// the given network. It is required when loading entity changes.
+	NetworkID *wrappers.StringValue `protobuf:"bytes,2,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// Kinds has to hold exactly one kind, which selects the stream to load
+	// from: either network changes, or the entity changes of NetworkID.
+	Kinds []Change_Kind `protobuf:"varint,3,rep,packed,name=kinds,proto3,enum=magma.orc8r.configurator.storage.Change_Kind" json:"kinds,omitempty"`
+	// If TypeFilter is provided, the query will only return entity changes
+	// matching the given entity type.
+	TypeFilter *wrappers.StringValue `protobuf:"bytes,4,opt,name=type_filter,json=typeFilter,proto3" json:"type_filter,omitempty"`
+	// Limit caps the number of changes returned. A value of 0 means no limit.
+	Limit                uint32   `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangeLoadFilter) Reset()         { *m = ChangeLoadFilter{} }
+func (m *ChangeLoadFilter) String() string { return proto.CompactTextString(m) }
+func (*ChangeLoadFilter) ProtoMessage()    {}
+func (*ChangeLoadFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{17}
+}
+
+func (m *ChangeLoadFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeLoadFilter.Unmarshal(m, b)
+}
+func (m *ChangeLoadFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeLoadFilter.Marshal(b, m, deterministic)
+}
+func (m *ChangeLoadFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeLoadFilter.Merge(m, src)
+}
+func (m *ChangeLoadFilter) XXX_Size() int {
+	return xxx_messageInfo_ChangeLoadFilter.Size(m)
+}
+func (m *ChangeLoadFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeLoadFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeLoadFilter proto.InternalMessageInfo
+
+func (m *ChangeLoadFilter) GetAfterSequence() uint64 {
+	if m != nil {
+		return m.AfterSequence
+	}
+	return 0
+}
+
+func (m *ChangeLoadFilter) GetNetworkID() *wrappers.StringValue {
+	if m != nil {
+		return m.NetworkID
+	}
+	return nil
+}
+
+func (m *ChangeLoadFilter) GetKinds() []Change_Kind {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
+func (m *ChangeLoadFilter) GetTypeFilter() *wrappers.StringValue {
+	if m != nil {
+		return m.TypeFilter
+	}
+	return nil
+}
+
+func (m *ChangeLoadFilter) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// ConfigRevision is a historical version of a network config or an entity's
+// config. A revision is recorded in the same transaction as every write to a
+// network or entity config.
+type ConfigRevision struct {
+	NetworkID string              `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Kind      ConfigRevision_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=magma.orc8r.configurator.storage.ConfigRevision_Kind" json:"kind,omitempty"`
+	// For network configs, Type is the config type and Key is empty.
+	// For entity configs, (Type, Key) identifies the entity.
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Key  string `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	// Revision numbers start at 1 and increase by 1 with each write to the
+	// config.
+	Revision uint64 `protobuf:"varint,10,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Value is the serialized config as of this revision. Value is empty if
+	// the config was deleted in this revision.
+	Value   []byte `protobuf:"bytes,11,opt,name=value,proto3" json:"value,omitempty"`
+	Deleted bool   `protobuf:"varint,12,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// For entity configs, Labels are the entity's labels as of this revision.
+	Labels map[string]string `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Author identifies who made the write, if known.
+	Author string `protobuf:"bytes,20,opt,name=author,proto3" json:"author,omitempty"`
+	// Unix timestamp (in seconds) at which the write was made
+	CreatedAt            int64    `protobuf:"varint,21,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigRevision) Reset()         { *m = ConfigRevision{} }
+func (m *ConfigRevision) String() string { return proto.CompactTextString(m) }
+func (*ConfigRevision) ProtoMessage()    {}
+func (*ConfigRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{18}
+}
+
+func (m *ConfigRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigRevision.Unmarshal(m, b)
+}
+func (m *ConfigRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigRevision.Marshal(b, m, deterministic)
+}
+func (m *ConfigRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigRevision.Merge(m, src)
+}
+func (m *ConfigRevision) XXX_Size() int {
+	return xxx_messageInfo_ConfigRevision.Size(m)
+}
+func (m *ConfigRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigRevision proto.InternalMessageInfo
+
+func (m *ConfigRevision) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *ConfigRevision) GetKind() ConfigRevision_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return ConfigRevision_NETWORK_CONFIG
+}
+
+func (m *ConfigRevision) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ConfigRevision) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ConfigRevision) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *ConfigRevision) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ConfigRevision) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *ConfigRevision) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *ConfigRevision) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *ConfigRevision) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+// ConfigHistoryFilter specifies which config revisions to load
+type ConfigHistoryFilter struct {
+	NetworkID string              `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	Kind      ConfigRevision_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=magma.orc8r.configurator.storage.ConfigRevision_Kind" json:"kind,omitempty"`
+	Type      string              `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Key       string              `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	// If Revision is provided, only that revision of the config will be
+	// loaded.
+	Revision             *wrappers.UInt64Value `protobuf:"bytes,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ConfigHistoryFilter) Reset()         { *m = ConfigHistoryFilter{} }
+func (m *ConfigHistoryFilter) String() string { return proto.CompactTextString(m) }
+func (*ConfigHistoryFilter) ProtoMessage()    {}
+func (*ConfigHistoryFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{19}
+}
+
+func (m *ConfigHistoryFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigHistoryFilter.Unmarshal(m, b)
+}
+func (m *ConfigHistoryFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigHistoryFilter.Marshal(b, m, deterministic)
+}
+func (m *ConfigHistoryFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigHistoryFilter.Merge(m, src)
+}
+func (m *ConfigHistoryFilter) XXX_Size() int {
+	return xxx_messageInfo_ConfigHistoryFilter.Size(m)
+}
+func (m *ConfigHistoryFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigHistoryFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigHistoryFilter proto.InternalMessageInfo
+
+func (m *ConfigHistoryFilter) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *ConfigHistoryFilter) GetKind() ConfigRevision_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return ConfigRevision_NETWORK_CONFIG
+}
+
+func (m *ConfigHistoryFilter) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ConfigHistoryFilter) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ConfigHistoryFilter) GetRevision() *wrappers.UInt64Value {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("magma.orc8r.configurator.storage.ACL_Permission", ACL_Permission_name, ACL_Permission_value)
 	proto.RegisterEnum("magma.orc8r.configurator.storage.ACL_Wildcard", ACL_Wildcard_name, ACL_Wildcard_value)
+	proto.RegisterEnum("magma.orc8r.configurator.storage.Change_Kind", Change_Kind_name, Change_Kind_value)
+	proto.RegisterEnum("magma.orc8r.configurator.storage.Change_Operation", Change_Operation_name, Change_Operation_value)
+	proto.RegisterEnum("magma.orc8r.configurator.storage.ConfigRevision_Kind", ConfigRevision_Kind_name, ConfigRevision_Kind_value)
 	proto.RegisterType((*Network)(nil), "magma.orc8r.configurator.storage.Network")
 	proto.RegisterMapType((map[string][]byte)(nil), "magma.orc8r.configurator.storage.Network.ConfigsEntry")
 	proto.RegisterType((*NetworkLoadFilter)(nil), "magma.orc8r.configurator.storage.NetworkLoadFilter")
@@ -1302,108 +1878,153 @@ func init() {
 	proto.RegisterMapType((map[string][]byte)(nil), "magma.orc8r.configurator.storage.NetworkUpdateCriteria.ConfigsToAddOrUpdateEntry")
 	proto.RegisterType((*EntityID)(nil), "magma.orc8r.configurator.storage.EntityID")
 	proto.RegisterType((*NetworkEntity)(nil), "magma.orc8r.configurator.storage.NetworkEntity")
+	proto.RegisterMapType((map[string]string)(nil), "magma.orc8r.configurator.storage.NetworkEntity.LabelsEntry")
 	proto.RegisterType((*ACL)(nil), "magma.orc8r.configurator.storage.ACL")
 	proto.RegisterType((*ACL_NetworkIDs)(nil), "magma.orc8r.configurator.storage.ACL.NetworkIDs")
 	proto.RegisterType((*EntityLoadFilter)(nil), "magma.orc8r.configurator.storage.EntityLoadFilter")
+	proto.RegisterMapType((map[string]string)(nil), "magma.orc8r.configurator.storage.EntityLoadFilter.LabelSelectorEntry")
 	proto.RegisterType((*EntityLoadCriteria)(nil), "magma.orc8r.configurator.storage.EntityLoadCriteria")
 	proto.RegisterType((*EntityLoadResult)(nil), "magma.orc8r.configurator.storage.EntityLoadResult")
 	proto.RegisterType((*EntityUpdateCriteria)(nil), "magma.orc8r.configurator.storage.EntityUpdateCriteria")
 	proto.RegisterType((*EntityAssociationsToSet)(nil), "magma.orc8r.configurator.storage.EntityAssociationsToSet")
+	proto.RegisterType((*EntityLabelsToSet)(nil), "magma.orc8r.configurator.storage.EntityLabelsToSet")
+	proto.RegisterMapType((map[string]string)(nil), "magma.orc8r.configurator.storage.EntityLabelsToSet.LabelsEntry")
 	proto.RegisterType((*EntityGraph)(nil), "magma.orc8r.configurator.storage.EntityGraph")
 	proto.RegisterType((*GraphEdge)(nil), "magma.orc8r.configurator.storage.GraphEdge")
+	proto.RegisterType((*Change)(nil), "magma.orc8r.configurator.storage.Change")
+	proto.RegisterType((*ChangeLoadFilter)(nil), "magma.orc8r.configurator.storage.ChangeLoadFilter")
+	proto.RegisterType((*ConfigRevision)(nil), "magma.orc8r.configurator.storage.ConfigRevision")
+	proto.RegisterMapType((map[string]string)(nil), "magma.orc8r.configurator.storage.ConfigRevision.LabelsEntry")
+	proto.RegisterType((*ConfigHistoryFilter)(nil), "magma.orc8r.configurator.storage.ConfigHistoryFilter")
 }
 
 func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 1421 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x6e, 0xdb, 0xc6,
-	0x12, 0x36, 0x25, 0xd9, 0x92, 0x86, 0x92, 0xad, 0xac, 0xe5, 0x84, 0xc7, 0x27, 0x70, 0x14, 0x1e,
-	0x04, 0x70, 0x72, 0x10, 0x25, 0x55, 0x80, 0x24, 0x75, 0xd3, 0x02, 0xb2, 0x28, 0x27, 0x42, 0x1d,
-	0xd9, 0x65, 0x94, 0xba, 0x4d, 0x11, 0xb0, 0x8c, 0xb8, 0x96, 0x09, 0x4b, 0x5c, 0x81, 0x5c, 0x45,
-	0xd0, 0x13, 0xb4, 0x45, 0xfb, 0x42, 0xbd, 0xed, 0xcb, 0xb4, 0x40, 0x81, 0xde, 0xf5, 0xbe, 0xd8,
-	0x1f, 0x52, 0x94, 0x9c, 0xc0, 0x64, 0x5a, 0xa0, 0x77, 0xe4, 0xec, 0x7e, 0xdf, 0xee, 0xfc, 0xec,
-	0x37, 0x03, 0xe5, 0x80, 0x12, 0xdf, 0x1e, 0xe0, 0xfa, 0xd8, 0x27, 0x94, 0xa0, 0xda, 0xc8, 0x1e,
-	0x8c, 0xec, 0x3a, 0xf1, 0xfb, 0x8f, 0xfd, 0x7a, 0x9f, 0x78, 0xa7, 0xee, 0x60, 0xe2, 0xdb, 0x94,
-	0xf8, 0x75, 0xb9, 0x6f, 0x7b, 0x67, 0x40, 0xc8, 0x60, 0x88, 0xef, 0xf1, 0xfd, 0x6f, 0x26, 0xa7,
-	0xf7, 0xa6, 0xbe, 0x3d, 0x1e, 0x63, 0x3f, 0x10, 0x0c, 0xfa, 0x8f, 0x19, 0xc8, 0x77, 0x31, 0x9d,
-	0x12, 0xff, 0x1c, 0xad, 0x43, 0xa6, 0x63, 0x68, 0x4a, 0x4d, 0xd9, 0x2d, 0x9a, 0x99, 0x8e, 0x81,
-	0x10, 0xe4, 0x7a, 0xb3, 0x31, 0xd6, 0x32, 0xdc, 0xc2, 0xbf, 0x99, 0xcd, 0xb3, 0x47, 0x58, 0x03,
-	0x61, 0x63, 0xdf, 0xa8, 0x06, 0xaa, 0x83, 0x83, 0xbe, 0xef, 0x8e, 0xa9, 0x4b, 0x3c, 0x4d, 0xe5,
-	0x4b, 0x71, 0x13, 0x3a, 0x86, 0xbc, 0xb8, 0x5d, 0xa0, 0x55, 0x6b, 0xd9, 0x5d, 0xb5, 0xf1, 0xb0,
-	0x7e, 0xd9, 0xcd, 0xeb, 0xf2, 0x56, 0xf5, 0x96, 0x00, 0xb6, 0x3d, 0xea, 0xcf, 0xcc, 0x90, 0x06,
-	0x69, 0x90, 0x7f, 0x8b, 0xfd, 0x80, 0x9d, 0xb7, 0x53, 0x53, 0x76, 0x73, 0x66, 0xf8, 0xbb, 0xbd,
-	0x07, 0xa5, 0x38, 0x04, 0x55, 0x20, 0x7b, 0x8e, 0x67, 0xd2, 0x2d, 0xf6, 0x89, 0xaa, 0xb0, 0xfa,
-	0xd6, 0x1e, 0x4e, 0x84, 0x63, 0x25, 0x53, 0xfc, 0xec, 0x65, 0x1e, 0x2b, 0xba, 0x03, 0x57, 0xe4,
-	0xb1, 0x87, 0xc4, 0x76, 0x0e, 0xdc, 0x21, 0xc5, 0x3e, 0x23, 0x70, 0x9d, 0x40, 0x53, 0x6a, 0x59,
-	0x46, 0xe0, 0x3a, 0x01, 0xfa, 0x14, 0x54, 0x3a, 0x1b, 0x63, 0xeb, 0x94, 0x6f, 0xe0, 0x34, 0x6a,
-	0xe3, 0x7a, 0x5d, 0x84, 0xba, 0x1e, 0x86, 0xba, 0xfe, 0x82, 0xfa, 0xae, 0x37, 0xf8, 0x92, 0xb1,
-	0x9b, 0xc0, 0x00, 0x82, 0x50, 0x7f, 0x0d, 0x9b, 0xb1, 0x53, 0x5a, 0xbe, 0x4b, 0xb1, 0xef, 0xda,
-	0xe8, 0x7f, 0x50, 0x1e, 0x12, 0xdb, 0xb1, 0x46, 0x98, 0xda, 0x8e, 0x4d, 0x6d, 0x7e, 0xe5, 0x82,
-	0x59, 0x62, 0xc6, 0xe7, 0xd2, 0x86, 0x6e, 0x02, 0xff, 0xb7, 0xc2, 0x70, 0x66, 0xf8, 0x1e, 0x95,
-	0xd9, 0xa4, 0xd7, 0xfa, 0x4f, 0xca, 0x82, 0x17, 0x26, 0x0e, 0x26, 0x43, 0x8a, 0xda, 0x50, 0xf0,
-	0x84, 0x51, 0xb8, 0xa2, 0x36, 0x6e, 0x27, 0xce, 0x81, 0x19, 0x41, 0xd1, 0x7d, 0xa8, 0xca, 0xef,
-	0x8e, 0x11, 0x58, 0x1e, 0xa1, 0xd6, 0x29, 0x99, 0x78, 0x8e, 0x96, 0xe1, 0xd1, 0x41, 0xf3, 0xb5,
-	0x2e, 0xa1, 0x07, 0x6c, 0x45, 0xff, 0x3e, 0x07, 0x5b, 0x92, 0xe7, 0xe5, 0xd8, 0xb1, 0x29, 0x8e,
-	0x1c, 0x5e, 0xae, 0xb7, 0x5b, 0xb0, 0xee, 0xe0, 0x21, 0xa6, 0xd8, 0x92, 0x34, 0xbc, 0xca, 0x0a,
-	0x66, 0x59, 0x58, 0xc3, 0x32, 0x7d, 0xc4, 0x3c, 0x99, 0x5a, 0xbc, 0x0c, 0xab, 0x09, 0x42, 0x9f,
-	0xf7, 0xf0, 0xb4, 0xcb, 0xea, 0xb4, 0x0d, 0x1b, 0x0c, 0x18, 0xaf, 0xd5, 0xad, 0x04, 0xf8, 0x75,
-	0x0f, 0x4f, 0x8d, 0x58, 0x31, 0xcb, 0xf3, 0x59, 0x42, 0xb5, 0xab, 0x09, 0xcf, 0xe7, 0x6f, 0xe7,
-	0x07, 0x05, 0x34, 0x99, 0x37, 0x8b, 0x12, 0xcb, 0x76, 0x1c, 0x8b, 0xf8, 0xd6, 0x84, 0x07, 0x45,
-	0xdb, 0xe1, 0x39, 0xf9, 0x22, 0x71, 0x4e, 0x16, 0x63, 0x19, 0xbe, 0x92, 0x1e, 0x69, 0x3a, 0xce,
-	0x91, 0x2f, 0x16, 0xc5, 0x93, 0xa9, 0xf6, 0xdf, 0xb1, 0x84, 0xee, 0xc0, 0x95, 0xd8, 0x55, 0x44,
-	0x80, 0xb5, 0x1b, 0x3c, 0x89, 0x1b, 0x11, 0xc0, 0xe0, 0xe6, 0xed, 0xa7, 0xf0, 0x9f, 0xf7, 0xd2,
-	0xa7, 0x7a, 0x5e, 0xf7, 0xa1, 0xd0, 0xf6, 0xa8, 0x4b, 0x67, 0x42, 0x5c, 0x78, 0x04, 0x05, 0x90,
-	0x7f, 0x87, 0x5c, 0x99, 0x88, 0x4b, 0xff, 0x3d, 0x0b, 0x65, 0xe9, 0xb0, 0x40, 0xa2, 0xeb, 0x50,
-	0x8c, 0x8a, 0x4c, 0x82, 0xe7, 0x86, 0x88, 0x35, 0x73, 0x91, 0x35, 0x3b, 0xbf, 0xe1, 0x87, 0x89,
-	0xd8, 0x0e, 0xc0, 0xf8, 0x6c, 0x16, 0xb8, 0x7d, 0x7b, 0xd8, 0x31, 0x78, 0xe5, 0x15, 0xcd, 0x98,
-	0x05, 0x5d, 0x85, 0x35, 0x11, 0x39, 0xae, 0x48, 0x25, 0x53, 0xfe, 0x31, 0xa9, 0x1a, 0xf8, 0xf6,
-	0xf8, 0xac, 0x63, 0x68, 0xbb, 0x1c, 0x14, 0xfe, 0xa2, 0x2e, 0x94, 0xec, 0x20, 0x20, 0x7d, 0xd7,
-	0x66, 0x07, 0x04, 0x5a, 0x83, 0xd7, 0xc0, 0x9d, 0xcb, 0x6b, 0x20, 0x8c, 0xa2, 0xb9, 0x80, 0x47,
-	0xdf, 0xc0, 0xe6, 0xd8, 0xf6, 0xb1, 0x47, 0xad, 0x05, 0xda, 0x07, 0xa9, 0x69, 0x91, 0xa0, 0x69,
-	0xc6, 0xc9, 0x9f, 0x82, 0x3a, 0xc6, 0xfe, 0xc8, 0x0d, 0x02, 0x4e, 0xfa, 0x84, 0x93, 0xde, 0xba,
-	0x9c, 0xb4, 0xd9, 0x3a, 0x34, 0xe3, 0xc8, 0xb8, 0x74, 0x1f, 0x2c, 0x48, 0xb7, 0xfe, 0x5b, 0x0e,
-	0xb2, 0xcd, 0xd6, 0xe1, 0x05, 0x61, 0x78, 0x0d, 0x95, 0xa0, 0x4f, 0xc6, 0x91, 0x2e, 0x74, 0x8c,
-	0x80, 0xe7, 0x4e, 0x6d, 0xdc, 0x4f, 0x74, 0x7e, 0xf8, 0x66, 0x3a, 0x46, 0xf0, 0x6c, 0xc5, 0xdc,
-	0xe0, 0x5c, 0x73, 0x13, 0x3a, 0x81, 0x75, 0x41, 0x3f, 0x75, 0x87, 0x4e, 0xdf, 0xf6, 0x1d, 0x9e,
-	0xfd, 0xf5, 0x46, 0x3d, 0x19, 0xf9, 0x89, 0x44, 0x3d, 0x5b, 0x31, 0xcb, 0x9c, 0x27, 0x34, 0xa0,
-	0x63, 0x80, 0xb9, 0xe3, 0xbc, 0x62, 0xd6, 0x93, 0xde, 0xf8, 0x38, 0xc2, 0x99, 0x31, 0x0e, 0x74,
-	0x13, 0x54, 0xcc, 0x93, 0x24, 0xe4, 0x87, 0x15, 0x5a, 0xf1, 0x99, 0x62, 0x82, 0x30, 0x72, 0x95,
-	0x79, 0x09, 0x65, 0xde, 0x9c, 0x22, 0x67, 0x6e, 0x7c, 0x90, 0x33, 0x8a, 0x59, 0x62, 0x34, 0x91,
-	0x2f, 0xdb, 0x50, 0xe8, 0x18, 0xa2, 0x81, 0x69, 0xbb, 0x5c, 0x27, 0xa2, 0xff, 0x78, 0x46, 0x1b,
-	0x8b, 0xcd, 0x78, 0x07, 0x20, 0x16, 0xe8, 0x0a, 0x64, 0x59, 0xea, 0x64, 0x27, 0xed, 0x18, 0x81,
-	0xfe, 0x08, 0x60, 0xee, 0x29, 0x52, 0x21, 0xdf, 0x3d, 0xb2, 0x8e, 0xdb, 0xe6, 0xf3, 0xca, 0x0a,
-	0x2a, 0x40, 0xce, 0x6c, 0x37, 0x8d, 0x8a, 0x82, 0x8a, 0xb0, 0x7a, 0x62, 0x76, 0x7a, 0xed, 0x4a,
-	0x06, 0xe5, 0x21, 0x7b, 0x74, 0xd2, 0xad, 0x64, 0xf5, 0xbb, 0x50, 0x88, 0xae, 0xb6, 0x01, 0x6a,
-	0xf7, 0xc8, 0x3a, 0xe9, 0x1c, 0x1a, 0xad, 0xa6, 0x69, 0x54, 0x56, 0x50, 0x05, 0x4a, 0xe1, 0x9f,
-	0xd5, 0x3c, 0x3c, 0xac, 0x28, 0xfb, 0x79, 0x58, 0xe5, 0xa9, 0xd9, 0x5f, 0x13, 0x02, 0xa1, 0xff,
-	0x92, 0x81, 0x8a, 0x28, 0xf7, 0x58, 0xa7, 0x5f, 0xea, 0xeb, 0x4a, 0xba, 0xbe, 0x8e, 0x3e, 0x01,
-	0x38, 0xc7, 0xb3, 0x34, 0x53, 0x41, 0xf1, 0x1c, 0xcf, 0x24, 0xf8, 0x89, 0x88, 0x4d, 0x36, 0xf5,
-	0x5b, 0x65, 0x30, 0xf4, 0x70, 0xae, 0x31, 0xb9, 0x24, 0x2d, 0x29, 0x54, 0xa0, 0x27, 0x0b, 0x9a,
-	0xb6, 0x9a, 0xc4, 0xe1, 0xf9, 0x7e, 0xfd, 0x57, 0x05, 0xd0, 0x3c, 0x88, 0xe9, 0x06, 0x99, 0x1b,
-	0xa0, 0xc6, 0x06, 0x19, 0x39, 0xc7, 0xc0, 0x7c, 0x8e, 0x41, 0x77, 0x61, 0x93, 0x6f, 0xe0, 0x52,
-	0xc6, 0xbb, 0x14, 0x3d, 0x73, 0x03, 0x2e, 0xe3, 0x05, 0xb3, 0xc2, 0x96, 0xb8, 0x3c, 0x05, 0x3d,
-	0xd2, 0x3b, 0x73, 0x03, 0xf4, 0x11, 0x6c, 0xc5, 0xb7, 0x9f, 0xfa, 0x64, 0x24, 0x00, 0x39, 0x0e,
-	0x40, 0x73, 0xc0, 0x81, 0x4f, 0x46, 0x1c, 0x72, 0x1b, 0x38, 0x8d, 0x15, 0x97, 0xb5, 0x55, 0xbe,
-	0x7b, 0x83, 0xd9, 0xe7, 0x85, 0x19, 0xe8, 0x3f, 0x2b, 0xf1, 0x72, 0x91, 0x23, 0xd5, 0xe7, 0x50,
-	0xe0, 0xef, 0xce, 0xc5, 0xe1, 0x48, 0x75, 0x2f, 0x71, 0xfb, 0x16, 0x64, 0x66, 0x44, 0x80, 0xbe,
-	0x02, 0x14, 0x7e, 0x2f, 0x8d, 0x55, 0xe9, 0xca, 0xa1, 0x12, 0xb2, 0x44, 0x03, 0xd8, 0x9f, 0x6b,
-	0x50, 0x15, 0xcb, 0x4b, 0xf3, 0x57, 0xa2, 0x16, 0xcc, 0xb2, 0x29, 0xa7, 0x32, 0x21, 0x32, 0x72,
-	0x28, 0x2b, 0x09, 0xa3, 0xec, 0xca, 0xff, 0xf6, 0x4c, 0xd6, 0x02, 0x66, 0xb1, 0x62, 0xb5, 0x9c,
-	0x64, 0x32, 0x2b, 0x7b, 0x78, 0x7a, 0x3c, 0x6f, 0xe0, 0x7b, 0x00, 0x8c, 0x44, 0x56, 0xe4, 0x35,
-	0x4e, 0xf0, 0xdf, 0x0b, 0x04, 0xfb, 0x33, 0x8a, 0x03, 0xf9, 0x7c, 0x3d, 0x3c, 0x95, 0xd5, 0xea,
-	0xc2, 0x66, 0xbc, 0xe7, 0xb2, 0x72, 0x0d, 0x30, 0xe5, 0x02, 0xad, 0x36, 0x3e, 0x4e, 0x9a, 0xbf,
-	0x78, 0xc3, 0xed, 0x91, 0x17, 0x98, 0x9a, 0x57, 0xec, 0x65, 0x13, 0x7a, 0x75, 0xf1, 0x28, 0xdb,
-	0x71, 0xf8, 0xf0, 0x96, 0xae, 0x54, 0x96, 0xb8, 0x9b, 0x8e, 0x83, 0xbe, 0x85, 0xab, 0xcb, 0xdc,
-	0x72, 0x36, 0xac, 0xa5, 0xa6, 0xaf, 0x2e, 0xd2, 0x8b, 0x61, 0x12, 0x7d, 0x0d, 0x5b, 0xb1, 0xf7,
-	0xc6, 0x0e, 0xe8, 0xfb, 0x98, 0x0d, 0xc0, 0xbb, 0x69, 0x06, 0x8a, 0xcd, 0x18, 0x47, 0x8f, 0xb4,
-	0x38, 0xc3, 0x3b, 0xa8, 0xe5, 0x6c, 0x7d, 0xfb, 0xc3, 0xa9, 0xe5, 0xb8, 0xdc, 0xb8, 0x40, 0x2d,
-	0xc3, 0x72, 0x87, 0xf7, 0xb2, 0x45, 0x8c, 0xf0, 0x54, 0x9f, 0xc0, 0xb5, 0xf7, 0x64, 0xf5, 0x5d,
-	0x29, 0x64, 0xd5, 0xa2, 0xfc, 0xdd, 0x14, 0xbe, 0xc0, 0x54, 0xff, 0x43, 0x01, 0x55, 0xac, 0x3f,
-	0x65, 0x22, 0xff, 0xcf, 0xaa, 0xd4, 0x11, 0x94, 0x7d, 0x42, 0xa8, 0x15, 0x31, 0xa6, 0x17, 0xa8,
-	0x12, 0x23, 0x68, 0x87, 0x84, 0x4d, 0x58, 0xc5, 0xce, 0x00, 0x87, 0x8d, 0xef, 0xff, 0x97, 0x13,
-	0x71, 0xaf, 0xda, 0xce, 0x00, 0x9b, 0x02, 0xa9, 0x7f, 0xa7, 0x40, 0x31, 0x32, 0xa2, 0x3d, 0xc8,
-	0x50, 0x22, 0x5b, 0x77, 0x9a, 0x6b, 0x65, 0x28, 0x41, 0x9f, 0x41, 0x8e, 0xf5, 0x0d, 0xd9, 0xba,
-	0xd3, 0xa0, 0x39, 0x6e, 0xbf, 0xf8, 0x2a, 0x2f, 0x57, 0xde, 0xac, 0x71, 0xbd, 0x78, 0xf0, 0x57,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x6d, 0xe9, 0x2f, 0xc4, 0xb1, 0x11, 0x00, 0x00,
+	// 1987 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0x4f, 0x73, 0x1b, 0x49,
+	0x15, 0xf7, 0x48, 0xb2, 0x2d, 0xbd, 0x91, 0x64, 0xb9, 0xed, 0xec, 0x0e, 0xde, 0xc5, 0xf6, 0x8a,
+	0x0a, 0xe5, 0x84, 0x8a, 0x12, 0x14, 0xc8, 0x66, 0xbd, 0xe1, 0x8f, 0x22, 0xc9, 0x8e, 0x2a, 0x5e,
+	0xdb, 0xb4, 0x95, 0x35, 0xbb, 0xd4, 0xd6, 0x30, 0xd1, 0xb4, 0xe5, 0x29, 0xcb, 0x33, 0x62, 0xa6,
+	0x1d, 0xaf, 0xf6, 0x0b, 0x50, 0x14, 0x54, 0x71, 0xe4, 0x23, 0xf0, 0x45, 0xb8, 0x53, 0x7c, 0x01,
+	0xaa, 0xe0, 0xc4, 0x89, 0x03, 0x57, 0x2e, 0x54, 0xbf, 0xee, 0xf9, 0x23, 0x29, 0xc1, 0x33, 0xd9,
+	0x3d, 0x50, 0xdc, 0xba, 0xdf, 0xf4, 0xfb, 0xf5, 0xeb, 0xee, 0x5f, 0xbf, 0xf7, 0xeb, 0x81, 0x4a,
+	0xc0, 0x3d, 0xdf, 0x1a, 0xb2, 0xc6, 0xd8, 0xf7, 0xb8, 0x47, 0xb6, 0x2f, 0xad, 0xe1, 0xa5, 0xd5,
+	0xf0, 0xfc, 0xc1, 0x63, 0xbf, 0x31, 0xf0, 0xdc, 0x33, 0x67, 0x78, 0xe5, 0x5b, 0xdc, 0xf3, 0x1b,
+	0x6a, 0xdc, 0xc6, 0xe6, 0xd0, 0xf3, 0x86, 0x23, 0x76, 0x1f, 0xc7, 0xbf, 0xbc, 0x3a, 0xbb, 0x7f,
+	0xed, 0x5b, 0xe3, 0x31, 0xf3, 0x03, 0x89, 0x50, 0xff, 0x6d, 0x0e, 0x96, 0x0f, 0x19, 0xbf, 0xf6,
+	0xfc, 0x0b, 0x52, 0x85, 0x5c, 0xaf, 0x63, 0x68, 0xdb, 0xda, 0x4e, 0x89, 0xe6, 0x7a, 0x1d, 0x42,
+	0xa0, 0xd0, 0x9f, 0x8c, 0x99, 0x91, 0x43, 0x0b, 0xb6, 0x85, 0xcd, 0xb5, 0x2e, 0x99, 0x01, 0xd2,
+	0x26, 0xda, 0x64, 0x1b, 0x74, 0x9b, 0x05, 0x03, 0xdf, 0x19, 0x73, 0xc7, 0x73, 0x0d, 0x1d, 0x3f,
+	0x25, 0x4d, 0xe4, 0x18, 0x96, 0x65, 0x74, 0x81, 0xb1, 0xbe, 0x9d, 0xdf, 0xd1, 0x9b, 0x8f, 0x1a,
+	0x37, 0x45, 0xde, 0x50, 0x51, 0x35, 0xda, 0xd2, 0xb1, 0xeb, 0x72, 0x7f, 0x42, 0x43, 0x18, 0x62,
+	0xc0, 0xf2, 0x2b, 0xe6, 0x07, 0x62, 0xbe, 0xcd, 0x6d, 0x6d, 0xa7, 0x40, 0xc3, 0xee, 0xc6, 0x2e,
+	0x94, 0x93, 0x2e, 0xa4, 0x06, 0xf9, 0x0b, 0x36, 0x51, 0xcb, 0x12, 0x4d, 0xb2, 0x0e, 0x8b, 0xaf,
+	0xac, 0xd1, 0x95, 0x5c, 0x58, 0x99, 0xca, 0xce, 0x6e, 0xee, 0xb1, 0x56, 0xb7, 0x61, 0x55, 0x4d,
+	0x7b, 0xe0, 0x59, 0xf6, 0x9e, 0x33, 0xe2, 0xcc, 0x17, 0x00, 0x8e, 0x1d, 0x18, 0xda, 0x76, 0x5e,
+	0x00, 0x38, 0x76, 0x40, 0x7e, 0x04, 0x3a, 0x9f, 0x8c, 0x99, 0x79, 0x86, 0x03, 0x10, 0x46, 0x6f,
+	0xbe, 0xdf, 0x90, 0x5b, 0xdd, 0x08, 0xb7, 0xba, 0x71, 0xc2, 0x7d, 0xc7, 0x1d, 0x7e, 0x2a, 0xd0,
+	0x29, 0x08, 0x07, 0x09, 0x58, 0xff, 0x02, 0xd6, 0x12, 0xb3, 0xb4, 0x7d, 0x87, 0x33, 0xdf, 0xb1,
+	0xc8, 0x77, 0xa0, 0x32, 0xf2, 0x2c, 0xdb, 0xbc, 0x64, 0xdc, 0xb2, 0x2d, 0x6e, 0x61, 0xc8, 0x45,
+	0x5a, 0x16, 0xc6, 0x4f, 0x94, 0x8d, 0x7c, 0x00, 0xd8, 0x37, 0xc3, 0xed, 0xcc, 0xe1, 0x18, 0x5d,
+	0xd8, 0xd4, 0xaa, 0xeb, 0xbf, 0xd3, 0xa6, 0x56, 0x41, 0x59, 0x70, 0x35, 0xe2, 0xa4, 0x0b, 0x45,
+	0x57, 0x1a, 0xe5, 0x52, 0xf4, 0xe6, 0x9d, 0xd4, 0x67, 0x40, 0x23, 0x57, 0xf2, 0x00, 0xd6, 0x55,
+	0xbb, 0xd7, 0x09, 0x4c, 0xd7, 0xe3, 0xe6, 0x99, 0x77, 0xe5, 0xda, 0x46, 0x0e, 0x77, 0x87, 0xc4,
+	0xdf, 0x0e, 0x3d, 0xbe, 0x27, 0xbe, 0xd4, 0xff, 0x52, 0x80, 0x5b, 0x0a, 0xe7, 0xc5, 0xd8, 0xb6,
+	0x38, 0x8b, 0x16, 0x3c, 0xcb, 0xb7, 0xdb, 0x50, 0xb5, 0xd9, 0x88, 0x71, 0x66, 0x2a, 0x18, 0x64,
+	0x59, 0x91, 0x56, 0xa4, 0x35, 0xa4, 0xe9, 0x3e, 0xd4, 0xd8, 0x97, 0x63, 0x36, 0xe0, 0xcc, 0x36,
+	0x43, 0x0e, 0xe8, 0x6f, 0x38, 0x82, 0x17, 0x3d, 0x97, 0x3f, 0xfa, 0x81, 0x3c, 0x82, 0x95, 0xd0,
+	0xeb, 0x53, 0xe9, 0x44, 0x3e, 0x14, 0x5b, 0x72, 0x6d, 0x22, 0x9f, 0xd7, 0x53, 0x9c, 0xe1, 0xb2,
+	0xcb, 0xae, 0x0f, 0x05, 0xe1, 0xbb, 0xb0, 0x22, 0x1c, 0x93, 0xa4, 0xbf, 0x95, 0xc2, 0xbf, 0xea,
+	0xb2, 0xeb, 0x4e, 0xec, 0x13, 0xce, 0x2f, 0x98, 0x61, 0xbc, 0x93, 0x72, 0x7e, 0xbc, 0x84, 0xbf,
+	0xd1, 0xc0, 0x50, 0x04, 0x30, 0xb9, 0x67, 0x5a, 0xb6, 0x6d, 0x7a, 0xbe, 0x79, 0x85, 0xbb, 0x6b,
+	0x6c, 0xe2, 0xe1, 0xfe, 0x2c, 0xf5, 0xe1, 0x4e, 0x1f, 0x4a, 0x78, 0xdd, 0xfa, 0x5e, 0xcb, 0xb6,
+	0x8f, 0x7c, 0xf9, 0x51, 0xde, 0xbd, 0xf5, 0xc1, 0x6b, 0x3e, 0x91, 0xbb, 0xb0, 0x9a, 0x08, 0x45,
+	0x9e, 0x94, 0xb1, 0x85, 0x6c, 0x58, 0x89, 0x1c, 0x3a, 0x68, 0xde, 0xd8, 0x87, 0x6f, 0xbd, 0x11,
+	0x3e, 0xd3, 0x3d, 0x7d, 0x00, 0xc5, 0xae, 0xcb, 0x1d, 0x3e, 0x91, 0x59, 0x0a, 0x77, 0x50, 0x3a,
+	0x62, 0x3b, 0xc4, 0xca, 0x45, 0x58, 0xf5, 0x7f, 0x17, 0xa0, 0xa2, 0x16, 0x2c, 0x3d, 0xc9, 0xfb,
+	0x50, 0x8a, 0xd8, 0xaa, 0x9c, 0x63, 0x43, 0x84, 0x9a, 0x9b, 0x47, 0xcd, 0xc7, 0x11, 0xbe, 0x5d,
+	0x36, 0xdc, 0x04, 0x18, 0x9f, 0x4f, 0x02, 0x67, 0x60, 0x8d, 0x7a, 0x1d, 0x64, 0x5e, 0x89, 0x26,
+	0x2c, 0xe4, 0x1d, 0x58, 0x92, 0x3b, 0x87, 0xa9, 0xad, 0x4c, 0x55, 0x4f, 0xe4, 0xbc, 0xa1, 0x6f,
+	0x8d, 0xcf, 0x7b, 0x1d, 0x63, 0x07, 0x9d, 0xc2, 0x2e, 0x39, 0x84, 0xb2, 0x15, 0x04, 0xde, 0xc0,
+	0xb1, 0xc4, 0x04, 0x81, 0xd1, 0x44, 0x0e, 0xdc, 0xbd, 0x99, 0x03, 0xe1, 0x2e, 0xd2, 0x29, 0x7f,
+	0xf2, 0x0b, 0x58, 0x1b, 0x5b, 0x3e, 0x73, 0xb9, 0x39, 0x05, 0xfb, 0x30, 0x33, 0x2c, 0x91, 0x30,
+	0xad, 0x24, 0xf8, 0x3e, 0xe8, 0x63, 0xe6, 0x5f, 0x3a, 0x41, 0x80, 0xa0, 0x4f, 0x10, 0xf4, 0xf6,
+	0xcd, 0xa0, 0xad, 0xf6, 0x01, 0x4d, 0x7a, 0x26, 0x6b, 0xc0, 0xde, 0x54, 0x0d, 0x20, 0x27, 0xb0,
+	0x34, 0xb2, 0x5e, 0xb2, 0x51, 0x60, 0x1c, 0x23, 0xfa, 0xc7, 0xa9, 0x6f, 0x83, 0x8c, 0xbc, 0x71,
+	0x80, 0xde, 0x92, 0xf7, 0x0a, 0x6a, 0xe3, 0x23, 0xd0, 0x13, 0xe6, 0x9b, 0xf8, 0x5a, 0x4a, 0xf2,
+	0xf5, 0x1f, 0x05, 0xc8, 0xb7, 0xda, 0x07, 0x73, 0x19, 0xef, 0x0b, 0xa8, 0x05, 0x03, 0x6f, 0x1c,
+	0x25, 0xbc, 0x5e, 0x27, 0x40, 0x2e, 0xe9, 0xcd, 0x07, 0xa9, 0xf6, 0x23, 0x8c, 0xba, 0xd7, 0x09,
+	0x9e, 0x2d, 0xd0, 0x15, 0xc4, 0x8a, 0x4d, 0xe4, 0x14, 0xaa, 0x12, 0xfe, 0xda, 0x19, 0xd9, 0x03,
+	0xcb, 0xb7, 0x91, 0x8d, 0xd5, 0x66, 0x23, 0x1d, 0xf8, 0xa9, 0xf2, 0x7a, 0xb6, 0x40, 0x2b, 0x88,
+	0x13, 0x1a, 0xc8, 0x31, 0x40, 0x7c, 0x10, 0xc8, 0xe0, 0x6a, 0xda, 0x88, 0x8f, 0x23, 0x3f, 0x9a,
+	0xc0, 0x20, 0x1f, 0x80, 0xce, 0x70, 0xeb, 0x65, 0x3a, 0x14, 0xc4, 0x2f, 0x3d, 0xd3, 0x28, 0x48,
+	0x23, 0x66, 0xbd, 0x17, 0x50, 0xe1, 0x93, 0xe4, 0x62, 0xb6, 0xde, 0x6a, 0x31, 0x1a, 0x2d, 0x0b,
+	0x98, 0x68, 0x2d, 0x1b, 0x50, 0xec, 0x75, 0x64, 0x65, 0x36, 0x76, 0x30, 0x6f, 0x45, 0xfd, 0x24,
+	0xc3, 0x9a, 0xd3, 0x2a, 0x63, 0x13, 0x20, 0xb1, 0xd1, 0x35, 0xc8, 0xf7, 0x3a, 0xb2, 0xae, 0x96,
+	0xa8, 0x68, 0xd6, 0x3f, 0x04, 0x88, 0x57, 0x4a, 0x74, 0x58, 0x3e, 0x3c, 0x32, 0x8f, 0xbb, 0xf4,
+	0x93, 0xda, 0x02, 0x29, 0x42, 0x81, 0x76, 0x5b, 0x9d, 0x9a, 0x46, 0x4a, 0xb0, 0x78, 0x4a, 0x7b,
+	0xfd, 0x6e, 0x2d, 0x47, 0x96, 0x21, 0x7f, 0x74, 0x7a, 0x58, 0xcb, 0xd7, 0xef, 0x41, 0x31, 0x0a,
+	0x6d, 0x05, 0xf4, 0xc3, 0x23, 0xf3, 0xb4, 0x77, 0xd0, 0x69, 0xb7, 0x68, 0xa7, 0xb6, 0x40, 0x6a,
+	0x50, 0x0e, 0x7b, 0x66, 0xeb, 0xe0, 0xa0, 0xa6, 0x3d, 0x5d, 0x86, 0x45, 0x3c, 0x9a, 0xa7, 0x4b,
+	0x32, 0x61, 0xd5, 0xff, 0x95, 0x87, 0x9a, 0x24, 0x71, 0x42, 0xc2, 0xcc, 0x08, 0x16, 0x2d, 0x9b,
+	0x60, 0x21, 0x1f, 0x03, 0x5c, 0xb0, 0x49, 0x16, 0xb9, 0x53, 0xba, 0x60, 0x13, 0xe5, 0xfc, 0x44,
+	0xee, 0x4d, 0x3e, 0x73, 0xee, 0x10, 0x6e, 0xe4, 0x51, 0x9c, 0xf3, 0x0a, 0x69, 0x4a, 0xa4, 0x1a,
+	0x4c, 0x9e, 0x4c, 0xe5, 0xd8, 0xc5, 0x34, 0x0b, 0x8e, 0xc7, 0x93, 0x11, 0x54, 0xf1, 0xd2, 0x9b,
+	0x01, 0x1b, 0xb1, 0x01, 0xf7, 0x7c, 0x63, 0x09, 0xc3, 0xef, 0xa6, 0x0d, 0x3f, 0xde, 0x7b, 0x99,
+	0x4a, 0x4e, 0x14, 0x8e, 0xcc, 0x28, 0x95, 0x51, 0xd2, 0xb6, 0xf1, 0x53, 0x20, 0xf3, 0x83, 0x32,
+	0xe5, 0x97, 0x3f, 0xe5, 0x80, 0xc4, 0x13, 0x67, 0x53, 0x94, 0x5b, 0xa0, 0x27, 0x14, 0xa5, 0x12,
+	0x94, 0x10, 0x0b, 0x4a, 0x72, 0x0f, 0xd6, 0x70, 0x00, 0x96, 0x02, 0xac, 0xf2, 0xfc, 0xdc, 0x09,
+	0xb0, 0x0c, 0x16, 0x69, 0x4d, 0x7c, 0xc2, 0xf4, 0x1e, 0xf4, 0xbd, 0xfe, 0xb9, 0x13, 0x90, 0xef,
+	0xc3, 0xad, 0xe4, 0xf0, 0x33, 0xdf, 0xbb, 0x94, 0x0e, 0x05, 0x74, 0x20, 0xb1, 0xc3, 0x9e, 0xef,
+	0x5d, 0xa2, 0xcb, 0x1d, 0x40, 0x18, 0x33, 0x59, 0x16, 0x16, 0x71, 0xf4, 0x8a, 0xb0, 0xc7, 0x17,
+	0x29, 0x88, 0xa2, 0x55, 0xe9, 0x7d, 0x29, 0x8e, 0x56, 0xe6, 0x66, 0xf2, 0x1e, 0x94, 0xc6, 0xd6,
+	0x90, 0x99, 0x81, 0xf3, 0x95, 0xac, 0xcb, 0x15, 0x5a, 0x14, 0x86, 0x13, 0xe7, 0x2b, 0x46, 0xbe,
+	0x0d, 0x80, 0x1f, 0xb9, 0x77, 0xc1, 0xc2, 0xd2, 0x8c, 0xc3, 0xfb, 0xc2, 0x50, 0xff, 0x9b, 0x96,
+	0xbc, 0x3b, 0x4a, 0x38, 0x3f, 0x87, 0x22, 0x26, 0x21, 0x87, 0x85, 0xc2, 0xf9, 0x7e, 0xc6, 0x6a,
+	0x42, 0x23, 0x00, 0xf2, 0x73, 0x20, 0x61, 0x7b, 0x46, 0x3c, 0x67, 0xbb, 0x1b, 0xb5, 0x10, 0x25,
+	0x94, 0xd9, 0xe4, 0xbb, 0x42, 0x93, 0x7e, 0xc9, 0xcd, 0xc4, 0xfa, 0xa4, 0x50, 0xa9, 0x08, 0xf3,
+	0x71, 0xb4, 0xc6, 0x3f, 0x14, 0x61, 0x5d, 0xc2, 0xcc, 0xa8, 0xf1, 0x54, 0x3a, 0x4a, 0x50, 0x4a,
+	0x69, 0x74, 0x99, 0x99, 0x95, 0x44, 0x2f, 0x4b, 0xa3, 0x04, 0xfe, 0xff, 0x51, 0xe8, 0x6d, 0x10,
+	0x16, 0x33, 0x91, 0x49, 0xd2, 0xe8, 0xf4, 0x8a, 0xcb, 0xae, 0x8f, 0x23, 0x17, 0xb2, 0x0b, 0x20,
+	0x40, 0xd4, 0xfd, 0x7a, 0x17, 0x01, 0xde, 0x9b, 0x03, 0x78, 0x3a, 0xe1, 0x2c, 0x50, 0xc9, 0xd3,
+	0x65, 0xd7, 0xea, 0xee, 0x39, 0xb0, 0x96, 0x54, 0x60, 0xe2, 0xf2, 0x05, 0x8c, 0x63, 0x79, 0xd4,
+	0x9b, 0x1f, 0xa5, 0x25, 0x4c, 0x52, 0x7e, 0xf5, 0xbd, 0x13, 0xc6, 0xe9, 0xaa, 0x35, 0x6b, 0x22,
+	0x9f, 0xcf, 0x4f, 0x65, 0xd9, 0xb6, 0xb1, 0x95, 0x99, 0x9b, 0x33, 0xd8, 0x2d, 0xdb, 0x26, 0xbf,
+	0x84, 0x77, 0x66, 0xb1, 0xd5, 0x4b, 0x61, 0x3b, 0x33, 0xfc, 0xfa, 0x34, 0xbc, 0x7c, 0x5a, 0x90,
+	0xcf, 0xe0, 0x56, 0x22, 0x7b, 0x88, 0x09, 0x06, 0x3e, 0x13, 0xcf, 0xa1, 0x9d, 0x2c, 0xf2, 0x72,
+	0x2d, 0x81, 0xd1, 0xf7, 0xda, 0x88, 0xf0, 0x1a, 0x68, 0xf5, 0xd2, 0xba, 0xf3, 0xf6, 0xd0, 0xea,
+	0xf1, 0xd4, 0x9c, 0x83, 0x56, 0xdb, 0x72, 0x17, 0x95, 0xc4, 0xb4, 0x8f, 0x5a, 0xe9, 0x29, 0xc8,
+	0xf2, 0x11, 0x91, 0xa1, 0x89, 0x64, 0x78, 0x98, 0xba, 0x34, 0xa1, 0xb3, 0xa4, 0x81, 0x3e, 0x8a,
+	0x3b, 0xf5, 0x2b, 0x78, 0xf7, 0x0d, 0x74, 0x79, 0x1d, 0x37, 0xc4, 0xcc, 0xda, 0xd7, 0xe5, 0x86,
+	0x98, 0xf6, 0x8f, 0x1a, 0xac, 0xce, 0x45, 0x46, 0x4e, 0x23, 0x05, 0x2f, 0x27, 0xf9, 0xc9, 0x5b,
+	0x2c, 0xef, 0x9b, 0x56, 0xf1, 0xff, 0xd4, 0x40, 0x97, 0x93, 0xec, 0x0b, 0x95, 0xf1, 0xcd, 0x56,
+	0x86, 0x23, 0xa8, 0xf8, 0x9e, 0xc7, 0xcd, 0x08, 0x31, 0x7b, 0x51, 0x28, 0x0b, 0x80, 0x6e, 0x08,
+	0xd8, 0x82, 0x45, 0x66, 0x0f, 0x59, 0xa8, 0xbc, 0xbe, 0x77, 0x33, 0x10, 0xae, 0xaa, 0x6b, 0x0f,
+	0x19, 0x95, 0x9e, 0xf5, 0x5f, 0x6b, 0x50, 0x8a, 0x8c, 0x64, 0x17, 0x72, 0xdc, 0x53, 0xda, 0x31,
+	0x4b, 0x58, 0x39, 0xee, 0x91, 0x1f, 0x43, 0x41, 0x08, 0x01, 0x23, 0x97, 0xd9, 0x1b, 0xfd, 0xea,
+	0x7f, 0xce, 0xc1, 0x52, 0xfb, 0xdc, 0x72, 0x87, 0x4c, 0xe8, 0xf5, 0x80, 0xfd, 0xea, 0x8a, 0xb9,
+	0x03, 0x59, 0xab, 0x0a, 0x34, 0xea, 0x4f, 0xbf, 0xe9, 0x73, 0xb3, 0x6f, 0xfa, 0x16, 0x14, 0x2e,
+	0x1c, 0xd7, 0xc6, 0xba, 0x58, 0x6d, 0xde, 0xbb, 0x39, 0x08, 0x39, 0x63, 0xe3, 0xb9, 0xe3, 0xda,
+	0x14, 0x5d, 0xc9, 0x31, 0x94, 0xbc, 0x31, 0xf3, 0x91, 0xbf, 0x28, 0x68, 0xaa, 0xcd, 0x66, 0x6a,
+	0x9c, 0xa3, 0xd0, 0x93, 0xc6, 0x20, 0x51, 0xd9, 0x85, 0xf9, 0xb2, 0xab, 0xc7, 0xbf, 0x2f, 0xb6,
+	0xa0, 0x20, 0xa2, 0xc0, 0x87, 0x44, 0xb7, 0x7f, 0x7a, 0x44, 0x9f, 0xd7, 0x16, 0x08, 0xc0, 0x52,
+	0xf7, 0xb0, 0xdf, 0xeb, 0x7f, 0x56, 0xd3, 0xea, 0xf7, 0xa1, 0x14, 0xc1, 0x8b, 0x0f, 0x6d, 0xda,
+	0x6d, 0xf5, 0xbb, 0x72, 0xd0, 0x8b, 0xe3, 0x8e, 0x68, 0x6b, 0xa2, 0xdd, 0xe9, 0x1e, 0x74, 0xc5,
+	0x83, 0xa3, 0xfe, 0xfb, 0x1c, 0xd4, 0x64, 0x5c, 0x89, 0x77, 0xc2, 0x6d, 0xa8, 0x5a, 0x67, 0x9c,
+	0xf9, 0xe6, 0xcc, 0x0e, 0x57, 0xd0, 0x7a, 0x12, 0x6e, 0xf3, 0xee, 0xec, 0x36, 0xdf, 0xf8, 0x1c,
+	0x88, 0x0f, 0xa1, 0x0d, 0x8b, 0x62, 0x27, 0x25, 0x2d, 0x33, 0x9f, 0x82, 0xf4, 0x9d, 0x7d, 0xcf,
+	0x14, 0x32, 0xbe, 0x67, 0xd6, 0x61, 0x71, 0xe4, 0x5c, 0x3a, 0x1c, 0x45, 0x66, 0x85, 0xca, 0x4e,
+	0xfd, 0xef, 0x79, 0xa8, 0xca, 0xb2, 0x4b, 0xd9, 0x2b, 0x07, 0xf5, 0xc7, 0x7f, 0xff, 0x47, 0xd4,
+	0x53, 0x7c, 0xca, 0x21, 0x0f, 0x7e, 0x98, 0x62, 0x25, 0x53, 0xe8, 0x49, 0x5e, 0x85, 0x2c, 0xc8,
+	0xcf, 0xb3, 0xa0, 0x10, 0xa7, 0xa6, 0x0d, 0x28, 0xfa, 0xca, 0x19, 0xf9, 0x52, 0xa0, 0x51, 0x3f,
+	0x4e, 0x5b, 0x7a, 0xe2, 0x67, 0x99, 0x78, 0xc0, 0xca, 0x8a, 0x62, 0x1b, 0x65, 0x14, 0x6a, 0x61,
+	0x97, 0xf4, 0xa3, 0x04, 0x5b, 0xc1, 0xfc, 0xf0, 0x24, 0x73, 0xf8, 0xaf, 0xc9, 0xae, 0xe2, 0xd7,
+	0x95, 0x75, 0xc5, 0xcf, 0x3d, 0x5f, 0xfd, 0xd6, 0x52, 0x3d, 0x21, 0xbc, 0x65, 0x3d, 0xb6, 0x4d,
+	0x8b, 0xa3, 0x14, 0xcb, 0xd3, 0x92, 0xb2, 0xb4, 0xf8, 0xd7, 0x49, 0xca, 0xf7, 0xd4, 0xcd, 0x20,
+	0x50, 0x55, 0x37, 0xc3, 0x6c, 0x1f, 0x1d, 0xee, 0xf5, 0xf6, 0x6b, 0x0b, 0x64, 0x15, 0x2a, 0xf2,
+	0x82, 0x84, 0x26, 0xad, 0xfe, 0x57, 0x0d, 0xd6, 0xe4, 0x3a, 0x9e, 0x39, 0x62, 0x5d, 0xe1, 0x2b,
+	0xf5, 0x7f, 0xec, 0xa4, 0x1f, 0x27, 0x4e, 0x7a, 0x31, 0x85, 0x72, 0x8e, 0x46, 0x3f, 0x2d, 0x7d,
+	0xbe, 0xac, 0x82, 0x78, 0xb9, 0x84, 0x43, 0x1f, 0xfe, 0x67, 0x00, 0x56, 0xf4, 0x9b, 0x1a, 0x35,
+	0x1a, 0x00, 0x00,
 }
@@ -9,6 +9,8 @@
 package configurator
 
 import (
+	"time"
+
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/configurator/storage"
 	storage2 "magma/orc8r/cloud/go/storage"
@@ -75,6 +77,10 @@ type NetworkUpdateCriteria struct {
 	// Set DeleteNetwork to true to delete the network
 	DeleteNetwork bool
 
+	// If non-nil, the update is only applied if the network's current
+	// version matches. ErrVersionMismatch is returned otherwise.
+	ExpectedVersion *uint64
+
 	// Set NewType, NewName or NewDescription to nil to indicate that no update is
 	// desired. To clear the value of name or description, set these fields to
 	// a pointer to an empty string.
@@ -98,7 +104,8 @@ func (nuc NetworkUpdateCriteria) toStorageProto() (*storage.NetworkUpdateCriteri
 	ret := &storage.NetworkUpdateCriteria{
 		ID: nuc.ID,
 
-		DeleteNetwork: nuc.DeleteNetwork,
+		DeleteNetwork:   nuc.DeleteNetwork,
+		ExpectedVersion: uint64PtrToWrapper(nuc.ExpectedVersion),
 
 		NewName:        strPtrToWrapper(nuc.NewName),
 		NewDescription: strPtrToWrapper(nuc.NewDescription),
@@ -131,6 +138,11 @@ type NetworkEntity struct {
 
 	Config interface{}
 
+	// Labels are arbitrary key-value pairs which can be used to select
+	// entities when loading. Labels are only loaded if requested by the load
+	// criteria.
+	Labels map[string]string
+
 	// GraphID is a mostly-internal field to designate the DAG that this
 	// network entity belongs to. This field is system-generated and will be
 	// ignored if set during entity creation.
@@ -160,6 +172,7 @@ func (ent NetworkEntity) toStorageProto() (*storage.NetworkEntity, error) {
 		PhysicalID:  ent.PhysicalID,
 
 		Associations: tksToEntIDs(ent.Associations),
+		Labels:       ent.Labels,
 
 		// don't set graphID, parent assocs, or version because those are
 		// read-only fields
@@ -186,6 +199,7 @@ func (ent NetworkEntity) fromStorageProto(protoEnt *storage.NetworkEntity) (Netw
 	ent.GraphID = protoEnt.GraphID
 	ent.Associations = entIDsToTKs(protoEnt.Associations)
 	ent.ParentAssociations = entIDsToTKs(protoEnt.ParentAssociations)
+	ent.Labels = protoEnt.Labels
 	ent.Version = protoEnt.Version
 
 	if !funk.IsEmpty(protoEnt.Config) {
@@ -275,6 +289,10 @@ type EntityLoadFilter struct {
 	// If PhysicalID is provided, the query will return all entities matching
 	// the provided ID value.
 	PhysicalID *string
+
+	// If LabelSelector is provided, the query will only return entities
+	// which have all of the given labels with matching values.
+	LabelSelector map[string]string
 }
 
 // EntityLoadCriteria specifies how much of an entity to load
@@ -286,6 +304,14 @@ type EntityLoadCriteria struct {
 
 	LoadAssocsToThis   bool
 	LoadAssocsFromThis bool
+
+	LoadLabels bool
+
+	// Set PageSize to a nonzero value to load at most that many entities,
+	// ordered by (type, key). PageToken is the token returned with the
+	// previous page, or empty for the first page.
+	PageSize  uint32
+	PageToken string
 }
 
 func (elc EntityLoadCriteria) toStorageProto() *storage.EntityLoadCriteria {
@@ -294,6 +320,9 @@ func (elc EntityLoadCriteria) toStorageProto() *storage.EntityLoadCriteria {
 		LoadConfig:         elc.LoadConfig,
 		LoadAssocsToThis:   elc.LoadAssocsToThis,
 		LoadAssocsFromThis: elc.LoadAssocsFromThis,
+		LoadLabels:         elc.LoadLabels,
+		PageSize:           elc.PageSize,
+		PageToken:          elc.PageToken,
 	}
 }
 
@@ -305,6 +334,7 @@ func FullEntityLoadCriteria() EntityLoadCriteria {
 		LoadConfig:         true,
 		LoadAssocsToThis:   true,
 		LoadAssocsFromThis: true,
+		LoadLabels:         true,
 	}
 }
 
@@ -331,6 +361,10 @@ type EntityUpdateCriteria struct {
 	// Set DeleteEntity to true to mark the entity for deletion
 	DeleteEntity bool
 
+	// If non-nil, the update is only applied if the entity's current version
+	// matches. ErrVersionMismatch is returned otherwise.
+	ExpectedVersion *uint64
+
 	NewName        *string
 	NewDescription *string
 
@@ -349,6 +383,11 @@ type EntityUpdateCriteria struct {
 	AssociationsToSet    []storage2.TypeAndKey
 	AssociationsToAdd    []storage2.TypeAndKey
 	AssociationsToDelete []storage2.TypeAndKey
+
+	// A non-nil LabelsToSet replaces all labels on the entity. As with
+	// AssociationsToSet, an empty, non-nil value clears all labels and a nil
+	// value will be ignored.
+	LabelsToSet map[string]string
 }
 
 func (euc EntityUpdateCriteria) toStorageProto() (*storage.EntityUpdateCriteria, error) {
@@ -356,6 +395,7 @@ func (euc EntityUpdateCriteria) toStorageProto() (*storage.EntityUpdateCriteria,
 		Type:                 euc.Type,
 		Key:                  euc.Key,
 		DeleteEntity:         euc.DeleteEntity,
+		ExpectedVersion:      uint64PtrToWrapper(euc.ExpectedVersion),
 		NewName:              strPtrToWrapper(euc.NewName),
 		NewDescription:       strPtrToWrapper(euc.NewDescription),
 		NewPhysicalID:        strPtrToWrapper(euc.NewPhysicalID),
@@ -369,6 +409,10 @@ func (euc EntityUpdateCriteria) toStorageProto() (*storage.EntityUpdateCriteria,
 		}
 	}
 
+	if euc.LabelsToSet != nil {
+		ret.LabelsToSet = &storage.EntityLabelsToSet{Labels: euc.LabelsToSet}
+	}
+
 	if euc.NewConfig != nil {
 		bConfig, err := serde.Serialize(NetworkEntitySerdeDomain, euc.Type, euc.NewConfig)
 		if err != nil {
@@ -389,6 +433,67 @@ func (euc EntityUpdateCriteria) GetTypeAndKey() storage2.TypeAndKey {
 
 func (euc EntityUpdateCriteria) isEntityWriteOperation() {}
 
+// ConfigID identifies a network config or an entity's config in config
+// history.
+type ConfigID struct {
+	Kind storage.ConfigRevision_Kind
+	// Type is the config type for network configs and the entity type for
+	// entity configs
+	Type string
+	// Key is the entity key for entity configs and empty for network configs
+	Key string
+}
+
+// NetworkConfigID returns the ConfigID of a network config of the given type.
+func NetworkConfigID(configType string) ConfigID {
+	return ConfigID{Kind: storage.ConfigRevision_NETWORK_CONFIG, Type: configType}
+}
+
+// EntityConfigID returns the ConfigID of the config of the given entity.
+func EntityConfigID(entityType string, entityKey string) ConfigID {
+	return ConfigID{Kind: storage.ConfigRevision_ENTITY_CONFIG, Type: entityType, Key: entityKey}
+}
+
+func (id ConfigID) serdeDomain() string {
+	if id.Kind == storage.ConfigRevision_ENTITY_CONFIG {
+		return NetworkEntitySerdeDomain
+	}
+	return NetworkConfigSerdeDomain
+}
+
+// ConfigRevision is a historical version of a network config or an entity's
+// config.
+type ConfigRevision struct {
+	Revision uint64
+	// Config is the deserialized config as of this revision. Config is nil
+	// if the config was deleted in this revision.
+	Config  interface{}
+	Deleted bool
+	// Labels are the entity's labels as of this revision, for entity configs
+	Labels map[string]string
+
+	// Author identifies who made the write, if known
+	Author    string
+	CreatedAt time.Time
+}
+
+func (rev ConfigRevision) fromStorageProto(id ConfigID, protoRev *storage.ConfigRevision) (ConfigRevision, error) {
+	rev.Revision = protoRev.Revision
+	rev.Deleted = protoRev.Deleted
+	rev.Labels = protoRev.Labels
+	rev.Author = protoRev.Author
+	rev.CreatedAt = time.Unix(protoRev.CreatedAt, 0)
+
+	if !funk.IsEmpty(protoRev.Value) {
+		iConfig, err := serde.Deserialize(id.serdeDomain(), id.Type, protoRev.Value)
+		if err != nil {
+			return rev, errors.Wrapf(err, "failed to deserialize revision %d of config (%s, %s)", protoRev.Revision, id.Type, id.Key)
+		}
+		rev.Config = iConfig
+	}
+	return rev, nil
+}
+
 func marshalConfigs(configs map[string]interface{}, domain string) (map[string][]byte, error) {
 	ret := map[string][]byte{}
 	for configType, iConfig := range configs {
@@ -420,6 +525,13 @@ func strPtrToWrapper(in *string) *wrappers.StringValue {
 	return &wrappers.StringValue{Value: *in}
 }
 
+func uint64PtrToWrapper(in *uint64) *wrappers.UInt64Value {
+	if in == nil {
+		return nil
+	}
+	return &wrappers.UInt64Value{Value: *in}
+}
+
 func tksToEntIDs(tks []storage2.TypeAndKey) []*storage.EntityID {
 	if funk.IsEmpty(tks) {
 		return nil
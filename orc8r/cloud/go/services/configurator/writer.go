@@ -0,0 +1,348 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package configurator
+
+import (
+	"context"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/services/configurator/protos"
+	"magma/orc8r/cloud/go/services/configurator/storage"
+	storage2 "magma/orc8r/cloud/go/storage"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Writer makes configurator writes attributed to its author, who is recorded
+// in the config history of all configs the writes change. The package level
+// write functions are the writes of the zero Writer, which has no author.
+// The author is advisory: configurator records it as claimed by the caller.
+type Writer struct {
+	author string
+}
+
+// AuthoredBy returns a Writer of writes attributed to author.
+func AuthoredBy(author string) Writer {
+	return Writer{author: author}
+}
+
+// context returns the context of the writer's requests, carrying its author
+// under AuthorMetadataKey
+func (w Writer) context() context.Context {
+	ctx := context.Background()
+	if w.author != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, AuthorMetadataKey, w.author)
+	}
+	return ctx
+}
+
+func (w Writer) CreateNetwork(network Network) error {
+	_, err := w.CreateNetworks([]Network{network})
+	return err
+}
+
+// CreateNetworks registers the given list of Networks and returns the created networks
+func (w Writer) CreateNetworks(networks []Network) ([]Network, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &protos.CreateNetworksRequest{Networks: make([]*storage.Network, 0, len(networks))}
+	for _, n := range networks {
+		pNet, err := n.toStorageProto()
+		if err != nil {
+			return nil, err
+		}
+		req.Networks = append(req.Networks, pNet)
+	}
+	result, err := client.CreateNetworks(w.context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]Network, len(result.CreatedNetworks))
+	for i, protoNet := range result.CreatedNetworks {
+		ent, err := ret[i].fromStorageProto(protoNet)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = ent
+	}
+	return ret, nil
+}
+
+// UpdateNetworks updates the specified networks and returns the updated networks
+func (w Writer) UpdateNetworks(updates []NetworkUpdateCriteria) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+
+	request := &protos.UpdateNetworksRequest{Updates: make([]*storage.NetworkUpdateCriteria, 0, len(updates))}
+	for _, update := range updates {
+		protoUpdate, err := update.toStorageProto()
+		if err != nil {
+			return err
+		}
+		request.Updates = append(request.Updates, protoUpdate)
+	}
+	_, err = client.UpdateNetworks(w.context(), request)
+	if status.Code(err) == codes.FailedPrecondition {
+		return ErrVersionMismatch
+	}
+	return err
+}
+
+// DeleteNetworks deletes the network specified by networkID
+func (w Writer) DeleteNetworks(networkIDs []string) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteNetworks(w.context(), &protos.DeleteNetworksRequest{NetworkIDs: networkIDs})
+	return err
+}
+
+// DeleteNetwork deletes a network.
+func (w Writer) DeleteNetwork(networkID string) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteNetworks(
+		w.context(),
+		&protos.DeleteNetworksRequest{NetworkIDs: []string{networkID}},
+	)
+	return err
+}
+
+func (w Writer) UpdateNetworkConfig(networkID, configType string, config interface{}) error {
+	updateCriteria := NetworkUpdateCriteria{
+		ID:                   networkID,
+		ConfigsToAddOrUpdate: map[string]interface{}{configType: config},
+	}
+	return w.UpdateNetworks([]NetworkUpdateCriteria{updateCriteria})
+}
+
+func (w Writer) DeleteNetworkConfig(networkID, configType string) error {
+	updateCriteria := NetworkUpdateCriteria{
+		ID:              networkID,
+		ConfigsToDelete: []string{configType},
+	}
+	return w.UpdateNetworks([]NetworkUpdateCriteria{updateCriteria})
+}
+
+// WriteEntities executes a series of entity writes (creation or update) to be
+// executed in order within a single transaction.
+// This function is all-or-nothing - any failure or error encountered during
+// any operation will rollback the entire batch.
+func (w Writer) WriteEntities(networkID string, writes ...EntityWriteOperation) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+
+	req := &protos.WriteEntitiesRequest{NetworkID: networkID}
+	for _, write := range writes {
+		switch op := write.(type) {
+		case NetworkEntity:
+			protoEnt, err := op.toStorageProto()
+			if err != nil {
+				return err
+			}
+			req.Writes = append(req.Writes, &protos.WriteEntityRequest{Request: &protos.WriteEntityRequest_Create{Create: protoEnt}})
+		case EntityUpdateCriteria:
+			protoEuc, err := op.toStorageProto()
+			if err != nil {
+				return err
+			}
+			req.Writes = append(req.Writes, &protos.WriteEntityRequest{Request: &protos.WriteEntityRequest_Update{Update: protoEuc}})
+		default:
+			return errors.Errorf("unrecognized entity write operation %T", op)
+		}
+	}
+
+	_, err = client.WriteEntities(w.context(), req)
+	if status.Code(err) == codes.FailedPrecondition {
+		return ErrVersionMismatch
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w Writer) CreateEntity(networkID string, entity NetworkEntity) (NetworkEntity, error) {
+	ret, err := w.CreateEntities(networkID, []NetworkEntity{entity})
+	if err != nil {
+		return NetworkEntity{}, err
+	}
+	return ret[0], nil
+}
+
+// CreateEntities registers the given entities and returns the created network entities
+func (w Writer) CreateEntities(networkID string, entities []NetworkEntity) ([]NetworkEntity, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &protos.CreateEntitiesRequest{NetworkID: networkID, Entities: make([]*storage.NetworkEntity, 0, len(entities))}
+	for _, ent := range entities {
+		protoEnt, err := ent.toStorageProto()
+		if err != nil {
+			return nil, err
+		}
+		request.Entities = append(request.Entities, protoEnt)
+	}
+	response, err := client.CreateEntities(w.context(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]NetworkEntity, len(response.CreatedEntities))
+	for i, protoEnt := range response.CreatedEntities {
+		ent, err := ret[i].fromStorageProto(protoEnt)
+		if err != nil {
+			return nil, errors.Wrap(err, "request succeeded but deserialization failed")
+		}
+		ret[i] = ent
+	}
+	return ret, err
+}
+
+// CreateInternalEntity is a loose wrapper around CreateEntity to create an
+// entity in the internal network structure
+func (w Writer) CreateInternalEntity(entity NetworkEntity) (NetworkEntity, error) {
+	return w.CreateEntity(storage.InternalNetworkID, entity)
+}
+
+func (w Writer) UpdateEntity(networkID string, update EntityUpdateCriteria) (NetworkEntity, error) {
+	retMap, err := w.UpdateEntities(networkID, []EntityUpdateCriteria{update})
+	if err != nil {
+		return NetworkEntity{}, err
+	}
+	for _, v := range retMap {
+		return v, nil
+	}
+	return NetworkEntity{}, merrors.ErrNotFound
+}
+
+// UpdateEntities updates the registered entities and returns the updated entities
+func (w Writer) UpdateEntities(networkID string, updates []EntityUpdateCriteria) (map[string]NetworkEntity, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &protos.UpdateEntitiesRequest{NetworkID: networkID, Updates: make([]*storage.EntityUpdateCriteria, 0, len(updates))}
+	for _, update := range updates {
+		upProto, err := update.toStorageProto()
+		if err != nil {
+			return nil, err
+		}
+		request.Updates = append(request.Updates, upProto)
+	}
+	response, err := client.UpdateEntities(w.context(), request)
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, ErrVersionMismatch
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[string]NetworkEntity{}
+	for id, protoEnt := range response.UpdatedEntities {
+		ent, err := (NetworkEntity{}).fromStorageProto(protoEnt)
+		if err != nil {
+			return nil, errors.Wrap(err, "request succeeded but response deserialization failed")
+		}
+		ret[id] = ent
+	}
+	return ret, err
+}
+
+// UpdateInternalEntity is a loose wrapper around UpdateEntity to update an
+// entity in the internal network structure
+func (w Writer) UpdateInternalEntity(update EntityUpdateCriteria) (NetworkEntity, error) {
+	return w.UpdateEntity(storage.InternalNetworkID, update)
+}
+
+func (w Writer) CreateOrUpdateEntityConfig(networkID string, entityType string, entityKey string, config interface{}) error {
+	updateCriteria := EntityUpdateCriteria{
+		Key:       entityKey,
+		Type:      entityType,
+		NewConfig: config,
+	}
+	_, err := w.UpdateEntities(networkID, []EntityUpdateCriteria{updateCriteria})
+	return err
+}
+
+func (w Writer) DeleteEntityConfig(networkID, entityType, entityKey string) error {
+	updateCriteria := EntityUpdateCriteria{
+		Key:          entityKey,
+		Type:         entityType,
+		DeleteConfig: true,
+	}
+	_, err := w.UpdateEntities(networkID, []EntityUpdateCriteria{updateCriteria})
+	return err
+}
+
+func (w Writer) DeleteEntity(networkID string, entityType string, entityKey string) error {
+	return w.DeleteEntities(networkID, []storage2.TypeAndKey{{Type: entityType, Key: entityKey}})
+}
+
+// DeleteEntity deletes the entity specified by networkID, type, key
+// We also have cascading deletes to delete foreign keys for assocs
+func (w Writer) DeleteEntities(networkID string, ids []storage2.TypeAndKey) error {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteEntities(
+		w.context(),
+		&protos.DeleteEntitiesRequest{
+			NetworkID: networkID,
+			ID:        tksToEntIDs(ids),
+		},
+	)
+	return err
+}
+
+// DeleteInternalEntity is a loose wrapper around DeleteEntities to delete an
+// entity in the internal network structure
+func (w Writer) DeleteInternalEntity(entityType, entityKey string) error {
+	return w.DeleteEntity(storage.InternalNetworkID, entityType, entityKey)
+}
+
+// RollbackConfig atomically restores a network config or an entity's config
+// to its value as of the given revision, entities' labels are restored as
+// well. The restore is recorded as a new revision. If the revision doesn't exist, merrors.ErrNotFound is returned.
+func (w Writer) RollbackConfig(networkID string, id ConfigID, revision uint64) (ConfigRevision, error) {
+	client, err := getNBConfiguratorClient()
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+
+	protoRev, err := client.RollbackConfig(
+		w.context(),
+		&protos.RollbackConfigRequest{NetworkID: networkID, Kind: id.Kind, Type: id.Type, Key: id.Key, Revision: revision},
+	)
+	if status.Code(err) == codes.NotFound {
+		return ConfigRevision{}, merrors.ErrNotFound
+	}
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+	return (ConfigRevision{}).fromStorageProto(id, protoRev)
+}
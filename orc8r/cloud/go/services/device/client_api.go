@@ -20,8 +20,14 @@ import (
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// ErrModified is returned by CompareAndSwapDevice when the device's info
+// isn't the expected one anymore
+var ErrModified = errors.New("device was modified")
+
 func getDeviceClient() (protos.DeviceClient, error) {
 	conn, err := registry.GetConnection(ServiceName)
 	if err != nil {
@@ -78,6 +84,42 @@ func UpdateDevice(networkID, deviceType, deviceKey string, info interface{}) err
 	return err
 }
 
+// CompareAndSwapDevice updates the device's info only if it is still the
+// expected one. It returns ErrModified if the device was modified since it
+// was read, and ErrNotFound if the device doesn't exist.
+func CompareAndSwapDevice(networkID, deviceType, deviceKey string, expected, updated interface{}) error {
+	client, err := getDeviceClient()
+	if err != nil {
+		return err
+	}
+
+	serializedExpected, err := serde.Serialize(SerdeDomain, deviceType, expected)
+	if err != nil {
+		return err
+	}
+	serializedInfo, err := serde.Serialize(SerdeDomain, deviceType, updated)
+	if err != nil {
+		return err
+	}
+	req := &protos.CompareAndSwapDeviceRequest{
+		NetworkID: networkID,
+		Entity: &protos.PhysicalEntity{
+			DeviceID: deviceKey,
+			Type:     deviceType,
+			Info:     serializedInfo,
+		},
+		ExpectedInfo: serializedExpected,
+	}
+	_, err = client.CompareAndSwapDevice(context.Background(), req)
+	switch status.Code(err) {
+	case codes.Aborted:
+		return ErrModified
+	case codes.NotFound:
+		return merrors.ErrNotFound
+	}
+	return err
+}
+
 func DeleteDevices(networkID string, ids []storage.TypeAndKey) error {
 	client, err := getDeviceClient()
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"testing"
 
+	merrors "magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/services/device"
 	"magma/orc8r/cloud/go/services/device/test_init"
@@ -66,6 +67,16 @@ func TestDeviceService(t *testing.T) {
 	bundle1.info = 5
 	updateDevicesAssertNoError(t, networkID, bundle1)
 
+	// Compare and swap only applies on the expected info
+	err = device.CompareAndSwapDevice(networkID, typeVal, bundle1.deviceKey, 5, 6)
+	assert.NoError(t, err)
+	err = device.CompareAndSwapDevice(networkID, typeVal, bundle1.deviceKey, 5, 7)
+	assert.Equal(t, device.ErrModified, err)
+	bundle1.info = 6
+	assertDevicesAreRegistered(t, bundle1)
+	err = device.CompareAndSwapDevice(networkID, typeVal, "device3", 5, 6)
+	assert.Equal(t, merrors.ErrNotFound, err)
+
 	// Test deletion
 	err = device.DeleteDevices(networkID, []storage.TypeAndKey{{Type: bundle1.deviceType, Key: bundle1.deviceKey}})
 	assert.NoError(t, err)
@@ -311,6 +311,63 @@ func (m *DeleteDevicesRequest) GetDeviceIDs() []*DeviceID {
 	return nil
 }
 
+type CompareAndSwapDeviceRequest struct {
+	NetworkID string `protobuf:"bytes,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	// Device with its new info
+	Entity *PhysicalEntity `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	// Info the device must currently have for the update to be applied
+	ExpectedInfo         []byte   `protobuf:"bytes,3,opt,name=expectedInfo,proto3" json:"expectedInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompareAndSwapDeviceRequest) Reset()         { *m = CompareAndSwapDeviceRequest{} }
+func (m *CompareAndSwapDeviceRequest) String() string { return proto.CompactTextString(m) }
+func (*CompareAndSwapDeviceRequest) ProtoMessage()    {}
+func (*CompareAndSwapDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_870276a56ac00da5, []int{6}
+}
+
+func (m *CompareAndSwapDeviceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompareAndSwapDeviceRequest.Unmarshal(m, b)
+}
+func (m *CompareAndSwapDeviceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompareAndSwapDeviceRequest.Marshal(b, m, deterministic)
+}
+func (m *CompareAndSwapDeviceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareAndSwapDeviceRequest.Merge(m, src)
+}
+func (m *CompareAndSwapDeviceRequest) XXX_Size() int {
+	return xxx_messageInfo_CompareAndSwapDeviceRequest.Size(m)
+}
+func (m *CompareAndSwapDeviceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareAndSwapDeviceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompareAndSwapDeviceRequest proto.InternalMessageInfo
+
+func (m *CompareAndSwapDeviceRequest) GetNetworkID() string {
+	if m != nil {
+		return m.NetworkID
+	}
+	return ""
+}
+
+func (m *CompareAndSwapDeviceRequest) GetEntity() *PhysicalEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+func (m *CompareAndSwapDeviceRequest) GetExpectedInfo() []byte {
+	if m != nil {
+		return m.ExpectedInfo
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PhysicalEntity)(nil), "magma.orc8r.device.PhysicalEntity")
 	proto.RegisterType((*RegisterOrUpdateDevicesRequest)(nil), "magma.orc8r.device.RegisterOrUpdateDevicesRequest")
@@ -319,38 +376,43 @@ func init() {
 	proto.RegisterType((*GetDeviceInfoResponse)(nil), "magma.orc8r.device.GetDeviceInfoResponse")
 	proto.RegisterMapType((map[string]*PhysicalEntity)(nil), "magma.orc8r.device.GetDeviceInfoResponse.DeviceMapEntry")
 	proto.RegisterType((*DeleteDevicesRequest)(nil), "magma.orc8r.device.DeleteDevicesRequest")
+	proto.RegisterType((*CompareAndSwapDeviceRequest)(nil), "magma.orc8r.device.CompareAndSwapDeviceRequest")
 }
 
 func init() { proto.RegisterFile("device.proto", fileDescriptor_870276a56ac00da5) }
 
 var fileDescriptor_870276a56ac00da5 = []byte{
-	// 413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x41, 0x8f, 0x9a, 0x40,
-	0x14, 0x16, 0x6c, 0x0d, 0xbe, 0xaa, 0x6d, 0x27, 0x6d, 0x42, 0x89, 0x69, 0xcc, 0x9c, 0xe8, 0x05,
-	0x13, 0x7b, 0x21, 0x1e, 0x7a, 0x68, 0x30, 0x4d, 0x0f, 0xb6, 0x0d, 0x69, 0x4d, 0xe3, 0xa9, 0x14,
-	0x9f, 0x2e, 0x51, 0x18, 0x96, 0x19, 0xdd, 0x70, 0xd9, 0xff, 0xb8, 0x7f, 0x66, 0xcf, 0x1b, 0x18,
-	0xd0, 0xb0, 0x4b, 0x36, 0x98, 0xdd, 0x13, 0x33, 0x6f, 0xde, 0xf7, 0xbd, 0xef, 0xbd, 0xef, 0x05,
-	0xe8, 0xad, 0xf0, 0x10, 0xf8, 0x68, 0xc5, 0x09, 0x13, 0x8c, 0x90, 0xd0, 0xdb, 0x84, 0x9e, 0xc5,
-	0x12, 0xdf, 0x4e, 0x2c, 0xf9, 0x62, 0x7c, 0xc8, 0x6f, 0xe3, 0x3c, 0x81, 0x8f, 0x7d, 0x16, 0x86,
-	0x2c, 0x92, 0xe9, 0xf4, 0x37, 0x0c, 0x7e, 0x5d, 0xa4, 0x3c, 0xf0, 0xbd, 0xdd, 0x2c, 0x12, 0x81,
-	0x48, 0x89, 0x01, 0x9a, 0x84, 0x7d, 0x77, 0x74, 0x65, 0xa4, 0x98, 0x5d, 0xf7, 0x78, 0x27, 0x04,
-	0x5e, 0x88, 0x34, 0x46, 0x5d, 0xcd, 0xe3, 0xf9, 0x39, 0x8b, 0x05, 0xd1, 0x9a, 0xe9, 0xed, 0x91,
-	0x62, 0xf6, 0xdc, 0xfc, 0x4c, 0xaf, 0xe1, 0xa3, 0x8b, 0x9b, 0x80, 0x0b, 0x4c, 0x7e, 0x26, 0x7f,
-	0xe2, 0x95, 0x27, 0xd0, 0xc9, 0x39, 0xb8, 0x8b, 0x97, 0x7b, 0xe4, 0x82, 0x0c, 0xa1, 0x1b, 0xa1,
-	0xb8, 0x62, 0xc9, 0xf6, 0x58, 0xe6, 0x14, 0x20, 0x5f, 0x40, 0xc3, 0x4c, 0x4d, 0x80, 0x5c, 0x57,
-	0x47, 0x6d, 0xf3, 0xd5, 0x84, 0x5a, 0x0f, 0xfb, 0xb2, 0xaa, 0xca, 0xdd, 0x23, 0x86, 0x4e, 0x41,
-	0x73, 0x4a, 0xcd, 0x67, 0xf6, 0x43, 0x63, 0x78, 0xf7, 0x0d, 0x45, 0x01, 0x8f, 0xd6, 0xac, 0x99,
-	0xe2, 0x29, 0x74, 0x4b, 0xd6, 0x52, 0xf2, 0xb0, 0x4e, 0x72, 0x29, 0xcb, 0x3d, 0xa5, 0xd3, 0x1b,
-	0x05, 0xde, 0xdf, 0x2b, 0xc9, 0x63, 0x16, 0x71, 0x24, 0x8b, 0x92, 0x75, 0xee, 0xc5, 0xba, 0x92,
-	0xb3, 0xda, 0x75, 0xac, 0xb5, 0xe8, 0xa2, 0xd6, 0xdc, 0x8b, 0x67, 0x91, 0x48, 0x52, 0xf7, 0x44,
-	0x65, 0xfc, 0x83, 0x41, 0xf5, 0x91, 0xbc, 0x81, 0xf6, 0x16, 0xd3, 0xa2, 0xaf, 0xec, 0x48, 0x6c,
-	0x78, 0x79, 0xf0, 0x76, 0x7b, 0x39, 0x9c, 0x66, 0x06, 0x48, 0xc0, 0x54, 0xb5, 0x95, 0x6c, 0x8a,
-	0x0e, 0xee, 0xf0, 0x4c, 0xdf, 0x9f, 0x30, 0xc5, 0xc9, 0xad, 0x0a, 0x1d, 0x19, 0x27, 0x4b, 0x78,
-	0x5d, 0xae, 0x5f, 0x51, 0x9e, 0x4c, 0xea, 0x68, 0x1e, 0xdf, 0x51, 0xe3, 0x6d, 0x05, 0xb3, 0x60,
-	0xc1, 0x8a, 0xb6, 0xc8, 0x5f, 0xe8, 0x57, 0x92, 0x9f, 0x8f, 0x79, 0x0d, 0xfd, 0x8a, 0x8f, 0xc4,
-	0x6c, 0x60, 0xb5, 0xe4, 0xfb, 0xd4, 0x78, 0x29, 0x68, 0x8b, 0xfc, 0x80, 0x7e, 0xc5, 0x9a, 0xfa,
-	0x3a, 0x75, 0xee, 0xd5, 0xea, 0xfe, 0xaa, 0x2d, 0x3b, 0xf2, 0xcf, 0xf2, 0x5f, 0x7e, 0x3f, 0xdf,
-	0x05, 0x00, 0x00, 0xff, 0xff, 0x8c, 0x49, 0xca, 0xd2, 0x92, 0x04, 0x00, 0x00,
+	// 467 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0xad, 0x13, 0x88, 0x92, 0x21, 0x29, 0x30, 0x2a, 0x92, 0x09, 0x15, 0x8a, 0xf6, 0x14, 0x2e,
+	0x8e, 0x14, 0x2e, 0x51, 0x0e, 0x48, 0x40, 0x2a, 0xd4, 0x43, 0x01, 0x19, 0xa8, 0x50, 0xc5, 0x81,
+	0xc5, 0x9e, 0x14, 0xab, 0xb1, 0x77, 0xb1, 0xb7, 0x2d, 0xbe, 0xf0, 0x23, 0x7c, 0x15, 0x12, 0x1f,
+	0x84, 0xb2, 0x6b, 0xc7, 0x32, 0xac, 0x22, 0x47, 0xf4, 0xe4, 0xdd, 0xd9, 0x79, 0x6f, 0xde, 0xcc,
+	0xbe, 0x35, 0xf4, 0x43, 0xba, 0x8a, 0x02, 0xf2, 0x64, 0x2a, 0x94, 0x40, 0x8c, 0xf9, 0x79, 0xcc,
+	0x3d, 0x91, 0x06, 0xb3, 0xd4, 0x33, 0x27, 0xc3, 0x87, 0x7a, 0x37, 0xd1, 0x09, 0xd9, 0x24, 0x10,
+	0x71, 0x2c, 0x12, 0x93, 0xce, 0xde, 0xc3, 0xfe, 0xdb, 0xaf, 0x79, 0x16, 0x05, 0x7c, 0x75, 0x94,
+	0xa8, 0x48, 0xe5, 0x38, 0x84, 0xae, 0x81, 0x1d, 0x2f, 0x5c, 0x67, 0xe4, 0x8c, 0x7b, 0xfe, 0x66,
+	0x8f, 0x08, 0xb7, 0x54, 0x2e, 0xc9, 0x6d, 0xe9, 0xb8, 0x5e, 0xaf, 0x63, 0x51, 0xb2, 0x14, 0x6e,
+	0x7b, 0xe4, 0x8c, 0xfb, 0xbe, 0x5e, 0xb3, 0x1f, 0xf0, 0xd8, 0xa7, 0xf3, 0x28, 0x53, 0x94, 0xbe,
+	0x49, 0x3f, 0xc8, 0x90, 0x2b, 0x5a, 0x68, 0x8e, 0xcc, 0xa7, 0x6f, 0x97, 0x94, 0x29, 0x3c, 0x84,
+	0x5e, 0x42, 0xea, 0x5a, 0xa4, 0x17, 0x9b, 0x32, 0x55, 0x00, 0x9f, 0x41, 0x97, 0xd6, 0x6a, 0x22,
+	0xca, 0xdc, 0xd6, 0xa8, 0x3d, 0xbe, 0x33, 0x65, 0xde, 0xbf, 0x7d, 0x79, 0x75, 0xe5, 0xfe, 0x06,
+	0xc3, 0xe6, 0xd0, 0x5d, 0x94, 0x9a, 0x77, 0xec, 0x87, 0x49, 0x38, 0x78, 0x45, 0xaa, 0x80, 0x27,
+	0x4b, 0xd1, 0x4c, 0xf1, 0x1c, 0x7a, 0x25, 0x6b, 0x29, 0xf9, 0xd0, 0x26, 0xb9, 0x94, 0xe5, 0x57,
+	0xe9, 0xec, 0x97, 0x03, 0x0f, 0xfe, 0x2a, 0x99, 0x49, 0x91, 0x64, 0x84, 0xa7, 0x25, 0xeb, 0x09,
+	0x97, 0xae, 0xa3, 0x59, 0x67, 0x36, 0x56, 0x2b, 0xba, 0xa8, 0x75, 0xc2, 0xe5, 0x51, 0xa2, 0xd2,
+	0xdc, 0xaf, 0xa8, 0x86, 0x9f, 0x61, 0xbf, 0x7e, 0x88, 0xf7, 0xa0, 0x7d, 0x41, 0x79, 0xd1, 0xd7,
+	0x7a, 0x89, 0x33, 0xb8, 0x7d, 0xc5, 0x57, 0x97, 0x66, 0x38, 0xcd, 0x2e, 0xc0, 0x00, 0xe6, 0xad,
+	0x99, 0xb3, 0x9e, 0xe2, 0x82, 0x56, 0xb4, 0xe3, 0xbd, 0xff, 0xcf, 0x14, 0x7f, 0x3a, 0xf0, 0xe8,
+	0xa5, 0x88, 0x25, 0x4f, 0xe9, 0x79, 0x12, 0xbe, 0xbb, 0xe6, 0xd2, 0x64, 0x35, 0xad, 0xdc, 0xd1,
+	0xee, 0xc9, 0x77, 0x68, 0xb7, 0x40, 0x20, 0x83, 0x3e, 0x7d, 0x97, 0x14, 0x28, 0x0a, 0x8f, 0xab,
+	0x97, 0x50, 0x8b, 0x4d, 0x7f, 0xb7, 0xa1, 0x63, 0xf4, 0xe0, 0x19, 0xdc, 0x2d, 0x1f, 0x47, 0x31,
+	0x1c, 0x9c, 0xda, 0xaa, 0x6d, 0x7f, 0x41, 0xc3, 0xfb, 0x35, 0xcc, 0xa9, 0x88, 0x42, 0xb6, 0x87,
+	0x1f, 0x61, 0x50, 0x4b, 0xbe, 0x39, 0xe6, 0x25, 0x0c, 0x6a, 0x2e, 0xc3, 0x71, 0x03, 0x23, 0x1a,
+	0xbe, 0x27, 0x8d, 0x2d, 0xcb, 0xf6, 0xf0, 0x35, 0x0c, 0x6a, 0xc6, 0xb1, 0xd7, 0xb1, 0x79, 0xcb,
+	0xae, 0xfb, 0x13, 0x1c, 0xd8, 0x5c, 0x81, 0x13, 0x1b, 0xed, 0x16, 0xff, 0x58, 0xd9, 0x5f, 0x74,
+	0xcf, 0x3a, 0xe6, 0xaf, 0xfa, 0xc5, 0x7c, 0x9f, 0xfe, 0x19, 0x00, 0x55, 0x40, 0xbf, 0xa0, 0x8e,
+	0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -369,6 +431,9 @@ type DeviceClient interface {
 	UpdateDevices(ctx context.Context, in *RegisterOrUpdateDevicesRequest, opts ...grpc.CallOption) (*protos.Void, error)
 	GetDeviceInfo(ctx context.Context, in *GetDeviceInfoRequest, opts ...grpc.CallOption) (*GetDeviceInfoResponse, error)
 	DeleteDevices(ctx context.Context, in *DeleteDevicesRequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// CompareAndSwapDevice updates the device only if its current info is
+	// the expected one, it fails with ABORTED otherwise
+	CompareAndSwapDevice(ctx context.Context, in *CompareAndSwapDeviceRequest, opts ...grpc.CallOption) (*protos.Void, error)
 }
 
 type deviceClient struct {
@@ -415,12 +480,24 @@ func (c *deviceClient) DeleteDevices(ctx context.Context, in *DeleteDevicesReque
 	return out, nil
 }
 
+func (c *deviceClient) CompareAndSwapDevice(ctx context.Context, in *CompareAndSwapDeviceRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.device.Device/CompareAndSwapDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeviceServer is the server API for Device service.
 type DeviceServer interface {
 	RegisterDevices(context.Context, *RegisterOrUpdateDevicesRequest) (*protos.Void, error)
 	UpdateDevices(context.Context, *RegisterOrUpdateDevicesRequest) (*protos.Void, error)
 	GetDeviceInfo(context.Context, *GetDeviceInfoRequest) (*GetDeviceInfoResponse, error)
 	DeleteDevices(context.Context, *DeleteDevicesRequest) (*protos.Void, error)
+	// CompareAndSwapDevice updates the device only if its current info is
+	// the expected one, it fails with ABORTED otherwise
+	CompareAndSwapDevice(context.Context, *CompareAndSwapDeviceRequest) (*protos.Void, error)
 }
 
 // UnimplementedDeviceServer can be embedded to have forward compatible implementations.
@@ -439,6 +516,9 @@ func (*UnimplementedDeviceServer) GetDeviceInfo(ctx context.Context, req *GetDev
 func (*UnimplementedDeviceServer) DeleteDevices(ctx context.Context, req *DeleteDevicesRequest) (*protos.Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevices not implemented")
 }
+func (*UnimplementedDeviceServer) CompareAndSwapDevice(ctx context.Context, req *CompareAndSwapDeviceRequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareAndSwapDevice not implemented")
+}
 
 func RegisterDeviceServer(s *grpc.Server, srv DeviceServer) {
 	s.RegisterService(&_Device_serviceDesc, srv)
@@ -516,6 +596,24 @@ func _Device_DeleteDevices_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Device_CompareAndSwapDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareAndSwapDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServer).CompareAndSwapDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.device.Device/CompareAndSwapDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServer).CompareAndSwapDevice(ctx, req.(*CompareAndSwapDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Device_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.orc8r.device.Device",
 	HandlerType: (*DeviceServer)(nil),
@@ -536,6 +634,10 @@ var _Device_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDevices",
 			Handler:    _Device_DeleteDevices_Handler,
 		},
+		{
+			MethodName: "CompareAndSwapDevice",
+			Handler:    _Device_CompareAndSwapDevice_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "device.proto",
@@ -9,14 +9,18 @@ LICENSE file in the root directory of this source tree.
 package servicers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"magma/orc8r/cloud/go/blobstore"
 	commonProtos "magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/device/protos"
+	"magma/orc8r/cloud/go/storage"
 
 	"github.com/thoas/go-funk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type deviceServicer struct {
@@ -117,3 +121,44 @@ func (srv *deviceServicer) DeleteDevices(ctx context.Context, req *protos.Delete
 	}
 	return void, store.Commit()
 }
+
+func (srv *deviceServicer) CompareAndSwapDevice(ctx context.Context, req *protos.CompareAndSwapDeviceRequest) (*commonProtos.Void, error) {
+	void := &commonProtos.Void{}
+	if err := ValidateCompareAndSwapDeviceRequest(req); err != nil {
+		return void, err
+	}
+
+	blob := protos.EntitiesToBlobs([]*protos.PhysicalEntity{req.Entity})[0]
+	store, err := srv.factory.StartTransaction(nil)
+	if err != nil {
+		return nil, err
+	}
+	// Incrementing the version first locks the device until the swap is
+	// committed
+	tk := storage.TypeAndKey{Type: blob.Type, Key: blob.Key}
+	if err = store.IncrementVersion(req.NetworkID, tk); err != nil {
+		store.Rollback()
+		return void, err
+	}
+	current, err := store.Get(req.NetworkID, tk)
+	if err != nil {
+		store.Rollback()
+		return void, err
+	}
+	// Locking a device which doesn't exist creates an empty blob, which the
+	// rollback discards
+	if len(current.Value) == 0 {
+		store.Rollback()
+		return void, status.Errorf(codes.NotFound, "device %s is not registered", blob.Key)
+	}
+	if !bytes.Equal(current.Value, req.ExpectedInfo) {
+		store.Rollback()
+		return void, status.Errorf(codes.Aborted, "device %s was modified", blob.Key)
+	}
+	err = store.CreateOrUpdate(req.NetworkID, []blobstore.Blob{blob})
+	if err != nil {
+		store.Rollback()
+		return void, err
+	}
+	return void, store.Commit()
+}
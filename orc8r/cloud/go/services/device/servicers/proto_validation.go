@@ -35,6 +35,16 @@ func ValidateDeleteDevicesRequest(req *protos.DeleteDevicesRequest) error {
 	return nonEmptyNetworkIDAndDeviceIDs(req.GetNetworkID(), req.GetDeviceIDs())
 }
 
+func ValidateCompareAndSwapDeviceRequest(req *protos.CompareAndSwapDeviceRequest) error {
+	if err := nonEmptyNetworkID(req.GetNetworkID()); err != nil {
+		return err
+	}
+	if req.GetEntity() == nil {
+		return fmt.Errorf("Entity field must be non-empty")
+	}
+	return deserializableWithSerde([]*protos.PhysicalEntity{req.GetEntity()})
+}
+
 func deserializableWithSerde(entities []*protos.PhysicalEntity) error {
 	for _, entity := range entities {
 		_, err := serde.Deserialize(device.SerdeDomain, entity.GetType(), entity.GetInfo())
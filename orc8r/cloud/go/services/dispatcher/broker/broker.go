@@ -10,10 +10,14 @@ package broker
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/dispatcher/broker/memstore"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
+
+	"github.com/golang/glog"
 )
 
 const (
@@ -21,6 +25,14 @@ const (
 	queueLen               = 50
 )
 
+// ReplayResponseTimeout is how long brokers created afterwards wait for the
+// response to a replayed request before cancelling the request
+var ReplayResponseTimeout = time.Second * 15
+
+// ErrOfflineQueueDisabled is returned when queueing a request for a
+// disconnected gateway while the broker has no offline queue
+var ErrOfflineQueueDisabled = errors.New("offline request queue is not enabled")
+
 type GatewayResponseChannel struct {
 	RespChan chan *protos.GatewayResponse
 	ReqId    uint32
@@ -44,20 +56,57 @@ type GatewayRPCBroker interface {
 	InitializeGateway(gwId string) chan *protos.SyncRPCRequest
 	// CleanUpGateway cleans up the data and resources for a gwId when the gw loses SyncRPC connection to the cloud.
 	CleanupGateway(gwId string) error
-	// CancelGatewayRequest notifies the gateway to stop handling the request with ID reqId.
+	// CancelGatewayRequest notifies the gateway to stop handling the request with ID reqId,
+	// and drops any later response to it.
 	CancelGatewayRequest(gwId string, reqId uint32) error
+	// IsGatewayConnected returns true if the gateway with gwId has an active
+	// SyncRPC stream to this dispatcher instance.
+	IsGatewayConnected(gwId string) bool
+	// QueueRequestForGateway persists a request for a disconnected gateway,
+	// it's sent to the gateway when it reconnects, unless it expires after ttl.
+	// Returns the ID of the queued request.
+	QueueRequestForGateway(gwReq *protos.GatewayRequest, ttl time.Duration) (string, error)
 }
 
 // GatewayRPCBrokerImpl implements a GatewayRPCBroker, managing a response table and request queue.
 type GatewayRPCBrokerImpl struct {
 	responseTable memstore.ResponseTable
 	requests      memstore.RequestQueue
+	// offlineQueue keeps requests for disconnected gateways, nil if disabled
+	offlineQueue *offline.Queue
+	// replayLocks serialize replays of each gateway's offline requests, by
+	// gateway ID. A gateway's lock is only kept while replays of its
+	// requests are running or waiting to run.
+	replayLocks   map[string]*replayLock
+	replayLocksMu sync.Mutex
+	// replayResponseTimeout is the broker's ReplayResponseTimeout
+	replayResponseTimeout time.Duration
+}
+
+// replayLock is a gateway's replay lock, along with the number of replays
+// holding or waiting for it
+type replayLock struct {
+	sync.Mutex
+	refs int
 }
 
 func NewGatewayReqRespBroker() *GatewayRPCBrokerImpl {
+	return NewGatewayReqRespBrokerWithOfflineQueue(nil)
+}
+
+// NewGatewayReqRespBrokerWithOfflineQueue creates a broker which keeps
+// requests for disconnected gateways in offlineQueue and replays them
+// when the gateways reconnect
+func NewGatewayReqRespBrokerWithOfflineQueue(offlineQueue *offline.Queue) *GatewayRPCBrokerImpl {
 	respTable := memstore.NewResponseTable(processResponseTimeout)
 	requests := memstore.NewRequestQueue(queueLen)
-	return &GatewayRPCBrokerImpl{responseTable: respTable, requests: requests}
+	return &GatewayRPCBrokerImpl{
+		responseTable:         respTable,
+		requests:              requests,
+		offlineQueue:          offlineQueue,
+		replayLocks:           map[string]*replayLock{},
+		replayResponseTimeout: ReplayResponseTimeout,
+	}
 }
 
 func (broker *GatewayRPCBrokerImpl) SendRequestToGateway(
@@ -83,6 +132,10 @@ func (broker *GatewayRPCBrokerImpl) InitializeGateway(gwId string) chan *protos.
 	// Also returns the old queue that requests in which can be cancelled.
 	// As we don't do anything now, the requests will just time out.
 	initializedQueue := broker.requests.InitializeQueue(gwId)
+	if broker.offlineQueue != nil {
+		// the caller starts listening on the queue after this returns
+		go broker.replayQueuedRequests(gwId)
+	}
 	return initializedQueue.NewQueue
 }
 
@@ -94,9 +147,120 @@ func (broker *GatewayRPCBrokerImpl) CleanupGateway(gwId string) error {
 }
 
 func (broker *GatewayRPCBrokerImpl) CancelGatewayRequest(gwId string, reqId uint32) error {
+	broker.responseTable.CancelResponse(reqId)
 	syncRPCRequest := &protos.SyncRPCRequest{ReqId: reqId, ReqBody: &protos.GatewayRequest{GwId: gwId}, ConnClosed: true}
 	if err := broker.requests.Enqueue(syncRPCRequest); err != nil {
 		return err
 	}
 	return nil
 }
+
+func (broker *GatewayRPCBrokerImpl) IsGatewayConnected(gwId string) bool {
+	return broker.requests.HasQueue(gwId)
+}
+
+func (broker *GatewayRPCBrokerImpl) QueueRequestForGateway(gwReq *protos.GatewayRequest, ttl time.Duration) (string, error) {
+	if broker.offlineQueue == nil {
+		return "", ErrOfflineQueueDisabled
+	}
+	queuedReq, err := broker.offlineQueue.Push(gwReq, ttl)
+	if err != nil {
+		return "", err
+	}
+	// the gateway may have connected since the caller checked
+	if broker.IsGatewayConnected(gwReq.GwId) {
+		go broker.replayQueuedRequests(gwReq.GwId)
+	}
+	return queuedReq.Id, nil
+}
+
+// replayQueuedRequests sends the gateway's queued requests to the gateway in
+// order. A request is claimed, i.e. removed from the offline queue, before
+// it's added to the gateway's request queue, so it's replayed at most once
+// even if several dispatcher instances replay the gateway's queue. Replay
+// stops at the first request which can't be claimed or sent, a claimed
+// request which can't be sent is put back in the offline queue.
+func (broker *GatewayRPCBrokerImpl) replayQueuedRequests(gwId string) {
+	defer broker.lockReplay(gwId)()
+	queuedReqs, err := broker.offlineQueue.List(gwId)
+	if err != nil {
+		glog.Errorf("Failed to list queued requests for hwId %v: %v\n", gwId, err)
+		return
+	}
+	for _, queuedReq := range queuedReqs {
+		if !broker.IsGatewayConnected(gwId) {
+			return
+		}
+		claimed, err := broker.offlineQueue.Claim(gwId, queuedReq.Id)
+		if err != nil {
+			glog.Errorf("Failed to claim queued request %v for hwId %v: %v\n", queuedReq.Id, gwId, err)
+			return
+		}
+		if !claimed {
+			// replayed by another dispatcher instance or removed meanwhile
+			continue
+		}
+		respChannel, err := broker.SendRequestToGateway(queuedReq.Request)
+		if err != nil {
+			glog.Errorf("Failed to replay queued request %v for hwId %v: %v\n", queuedReq.Id, gwId, err)
+			if err = broker.offlineQueue.Requeue(queuedReq); err != nil {
+				glog.Errorf("Failed to requeue request %v for hwId %v: %v\n", queuedReq.Id, gwId, err)
+			}
+			return
+		}
+		go broker.awaitReplayedResponse(gwId, queuedReq.Id, respChannel)
+	}
+}
+
+// lockReplay locks the replay lock of the gateway, and returns the function
+// unlocking it. The lock is deleted once no replays hold or wait for it.
+func (broker *GatewayRPCBrokerImpl) lockReplay(gwId string) func() {
+	broker.replayLocksMu.Lock()
+	lock, ok := broker.replayLocks[gwId]
+	if !ok {
+		lock = &replayLock{}
+		broker.replayLocks[gwId] = lock
+	}
+	lock.refs++
+	broker.replayLocksMu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		broker.replayLocksMu.Lock()
+		defer broker.replayLocksMu.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(broker.replayLocks, gwId)
+		}
+	}
+}
+
+// awaitReplayedResponse receives and logs the response to a replayed request,
+// nobody else is waiting for it. The request is cancelled if the gateway
+// doesn't respond in time.
+func (broker *GatewayRPCBrokerImpl) awaitReplayedResponse(gwId string, id string, respChannel *GatewayResponseChannel) {
+	for {
+		select {
+		case gwResp, ok := <-respChannel.RespChan:
+			if !ok || gwResp == nil {
+				return
+			}
+			if gwResp.KeepConnActive {
+				continue
+			}
+			if len(gwResp.Err) != 0 {
+				glog.Errorf("Replayed request %v for hwId %v failed: %v\n", id, gwId, gwResp.Err)
+			} else {
+				glog.V(2).Infof("Replayed request %v for hwId %v, status: %v\n", id, gwId, gwResp.Status)
+			}
+			return
+		case <-time.After(broker.replayResponseTimeout):
+			glog.Errorf("Timed out waiting for response to replayed request %v for hwId %v\n", id, gwId)
+			if err := broker.CancelGatewayRequest(gwId, respChannel.ReqId); err != nil {
+				glog.Errorf("Failed to cancel replayed request %v for hwId %v: %v\n", id, gwId, err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package broker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayRPCBrokerImpl_ReplayLocks(t *testing.T) {
+	offlineQueue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	broker := NewGatewayReqRespBrokerWithOfflineQueue(offlineQueue)
+	for _, gwId := range []string{"gw1", "gw2"} {
+		_, err := offlineQueue.Push(&protos.GatewayRequest{GwId: gwId, Authority: "magmad", Path: "/magma.orc8r.Magmad/Reboot"}, time.Hour)
+		assert.NoError(t, err)
+		broker.requests.InitializeQueue(gwId)
+	}
+
+	// concurrent replays of the same and different gateways don't keep
+	// their locks once they're done
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		for _, gwId := range []string{"gw1", "gw2"} {
+			wg.Add(1)
+			go func(gwId string) {
+				defer wg.Done()
+				broker.replayQueuedRequests(gwId)
+			}(gwId)
+		}
+	}
+	wg.Wait()
+	assert.Empty(t, broker.replayLocks)
+	for _, gwId := range []string{"gw1", "gw2"} {
+		queuedReqs, err := offlineQueue.List(gwId)
+		assert.NoError(t, err)
+		assert.Empty(t, queuedReqs)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package broker_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayRPCBrokerImpl_ReplayQueuedRequests(t *testing.T) {
+	offlineQueue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	gwBroker := broker.NewGatewayReqRespBrokerWithOfflineQueue(offlineQueue)
+
+	req1 := &protos.GatewayRequest{GwId: "gw1", Authority: "magmad", Path: "/magma.orc8r.Magmad/Reboot"}
+	req2 := &protos.GatewayRequest{GwId: "gw1", Authority: "magmad", Path: "/magma.orc8r.Magmad/RestartServices"}
+	assert.False(t, gwBroker.IsGatewayConnected("gw1"))
+	id1, err := gwBroker.QueueRequestForGateway(req1, time.Hour)
+	assert.NoError(t, err)
+	id2, err := gwBroker.QueueRequestForGateway(req2, time.Hour)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id1, id2)
+
+	// queued requests are replayed in order when the gateway connects
+	queue := gwBroker.InitializeGateway("gw1")
+	assert.True(t, gwBroker.IsGatewayConnected("gw1"))
+	syncReq1 := receiveRequest(t, queue)
+	assert.Equal(t, protos.TestMarshal(req1), protos.TestMarshal(syncReq1.ReqBody))
+	syncReq2 := receiveRequest(t, queue)
+	assert.Equal(t, protos.TestMarshal(req2), protos.TestMarshal(syncReq2.ReqBody))
+	// the broker receives the responses
+	err = gwBroker.ProcessGatewayResponse(&protos.SyncRPCResponse{
+		ReqId:    syncReq1.ReqId,
+		RespBody: &protos.GatewayResponse{Status: "200"},
+	})
+	assert.NoError(t, err)
+	assertQueueEmpty(t, offlineQueue, "gw1")
+
+	// requests queued while the gateway is connected are replayed right away
+	req3 := &protos.GatewayRequest{GwId: "gw1", Authority: "magmad", Path: "/magma.orc8r.Magmad/Reboot"}
+	_, err = gwBroker.QueueRequestForGateway(req3, time.Hour)
+	assert.NoError(t, err)
+	syncReq3 := receiveRequest(t, queue)
+	assert.Equal(t, protos.TestMarshal(req3), protos.TestMarshal(syncReq3.ReqBody))
+	assertQueueEmpty(t, offlineQueue, "gw1")
+
+	// requests are kept until the gateway reconnects
+	assert.NoError(t, gwBroker.CleanupGateway("gw1"))
+	_, err = gwBroker.QueueRequestForGateway(req1, time.Hour)
+	assert.NoError(t, err)
+	queuedReqs, err := offlineQueue.List("gw1")
+	assert.NoError(t, err)
+	assert.Len(t, queuedReqs, 1)
+}
+
+func TestGatewayRPCBrokerImpl_ReplayResponseTimeout(t *testing.T) {
+	replayResponseTimeout := broker.ReplayResponseTimeout
+	defer func() { broker.ReplayResponseTimeout = replayResponseTimeout }()
+	broker.ReplayResponseTimeout = time.Millisecond * 100
+
+	offlineQueue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	gwBroker := broker.NewGatewayReqRespBrokerWithOfflineQueue(offlineQueue)
+	req := &protos.GatewayRequest{GwId: "gw1", Authority: "magmad", Path: "/magma.orc8r.Magmad/Reboot"}
+	_, err := gwBroker.QueueRequestForGateway(req, time.Hour)
+	assert.NoError(t, err)
+
+	// replayed requests the gateway doesn't respond to are cancelled
+	queue := gwBroker.InitializeGateway("gw1")
+	syncReq := receiveRequest(t, queue)
+	assert.False(t, syncReq.ConnClosed)
+	cancelReq := receiveRequest(t, queue)
+	assert.True(t, cancelReq.ConnClosed)
+	assert.Equal(t, syncReq.ReqId, cancelReq.ReqId)
+	// and late responses are dropped
+	err = gwBroker.ProcessGatewayResponse(&protos.SyncRPCResponse{
+		ReqId:    syncReq.ReqId,
+		RespBody: &protos.GatewayResponse{Status: "200"},
+	})
+	assert.Error(t, err)
+}
+
+func TestGatewayRPCBrokerImpl_OfflineQueueDisabled(t *testing.T) {
+	gwBroker := broker.NewGatewayReqRespBroker()
+	_, err := gwBroker.QueueRequestForGateway(&protos.GatewayRequest{GwId: "gw1"}, time.Hour)
+	assert.Equal(t, broker.ErrOfflineQueueDisabled, err)
+}
+
+func receiveRequest(t *testing.T, queue chan *protos.SyncRPCRequest) *protos.SyncRPCRequest {
+	select {
+	case req := <-queue:
+		return req
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for replayed request")
+	}
+	return nil
+}
+
+// assertQueueEmpty waits for the replayed requests to be removed from the
+// offline queue
+func assertQueueEmpty(t *testing.T, queue *offline.Queue, gwId string) {
+	for i := 0; i < 50; i++ {
+		queuedReqs, err := queue.List(gwId)
+		assert.NoError(t, err)
+		if len(queuedReqs) == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	t.Fatalf("queued requests of %s were not removed", gwId)
+}
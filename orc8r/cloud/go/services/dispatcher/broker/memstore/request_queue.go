@@ -27,6 +27,7 @@ type RequestQueue interface {
 	InitializeQueue(gwId string) InitializedQueue
 	CleanupQueue(gwId string) chan *protos.SyncRPCRequest
 	Enqueue(req *protos.SyncRPCRequest) error
+	HasQueue(gwId string) bool
 }
 
 type requestQueueImpl struct {
@@ -74,6 +75,14 @@ func (queues *requestQueueImpl) CleanupQueue(gwId string) chan *protos.SyncRPCRe
 	return nil
 }
 
+// HasQueue returns true if a queue is initialized for gatewayId gwId
+func (queues *requestQueueImpl) HasQueue(gwId string) bool {
+	queues.RLock()
+	defer queues.RUnlock()
+	_, ok := queues.reqQueueByGwId[gwId]
+	return ok
+}
+
 // Enqueue adds a SyncRPCRequest to the queue of gatewayId gwId.
 // gwId cannot be empty string, gwReq or ReqId of gwReq cannot be nil.
 // gwId: key of the syncRPCReqQueue map
@@ -67,3 +67,13 @@ func enqueueGwWithReqId(t *testing.T, queue memstore.RequestQueue, gwId string,
 	err := queue.Enqueue(req)
 	assert.NoError(t, err)
 }
+
+func TestRequestQueueImpl_HasQueue(t *testing.T) {
+	queue := memstore.NewRequestQueue(1)
+	assert.False(t, queue.HasQueue("gwId1"))
+	queue.InitializeQueue("gwId1")
+	assert.True(t, queue.HasQueue("gwId1"))
+	assert.False(t, queue.HasQueue("gwId2"))
+	queue.CleanupQueue("gwId1")
+	assert.False(t, queue.HasQueue("gwId1"))
+}
@@ -23,6 +23,7 @@ import (
 type ResponseTable interface {
 	InitializeResponse() (chan *protos.GatewayResponse, uint32)
 	SendResponse(*protos.SyncRPCResponse) error
+	CancelResponse(reqId uint32)
 }
 
 type ResponseTableImpl struct {
@@ -72,6 +73,12 @@ func (table *ResponseTableImpl) SendResponse(resp *protos.SyncRPCResponse) error
 	}
 }
 
+// CancelResponse deletes the response channel of the request with ID reqId,
+// later responses to the request are dropped.
+func (table *ResponseTableImpl) CancelResponse(reqId uint32) {
+	table.respChanByReqId.Delete(reqId)
+}
+
 func generateReqId(counter *uint32) uint32 {
 	return atomic.AddUint32(counter, 1)
 }
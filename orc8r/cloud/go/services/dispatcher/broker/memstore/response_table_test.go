@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package memstore_test
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -59,6 +60,15 @@ func TestResponseTableImpl_SendResponseTimeout(t *testing.T) {
 	assert.EqualError(t, err, "sendResponse timed out as respChan is not being actively waited on")
 }
 
+func TestResponseTableImpl_CancelResponse(t *testing.T) {
+	table := memstore.NewResponseTable(time.Second * 3)
+	_, reqId := table.InitializeResponse()
+	table.CancelResponse(reqId)
+	gwResp := &protos.GatewayResponse{Status: "200", Payload: []byte("test payload")}
+	err := table.SendResponse(&protos.SyncRPCResponse{ReqId: reqId, RespBody: gwResp})
+	assert.EqualError(t, err, fmt.Sprintf("No response channel found for reqId %v\n", reqId))
+}
+
 // initialize response with respChan, waits for a response and expect it to equal expectedGwResp
 // create a response channel and waits on it for response. Meanwhile in another goroutine, initializeResponse on the
 // table, and send response back. Assert the response is sent back to the correct channel.
@@ -14,6 +14,7 @@ package mocks
 import broker "magma/orc8r/cloud/go/services/dispatcher/broker"
 import mock "github.com/stretchr/testify/mock"
 import protos "magma/orc8r/cloud/go/protos"
+import time "time"
 
 // GatewayRPCBroker is an autogenerated mock type for the GatewayRPCBroker type
 type GatewayRPCBroker struct {
@@ -64,6 +65,20 @@ func (_m *GatewayRPCBroker) InitializeGateway(gwId string) chan *protos.SyncRPCR
 	return r0
 }
 
+// IsGatewayConnected provides a mock function with given fields: gwId
+func (_m *GatewayRPCBroker) IsGatewayConnected(gwId string) bool {
+	ret := _m.Called(gwId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(gwId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // ProcessGatewayResponse provides a mock function with given fields: response
 func (_m *GatewayRPCBroker) ProcessGatewayResponse(response *protos.SyncRPCResponse) error {
 	ret := _m.Called(response)
@@ -78,6 +93,27 @@ func (_m *GatewayRPCBroker) ProcessGatewayResponse(response *protos.SyncRPCRespo
 	return r0
 }
 
+// QueueRequestForGateway provides a mock function with given fields: gwReq, ttl
+func (_m *GatewayRPCBroker) QueueRequestForGateway(gwReq *protos.GatewayRequest, ttl time.Duration) (string, error) {
+	ret := _m.Called(gwReq, ttl)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*protos.GatewayRequest, time.Duration) string); ok {
+		r0 = rf(gwReq, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*protos.GatewayRequest, time.Duration) error); ok {
+		r1 = rf(gwReq, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendRequestToGateway provides a mock function with given fields: gwReq
 func (_m *GatewayRPCBroker) SendRequestToGateway(gwReq *protos.GatewayRequest) (*broker.GatewayResponseChannel, error) {
 	ret := _m.Called(gwReq)
@@ -0,0 +1,324 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package offline implements a durable queue of SyncRPC requests sent to
+// gateways which are not connected to any dispatcher instance. The queue is
+// kept in a datastore shared by all dispatcher instances, so the requests
+// can be replayed by whichever instance the gateway reconnects to.
+package offline
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	dispatcherprotos "magma/orc8r/cloud/go/services/dispatcher/protos"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	// DBTableName is the blobstore table of the offline queue. Queued
+	// requests are stored under the hardware ID of their gateway, so a
+	// gateway's queue is read with a lookup on the table's primary key.
+	DBTableName = "dispatcher_offline_queue"
+
+	queuedRequestType = "queued_request"
+
+	// gatewaysNetworkID groups the blobs which track the gateways with queued
+	// requests, so garbage collection doesn't have to scan the whole table
+	gatewaysNetworkID = "offline_queue_gateways"
+	gatewayType       = "gateway"
+)
+
+// MaxRequestTTL is the longest time a request can stay queued
+var MaxRequestTTL = time.Hour * 24 * 7
+
+type Queue struct {
+	factory blobstore.BlobStorageFactory
+}
+
+func NewQueue(factory blobstore.BlobStorageFactory) *Queue {
+	return &Queue{factory: factory}
+}
+
+// Push adds the request to the queue of its gateway, the request expires
+// after ttl (capped by MaxRequestTTL)
+func (q *Queue) Push(gwReq *protos.GatewayRequest, ttl time.Duration) (*dispatcherprotos.QueuedRequest, error) {
+	if gwReq == nil || len(gwReq.GwId) == 0 || gwReq.GwId == gatewaysNetworkID {
+		return nil, errors.New("GatewayRequest cannot be nil and gwId has to be valid")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("invalid queued request TTL: %v", ttl)
+	}
+	if ttl > MaxRequestTTL {
+		ttl = MaxRequestTTL
+	}
+	now := clock.Now().UTC()
+	queuedReq := &dispatcherprotos.QueuedRequest{Id: newRequestID(now), Request: gwReq}
+	queuedReq.QueuedAt, _ = ptypes.TimestampProto(now)
+	queuedReq.ExpiresAt, _ = ptypes.TimestampProto(now.Add(ttl))
+	marshaledReq, err := proto.Marshal(queuedReq)
+	if err != nil {
+		return nil, fmt.Errorf("QueuedRequest Marshal error: %s", err)
+	}
+
+	store, err := q.factory.StartTransaction(nil)
+	if err != nil {
+		return nil, err
+	}
+	err = store.CreateOrUpdate(gwReq.GwId, []blobstore.Blob{{Type: queuedRequestType, Key: queuedReq.Id, Value: marshaledReq}})
+	if err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("QueuedRequest PUT error for %s: %s", gwReq.GwId, err)
+	}
+	err = store.CreateOrUpdate(gatewaysNetworkID, []blobstore.Blob{{Type: gatewayType, Key: gwReq.GwId, Value: []byte{}}})
+	if err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("failed to track queue of %s: %s", gwReq.GwId, err)
+	}
+	return queuedReq, store.Commit()
+}
+
+// List returns the gateway's queued requests which haven't expired yet,
+// oldest first
+func (q *Queue) List(gwId string) ([]*dispatcherprotos.QueuedRequest, error) {
+	store, err := q.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	queuedReqs, err := searchQueuedRequests(store, gwId)
+	if err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("Get QueuedRequests error for %s: %s", gwId, err)
+	}
+	if err = store.Commit(); err != nil {
+		return nil, err
+	}
+	var ret []*dispatcherprotos.QueuedRequest
+	now := clock.Now()
+	for _, stored := range queuedReqs {
+		if stored.request == nil || isExpired(stored.request, now) {
+			continue
+		}
+		ret = append(ret, stored.request)
+	}
+	return ret, nil
+}
+
+// Remove removes the request with ID id from the gateway's queue
+func (q *Queue) Remove(gwId string, id string) error {
+	store, err := q.factory.StartTransaction(nil)
+	if err != nil {
+		return err
+	}
+	if err = store.Delete(gwId, []storage.TypeAndKey{{Type: queuedRequestType, Key: id}}); err != nil {
+		store.Rollback()
+		return fmt.Errorf("QueuedRequest DELETE error for %s/%s: %s", gwId, id, err)
+	}
+	return store.Commit()
+}
+
+// Claim removes the request with ID id from the gateway's queue and returns
+// true if this call removed it. Concurrent claims of a request are
+// serialized, only one of them returns true, so a request claimed by several
+// dispatcher instances is replayed once.
+func (q *Queue) Claim(gwId string, id string) (bool, error) {
+	store, err := q.factory.StartTransaction(nil)
+	if err != nil {
+		return false, err
+	}
+	tk := storage.TypeAndKey{Type: queuedRequestType, Key: id}
+	// incrementing the version locks the request until the claim commits,
+	// a request which doesn't exist (anymore) is created empty and the
+	// rollback discards it
+	if err = store.IncrementVersion(gwId, tk); err != nil {
+		store.Rollback()
+		return false, fmt.Errorf("failed to lock queued request %s/%s: %s", gwId, id, err)
+	}
+	blob, err := store.Get(gwId, tk)
+	if err != nil {
+		store.Rollback()
+		return false, fmt.Errorf("QueuedRequest GET error for %s/%s: %s", gwId, id, err)
+	}
+	if len(blob.Value) == 0 {
+		return false, store.Rollback()
+	}
+	if err = store.Delete(gwId, []storage.TypeAndKey{tk}); err != nil {
+		store.Rollback()
+		return false, fmt.Errorf("QueuedRequest DELETE error for %s/%s: %s", gwId, id, err)
+	}
+	if err = store.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Requeue puts a claimed request back in its gateway's queue, with its
+// original ID and expiry
+func (q *Queue) Requeue(queuedReq *dispatcherprotos.QueuedRequest) error {
+	gwId := queuedReq.GetRequest().GetGwId()
+	if len(gwId) == 0 || gwId == gatewaysNetworkID {
+		return errors.New("QueuedRequest must have a valid gwId")
+	}
+	marshaledReq, err := proto.Marshal(queuedReq)
+	if err != nil {
+		return fmt.Errorf("QueuedRequest Marshal error: %s", err)
+	}
+	store, err := q.factory.StartTransaction(nil)
+	if err != nil {
+		return err
+	}
+	err = store.CreateOrUpdate(gwId, []blobstore.Blob{{Type: queuedRequestType, Key: queuedReq.Id, Value: marshaledReq}})
+	if err != nil {
+		store.Rollback()
+		return fmt.Errorf("QueuedRequest PUT error for %s: %s", gwId, err)
+	}
+	err = store.CreateOrUpdate(gatewaysNetworkID, []blobstore.Blob{{Type: gatewayType, Key: gwId, Value: []byte{}}})
+	if err != nil {
+		store.Rollback()
+		return fmt.Errorf("failed to track queue of %s: %s", gwId, err)
+	}
+	return store.Commit()
+}
+
+// Purge removes all requests queued for the gateway
+func (q *Queue) Purge(gwId string) error {
+	store, err := q.factory.StartTransaction(nil)
+	if err != nil {
+		return err
+	}
+	keys, err := store.ListKeys(gwId, queuedRequestType)
+	if err != nil {
+		store.Rollback()
+		return fmt.Errorf("failed to list queued requests of %s: %s", gwId, err)
+	}
+	if err = deleteQueuedRequests(store, gwId, keys, true); err != nil {
+		store.Rollback()
+		return fmt.Errorf("failed to purge queued requests of %s: %s", gwId, err)
+	}
+	return store.Commit()
+}
+
+// CollectGarbage removes expired requests of all gateways, and stops tracking
+// the gateways whose queues are empty. A request pushed while its gateway's
+// queue is collected may remain untracked until the next push, it's still
+// dropped once it expires.
+func (q *Queue) CollectGarbage() error {
+	store, err := q.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	gwIds, err := store.ListKeys(gatewaysNetworkID, gatewayType)
+	if err != nil {
+		store.Rollback()
+		return fmt.Errorf("failed to list gateways with queued requests: %s", err)
+	}
+	if err = store.Commit(); err != nil {
+		return err
+	}
+	removed := 0
+	for _, gwId := range gwIds {
+		removedForGw, err := q.collectGatewayGarbage(gwId)
+		if err != nil {
+			return err
+		}
+		removed += removedForGw
+	}
+	glog.V(2).Infof("Removed %d expired queued requests", removed)
+	return nil
+}
+
+func (q *Queue) collectGatewayGarbage(gwId string) (int, error) {
+	store, err := q.factory.StartTransaction(nil)
+	if err != nil {
+		return 0, err
+	}
+	queuedReqs, err := searchQueuedRequests(store, gwId)
+	if err != nil {
+		store.Rollback()
+		return 0, fmt.Errorf("failed to get queued requests of %s: %s", gwId, err)
+	}
+	var staleKeys []string
+	now := clock.Now()
+	for _, stored := range queuedReqs {
+		if stored.request == nil || isExpired(stored.request, now) {
+			staleKeys = append(staleKeys, stored.id)
+		}
+	}
+	if err = deleteQueuedRequests(store, gwId, staleKeys, len(staleKeys) == len(queuedReqs)); err != nil {
+		store.Rollback()
+		return 0, fmt.Errorf("failed to delete queued requests of %s: %s", gwId, err)
+	}
+	return len(staleKeys), store.Commit()
+}
+
+type storedRequest struct {
+	id string
+	// request is nil if the stored request can't be unmarshaled
+	request *dispatcherprotos.QueuedRequest
+}
+
+// searchQueuedRequests returns the gateway's queued requests, oldest first
+func searchQueuedRequests(store blobstore.TransactionalBlobStorage, gwId string) ([]storedRequest, error) {
+	// request IDs start with zero padded timestamps, so the blobs are sorted
+	// chronologically
+	blobs, err := store.Search(gwId, blobstore.SearchCriteria{Types: []string{queuedRequestType}})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]storedRequest, 0, len(blobs))
+	for _, blob := range blobs {
+		queuedReq := &dispatcherprotos.QueuedRequest{}
+		if err := proto.Unmarshal(blob.Value, queuedReq); err != nil {
+			glog.Errorf("QueuedRequest Unmarshal error for %s/%s: %s", gwId, blob.Key, err)
+			queuedReq = nil
+		}
+		ret = append(ret, storedRequest{id: blob.Key, request: queuedReq})
+	}
+	return ret, nil
+}
+
+// deleteQueuedRequests deletes the gateway's queued requests with the IDs,
+// and stops tracking the gateway if its queue is emptied
+func deleteQueuedRequests(store blobstore.TransactionalBlobStorage, gwId string, ids []string, emptied bool) error {
+	if len(ids) > 0 {
+		tks := make([]storage.TypeAndKey, 0, len(ids))
+		for _, id := range ids {
+			tks = append(tks, storage.TypeAndKey{Type: queuedRequestType, Key: id})
+		}
+		if err := store.Delete(gwId, tks); err != nil {
+			return err
+		}
+	}
+	if !emptied {
+		return nil
+	}
+	return store.Delete(gatewaysNetworkID, []storage.TypeAndKey{{Type: gatewayType, Key: gwId}})
+}
+
+func isExpired(queuedReq *dispatcherprotos.QueuedRequest, now time.Time) bool {
+	expiresAt, err := ptypes.Timestamp(queuedReq.ExpiresAt)
+	return err != nil || !now.Before(expiresAt)
+}
+
+// newRequestID returns a unique request ID starting with the zero padded
+// queueing timestamp
+func newRequestID(ts time.Time) string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return fmt.Sprintf("%020d-%s", ts.UnixNano(), hex.EncodeToString(suffix))
+}
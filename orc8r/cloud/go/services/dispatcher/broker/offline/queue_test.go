@@ -0,0 +1,181 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package offline_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
+	dispatcherprotos "magma/orc8r/cloud/go/services/dispatcher/protos"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue_PushList(t *testing.T) {
+	queue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	defer clock.UnfreezeClock(t)
+
+	var expected []*dispatcherprotos.QueuedRequest
+	for i := 1; i <= 3; i++ {
+		clock.SetAndFreezeClock(t, time.Unix(int64(1000*i), 0))
+		queuedReq, err := queue.Push(newGatewayRequest("gw1", i), time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, protos.TestMarshal(newGatewayRequest("gw1", i)), protos.TestMarshal(queuedReq.Request))
+		assert.Equal(t, int64(1000*i), queuedReq.QueuedAt.Seconds)
+		assert.Equal(t, int64(1000*i+3600), queuedReq.ExpiresAt.Seconds)
+		expected = append(expected, queuedReq)
+	}
+	_, err := queue.Push(newGatewayRequest("gw2", 1), time.Hour)
+	assert.NoError(t, err)
+
+	// oldest first, other gateways' requests are not listed
+	actual, err := queue.List("gw1")
+	assert.NoError(t, err)
+	assertQueuedRequests(t, expected, actual)
+
+	actual, err = queue.List("gw3")
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	// expired requests are not listed
+	clock.SetAndFreezeClock(t, time.Unix(1000+3600, 0))
+	actual, err = queue.List("gw1")
+	assert.NoError(t, err)
+	assertQueuedRequests(t, expected[1:], actual)
+
+	// TTL is capped
+	queuedReq, err := queue.Push(newGatewayRequest("gw1", 4), offline.MaxRequestTTL*2)
+	assert.NoError(t, err)
+	queuedAt, _ := ptypes.Timestamp(queuedReq.QueuedAt)
+	expiresAt, _ := ptypes.Timestamp(queuedReq.ExpiresAt)
+	assert.Equal(t, offline.MaxRequestTTL, expiresAt.Sub(queuedAt))
+
+	// invalid requests
+	_, err = queue.Push(newGatewayRequest("gw1", 5), 0)
+	assert.EqualError(t, err, "invalid queued request TTL: 0s")
+	_, err = queue.Push(newGatewayRequest("", 5), time.Hour)
+	assert.EqualError(t, err, "GatewayRequest cannot be nil and gwId has to be valid")
+	_, err = queue.Push(newGatewayRequest("offline_queue_gateways", 5), time.Hour)
+	assert.EqualError(t, err, "GatewayRequest cannot be nil and gwId has to be valid")
+}
+
+func TestQueue_RemovePurge(t *testing.T) {
+	queue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	defer clock.UnfreezeClock(t)
+	var gw1Reqs []*dispatcherprotos.QueuedRequest
+	for i := 1; i <= 3; i++ {
+		clock.SetAndFreezeClock(t, time.Unix(int64(1000*i), 0))
+		queuedReq, err := queue.Push(newGatewayRequest("gw1", i), time.Hour)
+		assert.NoError(t, err)
+		gw1Reqs = append(gw1Reqs, queuedReq)
+	}
+	gw2Req, err := queue.Push(newGatewayRequest("gw2", 1), time.Hour)
+	assert.NoError(t, err)
+
+	assert.NoError(t, queue.Remove("gw1", gw1Reqs[1].Id))
+	actual, err := queue.List("gw1")
+	assert.NoError(t, err)
+	assertQueuedRequests(t, []*dispatcherprotos.QueuedRequest{gw1Reqs[0], gw1Reqs[2]}, actual)
+
+	assert.NoError(t, queue.Purge("gw1"))
+	actual, err = queue.List("gw1")
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+	actual, err = queue.List("gw2")
+	assert.NoError(t, err)
+	assertQueuedRequests(t, []*dispatcherprotos.QueuedRequest{gw2Req}, actual)
+
+	// purging an empty queue is a no-op
+	assert.NoError(t, queue.Purge("gw1"))
+}
+
+func TestQueue_ClaimRequeue(t *testing.T) {
+	queue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	queuedReq, err := queue.Push(newGatewayRequest("gw1", 1), time.Hour)
+	assert.NoError(t, err)
+
+	// a request is claimed once
+	claimed, err := queue.Claim("gw1", queuedReq.Id)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = queue.Claim("gw1", queuedReq.Id)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+	actual, err := queue.List("gw1")
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	// requeued requests keep their ID and expiry
+	assert.NoError(t, queue.Requeue(queuedReq))
+	actual, err = queue.List("gw1")
+	assert.NoError(t, err)
+	assertQueuedRequests(t, []*dispatcherprotos.QueuedRequest{queuedReq}, actual)
+
+	// claiming unknown requests leaves the queue as is
+	claimed, err = queue.Claim("gw2", queuedReq.Id)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+	actual, err = queue.List("gw2")
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+}
+
+func TestQueue_CollectGarbage(t *testing.T) {
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	queue := offline.NewQueue(factory)
+	defer clock.UnfreezeClock(t)
+
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	_, err := queue.Push(newGatewayRequest("gw1", 1), time.Minute)
+	assert.NoError(t, err)
+	_, err = queue.Push(newGatewayRequest("gw2", 1), time.Minute)
+	assert.NoError(t, err)
+	fresh, err := queue.Push(newGatewayRequest("gw1", 2), time.Hour)
+	assert.NoError(t, err)
+
+	clock.SetAndFreezeClock(t, time.Unix(1000+60, 0))
+	assert.NoError(t, queue.CollectGarbage())
+	store, err := factory.StartTransaction(nil)
+	assert.NoError(t, err)
+	keys, err := store.ListKeys("gw1", "queued_request")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{fresh.Id}, keys)
+	keys, err = store.ListKeys("gw2", "queued_request")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+	// gateways with empty queues aren't tracked anymore
+	gwIds, err := store.ListKeys("offline_queue_gateways", "gateway")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gw1"}, gwIds)
+	assert.NoError(t, store.Commit())
+}
+
+func assertQueuedRequests(t *testing.T, expected, actual []*dispatcherprotos.QueuedRequest) {
+	if !assert.Equal(t, len(expected), len(actual)) {
+		return
+	}
+	for i := range expected {
+		assert.Equal(t, protos.TestMarshal(expected[i]), protos.TestMarshal(actual[i]))
+	}
+}
+
+func newGatewayRequest(gwId string, i int) *protos.GatewayRequest {
+	return &protos.GatewayRequest{
+		GwId:      gwId,
+		Authority: "magmad",
+		Path:      "/magma.orc8r.Magmad/Reboot",
+		Headers:   map[string]string{"Content-Type": "application/grpc"},
+		Payload:   []byte{0, 0, 0, 0, byte(i)},
+	}
+}
@@ -9,18 +9,25 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"time"
 
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/datastore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/services/dispatcher"
 	sync_rpc_broker "magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
 	"magma/orc8r/cloud/go/services/dispatcher/httpserver"
+	dispatcherprotos "magma/orc8r/cloud/go/services/dispatcher/protos"
 	"magma/orc8r/cloud/go/services/dispatcher/servicers"
+	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/glog"
 	"google.golang.org/grpc"
@@ -28,6 +35,11 @@ import (
 
 const HTTP_SERVER_PORT = 9080
 
+var (
+	offlineQueueEnabled = flag.Bool("offline-queue", false, "Queue opted in requests for disconnected gateways in the datastore")
+	offlineQueueGCHours = flag.Int64("offline-queue-gc-hours", 1, "Offline queue expired requests garbage collection time interval (in hours)")
+)
+
 func main() {
 	// Set MaxConnectionAge to infinity so Sync RPC stream doesn't restart
 	var keepaliveParams = service.GetDefaultKeepaliveParameters()
@@ -46,6 +58,32 @@ func main() {
 
 	// create a broker
 	broker := sync_rpc_broker.NewGatewayReqRespBroker()
+	var offlineQueue *offline.Queue
+	if *offlineQueueEnabled {
+		db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+		if err != nil {
+			glog.Fatalf("Failed to connect to database: %s", err)
+		}
+		store := blobstore.NewSQLBlobStorageFactory(offline.DBTableName, db, sqorc.GetSqlBuilder())
+		if err = store.InitializeFactory(); err != nil {
+			glog.Fatalf("Error initializing offline queue database: %s", err)
+		}
+		offlineQueue = offline.NewQueue(store)
+		broker = sync_rpc_broker.NewGatewayReqRespBrokerWithOfflineQueue(offlineQueue)
+
+		// Start Offline Queue Garbage Collector Ticker
+		gc := time.Tick(time.Hour * time.Duration(*offlineQueueGCHours))
+		go func() {
+			for range gc {
+				if err := offlineQueue.CollectGarbage(); err != nil {
+					glog.Errorf("error collecting garbage for offline request queue: %s", err)
+				}
+			}
+		}()
+	}
+
+	// the queue servicer reports empty queues if the offline queue is disabled
+	dispatcherprotos.RegisterOfflineRequestQueueServer(srv.GrpcServer, servicers.NewOfflineRequestQueueServer(offlineQueue))
 
 	// get ec2 public host name
 	hostName := getHostName()
@@ -57,7 +95,7 @@ func main() {
 	}
 
 	// create http server
-	httpServer := httpserver.NewSyncRPCHttpServer(broker)
+	httpServer := httpserver.NewSyncRPCHttpServer(hostName, broker)
 
 	protos.RegisterSyncRPCServiceServer(srv.GrpcServer, syncRpcServicer)
 	srv.GrpcServer.RegisterService(protos.GetLegacyDispatcherDesc(), syncRpcServicer)
@@ -37,6 +37,15 @@ const (
 
 	// SyncRPC gateway header key
 	GatewayIdHeaderKey = "Gatewayid"
+	// ForwardedHeaderKey marks requests forwarded from another dispatcher
+	// instance to the one the gateway is connected to
+	ForwardedHeaderKey = "Syncrpc-Forwarded"
+	// OfflineTTLHeaderKey opts a request in to be queued, for up to the
+	// header's duration (e.g. "1h"), if the gateway is not connected
+	OfflineTTLHeaderKey = "Syncrpc-Offline-Ttl"
+	// QueuedRequestHeaderKey is set in the response header of queued
+	// requests to the ID of the queued request
+	QueuedRequestHeaderKey = "Syncrpc-Queued-Id"
 
 	HttpServerAddressPort = 9080
 )
@@ -83,11 +92,16 @@ func GetServiceAddressForGateway(hwId string) (string, error) {
 		fmt.Printf("err getting hostName in GetServiceAddressForGateway for hwId %v: %v\n", hwId, err)
 		return "", err
 	}
+	return GetServiceAddressForHost(hostName), nil
+}
+
+// GetServiceAddressForHost returns the addr of the SyncRPCHTTPServer
+// instance running on the dispatcher host with hostName.
+func GetServiceAddressForHost(hostName string) string {
 	config.RLock()
 	port := config.port
 	config.RUnlock()
-	addr := fmt.Sprintf("%s:%v", hostName, port)
-	return addr, nil
+	return fmt.Sprintf("%s:%v", hostName, port)
 }
 
 // GetGatewayConnection gets a connection to the SyncRPC HTTP server
@@ -119,6 +133,24 @@ func GetGatewayConnection(service GwServiceType, hwId string) (*grpc.ClientConn,
 
 }
 
+// WithOfflineQueueing returns a copy of a gateway connection context, which
+// makes the rpc calls queue up to ttl for the gateway if it's not connected.
+// A queued call succeeds with an empty response, GetQueuedRequestID tells
+// queued calls apart.
+func WithOfflineQueueing(ctx context.Context, ttl time.Duration) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, OfflineTTLHeaderKey, ttl.String())
+}
+
+// GetQueuedRequestID returns the ID of the queued request if the response
+// header (see grpc.Header call option) is of a queued call
+func GetQueuedRequestID(header metadata.MD) (string, bool) {
+	ids := header.Get(QueuedRequestHeaderKey)
+	if len(ids) == 0 || len(ids[0]) == 0 {
+		return "", false
+	}
+	return ids[0], true
+}
+
 func ListAllGwServices() []GwServiceType {
 	return services
 }
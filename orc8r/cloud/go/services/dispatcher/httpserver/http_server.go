@@ -17,6 +17,13 @@ LICENSE file in the root directory of this source tree.
 // This httpServer converts httpRequest to GatewayRequest, send it over to grpc
 // servicer using GatewayRPCBroker, waits for a response, and converts the
 // GatewayResponse to a HttpResponse and send it back to the client.
+//
+// If the gateway is not connected to this dispatcher instance, the request is
+// forwarded to the httpServer of the instance registered as the gateway's
+// owner in directoryd, so a client can reach the gateway through any instance.
+//
+// Requests carrying the offline TTL header are queued by the broker if the
+// gateway is not connected, and are answered with an empty grpc response.
 package httpserver
 
 import (
@@ -25,6 +32,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
@@ -32,6 +40,7 @@ import (
 
 	"magma/orc8r/cloud/go/http2"
 	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/directoryd"
 	"magma/orc8r/cloud/go/services/dispatcher/broker"
 	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
 
@@ -48,11 +57,21 @@ const (
 
 type SyncRPCHttpServer struct {
 	*http2.H2CServer
-	broker broker.GatewayRPCBroker
+	// hostName is the host at which this server instance is running on
+	hostName string
+	broker   broker.GatewayRPCBroker
+	// forwardTransport is the h2c transport of requests forwarded to
+	// other dispatcher instances
+	forwardTransport http.RoundTripper
 }
 
-func NewSyncRPCHttpServer(broker broker.GatewayRPCBroker) *SyncRPCHttpServer {
-	return &SyncRPCHttpServer{http2.NewH2CServer(), broker}
+func NewSyncRPCHttpServer(hostName string, broker broker.GatewayRPCBroker) *SyncRPCHttpServer {
+	return &SyncRPCHttpServer{
+		H2CServer:        http2.NewH2CServer(),
+		hostName:         hostName,
+		broker:           broker,
+		forwardTransport: http2.NewH2CClient().Transport,
+	}
 }
 
 func (server *SyncRPCHttpServer) Run(addr string) {
@@ -65,7 +84,22 @@ func (server *SyncRPCHttpServer) Serve(listener net.Listener) {
 
 func (server *SyncRPCHttpServer) rootHandler(responseWriter http.ResponseWriter, req *http.Request) {
 	http2.LogRequestWithVerbosity(req, 4)
-	respChan, err := server.sendRequest(req)
+	if addr, ok := server.getForwardingAddress(req); ok {
+		server.forwardRequest(responseWriter, req, addr)
+		return
+	}
+	gwReq, offlineTTL, err := createRequest(req)
+	if err != nil {
+		glog.Errorf(err.Msg)
+		http2.WriteErrResponse(responseWriter, err)
+		return
+	}
+	if offlineTTL > 0 && !server.broker.IsGatewayConnected(gwReq.GwId) {
+		if server.queueRequest(responseWriter, gwReq, offlineTTL) {
+			return
+		}
+	}
+	respChan, err := server.sendRequest(req, gwReq)
 	if err != nil {
 		glog.Errorf(err.Msg)
 		// Also write to client.
@@ -95,13 +129,80 @@ func (server *SyncRPCHttpServer) rootHandler(responseWriter http.ResponseWriter,
 	}
 }
 
-// sendRequest sends a SyncRPCRequest to the gateway and creates
-// a goroutine to notify the gateway when the context is done.
-func (server *SyncRPCHttpServer) sendRequest(req *http.Request) (chan *protos.GatewayResponse, *http2.HTTPGrpcError) {
-	gwReq, err := createRequest(req)
+// getForwardingAddress returns the addr of the httpServer of the dispatcher
+// instance the request's gateway is connected to, if it's not this instance.
+// Requests which were already forwarded are always handled locally to
+// avoid forwarding loops between instances with stale ownership records.
+func (server *SyncRPCHttpServer) getForwardingAddress(req *http.Request) (string, bool) {
+	if len(req.Header.Get(gateway_registry.ForwardedHeaderKey)) != 0 {
+		return "", false
+	}
+	gwId := req.Header.Get(gateway_registry.GatewayIdHeaderKey)
+	if len(gwId) == 0 || server.broker.IsGatewayConnected(gwId) {
+		return "", false
+	}
+	hostName, err := directoryd.GetHostNameByIMSI(gwId)
+	if err != nil {
+		glog.V(2).Infof("err getting hostName for hwId %v, handling request locally: %v\n", gwId, err)
+		return "", false
+	}
+	if len(hostName) == 0 || hostName == server.hostName {
+		return "", false
+	}
+	return gateway_registry.GetServiceAddressForHost(hostName), true
+}
+
+// forwardRequest proxies the request to the httpServer at addr, streaming
+// the response, including the grpc trailers, back to the client.
+func (server *SyncRPCHttpServer) forwardRequest(responseWriter http.ResponseWriter, req *http.Request, addr string) {
+	glog.V(2).Infof("forwarding request for hwId %v to %v\n", req.Header.Get(gateway_registry.GatewayIdHeaderKey), addr)
+	proxy := &httputil.ReverseProxy{
+		Director: func(outReq *http.Request) {
+			outReq.URL.Scheme = "http"
+			outReq.URL.Host = addr
+			outReq.Header.Set(gateway_registry.ForwardedHeaderKey, server.hostName)
+		},
+		Transport: server.forwardTransport,
+		// flush immediately, so streamed responses are not delayed
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			errMsg := fmt.Sprintf("err forwarding request to %v: %v", addr, err)
+			glog.Errorf(errMsg)
+			http2.WriteErrResponse(w, http2.NewHTTPGrpcError(errMsg, int(codes.Unavailable), http.StatusBadGateway))
+		},
+	}
+	proxy.ServeHTTP(responseWriter, req)
+}
+
+// queueRequest queues the request for the disconnected gateway and writes
+// an empty grpc response with the queued request's ID in the header. Returns
+// false without writing a response if the offline queue is disabled, the
+// request is then sent as if it wasn't opted in to queueing.
+func (server *SyncRPCHttpServer) queueRequest(responseWriter http.ResponseWriter, gwReq *protos.GatewayRequest, ttl time.Duration) bool {
+	id, err := server.broker.QueueRequestForGateway(gwReq, ttl)
+	if err == broker.ErrOfflineQueueDisabled {
+		return false
+	}
 	if err != nil {
-		return nil, err
+		errMsg := fmt.Sprintf("err queueing request %v for gateway: %v", gwReq, err)
+		glog.Errorf(errMsg)
+		http2.WriteErrResponse(responseWriter, http2.NewHTTPGrpcError(errMsg, int(codes.Internal), http.StatusInternalServerError))
+		return true
 	}
+	glog.V(2).Infof("queued request %v for hwId %v for %v\n", id, gwReq.GwId, ttl)
+	responseWriter.Header().Set(gateway_registry.QueuedRequestHeaderKey, id)
+	processResponse(responseWriter, &protos.GatewayResponse{
+		Status:  strconv.Itoa(http.StatusOK),
+		Headers: map[string]string{"content-type": "application/grpc", "grpc-status": "0"},
+		// grpc message frame of an empty message: not compressed, zero length
+		Payload: []byte{0, 0, 0, 0, 0},
+	})
+	return true
+}
+
+// sendRequest sends a SyncRPCRequest to the gateway and creates
+// a goroutine to notify the gateway when the context is done.
+func (server *SyncRPCHttpServer) sendRequest(req *http.Request, gwReq *protos.GatewayRequest) (chan *protos.GatewayResponse, *http2.HTTPGrpcError) {
 	gwRespChannel, sendReqErr := server.broker.SendRequestToGateway(gwReq)
 	if sendReqErr != nil {
 		errMsg := fmt.Sprintf("err sending request %v to gateway: %v", gwReq, sendReqErr)
@@ -131,26 +232,33 @@ func (server *SyncRPCHttpServer) sendRequest(req *http.Request) (chan *protos.Ga
 	return gwRespChannel.RespChan, nil
 }
 
-// createRequest converts a HTTP request to a GatewayRequest.
-func createRequest(req *http.Request) (*protos.GatewayRequest, *http2.HTTPGrpcError) {
+// createRequest converts a HTTP request to a GatewayRequest. It also returns
+// the TTL of the request in the offline queue, 0 if it shouldn't be queued.
+func createRequest(req *http.Request) (*protos.GatewayRequest, time.Duration, *http2.HTTPGrpcError) {
 	headers := req.Header
 	gwIds := headers[gateway_registry.GatewayIdHeaderKey]
 	if len(gwIds) == 0 || len(gwIds[0]) == 0 {
-		return nil, http2.NewHTTPGrpcError("No Gatewayid provided in metaData", int(codes.InvalidArgument), http.StatusBadRequest)
+		return nil, 0, http2.NewHTTPGrpcError("No Gatewayid provided in metaData", int(codes.InvalidArgument), http.StatusBadRequest)
 	}
 	gwId := gwIds[0]
+	offlineTTL, err := getOfflineTTL(headers.Get(gateway_registry.OfflineTTLHeaderKey))
+	if err != nil {
+		return nil, 0, err
+	}
 	delete(headers, gateway_registry.GatewayIdHeaderKey)
+	delete(headers, gateway_registry.ForwardedHeaderKey)
+	delete(headers, gateway_registry.OfflineTTLHeaderKey)
 	authority, err := getAuthority(req.Host)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	path, err := getPath(req.URL)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	body, err := getPayload(req.Body)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	gwReq := &protos.GatewayRequest{
 		GwId:      gwId,
@@ -159,7 +267,19 @@ func createRequest(req *http.Request) (*protos.GatewayRequest, *http2.HTTPGrpcEr
 		Headers:   convertHeadersForProto(headers),
 		Payload:   body,
 	}
-	return gwReq, nil
+	return gwReq, offlineTTL, nil
+}
+
+func getOfflineTTL(value string) (time.Duration, *http2.HTTPGrpcError) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		errMsg := fmt.Sprintf("Invalid %s: %v", gateway_registry.OfflineTTLHeaderKey, value)
+		return 0, http2.NewHTTPGrpcError(errMsg, int(codes.InvalidArgument), http.StatusBadRequest)
+	}
+	return ttl, nil
 }
 
 func getAuthority(host string) (string, *http2.HTTPGrpcError) {
@@ -8,6 +8,178 @@ LICENSE file in the root directory of this source tree.
 
 package httpserver_test
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/http2"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/directoryd"
+	directorydTestInit "magma/orc8r/cloud/go/services/directoryd/test_init"
+	"magma/orc8r/cloud/go/services/dispatcher/broker"
+	"magma/orc8r/cloud/go/services/dispatcher/gateway_registry"
+	"magma/orc8r/cloud/go/services/dispatcher/test_init"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPayload = "\x00\x00\x00\x00\x00"
+
+func TestSyncRPCHttpServer_ForwardToOwner(t *testing.T) {
+	directorydTestInit.StartTestService(t)
+	ownerAddr, ownerBroker := test_init.StartTestHttpServerWithHostName(t, "127.0.0.1")
+	addr, localBroker := test_init.StartTestHttpServerWithHostName(t, "test_dispatcher")
+	gateway_registry.SetPort(ownerAddr.(*net.TCPAddr).Port)
+	defer gateway_registry.SetPort(gateway_registry.HttpServerAddressPort)
+	assert.NoError(t, directoryd.UpdateHostNameByHwId("fwd_gw", "127.0.0.1"))
+
+	localBroker.On("IsGatewayConnected", "fwd_gw").Return(false)
+	respChan := make(chan *protos.GatewayResponse, 1)
+	respChan <- &protos.GatewayResponse{
+		Status:  "200",
+		Headers: map[string]string{"content-type": "application/grpc", "grpc-status": "0", "grpc-message": ""},
+		Payload: []byte(testPayload),
+	}
+	ownerBroker.On("SendRequestToGateway", mock.MatchedBy(func(req *protos.GatewayRequest) bool {
+		_, forwarded := req.Headers[gateway_registry.ForwardedHeaderKey]
+		return req.GwId == "fwd_gw" &&
+			req.Authority == "mobilityd" &&
+			req.Path == "/magma.MobilityService/ListAddedIPv4Blocks" &&
+			string(req.Payload) == testPayload &&
+			!forwarded
+	})).Return(&broker.GatewayResponseChannel{RespChan: respChan, ReqId: 1}, nil)
+	ownerBroker.On("CancelGatewayRequest", "fwd_gw", uint32(1)).Return(nil)
+
+	resp, body := sendGatewayRequest(t, addr, "fwd_gw", false, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, testPayload, string(body))
+	assert.Equal(t, "0", getGrpcStatus(resp))
+	localBroker.AssertNotCalled(t, "SendRequestToGateway", mock.Anything)
+	ownerBroker.AssertNotCalled(t, "IsGatewayConnected", mock.Anything)
+	ownerBroker.AssertCalled(t, "SendRequestToGateway", mock.Anything)
+
+	// owner is not reachable
+	lis, err := net.Listen("tcp", "")
+	assert.NoError(t, err)
+	gateway_registry.SetPort(lis.Addr().(*net.TCPAddr).Port)
+	lis.Close()
+	resp, _ = sendGatewayRequest(t, addr, "fwd_gw", false, "")
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, "14", getGrpcStatus(resp))
+}
+
+func TestSyncRPCHttpServer_HandleLocally(t *testing.T) {
+	directorydTestInit.StartTestService(t)
+	addr, localBroker := test_init.StartTestHttpServerWithHostName(t, "test_dispatcher")
+	localBroker.On("IsGatewayConnected", "local_gw").Return(true)
+	localBroker.On("IsGatewayConnected", "unknown_gw").Return(false)
+	localBroker.On("IsGatewayConnected", "own_gw").Return(false)
+	localBroker.On("SendRequestToGateway", mock.AnythingOfType("*protos.GatewayRequest")).
+		Return(nil, errors.New("test error"))
+	assert.NoError(t, directoryd.UpdateHostNameByHwId("own_gw", "test_dispatcher"))
+	assert.NoError(t, directoryd.UpdateHostNameByHwId("forwarded_gw", "127.0.0.1"))
+
+	// connected gateway, gateway without ownership record, gateway owned by
+	// this instance and already forwarded requests are all handled locally
+	for _, gwId := range []string{"local_gw", "unknown_gw", "own_gw", "forwarded_gw"} {
+		resp, _ := sendGatewayRequest(t, addr, gwId, gwId == "forwarded_gw", "")
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, gwId)
+		assert.Equal(t, "13", getGrpcStatus(resp), gwId)
+		localBroker.AssertCalled(t, "SendRequestToGateway", mock.MatchedBy(func(req *protos.GatewayRequest) bool {
+			return req.GwId == gwId
+		}))
+	}
+	localBroker.AssertNotCalled(t, "IsGatewayConnected", "forwarded_gw")
+}
+
+func TestSyncRPCHttpServer_QueueRequest(t *testing.T) {
+	addr, localBroker := test_init.StartTestHttpServerWithHostName(t, "test_dispatcher")
+	localBroker.On("IsGatewayConnected", "offline_gw").Return(false)
+	localBroker.On("QueueRequestForGateway", mock.MatchedBy(func(req *protos.GatewayRequest) bool {
+		_, hasTTL := req.Headers[gateway_registry.OfflineTTLHeaderKey]
+		return req.GwId == "offline_gw" &&
+			req.Path == "/magma.MobilityService/ListAddedIPv4Blocks" &&
+			string(req.Payload) == testPayload &&
+			!hasTTL
+	}), time.Hour).Return("queued_req_id", nil)
+
+	resp, body := sendGatewayRequest(t, addr, "offline_gw", false, "1h")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "queued_req_id", resp.Header.Get(gateway_registry.QueuedRequestHeaderKey))
+	assert.Equal(t, testPayload, string(body))
+	assert.Equal(t, "0", getGrpcStatus(resp))
+	localBroker.AssertNotCalled(t, "SendRequestToGateway", mock.Anything)
+
+	// invalid TTL
+	resp, _ = sendGatewayRequest(t, addr, "offline_gw", false, "forever")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "3", getGrpcStatus(resp))
+
+	// failure to queue
+	localBroker.On("QueueRequestForGateway", mock.Anything, time.Minute).Return("", errors.New("test error"))
+	resp, _ = sendGatewayRequest(t, addr, "offline_gw", false, "1m")
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "13", getGrpcStatus(resp))
+	localBroker.AssertNotCalled(t, "SendRequestToGateway", mock.Anything)
+
+	// requests to connected gateways are not queued
+	localBroker.On("IsGatewayConnected", "online_gw").Return(true)
+	localBroker.On("SendRequestToGateway", mock.AnythingOfType("*protos.GatewayRequest")).
+		Return(nil, errors.New("test error"))
+	resp, _ = sendGatewayRequest(t, addr, "online_gw", false, "1h")
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	localBroker.AssertCalled(t, "SendRequestToGateway", mock.Anything)
+	localBroker.AssertNumberOfCalls(t, "QueueRequestForGateway", 2)
+
+	// requests are sent as usual if the offline queue is disabled
+	localBroker.On("IsGatewayConnected", "unqueued_gw").Return(false)
+	localBroker.On("QueueRequestForGateway", mock.Anything, 2*time.Hour).Return("", broker.ErrOfflineQueueDisabled)
+	resp, _ = sendGatewayRequest(t, addr, "unqueued_gw", false, "2h")
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "13", getGrpcStatus(resp))
+	localBroker.AssertCalled(t, "SendRequestToGateway", mock.MatchedBy(func(req *protos.GatewayRequest) bool {
+		return req.GwId == "unqueued_gw"
+	}))
+	localBroker.AssertNumberOfCalls(t, "QueueRequestForGateway", 3)
+}
+
+func sendGatewayRequest(t *testing.T, addr net.Addr, gwId string, forwarded bool, offlineTTL string) (*http.Response, []byte) {
+	url := fmt.Sprintf("http://%s/magma.MobilityService/ListAddedIPv4Blocks", addr)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(testPayload)))
+	assert.NoError(t, err)
+	req.Host = "mobilityd"
+	req.Header.Set("content-type", "application/grpc")
+	req.Header.Set(gateway_registry.GatewayIdHeaderKey, gwId)
+	if forwarded {
+		req.Header.Set(gateway_registry.ForwardedHeaderKey, "test_dispatcher_2")
+	}
+	if len(offlineTTL) != 0 {
+		req.Header.Set(gateway_registry.OfflineTTLHeaderKey, offlineTTL)
+	}
+	resp, err := http2.NewH2CClient().Do(req)
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	return resp, body
+}
+
+// getGrpcStatus returns the grpc status of the response, which is sent
+// either as a trailer or, for responses without a body, as a header
+func getGrpcStatus(resp *http.Response) string {
+	if st := resp.Trailer.Get("Grpc-Status"); len(st) != 0 {
+		return st
+	}
+	return resp.Header.Get("Grpc-Status")
+}
+
 // Everything commented out until we can write some tests that don't depend
 // on mobilityd, which is an lte service
 
@@ -0,0 +1,61 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/registry"
+	dispatcherprotos "magma/orc8r/cloud/go/services/dispatcher/protos"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+// getOfflineRequestQueueClient is a utility function to get a RPC connection
+// to the dispatcher service's offline request queue
+func getOfflineRequestQueueClient() (dispatcherprotos.OfflineRequestQueueClient, error) {
+	conn, err := registry.GetConnection(ServiceName)
+	if err != nil {
+		initErr := merrors.NewInitError(err, ServiceName)
+		glog.Error(initErr)
+		return nil, initErr
+	}
+	return dispatcherprotos.NewOfflineRequestQueueClient(conn), err
+}
+
+// ListQueuedRequests returns the requests queued for the gateway with
+// hardware ID hwID until it reconnects, oldest first
+func ListQueuedRequests(hwID string) ([]*dispatcherprotos.QueuedRequest, error) {
+	client, err := getOfflineRequestQueueClient()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.ListQueuedRequests(context.Background(), &protos.AccessGatewayID{Id: hwID})
+	if err != nil {
+		return nil, fmt.Errorf("List queued requests for %s error: %s", hwID, err)
+	}
+	return res.Requests, nil
+}
+
+// PurgeQueuedRequests removes all requests queued for the gateway with
+// hardware ID hwID
+func PurgeQueuedRequests(hwID string) error {
+	client, err := getOfflineRequestQueueClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.PurgeQueuedRequests(context.Background(), &protos.AccessGatewayID{Id: hwID})
+	if err != nil {
+		return fmt.Errorf("Purge queued requests for %s error: %s", hwID, err)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: offline_queue.proto
+
+package protos
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protos "magma/orc8r/cloud/go/protos"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type QueuedRequest struct {
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Request              *protos.GatewayRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	QueuedAt             *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=queued_at,json=queuedAt,proto3" json:"queued_at,omitempty"`
+	ExpiresAt            *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *QueuedRequest) Reset()         { *m = QueuedRequest{} }
+func (m *QueuedRequest) String() string { return proto.CompactTextString(m) }
+func (*QueuedRequest) ProtoMessage()    {}
+func (*QueuedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93fc348d42506fc2, []int{0}
+}
+
+func (m *QueuedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueuedRequest.Unmarshal(m, b)
+}
+func (m *QueuedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueuedRequest.Marshal(b, m, deterministic)
+}
+func (m *QueuedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueuedRequest.Merge(m, src)
+}
+func (m *QueuedRequest) XXX_Size() int {
+	return xxx_messageInfo_QueuedRequest.Size(m)
+}
+func (m *QueuedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueuedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueuedRequest proto.InternalMessageInfo
+
+func (m *QueuedRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *QueuedRequest) GetRequest() *protos.GatewayRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *QueuedRequest) GetQueuedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.QueuedAt
+	}
+	return nil
+}
+
+func (m *QueuedRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type QueuedRequests struct {
+	Requests             []*QueuedRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *QueuedRequests) Reset()         { *m = QueuedRequests{} }
+func (m *QueuedRequests) String() string { return proto.CompactTextString(m) }
+func (*QueuedRequests) ProtoMessage()    {}
+func (*QueuedRequests) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93fc348d42506fc2, []int{1}
+}
+
+func (m *QueuedRequests) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueuedRequests.Unmarshal(m, b)
+}
+func (m *QueuedRequests) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueuedRequests.Marshal(b, m, deterministic)
+}
+func (m *QueuedRequests) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueuedRequests.Merge(m, src)
+}
+func (m *QueuedRequests) XXX_Size() int {
+	return xxx_messageInfo_QueuedRequests.Size(m)
+}
+func (m *QueuedRequests) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueuedRequests.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueuedRequests proto.InternalMessageInfo
+
+func (m *QueuedRequests) GetRequests() []*QueuedRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*QueuedRequest)(nil), "magma.orc8r.dispatcher.QueuedRequest")
+	proto.RegisterType((*QueuedRequests)(nil), "magma.orc8r.dispatcher.QueuedRequests")
+}
+
+func init() { proto.RegisterFile("offline_queue.proto", fileDescriptor_93fc348d42506fc2) }
+
+var fileDescriptor_93fc348d42506fc2 = []byte{
+	// 348 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xd1, 0x4a, 0xc3, 0x30,
+	0x14, 0x86, 0xd7, 0x4d, 0x74, 0xcb, 0x70, 0x60, 0x06, 0x52, 0x3b, 0xc1, 0x51, 0x51, 0x76, 0x95,
+	0xc1, 0x44, 0xd4, 0xcb, 0x8a, 0xa0, 0x82, 0xa0, 0x56, 0xf1, 0x42, 0x84, 0xd2, 0xa5, 0x67, 0x35,
+	0xb0, 0x36, 0x5d, 0x4e, 0xaa, 0xee, 0xe1, 0x7c, 0x01, 0x9f, 0x4a, 0xd6, 0x74, 0x62, 0x41, 0x50,
+	0xaf, 0x02, 0xf9, 0xff, 0xef, 0x9c, 0xff, 0xe4, 0x84, 0x74, 0xe5, 0x64, 0x32, 0x15, 0x29, 0x04,
+	0xb3, 0x1c, 0x72, 0x60, 0x99, 0x92, 0x5a, 0xd2, 0xcd, 0x24, 0x8c, 0x93, 0x90, 0x49, 0xc5, 0x8f,
+	0x15, 0x8b, 0x04, 0x66, 0xa1, 0xe6, 0xcf, 0xa0, 0x9c, 0xad, 0xe2, 0x66, 0x58, 0x98, 0x70, 0xc8,
+	0x65, 0x92, 0xc8, 0xd4, 0x20, 0x4e, 0xaf, 0x22, 0x89, 0x08, 0x52, 0x2d, 0xf4, 0xbc, 0x14, 0x77,
+	0x2b, 0x22, 0xce, 0x53, 0x1e, 0xa8, 0x8c, 0x07, 0x08, 0xea, 0x45, 0xf0, 0xb2, 0xa9, 0xb3, 0x13,
+	0x4b, 0x19, 0x4f, 0xc1, 0xb8, 0xc6, 0xf9, 0x64, 0xa8, 0x45, 0x02, 0xa8, 0xc3, 0x24, 0x33, 0x06,
+	0xf7, 0xc3, 0x22, 0xeb, 0xb7, 0x8b, 0x94, 0x91, 0x0f, 0xb3, 0x1c, 0x50, 0xd3, 0x0e, 0xa9, 0x8b,
+	0xc8, 0xb6, 0xfa, 0xd6, 0xa0, 0xe5, 0xd7, 0x45, 0x44, 0x0f, 0xc9, 0x9a, 0x32, 0x92, 0x5d, 0xef,
+	0x5b, 0x83, 0xf6, 0xa8, 0xc7, 0xbe, 0x4f, 0x72, 0x1e, 0x6a, 0x78, 0x0d, 0xe7, 0x25, 0xed, 0x2f,
+	0xbd, 0xf4, 0x88, 0xb4, 0x8a, 0xe9, 0xa3, 0x20, 0xd4, 0x76, 0xa3, 0x00, 0x1d, 0x66, 0xd2, 0xb0,
+	0x65, 0x1a, 0x76, 0xbf, 0x4c, 0xe3, 0x37, 0x8d, 0xd9, 0xd3, 0xf4, 0x84, 0x10, 0x78, 0xcb, 0x84,
+	0x02, 0x5c, 0x90, 0x2b, 0xbf, 0x92, 0xad, 0xd2, 0xed, 0x69, 0xf7, 0x8e, 0x74, 0x2a, 0xb3, 0x20,
+	0xf5, 0x48, 0xb3, 0x0c, 0x84, 0xb6, 0xd5, 0x6f, 0x0c, 0xda, 0xa3, 0x3d, 0xf6, 0xf3, 0x1e, 0x58,
+	0x85, 0xf4, 0xbf, 0xb0, 0xd1, 0xbb, 0x45, 0xba, 0xd7, 0x66, 0x9f, 0xa5, 0x58, 0x38, 0xe9, 0x13,
+	0xa1, 0x57, 0x02, 0x75, 0x05, 0x43, 0xba, 0x5d, 0x29, 0xef, 0x71, 0x0e, 0x88, 0xe5, 0x13, 0x5d,
+	0x9e, 0x39, 0xfb, 0x7f, 0x6a, 0x8e, 0x6e, 0x8d, 0x5e, 0x90, 0xee, 0x4d, 0xae, 0x62, 0xf8, 0x57,
+	0xf9, 0x8d, 0x8a, 0xfa, 0x20, 0x45, 0xe4, 0xd6, 0x4e, 0x9b, 0x8f, 0xab, 0xe6, 0x8f, 0x8c, 0xcd,
+	0x79, 0xf0, 0x39, 0x00, 0x61, 0x92, 0x41, 0x63, 0x9f, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OfflineRequestQueueClient is the client API for OfflineRequestQueue service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OfflineRequestQueueClient interface {
+	// Returns the gateway's (hardware ID) pending requests, oldest first
+	ListQueuedRequests(ctx context.Context, in *protos.AccessGatewayID, opts ...grpc.CallOption) (*QueuedRequests, error)
+	// Removes all pending requests of the gateway (hardware ID)
+	PurgeQueuedRequests(ctx context.Context, in *protos.AccessGatewayID, opts ...grpc.CallOption) (*protos.Void, error)
+}
+
+type offlineRequestQueueClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOfflineRequestQueueClient(cc *grpc.ClientConn) OfflineRequestQueueClient {
+	return &offlineRequestQueueClient{cc}
+}
+
+func (c *offlineRequestQueueClient) ListQueuedRequests(ctx context.Context, in *protos.AccessGatewayID, opts ...grpc.CallOption) (*QueuedRequests, error) {
+	out := new(QueuedRequests)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.dispatcher.OfflineRequestQueue/ListQueuedRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *offlineRequestQueueClient) PurgeQueuedRequests(ctx context.Context, in *protos.AccessGatewayID, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.orc8r.dispatcher.OfflineRequestQueue/PurgeQueuedRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OfflineRequestQueueServer is the server API for OfflineRequestQueue service.
+type OfflineRequestQueueServer interface {
+	// Returns the gateway's (hardware ID) pending requests, oldest first
+	ListQueuedRequests(context.Context, *protos.AccessGatewayID) (*QueuedRequests, error)
+	// Removes all pending requests of the gateway (hardware ID)
+	PurgeQueuedRequests(context.Context, *protos.AccessGatewayID) (*protos.Void, error)
+}
+
+// UnimplementedOfflineRequestQueueServer can be embedded to have forward compatible implementations.
+type UnimplementedOfflineRequestQueueServer struct {
+}
+
+func (*UnimplementedOfflineRequestQueueServer) ListQueuedRequests(ctx context.Context, req *protos.AccessGatewayID) (*QueuedRequests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueuedRequests not implemented")
+}
+func (*UnimplementedOfflineRequestQueueServer) PurgeQueuedRequests(ctx context.Context, req *protos.AccessGatewayID) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeQueuedRequests not implemented")
+}
+
+func RegisterOfflineRequestQueueServer(s *grpc.Server, srv OfflineRequestQueueServer) {
+	s.RegisterService(&_OfflineRequestQueue_serviceDesc, srv)
+}
+
+func _OfflineRequestQueue_ListQueuedRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.AccessGatewayID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OfflineRequestQueueServer).ListQueuedRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.dispatcher.OfflineRequestQueue/ListQueuedRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OfflineRequestQueueServer).ListQueuedRequests(ctx, req.(*protos.AccessGatewayID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OfflineRequestQueue_PurgeQueuedRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.AccessGatewayID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OfflineRequestQueueServer).PurgeQueuedRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.orc8r.dispatcher.OfflineRequestQueue/PurgeQueuedRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OfflineRequestQueueServer).PurgeQueuedRequests(ctx, req.(*protos.AccessGatewayID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OfflineRequestQueue_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "magma.orc8r.dispatcher.OfflineRequestQueue",
+	HandlerType: (*OfflineRequestQueueServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListQueuedRequests",
+			Handler:    _OfflineRequestQueue_ListQueuedRequests_Handler,
+		},
+		{
+			MethodName: "PurgeQueuedRequests",
+			Handler:    _OfflineRequestQueue_PurgeQueuedRequests_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "offline_queue.proto",
+}
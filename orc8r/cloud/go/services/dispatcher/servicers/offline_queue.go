@@ -0,0 +1,67 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
+	dispatcherprotos "magma/orc8r/cloud/go/services/dispatcher/protos"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+type OfflineRequestQueueServer struct {
+	// queue is nil if the offline queue is disabled
+	queue *offline.Queue
+}
+
+// NewOfflineRequestQueueServer returns a servicer of the offline queue, or of
+// permanently empty queues if queue is nil
+func NewOfflineRequestQueueServer(queue *offline.Queue) *OfflineRequestQueueServer {
+	return &OfflineRequestQueueServer{queue: queue}
+}
+
+// ListQueuedRequests returns the gateway's pending requests, oldest first
+func (srv *OfflineRequestQueueServer) ListQueuedRequests(
+	ctx context.Context,
+	gwId *protos.AccessGatewayID,
+) (*dispatcherprotos.QueuedRequests, error) {
+	res := &dispatcherprotos.QueuedRequests{}
+	if gwId == nil || len(gwId.Id) == 0 {
+		return res, protos.Errorf(codes.InvalidArgument, "Empty gateway hardware ID")
+	}
+	if srv.queue == nil {
+		return res, nil
+	}
+	queuedReqs, err := srv.queue.List(gwId.Id)
+	if err != nil {
+		return res, protos.Errorf(codes.Unknown, "List queued requests error: %s", err)
+	}
+	res.Requests = queuedReqs
+	return res, nil
+}
+
+// PurgeQueuedRequests removes all pending requests of the gateway
+func (srv *OfflineRequestQueueServer) PurgeQueuedRequests(
+	ctx context.Context,
+	gwId *protos.AccessGatewayID,
+) (*protos.Void, error) {
+	voidRes := &protos.Void{}
+	if gwId == nil || len(gwId.Id) == 0 {
+		return voidRes, protos.Errorf(codes.InvalidArgument, "Empty gateway hardware ID")
+	}
+	if srv.queue == nil {
+		return voidRes, nil
+	}
+	if err := srv.queue.Purge(gwId.Id); err != nil {
+		return voidRes, protos.Errorf(codes.Unknown, "Purge queued requests error: %s", err)
+	}
+	return voidRes, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
+	"magma/orc8r/cloud/go/services/dispatcher/servicers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineRequestQueueServer(t *testing.T) {
+	queue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	srv := servicers.NewOfflineRequestQueueServer(queue)
+	_, err := queue.Push(&protos.GatewayRequest{GwId: "gw1", Authority: "magmad"}, time.Hour)
+	assert.NoError(t, err)
+
+	res, err := srv.ListQueuedRequests(context.Background(), &protos.AccessGatewayID{Id: "gw1"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Requests, 1)
+	_, err = srv.PurgeQueuedRequests(context.Background(), &protos.AccessGatewayID{Id: "gw1"})
+	assert.NoError(t, err)
+	res, err = srv.ListQueuedRequests(context.Background(), &protos.AccessGatewayID{Id: "gw1"})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Requests)
+
+	_, err = srv.ListQueuedRequests(context.Background(), &protos.AccessGatewayID{})
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = Empty gateway hardware ID")
+
+	// queues are empty if the offline queue is disabled
+	srv = servicers.NewOfflineRequestQueueServer(nil)
+	res, err = srv.ListQueuedRequests(context.Background(), &protos.AccessGatewayID{Id: "gw1"})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Requests)
+	_, err = srv.PurgeQueuedRequests(context.Background(), &protos.AccessGatewayID{Id: "gw1"})
+	assert.NoError(t, err)
+}
@@ -14,7 +14,6 @@ import (
 	"sync"
 	"time"
 
-	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/directoryd"
 	"magma/orc8r/cloud/go/services/dispatcher/broker"
@@ -51,11 +50,9 @@ func (srv *SyncRPCService) SyncRPC(stream protos.SyncRPCService_SyncRPCServer) e
 //
 // Every active connection will run this function in its own goroutine.
 func (srv *SyncRPCService) EstablishSyncRPCStream(stream protos.SyncRPCService_EstablishSyncRPCStreamServer) error {
-	// Check if we can get a valid Gateway identity.
-	gw, err := identity.GetStreamGatewayId(stream)
-	if err != nil {
-		return err
-	}
+	// The Gateway identity is verified & injected into the stream's context
+	// by the stream identity decorator middleware
+	gw := protos.GetClientGateway(stream.Context())
 	if gw == nil || len(gw.HardwareId) == 0 {
 		return status.Errorf(codes.PermissionDenied, "Gateway hardware id is nil")
 	}
@@ -86,6 +83,13 @@ func (srv *SyncRPCService) serveGwId(stream protos.SyncRPCService_EstablishSyncR
 	coordinator := newStreamCoordinator(gwId, stream.Context())
 	queue := srv.broker.InitializeGateway(gwId)
 	glog.V(2).Infof("Initialized gateway for hwId %v\n", gwId)
+	// Take ownership of the gateway right away, so other dispatcher instances
+	// forward its requests here without waiting for the first heartbeat.
+	// The record is not removed on cleanup, the gateway may have already
+	// reconnected to another instance by then.
+	if err := directoryd.UpdateHostNameByHwId(gwId, srv.hostName); err != nil {
+		glog.Errorf("Failed to register hostName %v for hwId %v: %v\n", srv.hostName, gwId, err)
+	}
 	coordinator.Wg.Add(1)
 	go srv.receiveFromStream(stream, coordinator)
 	coordinator.Wg.Add(1)
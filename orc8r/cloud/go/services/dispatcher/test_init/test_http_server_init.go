@@ -17,13 +17,19 @@ import (
 )
 
 func StartTestHttpServer(t *testing.T) (net.Addr, *mocks.GatewayRPCBroker) {
+	return StartTestHttpServerWithHostName(t, "test host name")
+}
+
+// StartTestHttpServerWithHostName starts a SyncRPC http server of the
+// dispatcher instance running at hostName
+func StartTestHttpServerWithHostName(t *testing.T, hostName string) (net.Addr, *mocks.GatewayRPCBroker) {
 	lis, err := net.Listen("tcp", "")
 	if err != nil {
 		t.Fatalf("net.Listen err: %v\n", err)
 	}
 
 	broker := new(mocks.GatewayRPCBroker)
-	server := httpserver.NewSyncRPCHttpServer(broker)
+	server := httpserver.NewSyncRPCHttpServer(hostName, broker)
 	go server.Serve(lis)
 	return lis.Addr(), broker
 }
@@ -11,10 +11,13 @@ package test_init
 import (
 	"testing"
 
+	"magma/orc8r/cloud/go/blobstore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/dispatcher"
 	"magma/orc8r/cloud/go/services/dispatcher/broker/mocks"
+	"magma/orc8r/cloud/go/services/dispatcher/broker/offline"
+	dispatcherprotos "magma/orc8r/cloud/go/services/dispatcher/protos"
 	"magma/orc8r/cloud/go/services/dispatcher/servicers"
 	"magma/orc8r/cloud/go/test_utils"
 )
@@ -30,3 +33,13 @@ func StartTestService(t *testing.T) *mocks.GatewayRPCBroker {
 	go srv.RunTest(lis)
 	return mockBroker
 }
+
+// StartTestOfflineQueueService starts a dispatcher service serving only the
+// offline request queue, and returns the queue
+func StartTestOfflineQueueService(t *testing.T) *offline.Queue {
+	srv, lis := test_utils.NewTestService(t, orc8r.ModuleName, dispatcher.ServiceName)
+	queue := offline.NewQueue(blobstore.NewMemoryBlobStorageFactory())
+	dispatcherprotos.RegisterOfflineRequestQueueServer(srv.GrpcServer, servicers.NewOfflineRequestQueueServer(queue))
+	go srv.RunTest(lis)
+	return queue
+}
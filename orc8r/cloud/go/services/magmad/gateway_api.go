@@ -11,6 +11,7 @@ package magmad
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/protos"
@@ -19,6 +20,8 @@ import (
 
 	"github.com/golang/glog"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func getGWMagmadClient(networkID string, gatewayID string) (protos.MagmadClient, context.Context, error) {
@@ -35,22 +38,71 @@ func getGWMagmadClient(networkID string, gatewayID string) (protos.MagmadClient,
 	return protos.NewMagmadClient(conn), ctx, nil
 }
 
+// OfflineCommandTTL is how long reboot and restart commands for a
+// disconnected gateway are queued when the caller opts in to queueing, by
+// dispatchers with the offline queue enabled. The commands are run once the
+// gateway reconnects.
+var OfflineCommandTTL = time.Hour * 24
+
 func GatewayReboot(networkId string, gatewayId string) error {
+	_, err := gatewayReboot(networkId, gatewayId, false)
+	return err
+}
+
+// GatewayRebootOrQueue reboots the gateway, or queues the reboot for up to
+// OfflineCommandTTL if the gateway is disconnected. Returns the ID of the
+// queued request if the reboot was queued, an empty string if the gateway
+// was rebooted.
+func GatewayRebootOrQueue(networkId string, gatewayId string) (string, error) {
+	return gatewayReboot(networkId, gatewayId, true)
+}
+
+func gatewayReboot(networkId string, gatewayId string, queueIfOffline bool) (string, error) {
 	client, ctx, err := getGWMagmadClient(networkId, gatewayId)
 	if err != nil {
-		return err
+		return "", err
 	}
-	_, err = client.Reboot(ctx, new(protos.Void))
-	return err
+	ctx, header := withOptionalQueueing(ctx, queueIfOffline)
+	_, err = client.Reboot(ctx, new(protos.Void), grpc.Header(header))
+	return getQueuedRequestID(header, err)
 }
 
 func GatewayRestartServices(networkId string, gatewayId string, services []string) error {
+	_, err := gatewayRestartServices(networkId, gatewayId, services, false)
+	return err
+}
+
+// GatewayRestartServicesOrQueue restarts the gateway's services, or queues
+// the restart for up to OfflineCommandTTL if the gateway is disconnected.
+// Returns the ID of the queued request if the restart was queued, an empty
+// string if the services were restarted.
+func GatewayRestartServicesOrQueue(networkId string, gatewayId string, services []string) (string, error) {
+	return gatewayRestartServices(networkId, gatewayId, services, true)
+}
+
+func gatewayRestartServices(networkId string, gatewayId string, services []string, queueIfOffline bool) (string, error) {
 	client, ctx, err := getGWMagmadClient(networkId, gatewayId)
 	if err != nil {
-		return err
+		return "", err
 	}
-	_, err = client.RestartServices(ctx, &protos.RestartServicesRequest{Services: services})
-	return err
+	ctx, header := withOptionalQueueing(ctx, queueIfOffline)
+	_, err = client.RestartServices(ctx, &protos.RestartServicesRequest{Services: services}, grpc.Header(header))
+	return getQueuedRequestID(header, err)
+}
+
+func withOptionalQueueing(ctx context.Context, queueIfOffline bool) (context.Context, *metadata.MD) {
+	if queueIfOffline {
+		ctx = gateway_registry.WithOfflineQueueing(ctx, OfflineCommandTTL)
+	}
+	return ctx, &metadata.MD{}
+}
+
+func getQueuedRequestID(header *metadata.MD, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	id, _ := gateway_registry.GetQueuedRequestID(*header)
+	return id, nil
 }
 
 func GatewayPing(networkId string, gatewayId string, packets int32, hosts []string) (*protos.NetworkTestResponse, error) {
@@ -0,0 +1,91 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs
+
+import (
+	"fmt"
+	"sort"
+
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/magmad"
+	"magma/orc8r/cloud/go/storage"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// ResolveGateways returns sorted IDs of the network's magmad gateways
+// matching the selector. Explicitly selected gateways must exist unless the
+// selector also has labels, gateways without the labels are then skipped.
+func ResolveGateways(networkID string, selector Selector) ([]string, error) {
+	criteria := configurator.EntityLoadCriteria{LoadAssocsToThis: len(selector.Tier) > 0}
+	gateways, err := configurator.LoadAllEntitiesWithLabels(
+		networkID, orc8r.MagmadGatewayType, selector.Labels, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gateways: %s", err)
+	}
+	var explicitIDs map[string]bool
+	if len(selector.GatewayIDs) > 0 {
+		explicitIDs = map[string]bool{}
+		for _, gwID := range selector.GatewayIDs {
+			explicitIDs[gwID] = false
+		}
+	}
+	tierTK := storage.TypeAndKey{Type: orc8r.UpgradeTierEntityType, Key: selector.Tier}
+
+	ret := []string{}
+	for _, gw := range gateways {
+		if explicitIDs != nil {
+			if _, ok := explicitIDs[gw.Key]; !ok {
+				continue
+			}
+			explicitIDs[gw.Key] = true
+		}
+		if len(selector.Tier) > 0 && !containsTK(gw.ParentAssociations, tierTK) {
+			continue
+		}
+		ret = append(ret, gw.Key)
+	}
+	if len(selector.Labels) == 0 {
+		for gwID, found := range explicitIDs {
+			if !found {
+				return nil, fmt.Errorf("gateway %s not found", gwID)
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// ExecuteGatewayCommand runs the command on the gateway via its magmad
+func ExecuteGatewayCommand(networkID, gatewayID string, cmd Command) (*structpb.Struct, error) {
+	switch cmd.Type {
+	case RebootCommand:
+		return nil, magmad.GatewayReboot(networkID, gatewayID)
+	case RestartServicesCommand:
+		return nil, magmad.GatewayRestartServices(networkID, gatewayID, cmd.Services)
+	case GenericCommand:
+		resp, err := magmad.GatewayGenericCommand(networkID, gatewayID, cmd.Generic)
+		if err != nil {
+			return nil, err
+		}
+		return resp.GetResponse(), nil
+	default:
+		return nil, fmt.Errorf("unsupported command type '%s'", cmd.Type)
+	}
+}
+
+func containsTK(tks []storage.TypeAndKey, tk storage.TypeAndKey) bool {
+	for _, t := range tks {
+		if t == tk {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/plugin"
+	"magma/orc8r/cloud/go/pluginimpl"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/services/magmad/jobs"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveGateways(t *testing.T) {
+	_ = plugin.RegisterPluginForTests(t, &pluginimpl.BaseOrchestratorPlugin{})
+	test_init.StartTestService(t)
+	assert.NoError(t, configurator.CreateNetwork(configurator.Network{ID: "n1"}))
+	_, err := configurator.CreateEntities("n1", []configurator.NetworkEntity{
+		{Type: orc8r.MagmadGatewayType, Key: "gw1", Labels: map[string]string{"region": "west"}},
+		{Type: orc8r.MagmadGatewayType, Key: "gw2", Labels: map[string]string{"region": "east"}},
+		{Type: orc8r.MagmadGatewayType, Key: "gw3", Labels: map[string]string{"region": "west"}},
+		{Type: orc8r.MagmadGatewayType, Key: "gw4"},
+		{
+			Type: orc8r.UpgradeTierEntityType, Key: "t1",
+			Associations: []storage.TypeAndKey{
+				{Type: orc8r.MagmadGatewayType, Key: "gw1"},
+				{Type: orc8r.MagmadGatewayType, Key: "gw2"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		selector jobs.Selector
+		expected []string
+	}{
+		{jobs.Selector{}, []string{"gw1", "gw2", "gw3", "gw4"}},
+		{jobs.Selector{GatewayIDs: []string{"gw4", "gw2"}}, []string{"gw2", "gw4"}},
+		{jobs.Selector{Tier: "t1"}, []string{"gw1", "gw2"}},
+		{jobs.Selector{Labels: map[string]string{"region": "west"}}, []string{"gw1", "gw3"}},
+		{jobs.Selector{Tier: "t1", Labels: map[string]string{"region": "west"}}, []string{"gw1"}},
+		{jobs.Selector{GatewayIDs: []string{"gw2", "gw3"}, Labels: map[string]string{"region": "west"}}, []string{"gw3"}},
+		{jobs.Selector{Tier: "t2"}, []string{}},
+	}
+	for _, test := range tests {
+		actual, err := jobs.ResolveGateways("n1", test.selector)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, actual, "%+v", test.selector)
+	}
+
+	// explicitly selected gateways must exist
+	_, err = jobs.ResolveGateways("n1", jobs.Selector{GatewayIDs: []string{"gw1", "gw5"}})
+	assert.EqualError(t, err, "gateway gw5 not found")
+}
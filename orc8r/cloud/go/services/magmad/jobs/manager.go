@@ -0,0 +1,515 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/datastore"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/golang/glog"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+var (
+	// DefaultConcurrency is the concurrency of jobs submitted without one
+	DefaultConcurrency = 1
+	// MaxConcurrency is the max allowed job concurrency
+	MaxConcurrency = 50
+	// FinishedJobRetention is the time finished jobs are kept for inspection
+	FinishedJobRetention = time.Hour * 24
+	// LeaseDuration is how long a manager keeps running a job without
+	// renewing its lease on it. Jobs whose lease expired, e.g. because their
+	// manager's process stopped, are resumed by another manager.
+	LeaseDuration = time.Minute
+)
+
+// interruptedError is the error of gateways whose command was started by a
+// manager which stopped before recording the command's result
+const interruptedError = "the job was interrupted while the command was running"
+
+// Executor runs the command on the network's gateway and returns the
+// command's response (generic commands only)
+type Executor func(networkID, gatewayID string, cmd Command) (*structpb.Struct, error)
+
+// Resolver returns IDs of the network's gateways matching the selector
+type Resolver func(networkID string, selector Selector) ([]string, error)
+
+// Manager runs & keeps track of batch jobs. Jobs and their gateways' results
+// are kept in a blobstore shared by all managers, so any manager can inspect
+// and cancel any job. Each job is run by the manager which holds its lease,
+// jobs of managers which stop renewing their leases are claimed & resumed by
+// other managers.
+type Manager struct {
+	id       string
+	factory  blobstore.BlobStorageFactory
+	executor Executor
+	resolver Resolver
+
+	// storeMu serializes this manager's writes to the blobstore, writes
+	// across managers are serialized by the blobstore's transactions
+	storeMu sync.Mutex
+
+	sync.Mutex
+	// running holds the jobs run by this manager, by jobKey
+	running map[string]*runningJob
+}
+
+type runningJob struct {
+	cancel     chan struct{}
+	cancelOnce sync.Once
+}
+
+// NewManager returns a Manager which keeps jobs in the blobstore, selects
+// jobs' gateways with the resolver and runs their commands with the executor
+func NewManager(factory blobstore.BlobStorageFactory, executor Executor, resolver Resolver) *Manager {
+	return &Manager{
+		id:       newJobID(),
+		factory:  factory,
+		executor: executor,
+		resolver: resolver,
+		running:  map[string]*runningJob{},
+	}
+}
+
+// resumeInterval is the interval at which the default manager resumes jobs
+// with expired leases
+const resumeInterval = time.Minute
+
+var (
+	defaultManager     *Manager
+	defaultManagerErr  error
+	defaultManagerOnce sync.Once
+)
+
+// DefaultManager returns the process wide Manager which keeps jobs in the
+// orchestrator's database and runs magmad commands on gateways selected by
+// ResolveGateways. The manager is created by the first call, from then on it
+// resumes jobs with expired leases in the background. Only the obsidian
+// service is expected to call DefaultManager.
+func DefaultManager() (*Manager, error) {
+	defaultManagerOnce.Do(func() {
+		db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
+		if err != nil {
+			defaultManagerErr = fmt.Errorf("failed to connect to database: %s", err)
+			return
+		}
+		factory := blobstore.NewSQLBlobStorageFactory(DBTableName, db, sqorc.GetSqlBuilder())
+		if err = factory.InitializeFactory(); err != nil {
+			defaultManagerErr = fmt.Errorf("failed to initialize batch jobs table: %s", err)
+			return
+		}
+		defaultManager = NewManager(factory, ExecuteGatewayCommand, ResolveGateways)
+		go defaultManager.Run(resumeInterval)
+	})
+	return defaultManager, defaultManagerErr
+}
+
+// Submit creates a job running the command on the network's gateways
+// matching the selector and returns it. The job starts at startTime or
+// immediately if startTime is zero or in the past. Gateways are selected at
+// submission time.
+func (m *Manager) Submit(
+	networkID string,
+	cmd Command,
+	selector Selector,
+	concurrency int,
+	startTime time.Time,
+) (*Job, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+	if concurrency == 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency < 0 || concurrency > MaxConcurrency {
+		return nil, fmt.Errorf("concurrency must be between 1 and %d", MaxConcurrency)
+	}
+	gatewayIDs, err := m.resolver(networkID, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(gatewayIDs) == 0 {
+		return nil, ErrNoGateways
+	}
+
+	now := clock.Now()
+	if startTime.IsZero() {
+		startTime = now
+	}
+	job := &storedJob{
+		Job: &Job{
+			ID:          newJobID(),
+			NetworkID:   networkID,
+			Command:     cmd,
+			Selector:    selector,
+			Concurrency: concurrency,
+			StartTime:   startTime,
+			State:       JobScheduled,
+			CreatedAt:   now,
+			Results:     make(map[string]*GatewayResult, len(gatewayIDs)),
+		},
+		Owner:       m.id,
+		LeaseExpiry: now.Add(LeaseDuration),
+	}
+	for _, gwID := range gatewayIDs {
+		job.Results[gwID] = &GatewayResult{State: GatewayPending}
+	}
+	if err = m.createJob(job); err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Submitted %s job %s for %d gateways of network %s", cmd.Type, job.ID, len(gatewayIDs), networkID)
+	m.start(networkID, job.ID)
+	return job.Job, nil
+}
+
+// Get returns the network's job
+func (m *Manager) Get(networkID, jobID string) (*Job, error) {
+	job, err := m.getJob(networkID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return job.Job, nil
+}
+
+// List returns the network's jobs, oldest first
+func (m *Manager) List(networkID string) ([]*Job, error) {
+	storedJobs, err := m.listJobs(networkID)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Job, 0, len(storedJobs))
+	for _, job := range storedJobs {
+		ret = append(ret, job.Job)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].CreatedAt.Equal(ret[j].CreatedAt) {
+			return ret[i].ID < ret[j].ID
+		}
+		return ret[i].CreatedAt.Before(ret[j].CreatedAt)
+	})
+	return ret, nil
+}
+
+// Cancel stops the network's job, gateways which have not started the
+// command yet are skipped. Commands already running on gateways are not
+// interrupted, the job is finished once they complete. Jobs run by other
+// managers stop once their manager notices the cancellation, at the latest
+// when it renews its lease.
+func (m *Manager) Cancel(networkID, jobID string) (*Job, error) {
+	job, err := m.updateJob(networkID, jobID, func(job *storedJob) error {
+		if job.IsFinished() {
+			return ErrFinished
+		}
+		if job.Cancelled {
+			return errUnchanged
+		}
+		job.Cancelled = true
+		return nil
+	})
+	if err != nil && err != errUnchanged {
+		return nil, err
+	}
+	if err == nil {
+		glog.Infof("Cancelled job %s of network %s", jobID, networkID)
+	}
+
+	m.Lock()
+	if running, ok := m.running[jobKey(networkID, jobID)]; ok {
+		running.cancelOnce.Do(func() { close(running.cancel) })
+	}
+	m.Unlock()
+	return job.Job, nil
+}
+
+// Run resumes jobs with expired leases and deletes old finished jobs every
+// interval. This function blocks.
+func (m *Manager) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := m.ResumeJobs(); err != nil {
+			glog.Errorf("Error resuming batch jobs: %s", err)
+		}
+	}
+}
+
+// ResumeJobs claims & resumes unfinished jobs whose lease expired, and
+// deletes jobs finished more than FinishedJobRetention ago. Gateways whose
+// command was running when the job's previous manager stopped are marked
+// failed, their commands aren't run again.
+func (m *Manager) ResumeJobs() error {
+	storedJobs, err := m.listJobs("")
+	if err != nil {
+		return err
+	}
+	now := clock.Now()
+	gcThreshold := now.Add(-FinishedJobRetention)
+	var garbage []*storedJob
+	for _, job := range storedJobs {
+		if job.IsFinished() {
+			if job.FinishedAt.Before(gcThreshold) {
+				garbage = append(garbage, job)
+			}
+			continue
+		}
+		if !job.LeaseExpiry.Before(now) || m.isRunning(job.NetworkID, job.ID) {
+			continue
+		}
+		_, err := m.updateJob(job.NetworkID, job.ID, func(job *storedJob) error {
+			return m.claim(job, clock.Now())
+		})
+		if err == errUnchanged {
+			continue
+		}
+		if err != nil {
+			glog.Errorf("Failed to claim job %s of network %s: %s", job.ID, job.NetworkID, err)
+			continue
+		}
+		glog.Infof("Resuming job %s of network %s, previously run by manager %s", job.ID, job.NetworkID, job.Owner)
+		m.start(job.NetworkID, job.ID)
+	}
+	if len(garbage) > 0 {
+		return m.deleteJobs(garbage)
+	}
+	return nil
+}
+
+// claim takes over the job if it's unfinished and its lease is expired
+func (m *Manager) claim(job *storedJob, now time.Time) error {
+	if job.IsFinished() || !job.LeaseExpiry.Before(now) {
+		return errUnchanged
+	}
+	job.Owner, job.LeaseExpiry = m.id, now.Add(LeaseDuration)
+	for _, res := range job.Results {
+		if res.State == GatewayRunning {
+			res.State, res.Error, res.FinishedAt = GatewayFailed, interruptedError, now
+		}
+	}
+	return nil
+}
+
+// start runs the job in the background, the manager must hold the job's
+// lease
+func (m *Manager) start(networkID, jobID string) {
+	running := &runningJob{cancel: make(chan struct{})}
+	m.Lock()
+	m.running[jobKey(networkID, jobID)] = running
+	m.Unlock()
+	go func() {
+		m.run(networkID, jobID, running.cancel)
+		m.Lock()
+		delete(m.running, jobKey(networkID, jobID))
+		m.Unlock()
+	}()
+}
+
+func (m *Manager) isRunning(networkID, jobID string) bool {
+	m.Lock()
+	defer m.Unlock()
+	_, ok := m.running[jobKey(networkID, jobID)]
+	return ok
+}
+
+func (m *Manager) run(networkID, jobID string, cancel <-chan struct{}) {
+	renewal := time.NewTicker(LeaseDuration / 3)
+	defer renewal.Stop()
+
+	// Wait for the start time, renewing the lease meanwhile
+	job, err := m.renewLease(networkID, jobID)
+	for err == nil && !job.Cancelled {
+		wait := job.StartTime.Sub(clock.Now())
+		if wait <= 0 {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-renewal.C:
+		case <-cancel:
+		}
+		timer.Stop()
+		job, err = m.renewLease(networkID, jobID)
+	}
+	if err != nil {
+		glog.Errorf("Stopped running job %s of network %s: %s", jobID, networkID, err)
+		return
+	}
+	if job.Cancelled {
+		m.finish(networkID, jobID)
+		return
+	}
+
+	job, err = m.updateJob(networkID, jobID, func(job *storedJob) error {
+		if job.Owner != m.id {
+			return errLeaseLost
+		}
+		job.State = JobRunning
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("Stopped running job %s of network %s: %s", jobID, networkID, err)
+		return
+	}
+
+	var gatewayIDs []string
+	for gwID, res := range job.Results {
+		if res.State == GatewayPending {
+			gatewayIDs = append(gatewayIDs, gwID)
+		}
+	}
+	sort.Strings(gatewayIDs)
+	queue := make(chan string, len(gatewayIDs))
+	for _, gwID := range gatewayIDs {
+		queue <- gwID
+	}
+	close(queue)
+
+	// Keep renewing the lease while the gateways' commands run
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-renewal.C:
+				if _, err := m.renewLease(networkID, jobID); err != nil {
+					glog.Errorf("Failed to renew lease on job %s of network %s: %s", jobID, networkID, err)
+				}
+			}
+		}
+	}()
+	wg := sync.WaitGroup{}
+	for i := 0; i < job.Concurrency && i < len(gatewayIDs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for gwID := range queue {
+				if !m.startGateway(networkID, jobID, gwID) {
+					return
+				}
+				resp, err := m.executor(networkID, gwID, job.Command)
+				m.finishGateway(networkID, jobID, gwID, resp, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	m.finish(networkID, jobID)
+}
+
+// errLeaseLost is returned by updates of a job whose lease was claimed by
+// another manager
+var errLeaseLost = errors.New("the job's lease was claimed by another manager")
+
+// renewLease extends this manager's lease on the job and returns the job
+func (m *Manager) renewLease(networkID, jobID string) (*storedJob, error) {
+	return m.updateJob(networkID, jobID, func(job *storedJob) error {
+		if job.Owner != m.id {
+			return errLeaseLost
+		}
+		job.LeaseExpiry = clock.Now().Add(LeaseDuration)
+		return nil
+	})
+}
+
+// startGateway marks the gateway's command as running, it returns false if
+// the job was cancelled or this manager lost its lease on the job
+func (m *Manager) startGateway(networkID, jobID, gatewayID string) bool {
+	_, err := m.updateJob(networkID, jobID, func(job *storedJob) error {
+		if job.Owner != m.id {
+			return errLeaseLost
+		}
+		if job.Cancelled {
+			return errUnchanged
+		}
+		now := clock.Now()
+		res := job.Results[gatewayID]
+		res.State, res.StartedAt = GatewayRunning, now
+		job.LeaseExpiry = now.Add(LeaseDuration)
+		return nil
+	})
+	switch {
+	case err == errUnchanged:
+		return false
+	case err != nil:
+		glog.Errorf("Failed to start command of job %s on gateway %s: %s", jobID, gatewayID, err)
+		return false
+	}
+	return true
+}
+
+func (m *Manager) finishGateway(networkID, jobID, gatewayID string, resp *structpb.Struct, cmdErr error) {
+	_, err := m.updateJob(networkID, jobID, func(job *storedJob) error {
+		if job.Owner != m.id {
+			return errLeaseLost
+		}
+		res := job.Results[gatewayID]
+		res.FinishedAt = clock.Now()
+		if cmdErr != nil {
+			res.State, res.Error = GatewayFailed, cmdErr.Error()
+			return nil
+		}
+		res.State, res.Response = GatewaySucceeded, resp
+		return nil
+	})
+	if cmdErr != nil {
+		glog.Errorf("Job %s command failed on gateway %s of network %s: %s", jobID, gatewayID, networkID, cmdErr)
+	}
+	if err != nil {
+		glog.Errorf("Failed to record result of job %s on gateway %s: %s", jobID, gatewayID, err)
+	}
+}
+
+// finish sets the job's final state and releases its lease, gateways which
+// didn't run the command are marked cancelled
+func (m *Manager) finish(networkID, jobID string) {
+	job, err := m.updateJob(networkID, jobID, func(job *storedJob) error {
+		if job.Owner != m.id {
+			return errLeaseLost
+		}
+		job.State, job.FinishedAt = JobCompleted, clock.Now()
+		if job.Cancelled {
+			job.State = JobCancelled
+			for _, res := range job.Results {
+				if res.State == GatewayPending {
+					res.State = GatewayCancelled
+				}
+			}
+		}
+		job.Owner, job.LeaseExpiry = "", time.Time{}
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("Failed to finish job %s of network %s: %s", jobID, networkID, err)
+		return
+	}
+	counts := job.Counts()
+	glog.Infof(
+		"Job %s of network %s %s: %d succeeded, %d failed, %d cancelled",
+		job.ID, job.NetworkID, job.State, counts[GatewaySucceeded], counts[GatewayFailed], counts[GatewayCancelled])
+}
+
+func jobKey(networkID, jobID string) string {
+	return networkID + "/" + jobID
+}
+
+func newJobID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/magmad/jobs"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+)
+
+// testExecutor records executed commands, fails gateways in failGateways and
+// blocks gateways' commands until release is closed
+type testExecutor struct {
+	sync.Mutex
+	executed     []string
+	inFlight     int
+	maxInFlight  int
+	failGateways map[string]bool
+	release      chan struct{}
+}
+
+func newTestExecutor(failGateways ...string) *testExecutor {
+	e := &testExecutor{failGateways: map[string]bool{}, release: make(chan struct{})}
+	for _, gwID := range failGateways {
+		e.failGateways[gwID] = true
+	}
+	return e
+}
+
+func (e *testExecutor) execute(networkID, gatewayID string, cmd jobs.Command) (*structpb.Struct, error) {
+	e.Lock()
+	e.executed = append(e.executed, gatewayID)
+	e.inFlight++
+	if e.inFlight > e.maxInFlight {
+		e.maxInFlight = e.inFlight
+	}
+	e.Unlock()
+
+	<-e.release
+
+	e.Lock()
+	e.inFlight--
+	e.Unlock()
+	if e.failGateways[gatewayID] {
+		return nil, fmt.Errorf("gateway %s is offline", gatewayID)
+	}
+	if cmd.Type == jobs.GenericCommand {
+		return &structpb.Struct{Fields: map[string]*structpb.Value{
+			"gateway": {Kind: &structpb.Value_StringValue{StringValue: gatewayID}},
+		}}, nil
+	}
+	return nil, nil
+}
+
+func (e *testExecutor) executedCount() int {
+	e.Lock()
+	defer e.Unlock()
+	return len(e.executed)
+}
+
+func testResolver(networkID string, selector jobs.Selector) ([]string, error) {
+	if networkID == "empty" {
+		return nil, nil
+	}
+	if len(selector.GatewayIDs) > 0 {
+		return selector.GatewayIDs, nil
+	}
+	return []string{"gw1", "gw2", "gw3", "gw4", "gw5"}, nil
+}
+
+func TestManager_Submit(t *testing.T) {
+	executor := newTestExecutor("gw2")
+	close(executor.release)
+	m := jobs.NewManager(blobstore.NewMemoryBlobStorageFactory(), executor.execute, testResolver)
+
+	job, err := m.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 0, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, "n1", job.NetworkID)
+	assert.Equal(t, jobs.DefaultConcurrency, job.Concurrency)
+	assert.Len(t, job.Results, 5)
+
+	job = waitForJob(t, m, "n1", job.ID)
+	assert.Equal(t, jobs.JobCompleted, job.State)
+	assert.False(t, job.FinishedAt.IsZero())
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewaySucceeded: 4, jobs.GatewayFailed: 1}, job.Counts())
+	assert.Equal(t, "gateway gw2 is offline", job.Results["gw2"].Error)
+	assert.Equal(t, 1, executor.maxInFlight)
+
+	// generic command responses are recorded
+	cmd := jobs.Command{Type: jobs.GenericCommand, Generic: &protos.GenericCommandParams{Command: "echo"}}
+	job, err = m.Submit("n1", cmd, jobs.Selector{GatewayIDs: []string{"gw3"}}, 1, time.Time{})
+	assert.NoError(t, err)
+	job = waitForJob(t, m, "n1", job.ID)
+	assert.Equal(t, jobs.GatewaySucceeded, job.Results["gw3"].State)
+	assert.Equal(t, "gw3", job.Results["gw3"].Response.Fields["gateway"].GetStringValue())
+
+	// jobs are listed per network
+	listed, err := m.List("n1")
+	assert.NoError(t, err)
+	assert.Len(t, listed, 2)
+	listed, err = m.List("n2")
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+	_, err = m.Get("n2", job.ID)
+	assert.Equal(t, jobs.ErrNotFound, err)
+
+	// invalid jobs
+	_, err = m.Submit("n1", jobs.Command{Type: "shutdown"}, jobs.Selector{}, 1, time.Time{})
+	assert.Error(t, err)
+	_, err = m.Submit("n1", jobs.Command{Type: jobs.RestartServicesCommand}, jobs.Selector{}, 1, time.Time{})
+	assert.Error(t, err)
+	_, err = m.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, jobs.MaxConcurrency+1, time.Time{})
+	assert.Error(t, err)
+	_, err = m.Submit("empty", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 1, time.Time{})
+	assert.Equal(t, jobs.ErrNoGateways, err)
+}
+
+func TestManager_Concurrency(t *testing.T) {
+	executor := newTestExecutor()
+	m := jobs.NewManager(blobstore.NewMemoryBlobStorageFactory(), executor.execute, testResolver)
+
+	job, err := m.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 2, time.Time{})
+	assert.NoError(t, err)
+	waitFor(t, func() bool { return executor.executedCount() == 2 })
+	job, err = m.Get("n1", job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.JobRunning, job.State)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewayRunning: 2, jobs.GatewayPending: 3}, job.Counts())
+
+	close(executor.release)
+	job = waitForJob(t, m, "n1", job.ID)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewaySucceeded: 5}, job.Counts())
+	assert.Equal(t, 2, executor.maxInFlight)
+}
+
+func TestManager_Cancel(t *testing.T) {
+	executor := newTestExecutor()
+	m := jobs.NewManager(blobstore.NewMemoryBlobStorageFactory(), executor.execute, testResolver)
+
+	// cancel a running job, the running command completes
+	job, err := m.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 1, time.Time{})
+	assert.NoError(t, err)
+	waitFor(t, func() bool { return executor.executedCount() == 1 })
+	_, err = m.Cancel("n1", job.ID)
+	assert.NoError(t, err)
+	close(executor.release)
+	job = waitForJob(t, m, "n1", job.ID)
+	assert.Equal(t, jobs.JobCancelled, job.State)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewaySucceeded: 1, jobs.GatewayCancelled: 4}, job.Counts())
+	assert.Equal(t, 1, executor.executedCount())
+
+	// finished jobs can't be cancelled
+	_, err = m.Cancel("n1", job.ID)
+	assert.Equal(t, jobs.ErrFinished, err)
+	_, err = m.Cancel("n1", "unknown")
+	assert.Equal(t, jobs.ErrNotFound, err)
+}
+
+func TestManager_Scheduled(t *testing.T) {
+	executor := newTestExecutor()
+	close(executor.release)
+	m := jobs.NewManager(blobstore.NewMemoryBlobStorageFactory(), executor.execute, testResolver)
+
+	job, err := m.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 5, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.JobScheduled, job.State)
+	time.Sleep(time.Millisecond * 50)
+	job, err = m.Get("n1", job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.JobScheduled, job.State)
+	assert.Equal(t, 0, executor.executedCount())
+
+	// cancel a scheduled job
+	_, err = m.Cancel("n1", job.ID)
+	assert.NoError(t, err)
+	job = waitForJob(t, m, "n1", job.ID)
+	assert.Equal(t, jobs.JobCancelled, job.State)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewayCancelled: 5}, job.Counts())
+
+	// job starts at its start time
+	job, err = m.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 5, time.Now().Add(time.Millisecond*100))
+	assert.NoError(t, err)
+	job = waitForJob(t, m, "n1", job.ID)
+	assert.Equal(t, jobs.JobCompleted, job.State)
+	assert.Equal(t, 5, executor.executedCount())
+	for _, res := range job.Results {
+		assert.False(t, res.StartedAt.Before(job.StartTime))
+	}
+}
+
+func TestManager_SharedStore(t *testing.T) {
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	executor := newTestExecutor()
+	m1 := jobs.NewManager(factory, executor.execute, testResolver)
+	m2 := jobs.NewManager(factory, newTestExecutor().execute, testResolver)
+
+	// jobs run by one manager can be inspected and cancelled by another
+	job, err := m1.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 1, time.Time{})
+	assert.NoError(t, err)
+	waitFor(t, func() bool { return executor.executedCount() == 1 })
+	listed, err := m2.List("n1")
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+	job, err = m2.Get("n1", job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewayRunning: 1, jobs.GatewayPending: 4}, job.Counts())
+
+	_, err = m2.Cancel("n1", job.ID)
+	assert.NoError(t, err)
+	close(executor.release)
+	job = waitForJob(t, m2, "n1", job.ID)
+	assert.Equal(t, jobs.JobCancelled, job.State)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewaySucceeded: 1, jobs.GatewayCancelled: 4}, job.Counts())
+	assert.Equal(t, 1, executor.executedCount())
+}
+
+func TestManager_ResumeJobs(t *testing.T) {
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	now := time.Now()
+	clock.SetAndFreezeClock(t, now)
+	defer clock.UnfreezeClock(t)
+
+	stoppedExecutor := newTestExecutor()
+	stopped := jobs.NewManager(factory, stoppedExecutor.execute, testResolver)
+	job, err := stopped.Submit("n1", jobs.Command{Type: jobs.RebootCommand}, jobs.Selector{}, 1, time.Time{})
+	assert.NoError(t, err)
+	waitFor(t, func() bool { return stoppedExecutor.executedCount() == 1 })
+
+	// jobs with a valid lease aren't claimed
+	executor := newTestExecutor()
+	close(executor.release)
+	m := jobs.NewManager(factory, executor.execute, testResolver)
+	assert.NoError(t, m.ResumeJobs())
+	job, err = m.Get("n1", job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.JobRunning, job.State)
+	assert.Equal(t, 0, executor.executedCount())
+
+	// once the lease expires, the job is resumed without re-running the
+	// interrupted command
+	clock.SetAndFreezeClock(t, now.Add(jobs.LeaseDuration+time.Second))
+	assert.NoError(t, m.ResumeJobs())
+	job = waitForJob(t, m, "n1", job.ID)
+	assert.Equal(t, jobs.JobCompleted, job.State)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewaySucceeded: 4, jobs.GatewayFailed: 1}, job.Counts())
+	assert.Equal(t, 4, executor.executedCount())
+	for gwID, res := range job.Results {
+		if res.State == jobs.GatewayFailed {
+			assert.Equal(t, []string{gwID}, stoppedExecutor.executed)
+		}
+	}
+
+	// the stopped manager's late result is discarded and it runs no more
+	// commands
+	close(stoppedExecutor.release)
+	time.Sleep(time.Millisecond * 50)
+	job, err = m.Get("n1", job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[jobs.GatewayState]int{jobs.GatewaySucceeded: 4, jobs.GatewayFailed: 1}, job.Counts())
+	assert.Equal(t, 1, stoppedExecutor.executedCount())
+
+	// finished jobs are deleted after the retention period
+	clock.SetAndFreezeClock(t, now.Add(jobs.FinishedJobRetention+jobs.LeaseDuration+time.Minute))
+	assert.NoError(t, m.ResumeJobs())
+	_, err = m.Get("n1", job.ID)
+	assert.Equal(t, jobs.ErrNotFound, err)
+}
+
+func waitForJob(t *testing.T, m *jobs.Manager, networkID, jobID string) *jobs.Job {
+	var job *jobs.Job
+	waitFor(t, func() bool {
+		var err error
+		job, err = m.Get(networkID, jobID)
+		assert.NoError(t, err)
+		return job.IsFinished()
+	})
+	return job
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatal("condition not met in time")
+}
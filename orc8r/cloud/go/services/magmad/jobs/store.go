@@ -0,0 +1,312 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	magmaerrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+const (
+	// DBTableName is the blobstore table of batch jobs. Jobs are stored under
+	// their network's ID, each job with the results of all its gateways in a
+	// single blob.
+	DBTableName = "magmad_batch_jobs"
+
+	jobType = "batch_job"
+)
+
+// errUnchanged is returned by job updates which don't modify the job
+var errUnchanged = errors.New("job unchanged")
+
+// storedJob is a job with the state shared by all managers
+type storedJob struct {
+	*Job
+	// Cancelled is set once the job is cancelled, the manager running the job
+	// stops starting gateways' commands once it notices
+	Cancelled bool
+	// Owner is the ID of the manager running the job, other managers claim
+	// the job once its lease expires
+	Owner       string
+	LeaseExpiry time.Time
+}
+
+// jobRecord is the serialized form of a storedJob, protos are kept in their
+// binary encoding
+type jobRecord struct {
+	ID             string
+	NetworkID      string
+	CommandType    CommandType
+	Services       []string `json:",omitempty"`
+	GenericCommand []byte   `json:",omitempty"`
+	Selector       Selector
+	Concurrency    int
+	StartTime      time.Time
+	State          JobState
+	CreatedAt      time.Time
+	FinishedAt     time.Time
+	Results        map[string]*resultRecord
+	Cancelled      bool
+	Owner          string
+	LeaseExpiry    time.Time
+}
+
+type resultRecord struct {
+	State      GatewayState
+	Error      string `json:",omitempty"`
+	Response   []byte `json:",omitempty"`
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+func marshalJob(job *storedJob) ([]byte, error) {
+	record := jobRecord{
+		ID:          job.ID,
+		NetworkID:   job.NetworkID,
+		CommandType: job.Command.Type,
+		Services:    job.Command.Services,
+		Selector:    job.Selector,
+		Concurrency: job.Concurrency,
+		StartTime:   job.StartTime,
+		State:       job.State,
+		CreatedAt:   job.CreatedAt,
+		FinishedAt:  job.FinishedAt,
+		Results:     make(map[string]*resultRecord, len(job.Results)),
+		Cancelled:   job.Cancelled,
+		Owner:       job.Owner,
+		LeaseExpiry: job.LeaseExpiry,
+	}
+	if job.Command.Generic != nil {
+		generic, err := proto.Marshal(job.Command.Generic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal generic command: %s", err)
+		}
+		record.GenericCommand = generic
+	}
+	for gwID, res := range job.Results {
+		resRecord := &resultRecord{State: res.State, Error: res.Error, StartedAt: res.StartedAt, FinishedAt: res.FinishedAt}
+		if res.Response != nil {
+			response, err := proto.Marshal(res.Response)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response of gateway %s: %s", gwID, err)
+			}
+			resRecord.Response = response
+		}
+		record.Results[gwID] = resRecord
+	}
+	return json.Marshal(record)
+}
+
+func unmarshalJob(marshaled []byte) (*storedJob, error) {
+	record := jobRecord{}
+	if err := json.Unmarshal(marshaled, &record); err != nil {
+		return nil, err
+	}
+	job := &storedJob{
+		Job: &Job{
+			ID:          record.ID,
+			NetworkID:   record.NetworkID,
+			Command:     Command{Type: record.CommandType, Services: record.Services},
+			Selector:    record.Selector,
+			Concurrency: record.Concurrency,
+			StartTime:   record.StartTime,
+			State:       record.State,
+			CreatedAt:   record.CreatedAt,
+			FinishedAt:  record.FinishedAt,
+			Results:     make(map[string]*GatewayResult, len(record.Results)),
+		},
+		Cancelled:   record.Cancelled,
+		Owner:       record.Owner,
+		LeaseExpiry: record.LeaseExpiry,
+	}
+	if record.GenericCommand != nil {
+		job.Command.Generic = &protos.GenericCommandParams{}
+		if err := proto.Unmarshal(record.GenericCommand, job.Command.Generic); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal generic command: %s", err)
+		}
+	}
+	for gwID, resRecord := range record.Results {
+		res := &GatewayResult{State: resRecord.State, Error: resRecord.Error, StartedAt: resRecord.StartedAt, FinishedAt: resRecord.FinishedAt}
+		if resRecord.Response != nil {
+			res.Response = &structpb.Struct{}
+			if err := proto.Unmarshal(resRecord.Response, res.Response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal response of gateway %s: %s", gwID, err)
+			}
+		}
+		job.Results[gwID] = res
+	}
+	return job, nil
+}
+
+// createJob stores the new job
+func (m *Manager) createJob(job *storedJob) error {
+	marshaled, err := marshalJob(job)
+	if err != nil {
+		return err
+	}
+	store, err := m.factory.StartTransaction(nil)
+	if err != nil {
+		return err
+	}
+	err = store.CreateOrUpdate(job.NetworkID, []blobstore.Blob{{Type: jobType, Key: job.ID, Value: marshaled}})
+	if err != nil {
+		store.Rollback()
+		return fmt.Errorf("failed to create job %s: %s", job.ID, err)
+	}
+	return store.Commit()
+}
+
+// getJob returns the network's job or ErrNotFound
+func (m *Manager) getJob(networkID, jobID string) (*storedJob, error) {
+	store, err := m.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	blob, err := store.Get(networkID, storage.TypeAndKey{Type: jobType, Key: jobID})
+	if err == magmaerrors.ErrNotFound || (err == nil && len(blob.Value) == 0) {
+		store.Rollback()
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("failed to load job %s: %s", jobID, err)
+	}
+	if err = store.Commit(); err != nil {
+		return nil, err
+	}
+	return unmarshalJob(blob.Value)
+}
+
+// listJobs returns the jobs of the network, or of all networks if networkID
+// is empty
+func (m *Manager) listJobs(networkID string) ([]*storedJob, error) {
+	store, err := m.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	networkIDs := []string{networkID}
+	if networkID == "" {
+		networkIDs, err = store.ListNetworkIDs()
+		if err != nil {
+			store.Rollback()
+			return nil, fmt.Errorf("failed to list networks with jobs: %s", err)
+		}
+	}
+	var blobs []blobstore.Blob
+	for _, nid := range networkIDs {
+		networkBlobs, err := store.Search(nid, blobstore.SearchCriteria{Types: []string{jobType}})
+		if err != nil {
+			store.Rollback()
+			return nil, fmt.Errorf("failed to load jobs of network %s: %s", nid, err)
+		}
+		blobs = append(blobs, networkBlobs...)
+	}
+	if err = store.Commit(); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*storedJob, 0, len(blobs))
+	for _, blob := range blobs {
+		if len(blob.Value) == 0 {
+			continue
+		}
+		job, err := unmarshalJob(blob.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job %s: %s", blob.Key, err)
+		}
+		ret = append(ret, job)
+	}
+	return ret, nil
+}
+
+// updateJob applies the update to the network's job and stores the result.
+// If the update returns errUnchanged, the job is returned as is along with
+// errUnchanged. Updates of a job are serialized across managers: the job's
+// version is incremented first, which locks the job until the update is
+// committed.
+func (m *Manager) updateJob(networkID, jobID string, update func(job *storedJob) error) (*storedJob, error) {
+	m.storeMu.Lock()
+	defer m.storeMu.Unlock()
+
+	store, err := m.factory.StartTransaction(nil)
+	if err != nil {
+		return nil, err
+	}
+	tk := storage.TypeAndKey{Type: jobType, Key: jobID}
+	if err = store.IncrementVersion(networkID, tk); err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("failed to lock job %s: %s", jobID, err)
+	}
+	blob, err := store.Get(networkID, tk)
+	if err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("failed to load job %s: %s", jobID, err)
+	}
+	// Locking a job which doesn't exist creates an empty blob, which the
+	// rollback discards
+	if len(blob.Value) == 0 {
+		store.Rollback()
+		return nil, ErrNotFound
+	}
+	job, err := unmarshalJob(blob.Value)
+	if err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("failed to unmarshal job %s: %s", jobID, err)
+	}
+
+	err = update(job)
+	if err == errUnchanged {
+		store.Rollback()
+		return job, errUnchanged
+	}
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+	marshaled, err := marshalJob(job)
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+	err = store.CreateOrUpdate(networkID, []blobstore.Blob{{Type: jobType, Key: jobID, Value: marshaled}})
+	if err != nil {
+		store.Rollback()
+		return nil, fmt.Errorf("failed to update job %s: %s", jobID, err)
+	}
+	return job, store.Commit()
+}
+
+// deleteJobs deletes the given jobs
+func (m *Manager) deleteJobs(jobs []*storedJob) error {
+	m.storeMu.Lock()
+	defer m.storeMu.Unlock()
+
+	store, err := m.factory.StartTransaction(nil)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		err = store.Delete(job.NetworkID, []storage.TypeAndKey{{Type: jobType, Key: job.ID}})
+		if err != nil {
+			store.Rollback()
+			return fmt.Errorf("failed to delete job %s: %s", job.ID, err)
+		}
+	}
+	return store.Commit()
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package jobs implements batch jobs running a magmad command (reboot,
+// restart services, generic command) on a selected set of a network's
+// gateways, with limited concurrency, optional scheduled start, per gateway
+// result tracking & cancellation.
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// CommandType is the type of a job's gateway command
+type CommandType string
+
+const (
+	RebootCommand          CommandType = "reboot"
+	RestartServicesCommand CommandType = "restart_services"
+	GenericCommand         CommandType = "generic"
+)
+
+// JobState is the state of a job
+type JobState string
+
+const (
+	// JobScheduled - the job waits for its start time
+	JobScheduled JobState = "scheduled"
+	JobRunning   JobState = "running"
+	// JobCompleted - the command was run on all gateways, successfully or not
+	JobCompleted JobState = "completed"
+	// JobCancelled - the job was cancelled before completion, commands
+	// already running on gateways are not interrupted
+	JobCancelled JobState = "cancelled"
+)
+
+// GatewayState is the state of a job's command on a single gateway
+type GatewayState string
+
+const (
+	GatewayPending   GatewayState = "pending"
+	GatewayRunning   GatewayState = "running"
+	GatewaySucceeded GatewayState = "succeeded"
+	GatewayFailed    GatewayState = "failed"
+	GatewayCancelled GatewayState = "cancelled"
+)
+
+var (
+	// ErrNotFound is returned for unknown jobs
+	ErrNotFound = errors.New("job not found")
+	// ErrFinished is returned when cancelling a job which is already finished
+	ErrFinished = errors.New("job is already finished")
+	// ErrNoGateways is returned when a job's selector matches no gateways
+	ErrNoGateways = errors.New("no gateways match the selector")
+)
+
+// Command is the magmad command run on every gateway of a job
+type Command struct {
+	Type CommandType
+	// Services to restart, RestartServicesCommand only
+	Services []string
+	// Generic command & its params, GenericCommand only
+	Generic *protos.GenericCommandParams
+}
+
+// Validate returns an error if the command is incomplete
+func (c Command) Validate() error {
+	switch c.Type {
+	case RebootCommand:
+	case RestartServicesCommand:
+		if len(c.Services) == 0 {
+			return fmt.Errorf("%s command requires services", c.Type)
+		}
+	case GenericCommand:
+		if c.Generic == nil || len(c.Generic.Command) == 0 {
+			return fmt.Errorf("%s command requires a command name", c.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported command type '%s'", c.Type)
+	}
+	return nil
+}
+
+// Selector selects the gateways of a job within the job's network, a gateway
+// is selected if it matches all non-empty criteria
+type Selector struct {
+	// GatewayIDs - the gateway must be one of these
+	GatewayIDs []string
+	// Tier - the gateway must be in this upgrade tier
+	Tier string
+	// Labels - the gateway must have all these labels with matching values
+	Labels map[string]string
+}
+
+// Job is a snapshot of a batch job
+type Job struct {
+	ID        string
+	NetworkID string
+	Command   Command
+	Selector  Selector
+	// Concurrency is the max number of gateways running the command at once
+	Concurrency int
+	// StartTime is the time the job is scheduled to start at
+	StartTime  time.Time
+	State      JobState
+	CreatedAt  time.Time
+	FinishedAt time.Time
+	// Results by gateway ID
+	Results map[string]*GatewayResult
+}
+
+// GatewayResult is the result of a job's command on a single gateway
+type GatewayResult struct {
+	State GatewayState
+	// Error of a failed command
+	Error string
+	// Response of a succeeded generic command
+	Response   *structpb.Struct
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// IsFinished returns true if the job is completed or cancelled
+func (j *Job) IsFinished() bool {
+	return j.State == JobCompleted || j.State == JobCancelled
+}
+
+// Counts returns the number of the job's gateways in each state
+func (j *Job) Counts() map[GatewayState]int {
+	ret := map[GatewayState]int{}
+	for _, res := range j.Results {
+		ret[res.State]++
+	}
+	return ret
+}
@@ -14,11 +14,13 @@ import (
 	"net/http"
 	"regexp"
 	"sort"
+	"strconv"
 
 	"magma/orc8r/cloud/go/datastore"
 	merrors "magma/orc8r/cloud/go/errors"
 	models2 "magma/orc8r/cloud/go/models"
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/handlers"
 	"magma/orc8r/cloud/go/pluginimpl/models"
@@ -121,7 +123,7 @@ func createGateway(c echo.Context) error {
 		Key:        gatewayID,
 		PhysicalID: record.HardwareID,
 	}
-	_, err = configurator.CreateEntity(networkID, gwEntity)
+	_, err = access.ConfigWriter(c).CreateEntity(networkID, gwEntity)
 	if err != nil {
 		derr := device.DeleteDevice(networkID, orc8r.AccessGatewayRecordType, record.HardwareID)
 		if derr != nil {
@@ -207,7 +209,7 @@ func updateGatewayNameHandler(c echo.Context) error {
 		Type:    orc8r.MagmadGatewayType,
 		NewName: swag.String(string(payload)),
 	}
-	_, err := configurator.UpdateEntities(networkID, []configurator.EntityUpdateCriteria{updateRequest})
+	_, err := access.ConfigWriter(c).UpdateEntities(networkID, []configurator.EntityUpdateCriteria{updateRequest})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -249,7 +251,7 @@ func deleteGateway(c echo.Context) error {
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
-	err = configurator.DeleteEntity(networkID, orc8r.MagmadGatewayType, gatewayID)
+	err = access.ConfigWriter(c).DeleteEntity(networkID, orc8r.MagmadGatewayType, gatewayID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -275,13 +277,27 @@ func rebootGateway(c echo.Context) error {
 		return gerr
 	}
 
-	err := magmad.GatewayReboot(networkId, gatewayId)
+	queueIfOffline, qerr := getQueueIfOffline(c)
+	if qerr != nil {
+		return qerr
+	}
+
+	var queuedID string
+	var err error
+	if queueIfOffline {
+		queuedID, err = magmad.GatewayRebootOrQueue(networkId, gatewayId)
+	} else {
+		err = magmad.GatewayReboot(networkId, gatewayId)
+	}
 	if err != nil {
 		if datastore.IsErrNotFound(err) {
 			return obsidian.HttpError(err, http.StatusNotFound)
 		}
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
+	if queuedID != "" {
+		return c.JSON(http.StatusAccepted, &magmad_models.QueuedCommand{QueuedRequestID: queuedID})
+	}
 
 	return c.NoContent(http.StatusOK)
 }
@@ -296,22 +312,49 @@ func restartServices(c echo.Context) error {
 		return gerr
 	}
 
+	queueIfOffline, qerr := getQueueIfOffline(c)
+	if qerr != nil {
+		return qerr
+	}
+
 	var services []string
 	err := c.Bind(&services)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
-	err = magmad.GatewayRestartServices(networkId, gatewayId, services)
+	var queuedID string
+	if queueIfOffline {
+		queuedID, err = magmad.GatewayRestartServicesOrQueue(networkId, gatewayId, services)
+	} else {
+		err = magmad.GatewayRestartServices(networkId, gatewayId, services)
+	}
 	if err != nil {
 		if datastore.IsErrNotFound(err) {
 			return obsidian.HttpError(err, http.StatusNotFound)
 		}
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
+	if queuedID != "" {
+		return c.JSON(http.StatusAccepted, &magmad_models.QueuedCommand{QueuedRequestID: queuedID})
+	}
 
 	return c.NoContent(http.StatusOK)
 }
 
+// getQueueIfOffline returns the value of the optional queue_if_offline query
+// param, which opts a command in to be queued if the gateway is offline
+func getQueueIfOffline(c echo.Context) (bool, *echo.HTTPError) {
+	param := c.QueryParam("queue_if_offline")
+	if param == "" {
+		return false, nil
+	}
+	queueIfOffline, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, obsidian.HttpError(fmt.Errorf("invalid queue_if_offline: %s", param), http.StatusBadRequest)
+	}
+	return queueIfOffline, nil
+}
+
 func gatewayPing(c echo.Context) error {
 	networkId, nerr := obsidian.GetNetworkId(c)
 	if nerr != nil {
@@ -14,6 +14,7 @@ import (
 	"regexp"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -78,7 +79,7 @@ func registerNetwork(c echo.Context) error {
 		},
 	}
 
-	err = configurator.CreateNetwork(network)
+	err = access.ConfigWriter(c).CreateNetwork(network)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
@@ -129,7 +130,7 @@ func updateNetwork(c echo.Context) error {
 			orc8r.NetworkFeaturesConfig: &models.NetworkFeatures{Features: record.Features},
 		},
 	}
-	err := configurator.UpdateNetworks([]configurator.NetworkUpdateCriteria{updateCriteria})
+	err := access.ConfigWriter(c).UpdateNetworks([]configurator.NetworkUpdateCriteria{updateCriteria})
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
@@ -142,7 +143,7 @@ func deleteNetwork(c echo.Context) error {
 		return nerr
 	}
 
-	err := configurator.DeleteNetwork(networkID)
+	err := access.ConfigWriter(c).DeleteNetwork(networkID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
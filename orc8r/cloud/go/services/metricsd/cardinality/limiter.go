@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package cardinality limits the number of distinct series each network can
+// export, so gateways emitting high cardinality labels can't overload the
+// metrics backends.
+package cardinality
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/metrics"
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// SeriesTTL is how long a series counts towards its network's limit after
+// it was last seen
+var SeriesTTL = time.Hour
+
+var (
+	droppedSeries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metricsd_dropped_series",
+			Help: "Number of samples dropped because their network exceeded its series limit",
+		},
+		[]string{metrics.NetworkLabelName},
+	)
+	activeSeries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "metricsd_network_active_series",
+			Help: "Number of distinct series tracked for the network's series limit",
+		},
+		[]string{metrics.NetworkLabelName},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(droppedSeries, activeSeries)
+}
+
+// Limiter tracks the series exported by each network
+type Limiter struct {
+	sync.Mutex
+	networks map[string]*networkSeries
+}
+
+type networkSeries struct {
+	lastSeenBySeries map[string]time.Time
+	lastGC           time.Time
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{networks: map[string]*networkSeries{}}
+}
+
+// Filter removes the series which would take the network above maxSeries
+// distinct series, 0 means unlimited. Series of unlimited networks aren't
+// tracked. Families left without series are removed.
+func (l *Limiter) Filter(networkID string, maxSeries uint32, metricsToFilter []exporters.MetricAndContext) []exporters.MetricAndContext {
+	l.Lock()
+	defer l.Unlock()
+
+	if maxSeries == 0 {
+		l.forgetNetwork(networkID)
+		return removeEmptyFamilies(metricsToFilter)
+	}
+
+	now := clock.Now()
+	network := l.getNetwork(networkID, now)
+	if now.Sub(network.lastGC) >= SeriesTTL {
+		network.collectGarbage(now)
+	}
+
+	ret := make([]exporters.MetricAndContext, 0, len(metricsToFilter))
+	dropped := 0
+	for _, metricAndContext := range metricsToFilter {
+		family := metricAndContext.Family
+		kept := family.Metric[:0]
+		for _, metric := range family.Metric {
+			key := getSeriesKey(metricAndContext.Context.MetricName, metric.Label)
+			if _, ok := network.lastSeenBySeries[key]; !ok && len(network.lastSeenBySeries) >= int(maxSeries) {
+				dropped++
+				continue
+			}
+			network.lastSeenBySeries[key] = now
+			kept = append(kept, metric)
+		}
+		family.Metric = kept
+		if len(kept) > 0 {
+			ret = append(ret, metricAndContext)
+		}
+	}
+
+	if dropped > 0 {
+		droppedSeries.WithLabelValues(networkID).Add(float64(dropped))
+	}
+	activeSeries.WithLabelValues(networkID).Set(float64(len(network.lastSeenBySeries)))
+	return ret
+}
+
+func (l *Limiter) getNetwork(networkID string, now time.Time) *networkSeries {
+	network, ok := l.networks[networkID]
+	if !ok {
+		network = &networkSeries{lastSeenBySeries: map[string]time.Time{}, lastGC: now}
+		l.networks[networkID] = network
+	}
+	return network
+}
+
+// forgetNetwork drops the tracked series of a network which no longer has a
+// limit
+func (l *Limiter) forgetNetwork(networkID string) {
+	if _, ok := l.networks[networkID]; !ok {
+		return
+	}
+	delete(l.networks, networkID)
+	activeSeries.DeleteLabelValues(networkID)
+}
+
+func removeEmptyFamilies(metrics []exporters.MetricAndContext) []exporters.MetricAndContext {
+	ret := make([]exporters.MetricAndContext, 0, len(metrics))
+	for _, metricAndContext := range metrics {
+		if len(metricAndContext.Family.Metric) > 0 {
+			ret = append(ret, metricAndContext)
+		}
+	}
+	return ret
+}
+
+// collectGarbage forgets the series which weren't seen for SeriesTTL
+func (n *networkSeries) collectGarbage(now time.Time) {
+	for key, lastSeen := range n.lastSeenBySeries {
+		if now.Sub(lastSeen) >= SeriesTTL {
+			delete(n.lastSeenBySeries, key)
+		}
+	}
+	n.lastGC = now
+}
+
+// getSeriesKey returns a string uniquely identifying a series of the metric
+func getSeriesKey(metricName string, labels []*dto.LabelPair) string {
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, strconv.Quote(label.GetName())+"="+strconv.Quote(label.GetValue()))
+	}
+	sort.Strings(pairs)
+	return metricName + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// GetActiveSeries returns the number of series tracked for the network
+func (l *Limiter) GetActiveSeries(networkID string) int {
+	l.Lock()
+	defer l.Unlock()
+	network, ok := l.networks[networkID]
+	if !ok {
+		return 0
+	}
+	return len(network.lastSeenBySeries)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package cardinality_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/metricsd/cardinality"
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Filter(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	limiter := cardinality.NewLimiter()
+
+	// first 3 series fit in the limit
+	filtered := limiter.Filter("n1", 3, []exporters.MetricAndContext{
+		makeMetric("bytes", "1", "2"),
+		makeMetric("packets", "1", "2"),
+	})
+	assert.Equal(t, map[string][]string{"bytes": {"1", "2"}, "packets": {"1"}}, getSeries(filtered))
+
+	// known series keep being exported, families without series are removed
+	filtered = limiter.Filter("n1", 3, []exporters.MetricAndContext{
+		makeMetric("bytes", "2", "3"),
+		makeMetric("packets", "2"),
+		makeMetric("errors", "1"),
+	})
+	assert.Equal(t, map[string][]string{"bytes": {"2"}}, getSeries(filtered))
+
+	// limits are per network, 0 is unlimited and isn't tracked
+	filtered = limiter.Filter("n2", 0, []exporters.MetricAndContext{makeMetric("bytes", "1", "2", "3", "4"), makeMetric("errors")})
+	assert.Equal(t, map[string][]string{"bytes": {"1", "2", "3", "4"}}, getSeries(filtered))
+	assert.Equal(t, 0, limiter.GetActiveSeries("n2"))
+	assert.Equal(t, 3, limiter.GetActiveSeries("n1"))
+
+	// stale series are forgotten
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).Add(cardinality.SeriesTTL/2))
+	filtered = limiter.Filter("n1", 3, []exporters.MetricAndContext{makeMetric("bytes", "1")})
+	assert.Equal(t, map[string][]string{"bytes": {"1"}}, getSeries(filtered))
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).Add(cardinality.SeriesTTL))
+	filtered = limiter.Filter("n1", 3, []exporters.MetricAndContext{
+		makeMetric("bytes", "3", "4"),
+		makeMetric("packets", "2"),
+	})
+	assert.Equal(t, map[string][]string{"bytes": {"3", "4"}}, getSeries(filtered))
+
+	// removing the limit forgets the network's series
+	limiter.Filter("n1", 0, []exporters.MetricAndContext{makeMetric("bytes", "5")})
+	assert.Equal(t, 0, limiter.GetActiveSeries("n1"))
+}
+
+func makeMetric(name string, imsis ...string) exporters.MetricAndContext {
+	family := &dto.MetricFamily{Name: proto.String(name), Type: dto.MetricType_GAUGE.Enum()}
+	for _, imsi := range imsis {
+		family.Metric = append(family.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{{Name: proto.String("imsi"), Value: proto.String(imsi)}},
+			Gauge: &dto.Gauge{Value: proto.Float64(1)},
+		})
+	}
+	return exporters.MetricAndContext{
+		Family:  family,
+		Context: exporters.MetricsContext{MetricName: name},
+	}
+}
+
+func getSeries(metrics []exporters.MetricAndContext) map[string][]string {
+	ret := map[string][]string{}
+	for _, metric := range metrics {
+		for _, m := range metric.Family.Metric {
+			ret[metric.Context.MetricName] = append(ret[metric.Context.MetricName], m.Label[0].GetValue())
+		}
+	}
+	return ret
+}
@@ -13,6 +13,7 @@ const (
 	Profile                 = "profile"
 	PrometheusPushAddresses = "prometheusPushAddresses"
 	PrometheusQueryAddress  = "prometheusQueryAddress"
+	OTLPExportAddress       = "otlpExportAddress"
 
 	PrometheusConfigServiceURL   = "prometheusConfigServiceURL"
 	AlertmanagerConfigServiceURL = "alertmanagerConfigServiceURL"
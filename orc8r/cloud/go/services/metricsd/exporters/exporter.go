@@ -23,6 +23,51 @@ type Exporter interface {
 	Start()
 }
 
+// SyncExporter is an Exporter which can also export metrics synchronously.
+// Metrics buffered in the metricsd write-ahead log are delivered to sync
+// exporters with Export, so they're kept and replayed until the datasink
+// accepts them.
+type SyncExporter interface {
+	Exporter
+
+	// This method has to be thread-safe
+	// Export immediately exports metrics to the datasink, bypassing metrics
+	// buffered by Submit. Returns an error if the metrics weren't exported,
+	// which is a PermanentError if the datasink rejected the metrics and
+	// exporting them again can't succeed.
+	Export(metrics []MetricAndContext) error
+}
+
+// SinkSplitter is implemented by sync exporters which export to several
+// independent datasinks. The write-ahead log delivers to every datasink with
+// its own exporter, so a failing datasink neither holds back the others nor
+// causes redelivery to them.
+type SinkSplitter interface {
+	// SinkExporters returns an exporter for each datasink, keyed by a name
+	// which identifies the datasink across restarts
+	SinkExporters() map[string]SyncExporter
+}
+
+// PermanentError is returned by SyncExporter.Export when the datasink
+// rejected the metrics, so retrying the export won't succeed
+type PermanentError struct {
+	Err error
+}
+
+func NewPermanentError(err error) error {
+	return &PermanentError{Err: err}
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+// IsPermanentError returns true if err is a PermanentError
+func IsPermanentError(err error) bool {
+	_, ok := err.(*PermanentError)
+	return ok
+}
+
 // MetricAndContext wraps a metric family and metric context
 type MetricAndContext struct {
 	Family  *dto.MetricFamily
@@ -9,8 +9,11 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"time"
 
 	"magma/orc8r/cloud/go/orc8r"
@@ -19,7 +22,9 @@ import (
 	"magma/orc8r/cloud/go/services/metricsd"
 	"magma/orc8r/cloud/go/services/metricsd/collection"
 	"magma/orc8r/cloud/go/services/metricsd/confignames"
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
 	"magma/orc8r/cloud/go/services/metricsd/servicers"
+	"magma/orc8r/cloud/go/services/metricsd/wal"
 
 	"github.com/prometheus/client_model/go"
 )
@@ -28,6 +33,11 @@ const (
 	CloudMetricsCollectInterval = time.Second * 20
 )
 
+var (
+	walDir   = flag.String("wal-dir", "", "Metrics write-ahead log directory, buffers metrics of all exporters on disk if set")
+	walMaxMB = flag.Int64("wal-max-mb", 512, "Metrics write-ahead log size limit (in MB), the oldest metrics are dropped over the limit")
+)
+
 func main() {
 
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, metricsd.ServiceName)
@@ -54,8 +64,19 @@ func main() {
 	// Kick off gatherer and exporters
 	go controllerServer.ConsumeCloudMetrics(metricsChannel, os.Getenv("HOST_NAME"))
 	gatherer.Run()
+	if len(*walDir) > 0 {
+		buffer, err := newExportBuffer(selectedProfile)
+		if err != nil {
+			log.Fatalf("Error initializing metrics write-ahead log: %s", err)
+		}
+		controllerServer.RegisterExporter(buffer)
+		buffer.Start()
+	} else {
+		for _, exporter := range selectedProfile.Exporters {
+			controllerServer.RegisterExporter(exporter)
+		}
+	}
 	for _, exporter := range selectedProfile.Exporters {
-		controllerServer.RegisterExporter(exporter)
 		exporter.Start()
 	}
 
@@ -64,3 +85,26 @@ func main() {
 		log.Fatalf("Error running service: %s", err)
 	}
 }
+
+// newExportBuffer puts the write-ahead log between the servicer and all
+// exporters of the profile
+func newExportBuffer(profile metricsd.MetricsProfile) (*wal.Buffer, error) {
+	walLog, err := wal.Open(*walDir, *walMaxMB<<20)
+	if err != nil {
+		return nil, err
+	}
+	buffer := wal.NewBuffer(walLog)
+	for i, exporter := range profile.Exporters {
+		if err = buffer.AddExporter(getCursorName(profile.Name, i, exporter), exporter); err != nil {
+			return nil, err
+		}
+	}
+	return buffer, nil
+}
+
+// getCursorName returns the name of the exporter's log cursor, which has to
+// identify the exporter across restarts
+func getCursorName(profileName string, idx int, exporter exporters.Exporter) string {
+	exporterType := reflect.Indirect(reflect.ValueOf(exporter)).Type().Name()
+	return wal.CursorName(fmt.Sprintf("%s_%d_%s", profileName, idx, exporterType))
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/metrics"
+	mxd_exp "magma/orc8r/cloud/go/services/metricsd/exporters"
+	otlpprotos "magma/orc8r/cloud/go/services/metricsd/otlp/protos"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Resource attributes identifying the source of exported metrics
+const (
+	NetworkIDAttribute = "magma.network.id"
+	GatewayIDAttribute = "magma.gateway.id"
+	CloudHostAttribute = "host.name"
+)
+
+// resourceMetric is a converted metric whose data points all come from the
+// same resource (cloud host, network or gateway)
+type resourceMetric struct {
+	resourceKey string
+	resource    *otlpprotos.Resource
+	metric      *otlpprotos.Metric
+	points      int
+}
+
+func convertMetrics(metrics []mxd_exp.MetricAndContext, startTimes *startTimeTracker) []resourceMetric {
+	var converted []resourceMetric
+	for _, metricAndContext := range metrics {
+		if metricAndContext.Family == nil {
+			continue
+		}
+		converted = append(converted, convertMetricAndContext(metricAndContext, startTimes)...)
+	}
+	return converted
+}
+
+// convertMetricAndContext converts a metric family into OTLP metrics, one
+// per resource the family's metrics come from. Gateway ID labels of pushed
+// metrics are moved into the resource attributes. Cumulative data points get
+// their series' start time from startTimes.
+func convertMetricAndContext(metricAndContext mxd_exp.MetricAndContext, startTimes *startTimeTracker) []resourceMetric {
+	family := metricAndContext.Family
+	var ret []resourceMetric
+	byResource := map[string]int{}
+	for _, metric := range family.GetMetric() {
+		resourceAttrs, labels := getResourceAttributes(metricAndContext.Context.AdditionalContext, metric.GetLabel())
+		key := getResourceKey(resourceAttrs)
+		idx, ok := byResource[key]
+		if !ok {
+			idx = len(ret)
+			byResource[key] = idx
+			ret = append(ret, resourceMetric{
+				resourceKey: key,
+				resource:    &otlpprotos.Resource{Attributes: resourceAttrs},
+				metric: newMetric(
+					metricAndContext.Context.MetricName, family.GetHelp(), family.GetType()),
+			})
+		}
+		seriesKey := metricAndContext.Context.MetricName + "|" + key
+		if addDataPoint(ret[idx].metric, family.GetType(), metric, labels, seriesKey, startTimes) {
+			ret[idx].points++
+		}
+	}
+
+	// drop metrics of unsupported types
+	converted := ret[:0]
+	for _, resMetric := range ret {
+		if resMetric.points > 0 {
+			converted = append(converted, resMetric)
+		}
+	}
+	return converted
+}
+
+func getResourceAttributes(ctx mxd_exp.AdditionalMetricContext, labels []*dto.LabelPair) ([]*otlpprotos.KeyValue, []*dto.LabelPair) {
+	switch additionalCtx := ctx.(type) {
+	case *mxd_exp.CloudMetricContext:
+		return []*otlpprotos.KeyValue{stringAttribute(CloudHostAttribute, additionalCtx.CloudHost)}, labels
+	case *mxd_exp.GatewayMetricContext:
+		return []*otlpprotos.KeyValue{
+			stringAttribute(NetworkIDAttribute, additionalCtx.NetworkID),
+			stringAttribute(GatewayIDAttribute, additionalCtx.GatewayID),
+		}, labels
+	case *mxd_exp.PushedMetricContext:
+		attrs := []*otlpprotos.KeyValue{stringAttribute(NetworkIDAttribute, additionalCtx.NetworkID)}
+		remainingLabels := make([]*dto.LabelPair, 0, len(labels))
+		for _, label := range labels {
+			if label.GetName() == metrics.GatewayLabelName {
+				attrs = append(attrs, stringAttribute(GatewayIDAttribute, label.GetValue()))
+				continue
+			}
+			remainingLabels = append(remainingLabels, label)
+		}
+		return attrs, remainingLabels
+	}
+	return nil, labels
+}
+
+// getResourceKey returns a string uniquely identifying a set of string
+// resource attributes
+func getResourceKey(attrs []*otlpprotos.KeyValue) string {
+	parts := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		parts = append(parts, attr.Key+"="+attr.Value.GetStringValue())
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// getAttributesKey returns a string uniquely identifying a set of string
+// data point attributes, which are already sorted by name
+func getAttributesKey(attrs []*otlpprotos.KeyValue) string {
+	parts := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		parts = append(parts, strconv.Quote(attr.Key)+"="+strconv.Quote(attr.Value.GetStringValue()))
+	}
+	return strings.Join(parts, ",")
+}
+
+func newMetric(name string, help string, metricType dto.MetricType) *otlpprotos.Metric {
+	metric := &otlpprotos.Metric{Name: name, Description: help}
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		metric.Data = &otlpprotos.Metric_Sum{Sum: &otlpprotos.Sum{
+			AggregationTemporality: otlpprotos.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}}
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		metric.Data = &otlpprotos.Metric_Gauge{Gauge: &otlpprotos.Gauge{}}
+	case dto.MetricType_SUMMARY:
+		metric.Data = &otlpprotos.Metric_Summary{Summary: &otlpprotos.Summary{}}
+	case dto.MetricType_HISTOGRAM:
+		metric.Data = &otlpprotos.Metric_Histogram{Histogram: &otlpprotos.Histogram{
+			AggregationTemporality: otlpprotos.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}}
+	}
+	return metric
+}
+
+// addDataPoint converts the prometheus metric into a data point of the OTLP
+// metric, returns false if the metric type isn't supported. seriesKey
+// identifies the metric and resource the data point belongs to.
+func addDataPoint(
+	otlpMetric *otlpprotos.Metric,
+	metricType dto.MetricType,
+	metric *dto.Metric,
+	labels []*dto.LabelPair,
+	seriesKey string,
+	startTimes *startTimeTracker,
+) bool {
+	attrs := labelsToAttributes(labels)
+	ts := getTimestampNanos(metric)
+	getStartTime := func(value float64) uint64 {
+		return startTimes.getStartTime(seriesKey+"|"+getAttributesKey(attrs), ts, value)
+	}
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		sum := otlpMetric.GetSum()
+		point := newNumberDataPoint(attrs, ts, metric.GetCounter().GetValue())
+		point.StartTimeUnixNano = getStartTime(metric.GetCounter().GetValue())
+		sum.DataPoints = append(sum.DataPoints, point)
+	case dto.MetricType_GAUGE:
+		gauge := otlpMetric.GetGauge()
+		gauge.DataPoints = append(gauge.DataPoints, newNumberDataPoint(attrs, ts, metric.GetGauge().GetValue()))
+	case dto.MetricType_UNTYPED:
+		gauge := otlpMetric.GetGauge()
+		gauge.DataPoints = append(gauge.DataPoints, newNumberDataPoint(attrs, ts, metric.GetUntyped().GetValue()))
+	case dto.MetricType_SUMMARY:
+		summary := otlpMetric.GetSummary()
+		point := newSummaryDataPoint(attrs, ts, metric.GetSummary())
+		point.StartTimeUnixNano = getStartTime(float64(metric.GetSummary().GetSampleCount()))
+		summary.DataPoints = append(summary.DataPoints, point)
+	case dto.MetricType_HISTOGRAM:
+		histogram := otlpMetric.GetHistogram()
+		point := newHistogramDataPoint(attrs, ts, metric.GetHistogram())
+		point.StartTimeUnixNano = getStartTime(float64(metric.GetHistogram().GetSampleCount()))
+		histogram.DataPoints = append(histogram.DataPoints, point)
+	default:
+		return false
+	}
+	return true
+}
+
+func newNumberDataPoint(attrs []*otlpprotos.KeyValue, ts uint64, value float64) *otlpprotos.NumberDataPoint {
+	return &otlpprotos.NumberDataPoint{
+		Attributes:   attrs,
+		TimeUnixNano: ts,
+		Value:        &otlpprotos.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+}
+
+func newSummaryDataPoint(attrs []*otlpprotos.KeyValue, ts uint64, summary *dto.Summary) *otlpprotos.SummaryDataPoint {
+	point := &otlpprotos.SummaryDataPoint{
+		Attributes:   attrs,
+		TimeUnixNano: ts,
+		Count:        summary.GetSampleCount(),
+		Sum:          summary.GetSampleSum(),
+	}
+	for _, q := range summary.GetQuantile() {
+		point.QuantileValues = append(
+			point.QuantileValues,
+			&otlpprotos.SummaryDataPoint_ValueAtQuantile{Quantile: q.GetQuantile(), Value: q.GetValue()},
+		)
+	}
+	return point
+}
+
+// newHistogramDataPoint converts prometheus' cumulative buckets into OTLP
+// per bucket counts. The last OTLP bucket counts observations above the
+// highest explicit bound.
+func newHistogramDataPoint(attrs []*otlpprotos.KeyValue, ts uint64, histogram *dto.Histogram) *otlpprotos.HistogramDataPoint {
+	point := &otlpprotos.HistogramDataPoint{
+		Attributes:   attrs,
+		TimeUnixNano: ts,
+		Count:        histogram.GetSampleCount(),
+		Sum:          histogram.GetSampleSum(),
+	}
+	var prevCount uint64
+	for _, bucket := range histogram.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-prevCount)
+		prevCount = bucket.GetCumulativeCount()
+	}
+	var overflowCount uint64
+	if histogram.GetSampleCount() > prevCount {
+		overflowCount = histogram.GetSampleCount() - prevCount
+	}
+	point.BucketCounts = append(point.BucketCounts, overflowCount)
+	return point
+}
+
+func labelsToAttributes(labels []*dto.LabelPair) []*otlpprotos.KeyValue {
+	sorted := make([]*dto.LabelPair, len(labels))
+	copy(sorted, labels)
+	sort.Sort(mxd_exp.ByName(sorted))
+	attrs := make([]*otlpprotos.KeyValue, 0, len(sorted))
+	for _, label := range sorted {
+		attrs = append(attrs, stringAttribute(label.GetName(), label.GetValue()))
+	}
+	return attrs
+}
+
+func stringAttribute(key string, value string) *otlpprotos.KeyValue {
+	return &otlpprotos.KeyValue{
+		Key:   key,
+		Value: &otlpprotos.AnyValue{Value: &otlpprotos.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// getTimestampNanos returns the metric's timestamp, or the current time for
+// metrics without one
+func getTimestampNanos(metric *dto.Metric) uint64 {
+	if metric.GetTimestampMs() > 0 {
+		return uint64(metric.GetTimestampMs()) * uint64(time.Millisecond)
+	}
+	return uint64(clock.Now().UnixNano())
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/metrics"
+	"magma/orc8r/cloud/go/protos"
+	mxd_exp "magma/orc8r/cloud/go/services/metricsd/exporters"
+	otlpprotos "magma/orc8r/cloud/go/services/metricsd/otlp/protos"
+	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	sampleNetworkID = "sampleNetwork"
+	sampleGatewayID = "sampleGateway"
+	sampleLabels    = []*dto.LabelPair{
+		{Name: tests.MakeStringPointer("testLabel"), Value: tests.MakeStringPointer("testValue")},
+	}
+	sampleGatewayContext = mxd_exp.MetricsContext{
+		MetricName: "metric_A",
+		AdditionalContext: &mxd_exp.GatewayMetricContext{
+			NetworkID: sampleNetworkID,
+			GatewayID: sampleGatewayID,
+		},
+	}
+)
+
+func TestConvertMetricAndContext_Types(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+	nowNanos := uint64(time.Unix(1000000, 0).UnixNano())
+	expectedAttrs := []*otlpprotos.KeyValue{stringAttribute("testLabel", "testValue")}
+
+	// counter
+	converted := convertMetricAndContext(mxd_exp.MetricAndContext{
+		Family:  tests.MakeTestMetricFamily(dto.MetricType_COUNTER, 2, sampleLabels),
+		Context: sampleGatewayContext,
+	}, newStartTimeTracker())
+	assert.Len(t, converted, 1)
+	assert.Equal(t, 2, converted[0].points)
+	assertProtoEqual(t, &otlpprotos.Metric{
+		Name:        "metric_A",
+		Description: "testFamilyHelp",
+		Data: &otlpprotos.Metric_Sum{Sum: &otlpprotos.Sum{
+			DataPoints: []*otlpprotos.NumberDataPoint{
+				{Attributes: expectedAttrs, StartTimeUnixNano: nowNanos, TimeUnixNano: nowNanos, Value: &otlpprotos.NumberDataPoint_AsDouble{}},
+				{Attributes: expectedAttrs, StartTimeUnixNano: nowNanos, TimeUnixNano: nowNanos, Value: &otlpprotos.NumberDataPoint_AsDouble{}},
+			},
+			AggregationTemporality: otlpprotos.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}},
+	}, converted[0].metric)
+
+	// gauge and untyped
+	for _, metricType := range []dto.MetricType{dto.MetricType_GAUGE, dto.MetricType_UNTYPED} {
+		converted = convertMetricAndContext(mxd_exp.MetricAndContext{
+			Family:  tests.MakeTestMetricFamily(metricType, 1, sampleLabels),
+			Context: sampleGatewayContext,
+		}, newStartTimeTracker())
+		assert.Len(t, converted, 1)
+		assertProtoEqual(t, &otlpprotos.Metric{
+			Name:        "metric_A",
+			Description: "testFamilyHelp",
+			Data: &otlpprotos.Metric_Gauge{Gauge: &otlpprotos.Gauge{
+				DataPoints: []*otlpprotos.NumberDataPoint{newNumberDataPoint(expectedAttrs, nowNanos, 0)},
+			}},
+		}, converted[0].metric)
+	}
+
+	// summary
+	summary := tests.MakePromoSummary(map[float64]float64{0.5: 0.05, 0.9: 0.01}, []float64{1, 2, 3})
+	converted = convertMetricAndContext(mxd_exp.MetricAndContext{
+		Family: &dto.MetricFamily{
+			Type:   dto.MetricType_SUMMARY.Enum(),
+			Metric: []*dto.Metric{&summary},
+		},
+		Context: sampleGatewayContext,
+	}, newStartTimeTracker())
+	assert.Len(t, converted, 1)
+	assertProtoEqual(t, &otlpprotos.Metric{
+		Name: "metric_A",
+		Data: &otlpprotos.Metric_Summary{Summary: &otlpprotos.Summary{
+			DataPoints: []*otlpprotos.SummaryDataPoint{{
+				Attributes:        []*otlpprotos.KeyValue{},
+				StartTimeUnixNano: nowNanos,
+				TimeUnixNano:      nowNanos,
+				Count:             3,
+				Sum:               6,
+				QuantileValues: []*otlpprotos.SummaryDataPoint_ValueAtQuantile{
+					{Quantile: 0.5, Value: 2},
+					{Quantile: 0.9, Value: 3},
+				},
+			}},
+		}},
+	}, converted[0].metric)
+
+	// histogram, prometheus buckets are cumulative
+	histogram := tests.MakePromoHistogram([]float64{1, 5, 10}, []float64{0.5, 2, 3, 7, 20})
+	histogram.TimestampMs = proto.Int64(123000)
+	converted = convertMetricAndContext(mxd_exp.MetricAndContext{
+		Family: &dto.MetricFamily{
+			Type:   dto.MetricType_HISTOGRAM.Enum(),
+			Metric: []*dto.Metric{&histogram},
+		},
+		Context: sampleGatewayContext,
+	}, newStartTimeTracker())
+	assert.Len(t, converted, 1)
+	assertProtoEqual(t, &otlpprotos.Metric{
+		Name: "metric_A",
+		Data: &otlpprotos.Metric_Histogram{Histogram: &otlpprotos.Histogram{
+			DataPoints: []*otlpprotos.HistogramDataPoint{{
+				Attributes:        []*otlpprotos.KeyValue{},
+				StartTimeUnixNano: uint64(123 * time.Second),
+				TimeUnixNano:      uint64(123 * time.Second),
+				Count:             5,
+				Sum:               32.5,
+				BucketCounts:      []uint64{1, 2, 1, 1},
+				ExplicitBounds:    []float64{1, 5, 10},
+			}},
+			AggregationTemporality: otlpprotos.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}},
+	}, converted[0].metric)
+
+	// empty family
+	converted = convertMetricAndContext(mxd_exp.MetricAndContext{
+		Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 0, sampleLabels),
+		Context: sampleGatewayContext,
+	}, newStartTimeTracker())
+	assert.Empty(t, converted)
+}
+
+func TestConvertMetricAndContext_StartTimes(t *testing.T) {
+	startTimes := newStartTimeTracker()
+	convertCounter := func(tsMs int64, value float64) *otlpprotos.NumberDataPoint {
+		counter := &dto.Metric{
+			Label:       sampleLabels,
+			Counter:     &dto.Counter{Value: proto.Float64(value)},
+			TimestampMs: proto.Int64(tsMs),
+		}
+		converted := convertMetricAndContext(mxd_exp.MetricAndContext{
+			Family:  &dto.MetricFamily{Type: dto.MetricType_COUNTER.Enum(), Metric: []*dto.Metric{counter}},
+			Context: sampleGatewayContext,
+		}, startTimes)
+		return converted[0].metric.GetSum().DataPoints[0]
+	}
+
+	// the series starts when it is first exported
+	point := convertCounter(1000, 1)
+	assert.Equal(t, uint64(time.Second), point.StartTimeUnixNano)
+	point = convertCounter(2000, 5)
+	assert.Equal(t, uint64(time.Second), point.StartTimeUnixNano)
+	assert.Equal(t, uint64(2*time.Second), point.TimeUnixNano)
+
+	// and restarts when the counter is reset
+	point = convertCounter(3000, 2)
+	assert.Equal(t, uint64(3*time.Second), point.StartTimeUnixNano)
+	point = convertCounter(4000, 3)
+	assert.Equal(t, uint64(3*time.Second), point.StartTimeUnixNano)
+}
+
+func TestConvertMetricAndContext_Resources(t *testing.T) {
+	// gateway metrics
+	converted := convertMetricAndContext(mxd_exp.MetricAndContext{
+		Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+		Context: sampleGatewayContext,
+	}, newStartTimeTracker())
+	assert.Len(t, converted, 1)
+	assertProtoEqual(t, &otlpprotos.Resource{Attributes: []*otlpprotos.KeyValue{
+		stringAttribute(NetworkIDAttribute, sampleNetworkID),
+		stringAttribute(GatewayIDAttribute, sampleGatewayID),
+	}}, converted[0].resource)
+
+	// cloud metrics
+	converted = convertMetricAndContext(mxd_exp.MetricAndContext{
+		Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+		Context: mxd_exp.MetricsContext{
+			MetricName:        "metric_B",
+			AdditionalContext: &mxd_exp.CloudMetricContext{CloudHost: "host1"},
+		},
+	}, newStartTimeTracker())
+	assert.Len(t, converted, 1)
+	assertProtoEqual(t, &otlpprotos.Resource{Attributes: []*otlpprotos.KeyValue{
+		stringAttribute(CloudHostAttribute, "host1"),
+	}}, converted[0].resource)
+
+	// pushed metrics are split by their gateway ID labels
+	gw1, gw2, network := tests.MakePromoGauge(1), tests.MakePromoGauge(2), tests.MakePromoGauge(3)
+	gw1.Label = []*dto.LabelPair{
+		{Name: tests.MakeStringPointer(metrics.GatewayLabelName), Value: tests.MakeStringPointer("gw1")},
+		{Name: tests.MakeStringPointer("testLabel"), Value: tests.MakeStringPointer("testValue")},
+	}
+	gw2.Label = []*dto.LabelPair{
+		{Name: tests.MakeStringPointer(metrics.GatewayLabelName), Value: tests.MakeStringPointer("gw2")},
+	}
+	converted = convertMetricAndContext(mxd_exp.MetricAndContext{
+		Family: &dto.MetricFamily{
+			Type:   dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{&gw1, &gw2, &network},
+		},
+		Context: mxd_exp.MetricsContext{
+			MetricName:        "metric_C",
+			AdditionalContext: &mxd_exp.PushedMetricContext{NetworkID: sampleNetworkID},
+		},
+	}, newStartTimeTracker())
+	assert.Len(t, converted, 3)
+	expectedResources := []*otlpprotos.Resource{
+		{Attributes: []*otlpprotos.KeyValue{
+			stringAttribute(NetworkIDAttribute, sampleNetworkID),
+			stringAttribute(GatewayIDAttribute, "gw1"),
+		}},
+		{Attributes: []*otlpprotos.KeyValue{
+			stringAttribute(NetworkIDAttribute, sampleNetworkID),
+			stringAttribute(GatewayIDAttribute, "gw2"),
+		}},
+		{Attributes: []*otlpprotos.KeyValue{
+			stringAttribute(NetworkIDAttribute, sampleNetworkID),
+		}},
+	}
+	expectedAttrs := [][]*otlpprotos.KeyValue{
+		{stringAttribute("testLabel", "testValue")},
+		{},
+		{},
+	}
+	for i, resMetric := range converted {
+		assertProtoEqual(t, expectedResources[i], resMetric.resource)
+		assert.Equal(t, 1, resMetric.points)
+		assertProtoEqual(t, &otlpprotos.Gauge{DataPoints: []*otlpprotos.NumberDataPoint{{
+			Attributes:   expectedAttrs[i],
+			TimeUnixNano: resMetric.metric.GetGauge().DataPoints[0].TimeUnixNano,
+			Value:        &otlpprotos.NumberDataPoint_AsDouble{AsDouble: float64(i + 1)},
+		}}}, resMetric.metric.GetGauge())
+	}
+	// the submitted metrics are not modified
+	assert.Len(t, gw1.Label, 2)
+}
+
+func assertProtoEqual(t *testing.T, expected proto.Message, actual proto.Message) {
+	assert.Equal(t, protos.TestMarshal(expected), protos.TestMarshal(actual))
+}
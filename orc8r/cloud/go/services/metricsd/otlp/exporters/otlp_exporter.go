@@ -0,0 +1,279 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package exporters implements a metricsd exporter which pushes metrics to an
+// OpenTelemetry collector over OTLP/gRPC.
+package exporters
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	mxd_exp "magma/orc8r/cloud/go/services/metricsd/exporters"
+	otlpprotos "magma/orc8r/cloud/go/services/metricsd/otlp/protos"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	exportInterval = time.Second * 30
+
+	// InstrumentationScopeName is the instrumentation scope of all exported
+	// metrics
+	InstrumentationScopeName = "magma/metricsd"
+)
+
+var (
+	// MaxBatchSize is the max number of data points sent in a single Export
+	// request. Data points of a single metric are never split, so a metric
+	// with more data points is sent in a request of its own.
+	MaxBatchSize = 1000
+	// MaxBufferedDataPoints is the max number of data points waiting to be
+	// exported, metrics submitted while the buffer is full are dropped
+	MaxBufferedDataPoints = 100000
+
+	// ExportTimeout is the timeout of a single Export request
+	ExportTimeout = time.Second * 10
+	// MaxExportAttempts is the number of times a batch is sent before it is
+	// dropped, retryable errors only
+	MaxExportAttempts = 5
+	// InitialRetryBackoff is the delay before the first retry, it doubles with
+	// every retry up to MaxRetryBackoff
+	InitialRetryBackoff = time.Second
+	MaxRetryBackoff     = time.Second * 30
+)
+
+// OTLPExporter batches submitted metrics and exports them to an OTLP
+// collector every export interval. Network and gateway IDs (or the cloud
+// host name) of the metrics are sent as resource attributes.
+type OTLPExporter struct {
+	address        string
+	exportInterval time.Duration
+	pending        []resourceMetric
+	pendingPoints  int
+	startTimes     *startTimeTracker
+	client         otlpprotos.MetricsServiceClient
+	sync.Mutex
+}
+
+// NewOTLPExporter creates a new exporter to the OTLP/gRPC collector listening
+// on address (host:port)
+func NewOTLPExporter(address string) mxd_exp.Exporter {
+	return &OTLPExporter{
+		address:        address,
+		exportInterval: exportInterval,
+		startTimes:     newStartTimeTracker(),
+	}
+}
+
+// Submit converts the metrics into OTLP metrics and buffers them until the
+// next export
+func (e *OTLPExporter) Submit(metrics []mxd_exp.MetricAndContext) error {
+	converted := convertMetrics(metrics, e.startTimes)
+	e.Lock()
+	defer e.Unlock()
+	dropped := 0
+	for _, resMetric := range converted {
+		if e.pendingPoints+resMetric.points > MaxBufferedDataPoints {
+			dropped += resMetric.points
+			continue
+		}
+		e.pending = append(e.pending, resMetric)
+		e.pendingPoints += resMetric.points
+	}
+	if dropped > 0 {
+		return fmt.Errorf("OTLP export buffer is full, dropped %d data points", dropped)
+	}
+	return nil
+}
+
+// Export converts the metrics and sends them to the collector right away.
+// Returns a PermanentError if the collector rejected the metrics.
+func (e *OTLPExporter) Export(metrics []mxd_exp.MetricAndContext) error {
+	errs := e.exportMetrics(convertMetrics(metrics, e.startTimes))
+	if len(errs) == 0 {
+		return nil
+	}
+	err := fmt.Errorf("error in exporting to OTLP collector %s: %v", e.address, errs)
+	for _, exportErr := range errs {
+		if !mxd_exp.IsPermanentError(exportErr) {
+			return err
+		}
+	}
+	return mxd_exp.NewPermanentError(err)
+}
+
+// Start runs exportEvery() in a goroutine to continuously export metrics at
+// every export interval
+func (e *OTLPExporter) Start() {
+	go e.exportEvery()
+}
+
+func (e *OTLPExporter) exportEvery() {
+	for range time.Tick(e.exportInterval) {
+		errs := e.export()
+		if len(errs) > 0 {
+			glog.Errorf("error in exporting to OTLP collector %s: %v", e.address, errs)
+		}
+	}
+}
+
+// export sends all buffered metrics to the collector in batches of up to
+// MaxBatchSize data points. Batches which couldn't be exported are dropped.
+func (e *OTLPExporter) export() []error {
+	e.Lock()
+	pending := e.pending
+	e.pending, e.pendingPoints = nil, 0
+	e.Unlock()
+	return e.exportMetrics(pending)
+}
+
+func (e *OTLPExporter) exportMetrics(metrics []resourceMetric) []error {
+	if len(metrics) == 0 {
+		return []error{}
+	}
+	client, err := e.getClient()
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	batches := makeBatches(metrics, MaxBatchSize)
+	for i, b := range batches {
+		attempts, err := exportWithRetry(client, b)
+		if err == nil {
+			continue
+		}
+		exportErr := fmt.Errorf("failed to export %d data points after %d attempt(s): %v", b.points, attempts, err)
+		if !isRetryable(err) {
+			exportErr = mxd_exp.NewPermanentError(exportErr)
+		}
+		errs = append(errs, exportErr)
+		// don't wait for every remaining batch to time out while the
+		// collector is down
+		if isCollectorUnreachable(err) {
+			skippedPoints := 0
+			for _, skipped := range batches[i+1:] {
+				skippedPoints += skipped.points
+			}
+			if skippedPoints > 0 {
+				errs = append(errs, fmt.Errorf("collector unreachable, skipped %d data points", skippedPoints))
+			}
+			break
+		}
+	}
+	return errs
+}
+
+func (e *OTLPExporter) getClient() (otlpprotos.MetricsServiceClient, error) {
+	e.Lock()
+	defer e.Unlock()
+	if e.client != nil {
+		return e.client, nil
+	}
+	// Dial doesn't block, the connection is (re)established in the background
+	conn, err := grpc.Dial(e.address, grpc.WithInsecure(), grpc.WithBackoffMaxDelay(MaxRetryBackoff))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to OTLP collector %s: %v", e.address, err)
+	}
+	e.client = otlpprotos.NewMetricsServiceClient(conn)
+	return e.client, nil
+}
+
+type batch struct {
+	request *otlpprotos.ExportMetricsServiceRequest
+	points  int
+}
+
+// makeBatches groups the metrics into export requests of up to maxPoints data
+// points, metrics of the same resource in a request share a ResourceMetrics
+func makeBatches(metrics []resourceMetric, maxPoints int) []batch {
+	var batches []batch
+	var current batch
+	var byResource map[string]*otlpprotos.ScopeMetrics
+	for _, resMetric := range metrics {
+		if current.request == nil || (current.points > 0 && current.points+resMetric.points > maxPoints) {
+			if current.request != nil {
+				batches = append(batches, current)
+			}
+			current = batch{request: &otlpprotos.ExportMetricsServiceRequest{}}
+			byResource = map[string]*otlpprotos.ScopeMetrics{}
+		}
+		scopeMetrics, ok := byResource[resMetric.resourceKey]
+		if !ok {
+			scopeMetrics = &otlpprotos.ScopeMetrics{
+				Scope: &otlpprotos.InstrumentationScope{Name: InstrumentationScopeName},
+			}
+			byResource[resMetric.resourceKey] = scopeMetrics
+			current.request.ResourceMetrics = append(current.request.ResourceMetrics, &otlpprotos.ResourceMetrics{
+				Resource:     resMetric.resource,
+				ScopeMetrics: []*otlpprotos.ScopeMetrics{scopeMetrics},
+			})
+		}
+		scopeMetrics.Metrics = append(scopeMetrics.Metrics, resMetric.metric)
+		current.points += resMetric.points
+	}
+	if current.request != nil {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// exportWithRetry sends the batch to the collector, retrying transient
+// failures with exponential backoff. It returns the number of attempts and
+// the error of the last one.
+func exportWithRetry(client otlpprotos.MetricsServiceClient, b batch) (int, error) {
+	backoff := InitialRetryBackoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), ExportTimeout)
+		res, err := client.Export(ctx, b.request)
+		cancel()
+		if err == nil {
+			if partial := res.GetPartialSuccess(); partial.GetRejectedDataPoints() > 0 {
+				glog.Warningf(
+					"OTLP collector rejected %d of %d data points: %s",
+					partial.GetRejectedDataPoints(), b.points, partial.GetErrorMessage())
+			}
+			return attempt, nil
+		}
+		if !isRetryable(err) || attempt >= MaxExportAttempts {
+			return attempt, err
+		}
+		glog.V(2).Infof("OTLP export attempt %d failed, retrying in %v: %v", attempt, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > MaxRetryBackoff {
+			backoff = MaxRetryBackoff
+		}
+	}
+}
+
+// isCollectorUnreachable returns true for the errors which mean that no
+// batch can currently be exported
+func isCollectorUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// isRetryable returns true for the gRPC errors which the OTLP specification
+// considers transient
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+		codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
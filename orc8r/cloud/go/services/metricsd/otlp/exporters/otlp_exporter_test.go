@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"testing"
+	"time"
+
+	mxd_exp "magma/orc8r/cloud/go/services/metricsd/exporters"
+	"magma/orc8r/cloud/go/services/metricsd/otlp/test_init"
+	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestOTLPExporter_Export(t *testing.T) {
+	addr, collector := test_init.StartTestCollector(t)
+	exp := NewOTLPExporter(addr.String()).(*OTLPExporter)
+
+	// nothing to export
+	assert.Empty(t, exp.export())
+	assert.Equal(t, 0, collector.GetAttempts())
+
+	err := exp.Submit([]mxd_exp.MetricAndContext{
+		{
+			Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 2, sampleLabels),
+			Context: sampleGatewayContext,
+		},
+		{
+			Family:  tests.MakeTestMetricFamily(dto.MetricType_COUNTER, 1, sampleLabels),
+			Context: sampleGatewayContext,
+		},
+		{
+			Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+			Context: mxd_exp.MetricsContext{
+				MetricName:        "metric_B",
+				AdditionalContext: &mxd_exp.CloudMetricContext{CloudHost: "host1"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, exp.export())
+
+	requests := collector.GetRequests()
+	assert.Len(t, requests, 1)
+	resourceMetrics := requests[0].ResourceMetrics
+	assert.Len(t, resourceMetrics, 2)
+	assert.Equal(t, NetworkIDAttribute, resourceMetrics[0].Resource.Attributes[0].Key)
+	assert.Equal(t, sampleNetworkID, resourceMetrics[0].Resource.Attributes[0].Value.GetStringValue())
+	assert.Equal(t, GatewayIDAttribute, resourceMetrics[0].Resource.Attributes[1].Key)
+	assert.Equal(t, sampleGatewayID, resourceMetrics[0].Resource.Attributes[1].Value.GetStringValue())
+	assert.Len(t, resourceMetrics[0].ScopeMetrics, 1)
+	assert.Equal(t, InstrumentationScopeName, resourceMetrics[0].ScopeMetrics[0].Scope.Name)
+	assert.Len(t, resourceMetrics[0].ScopeMetrics[0].Metrics, 2)
+	assert.Len(t, resourceMetrics[0].ScopeMetrics[0].Metrics[0].GetGauge().DataPoints, 2)
+	assert.Len(t, resourceMetrics[0].ScopeMetrics[0].Metrics[1].GetSum().DataPoints, 1)
+	assert.Equal(t, CloudHostAttribute, resourceMetrics[1].Resource.Attributes[0].Key)
+	assert.Equal(t, "metric_B", resourceMetrics[1].ScopeMetrics[0].Metrics[0].Name)
+
+	// exported metrics are not sent again
+	assert.Empty(t, exp.export())
+	assert.Len(t, collector.GetRequests(), 1)
+}
+
+func TestOTLPExporter_SyncExport(t *testing.T) {
+	defer setRetryBackoff(time.Millisecond)()
+	addr, collector := test_init.StartTestCollector(t)
+	exp := NewOTLPExporter(addr.String()).(*OTLPExporter)
+	metrics := []mxd_exp.MetricAndContext{{
+		Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+		Context: sampleGatewayContext,
+	}}
+
+	// metrics are sent right away, without touching submitted metrics
+	assert.NoError(t, exp.Submit(metrics))
+	assert.NoError(t, exp.Export(metrics))
+	assert.Len(t, collector.GetRequests(), 1)
+	assert.Equal(t, 1, exp.pendingPoints)
+
+	// rejected metrics are reported as permanent errors, unlike an
+	// unavailable collector
+	collector.FailNext(codes.InvalidArgument)
+	err := exp.Export(metrics)
+	assert.True(t, mxd_exp.IsPermanentError(err))
+	assert.Len(t, collector.GetRequests(), 1)
+
+	collector.FailNext(codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable)
+	err = exp.Export(metrics)
+	assert.Error(t, err)
+	assert.False(t, mxd_exp.IsPermanentError(err))
+	assert.Len(t, collector.GetRequests(), 1)
+}
+
+func TestOTLPExporter_Batching(t *testing.T) {
+	defer setMaxBatchSize(3)()
+	addr, collector := test_init.StartTestCollector(t)
+	exp := NewOTLPExporter(addr.String()).(*OTLPExporter)
+
+	var metrics []mxd_exp.MetricAndContext
+	for _, count := range []int{2, 1, 2, 4} {
+		metrics = append(metrics, mxd_exp.MetricAndContext{
+			Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, count, sampleLabels),
+			Context: sampleGatewayContext,
+		})
+	}
+	assert.NoError(t, exp.Submit(metrics))
+	assert.Empty(t, exp.export())
+
+	// data points of a metric are never split between requests
+	requests := collector.GetRequests()
+	assert.Len(t, requests, 3)
+	expectedPoints := [][]int{{2, 1}, {2}, {4}}
+	for i, req := range requests {
+		assert.Len(t, req.ResourceMetrics, 1)
+		var points []int
+		for _, metric := range req.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+			points = append(points, len(metric.GetGauge().DataPoints))
+		}
+		assert.Equal(t, expectedPoints[i], points)
+	}
+}
+
+func TestOTLPExporter_Retry(t *testing.T) {
+	defer setRetryBackoff(time.Millisecond)()
+	addr, collector := test_init.StartTestCollector(t)
+	exp := NewOTLPExporter(addr.String()).(*OTLPExporter)
+	submit := func() {
+		err := exp.Submit([]mxd_exp.MetricAndContext{{
+			Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+			Context: sampleGatewayContext,
+		}})
+		assert.NoError(t, err)
+	}
+
+	// transient failures are retried
+	collector.FailNext(codes.Unavailable, codes.ResourceExhausted)
+	submit()
+	assert.Empty(t, exp.export())
+	assert.Equal(t, 3, collector.GetAttempts())
+	assert.Len(t, collector.GetRequests(), 1)
+
+	// permanent failures are not
+	collector.FailNext(codes.InvalidArgument)
+	submit()
+	errs := exp.export()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "failed to export 1 data points after 1 attempt(s)")
+	assert.Equal(t, 4, collector.GetAttempts())
+	assert.Len(t, collector.GetRequests(), 1)
+
+	// the batch is dropped after MaxExportAttempts
+	collector.FailNext(codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable)
+	submit()
+	errs = exp.export()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "after 5 attempt(s)")
+	assert.Equal(t, 9, collector.GetAttempts())
+	assert.Len(t, collector.GetRequests(), 1)
+	assert.Empty(t, exp.export())
+}
+
+func TestOTLPExporter_CollectorUnavailable(t *testing.T) {
+	defer setRetryBackoff(time.Millisecond)()
+	defer setMaxBatchSize(1)()
+	addr, collector := test_init.StartTestCollector(t)
+	exp := NewOTLPExporter(addr.String()).(*OTLPExporter)
+
+	var metrics []mxd_exp.MetricAndContext
+	for i := 0; i < 3; i++ {
+		metrics = append(metrics, mxd_exp.MetricAndContext{
+			Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+			Context: sampleGatewayContext,
+		})
+	}
+	assert.NoError(t, exp.Submit(metrics))
+
+	// the remaining batches aren't attempted once a batch exhausted its
+	// retries on an unavailable collector
+	collector.FailNext(codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable)
+	errs := exp.export()
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "after 5 attempt(s)")
+	assert.EqualError(t, errs[1], "collector unreachable, skipped 2 data points")
+	assert.Equal(t, 5, collector.GetAttempts())
+	assert.Empty(t, collector.GetRequests())
+}
+
+func TestOTLPExporter_BufferLimit(t *testing.T) {
+	defer setMaxBufferedDataPoints(3)()
+	exp := NewOTLPExporter("localhost:0").(*OTLPExporter)
+
+	err := exp.Submit([]mxd_exp.MetricAndContext{
+		{Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 2, sampleLabels), Context: sampleGatewayContext},
+		{Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 2, sampleLabels), Context: sampleGatewayContext},
+		{Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels), Context: sampleGatewayContext},
+	})
+	assert.EqualError(t, err, "OTLP export buffer is full, dropped 2 data points")
+	assert.Len(t, exp.pending, 2)
+	assert.Equal(t, 3, exp.pendingPoints)
+}
+
+func setMaxBatchSize(size int) func() {
+	prev := MaxBatchSize
+	MaxBatchSize = size
+	return func() { MaxBatchSize = prev }
+}
+
+func setMaxBufferedDataPoints(points int) func() {
+	prev := MaxBufferedDataPoints
+	MaxBufferedDataPoints = points
+	return func() { MaxBufferedDataPoints = prev }
+}
+
+func setRetryBackoff(backoff time.Duration) func() {
+	prevInitial, prevMax := InitialRetryBackoff, MaxRetryBackoff
+	InitialRetryBackoff, MaxRetryBackoff = backoff, backoff
+	return func() { InitialRetryBackoff, MaxRetryBackoff = prevInitial, prevMax }
+}
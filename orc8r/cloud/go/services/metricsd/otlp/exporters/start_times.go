@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package exporters
+
+import (
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+)
+
+// StartTimeTTL is how long the start time of a cumulative series is kept
+// after the series was last exported
+var StartTimeTTL = time.Hour
+
+type cumulativeSeries struct {
+	startNanos uint64
+	lastValue  float64
+	lastSeen   time.Time
+}
+
+// startTimeTracker keeps the start times OTLP requires on cumulative data
+// points. Prometheus metrics don't carry one, so a series starts when it is
+// first exported and restarts whenever its value decreases (counter reset).
+type startTimeTracker struct {
+	series map[string]*cumulativeSeries
+	lastGC time.Time
+	sync.Mutex
+}
+
+func newStartTimeTracker() *startTimeTracker {
+	return &startTimeTracker{series: map[string]*cumulativeSeries{}, lastGC: clock.Now()}
+}
+
+// getStartTime returns the start time of the series with the given key for
+// a data point at tsNanos with the given cumulative value
+func (t *startTimeTracker) getStartTime(key string, tsNanos uint64, value float64) uint64 {
+	now := clock.Now()
+	t.Lock()
+	defer t.Unlock()
+
+	if now.Sub(t.lastGC) >= StartTimeTTL {
+		for seriesKey, series := range t.series {
+			if now.Sub(series.lastSeen) >= StartTimeTTL {
+				delete(t.series, seriesKey)
+			}
+		}
+		t.lastGC = now
+	}
+
+	series, ok := t.series[key]
+	if !ok || value < series.lastValue || tsNanos < series.startNanos {
+		series = &cumulativeSeries{startNanos: tsNanos}
+		t.series[key] = series
+	}
+	series.lastValue = value
+	series.lastSeen = now
+	return series.startNanos
+}
@@ -0,0 +1,1527 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: otlp_metrics.proto
+
+package protos
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type AggregationTemporality int32
+
+const (
+	AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED AggregationTemporality = 0
+	AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA       AggregationTemporality = 1
+	AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE  AggregationTemporality = 2
+)
+
+var AggregationTemporality_name = map[int32]string{
+	0: "AGGREGATION_TEMPORALITY_UNSPECIFIED",
+	1: "AGGREGATION_TEMPORALITY_DELTA",
+	2: "AGGREGATION_TEMPORALITY_CUMULATIVE",
+}
+
+var AggregationTemporality_value = map[string]int32{
+	"AGGREGATION_TEMPORALITY_UNSPECIFIED": 0,
+	"AGGREGATION_TEMPORALITY_DELTA":       1,
+	"AGGREGATION_TEMPORALITY_CUMULATIVE":  2,
+}
+
+func (x AggregationTemporality) String() string {
+	return proto.EnumName(AggregationTemporality_name, int32(x))
+}
+
+func (AggregationTemporality) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{0}
+}
+
+type AnyValue struct {
+	// Types that are valid to be assigned to Value:
+	//	*AnyValue_StringValue
+	//	*AnyValue_BoolValue
+	//	*AnyValue_IntValue
+	//	*AnyValue_DoubleValue
+	//	*AnyValue_ArrayValue
+	//	*AnyValue_KvlistValue
+	//	*AnyValue_BytesValue
+	Value                isAnyValue_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *AnyValue) Reset()         { *m = AnyValue{} }
+func (m *AnyValue) String() string { return proto.CompactTextString(m) }
+func (*AnyValue) ProtoMessage()    {}
+func (*AnyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{0}
+}
+
+func (m *AnyValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnyValue.Unmarshal(m, b)
+}
+func (m *AnyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnyValue.Marshal(b, m, deterministic)
+}
+func (m *AnyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnyValue.Merge(m, src)
+}
+func (m *AnyValue) XXX_Size() int {
+	return xxx_messageInfo_AnyValue.Size(m)
+}
+func (m *AnyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnyValue proto.InternalMessageInfo
+
+type isAnyValue_Value interface {
+	isAnyValue_Value()
+}
+
+type AnyValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type AnyValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type AnyValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,3,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type AnyValue_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type AnyValue_ArrayValue struct {
+	ArrayValue *ArrayValue `protobuf:"bytes,5,opt,name=array_value,json=arrayValue,proto3,oneof"`
+}
+
+type AnyValue_KvlistValue struct {
+	KvlistValue *KeyValueList `protobuf:"bytes,6,opt,name=kvlist_value,json=kvlistValue,proto3,oneof"`
+}
+
+type AnyValue_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,7,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+func (*AnyValue_StringValue) isAnyValue_Value() {}
+
+func (*AnyValue_BoolValue) isAnyValue_Value() {}
+
+func (*AnyValue_IntValue) isAnyValue_Value() {}
+
+func (*AnyValue_DoubleValue) isAnyValue_Value() {}
+
+func (*AnyValue_ArrayValue) isAnyValue_Value() {}
+
+func (*AnyValue_KvlistValue) isAnyValue_Value() {}
+
+func (*AnyValue_BytesValue) isAnyValue_Value() {}
+
+func (m *AnyValue) GetValue() isAnyValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *AnyValue) GetStringValue() string {
+	if x, ok := m.GetValue().(*AnyValue_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *AnyValue) GetBoolValue() bool {
+	if x, ok := m.GetValue().(*AnyValue_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (m *AnyValue) GetIntValue() int64 {
+	if x, ok := m.GetValue().(*AnyValue_IntValue); ok {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (m *AnyValue) GetDoubleValue() float64 {
+	if x, ok := m.GetValue().(*AnyValue_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (m *AnyValue) GetArrayValue() *ArrayValue {
+	if x, ok := m.GetValue().(*AnyValue_ArrayValue); ok {
+		return x.ArrayValue
+	}
+	return nil
+}
+
+func (m *AnyValue) GetKvlistValue() *KeyValueList {
+	if x, ok := m.GetValue().(*AnyValue_KvlistValue); ok {
+		return x.KvlistValue
+	}
+	return nil
+}
+
+func (m *AnyValue) GetBytesValue() []byte {
+	if x, ok := m.GetValue().(*AnyValue_BytesValue); ok {
+		return x.BytesValue
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*AnyValue) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*AnyValue_StringValue)(nil),
+		(*AnyValue_BoolValue)(nil),
+		(*AnyValue_IntValue)(nil),
+		(*AnyValue_DoubleValue)(nil),
+		(*AnyValue_ArrayValue)(nil),
+		(*AnyValue_KvlistValue)(nil),
+		(*AnyValue_BytesValue)(nil),
+	}
+}
+
+type ArrayValue struct {
+	Values               []*AnyValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ArrayValue) Reset()         { *m = ArrayValue{} }
+func (m *ArrayValue) String() string { return proto.CompactTextString(m) }
+func (*ArrayValue) ProtoMessage()    {}
+func (*ArrayValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{1}
+}
+
+func (m *ArrayValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArrayValue.Unmarshal(m, b)
+}
+func (m *ArrayValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArrayValue.Marshal(b, m, deterministic)
+}
+func (m *ArrayValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArrayValue.Merge(m, src)
+}
+func (m *ArrayValue) XXX_Size() int {
+	return xxx_messageInfo_ArrayValue.Size(m)
+}
+func (m *ArrayValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArrayValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArrayValue proto.InternalMessageInfo
+
+func (m *ArrayValue) GetValues() []*AnyValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type KeyValueList struct {
+	Values               []*KeyValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *KeyValueList) Reset()         { *m = KeyValueList{} }
+func (m *KeyValueList) String() string { return proto.CompactTextString(m) }
+func (*KeyValueList) ProtoMessage()    {}
+func (*KeyValueList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{2}
+}
+
+func (m *KeyValueList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyValueList.Unmarshal(m, b)
+}
+func (m *KeyValueList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyValueList.Marshal(b, m, deterministic)
+}
+func (m *KeyValueList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValueList.Merge(m, src)
+}
+func (m *KeyValueList) XXX_Size() int {
+	return xxx_messageInfo_KeyValueList.Size(m)
+}
+func (m *KeyValueList) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValueList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValueList proto.InternalMessageInfo
+
+func (m *KeyValueList) GetValues() []*KeyValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type KeyValue struct {
+	Key                  string    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                *AnyValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *KeyValue) Reset()         { *m = KeyValue{} }
+func (m *KeyValue) String() string { return proto.CompactTextString(m) }
+func (*KeyValue) ProtoMessage()    {}
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{3}
+}
+
+func (m *KeyValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyValue.Unmarshal(m, b)
+}
+func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
+}
+func (m *KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValue.Merge(m, src)
+}
+func (m *KeyValue) XXX_Size() int {
+	return xxx_messageInfo_KeyValue.Size(m)
+}
+func (m *KeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValue proto.InternalMessageInfo
+
+func (m *KeyValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyValue) GetValue() *AnyValue {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type InstrumentationScope struct {
+	Name                   string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version                string      `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Attributes             []*KeyValue `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	DroppedAttributesCount uint32      `protobuf:"varint,4,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}    `json:"-"`
+	XXX_unrecognized       []byte      `json:"-"`
+	XXX_sizecache          int32       `json:"-"`
+}
+
+func (m *InstrumentationScope) Reset()         { *m = InstrumentationScope{} }
+func (m *InstrumentationScope) String() string { return proto.CompactTextString(m) }
+func (*InstrumentationScope) ProtoMessage()    {}
+func (*InstrumentationScope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{4}
+}
+
+func (m *InstrumentationScope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstrumentationScope.Unmarshal(m, b)
+}
+func (m *InstrumentationScope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstrumentationScope.Marshal(b, m, deterministic)
+}
+func (m *InstrumentationScope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstrumentationScope.Merge(m, src)
+}
+func (m *InstrumentationScope) XXX_Size() int {
+	return xxx_messageInfo_InstrumentationScope.Size(m)
+}
+func (m *InstrumentationScope) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstrumentationScope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstrumentationScope proto.InternalMessageInfo
+
+func (m *InstrumentationScope) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InstrumentationScope) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *InstrumentationScope) GetAttributes() []*KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *InstrumentationScope) GetDroppedAttributesCount() uint32 {
+	if m != nil {
+		return m.DroppedAttributesCount
+	}
+	return 0
+}
+
+type Resource struct {
+	Attributes             []*KeyValue `protobuf:"bytes,1,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	DroppedAttributesCount uint32      `protobuf:"varint,2,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}    `json:"-"`
+	XXX_unrecognized       []byte      `json:"-"`
+	XXX_sizecache          int32       `json:"-"`
+}
+
+func (m *Resource) Reset()         { *m = Resource{} }
+func (m *Resource) String() string { return proto.CompactTextString(m) }
+func (*Resource) ProtoMessage()    {}
+func (*Resource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{5}
+}
+
+func (m *Resource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resource.Unmarshal(m, b)
+}
+func (m *Resource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resource.Marshal(b, m, deterministic)
+}
+func (m *Resource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resource.Merge(m, src)
+}
+func (m *Resource) XXX_Size() int {
+	return xxx_messageInfo_Resource.Size(m)
+}
+func (m *Resource) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resource proto.InternalMessageInfo
+
+func (m *Resource) GetAttributes() []*KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Resource) GetDroppedAttributesCount() uint32 {
+	if m != nil {
+		return m.DroppedAttributesCount
+	}
+	return 0
+}
+
+type ResourceMetrics struct {
+	Resource             *Resource       `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	ScopeMetrics         []*ScopeMetrics `protobuf:"bytes,2,rep,name=scope_metrics,json=scopeMetrics,proto3" json:"scope_metrics,omitempty"`
+	SchemaUrl            string          `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ResourceMetrics) Reset()         { *m = ResourceMetrics{} }
+func (m *ResourceMetrics) String() string { return proto.CompactTextString(m) }
+func (*ResourceMetrics) ProtoMessage()    {}
+func (*ResourceMetrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{6}
+}
+
+func (m *ResourceMetrics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceMetrics.Unmarshal(m, b)
+}
+func (m *ResourceMetrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceMetrics.Marshal(b, m, deterministic)
+}
+func (m *ResourceMetrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceMetrics.Merge(m, src)
+}
+func (m *ResourceMetrics) XXX_Size() int {
+	return xxx_messageInfo_ResourceMetrics.Size(m)
+}
+func (m *ResourceMetrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceMetrics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceMetrics proto.InternalMessageInfo
+
+func (m *ResourceMetrics) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *ResourceMetrics) GetScopeMetrics() []*ScopeMetrics {
+	if m != nil {
+		return m.ScopeMetrics
+	}
+	return nil
+}
+
+func (m *ResourceMetrics) GetSchemaUrl() string {
+	if m != nil {
+		return m.SchemaUrl
+	}
+	return ""
+}
+
+type ScopeMetrics struct {
+	Scope                *InstrumentationScope `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Metrics              []*Metric             `protobuf:"bytes,2,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	SchemaUrl            string                `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ScopeMetrics) Reset()         { *m = ScopeMetrics{} }
+func (m *ScopeMetrics) String() string { return proto.CompactTextString(m) }
+func (*ScopeMetrics) ProtoMessage()    {}
+func (*ScopeMetrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{7}
+}
+
+func (m *ScopeMetrics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScopeMetrics.Unmarshal(m, b)
+}
+func (m *ScopeMetrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScopeMetrics.Marshal(b, m, deterministic)
+}
+func (m *ScopeMetrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScopeMetrics.Merge(m, src)
+}
+func (m *ScopeMetrics) XXX_Size() int {
+	return xxx_messageInfo_ScopeMetrics.Size(m)
+}
+func (m *ScopeMetrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScopeMetrics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScopeMetrics proto.InternalMessageInfo
+
+func (m *ScopeMetrics) GetScope() *InstrumentationScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *ScopeMetrics) GetMetrics() []*Metric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *ScopeMetrics) GetSchemaUrl() string {
+	if m != nil {
+		return m.SchemaUrl
+	}
+	return ""
+}
+
+type Metric struct {
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Unit        string `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	// Types that are valid to be assigned to Data:
+	//	*Metric_Gauge
+	//	*Metric_Sum
+	//	*Metric_Histogram
+	//	*Metric_Summary
+	Data                 isMetric_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Metric) Reset()         { *m = Metric{} }
+func (m *Metric) String() string { return proto.CompactTextString(m) }
+func (*Metric) ProtoMessage()    {}
+func (*Metric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{8}
+}
+
+func (m *Metric) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Metric.Unmarshal(m, b)
+}
+func (m *Metric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Metric.Marshal(b, m, deterministic)
+}
+func (m *Metric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metric.Merge(m, src)
+}
+func (m *Metric) XXX_Size() int {
+	return xxx_messageInfo_Metric.Size(m)
+}
+func (m *Metric) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metric proto.InternalMessageInfo
+
+func (m *Metric) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Metric) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Metric) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+type isMetric_Data interface {
+	isMetric_Data()
+}
+
+type Metric_Gauge struct {
+	Gauge *Gauge `protobuf:"bytes,5,opt,name=gauge,proto3,oneof"`
+}
+
+type Metric_Sum struct {
+	Sum *Sum `protobuf:"bytes,7,opt,name=sum,proto3,oneof"`
+}
+
+type Metric_Histogram struct {
+	Histogram *Histogram `protobuf:"bytes,9,opt,name=histogram,proto3,oneof"`
+}
+
+type Metric_Summary struct {
+	Summary *Summary `protobuf:"bytes,11,opt,name=summary,proto3,oneof"`
+}
+
+func (*Metric_Gauge) isMetric_Data() {}
+
+func (*Metric_Sum) isMetric_Data() {}
+
+func (*Metric_Histogram) isMetric_Data() {}
+
+func (*Metric_Summary) isMetric_Data() {}
+
+func (m *Metric) GetData() isMetric_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Metric) GetGauge() *Gauge {
+	if x, ok := m.GetData().(*Metric_Gauge); ok {
+		return x.Gauge
+	}
+	return nil
+}
+
+func (m *Metric) GetSum() *Sum {
+	if x, ok := m.GetData().(*Metric_Sum); ok {
+		return x.Sum
+	}
+	return nil
+}
+
+func (m *Metric) GetHistogram() *Histogram {
+	if x, ok := m.GetData().(*Metric_Histogram); ok {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (m *Metric) GetSummary() *Summary {
+	if x, ok := m.GetData().(*Metric_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Metric) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Metric_Gauge)(nil),
+		(*Metric_Sum)(nil),
+		(*Metric_Histogram)(nil),
+		(*Metric_Summary)(nil),
+	}
+}
+
+type Gauge struct {
+	DataPoints           []*NumberDataPoint `protobuf:"bytes,1,rep,name=data_points,json=dataPoints,proto3" json:"data_points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Gauge) Reset()         { *m = Gauge{} }
+func (m *Gauge) String() string { return proto.CompactTextString(m) }
+func (*Gauge) ProtoMessage()    {}
+func (*Gauge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{9}
+}
+
+func (m *Gauge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Gauge.Unmarshal(m, b)
+}
+func (m *Gauge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Gauge.Marshal(b, m, deterministic)
+}
+func (m *Gauge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Gauge.Merge(m, src)
+}
+func (m *Gauge) XXX_Size() int {
+	return xxx_messageInfo_Gauge.Size(m)
+}
+func (m *Gauge) XXX_DiscardUnknown() {
+	xxx_messageInfo_Gauge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Gauge proto.InternalMessageInfo
+
+func (m *Gauge) GetDataPoints() []*NumberDataPoint {
+	if m != nil {
+		return m.DataPoints
+	}
+	return nil
+}
+
+type Sum struct {
+	DataPoints             []*NumberDataPoint     `protobuf:"bytes,1,rep,name=data_points,json=dataPoints,proto3" json:"data_points,omitempty"`
+	AggregationTemporality AggregationTemporality `protobuf:"varint,2,opt,name=aggregation_temporality,json=aggregationTemporality,proto3,enum=opentelemetry.proto.collector.metrics.v1.AggregationTemporality" json:"aggregation_temporality,omitempty"`
+	IsMonotonic            bool                   `protobuf:"varint,3,opt,name=is_monotonic,json=isMonotonic,proto3" json:"is_monotonic,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}               `json:"-"`
+	XXX_unrecognized       []byte                 `json:"-"`
+	XXX_sizecache          int32                  `json:"-"`
+}
+
+func (m *Sum) Reset()         { *m = Sum{} }
+func (m *Sum) String() string { return proto.CompactTextString(m) }
+func (*Sum) ProtoMessage()    {}
+func (*Sum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{10}
+}
+
+func (m *Sum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sum.Unmarshal(m, b)
+}
+func (m *Sum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sum.Marshal(b, m, deterministic)
+}
+func (m *Sum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sum.Merge(m, src)
+}
+func (m *Sum) XXX_Size() int {
+	return xxx_messageInfo_Sum.Size(m)
+}
+func (m *Sum) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sum.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sum proto.InternalMessageInfo
+
+func (m *Sum) GetDataPoints() []*NumberDataPoint {
+	if m != nil {
+		return m.DataPoints
+	}
+	return nil
+}
+
+func (m *Sum) GetAggregationTemporality() AggregationTemporality {
+	if m != nil {
+		return m.AggregationTemporality
+	}
+	return AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
+}
+
+func (m *Sum) GetIsMonotonic() bool {
+	if m != nil {
+		return m.IsMonotonic
+	}
+	return false
+}
+
+type Histogram struct {
+	DataPoints             []*HistogramDataPoint  `protobuf:"bytes,1,rep,name=data_points,json=dataPoints,proto3" json:"data_points,omitempty"`
+	AggregationTemporality AggregationTemporality `protobuf:"varint,2,opt,name=aggregation_temporality,json=aggregationTemporality,proto3,enum=opentelemetry.proto.collector.metrics.v1.AggregationTemporality" json:"aggregation_temporality,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}               `json:"-"`
+	XXX_unrecognized       []byte                 `json:"-"`
+	XXX_sizecache          int32                  `json:"-"`
+}
+
+func (m *Histogram) Reset()         { *m = Histogram{} }
+func (m *Histogram) String() string { return proto.CompactTextString(m) }
+func (*Histogram) ProtoMessage()    {}
+func (*Histogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{11}
+}
+
+func (m *Histogram) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Histogram.Unmarshal(m, b)
+}
+func (m *Histogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Histogram.Marshal(b, m, deterministic)
+}
+func (m *Histogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Histogram.Merge(m, src)
+}
+func (m *Histogram) XXX_Size() int {
+	return xxx_messageInfo_Histogram.Size(m)
+}
+func (m *Histogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_Histogram.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Histogram proto.InternalMessageInfo
+
+func (m *Histogram) GetDataPoints() []*HistogramDataPoint {
+	if m != nil {
+		return m.DataPoints
+	}
+	return nil
+}
+
+func (m *Histogram) GetAggregationTemporality() AggregationTemporality {
+	if m != nil {
+		return m.AggregationTemporality
+	}
+	return AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
+}
+
+type Summary struct {
+	DataPoints           []*SummaryDataPoint `protobuf:"bytes,1,rep,name=data_points,json=dataPoints,proto3" json:"data_points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Summary) Reset()         { *m = Summary{} }
+func (m *Summary) String() string { return proto.CompactTextString(m) }
+func (*Summary) ProtoMessage()    {}
+func (*Summary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{12}
+}
+
+func (m *Summary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Summary.Unmarshal(m, b)
+}
+func (m *Summary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Summary.Marshal(b, m, deterministic)
+}
+func (m *Summary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Summary.Merge(m, src)
+}
+func (m *Summary) XXX_Size() int {
+	return xxx_messageInfo_Summary.Size(m)
+}
+func (m *Summary) XXX_DiscardUnknown() {
+	xxx_messageInfo_Summary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Summary proto.InternalMessageInfo
+
+func (m *Summary) GetDataPoints() []*SummaryDataPoint {
+	if m != nil {
+		return m.DataPoints
+	}
+	return nil
+}
+
+type NumberDataPoint struct {
+	Attributes        []*KeyValue `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	StartTimeUnixNano uint64      `protobuf:"fixed64,2,opt,name=start_time_unix_nano,json=startTimeUnixNano,proto3" json:"start_time_unix_nano,omitempty"`
+	TimeUnixNano      uint64      `protobuf:"fixed64,3,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//	*NumberDataPoint_AsDouble
+	//	*NumberDataPoint_AsInt
+	Value                isNumberDataPoint_Value `protobuf_oneof:"value"`
+	Flags                uint32                  `protobuf:"varint,8,opt,name=flags,proto3" json:"flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *NumberDataPoint) Reset()         { *m = NumberDataPoint{} }
+func (m *NumberDataPoint) String() string { return proto.CompactTextString(m) }
+func (*NumberDataPoint) ProtoMessage()    {}
+func (*NumberDataPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{13}
+}
+
+func (m *NumberDataPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NumberDataPoint.Unmarshal(m, b)
+}
+func (m *NumberDataPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NumberDataPoint.Marshal(b, m, deterministic)
+}
+func (m *NumberDataPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NumberDataPoint.Merge(m, src)
+}
+func (m *NumberDataPoint) XXX_Size() int {
+	return xxx_messageInfo_NumberDataPoint.Size(m)
+}
+func (m *NumberDataPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_NumberDataPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NumberDataPoint proto.InternalMessageInfo
+
+func (m *NumberDataPoint) GetAttributes() []*KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *NumberDataPoint) GetStartTimeUnixNano() uint64 {
+	if m != nil {
+		return m.StartTimeUnixNano
+	}
+	return 0
+}
+
+func (m *NumberDataPoint) GetTimeUnixNano() uint64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+type isNumberDataPoint_Value interface {
+	isNumberDataPoint_Value()
+}
+
+type NumberDataPoint_AsDouble struct {
+	AsDouble float64 `protobuf:"fixed64,4,opt,name=as_double,json=asDouble,proto3,oneof"`
+}
+
+type NumberDataPoint_AsInt struct {
+	AsInt int64 `protobuf:"fixed64,6,opt,name=as_int,json=asInt,proto3,oneof"`
+}
+
+func (*NumberDataPoint_AsDouble) isNumberDataPoint_Value() {}
+
+func (*NumberDataPoint_AsInt) isNumberDataPoint_Value() {}
+
+func (m *NumberDataPoint) GetValue() isNumberDataPoint_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *NumberDataPoint) GetAsDouble() float64 {
+	if x, ok := m.GetValue().(*NumberDataPoint_AsDouble); ok {
+		return x.AsDouble
+	}
+	return 0
+}
+
+func (m *NumberDataPoint) GetAsInt() int64 {
+	if x, ok := m.GetValue().(*NumberDataPoint_AsInt); ok {
+		return x.AsInt
+	}
+	return 0
+}
+
+func (m *NumberDataPoint) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*NumberDataPoint) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*NumberDataPoint_AsDouble)(nil),
+		(*NumberDataPoint_AsInt)(nil),
+	}
+}
+
+type HistogramDataPoint struct {
+	Attributes           []*KeyValue `protobuf:"bytes,9,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	StartTimeUnixNano    uint64      `protobuf:"fixed64,2,opt,name=start_time_unix_nano,json=startTimeUnixNano,proto3" json:"start_time_unix_nano,omitempty"`
+	TimeUnixNano         uint64      `protobuf:"fixed64,3,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	Count                uint64      `protobuf:"fixed64,4,opt,name=count,proto3" json:"count,omitempty"`
+	Sum                  float64     `protobuf:"fixed64,5,opt,name=sum,proto3" json:"sum,omitempty"`
+	BucketCounts         []uint64    `protobuf:"fixed64,6,rep,packed,name=bucket_counts,json=bucketCounts,proto3" json:"bucket_counts,omitempty"`
+	ExplicitBounds       []float64   `protobuf:"fixed64,7,rep,packed,name=explicit_bounds,json=explicitBounds,proto3" json:"explicit_bounds,omitempty"`
+	Flags                uint32      `protobuf:"varint,10,opt,name=flags,proto3" json:"flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *HistogramDataPoint) Reset()         { *m = HistogramDataPoint{} }
+func (m *HistogramDataPoint) String() string { return proto.CompactTextString(m) }
+func (*HistogramDataPoint) ProtoMessage()    {}
+func (*HistogramDataPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{14}
+}
+
+func (m *HistogramDataPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistogramDataPoint.Unmarshal(m, b)
+}
+func (m *HistogramDataPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistogramDataPoint.Marshal(b, m, deterministic)
+}
+func (m *HistogramDataPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistogramDataPoint.Merge(m, src)
+}
+func (m *HistogramDataPoint) XXX_Size() int {
+	return xxx_messageInfo_HistogramDataPoint.Size(m)
+}
+func (m *HistogramDataPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistogramDataPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistogramDataPoint proto.InternalMessageInfo
+
+func (m *HistogramDataPoint) GetAttributes() []*KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *HistogramDataPoint) GetStartTimeUnixNano() uint64 {
+	if m != nil {
+		return m.StartTimeUnixNano
+	}
+	return 0
+}
+
+func (m *HistogramDataPoint) GetTimeUnixNano() uint64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+func (m *HistogramDataPoint) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *HistogramDataPoint) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *HistogramDataPoint) GetBucketCounts() []uint64 {
+	if m != nil {
+		return m.BucketCounts
+	}
+	return nil
+}
+
+func (m *HistogramDataPoint) GetExplicitBounds() []float64 {
+	if m != nil {
+		return m.ExplicitBounds
+	}
+	return nil
+}
+
+func (m *HistogramDataPoint) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+type SummaryDataPoint struct {
+	Attributes           []*KeyValue                         `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	StartTimeUnixNano    uint64                              `protobuf:"fixed64,2,opt,name=start_time_unix_nano,json=startTimeUnixNano,proto3" json:"start_time_unix_nano,omitempty"`
+	TimeUnixNano         uint64                              `protobuf:"fixed64,3,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	Count                uint64                              `protobuf:"fixed64,4,opt,name=count,proto3" json:"count,omitempty"`
+	Sum                  float64                             `protobuf:"fixed64,5,opt,name=sum,proto3" json:"sum,omitempty"`
+	QuantileValues       []*SummaryDataPoint_ValueAtQuantile `protobuf:"bytes,6,rep,name=quantile_values,json=quantileValues,proto3" json:"quantile_values,omitempty"`
+	Flags                uint32                              `protobuf:"varint,8,opt,name=flags,proto3" json:"flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *SummaryDataPoint) Reset()         { *m = SummaryDataPoint{} }
+func (m *SummaryDataPoint) String() string { return proto.CompactTextString(m) }
+func (*SummaryDataPoint) ProtoMessage()    {}
+func (*SummaryDataPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{15}
+}
+
+func (m *SummaryDataPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SummaryDataPoint.Unmarshal(m, b)
+}
+func (m *SummaryDataPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SummaryDataPoint.Marshal(b, m, deterministic)
+}
+func (m *SummaryDataPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SummaryDataPoint.Merge(m, src)
+}
+func (m *SummaryDataPoint) XXX_Size() int {
+	return xxx_messageInfo_SummaryDataPoint.Size(m)
+}
+func (m *SummaryDataPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_SummaryDataPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SummaryDataPoint proto.InternalMessageInfo
+
+func (m *SummaryDataPoint) GetAttributes() []*KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *SummaryDataPoint) GetStartTimeUnixNano() uint64 {
+	if m != nil {
+		return m.StartTimeUnixNano
+	}
+	return 0
+}
+
+func (m *SummaryDataPoint) GetTimeUnixNano() uint64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+func (m *SummaryDataPoint) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *SummaryDataPoint) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *SummaryDataPoint) GetQuantileValues() []*SummaryDataPoint_ValueAtQuantile {
+	if m != nil {
+		return m.QuantileValues
+	}
+	return nil
+}
+
+func (m *SummaryDataPoint) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+type SummaryDataPoint_ValueAtQuantile struct {
+	Quantile             float64  `protobuf:"fixed64,1,opt,name=quantile,proto3" json:"quantile,omitempty"`
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SummaryDataPoint_ValueAtQuantile) Reset()         { *m = SummaryDataPoint_ValueAtQuantile{} }
+func (m *SummaryDataPoint_ValueAtQuantile) String() string { return proto.CompactTextString(m) }
+func (*SummaryDataPoint_ValueAtQuantile) ProtoMessage()    {}
+func (*SummaryDataPoint_ValueAtQuantile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{15, 0}
+}
+
+func (m *SummaryDataPoint_ValueAtQuantile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SummaryDataPoint_ValueAtQuantile.Unmarshal(m, b)
+}
+func (m *SummaryDataPoint_ValueAtQuantile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SummaryDataPoint_ValueAtQuantile.Marshal(b, m, deterministic)
+}
+func (m *SummaryDataPoint_ValueAtQuantile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SummaryDataPoint_ValueAtQuantile.Merge(m, src)
+}
+func (m *SummaryDataPoint_ValueAtQuantile) XXX_Size() int {
+	return xxx_messageInfo_SummaryDataPoint_ValueAtQuantile.Size(m)
+}
+func (m *SummaryDataPoint_ValueAtQuantile) XXX_DiscardUnknown() {
+	xxx_messageInfo_SummaryDataPoint_ValueAtQuantile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SummaryDataPoint_ValueAtQuantile proto.InternalMessageInfo
+
+func (m *SummaryDataPoint_ValueAtQuantile) GetQuantile() float64 {
+	if m != nil {
+		return m.Quantile
+	}
+	return 0
+}
+
+func (m *SummaryDataPoint_ValueAtQuantile) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type ExportMetricsServiceRequest struct {
+	ResourceMetrics      []*ResourceMetrics `protobuf:"bytes,1,rep,name=resource_metrics,json=resourceMetrics,proto3" json:"resource_metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ExportMetricsServiceRequest) Reset()         { *m = ExportMetricsServiceRequest{} }
+func (m *ExportMetricsServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportMetricsServiceRequest) ProtoMessage()    {}
+func (*ExportMetricsServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{16}
+}
+
+func (m *ExportMetricsServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportMetricsServiceRequest.Unmarshal(m, b)
+}
+func (m *ExportMetricsServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportMetricsServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportMetricsServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportMetricsServiceRequest.Merge(m, src)
+}
+func (m *ExportMetricsServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportMetricsServiceRequest.Size(m)
+}
+func (m *ExportMetricsServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportMetricsServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportMetricsServiceRequest proto.InternalMessageInfo
+
+func (m *ExportMetricsServiceRequest) GetResourceMetrics() []*ResourceMetrics {
+	if m != nil {
+		return m.ResourceMetrics
+	}
+	return nil
+}
+
+type ExportMetricsServiceResponse struct {
+	PartialSuccess       *ExportMetricsPartialSuccess `protobuf:"bytes,1,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ExportMetricsServiceResponse) Reset()         { *m = ExportMetricsServiceResponse{} }
+func (m *ExportMetricsServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportMetricsServiceResponse) ProtoMessage()    {}
+func (*ExportMetricsServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{17}
+}
+
+func (m *ExportMetricsServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportMetricsServiceResponse.Unmarshal(m, b)
+}
+func (m *ExportMetricsServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportMetricsServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportMetricsServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportMetricsServiceResponse.Merge(m, src)
+}
+func (m *ExportMetricsServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportMetricsServiceResponse.Size(m)
+}
+func (m *ExportMetricsServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportMetricsServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportMetricsServiceResponse proto.InternalMessageInfo
+
+func (m *ExportMetricsServiceResponse) GetPartialSuccess() *ExportMetricsPartialSuccess {
+	if m != nil {
+		return m.PartialSuccess
+	}
+	return nil
+}
+
+type ExportMetricsPartialSuccess struct {
+	RejectedDataPoints   int64    `protobuf:"varint,1,opt,name=rejected_data_points,json=rejectedDataPoints,proto3" json:"rejected_data_points,omitempty"`
+	ErrorMessage         string   `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportMetricsPartialSuccess) Reset()         { *m = ExportMetricsPartialSuccess{} }
+func (m *ExportMetricsPartialSuccess) String() string { return proto.CompactTextString(m) }
+func (*ExportMetricsPartialSuccess) ProtoMessage()    {}
+func (*ExportMetricsPartialSuccess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1e1570f259b0419, []int{18}
+}
+
+func (m *ExportMetricsPartialSuccess) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportMetricsPartialSuccess.Unmarshal(m, b)
+}
+func (m *ExportMetricsPartialSuccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportMetricsPartialSuccess.Marshal(b, m, deterministic)
+}
+func (m *ExportMetricsPartialSuccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportMetricsPartialSuccess.Merge(m, src)
+}
+func (m *ExportMetricsPartialSuccess) XXX_Size() int {
+	return xxx_messageInfo_ExportMetricsPartialSuccess.Size(m)
+}
+func (m *ExportMetricsPartialSuccess) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportMetricsPartialSuccess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportMetricsPartialSuccess proto.InternalMessageInfo
+
+func (m *ExportMetricsPartialSuccess) GetRejectedDataPoints() int64 {
+	if m != nil {
+		return m.RejectedDataPoints
+	}
+	return 0
+}
+
+func (m *ExportMetricsPartialSuccess) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("opentelemetry.proto.collector.metrics.v1.AggregationTemporality", AggregationTemporality_name, AggregationTemporality_value)
+	proto.RegisterType((*AnyValue)(nil), "opentelemetry.proto.collector.metrics.v1.AnyValue")
+	proto.RegisterType((*ArrayValue)(nil), "opentelemetry.proto.collector.metrics.v1.ArrayValue")
+	proto.RegisterType((*KeyValueList)(nil), "opentelemetry.proto.collector.metrics.v1.KeyValueList")
+	proto.RegisterType((*KeyValue)(nil), "opentelemetry.proto.collector.metrics.v1.KeyValue")
+	proto.RegisterType((*InstrumentationScope)(nil), "opentelemetry.proto.collector.metrics.v1.InstrumentationScope")
+	proto.RegisterType((*Resource)(nil), "opentelemetry.proto.collector.metrics.v1.Resource")
+	proto.RegisterType((*ResourceMetrics)(nil), "opentelemetry.proto.collector.metrics.v1.ResourceMetrics")
+	proto.RegisterType((*ScopeMetrics)(nil), "opentelemetry.proto.collector.metrics.v1.ScopeMetrics")
+	proto.RegisterType((*Metric)(nil), "opentelemetry.proto.collector.metrics.v1.Metric")
+	proto.RegisterType((*Gauge)(nil), "opentelemetry.proto.collector.metrics.v1.Gauge")
+	proto.RegisterType((*Sum)(nil), "opentelemetry.proto.collector.metrics.v1.Sum")
+	proto.RegisterType((*Histogram)(nil), "opentelemetry.proto.collector.metrics.v1.Histogram")
+	proto.RegisterType((*Summary)(nil), "opentelemetry.proto.collector.metrics.v1.Summary")
+	proto.RegisterType((*NumberDataPoint)(nil), "opentelemetry.proto.collector.metrics.v1.NumberDataPoint")
+	proto.RegisterType((*HistogramDataPoint)(nil), "opentelemetry.proto.collector.metrics.v1.HistogramDataPoint")
+	proto.RegisterType((*SummaryDataPoint)(nil), "opentelemetry.proto.collector.metrics.v1.SummaryDataPoint")
+	proto.RegisterType((*SummaryDataPoint_ValueAtQuantile)(nil), "opentelemetry.proto.collector.metrics.v1.SummaryDataPoint.ValueAtQuantile")
+	proto.RegisterType((*ExportMetricsServiceRequest)(nil), "opentelemetry.proto.collector.metrics.v1.ExportMetricsServiceRequest")
+	proto.RegisterType((*ExportMetricsServiceResponse)(nil), "opentelemetry.proto.collector.metrics.v1.ExportMetricsServiceResponse")
+	proto.RegisterType((*ExportMetricsPartialSuccess)(nil), "opentelemetry.proto.collector.metrics.v1.ExportMetricsPartialSuccess")
+}
+
+func init() { proto.RegisterFile("otlp_metrics.proto", fileDescriptor_f1e1570f259b0419) }
+
+var fileDescriptor_f1e1570f259b0419 = []byte{
+	// 1310 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x5f, 0x6f, 0x1b, 0xc5,
+	0x16, 0xf7, 0xda, 0xf5, 0xbf, 0x63, 0x27, 0xf6, 0x1d, 0x45, 0xad, 0xd5, 0xde, 0xe8, 0xba, 0x9b,
+	0xab, 0x5b, 0xeb, 0x4a, 0xa4, 0xad, 0x8b, 0x10, 0x20, 0x84, 0x70, 0x12, 0x37, 0x76, 0x9b, 0xa4,
+	0x61, 0xe2, 0x14, 0x68, 0x85, 0x56, 0xe3, 0xf5, 0xd4, 0x5d, 0xba, 0xbb, 0xb3, 0x9d, 0x99, 0x8d,
+	0xe2, 0x67, 0xde, 0x10, 0xe2, 0x85, 0x17, 0xf8, 0x0e, 0xbc, 0xf2, 0x29, 0x78, 0x40, 0xe2, 0x9d,
+	0x47, 0xbe, 0x02, 0xaf, 0xa0, 0x9d, 0xd9, 0xf5, 0x3f, 0xdc, 0xca, 0x4e, 0xa9, 0x54, 0x9e, 0xbc,
+	0xf3, 0x9b, 0x73, 0x7e, 0x73, 0xe6, 0xcc, 0x6f, 0xce, 0x1c, 0x03, 0x62, 0xd2, 0x0d, 0x2c, 0x8f,
+	0x4a, 0xee, 0xd8, 0x62, 0x3b, 0xe0, 0x4c, 0x32, 0xd4, 0x60, 0x01, 0xf5, 0x25, 0x75, 0x69, 0x04,
+	0x8f, 0x34, 0xb8, 0x6d, 0x33, 0xd7, 0xa5, 0xb6, 0x64, 0x7c, 0x3b, 0x31, 0x3e, 0xbb, 0x6d, 0xfe,
+	0x9e, 0x86, 0x42, 0xcb, 0x1f, 0x3d, 0x24, 0x6e, 0x48, 0xd1, 0x16, 0x94, 0x85, 0xe4, 0x8e, 0x3f,
+	0xb4, 0xce, 0xa2, 0x71, 0xcd, 0xa8, 0x1b, 0x8d, 0x62, 0x27, 0x85, 0x4b, 0x1a, 0xd5, 0x46, 0xff,
+	0x01, 0xe8, 0x33, 0xe6, 0xc6, 0x26, 0xe9, 0xba, 0xd1, 0x28, 0x74, 0x52, 0xb8, 0x18, 0x61, 0xda,
+	0x60, 0x13, 0x8a, 0x8e, 0x2f, 0xe3, 0xf9, 0x4c, 0xdd, 0x68, 0x64, 0x3a, 0x29, 0x5c, 0x70, 0x7c,
+	0x39, 0x5e, 0x64, 0xc0, 0xc2, 0xbe, 0x4b, 0x63, 0x8b, 0x4b, 0x75, 0xa3, 0x61, 0x44, 0x8b, 0x68,
+	0x54, 0x1b, 0x7d, 0x02, 0x25, 0xc2, 0x39, 0x19, 0xc5, 0x36, 0xd9, 0xba, 0xd1, 0x28, 0x35, 0xdf,
+	0xde, 0x5e, 0x76, 0x5b, 0xdb, 0xad, 0xc8, 0x59, 0x51, 0x75, 0x52, 0x18, 0xc8, 0x78, 0x84, 0x1e,
+	0x43, 0xf9, 0xd9, 0x99, 0xeb, 0x88, 0x24, 0xbe, 0x9c, 0x62, 0x7e, 0x67, 0x79, 0xe6, 0xfb, 0x54,
+	0x33, 0x1d, 0x38, 0x42, 0x46, 0x51, 0x6b, 0x36, 0x4d, 0x7e, 0x1d, 0x4a, 0xfd, 0x91, 0xa4, 0x22,
+	0xe6, 0xce, 0xd7, 0x8d, 0x46, 0x39, 0x5a, 0x5f, 0x81, 0xca, 0x64, 0x27, 0x0f, 0x59, 0x35, 0x69,
+	0x7e, 0x0a, 0x30, 0x09, 0x12, 0xdd, 0x83, 0x9c, 0x82, 0x45, 0xcd, 0xa8, 0x67, 0x1a, 0xa5, 0x66,
+	0x73, 0x85, 0xad, 0xc6, 0xa7, 0x87, 0x63, 0x06, 0xf3, 0x11, 0x94, 0xa7, 0x83, 0x7c, 0x15, 0xee,
+	0xfb, 0x74, 0x8e, 0xfb, 0x09, 0x14, 0x12, 0x0c, 0x55, 0x21, 0xf3, 0x8c, 0x8e, 0xb4, 0x48, 0x70,
+	0xf4, 0x89, 0x3a, 0x90, 0x9d, 0xa8, 0xe2, 0x62, 0x9b, 0x88, 0xb3, 0xf3, 0x8b, 0x01, 0x1b, 0x5d,
+	0x5f, 0x48, 0x1e, 0x7a, 0xd4, 0x97, 0x44, 0x3a, 0xcc, 0x3f, 0xb1, 0x59, 0x40, 0x11, 0x82, 0x4b,
+	0x3e, 0xf1, 0x62, 0x69, 0x62, 0xf5, 0x8d, 0x6a, 0x90, 0x3f, 0xa3, 0x5c, 0x38, 0xcc, 0x57, 0x0b,
+	0x17, 0x71, 0x32, 0x44, 0x18, 0x80, 0x48, 0xc9, 0x9d, 0x7e, 0x28, 0xa9, 0xa8, 0x65, 0x2e, 0xbc,
+	0xfd, 0x29, 0x16, 0xf4, 0x2e, 0xd4, 0x06, 0x9c, 0x05, 0x01, 0x1d, 0x58, 0x13, 0xd4, 0xb2, 0x59,
+	0xe8, 0x4b, 0xa5, 0xe5, 0x35, 0x7c, 0x39, 0x9e, 0x6f, 0x8d, 0xa7, 0x77, 0xa3, 0x59, 0xf3, 0x3b,
+	0x03, 0x0a, 0x98, 0x0a, 0x16, 0x72, 0x9b, 0xce, 0x85, 0x66, 0xbc, 0xf6, 0xd0, 0xd2, 0x2f, 0x0d,
+	0xed, 0x57, 0x03, 0x2a, 0x49, 0x68, 0x87, 0x7a, 0x15, 0x74, 0x04, 0x05, 0x1e, 0x43, 0x2a, 0xdd,
+	0x2b, 0xc5, 0x97, 0x90, 0xe1, 0x31, 0x07, 0x7a, 0x0c, 0x6b, 0x22, 0x3a, 0xc3, 0xa4, 0x56, 0xd5,
+	0xd2, 0xf5, 0xcc, 0x6a, 0x77, 0x4f, 0x49, 0x20, 0x0e, 0x0f, 0x97, 0xc5, 0xd4, 0x08, 0x6d, 0x02,
+	0x08, 0xfb, 0x29, 0xf5, 0x88, 0x15, 0x72, 0x57, 0x55, 0x9d, 0x22, 0x2e, 0x6a, 0xe4, 0x94, 0xbb,
+	0xe6, 0xcf, 0x06, 0x94, 0xa7, 0xbd, 0x51, 0x0f, 0xb2, 0xca, 0x3f, 0xde, 0xd9, 0x87, 0xcb, 0x07,
+	0xb1, 0x48, 0x96, 0x58, 0x93, 0xa1, 0x7b, 0x90, 0x9f, 0xdd, 0xdc, 0xad, 0xe5, 0x79, 0x75, 0x64,
+	0x38, 0xef, 0x2d, 0xb7, 0xa3, 0xaf, 0x32, 0x90, 0xd3, 0x2e, 0x0b, 0xef, 0x44, 0x1d, 0x4a, 0x03,
+	0x2a, 0x6c, 0xee, 0x04, 0x72, 0x72, 0x2f, 0xa6, 0xa1, 0xc8, 0x2b, 0xf4, 0x1d, 0x19, 0x33, 0xab,
+	0x6f, 0xb4, 0x0f, 0xd9, 0x21, 0x09, 0x87, 0x49, 0xc1, 0xbd, 0xb9, 0x7c, 0xf4, 0xfb, 0x91, 0x5b,
+	0x27, 0x85, 0xb5, 0x3f, 0x6a, 0x41, 0x46, 0x84, 0x9e, 0xaa, 0x80, 0xa5, 0xe6, 0x5b, 0x2b, 0x9c,
+	0x70, 0xe8, 0x75, 0x52, 0x38, 0xf2, 0x45, 0x27, 0x50, 0x7c, 0xea, 0x08, 0xc9, 0x86, 0x9c, 0x78,
+	0xb5, 0xa2, 0x22, 0xba, 0xb3, 0x3c, 0x51, 0x27, 0x71, 0x8d, 0xde, 0xa6, 0x31, 0x0f, 0x3a, 0x84,
+	0xbc, 0x08, 0x3d, 0x8f, 0xf0, 0x51, 0xad, 0xa4, 0x28, 0x6f, 0xaf, 0x14, 0x5b, 0xe4, 0xd8, 0x49,
+	0xe1, 0x84, 0x63, 0x27, 0x07, 0x97, 0x06, 0x44, 0x12, 0xd3, 0x86, 0xac, 0x4a, 0x00, 0x7a, 0x04,
+	0xa5, 0x08, 0xb0, 0x02, 0xe6, 0xf8, 0x32, 0xb9, 0xd6, 0xef, 0x2d, 0xbf, 0xc6, 0x51, 0xe8, 0xf5,
+	0x29, 0xdf, 0x23, 0x92, 0x1c, 0x47, 0x0c, 0x18, 0x06, 0xc9, 0xa7, 0x30, 0xff, 0x30, 0x20, 0x73,
+	0x12, 0x7a, 0xaf, 0x73, 0x0d, 0x34, 0x82, 0x2b, 0x64, 0x38, 0xe4, 0x74, 0xa8, 0xb4, 0x6d, 0x49,
+	0xea, 0x05, 0x8c, 0x13, 0xd7, 0x91, 0x23, 0x25, 0xa1, 0xf5, 0xe6, 0x47, 0x2b, 0xd4, 0xf4, 0x09,
+	0x51, 0x6f, 0xc2, 0x83, 0x2f, 0x93, 0x85, 0x38, 0xba, 0x0e, 0x65, 0x47, 0x58, 0x1e, 0xf3, 0x99,
+	0x64, 0xbe, 0x63, 0x2b, 0x5d, 0x16, 0x70, 0xc9, 0x11, 0x87, 0x09, 0x64, 0xfe, 0x66, 0x40, 0x71,
+	0x7c, 0xb0, 0xe8, 0xf3, 0x45, 0x79, 0xf8, 0xe0, 0x02, 0x12, 0x79, 0xd3, 0x52, 0x61, 0x3e, 0x81,
+	0x7c, 0x2c, 0x36, 0xf4, 0x78, 0xd1, 0x26, 0xdf, 0x5f, 0x59, 0xb4, 0x8b, 0x15, 0xf5, 0x6d, 0x1a,
+	0x2a, 0x73, 0x6a, 0x98, 0x7b, 0x97, 0xf2, 0x7f, 0xcb, 0xbb, 0x74, 0x13, 0x36, 0x84, 0x24, 0x5c,
+	0x5a, 0xd2, 0xf1, 0xa8, 0x15, 0xfa, 0xce, 0xb9, 0xe5, 0x13, 0x9f, 0xa9, 0x3c, 0xe6, 0xf0, 0xbf,
+	0xd4, 0x5c, 0xcf, 0xf1, 0xe8, 0xa9, 0xef, 0x9c, 0x1f, 0x11, 0x9f, 0xa1, 0xff, 0xc2, 0xfa, 0x9c,
+	0x69, 0x46, 0x99, 0x96, 0xe5, 0xb4, 0xd5, 0x26, 0x14, 0x89, 0xb0, 0x74, 0xdb, 0x38, 0x6e, 0x23,
+	0x0b, 0x44, 0xec, 0x29, 0x04, 0x5d, 0x81, 0x1c, 0x11, 0x96, 0xe3, 0x4b, 0xd5, 0xe4, 0x55, 0xa3,
+	0xe2, 0x44, 0x44, 0xd7, 0x97, 0x68, 0x03, 0xb2, 0x4f, 0x5c, 0x32, 0x14, 0xb5, 0x82, 0x7a, 0x13,
+	0xf5, 0x60, 0xd2, 0x99, 0xfd, 0x94, 0x06, 0xf4, 0x57, 0x6d, 0xcc, 0x25, 0xa6, 0xf8, 0x26, 0x27,
+	0x66, 0x03, 0xb2, 0x93, 0x7e, 0x24, 0x87, 0xf5, 0x00, 0x55, 0x75, 0x4d, 0x8e, 0x4a, 0xbb, 0xa1,
+	0x4b, 0xec, 0x16, 0xac, 0xf5, 0x43, 0xfb, 0x19, 0x95, 0xba, 0x47, 0x10, 0xb5, 0x5c, 0x3d, 0x13,
+	0x91, 0x69, 0x50, 0x75, 0x06, 0x02, 0xdd, 0x80, 0x0a, 0x3d, 0x0f, 0x5c, 0xc7, 0x76, 0xa4, 0xd5,
+	0x67, 0xa1, 0x3f, 0xd0, 0xaa, 0x30, 0xf0, 0x7a, 0x02, 0xef, 0x28, 0x74, 0x92, 0x56, 0x98, 0x4a,
+	0xab, 0xf9, 0x63, 0x06, 0xaa, 0xf3, 0x22, 0xfc, 0x27, 0x89, 0x6c, 0xd9, 0x5c, 0x0a, 0xa8, 0x3c,
+	0x0f, 0x89, 0x2f, 0x9d, 0xe4, 0x8f, 0x8d, 0xce, 0x66, 0xa9, 0x79, 0xef, 0xe2, 0x97, 0x75, 0x5b,
+	0xed, 0xb2, 0x25, 0x3f, 0x8e, 0x89, 0xf1, 0x7a, 0xb2, 0x84, 0x9a, 0x10, 0x8b, 0x95, 0x7c, 0x75,
+	0x17, 0x2a, 0x73, 0x8e, 0xe8, 0x2a, 0x14, 0x12, 0x57, 0xd5, 0x26, 0x18, 0x78, 0x3c, 0x8e, 0x48,
+	0x26, 0x5d, 0xbb, 0x91, 0x74, 0xe0, 0x5f, 0x1a, 0x70, 0xad, 0x7d, 0x1e, 0x30, 0x2e, 0xe3, 0x96,
+	0xe9, 0x84, 0xf2, 0x33, 0xc7, 0xa6, 0x98, 0x3e, 0x0f, 0xa9, 0x90, 0x68, 0x00, 0xd5, 0xa4, 0xb3,
+	0x1b, 0x37, 0x74, 0x2b, 0x3f, 0x45, 0x73, 0x2d, 0x27, 0xae, 0xf0, 0x59, 0xc0, 0xfc, 0xc6, 0x80,
+	0x7f, 0x2f, 0x8e, 0x42, 0x04, 0xcc, 0x17, 0x14, 0xf9, 0x50, 0x09, 0x08, 0x97, 0x0e, 0x71, 0x2d,
+	0x11, 0xda, 0x36, 0x15, 0x22, 0xee, 0xe8, 0xda, 0xcb, 0x47, 0x31, 0xb3, 0xc0, 0xb1, 0x66, 0x3b,
+	0xd1, 0x64, 0x78, 0x3d, 0x98, 0x19, 0x9b, 0x12, 0xae, 0xbd, 0xc4, 0x1c, 0xdd, 0x82, 0x0d, 0x4e,
+	0xbf, 0xa0, 0xb6, 0xa4, 0x03, 0x6b, 0xb6, 0x6e, 0x1b, 0x8d, 0x0c, 0x46, 0xc9, 0xdc, 0xde, 0xe4,
+	0x99, 0xd9, 0x82, 0x35, 0xca, 0x39, 0xe3, 0x96, 0x47, 0x85, 0x20, 0x43, 0x1a, 0xb7, 0x6a, 0x65,
+	0x05, 0x1e, 0x6a, 0xec, 0xff, 0x5f, 0x1b, 0x70, 0x79, 0xf1, 0x1b, 0x82, 0x6e, 0xc0, 0x56, 0x6b,
+	0x7f, 0x1f, 0xb7, 0xf7, 0x5b, 0xbd, 0xee, 0x83, 0x23, 0xab, 0xd7, 0x3e, 0x3c, 0x7e, 0x80, 0x5b,
+	0x07, 0xdd, 0xde, 0x67, 0xd6, 0xe9, 0xd1, 0xc9, 0x71, 0x7b, 0xb7, 0x7b, 0xb7, 0xdb, 0xde, 0xab,
+	0xa6, 0xd0, 0x75, 0xd8, 0x7c, 0x91, 0xe1, 0x5e, 0xfb, 0xa0, 0xd7, 0xaa, 0x1a, 0xe8, 0x7f, 0x60,
+	0xbe, 0xc8, 0x64, 0xf7, 0xf4, 0xf0, 0xf4, 0xa0, 0xd5, 0xeb, 0x3e, 0x6c, 0x57, 0xd3, 0xcd, 0x1f,
+	0x0c, 0x58, 0x9f, 0x3d, 0x0f, 0xf4, 0xbd, 0x01, 0x39, 0x9d, 0x18, 0x74, 0xd1, 0xcc, 0xcf, 0x0a,
+	0xec, 0xea, 0xdd, 0x57, 0xa5, 0xd1, 0x0a, 0x31, 0x53, 0x3b, 0x85, 0x47, 0x39, 0xe5, 0x2c, 0xfa,
+	0xfa, 0xf7, 0xce, 0x9f, 0x03, 0x00, 0x85, 0xae, 0xbe, 0x8c, 0x34, 0x11, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MetricsServiceClient is the client API for MetricsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MetricsServiceClient interface {
+	// Exports a batch of resource metrics to the collector
+	Export(ctx context.Context, in *ExportMetricsServiceRequest, opts ...grpc.CallOption) (*ExportMetricsServiceResponse, error)
+}
+
+type metricsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMetricsServiceClient(cc *grpc.ClientConn) MetricsServiceClient {
+	return &metricsServiceClient{cc}
+}
+
+func (c *metricsServiceClient) Export(ctx context.Context, in *ExportMetricsServiceRequest, opts ...grpc.CallOption) (*ExportMetricsServiceResponse, error) {
+	out := new(ExportMetricsServiceResponse)
+	err := c.cc.Invoke(ctx, "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetricsServiceServer is the server API for MetricsService service.
+type MetricsServiceServer interface {
+	// Exports a batch of resource metrics to the collector
+	Export(context.Context, *ExportMetricsServiceRequest) (*ExportMetricsServiceResponse, error)
+}
+
+// UnimplementedMetricsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedMetricsServiceServer struct {
+}
+
+func (*UnimplementedMetricsServiceServer) Export(ctx context.Context, req *ExportMetricsServiceRequest) (*ExportMetricsServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+
+func RegisterMetricsServiceServer(s *grpc.Server, srv MetricsServiceServer) {
+	s.RegisterService(&_MetricsService_serviceDesc, srv)
+}
+
+func _MetricsService_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMetricsServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServiceServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServiceServer).Export(ctx, req.(*ExportMetricsServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MetricsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.metrics.v1.MetricsService",
+	HandlerType: (*MetricsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    _MetricsService_Export_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "otlp_metrics.proto",
+}
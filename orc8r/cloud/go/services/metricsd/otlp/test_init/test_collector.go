@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package test_init
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	otlpprotos "magma/orc8r/cloud/go/services/metricsd/otlp/protos"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestCollector is a stand-in for an OTLP collector which records all
+// received Export requests
+type TestCollector struct {
+	sync.Mutex
+	requests []*otlpprotos.ExportMetricsServiceRequest
+	attempts int
+	failures []codes.Code
+}
+
+// StartTestCollector starts an OTLP/gRPC test collector on a local port
+func StartTestCollector(t *testing.T) (net.Addr, *TestCollector) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen err: %v\n", err)
+	}
+	collector := &TestCollector{}
+	server := grpc.NewServer()
+	otlpprotos.RegisterMetricsServiceServer(server, collector)
+	go server.Serve(lis)
+	return lis.Addr(), collector
+}
+
+func (c *TestCollector) Export(
+	ctx context.Context,
+	req *otlpprotos.ExportMetricsServiceRequest,
+) (*otlpprotos.ExportMetricsServiceResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	c.attempts++
+	if len(c.failures) > 0 {
+		code := c.failures[0]
+		c.failures = c.failures[1:]
+		return nil, status.Errorf(code, "test collector failure")
+	}
+	c.requests = append(c.requests, req)
+	return &otlpprotos.ExportMetricsServiceResponse{}, nil
+}
+
+// FailNext makes the next Export requests fail with the given codes, one
+// code per request
+func (c *TestCollector) FailNext(codes ...codes.Code) {
+	c.Lock()
+	defer c.Unlock()
+	c.failures = append(c.failures, codes...)
+}
+
+// GetRequests returns the successfully received Export requests
+func (c *TestCollector) GetRequests() []*otlpprotos.ExportMetricsServiceRequest {
+	c.Lock()
+	defer c.Unlock()
+	return append([]*otlpprotos.ExportMetricsServiceRequest{}, c.requests...)
+}
+
+// GetAttempts returns the number of received Export requests, including the
+// failed ones
+func (c *TestCollector) GetAttempts() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.attempts
+}
@@ -61,7 +61,43 @@ func NewCustomPushExporter(pushAddresses []string) mxd_exp.Exporter {
 func (e *CustomPushExporter) Submit(metrics []mxd_exp.MetricAndContext) error {
 	e.Lock()
 	defer e.Unlock()
+	addMetricsToFamilies(e.familiesByName, metrics)
+	return nil
+}
+
+// Export pushes the metrics to all push addresses right away. Returns a
+// PermanentError if the pushgateways rejected the metrics.
+func (e *CustomPushExporter) Export(metrics []mxd_exp.MetricAndContext) error {
+	familiesByName := make(map[string]*io_prometheus_client.MetricFamily)
+	addMetricsToFamilies(familiesByName, metrics)
+	errs := e.pushFamilies(familiesByName)
+	if len(errs) == 0 {
+		return nil
+	}
+	err := fmt.Errorf("error in pushing to pushgateway: %v", errs)
+	for _, pushErr := range errs {
+		if !mxd_exp.IsPermanentError(pushErr) {
+			return err
+		}
+	}
+	return mxd_exp.NewPermanentError(err)
+}
 
+// SinkExporters returns an exporter for each push address, so buffered
+// metrics are only pushed again to the pushgateways which failed
+func (e *CustomPushExporter) SinkExporters() map[string]mxd_exp.SyncExporter {
+	sinkExporters := make(map[string]mxd_exp.SyncExporter, len(e.pushAddresses))
+	for _, address := range e.pushAddresses {
+		sinkExporters[address] = &CustomPushExporter{
+			familiesByName: make(map[string]*io_prometheus_client.MetricFamily),
+			exportInterval: e.exportInterval,
+			pushAddresses:  []string{address},
+		}
+	}
+	return sinkExporters
+}
+
+func addMetricsToFamilies(familiesByName map[string]*io_prometheus_client.MetricFamily, metrics []mxd_exp.MetricAndContext) {
 	for _, metricAndContext := range metrics {
 		// Don't register family if it has 0 metrics. Would cause prometheus scrape
 		// to fail.
@@ -86,14 +122,13 @@ func (e *CustomPushExporter) Submit(metrics []mxd_exp.MetricAndContext) error {
 					metric.TimestampMs = &timeStamp
 				}
 			}
-			if baseFamily, ok := e.familiesByName[familyName]; ok {
+			if baseFamily, ok := familiesByName[familyName]; ok {
 				addMetricsToFamily(baseFamily, fam)
 			} else {
-				e.familiesByName[familyName] = fam
+				familiesByName[familyName] = fam
 			}
 		}
 	}
-	return nil
 }
 
 // dropInvalidMetrics because invalid label names would cause the entire scrape
@@ -148,29 +183,29 @@ func (e *CustomPushExporter) exportEvery() {
 }
 
 func (e *CustomPushExporter) export() []error {
-	errs := e.pushFamilies()
+	e.Lock()
+	familiesByName := e.familiesByName
 	e.resetFamilies()
-	return errs
+	e.Unlock()
+	return e.pushFamilies(familiesByName)
 }
 
-func (e *CustomPushExporter) pushFamilies() []error {
+func (e *CustomPushExporter) pushFamilies(familiesByName map[string]*io_prometheus_client.MetricFamily) []error {
 	var errs []error
-	if len(e.familiesByName) == 0 {
+	if len(familiesByName) == 0 {
 		return []error{}
 	}
 	bodyBuilder := strings.Builder{}
 
-	e.Lock()
-	for _, fam := range e.familiesByName {
+	for _, fam := range familiesByName {
 		familyString, err := familyToString(fam)
 		if err != nil {
-			errs = append(errs, err)
+			errs = append(errs, mxd_exp.NewPermanentError(err))
 			continue
 		}
 		bodyBuilder.WriteString(familyString)
 		bodyBuilder.WriteString("\n")
 	}
-	e.Unlock()
 
 	body := bodyBuilder.String()
 	client := http.Client{}
@@ -180,15 +215,29 @@ func (e *CustomPushExporter) pushFamilies() []error {
 			errs = append(errs, fmt.Errorf("error making request: %v", err))
 			continue
 		}
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
-			respBody, _ := ioutil.ReadAll(resp.Body)
-			errs = append(errs, fmt.Errorf("error pushing to pushgateway %s: %v", address, string(respBody)))
-			continue
+			err = fmt.Errorf("error pushing to pushgateway %s: %v", address, string(respBody))
+			if isRejected(resp.StatusCode) {
+				err = mxd_exp.NewPermanentError(err)
+			}
+			errs = append(errs, err)
 		}
 	}
 	return errs
 }
 
+// isRejected returns true for the client error responses which mean that the
+// pushgateway won't accept the pushed metrics when they're pushed again
+func isRejected(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return statusCode >= 400 && statusCode < 500
+}
+
 func (e *CustomPushExporter) resetFamilies() {
 	e.familiesByName = make(map[string]*io_prometheus_client.MetricFamily)
 }
@@ -9,8 +9,12 @@
 package exporters
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
 	"magma/orc8r/cloud/go/metrics"
@@ -60,6 +64,79 @@ func TestNewCustomPushExporter(t *testing.T) {
 	}
 }
 
+func TestCustomPushExporter_Export(t *testing.T) {
+	var bodies []string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	exp := NewCustomPushExporter([]string{server.URL}).(*CustomPushExporter)
+
+	// metrics are pushed right away, without touching submitted metrics
+	assert.NoError(t, submitNewMetric(exp, dto.MetricType_COUNTER, sampleGatewayContext))
+	metrics := []exporters.MetricAndContext{{
+		Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+		Context: sampleGatewayContext,
+	}}
+	assert.NoError(t, exp.Export(metrics))
+	assert.Len(t, bodies, 1)
+	assert.True(t, strings.HasPrefix(bodies[0], "# HELP metric_A testFamilyHelp\n# TYPE metric_A gauge\nmetric_A{"))
+	assert.Equal(t, 1, totalMetricCount(exp))
+
+	status = http.StatusInternalServerError
+	metrics[0].Family = tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels)
+	err := exp.Export(metrics)
+	assert.Error(t, err)
+	assert.False(t, exporters.IsPermanentError(err))
+	assert.Len(t, bodies, 2)
+
+	// rejected metrics won't be accepted when they're pushed again
+	status = http.StatusBadRequest
+	metrics[0].Family = tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels)
+	err = exp.Export(metrics)
+	assert.True(t, exporters.IsPermanentError(err))
+	status = http.StatusTooManyRequests
+	metrics[0].Family = tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels)
+	err = exp.Export(metrics)
+	assert.Error(t, err)
+	assert.False(t, exporters.IsPermanentError(err))
+}
+
+func TestCustomPushExporter_SinkExporters(t *testing.T) {
+	pushes := map[string]int{}
+	var mu sync.Mutex
+	newServer := func(name string, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			pushes[name]++
+			mu.Unlock()
+			w.WriteHeader(status)
+		}))
+	}
+	healthy, failing := newServer("healthy", http.StatusOK), newServer("failing", http.StatusInternalServerError)
+	defer healthy.Close()
+	defer failing.Close()
+	exp := NewCustomPushExporter([]string{healthy.URL, failing.URL}).(*CustomPushExporter)
+
+	// every address gets its own exporter, so a retry only pushes to the
+	// failed address
+	sinkExporters := exp.SinkExporters()
+	assert.Len(t, sinkExporters, 2)
+	metrics := []exporters.MetricAndContext{{
+		Family:  tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels),
+		Context: sampleGatewayContext,
+	}}
+	assert.NoError(t, sinkExporters[healthy.URL].Export(metrics))
+	for i := 0; i < 2; i++ {
+		metrics[0].Family = tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, sampleLabels)
+		assert.Error(t, sinkExporters[failing.URL].Export(metrics))
+	}
+	assert.Equal(t, map[string]int{"healthy": 1, "failing": 2}, pushes)
+}
+
 func testSubmitGauge(t *testing.T) {
 	exp := makeTestCustomPushExporter()
 	err := submitNewMetric(&exp, dto.MetricType_GAUGE, sampleGatewayContext)
@@ -0,0 +1,299 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package relabel implements rules which rewrite or drop metric series
+// before they are exported, modelled after Prometheus' relabel_configs.
+package relabel
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Rule actions
+const (
+	// ActionKeep drops series whose source label value doesn't match the regex
+	ActionKeep = "keep"
+	// ActionDrop drops series whose source label value matches the regex
+	ActionDrop = "drop"
+	// ActionReplace sets the target label to the expanded replacement if the
+	// source label value matches the regex
+	ActionReplace = "replace"
+	// ActionHashMod sets the target label to the hash of the source label
+	// value modulo the modulus
+	ActionHashMod = "hashmod"
+)
+
+const defaultRegex = "(.*)"
+
+// Results of collapsing series
+const (
+	resultSummed   = "summed"
+	resultReplaced = "replaced"
+)
+
+var collapsedSeries = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "metricsd_relabel_collapsed_series",
+		Help: "Number of series collapsed into another series by relabel rules, by whether their values were summed or replaced",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(collapsedSeries)
+}
+
+// Rule is a relabel rule, which applies to every series of the metric
+// families it selects. A missing source label has an empty value.
+type Rule struct {
+	Action string
+	// MetricRegex selects the metric families the rule applies to, all
+	// families if empty
+	MetricRegex string
+	// SourceLabel is the label whose value is matched against Regex. A drop
+	// rule without a source label drops all series of the selected families.
+	SourceLabel string
+	// Regex is matched against the whole source label value, (.*) if empty
+	Regex string
+	// TargetLabel is the label set by replace and hashmod rules
+	TargetLabel string
+	// Replacement is the value of the target label set by replace rules, $1,
+	// $2... reference Regex's capture groups. Target labels with an empty
+	// value are removed.
+	Replacement string
+	// Modulus of hashmod rules
+	Modulus uint64
+}
+
+type compiledRule struct {
+	Rule
+	metricRegex *regexp.Regexp
+	regex       *regexp.Regexp
+}
+
+// Rules is a validated sequence of relabel rules
+type Rules []compiledRule
+
+// Compile validates the rules and compiles their regexes
+func Compile(rules []Rule) (Rules, error) {
+	ret := make(Rules, 0, len(rules))
+	for i, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid relabel rule %d: %v", i, err)
+		}
+		ret = append(ret, compiled)
+	}
+	return ret, nil
+}
+
+func compileRule(rule Rule) (compiledRule, error) {
+	ret := compiledRule{Rule: rule}
+	switch rule.Action {
+	case ActionKeep:
+		if len(rule.SourceLabel) == 0 {
+			return ret, errors.New("keep rules require a source label")
+		}
+	case ActionDrop:
+	case ActionReplace:
+		if len(rule.TargetLabel) == 0 {
+			return ret, errors.New("replace rules require a target label")
+		}
+	case ActionHashMod:
+		if len(rule.SourceLabel) == 0 || len(rule.TargetLabel) == 0 {
+			return ret, errors.New("hashmod rules require a source and a target label")
+		}
+		if rule.Modulus == 0 {
+			return ret, errors.New("hashmod rules require a positive modulus")
+		}
+	default:
+		return ret, fmt.Errorf("unknown action '%s'", rule.Action)
+	}
+
+	var err error
+	if len(rule.MetricRegex) > 0 {
+		if ret.metricRegex, err = compileAnchored(rule.MetricRegex); err != nil {
+			return ret, fmt.Errorf("invalid metric regex: %v", err)
+		}
+	}
+	regex := rule.Regex
+	if len(regex) == 0 {
+		regex = defaultRegex
+	}
+	if ret.regex, err = compileAnchored(regex); err != nil {
+		return ret, fmt.Errorf("invalid regex: %v", err)
+	}
+	return ret, nil
+}
+
+// compileAnchored compiles a regex which has to match the whole value
+func compileAnchored(regex string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + regex + ")$")
+}
+
+// Apply applies the rules in order to every series of the family named
+// metricName. Dropped series are removed from the family. Series which
+// became identical are collapsed into one: counter, gauge and untyped values
+// are summed, as are the counts, sums and buckets of histograms. Summaries,
+// and histograms whose buckets differ, can't be summed and keep the last
+// series. Each collapsed series is counted in metricsd_relabel_collapsed_series.
+func (rules Rules) Apply(metricName string, family *dto.MetricFamily) {
+	if len(rules) == 0 || family == nil {
+		return
+	}
+	var applicable Rules
+	for _, rule := range rules {
+		if rule.metricRegex == nil || rule.metricRegex.MatchString(metricName) {
+			applicable = append(applicable, rule)
+		}
+	}
+	if len(applicable) == 0 {
+		return
+	}
+
+	kept := make([]*dto.Metric, 0, len(family.Metric))
+	indexBySeries := map[string]int{}
+	for _, metric := range family.Metric {
+		if !applicable.applyToMetric(metric) {
+			continue
+		}
+		key := getSeriesKey(metric.Label)
+		if idx, ok := indexBySeries[key]; ok {
+			if mergeMetric(kept[idx], metric) {
+				collapsedSeries.WithLabelValues(resultSummed).Inc()
+			} else {
+				kept[idx] = metric
+				collapsedSeries.WithLabelValues(resultReplaced).Inc()
+			}
+			continue
+		}
+		indexBySeries[key] = len(kept)
+		kept = append(kept, metric)
+	}
+	family.Metric = kept
+}
+
+// applyToMetric relabels the metric, returns false if it is dropped
+func (rules Rules) applyToMetric(metric *dto.Metric) bool {
+	for _, rule := range rules {
+		value := getLabelValue(metric.Label, rule.SourceLabel)
+		switch rule.Action {
+		case ActionKeep:
+			if !rule.regex.MatchString(value) {
+				return false
+			}
+		case ActionDrop:
+			if len(rule.SourceLabel) == 0 || rule.regex.MatchString(value) {
+				return false
+			}
+		case ActionReplace:
+			match := rule.regex.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+			replaced := rule.regex.ExpandString(nil, rule.Replacement, value, match)
+			metric.Label = setLabel(metric.Label, rule.TargetLabel, string(replaced))
+		case ActionHashMod:
+			hash := md5.Sum([]byte(value))
+			mod := binary.BigEndian.Uint64(hash[8:]) % rule.Modulus
+			metric.Label = setLabel(metric.Label, rule.TargetLabel, strconv.FormatUint(mod, 10))
+		}
+	}
+	return true
+}
+
+// mergeMetric adds the values of from to into, returns false if they can't
+// be summed. The merged series keeps the latest timestamp.
+func mergeMetric(into, from *dto.Metric) bool {
+	switch {
+	case into.Counter != nil && from.Counter != nil:
+		into.Counter.Value = proto.Float64(into.Counter.GetValue() + from.Counter.GetValue())
+	case into.Gauge != nil && from.Gauge != nil:
+		into.Gauge.Value = proto.Float64(into.Gauge.GetValue() + from.Gauge.GetValue())
+	case into.Untyped != nil && from.Untyped != nil:
+		into.Untyped.Value = proto.Float64(into.Untyped.GetValue() + from.Untyped.GetValue())
+	case into.Histogram != nil && from.Histogram != nil:
+		if !mergeHistogram(into.Histogram, from.Histogram) {
+			return false
+		}
+	default:
+		return false
+	}
+	if from.GetTimestampMs() > into.GetTimestampMs() {
+		into.TimestampMs = from.TimestampMs
+	}
+	return true
+}
+
+func mergeHistogram(into, from *dto.Histogram) bool {
+	if len(into.Bucket) != len(from.Bucket) {
+		return false
+	}
+	for i, bucket := range into.Bucket {
+		if bucket.GetUpperBound() != from.Bucket[i].GetUpperBound() {
+			return false
+		}
+	}
+	for i, bucket := range into.Bucket {
+		bucket.CumulativeCount = proto.Uint64(bucket.GetCumulativeCount() + from.Bucket[i].GetCumulativeCount())
+	}
+	into.SampleCount = proto.Uint64(into.GetSampleCount() + from.GetSampleCount())
+	into.SampleSum = proto.Float64(into.GetSampleSum() + from.GetSampleSum())
+	return true
+}
+
+func getLabelValue(labels []*dto.LabelPair, name string) string {
+	if len(name) == 0 {
+		return ""
+	}
+	for _, label := range labels {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// setLabel sets the label's value, an empty value removes the label
+func setLabel(labels []*dto.LabelPair, name string, value string) []*dto.LabelPair {
+	for i, label := range labels {
+		if label.GetName() != name {
+			continue
+		}
+		if len(value) == 0 {
+			return append(labels[:i:i], labels[i+1:]...)
+		}
+		label.Value = &value
+		return labels
+	}
+	if len(value) == 0 {
+		return labels
+	}
+	return append(labels, &dto.LabelPair{Name: &name, Value: &value})
+}
+
+// getSeriesKey returns a string uniquely identifying a set of labels
+func getSeriesKey(labels []*dto.LabelPair) string {
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, strconv.Quote(label.GetName())+"="+strconv.Quote(label.GetValue()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package relabel_test
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/services/metricsd/relabel"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	_, err := relabel.Compile([]relabel.Rule{
+		{Action: relabel.ActionDrop, MetricRegex: "session_.*"},
+		{Action: relabel.ActionKeep, SourceLabel: "imsi", Regex: "IMSI00101.*"},
+		{Action: relabel.ActionReplace, SourceLabel: "imsi", TargetLabel: "imsi"},
+		{Action: relabel.ActionHashMod, SourceLabel: "imsi", TargetLabel: "shard", Modulus: 4},
+	})
+	assert.NoError(t, err)
+
+	_, err = relabel.Compile([]relabel.Rule{{Action: "rename"}})
+	assert.EqualError(t, err, "invalid relabel rule 0: unknown action 'rename'")
+	_, err = relabel.Compile([]relabel.Rule{{Action: relabel.ActionDrop}, {Action: relabel.ActionKeep}})
+	assert.EqualError(t, err, "invalid relabel rule 1: keep rules require a source label")
+	_, err = relabel.Compile([]relabel.Rule{{Action: relabel.ActionReplace, SourceLabel: "imsi"}})
+	assert.EqualError(t, err, "invalid relabel rule 0: replace rules require a target label")
+	_, err = relabel.Compile([]relabel.Rule{{Action: relabel.ActionHashMod, SourceLabel: "imsi", TargetLabel: "shard"}})
+	assert.EqualError(t, err, "invalid relabel rule 0: hashmod rules require a positive modulus")
+	_, err = relabel.Compile([]relabel.Rule{{Action: relabel.ActionDrop, MetricRegex: "("}})
+	assert.Error(t, err)
+	_, err = relabel.Compile([]relabel.Rule{{Action: relabel.ActionDrop, SourceLabel: "imsi", Regex: "("}})
+	assert.Error(t, err)
+}
+
+func TestRules_Apply(t *testing.T) {
+	// drop whole families
+	rules := mustCompile(t, relabel.Rule{Action: relabel.ActionDrop, MetricRegex: "session_.*"})
+	family := makeFamily(map[string]string{"imsi": "1"}, map[string]string{"imsi": "2"})
+	rules.Apply("session_bytes", family)
+	assert.Empty(t, family.Metric)
+	family = makeFamily(map[string]string{"imsi": "1"})
+	rules.Apply("enodeb_bytes", family)
+	assert.Len(t, family.Metric, 1)
+
+	// drop and keep matching series
+	rules = mustCompile(t, relabel.Rule{Action: relabel.ActionDrop, SourceLabel: "imsi", Regex: "2|3"})
+	family = makeFamily(map[string]string{"imsi": "1"}, map[string]string{"imsi": "2"}, map[string]string{"imsi": "23"})
+	rules.Apply("bytes", family)
+	assert.Equal(t, []map[string]string{{"imsi": "1"}, {"imsi": "23"}}, getLabels(family))
+
+	rules = mustCompile(t, relabel.Rule{Action: relabel.ActionKeep, SourceLabel: "imsi", Regex: "2.*"})
+	family = makeFamily(map[string]string{"imsi": "1"}, map[string]string{"imsi": "2"}, map[string]string{"gw": "g1"})
+	rules.Apply("bytes", family)
+	assert.Equal(t, []map[string]string{{"imsi": "2"}}, getLabels(family))
+
+	// replace, removing labels merges series
+	rules = mustCompile(t,
+		relabel.Rule{Action: relabel.ActionReplace, SourceLabel: "imsi", Regex: "IMSI(\\d{5}).*", TargetLabel: "plmn", Replacement: "$1"},
+		relabel.Rule{Action: relabel.ActionReplace, TargetLabel: "imsi"},
+	)
+	family = makeFamily(
+		map[string]string{"imsi": "IMSI001010000000001"},
+		map[string]string{"imsi": "IMSI001010000000002"},
+		map[string]string{"imsi": "IMSI001020000000001"},
+		map[string]string{"gw": "g1"},
+	)
+	family.Metric[0].Gauge.Value = proto.Float64(5)
+	rules.Apply("bytes", family)
+	assert.Equal(t, []map[string]string{{"plmn": "00101"}, {"plmn": "00102"}, {"gw": "g1"}}, getLabels(family))
+	assert.Equal(t, 6.0, family.Metric[0].GetGauge().GetValue())
+	assert.Equal(t, 2.0, family.Metric[1].GetGauge().GetValue())
+
+	// hashmod
+	rules = mustCompile(t,
+		relabel.Rule{Action: relabel.ActionHashMod, SourceLabel: "imsi", TargetLabel: "shard", Modulus: 8},
+		relabel.Rule{Action: relabel.ActionReplace, TargetLabel: "imsi"},
+	)
+	family = makeFamily(map[string]string{"imsi": "IMSI001010000000001"})
+	rules.Apply("bytes", family)
+	labels := getLabels(family)
+	assert.Len(t, labels, 1)
+	assert.Contains(t, []string{"0", "1", "2", "3", "4", "5", "6", "7"}, labels[0]["shard"])
+	family2 := makeFamily(map[string]string{"imsi": "IMSI001010000000001"})
+	rules.Apply("bytes", family2)
+	assert.Equal(t, labels, getLabels(family2))
+}
+
+func TestRules_Apply_Collapse(t *testing.T) {
+	rules := mustCompile(t, relabel.Rule{Action: relabel.ActionReplace, TargetLabel: "imsi"})
+
+	// counters are summed, keeping the latest timestamp
+	family := &dto.MetricFamily{
+		Name: proto.String("bytes"),
+		Type: dto.MetricType_COUNTER.Enum(),
+		Metric: []*dto.Metric{
+			makeMetric("1", &dto.Metric{Counter: &dto.Counter{Value: proto.Float64(2)}, TimestampMs: proto.Int64(20)}),
+			makeMetric("2", &dto.Metric{Counter: &dto.Counter{Value: proto.Float64(3)}, TimestampMs: proto.Int64(10)}),
+		},
+	}
+	rules.Apply("bytes", family)
+	assert.Len(t, family.Metric, 1)
+	assert.Equal(t, 5.0, family.Metric[0].GetCounter().GetValue())
+	assert.Equal(t, int64(20), family.Metric[0].GetTimestampMs())
+
+	// histograms with the same buckets are summed
+	family = &dto.MetricFamily{
+		Name: proto.String("latency"),
+		Type: dto.MetricType_HISTOGRAM.Enum(),
+		Metric: []*dto.Metric{
+			makeMetric("1", &dto.Metric{Histogram: makeHistogram(1, 2, 10)}),
+			makeMetric("2", &dto.Metric{Histogram: makeHistogram(3, 4, 10)}),
+			makeMetric("3", &dto.Metric{Histogram: makeHistogram(5, 6, 20)}),
+		},
+	}
+	rules.Apply("latency", family)
+	assert.Len(t, family.Metric, 1)
+	// the last histogram has different buckets and replaces the sum
+	assert.Equal(t, makeHistogram(5, 6, 20).String(), family.Metric[0].GetHistogram().String())
+
+	family.Metric = []*dto.Metric{
+		makeMetric("1", &dto.Metric{Histogram: makeHistogram(1, 2, 10)}),
+		makeMetric("2", &dto.Metric{Histogram: makeHistogram(3, 4, 10)}),
+	}
+	rules.Apply("latency", family)
+	assert.Len(t, family.Metric, 1)
+	assert.Equal(t, makeHistogram(4, 6, 10).String(), family.Metric[0].GetHistogram().String())
+
+	// summaries keep the last series
+	family = &dto.MetricFamily{
+		Name: proto.String("latency"),
+		Type: dto.MetricType_SUMMARY.Enum(),
+		Metric: []*dto.Metric{
+			makeMetric("1", &dto.Metric{Summary: &dto.Summary{SampleCount: proto.Uint64(1)}}),
+			makeMetric("2", &dto.Metric{Summary: &dto.Summary{SampleCount: proto.Uint64(2)}}),
+		},
+	}
+	rules.Apply("latency", family)
+	assert.Len(t, family.Metric, 1)
+	assert.Equal(t, uint64(2), family.Metric[0].GetSummary().GetSampleCount())
+}
+
+func makeMetric(imsi string, metric *dto.Metric) *dto.Metric {
+	metric.Label = []*dto.LabelPair{{Name: proto.String("imsi"), Value: proto.String(imsi)}}
+	return metric
+}
+
+func makeHistogram(count uint64, sum float64, upperBound float64) *dto.Histogram {
+	return &dto.Histogram{
+		SampleCount: proto.Uint64(count),
+		SampleSum:   proto.Float64(sum),
+		Bucket:      []*dto.Bucket{{CumulativeCount: proto.Uint64(count), UpperBound: proto.Float64(upperBound)}},
+	}
+}
+
+func mustCompile(t *testing.T, rules ...relabel.Rule) relabel.Rules {
+	compiled, err := relabel.Compile(rules)
+	assert.NoError(t, err)
+	return compiled
+}
+
+func makeFamily(labelSets ...map[string]string) *dto.MetricFamily {
+	family := &dto.MetricFamily{Name: proto.String("bytes"), Type: dto.MetricType_GAUGE.Enum()}
+	for i, labels := range labelSets {
+		metric := &dto.Metric{Gauge: &dto.Gauge{Value: proto.Float64(float64(i))}}
+		for name, value := range labels {
+			metric.Label = append(metric.Label, &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)})
+		}
+		family.Metric = append(family.Metric, metric)
+	}
+	return family
+}
+
+func getLabels(family *dto.MetricFamily) []map[string]string {
+	var ret []map[string]string
+	for _, metric := range family.Metric {
+		labels := map[string]string{}
+		for _, label := range metric.Label {
+			labels[label.GetName()] = label.GetValue()
+		}
+		ret = append(ret, labels)
+	}
+	return ret
+}
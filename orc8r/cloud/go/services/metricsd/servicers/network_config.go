@@ -0,0 +1,111 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"sync"
+	"time"
+
+	"magma/orc8r/cloud/go/clock"
+	merrors "magma/orc8r/cloud/go/errors"
+	"magma/orc8r/cloud/go/orc8r"
+	"magma/orc8r/cloud/go/pluginimpl/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/metricsd/relabel"
+
+	"github.com/golang/glog"
+	"golang.org/x/sync/singleflight"
+)
+
+// NetworkConfigCacheTTL is how long network metrics configs are cached before
+// being reloaded from configurator
+var NetworkConfigCacheTTL = time.Minute
+
+// networkMetricsConfig is a network's compiled metrics config
+type networkMetricsConfig struct {
+	rules     relabel.Rules
+	maxSeries uint32
+	loadedAt  time.Time
+}
+
+type networkConfigCache struct {
+	sync.Mutex
+	configs map[string]*networkMetricsConfig
+	// refreshing holds the networks whose stale config is being reloaded
+	refreshing map[string]bool
+	loads      singleflight.Group
+	load       func(networkID string) (*networkMetricsConfig, error)
+}
+
+func newNetworkConfigCache() *networkConfigCache {
+	return &networkConfigCache{
+		configs:    map[string]*networkMetricsConfig{},
+		refreshing: map[string]bool{},
+		load:       loadNetworkMetricsConfig,
+	}
+}
+
+// get returns the network's metrics config, networks without a config get an
+// empty one. The first get of a network waits for its config to load, with
+// concurrent loads of a network sharing a single request to configurator.
+// Once cached, stale configs keep being returned while they are reloaded in
+// the background. If the config can't be loaded, the previously loaded
+// config keeps being used until the next reload.
+func (c *networkConfigCache) get(networkID string) *networkMetricsConfig {
+	c.Lock()
+	cached, ok := c.configs[networkID]
+	if ok && clock.Now().Sub(cached.loadedAt) >= NetworkConfigCacheTTL && !c.refreshing[networkID] {
+		c.refreshing[networkID] = true
+		go c.reload(networkID)
+	}
+	c.Unlock()
+	if ok {
+		return cached
+	}
+	return c.reload(networkID)
+}
+
+// reload loads the network's config and caches it
+func (c *networkConfigCache) reload(networkID string) *networkMetricsConfig {
+	config, _, _ := c.loads.Do(networkID, func() (interface{}, error) {
+		now := clock.Now()
+		config, err := c.load(networkID)
+
+		c.Lock()
+		defer c.Unlock()
+		if err != nil {
+			glog.Errorf("Failed to load metrics config of network %s: %v", networkID, err)
+			config = &networkMetricsConfig{}
+			if cached, ok := c.configs[networkID]; ok {
+				config.rules, config.maxSeries = cached.rules, cached.maxSeries
+			}
+		}
+		config.loadedAt = now
+		c.configs[networkID] = config
+		delete(c.refreshing, networkID)
+		return config, nil
+	})
+	return config.(*networkMetricsConfig)
+}
+
+func loadNetworkMetricsConfig(networkID string) (*networkMetricsConfig, error) {
+	iConfig, err := configurator.LoadNetworkConfig(networkID, orc8r.MetricsdNetworkType)
+	if err == merrors.ErrNotFound {
+		return &networkMetricsConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	config := iConfig.(*models.NetworkMetricsConfig)
+	rules, err := relabel.Compile(config.ToRelabelRules())
+	if err != nil {
+		return nil, err
+	}
+	return &networkMetricsConfig{rules: rules, maxSeries: config.MaxSeries}, nil
+}
@@ -17,19 +17,26 @@ import (
 	"magma/orc8r/cloud/go/metrics"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/services/metricsd/cardinality"
 	"magma/orc8r/cloud/go/services/metricsd/exporters"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	prometheusProto "github.com/prometheus/client_model/go"
 	"golang.org/x/net/context"
 )
 
 type MetricsControllerServer struct {
-	exporters []exporters.Exporter
+	exporters      []exporters.Exporter
+	limiter        *cardinality.Limiter
+	networkConfigs *networkConfigCache
 }
 
 func NewMetricsControllerServer() *MetricsControllerServer {
-	return &MetricsControllerServer{}
+	return &MetricsControllerServer{
+		limiter:        cardinality.NewLimiter(),
+		networkConfigs: newNetworkConfigCache(),
+	}
 }
 
 func (srv *MetricsControllerServer) Push(ctx context.Context, in *protos.PushedMetricsContainer) (*protos.Void, error) {
@@ -37,13 +44,11 @@ func (srv *MetricsControllerServer) Push(ctx context.Context, in *protos.PushedM
 		return new(protos.Void), nil
 	}
 
-	for _, e := range srv.exporters {
-		metricsToSubmit := pushedMetricsToMetricsAndContext(in)
-		err := e.Submit(metricsToSubmit)
-		if err != nil {
-			glog.Error(err)
-		}
+	metricsToSubmit := srv.processNetworkMetrics(in.NetworkId, pushedMetricsToMetricsAndContext(in))
+	if len(metricsToSubmit) == 0 {
+		return new(protos.Void), nil
 	}
+	srv.submitToExporters(metricsToSubmit)
 	return new(protos.Void), nil
 }
 
@@ -59,14 +64,38 @@ func (srv *MetricsControllerServer) Collect(ctx context.Context, in *protos.Metr
 	}
 	glog.V(2).Infof("collecting %v metrics from gateway %v\n", len(in.Family), in.GatewayId)
 
-	metricsToSubmit := metricsContainerToMetricAndContexts(in, networkID, gatewayID)
-	for _, e := range srv.exporters {
-		err := e.Submit(metricsToSubmit)
+	metricsToSubmit := srv.processNetworkMetrics(networkID, metricsContainerToMetricAndContexts(in, networkID, gatewayID))
+	if len(metricsToSubmit) == 0 {
+		return new(protos.Void), nil
+	}
+	srv.submitToExporters(metricsToSubmit)
+	return new(protos.Void), nil
+}
+
+// submitToExporters gives each exporter its own copy of the metrics, since
+// exporters may modify and retain the families they are given
+func (srv *MetricsControllerServer) submitToExporters(metricsToSubmit []exporters.MetricAndContext) {
+	for i, e := range srv.exporters {
+		exporterMetrics := metricsToSubmit
+		if i < len(srv.exporters)-1 {
+			exporterMetrics = copyMetrics(metricsToSubmit)
+		}
+		err := e.Submit(exporterMetrics)
 		if err != nil {
 			glog.Error(err)
 		}
 	}
-	return new(protos.Void), nil
+}
+
+func copyMetrics(metrics []exporters.MetricAndContext) []exporters.MetricAndContext {
+	ret := make([]exporters.MetricAndContext, 0, len(metrics))
+	for _, metricAndContext := range metrics {
+		ret = append(ret, exporters.MetricAndContext{
+			Family:  proto.Clone(metricAndContext.Family).(*prometheusProto.MetricFamily),
+			Context: metricAndContext.Context,
+		})
+	}
+	return ret
 }
 
 // Pulls metrics off the given input channel and sends them to all exporters
@@ -104,6 +133,18 @@ func (srv *MetricsControllerServer) RegisterExporter(e exporters.Exporter) []exp
 	return srv.exporters
 }
 
+// processNetworkMetrics applies the network's relabel rules then its series
+// limit to the metrics, before they reach any exporter
+func (srv *MetricsControllerServer) processNetworkMetrics(networkID string, metricsToProcess []exporters.MetricAndContext) []exporters.MetricAndContext {
+	config := srv.networkConfigs.get(networkID)
+	if len(config.rules) > 0 {
+		for _, metricAndContext := range metricsToProcess {
+			config.rules.Apply(metricAndContext.Context.MetricName, metricAndContext.Family)
+		}
+	}
+	return srv.limiter.Filter(networkID, config.maxSeries, metricsToProcess)
+}
+
 func metricsContainerToMetricAndContexts(
 	in *protos.MetricsContainer,
 	networkID, gatewayID string,
@@ -1,12 +1,17 @@
 package servicers
 
 import (
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/metrics"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/metricsd/exporters"
 
+	"magma/orc8r/cloud/go/services/metricsd/relabel"
 	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
 
 	prometheusProto "github.com/prometheus/client_model/go"
@@ -78,3 +83,105 @@ func TestPushedMetricsToMetricsAndContext(t *testing.T) {
 	assert.True(t, tests.HasLabel(labels, metrics.NetworkLabelName, "testNetwork"))
 	assert.True(t, tests.HasLabel(labels, "labelName", "labelValue"))
 }
+
+func TestNetworkConfigCache(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	rules, err := relabel.Compile([]relabel.Rule{{Action: relabel.ActionDrop, MetricRegex: "session_.*"}})
+	assert.NoError(t, err)
+	var mu sync.Mutex
+	loads := 0
+	var loadErr error
+	release := make(chan struct{})
+	cache := newNetworkConfigCache()
+	cache.load = func(networkID string) (*networkMetricsConfig, error) {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		loads++
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return &networkMetricsConfig{rules: rules, maxSeries: 10}, nil
+	}
+	getLoads := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return loads
+	}
+
+	// concurrent first gets share a single load
+	configs := make(chan *networkMetricsConfig, 5)
+	for i := 0; i < 5; i++ {
+		go func() { configs <- cache.get("n1") }()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, uint32(10), (<-configs).maxSeries)
+	}
+	cache.get("n1")
+	assert.Equal(t, 1, getLoads())
+
+	// stale configs are returned while they reload, failed reloads keep the
+	// stale config and are cached
+	mu.Lock()
+	loadErr = errors.New("configurator unavailable")
+	mu.Unlock()
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).Add(NetworkConfigCacheTTL))
+	config := cache.get("n1")
+	assert.Equal(t, uint32(10), config.maxSeries)
+	waitForRefresh(cache, "n1")
+	assert.Equal(t, 2, getLoads())
+	config = cache.get("n1")
+	assert.Equal(t, uint32(10), config.maxSeries)
+	assert.Len(t, config.rules, 1)
+	assert.Equal(t, 2, getLoads())
+
+	// networks which never loaded get an empty config, also cached
+	config = cache.get("n2")
+	assert.Equal(t, uint32(0), config.maxSeries)
+	assert.Empty(t, config.rules)
+	cache.get("n2")
+	assert.Equal(t, 3, getLoads())
+}
+
+func waitForRefresh(cache *networkConfigCache, networkID string) {
+	for i := 0; i < 100; i++ {
+		cache.Lock()
+		refreshing := cache.refreshing[networkID]
+		cache.Unlock()
+		if !refreshing {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSubmitToExporters(t *testing.T) {
+	e1, e2 := &familyRecordingExporter{}, &familyRecordingExporter{}
+	srv := NewMetricsControllerServer()
+	srv.RegisterExporter(e1)
+	srv.RegisterExporter(e2)
+
+	testFamily := tests.MakeTestMetricFamily(prometheusProto.MetricType_GAUGE, 1, testLabels)
+	srv.submitToExporters([]exporters.MetricAndContext{{Family: testFamily}})
+	assert.Len(t, e1.families, 1)
+	assert.Len(t, e2.families, 1)
+	assert.Equal(t, e1.families[0].String(), e2.families[0].String())
+	assert.False(t, e1.families[0] == e2.families[0])
+}
+
+type familyRecordingExporter struct {
+	families []*prometheusProto.MetricFamily
+}
+
+func (e *familyRecordingExporter) Submit(metrics []exporters.MetricAndContext) error {
+	for _, metric := range metrics {
+		e.families = append(e.families, metric.Family)
+	}
+	return nil
+}
+
+func (e *familyRecordingExporter) Start() {}
@@ -20,6 +20,7 @@ import (
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/configurator"
 	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
 	"magma/orc8r/cloud/go/services/configurator/test_utils"
 	"magma/orc8r/cloud/go/services/device"
@@ -28,6 +29,7 @@ import (
 	"magma/orc8r/cloud/go/services/metricsd/servicers"
 	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
 
+	"github.com/go-openapi/swag"
 	"github.com/golang/glog"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
@@ -254,3 +256,48 @@ func TestPush(t *testing.T) {
 	assert.Equal(t, timestamp, e.queue[0].TimestampMs())
 	assert.Equal(t, strconv.FormatFloat(value, 'f', -1, 64), e.queue[0].Value())
 }
+
+func TestPush_NetworkMetricsConfig(t *testing.T) {
+	deviceTestInit.StartTestService(t)
+	configuratorTestInit.StartTestService(t)
+	_ = serde.RegisterSerdes(configurator.NewNetworkConfigSerde(orc8r.MetricsdNetworkType, &models.NetworkMetricsConfig{}))
+
+	e := &testMetricExporter{}
+	ctx := context.Background()
+	srv := servicers.NewMetricsControllerServer()
+	srv.RegisterExporter(e)
+
+	networkID := "metricsd_servicer_test_network"
+	test_utils.RegisterNetwork(t, networkID, "Test Network Name")
+	err := configurator.UpdateNetworkConfig(networkID, orc8r.MetricsdNetworkType, &models.NetworkMetricsConfig{
+		MaxSeries: 2,
+		RelabelRules: []*models.MetricsRelabelRule{
+			{Action: swag.String("drop"), MetricRegex: "session_.*"},
+			{Action: swag.String("replace"), SourceLabel: "imsi", Regex: "IMSI(\\d{5}).*", TargetLabel: "plmn", Replacement: "$1"},
+			{Action: swag.String("replace"), TargetLabel: "imsi"},
+		},
+	})
+	assert.NoError(t, err)
+
+	pushedMetrics := protos.PushedMetricsContainer{
+		NetworkId: networkID,
+		Metrics: []*protos.PushedMetric{
+			{MetricName: "session_bytes", Value: 1, Labels: []*protos.LabelPair{{Name: "imsi", Value: "IMSI001010000000001"}}},
+			{MetricName: "ue_bytes", Value: 1, Labels: []*protos.LabelPair{{Name: "imsi", Value: "IMSI001010000000001"}}},
+			{MetricName: "ue_bytes", Value: 2, Labels: []*protos.LabelPair{{Name: "imsi", Value: "IMSI001020000000001"}}},
+			{MetricName: "ue_bytes", Value: 3, Labels: []*protos.LabelPair{{Name: "imsi", Value: "IMSI001030000000001"}}},
+		},
+	}
+	_, err = srv.Push(ctx, &pushedMetrics)
+	assert.NoError(t, err)
+
+	// session metrics are dropped, IMSIs are replaced by PLMNs and the third
+	// series exceeds the limit
+	assert.Equal(t, 2, len(e.queue))
+	for i, plmn := range []string{"00101", "00102"} {
+		assert.Equal(t, "ue_bytes", e.queue[i].Name())
+		assert.True(t, tests.HasLabel(e.queue[i].Labels(), "plmn", plmn))
+		assert.False(t, tests.HasLabelName(e.queue[i].Labels(), "imsi"))
+		assert.True(t, tests.HasLabel(e.queue[i].Labels(), metrics.NetworkLabelName, networkID))
+	}
+}
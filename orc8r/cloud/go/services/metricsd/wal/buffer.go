@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package wal
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+
+	"github.com/golang/glog"
+)
+
+var (
+	// DeliveryBatchSize is the max number of log records delivered to an
+	// exporter at once
+	DeliveryBatchSize = 100
+	// PollInterval is the delay between checks for new records once an
+	// exporter caught up with the log
+	PollInterval = time.Second * 5
+	// InitialRetryBackoff is the delay before the first redelivery after a
+	// failure, it doubles with every failure up to MaxRetryBackoff
+	InitialRetryBackoff = time.Second
+	MaxRetryBackoff     = time.Minute * 2
+)
+
+var nonCursorNameChars = regexp.MustCompile("[^a-zA-Z0-9_-]")
+
+// CursorName returns the name with all characters which can't be used in
+// cursor names replaced by underscores
+func CursorName(name string) string {
+	return nonCursorNameChars.ReplaceAllString(name, "_")
+}
+
+type bufferedExporter struct {
+	name     string
+	exporter exporters.Exporter
+}
+
+// Buffer is an Exporter which persists submitted metrics in the write-ahead
+// log and delivers them from there to the buffered exporters. Every exporter
+// reads the log through its own cursor, so metrics are kept until they are
+// delivered and are replayed once an exporter recovers from an outage.
+//
+// Sync exporters (see exporters.SyncExporter) get the metrics with Export,
+// and the metrics are kept until the datasink accepts them. Other exporters
+// get them with Submit, which only protects the metrics until the exporter
+// takes them over. Metrics which the datasink permanently rejects (see
+// exporters.PermanentError) are skipped instead of retried.
+type Buffer struct {
+	log       *Log
+	exporters []bufferedExporter
+}
+
+func NewBuffer(log *Log) *Buffer {
+	return &Buffer{log: log}
+}
+
+// AddExporter buffers metrics submitted for the exporter, name identifies the
+// exporter's log cursor and has to be stable across restarts. Exporters have
+// to be added before Start. Every datasink of an exporters.SinkSplitter gets
+// its own cursor.
+func (b *Buffer) AddExporter(name string, exporter exporters.Exporter) error {
+	if splitter, ok := exporter.(exporters.SinkSplitter); ok {
+		sinkExporters := splitter.SinkExporters()
+		sinkNames := make([]string, 0, len(sinkExporters))
+		for sinkName := range sinkExporters {
+			sinkNames = append(sinkNames, sinkName)
+		}
+		sort.Strings(sinkNames)
+		for _, sinkName := range sinkNames {
+			cursorName := fmt.Sprintf("%s_%s", name, CursorName(sinkName))
+			if err := b.addExporter(cursorName, sinkExporters[sinkName]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return b.addExporter(name, exporter)
+}
+
+func (b *Buffer) addExporter(name string, exporter exporters.Exporter) error {
+	for _, buffered := range b.exporters {
+		if buffered.name == name {
+			return fmt.Errorf("exporter %s is already buffered", name)
+		}
+	}
+	if err := b.log.RegisterCursor(name); err != nil {
+		return err
+	}
+	b.exporters = append(b.exporters, bufferedExporter{name: name, exporter: exporter})
+	return nil
+}
+
+// Submit appends the metrics to the log
+func (b *Buffer) Submit(metrics []exporters.MetricAndContext) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	data, err := encodeRecord(metrics)
+	if err != nil {
+		return err
+	}
+	_, err = b.log.Append(data)
+	return err
+}
+
+// Start runs the delivery of buffered metrics to every exporter in its own
+// goroutine
+func (b *Buffer) Start() {
+	for _, buffered := range b.exporters {
+		go b.deliverForever(buffered)
+	}
+}
+
+func (b *Buffer) deliverForever(buffered bufferedExporter) {
+	backoff := InitialRetryBackoff
+	for {
+		delivered, err := b.deliver(buffered)
+		if err != nil {
+			glog.Errorf("Error delivering buffered metrics to exporter %s, retrying in %v: %v", buffered.name, backoff, err)
+			deliveryFailures.WithLabelValues(buffered.name).Inc()
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > MaxRetryBackoff {
+				backoff = MaxRetryBackoff
+			}
+			continue
+		}
+		backoff = InitialRetryBackoff
+		if delivered == 0 {
+			time.Sleep(PollInterval)
+		}
+	}
+}
+
+// deliver sends the next batch of records following the exporter's cursor to
+// the exporter, and moves the cursor past them once the exporter accepts
+// or permanently rejects them. Returns the number of delivered records.
+func (b *Buffer) deliver(buffered bufferedExporter) (int, error) {
+	records, err := b.log.Read(buffered.name, DeliveryBatchSize)
+	if err != nil || len(records) == 0 {
+		return 0, err
+	}
+	var metrics []exporters.MetricAndContext
+	for _, record := range records {
+		decoded, err := decodeRecord(record.Data)
+		if err != nil {
+			glog.Errorf("Dropping undecodable log record %d: %v", record.Seq, err)
+			continue
+		}
+		metrics = append(metrics, decoded...)
+	}
+	if len(metrics) > 0 {
+		if syncExporter, ok := buffered.exporter.(exporters.SyncExporter); ok {
+			err = syncExporter.Export(metrics)
+		} else {
+			err = buffered.exporter.Submit(metrics)
+		}
+		if exporters.IsPermanentError(err) {
+			glog.Errorf("Skipping %d log records rejected by exporter %s: %v", len(records), buffered.name, err)
+			rejectedRecords.WithLabelValues(buffered.name).Add(float64(len(records)))
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	return len(records), b.log.Commit(buffered.name, records[len(records)-1].Seq)
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package wal
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+	tests "magma/orc8r/cloud/go/services/metricsd/test_common"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMetrics = []exporters.MetricAndContext{
+	{
+		Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, []*dto.LabelPair{}),
+		Context: exporters.MetricsContext{
+			MetricName:        "cloud_metric",
+			AdditionalContext: &exporters.CloudMetricContext{CloudHost: "host1"},
+		},
+	},
+	{
+		Family: tests.MakeTestMetricFamily(dto.MetricType_COUNTER, 2, []*dto.LabelPair{}),
+		Context: exporters.MetricsContext{
+			MetricName:        "gateway_metric",
+			AdditionalContext: &exporters.GatewayMetricContext{NetworkID: "nw1", GatewayID: "gw1"},
+		},
+	},
+	{
+		Family: tests.MakeTestMetricFamily(dto.MetricType_GAUGE, 1, []*dto.LabelPair{}),
+		Context: exporters.MetricsContext{
+			MetricName:        "pushed_metric",
+			AdditionalContext: &exporters.PushedMetricContext{NetworkID: "nw1"},
+		},
+	},
+}
+
+func TestEncodeDecodeRecord(t *testing.T) {
+	data, err := encodeRecord(testMetrics)
+	assert.NoError(t, err)
+	decoded, err := decodeRecord(data)
+	assert.NoError(t, err)
+	assertMetrics(t, testMetrics, decoded)
+
+	_, err = decodeRecord([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestBuffer_Deliver(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+	log, err := Open(dir, 1<<20)
+	assert.NoError(t, err)
+
+	syncExporter := &testExporter{}
+	asyncExporter := &testAsyncExporter{}
+	buffer := NewBuffer(log)
+	assert.NoError(t, buffer.AddExporter("sync", syncExporter))
+	assert.NoError(t, buffer.AddExporter("async", asyncExporter))
+	assert.EqualError(t, buffer.AddExporter("sync", syncExporter), "exporter sync is already buffered")
+
+	assert.NoError(t, buffer.Submit(testMetrics[:1]))
+	assert.NoError(t, buffer.Submit(testMetrics[1:]))
+
+	// sync exporters get the metrics with Export, others with Submit
+	delivered, err := buffer.deliver(buffer.exporters[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	assertMetrics(t, testMetrics, syncExporter.exported)
+	assert.Empty(t, syncExporter.submitted)
+	delivered, err = buffer.deliver(buffer.exporters[1])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	assertMetrics(t, testMetrics, asyncExporter.submitted)
+
+	// nothing left
+	delivered, err = buffer.deliver(buffer.exporters[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+
+	// failed deliveries are retried
+	syncExporter.reset(errors.New("datasink is down"))
+	assert.NoError(t, buffer.Submit(testMetrics))
+	_, err = buffer.deliver(buffer.exporters[0])
+	assert.EqualError(t, err, "datasink is down")
+	assert.Equal(t, uint64(1), log.Lag("sync"))
+	_, err = buffer.deliver(buffer.exporters[0])
+	assert.Error(t, err)
+
+	syncExporter.reset(nil)
+	delivered, err = buffer.deliver(buffer.exporters[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assertMetrics(t, testMetrics, syncExporter.exported)
+	assert.Equal(t, uint64(0), log.Lag("sync"))
+	assert.Equal(t, uint64(1), log.Lag("async"))
+}
+
+func TestBuffer_PermanentErrors(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+	log, err := Open(dir, 1<<20)
+	assert.NoError(t, err)
+
+	exporter := &testExporter{}
+	buffer := NewBuffer(log)
+	assert.NoError(t, buffer.AddExporter("sync", exporter))
+
+	// rejected records are skipped instead of blocking the cursor
+	exporter.reset(exporters.NewPermanentError(errors.New("invalid metrics")))
+	assert.NoError(t, buffer.Submit(testMetrics))
+	delivered, err := buffer.deliver(buffer.exporters[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, uint64(0), log.Lag("sync"))
+
+	exporter.reset(nil)
+	assert.NoError(t, buffer.Submit(testMetrics[:1]))
+	delivered, err = buffer.deliver(buffer.exporters[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assertMetrics(t, testMetrics[:1], exporter.exported)
+}
+
+func TestBuffer_SinkSplitter(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+	log, err := Open(dir, 1<<20)
+	assert.NoError(t, err)
+
+	sink1, sink2 := &testExporter{}, &testExporter{}
+	buffer := NewBuffer(log)
+	splitter := &testSplitExporter{sinks: map[string]exporters.SyncExporter{"http://sink1:9091": sink1, "sink2": sink2}}
+	assert.NoError(t, buffer.AddExporter("split", splitter))
+	assert.Len(t, buffer.exporters, 2)
+	assert.Equal(t, "split_http___sink1_9091", buffer.exporters[0].name)
+	assert.Equal(t, "split_sink2", buffer.exporters[1].name)
+
+	// a failing sink doesn't cause redelivery to the healthy one
+	sink2.reset(errors.New("sink2 is down"))
+	assert.NoError(t, buffer.Submit(testMetrics))
+	delivered, err := buffer.deliver(buffer.exporters[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	_, err = buffer.deliver(buffer.exporters[1])
+	assert.Error(t, err)
+
+	sink2.reset(nil)
+	delivered, err = buffer.deliver(buffer.exporters[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	delivered, err = buffer.deliver(buffer.exporters[1])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assertMetrics(t, testMetrics, sink1.exported)
+	assertMetrics(t, testMetrics, sink2.exported)
+}
+
+func TestBuffer_ReplayAfterRestart(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	log, err := Open(dir, 1<<20)
+	assert.NoError(t, err)
+	buffer := NewBuffer(log)
+	assert.NoError(t, buffer.AddExporter("sync", &testExporter{}))
+	assert.NoError(t, buffer.Submit(testMetrics))
+	assert.NoError(t, log.Close())
+
+	// the metrics are delivered by the next metricsd instance
+	log, err = Open(dir, 1<<20)
+	assert.NoError(t, err)
+	exporter := &testExporter{}
+	buffer = NewBuffer(log)
+	assert.NoError(t, buffer.AddExporter("sync", exporter))
+	buffer.Start()
+	for i := 0; i < 100 && log.Lag("sync") > 0; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Equal(t, uint64(0), log.Lag("sync"))
+	assertMetrics(t, testMetrics, exporter.getExported())
+}
+
+// testExporter is a sync exporter, which fails to export while err is set
+type testExporter struct {
+	sync.Mutex
+	submitted []exporters.MetricAndContext
+	exported  []exporters.MetricAndContext
+	err       error
+}
+
+func (e *testExporter) Submit(metrics []exporters.MetricAndContext) error {
+	e.Lock()
+	defer e.Unlock()
+	e.submitted = append(e.submitted, metrics...)
+	return nil
+}
+
+func (e *testExporter) Export(metrics []exporters.MetricAndContext) error {
+	e.Lock()
+	defer e.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	e.exported = append(e.exported, metrics...)
+	return nil
+}
+
+func (e *testExporter) Start() {}
+
+func (e *testExporter) reset(err error) {
+	e.Lock()
+	defer e.Unlock()
+	e.submitted, e.exported, e.err = nil, nil, err
+}
+
+func (e *testExporter) getExported() []exporters.MetricAndContext {
+	e.Lock()
+	defer e.Unlock()
+	return e.exported
+}
+
+type testSplitExporter struct {
+	testExporter
+	sinks map[string]exporters.SyncExporter
+}
+
+func (e *testSplitExporter) SinkExporters() map[string]exporters.SyncExporter {
+	return e.sinks
+}
+
+type testAsyncExporter struct {
+	submitted []exporters.MetricAndContext
+}
+
+func (e *testAsyncExporter) Submit(metrics []exporters.MetricAndContext) error {
+	e.submitted = append(e.submitted, metrics...)
+	return nil
+}
+
+func (e *testAsyncExporter) Start() {}
+
+func assertMetrics(t *testing.T, expected []exporters.MetricAndContext, actual []exporters.MetricAndContext) {
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	for i := range expected {
+		assert.Equal(t, expected[i].Context, actual[i].Context)
+		assert.Equal(t, protos.TestMarshal(expected[i].Family), protos.TestMarshal(actual[i].Family))
+	}
+}
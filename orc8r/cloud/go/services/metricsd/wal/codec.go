@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package wal
+
+import (
+	"fmt"
+
+	"magma/orc8r/cloud/go/services/metricsd/exporters"
+	walprotos "magma/orc8r/cloud/go/services/metricsd/wal/protos"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// encodeRecord marshals a batch of submitted metrics into a log record
+func encodeRecord(metrics []exporters.MetricAndContext) ([]byte, error) {
+	record := &walprotos.Record{}
+	for _, metricAndContext := range metrics {
+		family, err := proto.Marshal(metricAndContext.Family)
+		if err != nil {
+			return nil, fmt.Errorf("MetricFamily Marshal error: %s", err)
+		}
+		encoded := &walprotos.MetricAndContext{
+			MetricName: metricAndContext.Context.MetricName,
+			Family:     family,
+		}
+		switch ctx := metricAndContext.Context.AdditionalContext.(type) {
+		case *exporters.CloudMetricContext:
+			encoded.Context = &walprotos.MetricAndContext_Cloud{
+				Cloud: &walprotos.CloudMetricContext{CloudHost: ctx.CloudHost},
+			}
+		case *exporters.GatewayMetricContext:
+			encoded.Context = &walprotos.MetricAndContext_Gateway{
+				Gateway: &walprotos.GatewayMetricContext{NetworkId: ctx.NetworkID, GatewayId: ctx.GatewayID},
+			}
+		case *exporters.PushedMetricContext:
+			encoded.Context = &walprotos.MetricAndContext_Pushed{
+				Pushed: &walprotos.PushedMetricContext{NetworkId: ctx.NetworkID},
+			}
+		}
+		record.Metrics = append(record.Metrics, encoded)
+	}
+	return proto.Marshal(record)
+}
+
+// decodeRecord unmarshals a log record into the batch of metrics it keeps
+func decodeRecord(data []byte) ([]exporters.MetricAndContext, error) {
+	record := &walprotos.Record{}
+	if err := proto.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("Record Unmarshal error: %s", err)
+	}
+	ret := make([]exporters.MetricAndContext, 0, len(record.Metrics))
+	for _, encoded := range record.Metrics {
+		family := &dto.MetricFamily{}
+		if err := proto.Unmarshal(encoded.Family, family); err != nil {
+			return nil, fmt.Errorf("MetricFamily Unmarshal error: %s", err)
+		}
+		metricAndContext := exporters.MetricAndContext{
+			Family:  family,
+			Context: exporters.MetricsContext{MetricName: encoded.MetricName},
+		}
+		switch ctx := encoded.Context.(type) {
+		case *walprotos.MetricAndContext_Cloud:
+			metricAndContext.Context.AdditionalContext = &exporters.CloudMetricContext{
+				CloudHost: ctx.Cloud.CloudHost,
+			}
+		case *walprotos.MetricAndContext_Gateway:
+			metricAndContext.Context.AdditionalContext = &exporters.GatewayMetricContext{
+				NetworkID: ctx.Gateway.NetworkId,
+				GatewayID: ctx.Gateway.GatewayId,
+			}
+		case *walprotos.MetricAndContext_Pushed:
+			metricAndContext.Context.AdditionalContext = &exporters.PushedMetricContext{
+				NetworkID: ctx.Pushed.NetworkId,
+			}
+		}
+		ret = append(ret, metricAndContext)
+	}
+	return ret, nil
+}
@@ -0,0 +1,493 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package wal implements a bounded on-disk write-ahead log which buffers
+// metrics between the metricsd servicer and its exporters.
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+const (
+	segmentSuffix = ".wal"
+	cursorSuffix  = ".cursor"
+
+	// every record starts with its length and CRC32 checksum
+	recordHeaderSize = 8
+	maxRecordSize    = 64 << 20
+)
+
+var cursorNameRegex = regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+
+// MaxSegmentBytes is the size after which the log starts a new segment file.
+// Segments are the unit of truncation, a segment is removed once all cursors
+// moved past it or when the log exceeds its size limit.
+var MaxSegmentBytes int64 = 8 << 20
+
+// Record is a single log entry
+type Record struct {
+	Seq  uint64
+	Data []byte
+}
+
+type segment struct {
+	firstSeq uint64
+	path     string
+	size     int64
+}
+
+// position caches the file offset of a cursor to avoid rescanning segments
+type position struct {
+	seq      uint64
+	segSeq   uint64
+	offset   int64
+	hasValue bool
+}
+
+// Log is a sequence of records kept in segment files of a directory. Records
+// are read through named cursors, each reader (exporter) advances its own
+// cursor as it consumes records. Cursors are persisted, so readers continue
+// where they left off after restarts.
+// Appends aren't fsynced, records survive metricsd restarts but may be lost
+// if the host crashes.
+type Log struct {
+	dir      string
+	maxBytes int64
+
+	segments   []*segment
+	active     *os.File
+	nextSeq    uint64
+	totalBytes int64
+
+	// cursors of registered readers, only these keep records from removal
+	cursors map[string]uint64
+	// savedCursors are the persisted cursors found when opening the log
+	savedCursors map[string]uint64
+	positions    map[string]position
+	sync.Mutex
+}
+
+// Open opens the log in dir, creating it if it doesn't exist. When the log
+// grows over maxBytes, its oldest segments are dropped.
+func Open(dir string, maxBytes int64) (*Log, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("invalid log size limit: %d", maxBytes)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory %s: %v", dir, err)
+	}
+	l := &Log{
+		dir:          dir,
+		maxBytes:     maxBytes,
+		cursors:      map[string]uint64{},
+		savedCursors: map[string]uint64{},
+		positions:    map[string]position{},
+	}
+	if err := l.loadSegments(); err != nil {
+		return nil, err
+	}
+	if err := l.loadCursors(); err != nil {
+		return nil, err
+	}
+	l.updateGauges()
+	return l, nil
+}
+
+// Close closes the active segment, the log can't be used afterwards
+func (l *Log) Close() error {
+	l.Lock()
+	defer l.Unlock()
+	return l.active.Close()
+}
+
+// RegisterCursor adds a named cursor to the log. A persisted cursor continues
+// where it left off, a new one starts at the oldest record of the log.
+func (l *Log) RegisterCursor(name string) error {
+	if !cursorNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid cursor name: '%s'", name)
+	}
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := l.cursors[name]; ok {
+		return nil
+	}
+	firstSeq := l.segments[0].firstSeq
+	seq, ok := l.savedCursors[name]
+	if !ok || seq < firstSeq {
+		seq = firstSeq
+	}
+	if seq > l.nextSeq {
+		seq = l.nextSeq
+	}
+	l.cursors[name] = seq
+	l.updateGauges()
+	return l.writeCursor(name)
+}
+
+// Append adds a record to the end of the log and returns its sequence number
+func (l *Log) Append(data []byte) (uint64, error) {
+	if len(data) > maxRecordSize {
+		return 0, fmt.Errorf("record size %d exceeds the max record size", len(data))
+	}
+	l.Lock()
+	defer l.Unlock()
+
+	active := l.segments[len(l.segments)-1]
+	if active.size >= MaxSegmentBytes {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+		active = l.segments[len(l.segments)-1]
+	}
+	buf := make([]byte, recordHeaderSize+len(data))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(data))
+	copy(buf[recordHeaderSize:], data)
+	n, err := l.active.Write(buf)
+	if err != nil {
+		// drop the partially written record, so it's not read as a valid one
+		if truncErr := l.active.Truncate(active.size); truncErr != nil {
+			glog.Errorf("Failed to truncate log segment %s: %v", active.path, truncErr)
+		}
+		return 0, fmt.Errorf("failed to append log record: %v", err)
+	}
+	active.size += int64(n)
+	l.totalBytes += int64(n)
+	seq := l.nextSeq
+	l.nextSeq++
+	appendedRecords.Inc()
+
+	l.enforceSizeLimit()
+	l.updateGauges()
+	return seq, nil
+}
+
+// Read returns up to maxRecords records following the cursor, oldest first.
+// Reading doesn't move the cursor, see Commit.
+func (l *Log) Read(cursor string, maxRecords int) ([]Record, error) {
+	l.Lock()
+	defer l.Unlock()
+	seq, ok := l.cursors[cursor]
+	if !ok {
+		return nil, fmt.Errorf("unknown cursor %s", cursor)
+	}
+
+	var records []Record
+	pos := l.positions[cursor]
+	if !pos.hasValue || pos.seq != seq || l.getSegment(pos.segSeq) < 0 {
+		firstSeq := l.segments[l.findSegment(seq)].firstSeq
+		pos = position{seq: firstSeq, segSeq: firstSeq}
+	}
+	for segIdx := l.getSegment(pos.segSeq); segIdx < len(l.segments) && len(records) < maxRecords; segIdx++ {
+		seg := l.segments[segIdx]
+		if seg.firstSeq != pos.segSeq {
+			pos = position{seq: seg.firstSeq, segSeq: seg.firstSeq}
+		}
+		endSeq := l.getSegmentEnd(segIdx)
+		segRecords, endPos, err := readSegment(seg, pos, seq, endSeq, maxRecords-len(records))
+		if err != nil {
+			// the rest of the segment is unreadable, skip to the next one
+			glog.Errorf("Skipping records %d-%d of log segment %s: %v", endPos.seq, endSeq-1, seg.path, err)
+			records = append(records, segRecords...)
+			continue
+		}
+		records = append(records, segRecords...)
+		pos = endPos
+		if pos.seq < endSeq {
+			break
+		}
+	}
+	if len(records) > 0 {
+		pos.hasValue = true
+		l.positions[cursor] = pos
+	}
+	return records, nil
+}
+
+// Commit moves the cursor past the record with sequence number seq, segments
+// which all cursors moved past are removed
+func (l *Log) Commit(cursor string, seq uint64) error {
+	l.Lock()
+	defer l.Unlock()
+	current, ok := l.cursors[cursor]
+	if !ok {
+		return fmt.Errorf("unknown cursor %s", cursor)
+	}
+	if seq >= l.nextSeq {
+		return fmt.Errorf("record %d doesn't exist", seq)
+	}
+	if seq < current {
+		return nil
+	}
+	l.cursors[cursor] = seq + 1
+	err := l.writeCursor(cursor)
+	l.removeConsumedSegments()
+	l.updateGauges()
+	return err
+}
+
+// Lag returns the number of records following the cursor
+func (l *Log) Lag(cursor string) uint64 {
+	l.Lock()
+	defer l.Unlock()
+	seq, ok := l.cursors[cursor]
+	if !ok {
+		return 0
+	}
+	return l.nextSeq - seq
+}
+
+// Size returns the size of all log segments in bytes
+func (l *Log) Size() int64 {
+	l.Lock()
+	defer l.Unlock()
+	return l.totalBytes
+}
+
+// loadSegments finds the segments of the log and opens the last one for
+// appending. A torn record at the end of the last segment (e.g. after a
+// crash) is truncated.
+func (l *Log) loadSegments() error {
+	files, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list log directory %s: %v", l.dir, err)
+	}
+	for _, fi := range files {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), segmentSuffix) {
+			continue
+		}
+		firstSeq, err := strconv.ParseUint(strings.TrimSuffix(fi.Name(), segmentSuffix), 10, 64)
+		if err != nil {
+			glog.Errorf("Ignoring unexpected file %s in log directory", fi.Name())
+			continue
+		}
+		l.segments = append(l.segments, &segment{
+			firstSeq: firstSeq,
+			path:     filepath.Join(l.dir, fi.Name()),
+			size:     fi.Size(),
+		})
+		l.totalBytes += fi.Size()
+	}
+	sort.Slice(l.segments, func(i, j int) bool { return l.segments[i].firstSeq < l.segments[j].firstSeq })
+
+	if len(l.segments) == 0 {
+		return l.createSegment(0)
+	}
+	last := l.segments[len(l.segments)-1]
+	records, endPos, err := readSegment(last, position{seq: last.firstSeq}, last.firstSeq, ^uint64(0), -1)
+	if err != nil {
+		glog.Warningf("Truncating log segment %s after %d records: %v", last.path, len(records), err)
+		if err := os.Truncate(last.path, endPos.offset); err != nil {
+			return fmt.Errorf("failed to truncate log segment %s: %v", last.path, err)
+		}
+		l.totalBytes -= last.size - endPos.offset
+		last.size = endPos.offset
+	}
+	l.nextSeq = endPos.seq
+	l.active, err = os.OpenFile(last.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log segment %s: %v", last.path, err)
+	}
+	return nil
+}
+
+// loadCursors reads the persisted cursors, they become active once they are
+// registered again
+func (l *Log) loadCursors() error {
+	files, err := filepath.Glob(filepath.Join(l.dir, "*"+cursorSuffix))
+	if err != nil {
+		return fmt.Errorf("failed to list log cursors: %v", err)
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), cursorSuffix)
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read log cursor %s: %v", name, err)
+		}
+		seq, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			glog.Errorf("Ignoring invalid log cursor %s: %v", name, err)
+			continue
+		}
+		l.savedCursors[name] = seq
+	}
+	return nil
+}
+
+// writeCursor persists the cursor's position
+func (l *Log) writeCursor(name string) error {
+	path := filepath.Join(l.dir, name+cursorSuffix)
+	tmpPath := path + ".tmp"
+	content := []byte(strconv.FormatUint(l.cursors[name], 10))
+	if err := ioutil.WriteFile(tmpPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write log cursor %s: %v", name, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write log cursor %s: %v", name, err)
+	}
+	return nil
+}
+
+func (l *Log) createSegment(firstSeq uint64) error {
+	path := filepath.Join(l.dir, fmt.Sprintf("%020d%s", firstSeq, segmentSuffix))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create log segment %s: %v", path, err)
+	}
+	l.active = file
+	l.segments = append(l.segments, &segment{firstSeq: firstSeq, path: path})
+	l.nextSeq = firstSeq
+	return nil
+}
+
+func (l *Log) rotate() error {
+	if err := l.active.Close(); err != nil {
+		glog.Errorf("Failed to close log segment: %v", err)
+	}
+	return l.createSegment(l.nextSeq)
+}
+
+// enforceSizeLimit drops the oldest segments while the log exceeds its size
+// limit. Cursors pointing to dropped records are moved forward.
+func (l *Log) enforceSizeLimit() {
+	for l.totalBytes > l.maxBytes && len(l.segments) > 1 {
+		dropped := l.segments[0]
+		newFirstSeq := l.segments[1].firstSeq
+		l.removeOldestSegment()
+		for name, seq := range l.cursors {
+			if seq >= newFirstSeq {
+				continue
+			}
+			droppedRecords.WithLabelValues(name).Add(float64(newFirstSeq - seq))
+			glog.Warningf(
+				"Log size limit exceeded, dropped %d records of %s from %s", newFirstSeq-seq, name, dropped.path)
+			l.cursors[name] = newFirstSeq
+			if err := l.writeCursor(name); err != nil {
+				glog.Error(err)
+			}
+		}
+	}
+}
+
+// removeConsumedSegments removes segments all cursors moved past. The
+// active segment is never removed.
+func (l *Log) removeConsumedSegments() {
+	for len(l.segments) > 1 {
+		endSeq := l.segments[1].firstSeq
+		for _, seq := range l.cursors {
+			if seq < endSeq {
+				return
+			}
+		}
+		l.removeOldestSegment()
+	}
+}
+
+func (l *Log) removeOldestSegment() {
+	seg := l.segments[0]
+	if err := os.Remove(seg.path); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("Failed to remove log segment %s: %v", seg.path, err)
+	}
+	l.totalBytes -= seg.size
+	l.segments = l.segments[1:]
+}
+
+// findSegment returns the index of the segment containing seq
+func (l *Log) findSegment(seq uint64) int {
+	idx := sort.Search(len(l.segments), func(i int) bool { return l.segments[i].firstSeq > seq })
+	if idx == 0 {
+		return 0
+	}
+	return idx - 1
+}
+
+// getSegment returns the index of the segment starting at firstSeq, -1 if
+// there is no such segment
+func (l *Log) getSegment(firstSeq uint64) int {
+	for i, seg := range l.segments {
+		if seg.firstSeq == firstSeq {
+			return i
+		}
+	}
+	return -1
+}
+
+// getSegmentEnd returns the sequence number following the segment's last
+// record
+func (l *Log) getSegmentEnd(segIdx int) uint64 {
+	if segIdx == len(l.segments)-1 {
+		return l.nextSeq
+	}
+	return l.segments[segIdx+1].firstSeq
+}
+
+func (l *Log) updateGauges() {
+	logSize.Set(float64(l.totalBytes))
+	for name, seq := range l.cursors {
+		cursorLag.WithLabelValues(name).Set(float64(l.nextSeq - seq))
+	}
+}
+
+// readSegment reads up to maxRecords records (-1 for all) of a segment,
+// starting at pos and skipping records preceding fromSeq. Returns the read
+// records and the position following the last valid record.
+func readSegment(seg *segment, pos position, fromSeq uint64, endSeq uint64, maxRecords int) ([]Record, position, error) {
+	file, err := os.Open(seg.path)
+	if err != nil {
+		return nil, pos, err
+	}
+	defer file.Close()
+	if _, err = file.Seek(pos.offset, io.SeekStart); err != nil {
+		return nil, pos, err
+	}
+
+	var records []Record
+	reader := bufio.NewReader(file)
+	header := make([]byte, recordHeaderSize)
+	for pos.seq < endSeq && (maxRecords < 0 || len(records) < maxRecords) {
+		if _, err = io.ReadFull(reader, header); err != nil {
+			if err == io.EOF && endSeq == ^uint64(0) {
+				return records, pos, nil
+			}
+			return records, pos, fmt.Errorf("failed to read record header: %v", err)
+		}
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size > maxRecordSize {
+			return records, pos, errors.New("invalid record size")
+		}
+		data := make([]byte, size)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return records, pos, fmt.Errorf("failed to read record: %v", err)
+		}
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(header[4:8]) {
+			return records, pos, errors.New("record checksum mismatch")
+		}
+		if pos.seq >= fromSeq {
+			records = append(records, Record{Seq: pos.seq, Data: data})
+		}
+		pos.seq++
+		pos.offset += int64(recordHeaderSize + size)
+	}
+	return records, pos, nil
+}
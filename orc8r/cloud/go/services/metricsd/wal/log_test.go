@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package wal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog_AppendReadCommit(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+	defer setMaxSegmentBytes(100)()
+
+	log, err := Open(dir, 1<<20)
+	assert.NoError(t, err)
+	assert.NoError(t, log.RegisterCursor("a"))
+	assert.NoError(t, log.RegisterCursor("b"))
+	assert.Error(t, log.RegisterCursor("../c"))
+	_, err = log.Read("c", 10)
+	assert.EqualError(t, err, "unknown cursor c")
+
+	records, err := log.Read("a", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	// 40 byte records, 3 per segment
+	for i := 0; i < 10; i++ {
+		seq, err := log.Append(makeTestData(i))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i), seq)
+	}
+	assert.Len(t, getSegmentFiles(t, dir), 4)
+	assert.Equal(t, int64(400), log.Size())
+
+	// reads span segments and don't move the cursor
+	records, err = log.Read("a", 4)
+	assert.NoError(t, err)
+	assertRecords(t, records, 0, 4)
+	records, err = log.Read("a", 4)
+	assert.NoError(t, err)
+	assertRecords(t, records, 0, 4)
+
+	assert.NoError(t, log.Commit("a", 3))
+	assert.Equal(t, uint64(6), log.Lag("a"))
+	records, err = log.Read("a", 100)
+	assert.NoError(t, err)
+	assertRecords(t, records, 4, 10)
+	assert.NoError(t, log.Commit("a", 9))
+	assert.Equal(t, uint64(0), log.Lag("a"))
+	assert.EqualError(t, log.Commit("a", 10), "record 10 doesn't exist")
+
+	// segments are kept until all cursors move past them
+	assert.Len(t, getSegmentFiles(t, dir), 4)
+	assert.NoError(t, log.Commit("b", 4))
+	assert.Len(t, getSegmentFiles(t, dir), 3)
+	assert.Equal(t, int64(280), log.Size())
+	records, err = log.Read("b", 100)
+	assert.NoError(t, err)
+	assertRecords(t, records, 5, 10)
+	assert.NoError(t, log.Commit("b", 9))
+	// the active segment is never removed
+	assert.Len(t, getSegmentFiles(t, dir), 1)
+}
+
+func TestLog_Reopen(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+	defer setMaxSegmentBytes(100)()
+
+	log, err := Open(dir, 1<<20)
+	assert.NoError(t, err)
+	assert.NoError(t, log.RegisterCursor("a"))
+	for i := 0; i < 5; i++ {
+		_, err = log.Append(makeTestData(i))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, log.Commit("a", 1))
+	assert.NoError(t, log.Close())
+
+	// tear the last record
+	segments := getSegmentFiles(t, dir)
+	last := segments[len(segments)-1]
+	fi, err := os.Stat(last)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Truncate(last, fi.Size()-5))
+
+	log, err = Open(dir, 1<<20)
+	assert.NoError(t, err)
+	assert.NoError(t, log.RegisterCursor("a"))
+	assert.NoError(t, log.RegisterCursor("new"))
+	records, err := log.Read("a", 100)
+	assert.NoError(t, err)
+	assertRecords(t, records, 2, 4)
+	// new cursors start at the oldest record
+	records, err = log.Read("new", 100)
+	assert.NoError(t, err)
+	assertRecords(t, records, 0, 4)
+
+	seq, err := log.Append(makeTestData(4))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), seq)
+	records, err = log.Read("a", 100)
+	assert.NoError(t, err)
+	assertRecords(t, records, 2, 5)
+}
+
+func TestLog_SizeLimit(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+	defer setMaxSegmentBytes(100)()
+
+	log, err := Open(dir, 200)
+	assert.NoError(t, err)
+	assert.NoError(t, log.RegisterCursor("a"))
+	assert.NoError(t, log.RegisterCursor("b"))
+	for i := 0; i < 10; i++ {
+		_, err = log.Append(makeTestData(i))
+		assert.NoError(t, err)
+	}
+	// the oldest segments were dropped to stay under 200 bytes
+	assert.Equal(t, int64(160), log.Size())
+	assert.Len(t, getSegmentFiles(t, dir), 2)
+	assert.Equal(t, uint64(4), log.Lag("a"))
+	records, err := log.Read("a", 100)
+	assert.NoError(t, err)
+	assertRecords(t, records, 6, 10)
+
+	// a cursor ahead of the dropped segments is not affected
+	assert.NoError(t, log.Commit("b", 7))
+	_, err = log.Append(makeTestData(10))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), log.Lag("b"))
+	records, err = log.Read("b", 100)
+	assert.NoError(t, err)
+	assertRecords(t, records, 8, 11)
+}
+
+func makeTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "wal_test")
+	assert.NoError(t, err)
+	return dir
+}
+
+// makeTestData returns 32 bytes of data (40 bytes with the record header)
+func makeTestData(i int) []byte {
+	return []byte(fmt.Sprintf("record %025d", i))
+}
+
+func assertRecords(t *testing.T, records []Record, from int, to int) {
+	if !assert.Len(t, records, to-from) {
+		return
+	}
+	for i, record := range records {
+		assert.Equal(t, uint64(from+i), record.Seq)
+		assert.Equal(t, makeTestData(from+i), record.Data)
+	}
+}
+
+func getSegmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentSuffix))
+	assert.NoError(t, err)
+	return files
+}
+
+func setMaxSegmentBytes(size int64) func() {
+	prev := MaxSegmentBytes
+	MaxSegmentBytes = size
+	return func() { MaxSegmentBytes = prev }
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) Facebook, Inc. and its affiliates.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package wal
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const exporterLabelName = "exporter"
+
+var (
+	logSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "metricsd_wal_size_bytes",
+			Help: "Size of the metrics write-ahead log segments in bytes",
+		},
+	)
+	appendedRecords = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "metricsd_wal_appended_records",
+			Help: "Number of metric batches appended to the write-ahead log",
+		},
+	)
+	cursorLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "metricsd_wal_exporter_lag_records",
+			Help: "Number of metric batches in the write-ahead log not yet delivered to the exporter",
+		},
+		[]string{exporterLabelName},
+	)
+	droppedRecords = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metricsd_wal_dropped_records",
+			Help: "Number of metric batches dropped before delivery because the write-ahead log was full",
+		},
+		[]string{exporterLabelName},
+	)
+	rejectedRecords = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metricsd_wal_rejected_records",
+			Help: "Number of metric batches skipped because the exporter's datasink permanently rejected them",
+		},
+		[]string{exporterLabelName},
+	)
+	deliveryFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metricsd_wal_delivery_failures",
+			Help: "Number of failed deliveries of buffered metrics to the exporter",
+		},
+		[]string{exporterLabelName},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(logSize, appendedRecords, cursorLag, droppedRecords, rejectedRecords, deliveryFailures)
+}
@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: wal.proto
+
+package protos
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type CloudMetricContext struct {
+	CloudHost            string   `protobuf:"bytes,1,opt,name=cloud_host,json=cloudHost,proto3" json:"cloud_host,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudMetricContext) Reset()         { *m = CloudMetricContext{} }
+func (m *CloudMetricContext) String() string { return proto.CompactTextString(m) }
+func (*CloudMetricContext) ProtoMessage()    {}
+func (*CloudMetricContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae6364fc8077884f, []int{0}
+}
+
+func (m *CloudMetricContext) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudMetricContext.Unmarshal(m, b)
+}
+func (m *CloudMetricContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudMetricContext.Marshal(b, m, deterministic)
+}
+func (m *CloudMetricContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudMetricContext.Merge(m, src)
+}
+func (m *CloudMetricContext) XXX_Size() int {
+	return xxx_messageInfo_CloudMetricContext.Size(m)
+}
+func (m *CloudMetricContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudMetricContext.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudMetricContext proto.InternalMessageInfo
+
+func (m *CloudMetricContext) GetCloudHost() string {
+	if m != nil {
+		return m.CloudHost
+	}
+	return ""
+}
+
+type GatewayMetricContext struct {
+	NetworkId            string   `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	GatewayId            string   `protobuf:"bytes,2,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayMetricContext) Reset()         { *m = GatewayMetricContext{} }
+func (m *GatewayMetricContext) String() string { return proto.CompactTextString(m) }
+func (*GatewayMetricContext) ProtoMessage()    {}
+func (*GatewayMetricContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae6364fc8077884f, []int{1}
+}
+
+func (m *GatewayMetricContext) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayMetricContext.Unmarshal(m, b)
+}
+func (m *GatewayMetricContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayMetricContext.Marshal(b, m, deterministic)
+}
+func (m *GatewayMetricContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayMetricContext.Merge(m, src)
+}
+func (m *GatewayMetricContext) XXX_Size() int {
+	return xxx_messageInfo_GatewayMetricContext.Size(m)
+}
+func (m *GatewayMetricContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayMetricContext.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayMetricContext proto.InternalMessageInfo
+
+func (m *GatewayMetricContext) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *GatewayMetricContext) GetGatewayId() string {
+	if m != nil {
+		return m.GatewayId
+	}
+	return ""
+}
+
+type PushedMetricContext struct {
+	NetworkId            string   `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PushedMetricContext) Reset()         { *m = PushedMetricContext{} }
+func (m *PushedMetricContext) String() string { return proto.CompactTextString(m) }
+func (*PushedMetricContext) ProtoMessage()    {}
+func (*PushedMetricContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae6364fc8077884f, []int{2}
+}
+
+func (m *PushedMetricContext) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushedMetricContext.Unmarshal(m, b)
+}
+func (m *PushedMetricContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushedMetricContext.Marshal(b, m, deterministic)
+}
+func (m *PushedMetricContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushedMetricContext.Merge(m, src)
+}
+func (m *PushedMetricContext) XXX_Size() int {
+	return xxx_messageInfo_PushedMetricContext.Size(m)
+}
+func (m *PushedMetricContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushedMetricContext.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushedMetricContext proto.InternalMessageInfo
+
+func (m *PushedMetricContext) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+type MetricAndContext struct {
+	MetricName string `protobuf:"bytes,1,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	// Marshaled io.prometheus.client.MetricFamily
+	Family []byte `protobuf:"bytes,2,opt,name=family,proto3" json:"family,omitempty"`
+	// Types that are valid to be assigned to Context:
+	//	*MetricAndContext_Cloud
+	//	*MetricAndContext_Gateway
+	//	*MetricAndContext_Pushed
+	Context              isMetricAndContext_Context `protobuf_oneof:"context"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *MetricAndContext) Reset()         { *m = MetricAndContext{} }
+func (m *MetricAndContext) String() string { return proto.CompactTextString(m) }
+func (*MetricAndContext) ProtoMessage()    {}
+func (*MetricAndContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae6364fc8077884f, []int{3}
+}
+
+func (m *MetricAndContext) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricAndContext.Unmarshal(m, b)
+}
+func (m *MetricAndContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricAndContext.Marshal(b, m, deterministic)
+}
+func (m *MetricAndContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricAndContext.Merge(m, src)
+}
+func (m *MetricAndContext) XXX_Size() int {
+	return xxx_messageInfo_MetricAndContext.Size(m)
+}
+func (m *MetricAndContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricAndContext.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricAndContext proto.InternalMessageInfo
+
+func (m *MetricAndContext) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
+}
+
+func (m *MetricAndContext) GetFamily() []byte {
+	if m != nil {
+		return m.Family
+	}
+	return nil
+}
+
+type isMetricAndContext_Context interface {
+	isMetricAndContext_Context()
+}
+
+type MetricAndContext_Cloud struct {
+	Cloud *CloudMetricContext `protobuf:"bytes,3,opt,name=cloud,proto3,oneof"`
+}
+
+type MetricAndContext_Gateway struct {
+	Gateway *GatewayMetricContext `protobuf:"bytes,4,opt,name=gateway,proto3,oneof"`
+}
+
+type MetricAndContext_Pushed struct {
+	Pushed *PushedMetricContext `protobuf:"bytes,5,opt,name=pushed,proto3,oneof"`
+}
+
+func (*MetricAndContext_Cloud) isMetricAndContext_Context() {}
+
+func (*MetricAndContext_Gateway) isMetricAndContext_Context() {}
+
+func (*MetricAndContext_Pushed) isMetricAndContext_Context() {}
+
+func (m *MetricAndContext) GetContext() isMetricAndContext_Context {
+	if m != nil {
+		return m.Context
+	}
+	return nil
+}
+
+func (m *MetricAndContext) GetCloud() *CloudMetricContext {
+	if x, ok := m.GetContext().(*MetricAndContext_Cloud); ok {
+		return x.Cloud
+	}
+	return nil
+}
+
+func (m *MetricAndContext) GetGateway() *GatewayMetricContext {
+	if x, ok := m.GetContext().(*MetricAndContext_Gateway); ok {
+		return x.Gateway
+	}
+	return nil
+}
+
+func (m *MetricAndContext) GetPushed() *PushedMetricContext {
+	if x, ok := m.GetContext().(*MetricAndContext_Pushed); ok {
+		return x.Pushed
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*MetricAndContext) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*MetricAndContext_Cloud)(nil),
+		(*MetricAndContext_Gateway)(nil),
+		(*MetricAndContext_Pushed)(nil),
+	}
+}
+
+type Record struct {
+	Metrics              []*MetricAndContext `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+func (*Record) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae6364fc8077884f, []int{4}
+}
+
+func (m *Record) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Record.Unmarshal(m, b)
+}
+func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+}
+func (m *Record) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Record.Merge(m, src)
+}
+func (m *Record) XXX_Size() int {
+	return xxx_messageInfo_Record.Size(m)
+}
+func (m *Record) XXX_DiscardUnknown() {
+	xxx_messageInfo_Record.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Record proto.InternalMessageInfo
+
+func (m *Record) GetMetrics() []*MetricAndContext {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CloudMetricContext)(nil), "magma.orc8r.metricsd.wal.CloudMetricContext")
+	proto.RegisterType((*GatewayMetricContext)(nil), "magma.orc8r.metricsd.wal.GatewayMetricContext")
+	proto.RegisterType((*PushedMetricContext)(nil), "magma.orc8r.metricsd.wal.PushedMetricContext")
+	proto.RegisterType((*MetricAndContext)(nil), "magma.orc8r.metricsd.wal.MetricAndContext")
+	proto.RegisterType((*Record)(nil), "magma.orc8r.metricsd.wal.Record")
+}
+
+func init() { proto.RegisterFile("wal.proto", fileDescriptor_ae6364fc8077884f) }
+
+var fileDescriptor_ae6364fc8077884f = []byte{
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0x41, 0x4f, 0xc2, 0x30,
+	0x18, 0x86, 0x19, 0xc8, 0x70, 0x1f, 0x1e, 0x4c, 0x35, 0xa6, 0x17, 0x23, 0xd9, 0x89, 0x18, 0xed,
+	0x01, 0x3c, 0x78, 0x15, 0x48, 0x18, 0x26, 0x12, 0xd3, 0x78, 0xf2, 0x42, 0xea, 0x5a, 0x81, 0xb8,
+	0xae, 0xa4, 0x2b, 0x99, 0xfc, 0x2c, 0xff, 0xa1, 0xa1, 0xed, 0x2e, 0xc2, 0x0e, 0x9e, 0x9a, 0xbe,
+	0x5f, 0x9e, 0x27, 0xfd, 0xde, 0x14, 0xa2, 0x92, 0x65, 0x64, 0xa3, 0x95, 0x51, 0x08, 0x4b, 0xb6,
+	0x94, 0x8c, 0x28, 0x9d, 0x3e, 0x6a, 0x22, 0x85, 0xd1, 0xeb, 0xb4, 0xe0, 0xa4, 0x64, 0x59, 0x3c,
+	0x04, 0x34, 0xce, 0xd4, 0x96, 0xbf, 0xd8, 0x70, 0xac, 0x72, 0x23, 0xbe, 0x0d, 0xba, 0x06, 0x48,
+	0xf7, 0xe9, 0x62, 0xa5, 0x0a, 0x83, 0x83, 0x5e, 0xd0, 0x8f, 0x68, 0x64, 0x93, 0x44, 0x15, 0x26,
+	0x7e, 0x83, 0xcb, 0x29, 0x33, 0xa2, 0x64, 0xbb, 0x03, 0x2c, 0x17, 0xa6, 0x54, 0xfa, 0x6b, 0xb1,
+	0xe6, 0x15, 0xe6, 0x93, 0x19, 0xdf, 0x8f, 0x97, 0x0e, 0xdb, 0x8f, 0x9b, 0x6e, 0xec, 0x93, 0x19,
+	0x8f, 0x1f, 0xe0, 0xe2, 0x75, 0x5b, 0xac, 0x04, 0xff, 0x8f, 0x34, 0xfe, 0x69, 0xc2, 0xb9, 0x03,
+	0x9e, 0x72, 0x5e, 0x31, 0x37, 0xd0, 0x75, 0x5b, 0x2e, 0x72, 0x26, 0x85, 0x87, 0xc0, 0x45, 0x73,
+	0x26, 0x05, 0xba, 0x82, 0xf0, 0x93, 0xc9, 0x75, 0xb6, 0xb3, 0xcf, 0x38, 0xa3, 0xfe, 0x86, 0x26,
+	0xd0, 0xb6, 0x6b, 0xe2, 0x56, 0x2f, 0xe8, 0x77, 0x07, 0x77, 0xa4, 0xae, 0x38, 0x72, 0xd8, 0x5a,
+	0xd2, 0xa0, 0x0e, 0x46, 0xcf, 0xd0, 0xf1, 0x6b, 0xe1, 0x13, 0xeb, 0x21, 0xf5, 0x9e, 0x63, 0x45,
+	0x26, 0x0d, 0x5a, 0x09, 0xd0, 0x14, 0xc2, 0x8d, 0x6d, 0x05, 0xb7, 0xad, 0xea, 0xbe, 0x5e, 0x75,
+	0xa4, 0xbd, 0xa4, 0x41, 0x3d, 0x3e, 0x8a, 0xa0, 0x93, 0xba, 0x30, 0x9e, 0x43, 0x48, 0x45, 0xaa,
+	0x34, 0x47, 0x13, 0xe8, 0x78, 0x05, 0x0e, 0x7a, 0xad, 0x7e, 0x77, 0x70, 0x5b, 0xaf, 0xff, 0xdb,
+	0x32, 0xad, 0xd0, 0xd1, 0xe9, 0x7b, 0x68, 0xff, 0x59, 0xf1, 0xe1, 0xce, 0xe1, 0xef, 0x00, 0x51,
+	0x68, 0x2d, 0x93, 0x7c, 0x02, 0x00, 0x00,
+}
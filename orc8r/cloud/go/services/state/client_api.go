@@ -35,6 +35,8 @@ type State struct {
 	CertExpirationTime int64
 	ReportedState      interface{}
 	Version            uint64
+	// Stale is true if the state hasn't been re-reported within its type's TTL
+	Stale bool
 }
 
 // SerializedStateWithMeta includes reported operational states and additional info
@@ -52,6 +54,21 @@ type StateID struct {
 	DeviceID string
 }
 
+// SearchParams filters the states returned by SearchStates. Empty fields
+// match all states.
+type SearchParams struct {
+	Types []string
+	// KeyPrefix is a prefix of the device IDs of the states to return
+	KeyPrefix string
+	// ReporterHardwareID is the hardware ID of the gateway which reported
+	// the states
+	ReporterHardwareID string
+	// ReportedAfterMs and ReportedBeforeMs are inclusive bounds on the time
+	// at which the states were reported, in milliseconds since epoch
+	ReportedAfterMs  uint64
+	ReportedBeforeMs uint64
+}
+
 // Global clientconn that can be reused for this service
 var connSingleton = (*grpc.ClientConn)(nil)
 var connGuard = sync.Mutex{}
@@ -100,7 +117,9 @@ func GetState(networkID string, typeVal string, hwID string) (State, error) {
 	if len(ret.States) == 0 {
 		return State{}, errors.ErrNotFound
 	}
-	return toState(ret.States[0])
+	state, err := toState(ret.States[0])
+	state.Stale = len(ret.StaleIds) > 0
+	return state, err
 }
 
 // GetStates returns a map of states specified by the networkID and a list of type and key
@@ -123,16 +142,40 @@ func GetStates(networkID string, stateIDs []StateID) (map[StateID]State, error)
 	if err != nil {
 		return nil, err
 	}
-	idToValue := map[StateID]State{}
-	for _, pState := range res.States {
-		stateID := StateID{Type: pState.Type, DeviceID: pState.DeviceID}
-		state, err := toState(pState)
-		if err != nil {
-			return nil, err
-		}
-		idToValue[stateID] = state
+	return toStatesByID(res.States, res.StaleIds)
+}
+
+// SearchStates returns a page of the states in the network which match the
+// params, keyed by state ID, along with the token to pass to load the next
+// page. The returned token is empty if there are no more states to load.
+// A pageSize of 0 will use the service's default page size.
+func SearchStates(networkID string, params SearchParams, pageSize uint32, pageToken string) (map[StateID]State, string, error) {
+	client, err := GetStateClient()
+	if err != nil {
+		return nil, "", err
 	}
-	return idToValue, nil
+
+	res, err := client.SearchStates(
+		context.Background(),
+		&protos.SearchStatesRequest{
+			NetworkID:          networkID,
+			Types:              params.Types,
+			KeyPrefix:          params.KeyPrefix,
+			ReporterHardwareID: params.ReporterHardwareID,
+			ReportedAfterMs:    params.ReportedAfterMs,
+			ReportedBeforeMs:   params.ReportedBeforeMs,
+			PageSize:           pageSize,
+			PageToken:          pageToken,
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	states, err := toStatesByID(res.States, res.StaleIds)
+	if err != nil {
+		return nil, "", err
+	}
+	return states, res.NextPageToken, nil
 }
 
 // DeleteStates deletes states specified by the networkID and a list of type and key
@@ -185,6 +228,7 @@ func fillInGatewayStatusState(state State) *models.GatewayStatus {
 	gwStatus.CheckinTime = state.TimeMs
 	gwStatus.CertExpirationTime = state.CertExpirationTime
 	gwStatus.HardwareID = state.ReporterID
+	gwStatus.IsStale = state.Stale
 	return gwStatus
 }
 
@@ -196,6 +240,24 @@ func toProtosStateIDs(stateIDs []StateID) []*protos.StateID {
 	return ids
 }
 
+func toStatesByID(pStates []*protos.State, staleIDs []*protos.StateID) (map[StateID]State, error) {
+	isStale := map[StateID]bool{}
+	for _, id := range staleIDs {
+		isStale[StateID{Type: id.Type, DeviceID: id.DeviceID}] = true
+	}
+	idToValue := map[StateID]State{}
+	for _, pState := range pStates {
+		stateID := StateID{Type: pState.Type, DeviceID: pState.DeviceID}
+		state, err := toState(pState)
+		if err != nil {
+			return nil, err
+		}
+		state.Stale = isStale[stateID]
+		idToValue[stateID] = state
+	}
+	return idToValue, nil
+}
+
 func toState(pState *protos.State) (State, error) {
 	serialized := &SerializedStateWithMeta{}
 	err := json.Unmarshal(pState.Value, serialized)
@@ -13,13 +13,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/errors"
 	"magma/orc8r/cloud/go/orc8r"
 	models2 "magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/registry"
 	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/service/config"
 	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
 	configuratorTestUtils "magma/orc8r/cloud/go/services/configurator/test_utils"
 	"magma/orc8r/cloud/go/services/device"
@@ -52,6 +55,41 @@ func makeStateBundle(typeVal string, key string, value interface{}) stateBundle
 	return stateBundle{state: &state, ID: ID}
 }
 
+func TestGetStateTTLsFromConfig(t *testing.T) {
+	ttls, err := state.GetStateTTLsFromConfig(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, ttls)
+	ttls, err = state.GetStateTTLsFromConfig(config.NewConfigMap(map[interface{}]interface{}{"stateTTLs": nil}))
+	assert.NoError(t, err)
+	assert.Empty(t, ttls)
+
+	cfg := config.NewConfigMap(map[interface{}]interface{}{
+		"stateTTLs": map[interface{}]interface{}{"gw_status": "2h", "enodeb": "0s"},
+	})
+	ttls, err = state.GetStateTTLsFromConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"gw_status": 2 * time.Hour, "enodeb": 0}, ttls)
+
+	cfg = config.NewConfigMap(map[interface{}]interface{}{"stateTTLs": []interface{}{"gw_status"}})
+	_, err = state.GetStateTTLsFromConfig(cfg)
+	assert.EqualError(t, err, "stateTTLs must be a map of state type to duration")
+	cfg = config.NewConfigMap(map[interface{}]interface{}{
+		"stateTTLs": map[interface{}]interface{}{"gw_status": 60},
+	})
+	_, err = state.GetStateTTLsFromConfig(cfg)
+	assert.EqualError(t, err, "TTL of state type gw_status must be a duration string")
+	cfg = config.NewConfigMap(map[interface{}]interface{}{
+		"stateTTLs": map[interface{}]interface{}{"gw_status": "forever"},
+	})
+	_, err = state.GetStateTTLsFromConfig(cfg)
+	assert.Error(t, err)
+	cfg = config.NewConfigMap(map[interface{}]interface{}{
+		"stateTTLs": map[interface{}]interface{}{"gw_status": "-1h"},
+	})
+	_, err = state.GetStateTTLsFromConfig(cfg)
+	assert.EqualError(t, err, "TTL of state type gw_status must not be negative")
+}
+
 func TestStateService(t *testing.T) {
 	configuratorTestInit.StartTestService(t)
 	deviceTestInit.StartTestService(t)
@@ -59,8 +97,22 @@ func TestStateService(t *testing.T) {
 	stateTestInit.StartTestService(t)
 	err := serde.RegisterSerdes(
 		state.NewStateSerde("test-serde", &Name{}),
+		state.NewStateSerdeWithTTL("ttl-serde", &Name{}, time.Minute),
 		serde.NewBinarySerde(device.SerdeDomain, orc8r.AccessGatewayRecordType, &models2.GatewayDevice{}))
 	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, state.GetStateTTL("ttl-serde"))
+	assert.Equal(t, time.Duration(0), state.GetStateTTL("test-serde"))
+	assert.Equal(t, map[string]time.Duration{"ttl-serde": time.Minute}, state.GetStateTTLs())
+	assert.Empty(t, state.GetReapTTLs(time.Hour))
+
+	// Configured TTLs override registered ones
+	state.SetStateTTLs(map[string]time.Duration{"ttl-serde": time.Hour, "test-serde": time.Second})
+	assert.Equal(t, time.Hour, state.GetStateTTL("ttl-serde"))
+	assert.Equal(t, time.Second, state.GetStateTTL("test-serde"))
+	state.SetStateTTLs(map[string]time.Duration{"ttl-serde": 0})
+	assert.Equal(t, time.Duration(0), state.GetStateTTL("ttl-serde"))
+	state.SetStateTTLs(nil)
+	assert.Equal(t, time.Minute, state.GetStateTTL("ttl-serde"))
 
 	networkID := "state_service_test_network"
 	configuratorTestUtils.RegisterNetwork(t, networkID, "State Service Test")
@@ -140,6 +192,91 @@ func TestStateService(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(states))
 	testGetStatesResponse(t, states, bundle0, bundle1)
+
+	// States of types with a TTL go stale if they aren't re-reported in time.
+	// The gateway's certificate is validated against the clock as well.
+	reportTime := time.Now()
+	clock.SetAndFreezeClock(t, reportTime)
+	defer clock.UnfreezeClock(t)
+	ttlBundle := makeStateBundle("ttl-serde", "key4", value0)
+	_, err = reportStates(ctx, bundle0, ttlBundle)
+	assert.NoError(t, err)
+
+	clock.SetAndFreezeClock(t, reportTime.Add(time.Minute))
+	states, err = state.GetStates(networkID, []state.StateID{bundle0.ID, ttlBundle.ID})
+	assert.NoError(t, err)
+	testGetStatesResponse(t, states, bundle0, ttlBundle)
+	assert.False(t, states[bundle0.ID].Stale)
+	assert.False(t, states[ttlBundle.ID].Stale)
+
+	// States without a TTL never go stale
+	clock.SetAndFreezeClock(t, reportTime.Add(time.Minute+time.Millisecond))
+	states, err = state.GetStates(networkID, []state.StateID{bundle0.ID, ttlBundle.ID})
+	assert.NoError(t, err)
+	testGetStatesResponse(t, states, bundle0, ttlBundle)
+	assert.False(t, states[bundle0.ID].Stale)
+	assert.True(t, states[ttlBundle.ID].Stale)
+	st, err := state.GetState(networkID, ttlBundle.ID.Type, ttlBundle.ID.DeviceID)
+	assert.NoError(t, err)
+	assert.True(t, st.Stale)
+
+	// Re-reporting makes the state fresh again
+	_, err = reportStates(ctx, ttlBundle)
+	assert.NoError(t, err)
+	st, err = state.GetState(networkID, ttlBundle.ID.Type, ttlBundle.ID.DeviceID)
+	assert.NoError(t, err)
+	assert.False(t, st.Stale)
+
+	// Search states
+	states, nextPageToken, err := state.SearchStates(networkID, state.SearchParams{}, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", nextPageToken)
+	assert.Equal(t, 3, len(states))
+	testGetStatesResponse(t, states, bundle0, bundle1, ttlBundle)
+
+	// Paginate through states of a type
+	params := state.SearchParams{Types: []string{"test-serde"}, KeyPrefix: "key"}
+	states, nextPageToken, err = state.SearchStates(networkID, params, 1, "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nextPageToken)
+	assert.Equal(t, 1, len(states))
+	testGetStatesResponse(t, states, bundle0)
+	states, nextPageToken, err = state.SearchStates(networkID, params, 1, nextPageToken)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nextPageToken)
+	assert.Equal(t, 1, len(states))
+	testGetStatesResponse(t, states, bundle1)
+	states, nextPageToken, err = state.SearchStates(networkID, params, 1, nextPageToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "", nextPageToken)
+	assert.Equal(t, 0, len(states))
+
+	// Filter by reporter and report time
+	states, _, err = state.SearchStates(networkID, state.SearchParams{ReporterHardwareID: testAgHwId}, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(states))
+	states, _, err = state.SearchStates(networkID, state.SearchParams{ReporterHardwareID: "some-other-gw"}, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(states))
+	reportTimeMs := uint64(reportTime.Add(time.Minute).UnixNano()) / uint64(time.Millisecond)
+	states, _, err = state.SearchStates(networkID, state.SearchParams{ReportedAfterMs: reportTimeMs}, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(states))
+	testGetStatesResponse(t, states, ttlBundle)
+	assert.False(t, states[ttlBundle.ID].Stale)
+
+	// Filters applied on state metadata still fill up pages
+	states, nextPageToken, err = state.SearchStates(networkID, state.SearchParams{ReportedBeforeMs: reportTimeMs}, 2, "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nextPageToken)
+	assert.Equal(t, 2, len(states))
+	testGetStatesResponse(t, states, bundle0, bundle1)
+
+	// Bad requests
+	_, _, err = state.SearchStates(networkID, state.SearchParams{ReportedAfterMs: 2, ReportedBeforeMs: 1}, 0, "")
+	assert.Error(t, err)
+	_, _, err = state.SearchStates(networkID, state.SearchParams{}, 0, "not a token")
+	assert.Error(t, err)
 }
 
 type NameAndAge struct {
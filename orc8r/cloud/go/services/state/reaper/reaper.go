@@ -0,0 +1,163 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package reaper garbage collects states of expiring state types which haven't
+// been re-reported within their state type's TTL or reap TTL.
+package reaper
+
+import (
+	"sort"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// Reaper deletes expired states from the state service's blobstore.
+// A state expires once it has been stale for longer than the reaper's grace
+// period, or once its type's reap TTL has passed since it was last reported,
+// so stale states remain visible to clients for a while before they are
+// deleted.
+type Reaper struct {
+	factory     blobstore.BlobStorageFactory
+	gracePeriod time.Duration
+}
+
+func NewReaper(factory blobstore.BlobStorageFactory, gracePeriod time.Duration) *Reaper {
+	return &Reaper{factory: factory, gracePeriod: gracePeriod}
+}
+
+// Run reaps expired states every interval. This function blocks.
+func (r *Reaper) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		err := r.Reap()
+		if err != nil {
+			glog.Errorf("Error reaping expired states: %s", err)
+		}
+	}
+}
+
+// Reap deletes expired states of all garbage collected state types across
+// all networks with states, including networks which no longer exist in
+// configurator. States of types registered with NewStateSerdeWithTTL are only
+// marked stale and never deleted. Failures to
+// reap a single network are logged and don't prevent other networks from
+// being reaped.
+func (r *Reaper) Reap() error {
+	reapTTLs := state.GetReapTTLs(r.gracePeriod)
+	if len(reapTTLs) == 0 {
+		return nil
+	}
+	networkIDs, err := r.listNetworkIDs()
+	if err != nil {
+		return err
+	}
+
+	stateTypes := make([]string, 0, len(reapTTLs))
+	for stateType := range reapTTLs {
+		stateTypes = append(stateTypes, stateType)
+	}
+	sort.Strings(stateTypes)
+
+	now := clock.Now()
+	for _, networkID := range networkIDs {
+		numReaped, err := r.reapNetwork(networkID, stateTypes, reapTTLs, now)
+		if err != nil {
+			glog.Errorf("Error reaping expired states for network %s: %s", networkID, err)
+			continue
+		}
+		if numReaped > 0 {
+			glog.V(2).Infof("Reaped up to %d expired states for network %s", numReaped, networkID)
+		}
+	}
+	return nil
+}
+
+// listNetworkIDs lists the networks in the state table rather than in
+// configurator, so that states of deleted networks are reaped too
+func (r *Reaper) listNetworkIDs() ([]string, error) {
+	store, err := r.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start transaction")
+	}
+	networkIDs, err := store.ListNetworkIDs()
+	if err != nil {
+		store.Rollback()
+		return nil, errors.Wrap(err, "failed to list networks")
+	}
+	return networkIDs, store.Commit()
+}
+
+// reapNetwork deletes the network's expired states. States re-reported
+// after they were found to be expired are left in place. Returns the number
+// of expired states found.
+func (r *Reaper) reapNetwork(networkID string, stateTypes []string, reapTTLs map[string]time.Duration, now time.Time) (int, error) {
+	store, err := r.factory.StartTransaction(nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to start transaction")
+	}
+
+	expired := []blobstore.Blob{}
+	for _, stateType := range stateTypes {
+		blobs, err := getExpiredStates(store, networkID, stateType, now.Add(-reapTTLs[stateType]))
+		if err != nil {
+			store.Rollback()
+			return 0, err
+		}
+		expired = append(expired, blobs...)
+	}
+	if len(expired) == 0 {
+		return 0, store.Commit()
+	}
+
+	err = store.DeleteUnchanged(networkID, expired)
+	if err != nil {
+		store.Rollback()
+		return 0, errors.Wrap(err, "failed to delete expired states")
+	}
+	return len(expired), store.Commit()
+}
+
+// getExpiredStates returns the states of the type in the network which were
+// last reported before the cutoff
+func getExpiredStates(store blobstore.TransactionalBlobStorage, networkID string, stateType string, cutoff time.Time) ([]blobstore.Blob, error) {
+	keys, err := store.ListKeys(networkID, stateType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list keys of state type %s", stateType)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]storage.TypeAndKey, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, storage.TypeAndKey{Type: stateType, Key: key})
+	}
+	blobs, err := store.GetMany(networkID, ids)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load states of type %s", stateType)
+	}
+
+	ret := []blobstore.Blob{}
+	for _, blob := range blobs {
+		isExpired, err := state.IsReportedBefore(blob.Value, cutoff)
+		if err != nil {
+			glog.Errorf("Failed to determine report time of state (%s, %s) in network %s: %s", blob.Type, blob.Key, networkID, err)
+			continue
+		}
+		if isExpired {
+			ret = append(ret, blob)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reaper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/services/state/reaper"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReaper_Reap(t *testing.T) {
+	serde.UnregisterSerdesForDomain(t, state.SerdeDomain)
+	err := serde.RegisterSerdes(
+		state.NewExpiringStateSerde("ttl", &state.StringToStringMap{}, time.Minute),
+		state.NewStateSerdeWithTTL("stale_only", &state.StringToStringMap{}, time.Minute),
+		state.NewStateSerdeWithReapTTL("reap_ttl", &state.StringToStringMap{}, time.Minute, 20*time.Minute),
+		state.NewStateSerde("no_ttl", &state.StringToStringMap{}),
+	)
+	assert.NoError(t, err)
+
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	writeStates(t, factory, "n1",
+		makeStateBlob(t, "ttl", "old", time.Unix(1000, 0)),
+		makeStateBlob(t, "ttl", "stale", time.Unix(1900, 0)),
+		makeStateBlob(t, "ttl", "fresh", time.Unix(2000, 0)),
+		makeStateBlob(t, "stale_only", "old", time.Unix(1000, 0)),
+		makeStateBlob(t, "reap_ttl", "old", time.Unix(800, 0)),
+		makeStateBlob(t, "reap_ttl", "stale", time.Unix(1000, 0)),
+		makeStateBlob(t, "no_ttl", "old", time.Unix(1000, 0)),
+	)
+	// Networks are listed from the state table, so states of networks which
+	// don't exist in configurator are reaped too
+	writeStates(t, factory, "n2",
+		makeStateBlob(t, "ttl", "old", time.Unix(1000, 0)),
+	)
+
+	clock.SetAndFreezeClock(t, time.Unix(2030, 0))
+	defer clock.UnfreezeClock(t)
+	r := reaper.NewReaper(factory, 5*time.Minute)
+
+	// "old" has been stale for longer than the grace period, "stale" is still
+	// within it
+	err = r.Reap()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fresh", "stale"}, listKeys(t, factory, "n1", "ttl"))
+	assert.Equal(t, []string{"old"}, listKeys(t, factory, "n1", "stale_only"))
+	assert.Equal(t, []string{"stale"}, listKeys(t, factory, "n1", "reap_ttl"))
+	assert.Equal(t, []string{"old"}, listKeys(t, factory, "n1", "no_ttl"))
+	assert.Empty(t, listKeys(t, factory, "n2", "ttl"))
+
+	// Configured TTLs take precedence over registered ones
+	state.SetStateTTLs(map[string]time.Duration{"ttl": time.Hour})
+	defer state.SetStateTTLs(nil)
+	clock.SetAndFreezeClock(t, time.Unix(2261, 0))
+	err = r.Reap()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fresh", "stale"}, listKeys(t, factory, "n1", "ttl"))
+
+	state.SetStateTTLs(nil)
+	err = r.Reap()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fresh"}, listKeys(t, factory, "n1", "ttl"))
+	assert.Equal(t, []string{"old"}, listKeys(t, factory, "n1", "stale_only"))
+	assert.Equal(t, []string{"old"}, listKeys(t, factory, "n1", "no_ttl"))
+}
+
+func TestReaper_Reap_ReReported(t *testing.T) {
+	serde.UnregisterSerdesForDomain(t, state.SerdeDomain)
+	err := serde.RegisterSerdes(state.NewExpiringStateSerde("ttl", &state.StringToStringMap{}, time.Minute))
+	assert.NoError(t, err)
+
+	factory := blobstore.NewMemoryBlobStorageFactory()
+	writeStates(t, factory, "n1",
+		makeStateBlob(t, "ttl", "old", time.Unix(1000, 0)),
+		makeStateBlob(t, "ttl", "reported", time.Unix(1000, 0)),
+	)
+	clock.SetAndFreezeClock(t, time.Unix(2030, 0))
+	defer clock.UnfreezeClock(t)
+
+	// A state re-reported after the reaper read it isn't deleted
+	store, err := factory.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store.DeleteUnchanged("n1", []blobstore.Blob{
+		makeStateBlob(t, "ttl", "old", time.Unix(1000, 0)),
+		makeStateBlob(t, "ttl", "reported", time.Unix(1000, 0)),
+	}))
+	writeStates(t, factory, "n1", makeStateBlob(t, "ttl", "reported", time.Unix(2000, 0)))
+	assert.NoError(t, store.Commit())
+	assert.Equal(t, []string{"reported"}, listKeys(t, factory, "n1", "ttl"))
+
+	// The re-reported state is reaped once it expires
+	clock.SetAndFreezeClock(t, time.Unix(2400, 0))
+	err = reaper.NewReaper(factory, 5*time.Minute).Reap()
+	assert.NoError(t, err)
+	assert.Empty(t, listKeys(t, factory, "n1", "ttl"))
+}
+
+func makeStateBlob(t *testing.T, stateType string, key string, reportedAt time.Time) blobstore.Blob {
+	value, err := json.Marshal(state.SerializedStateWithMeta{
+		ReporterID:              "hw1",
+		TimeMs:                  uint64(reportedAt.UnixNano()) / uint64(time.Millisecond),
+		SerializedReportedState: []byte(`{"foo":"bar"}`),
+	})
+	assert.NoError(t, err)
+	return blobstore.Blob{Type: stateType, Key: key, Value: value}
+}
+
+func writeStates(t *testing.T, factory blobstore.BlobStorageFactory, networkID string, blobs ...blobstore.Blob) {
+	store, err := factory.StartTransaction(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store.CreateOrUpdate(networkID, blobs))
+	assert.NoError(t, store.Commit())
+}
+
+func listKeys(t *testing.T, factory blobstore.BlobStorageFactory, networkID string, stateType string) []string {
+	store, err := factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	keys, err := store.ListKeys(networkID, stateType)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Commit())
+	return keys
+}
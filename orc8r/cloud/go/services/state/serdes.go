@@ -10,15 +10,232 @@ package state
 
 import (
 	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
 	"magma/orc8r/cloud/go/serde"
+	"magma/orc8r/cloud/go/service/config"
+
+	"github.com/pkg/errors"
 )
 
-const StringMapSerdeType = "string_map"
+const (
+	StringMapSerdeType = "string_map"
+
+	// StateTTLsConfigKey is the key of the per state type TTL overrides in the
+	// state service's config
+	StateTTLsConfigKey = "stateTTLs"
+)
 
 func NewStateSerde(stateType string, modelPtr serde.ValidateableBinaryConvertible) serde.Serde {
 	return serde.NewBinarySerde(SerdeDomain, stateType, modelPtr)
 }
 
+// NewStateSerdeWithTTL returns a state serde for a state type whose states
+// go stale if they aren't re-reported within the given TTL. Stale states are
+// marked as such when they're read but are never deleted.
+// The TTL can be overridden with SetStateTTLs.
+func NewStateSerdeWithTTL(stateType string, modelPtr serde.ValidateableBinaryConvertible, ttl time.Duration) serde.Serde {
+	return &ttlSerde{Serde: NewStateSerde(stateType, modelPtr), ttl: ttl}
+}
+
+// NewExpiringStateSerde returns a state serde for a state type whose states
+// go stale if they aren't re-reported within the given TTL, like
+// NewStateSerdeWithTTL. Unlike those states, stale states of this type are
+// eventually garbage collected by the state service.
+func NewExpiringStateSerde(stateType string, modelPtr serde.ValidateableBinaryConvertible, ttl time.Duration) serde.Serde {
+	return &ttlSerde{Serde: NewStateSerde(stateType, modelPtr), ttl: ttl, expiring: true}
+}
+
+// NewStateSerdeWithReapTTL returns a state serde for a state type whose
+// states go stale if they aren't re-reported within ttl, like
+// NewStateSerdeWithTTL. Stale states stay visible until they haven't been
+// re-reported within reapTTL, after which the state service garbage collects
+// them.
+func NewStateSerdeWithReapTTL(stateType string, modelPtr serde.ValidateableBinaryConvertible, ttl time.Duration, reapTTL time.Duration) serde.Serde {
+	return &ttlSerde{Serde: NewStateSerde(stateType, modelPtr), ttl: ttl, expiring: true, reapTTL: reapTTL}
+}
+
+// TTLSerde is a state serde for a state type with a TTL.
+type TTLSerde interface {
+	serde.Serde
+
+	// GetTTL returns how long states of this serde's type remain fresh after
+	// they were last reported by default.
+	GetTTL() time.Duration
+
+	// IsExpiring returns true if stale states of this serde's type should be
+	// deleted rather than only marked as stale.
+	IsExpiring() bool
+
+	// GetReapTTL returns how long after they were last reported states of
+	// this serde's type are deleted, or 0 if they're deleted once they've
+	// been stale for the state service's grace period.
+	GetReapTTL() time.Duration
+}
+
+type ttlSerde struct {
+	serde.Serde
+	ttl      time.Duration
+	expiring bool
+	reapTTL  time.Duration
+}
+
+func (s *ttlSerde) GetTTL() time.Duration {
+	return s.ttl
+}
+
+func (s *ttlSerde) IsExpiring() bool {
+	return s.expiring
+}
+
+func (s *ttlSerde) GetReapTTL() time.Duration {
+	return s.reapTTL
+}
+
+var (
+	ttlOverridesMu sync.RWMutex
+	ttlOverrides   = map[string]time.Duration{}
+)
+
+// SetStateTTLs overrides the TTLs of the given state types, replacing any
+// previous overrides. A TTL of 0 disables staleness for the state type.
+// Overrides also apply to state types registered without a TTL, but states
+// of those types are never garbage collected.
+func SetStateTTLs(ttls map[string]time.Duration) {
+	overrides := make(map[string]time.Duration, len(ttls))
+	for stateType, ttl := range ttls {
+		overrides[stateType] = ttl
+	}
+	ttlOverridesMu.Lock()
+	defer ttlOverridesMu.Unlock()
+	ttlOverrides = overrides
+}
+
+// GetStateTTLsFromConfig parses per state type TTL overrides from the
+// stateTTLs map of the state service's config, e.g.
+//
+//	stateTTLs:
+//	  gw_status: 2h
+//
+// A missing config or key yields no overrides.
+func GetStateTTLsFromConfig(cfg *config.ConfigMap) (map[string]time.Duration, error) {
+	ret := map[string]time.Duration{}
+	if cfg == nil {
+		return ret, nil
+	}
+	rawTTLs, ok := cfg.RawMap[StateTTLsConfigKey]
+	if !ok || rawTTLs == nil {
+		return ret, nil
+	}
+	ttlMap, ok := rawTTLs.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map of state type to duration", StateTTLsConfigKey)
+	}
+	for rawType, rawTTL := range ttlMap {
+		stateType, ok := rawType.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid state type %v in %s", rawType, StateTTLsConfigKey)
+		}
+		ttlStr, ok := rawTTL.(string)
+		if !ok {
+			return nil, fmt.Errorf("TTL of state type %s must be a duration string", stateType)
+		}
+		ttl, err := time.ParseDuration(ttlStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid TTL for state type %s", stateType)
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("TTL of state type %s must not be negative", stateType)
+		}
+		ret[stateType] = ttl
+	}
+	return ret, nil
+}
+
+// GetStateTTL returns the TTL of the state type, or 0 if states of the type
+// never go stale. Overrides set with SetStateTTLs take precedence over the
+// TTL the state type was registered with.
+func GetStateTTL(stateType string) time.Duration {
+	ttlOverridesMu.RLock()
+	ttl, ok := ttlOverrides[stateType]
+	ttlOverridesMu.RUnlock()
+	if ok {
+		return ttl
+	}
+
+	s, err := serde.GetSerde(SerdeDomain, stateType)
+	if err != nil {
+		return 0
+	}
+	ttlS, ok := s.(TTLSerde)
+	if !ok {
+		return 0
+	}
+	return ttlS.GetTTL()
+}
+
+// GetStateTTLs returns the TTLs of all registered state types which have one,
+// keyed by state type.
+func GetStateTTLs() map[string]time.Duration {
+	ret := map[string]time.Duration{}
+	for _, s := range serde.GetSerdesForDomain(SerdeDomain) {
+		if ttl := GetStateTTL(s.GetType()); ttl > 0 {
+			ret[s.GetType()] = ttl
+		}
+	}
+	return ret
+}
+
+// GetReapTTLs returns how long after they were last reported states of each
+// garbage collected state type are deleted, keyed by state type. States of
+// types without a reap TTL are deleted once they've been stale for the grace
+// period. States are never deleted before they've been stale for the grace
+// period.
+func GetReapTTLs(gracePeriod time.Duration) map[string]time.Duration {
+	ret := map[string]time.Duration{}
+	for _, s := range serde.GetSerdesForDomain(SerdeDomain) {
+		ttlS, ok := s.(TTLSerde)
+		if !ok || !ttlS.IsExpiring() {
+			continue
+		}
+		ttl := GetStateTTL(s.GetType())
+		if ttl == 0 {
+			continue
+		}
+		reapTTL := ttl + gracePeriod
+		if ttlS.GetReapTTL() > reapTTL {
+			reapTTL = ttlS.GetReapTTL()
+		}
+		ret[s.GetType()] = reapTTL
+	}
+	return ret
+}
+
+// IsStale returns true if the serialized state was last reported longer than
+// its type's TTL before now. States of types without a TTL are never stale.
+func IsStale(stateType string, serializedStateWithMeta []byte, now time.Time) (bool, error) {
+	ttl := GetStateTTL(stateType)
+	if ttl == 0 {
+		return false, nil
+	}
+	return IsReportedBefore(serializedStateWithMeta, now.Add(-ttl))
+}
+
+// IsReportedBefore returns true if the serialized state was last reported
+// before the cutoff.
+func IsReportedBefore(serializedStateWithMeta []byte, cutoff time.Time) (bool, error) {
+	serialized := &SerializedStateWithMeta{}
+	err := json.Unmarshal(serializedStateWithMeta, serialized)
+	if err != nil {
+		return false, err
+	}
+	// Report times have millisecond precision
+	cutoffMs := cutoff.UnixNano() / int64(time.Millisecond)
+	return int64(serialized.TimeMs) < cutoffMs, nil
+}
+
 // A generic map that holds key value pair both of type string. This is used on
 // the gateway side in checkin_cli.py to simply test the connection between the
 // cloud and the gateway.
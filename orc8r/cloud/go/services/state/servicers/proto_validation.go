@@ -24,6 +24,18 @@ func ValidateGetStatesRequest(req *protos.GetStatesRequest) error {
 	return nil
 }
 
+// ValidateSearchStatesRequest checks that all required fields exist and that
+// the report time window is well-formed
+func ValidateSearchStatesRequest(req *protos.SearchStatesRequest) error {
+	if len(req.GetNetworkID()) == 0 {
+		return errors.New("Network ID must be specified")
+	}
+	if req.GetReportedAfterMs() != 0 && req.GetReportedBeforeMs() != 0 && req.GetReportedAfterMs() > req.GetReportedBeforeMs() {
+		return errors.New("Report time window start must not be after its end")
+	}
+	return nil
+}
+
 // ValidateDeleteStatesRequest checks that all required fields exist
 func ValidateDeleteStatesRequest(req *protos.DeleteStatesRequest) error {
 	if err := checkNonEmptyInput(req.GetNetworkID(), req.GetIds()); err != nil {
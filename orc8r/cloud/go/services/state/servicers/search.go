@@ -0,0 +1,125 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/protos"
+	stateService "magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSearchPageSize = 100
+	maxSearchPageSize     = 1000
+)
+
+// searchStates pages through the blobs of the network matching the
+// blobstore-level filters, applying the filters on state metadata to each
+// page, until a full page of matching states has been collected or the blobs
+// have been exhausted. It returns the matching blobs and the token for the
+// next page, which is empty if there are no more blobs to search.
+func searchStates(store blobstore.TransactionalBlobStorage, req *protos.SearchStatesRequest) ([]blobstore.Blob, string, error) {
+	pageSize := uint64(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultSearchPageSize
+	} else if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+	after, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, "", err
+	}
+
+	ret := []blobstore.Blob{}
+	for {
+		blobs, err := store.Search(req.GetNetworkID(), blobstore.SearchCriteria{
+			Types:     req.GetTypes(),
+			KeyPrefix: req.GetKeyPrefix(),
+			After:     after,
+			Limit:     pageSize,
+		})
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to search states")
+		}
+
+		for _, blob := range blobs {
+			isMatch, err := matchesStateMeta(blob, req)
+			if err != nil {
+				return nil, "", err
+			}
+			if isMatch {
+				ret = append(ret, blob)
+			}
+			if uint64(len(ret)) == pageSize {
+				nextPageToken, err := encodePageToken(blob)
+				return ret, nextPageToken, err
+			}
+		}
+		if uint64(len(blobs)) < pageSize {
+			return ret, "", nil
+		}
+		lastBlob := blobs[len(blobs)-1]
+		after = &storage.TypeAndKey{Type: lastBlob.Type, Key: lastBlob.Key}
+	}
+}
+
+func matchesStateMeta(blob blobstore.Blob, req *protos.SearchStatesRequest) (bool, error) {
+	if req.GetReporterHardwareID() == "" && req.GetReportedAfterMs() == 0 && req.GetReportedBeforeMs() == 0 {
+		return true, nil
+	}
+
+	serialized := &stateService.SerializedStateWithMeta{}
+	err := json.Unmarshal(blob.Value, serialized)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal state (%s, %s)", blob.Type, blob.Key)
+	}
+	if req.GetReporterHardwareID() != "" && serialized.ReporterID != req.GetReporterHardwareID() {
+		return false, nil
+	}
+	if req.GetReportedAfterMs() != 0 && serialized.TimeMs < req.GetReportedAfterMs() {
+		return false, nil
+	}
+	if req.GetReportedBeforeMs() != 0 && serialized.TimeMs > req.GetReportedBeforeMs() {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Page tokens are the base64-encoded ID of the last state on the page
+
+func encodePageToken(lastBlob blobstore.Blob) (string, error) {
+	marshaled, err := proto.Marshal(&protos.StateID{Type: lastBlob.Type, DeviceID: lastBlob.Key})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode page token")
+	}
+	return base64.RawURLEncoding.EncodeToString(marshaled), nil
+}
+
+func decodePageToken(token string) (*storage.TypeAndKey, error) {
+	if token == "" {
+		return nil, nil
+	}
+	marshaled, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid page token")
+	}
+	id := &protos.StateID{}
+	err = proto.Unmarshal(marshaled, id)
+	if err != nil {
+		return nil, errors.New("invalid page token")
+	}
+	return &storage.TypeAndKey{Type: id.Type, Key: id.DeviceID}, nil
+}
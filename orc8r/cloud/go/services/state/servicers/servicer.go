@@ -17,6 +17,7 @@ import (
 	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/protos"
 	stateService "magma/orc8r/cloud/go/services/state"
+	"magma/orc8r/cloud/go/storage"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -52,7 +53,41 @@ func (srv *stateServicer) GetStates(context context.Context, req *protos.GetStat
 		store.Rollback()
 		return nil, err
 	}
-	return &protos.GetStatesResponse{States: protos.BlobsToStates(states)}, store.Commit()
+	staleIDs, err := getStaleStateIDs(states)
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+	return &protos.GetStatesResponse{States: protos.BlobsToStates(states), StaleIds: staleIDs}, store.Commit()
+}
+
+// SearchStates retrieves a page of the states matching the request's filters
+// from blobstorage
+func (srv *stateServicer) SearchStates(context context.Context, req *protos.SearchStatesRequest) (*protos.SearchStatesResponse, error) {
+	if err := ValidateSearchStatesRequest(req); err != nil {
+		return nil, err
+	}
+
+	store, err := srv.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	states, nextPageToken, err := searchStates(store, req)
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+	staleIDs, err := getStaleStateIDs(states)
+	if err != nil {
+		store.Rollback()
+		return nil, err
+	}
+	ret := &protos.SearchStatesResponse{
+		States:        protos.BlobsToStates(states),
+		StaleIds:      staleIDs,
+		NextPageToken: nextPageToken,
+	}
+	return ret, store.Commit()
 }
 
 // ReportStates saves states into blobstorage
@@ -181,6 +216,23 @@ func isStateSynced(deviceIdToStates map[string][]*protos.State, reqIdAndVersion
 	return false, 0
 }
 
+// getStaleStateIDs returns the IDs of the states which haven't been
+// re-reported within their type's TTL.
+func getStaleStateIDs(states []blobstore.Blob) ([]*protos.StateID, error) {
+	now := clock.Now()
+	ret := []*protos.StateID{}
+	for _, st := range states {
+		isStale, err := stateService.IsStale(st.Type, st.Value, now)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to determine staleness of state (%s, %s): %s", st.Type, st.Key, err)
+		}
+		if isStale {
+			ret = append(ret, &protos.StateID{Type: st.Type, DeviceID: st.Key})
+		}
+	}
+	return ret, nil
+}
+
 func wrapStateWithAdditionalInfo(state *protos.State, hwID string, time uint64, certExpiry int64) ([]byte, error) {
 	wrap := stateService.SerializedStateWithMeta{
 		ReporterID:              hwID,
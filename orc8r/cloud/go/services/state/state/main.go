@@ -18,20 +18,34 @@ import (
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/services/state"
 	"magma/orc8r/cloud/go/services/state/metrics"
+	"magma/orc8r/cloud/go/services/state/reaper"
 	"magma/orc8r/cloud/go/services/state/servicers"
 	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/glog"
 )
 
-// how often to report gateway status
-const gatewayStatusReportInterval = time.Second * 60
+const (
+	// how often to report gateway status
+	gatewayStatusReportInterval = time.Second * 60
+
+	// how often to garbage collect expired states
+	stateReapInterval = time.Minute * 5
+	// how long stale states are kept around before being garbage collected
+	stateReapGracePeriod = time.Hour
+)
 
 func main() {
 	srv, err := service.NewOrchestratorService(orc8r.ModuleName, state.ServiceName)
 	if err != nil {
 		glog.Fatalf("Error creating state service %s", err)
 	}
+	ttls, err := state.GetStateTTLsFromConfig(srv.Config)
+	if err != nil {
+		glog.Fatalf("Error parsing state TTLs: %s", err)
+	}
+	state.SetStateTTLs(ttls)
+
 	db, err := sqorc.Open(datastore.SQL_DRIVER, datastore.DATABASE_SOURCE)
 	if err != nil {
 		glog.Fatalf("Failed to connect to database: %s", err)
@@ -51,6 +65,9 @@ func main() {
 	// periodically go through all existing gateways and log metrics
 	go metrics.PeriodicallyReportGatewayStatus(gatewayStatusReportInterval)
 
+	// periodically garbage collect expiring states which have outlived their TTL
+	go reaper.NewReaper(store, stateReapGracePeriod).Run(stateReapInterval)
+
 	err = srv.Run()
 	if err != nil {
 		glog.Fatalf("Error running service: %s", err)
@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package providers
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -28,6 +29,40 @@ type StreamProvider interface {
 	GetUpdates(gatewayId string, extraArgs *any.Any) ([]*protos.DataUpdate, error)
 }
 
+// ErrVersionTooOld is returned by DeltaStreamProvider.GetUpdatesSince when
+// a delta can't be computed from the requested version. The streamer will
+// fall back to a full sync.
+var ErrVersionTooOld = errors.New("version is too old to compute a delta from")
+
+// DeltaStreamProvider is an optional extension to StreamProvider for streams
+// which can send only the keys which changed since a version the gateway has
+// already applied. Versions are opaque to gateways but must increase
+// monotonically.
+type DeltaStreamProvider interface {
+	StreamProvider
+
+	// GetVersion returns the current version of the stream for a gateway.
+	// The snapshot returned by a subsequent call to GetUpdates must reflect
+	// at least this version.
+	GetVersion(gatewayId string, extraArgs *any.Any) (uint64, error)
+
+	// GetUpdatesSince returns the keys which were added, modified, or
+	// deleted since fromVersion, along with the version the delta brings
+	// the gateway to. If a delta can't be computed from fromVersion,
+	// ErrVersionTooOld should be returned.
+	GetUpdatesSince(gatewayId string, fromVersion uint64, extraArgs *any.Any) (*Delta, error)
+}
+
+// Delta is a set of changes to a stream between two versions
+type Delta struct {
+	// Version is the version of the stream after the delta is applied
+	Version uint64
+	// Updates contains the keys which were added or modified
+	Updates []*protos.DataUpdate
+	// DeletedKeys contains the keys which were deleted
+	DeletedKeys []string
+}
+
 type providerRegistry struct {
 	sync.RWMutex
 	providersByStream map[string]StreamProvider
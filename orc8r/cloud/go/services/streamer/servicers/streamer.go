@@ -16,10 +16,10 @@ the updates would be pushed through that channel.
 package servicers
 
 import (
-	"magma/orc8r/cloud/go/identity"
 	"magma/orc8r/cloud/go/protos"
 	"magma/orc8r/cloud/go/services/streamer/providers"
 
+	"github.com/golang/glog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -34,6 +34,10 @@ func GetUpdatesUnverified(
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "Stream %s does not exist", request.GetStreamName())
 	}
+	if deltaProvider, ok := streamProvider.(providers.DeltaStreamProvider); ok {
+		return getDeltaUpdates(deltaProvider, request, stream)
+	}
+
 	updates, err := streamProvider.GetUpdates(request.GetGatewayId(), request.ExtraArgs)
 	if err != nil {
 		return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
@@ -45,6 +49,44 @@ func GetUpdatesUnverified(
 	return nil
 }
 
+// getDeltaUpdates sends the changes since the gateway's last applied version
+// if the provider can compute them, and falls back to a full sync otherwise.
+func getDeltaUpdates(
+	provider providers.DeltaStreamProvider,
+	request *protos.StreamRequest,
+	stream protos.Streamer_GetUpdatesServer,
+) error {
+	if request.GetLastVersion() != 0 {
+		delta, err := provider.GetUpdatesSince(request.GetGatewayId(), request.GetLastVersion(), request.ExtraArgs)
+		switch {
+		case err == nil:
+			return stream.Send(&protos.DataUpdateBatch{
+				Updates:     delta.Updates,
+				DeletedKeys: delta.DeletedKeys,
+				Version:     delta.Version,
+			})
+		case err != providers.ErrVersionTooOld:
+			return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
+		}
+		glog.V(2).Infof(
+			"Falling back to full sync of stream %s for gateway %s from version %d",
+			request.GetStreamName(), request.GetGatewayId(), request.GetLastVersion(),
+		)
+	}
+
+	// Load the version before the snapshot so that changes which race with
+	// the snapshot are sent again in the next delta
+	version, err := provider.GetVersion(request.GetGatewayId(), request.ExtraArgs)
+	if err != nil {
+		return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
+	}
+	updates, err := provider.GetUpdates(request.GetGatewayId(), request.ExtraArgs)
+	if err != nil {
+		return status.Errorf(codes.Aborted, "Error while streaming updates: %s", err)
+	}
+	return stream.Send(&protos.DataUpdateBatch{Updates: updates, Resync: true, Version: version})
+}
+
 func (srv *StreamingServer) GetUpdates(
 	request *protos.StreamRequest,
 	stream protos.Streamer_GetUpdatesServer,
@@ -52,10 +94,11 @@ func (srv *StreamingServer) GetUpdates(
 	if request == nil {
 		return status.Error(codes.InvalidArgument, "nil request")
 	}
-	// Check if we can get a valid Gateway identity
-	gwIdentity, err := identity.GetStreamGatewayId(stream)
-	if err != nil {
-		return err
+	// The Gateway identity is verified & injected into the stream's context
+	// by the stream identity decorator middleware
+	gwIdentity := protos.GetClientGateway(stream.Context())
+	if gwIdentity == nil {
+		return status.Error(codes.PermissionDenied, "Missing Gateway Identity")
 	}
 	if gwIdentity.HardwareId == "" {
 		return status.Errorf(codes.FailedPrecondition, "Gateway ID is empty")
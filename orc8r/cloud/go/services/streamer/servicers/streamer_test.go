@@ -84,3 +84,79 @@ func TestStreamingServer_GetUpdates(t *testing.T) {
 	_, err = streamerClient.Recv()
 	assert.Error(t, err, "Stream stream_dne does not exist", codes.Unavailable)
 }
+
+type mockDeltaStreamProvider struct {
+	mockStreamProvider
+	version  uint64
+	delta    *providers.Delta
+	deltaErr error
+}
+
+func (m *mockDeltaStreamProvider) GetVersion(gatewayId string, extraArgs *any.Any) (uint64, error) {
+	return m.version, nil
+}
+
+func (m *mockDeltaStreamProvider) GetUpdatesSince(gatewayId string, fromVersion uint64, extraArgs *any.Any) (*providers.Delta, error) {
+	return m.delta, m.deltaErr
+}
+
+func TestStreamingServer_GetUpdates_Delta(t *testing.T) {
+	streamer_test_init.StartTestService(t)
+	conn, err := registry.GetConnection(streamer.ServiceName)
+	assert.NoError(t, err)
+	grpcClient := protos.NewStreamerClient(conn)
+
+	snapshot := []*protos.DataUpdate{
+		{Key: "a", Value: []byte("123")},
+		{Key: "b", Value: []byte("456")},
+	}
+	delta := &providers.Delta{
+		Version:     42,
+		Updates:     []*protos.DataUpdate{{Key: "a", Value: []byte("789")}},
+		DeletedKeys: []string{"b"},
+	}
+	provider := &mockDeltaStreamProvider{
+		mockStreamProvider: mockStreamProvider{name: "delta1", retVal: snapshot},
+		version:            40,
+		delta:              delta,
+	}
+	err = providers.RegisterStreamProvider(provider)
+	assert.NoError(t, err)
+
+	getBatch := func(lastVersion uint64) (*protos.DataUpdateBatch, error) {
+		streamerClient, err := grpcClient.GetUpdates(
+			context.Background(),
+			&protos.StreamRequest{GatewayId: "hwId", StreamName: "delta1", LastVersion: lastVersion},
+		)
+		assert.NoError(t, err)
+		return streamerClient.Recv()
+	}
+
+	// No version: full sync stamped with the current version
+	actual, err := getBatch(0)
+	assert.NoError(t, err)
+	assert.True(t, actual.Resync)
+	assert.Equal(t, uint64(40), actual.Version)
+	assert.Equal(t, protos.TestMarshal(&protos.DataUpdateBatch{Updates: snapshot, Resync: true, Version: 40}), protos.TestMarshal(actual))
+
+	// Delta from a known version
+	actual, err = getBatch(40)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		protos.TestMarshal(&protos.DataUpdateBatch{Updates: delta.Updates, DeletedKeys: delta.DeletedKeys, Version: 42}),
+		protos.TestMarshal(actual),
+	)
+
+	// Version too old: fall back to full sync
+	provider.deltaErr = providers.ErrVersionTooOld
+	actual, err = getBatch(1)
+	assert.NoError(t, err)
+	assert.Equal(t, protos.TestMarshal(&protos.DataUpdateBatch{Updates: snapshot, Resync: true, Version: 40}), protos.TestMarshal(actual))
+
+	// Other errors are surfaced
+	provider.deltaErr = errors.New("MOCK")
+	_, err = getBatch(40)
+	assert.Error(t, err)
+	assert.Equal(t, "rpc error: code = Aborted desc = Error while streaming updates: MOCK", err.Error())
+}
@@ -14,6 +14,7 @@ import (
 	"sort"
 
 	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/access"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/pluginimpl/models"
 	"magma/orc8r/cloud/go/services/configurator"
@@ -77,7 +78,7 @@ func createReleaseChannel(c echo.Context) error {
 		Name:   string(channel.Name),
 		Config: channel,
 	}
-	_, err := configurator.CreateInternalEntity(entity)
+	_, err := access.ConfigWriter(c).CreateInternalEntity(entity)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -107,7 +108,7 @@ func updateReleaseChannel(c echo.Context) error {
 		Type:      orc8r.UpgradeReleaseChannelEntityType,
 		NewConfig: channel,
 	}
-	_, err := configurator.UpdateInternalEntity(update)
+	_, err := access.ConfigWriter(c).UpdateInternalEntity(update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -125,7 +126,7 @@ func deleteReleaseChannel(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
-	err = configurator.DeleteInternalEntity(orc8r.UpgradeReleaseChannelEntityType, channelID)
+	err = access.ConfigWriter(c).DeleteInternalEntity(orc8r.UpgradeReleaseChannelEntityType, channelID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -185,7 +186,7 @@ func createrTier(c echo.Context) error {
 		Name:   string(tier.Name),
 		Config: tier,
 	}
-	_, err := configurator.CreateEntity(networkID, entity)
+	_, err := access.ConfigWriter(c).CreateEntity(networkID, entity)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -230,7 +231,7 @@ func updateTier(c echo.Context) error {
 		NewName:   swag.String(string(tier.Name)),
 		NewConfig: tier,
 	}
-	_, err := configurator.UpdateEntity(networkID, update)
+	_, err := access.ConfigWriter(c).UpdateEntity(networkID, update)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -252,7 +253,7 @@ func deleteTier(c echo.Context) error {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
 
-	err = configurator.DeleteEntity(networkID, orc8r.UpgradeTierEntityType, tierID)
+	err = access.ConfigWriter(c).DeleteEntity(networkID, orc8r.UpgradeTierEntityType, tierID)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
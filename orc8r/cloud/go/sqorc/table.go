@@ -36,8 +36,9 @@ column type to name inside the data structure for each builder.
 */
 
 var postgresColumnTypeMap = map[ColumnType]string{
-	ColumnTypeText: "TEXT",
-	ColumnTypeInt:  "INTEGER",
+	ColumnTypeText:   "TEXT",
+	ColumnTypeInt:    "INTEGER",
+	ColumnTypeBigInt: "BIGINT",
 	// BYTEA is effectively limited to 1GB
 	ColumnTypeBytes: "BYTEA",
 	ColumnTypeBool:  "BOOLEAN",
@@ -45,8 +46,9 @@ var postgresColumnTypeMap = map[ColumnType]string{
 
 var mariaColumnTypeMap = map[ColumnType]string{
 	// Mysql won't index TEXT columns, so choose VARCHAR(255) for text type
-	ColumnTypeText: "VARCHAR(255)",
-	ColumnTypeInt:  "INT",
+	ColumnTypeText:   "VARCHAR(255)",
+	ColumnTypeInt:    "INT",
+	ColumnTypeBigInt: "BIGINT",
 	// LONGBLOB stores up to 4GB and the cost is a flat extra 2 bytes of
 	// storage over BLOB, which is limited to 64KB
 	ColumnTypeBytes: "LONGBLOB",
@@ -71,6 +73,8 @@ const (
 	ColumnTypeInt
 	ColumnTypeBytes
 	ColumnTypeBool
+	// ColumnTypeBigInt is a 64-bit integer, e.g. for unix nano timestamps
+	ColumnTypeBigInt
 	// Fill in other types as needed
 )
 
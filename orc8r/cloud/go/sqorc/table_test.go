@@ -51,6 +51,15 @@ func TestColumnBuilder_ToSql(t *testing.T) {
 	expected = "version INTEGER NOT NULL DEFAULT 0"
 	assert.Equal(t, expected, actual)
 
+	actual, err = columnBuilder(postgresColumnTypeMap).
+		Name("ts").
+		Type(ColumnTypeBigInt).
+		NotNull().
+		ToSql()
+	assert.NoError(t, err)
+	expected = "ts BIGINT NOT NULL"
+	assert.Equal(t, expected, actual)
+
 	// maria
 	actual, err = columnBuilder(mariaColumnTypeMap).
 		Name("pk").
@@ -82,6 +91,15 @@ func TestColumnBuilder_ToSql(t *testing.T) {
 	assert.NoError(t, err)
 	expected = "version INT NOT NULL DEFAULT 0"
 	assert.Equal(t, expected, actual)
+
+	actual, err = columnBuilder(mariaColumnTypeMap).
+		Name("ts").
+		Type(ColumnTypeBigInt).
+		NotNull().
+		ToSql()
+	assert.NoError(t, err)
+	expected = "ts BIGINT NOT NULL"
+	assert.Equal(t, expected, actual)
 }
 
 func TestColumnBuilder_ToSql_Errors(t *testing.T) {
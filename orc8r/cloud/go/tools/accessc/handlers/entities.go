@@ -84,3 +84,66 @@ func (ents *Entities) Set(value string) error {
 	*ents = append(*ents, Entity{id, int32(perm)})
 	return nil
 }
+
+// Rules - list of role rules compiled from command line flags
+type Rules []*accessprotos.Role_Rule
+
+// String - stringer for rules
+func (rules *Rules) String() string {
+	if rules == nil {
+		return "<nil>"
+	}
+	res := make([]string, 0, len(*rules))
+	for _, rule := range *rules {
+		res = append(res, formatRule(rule))
+	}
+	return strings.Join(res, "; ")
+}
+
+// Set adds a new Rule from provided flag value string in the form:
+// <entity types|*>:<path patterns|*>:R|W|RW
+// Path patterns may include ':' (route parameters), so entity types end at
+// the first and permissions start after the last ':'
+func (rules *Rules) Set(value string) error {
+	firstIdx, lastIdx := strings.Index(value, ":"), strings.LastIndex(value, ":")
+	if firstIdx <= 0 || firstIdx == lastIdx {
+		return fmt.Errorf(
+			"Invalid Rule Specification for '%s', expected <entity types|*>:<path patterns|*>:R|W|RW",
+			value)
+	}
+	// reuse Entity permissions parsing
+	var ents Entities
+	if err := ents.Set(value[firstIdx+1:]); err != nil {
+		return err
+	}
+	*rules = append(*rules, &accessprotos.Role_Rule{
+		EntityTypes:  splitRuleList(value[:firstIdx]),
+		PathPatterns: splitRuleList(value[firstIdx+1 : lastIdx]),
+		Permissions:  accessprotos.AccessControl_Permission(ents[0].perm),
+	})
+	return nil
+}
+
+// splitRuleList splits comma separated list of entity types or path patterns,
+// '*' or an empty list matches everything
+func splitRuleList(value string) []string {
+	var res []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) > 0 && item != "*" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func formatRule(rule *accessprotos.Role_Rule) string {
+	entityTypes, pathPatterns := "*", "*"
+	if len(rule.EntityTypes) > 0 {
+		entityTypes = strings.Join(rule.EntityTypes, ",")
+	}
+	if len(rule.PathPatterns) > 0 {
+		pathPatterns = strings.Join(rule.PathPatterns, ",")
+	}
+	return fmt.Sprintf("%s:%s:%s", entityTypes, pathPatterns, rule.Permissions.ToString())
+}
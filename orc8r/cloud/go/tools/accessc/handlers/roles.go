@@ -0,0 +1,211 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package handlers implements individual accessc commands as well as common
+// across multiple commands functionality
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"magma/orc8r/cloud/go/identity"
+	"magma/orc8r/cloud/go/protos"
+	"magma/orc8r/cloud/go/services/accessd"
+	accessprotos "magma/orc8r/cloud/go/services/accessd/protos"
+	"magma/orc8r/cloud/go/tools/commands"
+)
+
+var (
+	roleRules     Rules  // flag for role-set command
+	roleNetworkID string // flag for role-bind & role-unbind commands
+)
+
+// Role commands - manage roles & operators' role bindings
+func init() {
+	cmd := CommandRegistry.Add(
+		"role-list",
+		"List all Roles and their rules",
+		roleList)
+	cmd.Flags().Usage = func() {
+		fmt.Fprintf(os.Stderr, "\tUsage: %s %s\n", os.Args[0], cmd.Name())
+	}
+
+	cmd = CommandRegistry.Add(
+		"role-set",
+		"Create a new Role or overwrite an existing Role's rules",
+		roleSet)
+	f := cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s %s [OPTIONS] <Role Name>\n",
+			os.Args[0], cmd.Name())
+		f.PrintDefaults()
+	}
+	f.Var(&roleRules, "r",
+		"Role rule in the form: <entity types|*>:<REST path patterns|*>:R|W|RW, "+
+			"types & patterns are comma separated, e.g. "+
+			"'subscribers:/magma/v1/lte/:network_id/subscribers*:RW'")
+
+	cmd = CommandRegistry.Add(
+		"role-delete",
+		"Delete given Role",
+		roleDelete)
+	cmd.Flags().Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s <Role Name>\n", os.Args[0], cmd.Name())
+	}
+
+	for _, bindCmd := range []struct {
+		name, help string
+		handler    commands.Handler
+	}{
+		{"role-bind", "Bind a Role to given Operator", roleBind},
+		{"role-unbind", "Remove given Operator's Role binding", roleUnbind},
+	} {
+		cmd := CommandRegistry.Add(bindCmd.name, bindCmd.help, bindCmd.handler)
+		f := cmd.Flags()
+		f.Usage = func() {
+			fmt.Fprintf(os.Stderr,
+				"\tUsage: %s %s [OPTIONS] <Operator ID> <Role Name>\n",
+				os.Args[0], cmd.Name())
+			f.PrintDefaults()
+		}
+		f.StringVar(&roleNetworkID, "n", "",
+			"Network Id the binding is scoped to. Default: all networks")
+	}
+
+	cmd = CommandRegistry.Add(
+		"role-bindings",
+		"List given Operator's Role bindings",
+		roleBindings)
+	cmd.Flags().Usage = func() {
+		fmt.Fprintf(os.Stderr,
+			"\tUsage: %s %s <Operator ID>\n", os.Args[0], cmd.Name())
+	}
+}
+
+func roleList(cmd *commands.Command, args []string) int {
+	roles, err := accessd.ListRoles()
+	if err != nil {
+		log.Fatalf("List Roles Error: %s", err)
+	}
+	fmt.Println("Roles:")
+	for _, role := range roles {
+		PrintRole(role)
+	}
+	return 0
+}
+
+func roleSet(cmd *commands.Command, args []string) int {
+	f := cmd.Flags()
+	name := strings.TrimSpace(f.Arg(0))
+	if f.NArg() != 1 || len(name) == 0 {
+		f.Usage()
+		log.Fatalf("A single Role name must be specified.")
+	}
+	if len(roleRules) == 0 {
+		f.Usage()
+		log.Fatalf("At least one Role rule must be specified.")
+	}
+	role := &accessprotos.Role{Name: name, Rules: roleRules}
+	err := accessd.SetRole(role)
+	if err != nil {
+		log.Fatalf("Set Role %s Error: %s", name, err)
+	}
+	fmt.Print("Role Set:\n")
+	PrintRole(role)
+	return 0
+}
+
+func roleDelete(cmd *commands.Command, args []string) int {
+	f := cmd.Flags()
+	name := strings.TrimSpace(f.Arg(0))
+	if f.NArg() != 1 || len(name) == 0 {
+		f.Usage()
+		log.Fatalf("A single Role name must be specified.")
+	}
+	err := accessd.DeleteRole(name)
+	if err != nil {
+		log.Fatalf("Delete Role %s Error: %s", name, err)
+	}
+	return 0
+}
+
+func roleBind(cmd *commands.Command, args []string) int {
+	operator, role := getOperatorAndRole(cmd)
+	err := accessd.AddRoleBinding(operator, role, roleNetworkID)
+	if err != nil {
+		log.Fatalf("Bind Role Error: %s", err)
+	}
+	printRoleBindings(operator)
+	return 0
+}
+
+func roleUnbind(cmd *commands.Command, args []string) int {
+	operator, role := getOperatorAndRole(cmd)
+	err := accessd.RemoveRoleBinding(operator, role, roleNetworkID)
+	if err != nil {
+		log.Fatalf("Unbind Role Error: %s", err)
+	}
+	printRoleBindings(operator)
+	return 0
+}
+
+func roleBindings(cmd *commands.Command, args []string) int {
+	f := cmd.Flags()
+	oid := strings.TrimSpace(f.Arg(0))
+	if f.NArg() != 1 || len(oid) == 0 {
+		f.Usage()
+		log.Fatalf("A single Operator Id must be specified.")
+	}
+	printRoleBindings(identity.NewOperator(oid))
+	return 0
+}
+
+func getOperatorAndRole(cmd *commands.Command) (*protos.Identity, string) {
+	f := cmd.Flags()
+	oid := strings.TrimSpace(f.Arg(0))
+	role := strings.TrimSpace(f.Arg(1))
+	if f.NArg() != 2 || len(oid) == 0 || len(role) == 0 {
+		f.Usage()
+		log.Fatalf("An Operator Id and a Role name must be specified.")
+	}
+	return identity.NewOperator(oid), role
+}
+
+func printRoleBindings(operator *protos.Identity) {
+	bindings, err := accessd.GetRoleBindings(operator)
+	if err != nil {
+		log.Fatalf("Get Role Bindings Error: %s", err)
+	}
+	fmt.Printf("\t%s Role Bindings:\n", operator.HashString())
+	for _, b := range bindings {
+		networkID := b.NetworkId
+		if len(networkID) == 0 {
+			networkID = "*"
+		}
+		fmt.Printf("\t\t  %s: %s\n", networkID, b.Role)
+	}
+	fmt.Println()
+}
+
+// PrintRole - prints role name & its rules
+func PrintRole(role *accessprotos.Role) {
+	builtin := ""
+	if role.Builtin {
+		builtin = " (built-in)"
+	}
+	fmt.Printf("\t%s%s:\n\t\tRules:\n", role.Name, builtin)
+	for _, rule := range role.Rules {
+		fmt.Printf("\t\t  %s\n", formatRule(rule))
+	}
+	fmt.Println()
+}
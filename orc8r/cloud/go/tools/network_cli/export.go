@@ -0,0 +1,52 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"bufio"
+	"os"
+
+	"magma/orc8r/cloud/go/services/configurator/archive"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+var exportPageSize int
+
+func init() {
+	cmdExport := &cobra.Command{
+		Use:   "export --network=<network-id> --file=<archive-file> [--page-size=<n>]",
+		Short: "export a network to an archive file",
+		Run:   exportCmd,
+	}
+	cmdExport.Flags().StringVar(&networkId, "network", "", "the network id")
+	cmdExport.MarkFlagRequired("network")
+	cmdExport.Flags().IntVar(&exportPageSize, "page-size", archive.DefaultPageSize, "the number of entities and devices to load at a time")
+
+	rootCmd.AddCommand(cmdExport)
+}
+
+func exportCmd(cmd *cobra.Command, args []string) {
+	f, err := os.Create(archiveFile)
+	if err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	err = archive.WriteExport(w, networkId, exportPageSize)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"magma/orc8r/cloud/go/services/configurator/archive"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+var dryRun bool
+
+func init() {
+	cmdImport := &cobra.Command{
+		Use:   "import --file=<archive-file> [--network=<network-id>] [--dry-run]",
+		Short: "import a network from an archive file",
+		Long: "Import creates or updates the network in an archive file. " +
+			"Importing the same archive again makes no changes. " +
+			"Anything in the network which isn't in the archive is kept.",
+		Run: importCmd,
+	}
+	cmdImport.Flags().StringVar(&networkId, "network", "", "the network id to import into, defaults to the archived network's id")
+	cmdImport.Flags().BoolVar(&dryRun, "dry-run", false, "print the changes the import would make without making them")
+
+	rootCmd.AddCommand(cmdImport)
+}
+
+func importCmd(cmd *cobra.Command, args []string) {
+	f, err := os.Open(archiveFile)
+	if err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	imported, err := archive.Read(f)
+	if err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
+
+	if networkId == "" {
+		networkId = imported.Network.ID
+	}
+	changes, err := archive.Import(networkId, imported, dryRun)
+	if err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("Network %s is up to date\n", networkId)
+	}
+}
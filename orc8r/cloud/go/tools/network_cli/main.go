@@ -0,0 +1,38 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// network_cli exports networks to portable archives and imports them into
+// another orchestrator.
+package main
+
+import (
+	"os"
+
+	"magma/orc8r/cloud/go/plugin"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "network_cli",
+	Short: "Network cli",
+}
+
+var networkId string
+var archiveFile string
+
+func main() {
+	plugin.LoadAllPluginsFatalOnError(&plugin.DefaultOrchestratorPluginLoader{})
+
+	rootCmd.PersistentFlags().StringVar(&archiveFile, "file", "", "the archive file")
+	rootCmd.MarkPersistentFlagRequired("file")
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(2)
+	}
+}
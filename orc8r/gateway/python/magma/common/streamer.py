@@ -68,6 +68,29 @@ class StreamerClient(threading.Thread):
             """
             raise NotImplementedError()
 
+        def supports_deltas(self) -> bool:
+            """
+            Returns true if the callback applies delta updates, i.e. updates
+            with resync set to false along with deleted keys. The client
+            then sends the version of the last applied batch in its requests
+            and streams which support deltas only send the changes since.
+            Callbacks which don't support deltas always get full snapshots.
+            """
+            return False
+
+        def process_deletes(self, stream_name, deleted_keys):
+            """
+            Called after process_update when the cloud deleted keys of the
+            stream since the last applied batch. Only called for callbacks
+            which support deltas, in the event loop provided to the
+            StreamerClient.
+
+            Args:
+                stream_name (string): Name of the stream
+                deleted_keys (string[]): Keys which were deleted
+            """
+            raise NotImplementedError()
+
     def __init__(self, stream_callbacks, loop):
         """
         Args:
@@ -78,6 +101,9 @@ class StreamerClient(threading.Thread):
         threading.Thread.__init__(self)
         self._stream_callbacks = stream_callbacks
         self._loop = loop
+        # Version of the last batch applied for each stream, only updated
+        # in the event loop
+        self._versions = {}
         # Set this thread as daemon thread. We can kill this background
         # thread abruptly since we handle all updates (and database
         # transactions) in the asyncio event loop.
@@ -124,18 +150,38 @@ class StreamerClient(threading.Thread):
 
     def process_stream_updates(self, client, stream_name, callback):
         extra_args = self._get_extra_args_any(callback, stream_name)
+        last_version = 0
+        if callback.supports_deltas():
+            last_version = self._versions.get(stream_name, 0)
         request = StreamRequest(gatewayId=snowflake.snowflake(),
                                 stream_name=stream_name,
-                                extra_args=extra_args)
+                                extra_args=extra_args,
+                                last_version=last_version)
         for update_batch in client.GetUpdates(
                 request, timeout=self._stream_timeout):
             self._loop.call_soon_threadsafe(
-                callback.process_update,
+                self._apply_update_batch,
                 stream_name,
-                update_batch.updates,
-                update_batch.resync,
+                callback,
+                update_batch,
             )
 
+    def _apply_update_batch(self, stream_name, callback, update_batch):
+        """
+        Applies the batch with the stream's callback, then records the
+        batch's version so that the next request only asks for the changes
+        since. If the callback fails, the version isn't recorded and the
+        changes are streamed again.
+        """
+        callback.process_update(
+            stream_name,
+            update_batch.updates,
+            update_batch.resync,
+        )
+        if update_batch.deleted_keys and callback.supports_deltas():
+            callback.process_deletes(stream_name, update_batch.deleted_keys)
+        self._versions[stream_name] = update_batch.version
+
     @staticmethod
     def _get_extra_args_any(callback, stream_name):
         extra_args = callback.get_request_args(stream_name)
@@ -19,7 +19,7 @@ import snowflake
 from cryptography.exceptions import InternalError
 from cryptography.hazmat.backends import default_backend
 from cryptography.hazmat.primitives import hashes, serialization
-from cryptography.hazmat.primitives.asymmetric import ec
+from cryptography.hazmat.primitives.asymmetric import ec, padding, rsa
 from cryptography.hazmat.primitives.asymmetric.utils import \
     decode_dss_signature
 from google.protobuf.duration_pb2 import Duration
@@ -28,7 +28,8 @@ from magma.common.sdwatchdog import SDWatchdogTask
 from magma.common.service_registry import ServiceRegistry
 from magma.configuration.service_configs import load_service_config
 from magma.magmad.metrics import BOOTSTRAP_EXCEPTION
-from orc8r.protos.bootstrapper_pb2 import ChallengeKey, Response
+from orc8r.protos.bootstrapper_pb2 import ChallengeKey, RenewRequest, \
+    Response
 from orc8r.protos.bootstrapper_pb2_grpc import BootstrapperStub
 from orc8r.protos.certifier_pb2 import CSR
 from orc8r.protos.identity_pb2 import AccessGatewayID, Identity
@@ -55,7 +56,9 @@ class BootstrapManager(SDWatchdogTask):
     verify the device. As a result of the bootstrap process, the
     gateways' session certs would be written to /var/opt/magma/certs.
     Before the session certs expire, bootstrap would make sure we
-    fetch new certs by maintaining a timer internally.
+    fetch new certs by maintaining a timer internally. Certs which are
+    still valid are renewed with the current session key, falling back to a
+    full bootstrap if the renewal fails.
     """
     # delay in asyncio should not exceed one day
     PERIODIC_BOOTSTRAP_CHECK_INTERVAL = datetime.timedelta(hours=1)
@@ -99,7 +102,7 @@ class BootstrapManager(SDWatchdogTask):
         elif self._state == BootstrapState.SCHEDULED_BOOTSTRAP:
             await self._bootstrap_now()
         elif self._state == BootstrapState.SCHEDULED_CHECK:
-            await self._bootstrap_now()
+            await self._bootstrap_check()
         elif self._state == BootstrapState.IDLE:
             pass
 
@@ -128,6 +131,8 @@ class BootstrapManager(SDWatchdogTask):
 
         Check whether cert is present and still valid
         If so, a future _bootstrap_check will be scheduled.
+        If the cert is expiring soon, _renew_now will be called immediately
+        and _bootstrap_now if the renewal fails.
         Otherwise _bootstrap_now will be called immediately
         """
         # flag to ensure the loop is still running, successfully or not
@@ -141,9 +146,9 @@ class BootstrapManager(SDWatchdogTask):
             return
 
         now = datetime.datetime.utcnow()
-        if now + self.PREEXPIRY_BOOTSTRAP_INTERVAL > cert.not_valid_after:
+        if now >= cert.not_valid_after:
             logging.info(
-                'Certificate is expiring soon at %s, start bootstrapping',
+                'Certificate has expired at %s, start bootstrapping',
                 cert.not_valid_after)
             await self._bootstrap_now()
             return
@@ -152,6 +157,14 @@ class BootstrapManager(SDWatchdogTask):
                 'Certificate is not valid until %s', cert.not_valid_before)
             await self._bootstrap_now()
             return
+        if now + self.PREEXPIRY_BOOTSTRAP_INTERVAL > cert.not_valid_after:
+            logging.info(
+                'Certificate is expiring soon at %s, start renewing',
+                cert.not_valid_after)
+            if not await self._renew_now(cert):
+                logging.info('Renewal failed, start bootstrapping')
+                await self._bootstrap_now()
+            return
 
         # no need to restart control_proxy
         await self._bootstrap_success_cb(False)
@@ -187,6 +200,79 @@ class BootstrapManager(SDWatchdogTask):
         except grpc.RpcError as err:
             self._get_challenge_done_fail(err)
 
+    async def _renew_now(self, cert):
+        """Renew a session cert which is still valid
+
+        1. get a challenge (async)
+        2. sign the challenge and a CSR for a new key with the current
+           session key and send them with cert to RenewCertificate (async)
+        3. call _request_sign_done_success to deal with the new cert
+
+        Args:
+            cert: current session cert
+
+        Returns:
+            True if the new cert was received, False if steps 1 or 2 failed
+        """
+        try:
+            current_key = cert_utils.load_key(self._gateway_key_file)
+        except (IOError, ValueError, TypeError) as exp:
+            logging.error('Cannot load the current gateway key: %s', exp)
+            BOOTSTRAP_EXCEPTION.labels(cause='RenewLoadKey').inc()
+            return False
+
+        try:
+            chan = ServiceRegistry.get_bootstrap_rpc_channel()
+        except ValueError as exp:
+            logging.error('Failed to get rpc channel: %s', exp)
+            BOOTSTRAP_EXCEPTION.labels(cause='RenewGetRPC').inc()
+            return False
+
+        client = BootstrapperStub(chan)
+        try:
+            challenge = await grpc_async_wrapper(
+                client.GetChallenge.future(AccessGatewayID(id=self._hw_id)),
+                self._loop
+            )
+        except grpc.RpcError as err:
+            logging.error('GetChallenge error! [%s] %s',
+                          err.code(), err.details())
+            BOOTSTRAP_EXCEPTION.labels(cause='RenewGetChallengeResp').inc()
+            return False
+
+        try:
+            self._gateway_key = ec.generate_private_key(
+                ec.SECP384R1(), default_backend())
+            csr = self._create_csr()
+            request = RenewRequest(
+                cert_der=cert.public_bytes(serialization.Encoding.DER),
+                challenge=challenge.challenge,
+                csr=csr,
+                signature=self._renew_signature(
+                    current_key, challenge.challenge + csr.csr_der),
+            )
+        except Exception as exp:
+            logging.error('Fail to create renew request: %s', exp)
+            BOOTSTRAP_EXCEPTION.labels(
+                cause='RenewCreateRequest:%s' % type(exp).__name__).inc()
+            self._gateway_key = None
+            return False
+
+        try:
+            result = await grpc_async_wrapper(
+                client.RenewCertificate.future(request),
+                self._loop
+            )
+        except grpc.RpcError as err:
+            logging.error('RenewCertificate error! [%s] %s',
+                          err.code(), err.details())
+            BOOTSTRAP_EXCEPTION.labels(cause='RenewCertificateResp').inc()
+            self._gateway_key = None
+            return False
+
+        await self._request_sign_done_success(result)
+        return True
+
     async def _get_challenge_done_success(self, challenge):
         # create key
         try:
@@ -373,6 +459,26 @@ class BootstrapManager(SDWatchdogTask):
 
         return True
 
+    def _renew_signature(self, key, data):
+        """Sign a renew request with the current session key
+
+        Args:
+            key: current session key
+            data: challenge followed by the DER encoded CSR
+
+        Returns:
+            signature in bytes, ASN.1 encoded for ECDSA keys
+
+        Raises:
+            BootstrapError: if the key type is not supported
+        """
+        if isinstance(key, ec.EllipticCurvePrivateKey):
+            return key.sign(data, ec.ECDSA(hashes.SHA256()))
+        if isinstance(key, rsa.RSAPrivateKey):
+            return key.sign(data, padding.PKCS1v15(), hashes.SHA256())
+        raise BootstrapError(
+            'Unsupported gateway key type: %s' % type(key).__name__)
+
     def _ecdsa_sha256_response(self, challenge):
         """Compute the ecdsa signature
 
@@ -44,15 +44,15 @@ def AsyncMock():
     return corofunc
 
 
-def make_awaitable(func):
+def make_awaitable(func, result=None):
     future = asyncio.Future()
-    future.set_result(None)
+    future.set_result(result)
     func.return_value = future
 
 
 class DummpyBootstrapperServer(bootstrapper_pb2_grpc.BootstrapperServicer):
     def __init__(self):
-        pass
+        self.renew_requests = []
 
     def add_to_server(self, server):
         bootstrapper_pb2_grpc.add_BootstrapperServicer_to_server(self, server)
@@ -67,6 +67,10 @@ class DummpyBootstrapperServer(bootstrapper_pb2_grpc.BootstrapperServicer):
     def RequestSign(self, request, context):
         return create_cert_message()
 
+    def RenewCertificate(self, request, context):
+        self.renew_requests.append(request)
+        return create_cert_message()
+
 
 class BootstrapManagerTest(TestCase):
     @patch('magma.common.cert_utils.write_key')
@@ -132,15 +136,18 @@ class BootstrapManagerTest(TestCase):
         self.loop.close()
 
     @patch('magma.common.cert_utils.load_cert')
+    @patch('%s.BootstrapManager._renew_now' % BM)
     @patch('%s.BootstrapManager._bootstrap_now' % BM)
     @patch('%s.BootstrapManager._schedule_next_bootstrap_check' % BM)
     def test__bootstrap_check(self,
                               schedule_bootstrap_check_mock,
                               bootstrap_now_mock,
+                              renew_now_mock,
                               load_cert_mock):
         async def test():
             make_awaitable(self.manager._bootstrap_now)
             make_awaitable(self.manager._bootstrap_success_cb)
+            make_awaitable(self.manager._renew_now, True)
 
             # cannot load cert
             load_cert_mock.side_effect = IOError
@@ -158,12 +165,33 @@ class BootstrapManagerTest(TestCase):
             await self.manager._bootstrap_check()
             bootstrap_now_mock.assert_has_calls([call()])
 
-            # invalid not_after
+            # expired
             load_cert_mock.reset_mock()
+            bootstrap_now_mock.reset_mock()
+            not_after = datetime.datetime.utcnow() - datetime.timedelta(
+                hours=1)
+            not_before = not_after - datetime.timedelta(days=3)
+            load_cert_mock.return_value = create_cert(not_before, not_after)
+            await self.manager._bootstrap_check()
+            bootstrap_now_mock.assert_has_calls([call()])
+            renew_now_mock.assert_not_called()
+
+            # expiring soon, renewed
+            load_cert_mock.reset_mock()
+            bootstrap_now_mock.reset_mock()
             not_before = datetime.datetime.utcnow()
             not_after = not_before + datetime.timedelta(hours=1)
-            load_cert_mock.return_value = create_cert(not_before, not_after)
+            expiring_cert = create_cert(not_before, not_after)
+            load_cert_mock.return_value = expiring_cert
             await self.manager._bootstrap_check()
+            renew_now_mock.assert_has_calls([call(expiring_cert)])
+            bootstrap_now_mock.assert_not_called()
+
+            # expiring soon, renewal failed
+            renew_now_mock.reset_mock()
+            make_awaitable(self.manager._renew_now, False)
+            await self.manager._bootstrap_check()
+            renew_now_mock.assert_has_calls([call(expiring_cert)])
             bootstrap_now_mock.assert_has_calls([call()])
 
             # cert is present and valid,
@@ -207,6 +235,56 @@ class BootstrapManagerTest(TestCase):
         self.manager._periodic_task.cancel()
         self.loop.run_until_complete(test())
 
+    @patch('%s.BootstrapManager._request_sign_done_success' % BM)
+    @patch('%s.ServiceRegistry.get_bootstrap_rpc_channel' % BM)
+    @patch('magma.common.cert_utils.load_key')
+    def test__renew_now(self,
+                        load_key_mock,
+                        bootstrap_channel_mock,
+                        request_sign_done_mock):
+        async def test():
+            current_key = ec.generate_private_key(
+                ec.SECP384R1(), default_backend())
+            load_key_mock.return_value = current_key
+            bootstrap_channel_mock.return_value = self.channel
+            make_awaitable(self.manager._request_sign_done_success)
+            not_before = datetime.datetime.utcnow()
+            cert = create_cert(not_before,
+                               not_before + datetime.timedelta(hours=1))
+
+            # no error
+            renewed = await self.manager._renew_now(cert)
+            self.assertTrue(renewed)
+            request_sign_done_mock.assert_has_calls([call(ANY)])
+            self.assertEqual(len(self._servicer.renew_requests), 1)
+            request = self._servicer.renew_requests[0]
+            self.assertEqual(
+                request.cert_der,
+                cert.public_bytes(serialization.Encoding.DER))
+            self.assertEqual(request.challenge, b'simple_challenge')
+            self.assertEqual(request.csr.id.gateway.hardware_id, self.hw_id)
+            current_key.public_key().verify(
+                request.signature,
+                request.challenge + request.csr.csr_der,
+                ec.ECDSA(hashes.SHA256()))
+
+            # fail to get channel
+            request_sign_done_mock.reset_mock()
+            bootstrap_channel_mock.side_effect = ValueError
+            renewed = await self.manager._renew_now(cert)
+            self.assertFalse(renewed)
+            request_sign_done_mock.assert_not_called()
+
+            # no current key
+            load_key_mock.side_effect = IOError
+            renewed = await self.manager._renew_now(cert)
+            self.assertFalse(renewed)
+            request_sign_done_mock.assert_not_called()
+
+        # Cancel the loop so that there's no periodic bootstrap/bootstrap_check
+        self.manager._periodic_task.cancel()
+        self.loop.run_until_complete(test())
+
     @patch('%s.BootstrapManager._schedule_next_bootstrap' % BM)
     @patch('%s.ServiceRegistry.get_bootstrap_rpc_channel' % BM)
     def test__bootstrap_fail(self,